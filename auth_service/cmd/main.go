@@ -50,6 +50,8 @@ func main() {
 		cfg.JwtSecret,
 		cfg.RefreshExpiration,
 		cfg.AccessExpiration,
+		cfg.AuthService.AccessGrantMaxDuration,
+		cfg.AuthService.ApiKeyMaxLifetime,
 	)
 
 	grpcHandler := transportgrpc.NewGRPCAuthHandler(authService)
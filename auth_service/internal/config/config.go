@@ -14,6 +14,11 @@ type AuthServiceConfig struct {
 	Port int    `yaml:"port" env:"PORT" env-default:"8080"`
 
 	RedisDB int `yaml:"redis_db" env:"REDIS_DB" env-required:"true"`
+
+	// AccessGrantMaxDuration is the longest time an access grant may last.
+	AccessGrantMaxDuration time.Duration `yaml:"access_grant_max_duration" env:"ACCESS_GRANT_MAX_DURATION" env-default:"720h"`
+	// ApiKeyMaxLifetime is the longest time an API key of a service account may last.
+	ApiKeyMaxLifetime time.Duration `yaml:"api_key_max_lifetime" env:"API_KEY_MAX_LIFETIME" env-default:"8760h"`
 }
 
 type Config struct {
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// AccessGrant is temporary access of a user beyond their clearance level: either a
+// raised clearance level or access to a single kind, until the grant expires or is
+// revoked. Exactly one of ClearanceLevel and KindID is set.
+type AccessGrant struct {
+	ID             string
+	UserID         string
+	ClearanceLevel int
+	KindID         int
+	Reason         string
+	GrantedBy      string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	RevokedAt      *time.Time
+	RevokedBy      string
+}
+
+// IsActive reports whether the grant gives access at the time.
+func (g *AccessGrant) IsActive(now time.Time) bool {
+	return g.RevokedAt == nil && now.Before(g.ExpiresAt)
+}
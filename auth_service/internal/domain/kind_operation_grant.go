@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Operations on data of a kind that kind operation grants allow.
+const (
+	KindOperationTokenize   = "tokenize"
+	KindOperationDetokenize = "detokenize"
+	KindOperationView       = "view"
+	KindOperationDelete     = "delete"
+)
+
+var KindOperations = []string{
+	KindOperationTokenize,
+	KindOperationDetokenize,
+	KindOperationView,
+	KindOperationDelete,
+}
+
+// KindOperationGrant allows a user, or every holder of a role, operations on data of a
+// kind whatever its access level. Exactly one of UserID and RoleID is set.
+type KindOperationGrant struct {
+	ID         int
+	UserID     string
+	RoleID     int
+	KindID     int
+	Operations []string
+	GrantedBy  string
+	CreatedAt  time.Time
+}
@@ -1,6 +1,17 @@
 package domain
 
+// Role is a set of permissions. Built-in roles are presets that cannot be changed or
+// deleted; custom roles are made of permissions from the catalog.
 type Role struct {
-	ID   int
-	Name string
+	ID          int
+	Name        string
+	Description string
+	Permissions []string
+	BuiltIn     bool
+}
+
+// Permission allows the action of the same name of the gateway access policy.
+type Permission struct {
+	Name        string
+	Description string
 }
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"net/netip"
+	"slices"
+	"time"
+)
+
+// ServiceAccount is the identity of a machine client. It has no password and
+// authenticates with its API keys.
+type ServiceAccount struct {
+	ID          string
+	Name        string
+	Description string
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+// ApiKey is a key a service account authenticates with. It allows the permissions of the
+// key on data of its kinds only, from its allowed addresses if any are set, until it
+// expires or is revoked. Only the hash of the key is kept; Prefix identifies the key
+// and is not secret.
+type ApiKey struct {
+	ID               string
+	ServiceAccountID string
+	Prefix           string
+	Hash             []byte
+	Permissions      []string
+	KindIDs          []int
+	// AllowedIPs are CIDR prefixes; a single address is kept as a /32 or /128 prefix.
+	AllowedIPs []string
+	CreatedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	LastUsedIP string
+	RevokedAt  *time.Time
+	RevokedBy  string
+}
+
+// IsActive reports whether the key can be used at the time.
+func (k *ApiKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
+
+// AllowsAddress reports whether the key can be used from the address.
+func (k *ApiKey) AllowsAddress(addr netip.Addr) bool {
+	if len(k.AllowedIPs) == 0 {
+		return true
+	}
+	addr = addr.Unmap()
+	return slices.ContainsFunc(k.AllowedIPs, func(allowed string) bool {
+		prefix, err := netip.ParsePrefix(allowed)
+		return err == nil && prefix.Contains(addr)
+	})
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type User struct {
 	ID             string
 	Login          string
@@ -7,3 +9,19 @@ type User struct {
 	Roles          []*Role
 	ClearanceLevel int
 }
+
+// ExternalIdentity is the account of a user at an OpenID Connect identity provider,
+// identified by the issuer and subject of its ID tokens.
+type ExternalIdentity struct {
+	Issuer  string
+	Subject string
+	Login   string
+}
+
+// BreakGlass is a break-glass session of a user: emergency access beyond the user's
+// clearance level, started with a justification and limited in time.
+type BreakGlass struct {
+	ID            string
+	Justification string
+	ExpiresAt     time.Time
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 )
 
 type AuthPostgresAdapter struct {
@@ -143,31 +144,18 @@ func (a *AuthPostgresAdapter) UpdateClearanceLevel(ctx context.Context, userId u
 }
 
 func (a *AuthPostgresAdapter) GetRolesList(ctx context.Context) ([]*domain.Role, error) {
-	query := `SELECT id, name FROM auth.roles ORDER BY id`
+	query := `
+		SELECT ` + roleColumns + `
+		FROM auth.roles r
+		ORDER BY r.id
+	`
 
 	rows, err := a.pool.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("get roles list: %w", err)
 	}
-	defer rows.Close()
-
-	var roles []*domain.Role
-
-	for rows.Next() {
-		var r domain.Role
-
-		if err = rows.Scan(&r.ID, &r.Name); err != nil {
-			return nil, fmt.Errorf("scan role: %w", err)
-		}
-
-		roles = append(roles, &r)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w", err)
-	}
 
-	return roles, nil
+	return scanRoles(rows)
 }
 
 func NewAuthPostgresAdapter(pool *pgxpool.Pool) *AuthPostgresAdapter {
@@ -205,6 +193,21 @@ func (a *AuthPostgresAdapter) LoginUser(ctx context.Context, login string) (*dom
 	return &user, nil
 }
 
+func (a *AuthPostgresAdapter) GetUser(ctx context.Context, userId uuid.UUID) (*domain.User, error) {
+	query := `SELECT id, login, clearance_level FROM auth.users WHERE id = $1`
+
+	var user domain.User
+	err := a.pool.QueryRow(ctx, query, userId).Scan(&user.ID, &user.Login, &user.ClearanceLevel)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	return &user, nil
+}
+
 func (a *AuthPostgresAdapter) IsAdminCheck(ctx context.Context, userId uuid.UUID) (bool, error) {
 	query := `SELECT COUNT(*) AS result FROM auth.users_roles WHERE user_id = $1 AND role_id = 1`
 
@@ -222,16 +225,26 @@ func (a *AuthPostgresAdapter) IsAdminCheck(ctx context.Context, userId uuid.UUID
 
 func (a *AuthPostgresAdapter) GetUserRoles(ctx context.Context, userId uuid.UUID) ([]*domain.Role, error) {
 	query := `
-		SELECT r.id, r.name
+		SELECT ` + roleColumns + `
 		FROM auth.users_roles ur
 		INNER JOIN auth.roles r ON r.id = ur.role_id
 		WHERE ur.user_id = $1
+		ORDER BY r.id
 	`
 
 	rows, err := a.pool.Query(ctx, query, userId)
 	if err != nil {
 		return nil, fmt.Errorf("get roles list: %w", err)
 	}
+
+	return scanRoles(rows)
+}
+
+// roleColumns selects a role of auth.roles r with its permissions.
+const roleColumns = `r.id, r.name, r.description, r.built_in,
+		ARRAY(SELECT rp.permission FROM auth.roles_permissions rp WHERE rp.role_id = r.id ORDER BY rp.permission)`
+
+func scanRoles(rows pgx.Rows) ([]*domain.Role, error) {
 	defer rows.Close()
 
 	var roles []*domain.Role
@@ -239,16 +252,799 @@ func (a *AuthPostgresAdapter) GetUserRoles(ctx context.Context, userId uuid.UUID
 	for rows.Next() {
 		var r domain.Role
 
-		if err = rows.Scan(&r.ID, &r.Name); err != nil {
+		if err := rows.Scan(&r.ID, &r.Name, &r.Description, &r.BuiltIn, &r.Permissions); err != nil {
 			return nil, fmt.Errorf("scan role: %w", err)
 		}
 
 		roles = append(roles, &r)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
 	return roles, nil
 }
+
+// GetPermissions returns the catalog of permissions roles are made of.
+func (a *AuthPostgresAdapter) GetPermissions(ctx context.Context) ([]*domain.Permission, error) {
+	rows, err := a.pool.Query(ctx, `SELECT name, description FROM auth.permissions ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("get permissions: %w", err)
+	}
+	defer rows.Close()
+
+	var permissions []*domain.Permission
+
+	for rows.Next() {
+		var p domain.Permission
+
+		if err = rows.Scan(&p.Name, &p.Description); err != nil {
+			return nil, fmt.Errorf("scan permission: %w", err)
+		}
+
+		permissions = append(permissions, &p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// roleError translates the constraint violations of a role and its permissions.
+func roleError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "23505":
+			return errs.ErrRoleAlreadyExists
+		case pgErr.Code == "23503" && pgErr.TableName == "users_roles":
+			return errs.ErrRoleInUse
+		case pgErr.Code == "23503":
+			return errs.ErrUnknownPermission
+		}
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func setRolePermissions(ctx context.Context, tx pgx.Tx, roleId int, permissions []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM auth.roles_permissions WHERE role_id = $1`, roleId); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO auth.roles_permissions (role_id, permission)
+		SELECT $1, p FROM unnest($2::text[]) AS p
+		ON CONFLICT DO NOTHING
+	`
+	_, err := tx.Exec(ctx, query, roleId, permissions)
+	return err
+}
+
+// CreateRole stores the custom role with its permissions and sets its ID.
+func (a *AuthPostgresAdapter) CreateRole(ctx context.Context, role *domain.Role) error {
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("create role: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO auth.roles (name, description) VALUES ($1, $2) RETURNING id`
+	if err = tx.QueryRow(ctx, query, role.Name, role.Description).Scan(&role.ID); err != nil {
+		return roleError("create role", err)
+	}
+	if err = setRolePermissions(ctx, tx, role.ID, role.Permissions); err != nil {
+		return roleError("create role", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("create role: commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateRole replaces the name, description and permissions of the custom role and
+// returns the IDs of the users holding it.
+func (a *AuthPostgresAdapter) UpdateRole(ctx context.Context, role *domain.Role) ([]string, error) {
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("update role: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var builtIn bool
+	err = tx.QueryRow(ctx, `SELECT built_in FROM auth.roles WHERE id = $1 FOR UPDATE`, role.ID).Scan(&builtIn)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("update role: %w", err)
+	}
+	if builtIn {
+		return nil, errs.ErrRoleBuiltIn
+	}
+
+	query := `UPDATE auth.roles SET name = $2, description = $3 WHERE id = $1`
+	if _, err = tx.Exec(ctx, query, role.ID, role.Name, role.Description); err != nil {
+		return nil, roleError("update role", err)
+	}
+	if err = setRolePermissions(ctx, tx, role.ID, role.Permissions); err != nil {
+		return nil, roleError("update role", err)
+	}
+
+	rows, err := tx.Query(ctx, `SELECT user_id::text FROM auth.users_roles WHERE role_id = $1`, role.ID)
+	if err != nil {
+		return nil, fmt.Errorf("update role: get role users: %w", err)
+	}
+	userIds, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("update role: scan role users: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("update role: commit transaction: %w", err)
+	}
+	return userIds, nil
+}
+
+// DeleteRole deletes the custom role if no user holds it.
+func (a *AuthPostgresAdapter) DeleteRole(ctx context.Context, roleId int) error {
+	query := `DELETE FROM auth.roles WHERE id = $1 RETURNING built_in`
+
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("delete role: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var builtIn bool
+	if err = tx.QueryRow(ctx, query, roleId).Scan(&builtIn); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errs.ErrRoleNotFound
+		}
+		return roleError("delete role", err)
+	}
+	if builtIn {
+		return errs.ErrRoleBuiltIn
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("delete role: commit transaction: %w", err)
+	}
+	return nil
+}
+
+const accessGrantColumns = `id, user_id, clearance_level, kind_id, reason, granted_by, created_at, expires_at,
+		revoked_at, revoked_by`
+
+func scanAccessGrant(row pgx.Row) (*domain.AccessGrant, error) {
+	var (
+		grant          domain.AccessGrant
+		clearanceLevel *int
+		kindID         *int
+		revokedBy      *string
+	)
+
+	err := row.Scan(&grant.ID, &grant.UserID, &clearanceLevel, &kindID, &grant.Reason, &grant.GrantedBy,
+		&grant.CreatedAt, &grant.ExpiresAt, &grant.RevokedAt, &revokedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if clearanceLevel != nil {
+		grant.ClearanceLevel = *clearanceLevel
+	}
+	if kindID != nil {
+		grant.KindID = *kindID
+	}
+	if revokedBy != nil {
+		grant.RevokedBy = *revokedBy
+	}
+	return &grant, nil
+}
+
+// CreateAccessGrant stores the grant of an existing user and sets its ID and creation
+// time.
+func (a *AuthPostgresAdapter) CreateAccessGrant(ctx context.Context, grant *domain.AccessGrant) error {
+	query := `
+		INSERT INTO auth.access_grants (user_id, clearance_level, kind_id, reason, granted_by, expires_at)
+		SELECT u.id, NULLIF($2, 0), NULLIF($3, 0), $4, $5, $6
+		FROM auth.users u
+		WHERE u.id = $1
+		RETURNING id, created_at
+	`
+
+	err := a.pool.QueryRow(ctx, query, grant.UserID, grant.ClearanceLevel, grant.KindID, grant.Reason,
+		grant.GrantedBy, grant.ExpiresAt).Scan(&grant.ID, &grant.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errs.ErrUserNotFound
+		}
+		return fmt.Errorf("create access grant: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAccessGrant revokes the grant if it is active.
+func (a *AuthPostgresAdapter) RevokeAccessGrant(ctx context.Context, grantId, revokedBy uuid.UUID) (*domain.AccessGrant, error) {
+	query := `
+		UPDATE auth.access_grants
+		SET revoked_at = now(), revoked_by = $2
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > now()
+		RETURNING ` + accessGrantColumns
+
+	grant, err := scanAccessGrant(a.pool.QueryRow(ctx, query, grantId, revokedBy))
+	if err == nil {
+		return grant, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("revoke access grant: %w", err)
+	}
+
+	var exists bool
+	err = a.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auth.access_grants WHERE id = $1)`, grantId).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("revoke access grant: %w", err)
+	}
+	if !exists {
+		return nil, errs.ErrAccessGrantNotFound
+	}
+	return nil, errs.ErrAccessGrantNotActive
+}
+
+// GetAccessGrants returns the grants of the user, or of all users if userId is nil,
+// newest first.
+func (a *AuthPostgresAdapter) GetAccessGrants(ctx context.Context, userId *uuid.UUID, activeOnly bool) ([]*domain.AccessGrant, error) {
+	query := `
+		SELECT ` + accessGrantColumns + `
+		FROM auth.access_grants
+		WHERE ($1::uuid IS NULL OR user_id = $1)
+			AND (NOT $2 OR (revoked_at IS NULL AND expires_at > now()))
+		ORDER BY created_at DESC
+	`
+
+	rows, err := a.pool.Query(ctx, query, userId, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("get access grants: %w", err)
+	}
+	defer rows.Close()
+
+	var grants []*domain.AccessGrant
+
+	for rows.Next() {
+		grant, err := scanAccessGrant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan access grant: %w", err)
+		}
+
+		grants = append(grants, grant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return grants, nil
+}
+
+const kindOperationGrantColumns = `id, user_id, role_id, kind_id, operations, granted_by, created_at`
+
+func scanKindOperationGrant(row pgx.Row) (*domain.KindOperationGrant, error) {
+	var (
+		grant  domain.KindOperationGrant
+		userID *string
+		roleID *int
+	)
+
+	err := row.Scan(&grant.ID, &userID, &roleID, &grant.KindID, &grant.Operations, &grant.GrantedBy, &grant.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != nil {
+		grant.UserID = *userID
+	}
+	if roleID != nil {
+		grant.RoleID = *roleID
+	}
+	return &grant, nil
+}
+
+// kindOperationGrantUsers returns the IDs of the users the grant applies to: its user, or
+// the holders of its role.
+func (a *AuthPostgresAdapter) kindOperationGrantUsers(ctx context.Context, grant *domain.KindOperationGrant) ([]string, error) {
+	if grant.UserID != "" {
+		return []string{grant.UserID}, nil
+	}
+
+	rows, err := a.pool.Query(ctx, `SELECT user_id::text FROM auth.users_roles WHERE role_id = $1`, grant.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// SetKindOperationGrant creates the grant of the user or role for the kind, or replaces
+// the operations of the existing one, sets its ID and creation time and returns the IDs
+// of the users it applies to.
+func (a *AuthPostgresAdapter) SetKindOperationGrant(ctx context.Context, grant *domain.KindOperationGrant) ([]string, error) {
+	conflict := `(role_id, kind_id) WHERE role_id IS NOT NULL`
+	if grant.UserID != "" {
+		conflict = `(user_id, kind_id) WHERE user_id IS NOT NULL`
+	}
+	query := `
+		INSERT INTO auth.kind_operation_grants (user_id, role_id, kind_id, operations, granted_by)
+		VALUES (NULLIF($1, '')::uuid, NULLIF($2, 0), $3, $4, $5)
+		ON CONFLICT ` + conflict + `
+		DO UPDATE SET operations = EXCLUDED.operations, granted_by = EXCLUDED.granted_by, created_at = now()
+		RETURNING id, created_at
+	`
+
+	err := a.pool.QueryRow(ctx, query, grant.UserID, grant.RoleID, grant.KindID, grant.Operations,
+		grant.GrantedBy).Scan(&grant.ID, &grant.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			if pgErr.ConstraintName == "fk_kind_operation_grants_role_id" {
+				return nil, errs.ErrRoleNotFound
+			}
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("set kind operation grant: %w", err)
+	}
+
+	userIds, err := a.kindOperationGrantUsers(ctx, grant)
+	if err != nil {
+		return nil, fmt.Errorf("set kind operation grant: get grant users: %w", err)
+	}
+	return userIds, nil
+}
+
+// DeleteKindOperationGrant deletes the grant and returns it with the IDs of the users it
+// applied to.
+func (a *AuthPostgresAdapter) DeleteKindOperationGrant(ctx context.Context, grantId int) (*domain.KindOperationGrant, []string, error) {
+	query := `DELETE FROM auth.kind_operation_grants WHERE id = $1 RETURNING ` + kindOperationGrantColumns
+
+	grant, err := scanKindOperationGrant(a.pool.QueryRow(ctx, query, grantId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errs.ErrKindOpGrantNotFound
+		}
+		return nil, nil, fmt.Errorf("delete kind operation grant: %w", err)
+	}
+
+	userIds, err := a.kindOperationGrantUsers(ctx, grant)
+	if err != nil {
+		return nil, nil, fmt.Errorf("delete kind operation grant: get grant users: %w", err)
+	}
+	return grant, userIds, nil
+}
+
+// GetKindOperationGrants returns the grants of the user or role, or all grants if userId
+// is nil and roleId is 0, ordered by kind. With effective, the grants of the user include
+// those of their roles.
+func (a *AuthPostgresAdapter) GetKindOperationGrants(ctx context.Context, userId *uuid.UUID, roleId int, effective bool) ([]*domain.KindOperationGrant, error) {
+	query := `
+		SELECT ` + kindOperationGrantColumns + `
+		FROM auth.kind_operation_grants
+		WHERE ($1::uuid IS NULL OR user_id = $1
+				OR ($3 AND role_id IN (SELECT role_id FROM auth.users_roles WHERE user_id = $1)))
+			AND ($2 = 0 OR role_id = $2)
+		ORDER BY kind_id, id
+	`
+
+	rows, err := a.pool.Query(ctx, query, userId, roleId, effective)
+	if err != nil {
+		return nil, fmt.Errorf("get kind operation grants: %w", err)
+	}
+	defer rows.Close()
+
+	var grants []*domain.KindOperationGrant
+
+	for rows.Next() {
+		grant, err := scanKindOperationGrant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan kind operation grant: %w", err)
+		}
+
+		grants = append(grants, grant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return grants, nil
+}
+
+const serviceAccountColumns = `id, name, description, created_by, created_at`
+
+func scanServiceAccount(row pgx.Row) (*domain.ServiceAccount, error) {
+	var account domain.ServiceAccount
+	err := row.Scan(&account.ID, &account.Name, &account.Description, &account.CreatedBy, &account.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CreateServiceAccount stores the service account and sets its ID and creation time.
+func (a *AuthPostgresAdapter) CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) error {
+	query := `
+		INSERT INTO auth.service_accounts (name, description, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := a.pool.QueryRow(ctx, query, account.Name, account.Description, account.CreatedBy).
+		Scan(&account.ID, &account.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return errs.ErrSvcAccountExists
+		}
+		return fmt.Errorf("create service account: %w", err)
+	}
+	return nil
+}
+
+// DeleteServiceAccount deletes the service account together with its API keys.
+func (a *AuthPostgresAdapter) DeleteServiceAccount(ctx context.Context, accountId uuid.UUID) (*domain.ServiceAccount, error) {
+	query := `DELETE FROM auth.service_accounts WHERE id = $1 RETURNING ` + serviceAccountColumns
+
+	account, err := scanServiceAccount(a.pool.QueryRow(ctx, query, accountId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrSvcAccountNotFound
+		}
+		return nil, fmt.Errorf("delete service account: %w", err)
+	}
+	return account, nil
+}
+
+// GetServiceAccounts returns all service accounts ordered by name.
+func (a *AuthPostgresAdapter) GetServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM auth.service_accounts ORDER BY name`
+
+	rows, err := a.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("get service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*domain.ServiceAccount
+
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan service account: %w", err)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return accounts, nil
+}
+
+const apiKeyColumns = `id, service_account_id, prefix, key_hash, permissions, kind_ids, allowed_ips, created_by,
+		created_at, expires_at, last_used_at, last_used_ip, revoked_at, revoked_by`
+
+func scanApiKey(row pgx.Row) (*domain.ApiKey, error) {
+	var (
+		key        domain.ApiKey
+		lastUsedIP *string
+		revokedBy  *string
+	)
+
+	err := row.Scan(&key.ID, &key.ServiceAccountID, &key.Prefix, &key.Hash, &key.Permissions, &key.KindIDs,
+		&key.AllowedIPs, &key.CreatedBy, &key.CreatedAt, &key.ExpiresAt, &key.LastUsedAt, &lastUsedIP,
+		&key.RevokedAt, &revokedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastUsedIP != nil {
+		key.LastUsedIP = *lastUsedIP
+	}
+	if revokedBy != nil {
+		key.RevokedBy = *revokedBy
+	}
+	return &key, nil
+}
+
+// rowQuerier is a pool or a transaction.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func insertApiKey(ctx context.Context, q rowQuerier, key *domain.ApiKey) error {
+	query := `
+		INSERT INTO auth.api_keys (service_account_id, prefix, key_hash, permissions, kind_ids, allowed_ips,
+			created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+
+	err := q.QueryRow(ctx, query, key.ServiceAccountID, key.Prefix, key.Hash, key.Permissions, key.KindIDs,
+		key.AllowedIPs, key.CreatedBy, key.ExpiresAt).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errs.ErrSvcAccountNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateApiKey stores the key of an existing service account and sets its ID and
+// creation time.
+func (a *AuthPostgresAdapter) CreateApiKey(ctx context.Context, key *domain.ApiKey) error {
+	if err := insertApiKey(ctx, a.pool, key); err != nil {
+		if errors.Is(err, errs.ErrSvcAccountNotFound) {
+			return err
+		}
+		return fmt.Errorf("create api key: %w", err)
+	}
+	return nil
+}
+
+// GetApiKey returns the key with the ID.
+func (a *AuthPostgresAdapter) GetApiKey(ctx context.Context, keyId uuid.UUID) (*domain.ApiKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM auth.api_keys WHERE id = $1`
+
+	key, err := scanApiKey(a.pool.QueryRow(ctx, query, keyId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrApiKeyNotFound
+		}
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+	return key, nil
+}
+
+// GetApiKeyByPrefix returns the key with the lookup prefix and its service account.
+func (a *AuthPostgresAdapter) GetApiKeyByPrefix(ctx context.Context, prefix string) (*domain.ApiKey, *domain.ServiceAccount, error) {
+	query := `
+		SELECT k.id, k.service_account_id, k.prefix, k.key_hash, k.permissions, k.kind_ids, k.allowed_ips,
+			k.created_by, k.created_at, k.expires_at, k.last_used_at, k.last_used_ip, k.revoked_at, k.revoked_by,
+			s.id, s.name, s.description, s.created_by, s.created_at
+		FROM auth.api_keys k
+		JOIN auth.service_accounts s ON s.id = k.service_account_id
+		WHERE k.prefix = $1
+	`
+
+	var (
+		key        domain.ApiKey
+		account    domain.ServiceAccount
+		lastUsedIP *string
+		revokedBy  *string
+	)
+	err := a.pool.QueryRow(ctx, query, prefix).Scan(&key.ID, &key.ServiceAccountID, &key.Prefix, &key.Hash,
+		&key.Permissions, &key.KindIDs, &key.AllowedIPs, &key.CreatedBy, &key.CreatedAt, &key.ExpiresAt,
+		&key.LastUsedAt, &lastUsedIP, &key.RevokedAt, &revokedBy,
+		&account.ID, &account.Name, &account.Description, &account.CreatedBy, &account.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errs.ErrApiKeyNotFound
+		}
+		return nil, nil, fmt.Errorf("get api key by prefix: %w", err)
+	}
+
+	if lastUsedIP != nil {
+		key.LastUsedIP = *lastUsedIP
+	}
+	if revokedBy != nil {
+		key.RevokedBy = *revokedBy
+	}
+	return &key, &account, nil
+}
+
+// apiKeyEndError tells a missing key from one that has already ended after an update of
+// an active key matched no rows.
+func (a *AuthPostgresAdapter) apiKeyEndError(ctx context.Context, op string, keyId uuid.UUID) error {
+	var exists bool
+	err := a.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auth.api_keys WHERE id = $1)`, keyId).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return errs.ErrApiKeyNotFound
+	}
+	return errs.ErrApiKeyNotActive
+}
+
+// RotateApiKey stores the new key in place of the active previous key and returns the
+// previous key. The previous key expires at graceUntil if it is set and earlier than its
+// own expiry, and is revoked otherwise.
+func (a *AuthPostgresAdapter) RotateApiKey(ctx context.Context, previousId uuid.UUID, key *domain.ApiKey,
+	graceUntil time.Time, rotatedBy uuid.UUID) (*domain.ApiKey, error) {
+
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("rotate api key: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var query string
+	args := []any{previousId}
+	if graceUntil.IsZero() {
+		query = `
+			UPDATE auth.api_keys
+			SET revoked_at = now(), revoked_by = $2
+			WHERE id = $1 AND revoked_at IS NULL AND expires_at > now()
+			RETURNING ` + apiKeyColumns
+		args = append(args, rotatedBy)
+	} else {
+		query = `
+			UPDATE auth.api_keys
+			SET expires_at = LEAST(expires_at, $2)
+			WHERE id = $1 AND revoked_at IS NULL AND expires_at > now()
+			RETURNING ` + apiKeyColumns
+		args = append(args, graceUntil)
+	}
+
+	previous, err := scanApiKey(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, a.apiKeyEndError(ctx, "rotate api key", previousId)
+		}
+		return nil, fmt.Errorf("rotate api key: %w", err)
+	}
+
+	key.ServiceAccountID = previous.ServiceAccountID
+	if err = insertApiKey(ctx, tx, key); err != nil {
+		return nil, fmt.Errorf("rotate api key: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("rotate api key: commit transaction: %w", err)
+	}
+	return previous, nil
+}
+
+// RevokeApiKey revokes the key if it is active.
+func (a *AuthPostgresAdapter) RevokeApiKey(ctx context.Context, keyId, revokedBy uuid.UUID) (*domain.ApiKey, error) {
+	query := `
+		UPDATE auth.api_keys
+		SET revoked_at = now(), revoked_by = $2
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > now()
+		RETURNING ` + apiKeyColumns
+
+	key, err := scanApiKey(a.pool.QueryRow(ctx, query, keyId, revokedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, a.apiKeyEndError(ctx, "revoke api key", keyId)
+		}
+		return nil, fmt.Errorf("revoke api key: %w", err)
+	}
+	return key, nil
+}
+
+// GetApiKeys returns the keys of the service account, or of all service accounts if
+// accountId is nil, newest first.
+func (a *AuthPostgresAdapter) GetApiKeys(ctx context.Context, accountId *uuid.UUID, activeOnly bool) ([]*domain.ApiKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM auth.api_keys
+		WHERE ($1::uuid IS NULL OR service_account_id = $1)
+			AND (NOT $2 OR (revoked_at IS NULL AND expires_at > now()))
+		ORDER BY created_at DESC
+	`
+
+	rows, err := a.pool.Query(ctx, query, accountId, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("get api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.ApiKey
+
+	for rows.Next() {
+		key, err := scanApiKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// TouchApiKey records the use of the key from the address. The time is only updated once
+// a minute, so that busy clients do not write on every request.
+func (a *AuthPostgresAdapter) TouchApiKey(ctx context.Context, keyId uuid.UUID, ip string) error {
+	query := `
+		UPDATE auth.api_keys
+		SET last_used_at = now(), last_used_ip = $2
+		WHERE id = $1
+			AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute' OR last_used_ip IS DISTINCT FROM $2)
+	`
+
+	if _, err := a.pool.Exec(ctx, query, keyId, ip); err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+	return nil
+}
+
+// LoginExternalUser returns the user linked to the external identity, creating the user
+// and the link on first sign-in, and reports whether the user was created. The roles and
+// clearance level of the user are replaced with the given ones.
+func (a *AuthPostgresAdapter) LoginExternalUser(ctx context.Context, identity *domain.ExternalIdentity,
+	roleIds []int, clearanceLevel int) (*domain.User, bool, error) {
+
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("login external user: begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	user := &domain.User{ClearanceLevel: clearanceLevel}
+	var created bool
+
+	err = tx.QueryRow(ctx, `
+		UPDATE auth.external_identities
+		SET last_login_at = now()
+		WHERE issuer = $1 AND subject = $2
+		RETURNING user_id`, identity.Issuer, identity.Subject).Scan(&user.ID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		err = tx.QueryRow(ctx, `
+			INSERT INTO auth.users (login, password_hash, clearance_level)
+			VALUES ($1, NULL, $2)
+			RETURNING id`, identity.Login, clearanceLevel).Scan(&user.ID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return nil, false, errs.ErrExternalLoginTaken
+			}
+			return nil, false, fmt.Errorf("login external user: insert user: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO auth.external_identities (issuer, subject, user_id)
+			VALUES ($1, $2, $3)`, identity.Issuer, identity.Subject, user.ID)
+		if err != nil {
+			return nil, false, fmt.Errorf("login external user: insert identity: %w", err)
+		}
+		created = true
+	case err != nil:
+		return nil, false, fmt.Errorf("login external user: %w", err)
+	default:
+		_, err = tx.Exec(ctx, `UPDATE auth.users SET clearance_level = $1 WHERE id = $2`, clearanceLevel, user.ID)
+		if err != nil {
+			return nil, false, fmt.Errorf("login external user: update clearance level: %w", err)
+		}
+	}
+
+	if err = tx.QueryRow(ctx, `SELECT login FROM auth.users WHERE id = $1`, user.ID).Scan(&user.Login); err != nil {
+		return nil, false, fmt.Errorf("login external user: get login: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM auth.users_roles WHERE user_id = $1`, user.ID); err != nil {
+		return nil, false, fmt.Errorf("login external user: delete roles: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO auth.users_roles (user_id, role_id)
+		SELECT $1, unnest($2::int[])`, user.ID, roleIds)
+	if err != nil {
+		return nil, false, fmt.Errorf("login external user: insert roles: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("login external user: commit transaction: %w", err)
+	}
+	return user, created, nil
+}
@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"github.com/NeF2le/anonix/auth_service/internal/domain"
+	"time"
 )
 
 type AuthUseCase interface {
@@ -18,6 +19,32 @@ type AuthUseCase interface {
 	RemoveRole(ctx context.Context, userId string, roleId int) error
 	GetRolesList(ctx context.Context) ([]*domain.Role, error)
 	GetUserRoles(ctx context.Context, userId string) ([]*domain.Role, error)
+	GetPermissions(ctx context.Context) ([]*domain.Permission, error)
+	CreateRole(ctx context.Context, role *domain.Role) error
+	UpdateRole(ctx context.Context, role *domain.Role) ([]string, error)
+	DeleteRole(ctx context.Context, roleId int) error
 
 	UpdateClearanceLevel(ctx context.Context, userId string, level int) error
+
+	IssueAccessToken(ctx context.Context, userId string, breakGlass *domain.BreakGlass) (string, time.Time, error)
+
+	CreateAccessGrant(ctx context.Context, grant *domain.AccessGrant) error
+	RevokeAccessGrant(ctx context.Context, grantId, revokedBy string) (*domain.AccessGrant, error)
+	GetAccessGrants(ctx context.Context, userId string, activeOnly bool) ([]*domain.AccessGrant, error)
+
+	SetKindOperationGrant(ctx context.Context, grant *domain.KindOperationGrant) ([]string, error)
+	DeleteKindOperationGrant(ctx context.Context, grantId int) (*domain.KindOperationGrant, []string, error)
+	GetKindOperationGrants(ctx context.Context, userId string, roleId int, effective bool) ([]*domain.KindOperationGrant, error)
+
+	CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) error
+	DeleteServiceAccount(ctx context.Context, accountId string) (*domain.ServiceAccount, error)
+	GetServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error)
+	CreateApiKey(ctx context.Context, key *domain.ApiKey) (string, error)
+	RotateApiKey(ctx context.Context, keyId string, grace time.Duration, rotatedBy string) (*domain.ApiKey, string, *domain.ApiKey, error)
+	RevokeApiKey(ctx context.Context, keyId, revokedBy string) (*domain.ApiKey, error)
+	GetApiKeys(ctx context.Context, accountId string, activeOnly bool) ([]*domain.ApiKey, error)
+	AuthenticateApiKey(ctx context.Context, key, ip string) (*domain.ApiKey, *domain.ServiceAccount, error)
+	IssueClientToken(ctx context.Context, clientId, secret string, scope []string, ip string) (*domain.ServiceAccount, string, time.Time, []string, error)
+
+	LoginExternal(ctx context.Context, identity *domain.ExternalIdentity, roleNames []string, clearanceLevel int) (string, string, string, bool, error)
 }
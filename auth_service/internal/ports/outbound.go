@@ -4,11 +4,13 @@ import (
 	"context"
 	"github.com/NeF2le/anonix/auth_service/internal/domain"
 	"github.com/google/uuid"
+	"time"
 )
 
 type StorageRepository interface {
 	RegisterUser(ctx context.Context, login string, passHash []byte) (string, error)
 	LoginUser(ctx context.Context, login string) (*domain.User, error)
+	GetUser(ctx context.Context, userId uuid.UUID) (*domain.User, error)
 	IsAdminCheck(ctx context.Context, userId uuid.UUID) (bool, error)
 
 	GetUsers(ctx context.Context) ([]*domain.User, error)
@@ -18,8 +20,33 @@ type StorageRepository interface {
 	RemoveRole(ctx context.Context, userId uuid.UUID, roleId int) error
 	GetRolesList(ctx context.Context) ([]*domain.Role, error)
 	GetUserRoles(ctx context.Context, userId uuid.UUID) ([]*domain.Role, error)
+	GetPermissions(ctx context.Context) ([]*domain.Permission, error)
+	CreateRole(ctx context.Context, role *domain.Role) error
+	UpdateRole(ctx context.Context, role *domain.Role) ([]string, error)
+	DeleteRole(ctx context.Context, roleId int) error
 
 	UpdateClearanceLevel(ctx context.Context, userId uuid.UUID, level int) error
+
+	CreateAccessGrant(ctx context.Context, grant *domain.AccessGrant) error
+	RevokeAccessGrant(ctx context.Context, grantId, revokedBy uuid.UUID) (*domain.AccessGrant, error)
+	GetAccessGrants(ctx context.Context, userId *uuid.UUID, activeOnly bool) ([]*domain.AccessGrant, error)
+
+	SetKindOperationGrant(ctx context.Context, grant *domain.KindOperationGrant) ([]string, error)
+	DeleteKindOperationGrant(ctx context.Context, grantId int) (*domain.KindOperationGrant, []string, error)
+	GetKindOperationGrants(ctx context.Context, userId *uuid.UUID, roleId int, effective bool) ([]*domain.KindOperationGrant, error)
+
+	CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) error
+	DeleteServiceAccount(ctx context.Context, accountId uuid.UUID) (*domain.ServiceAccount, error)
+	GetServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error)
+	CreateApiKey(ctx context.Context, key *domain.ApiKey) error
+	GetApiKey(ctx context.Context, keyId uuid.UUID) (*domain.ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (*domain.ApiKey, *domain.ServiceAccount, error)
+	RotateApiKey(ctx context.Context, previousId uuid.UUID, key *domain.ApiKey, graceUntil time.Time, rotatedBy uuid.UUID) (*domain.ApiKey, error)
+	RevokeApiKey(ctx context.Context, keyId, revokedBy uuid.UUID) (*domain.ApiKey, error)
+	GetApiKeys(ctx context.Context, accountId *uuid.UUID, activeOnly bool) ([]*domain.ApiKey, error)
+	TouchApiKey(ctx context.Context, keyId uuid.UUID, ip string) error
+
+	LoginExternalUser(ctx context.Context, identity *domain.ExternalIdentity, roleIds []int, clearanceLevel int) (*domain.User, bool, error)
 }
 
 type CacheRepository interface {
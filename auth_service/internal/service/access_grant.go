@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeF2le/anonix/auth_service/internal/domain"
+	"github.com/NeF2le/anonix/auth_service/internal/service/utils"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// accessClaims is what an access token of a user carries: their roles and the
+// permissions of those roles, their clearance level raised by their grants, the kinds
+// granted to them and the operations allowed to them and their roles on kinds.
+// grantsEndAt is when the first of their active grants ends, or the zero time if they
+// have none.
+type accessClaims struct {
+	roles          []string
+	permissions    []string
+	clearanceLevel int
+	kindGrants     []int
+	kindOperations map[int][]string
+	grantsEndAt    time.Time
+}
+
+func (s *AuthService) getAccessClaims(ctx context.Context, user *domain.User) (*accessClaims, error) {
+	userUUID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	domainRoles, err := s.storage.GetUserRoles(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+	grants, err := s.storage.GetAccessGrants(ctx, &userUUID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user access grants: %w", err)
+	}
+	operationGrants, err := s.storage.GetKindOperationGrants(ctx, &userUUID, 0, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user kind operation grants: %w", err)
+	}
+
+	claims := &accessClaims{
+		roles:          make([]string, 0, len(domainRoles)),
+		clearanceLevel: user.ClearanceLevel,
+		kindOperations: kindOperations(operationGrants),
+	}
+	for _, r := range domainRoles {
+		claims.roles = append(claims.roles, r.Name)
+		claims.permissions = append(claims.permissions, r.Permissions...)
+	}
+	slices.Sort(claims.permissions)
+	claims.permissions = slices.Compact(claims.permissions)
+
+	now := time.Now()
+	for _, g := range grants {
+		if !g.IsActive(now) {
+			continue
+		}
+		if g.KindID != 0 {
+			claims.kindGrants = append(claims.kindGrants, g.KindID)
+		} else if g.ClearanceLevel > claims.clearanceLevel {
+			claims.clearanceLevel = g.ClearanceLevel
+		}
+		if claims.grantsEndAt.IsZero() || g.ExpiresAt.Before(claims.grantsEndAt) {
+			claims.grantsEndAt = g.ExpiresAt
+		}
+	}
+
+	return claims, nil
+}
+
+// generateAccessToken generates an access token of the user with the claims. The token
+// expires no later than the first of the user's grants, so that the next one is issued
+// without it; a token of a break-glass session expires with the session.
+func (s *AuthService) generateAccessToken(user *domain.User, claims *accessClaims, breakGlass *domain.BreakGlass) (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.AccessExpiration)
+	if breakGlass != nil {
+		expiresAt = breakGlass.ExpiresAt
+	}
+	if !claims.grantsEndAt.IsZero() && claims.grantsEndAt.Before(expiresAt) {
+		expiresAt = claims.grantsEndAt
+	}
+
+	var (
+		accessToken string
+		err         error
+	)
+	if breakGlass != nil {
+		accessToken, err = utils.GenerateBreakGlassJWT(user.ID, time.Until(expiresAt), s.jwtSecret, claims.roles,
+			claims.permissions, claims.clearanceLevel, claims.kindOperations, breakGlass.ID, breakGlass.Justification)
+	} else {
+		accessToken, err = utils.GenerateAccessJWT(user.ID, time.Until(expiresAt), s.jwtSecret, claims.roles,
+			claims.permissions, claims.clearanceLevel, claims.kindGrants, claims.kindOperations)
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return accessToken, expiresAt, nil
+}
+
+// CreateAccessGrant grants the user a raised clearance level or access to a kind until
+// the grant expires. The grant reaches the user with their next access token.
+func (s *AuthService) CreateAccessGrant(ctx context.Context, grant *domain.AccessGrant) error {
+	if _, err := uuid.Parse(grant.UserID); err != nil {
+		return errs.ErrInvalidCredentials
+	}
+	if _, err := uuid.Parse(grant.GrantedBy); err != nil {
+		return errs.ErrInvalidCredentials
+	}
+
+	if (grant.ClearanceLevel == 0) == (grant.KindID == 0) || grant.KindID < 0 ||
+		grant.ClearanceLevel < 0 || grant.ClearanceLevel > 4 {
+		return errs.ErrInvalidAccessGrant
+	}
+
+	duration := time.Until(grant.ExpiresAt)
+	if duration <= 0 || duration > s.AccessGrantMaxDuration {
+		return errs.ErrAccessGrantDuration
+	}
+
+	return s.storage.CreateAccessGrant(ctx, grant)
+}
+
+// RevokeAccessGrant ends the active grant before it expires.
+func (s *AuthService) RevokeAccessGrant(ctx context.Context, grantId, revokedBy string) (*domain.AccessGrant, error) {
+	grantUUID, err := uuid.Parse(grantId)
+	if err != nil {
+		return nil, errs.ErrAccessGrantNotFound
+	}
+	revokedByUUID, err := uuid.Parse(revokedBy)
+	if err != nil {
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	return s.storage.RevokeAccessGrant(ctx, grantUUID, revokedByUUID)
+}
+
+// GetAccessGrants returns the grants of the user, or of all users if userId is empty,
+// newest first.
+func (s *AuthService) GetAccessGrants(ctx context.Context, userId string, activeOnly bool) ([]*domain.AccessGrant, error) {
+	var userUUID *uuid.UUID
+	if userId != "" {
+		parsed, err := uuid.Parse(userId)
+		if err != nil {
+			return nil, errs.ErrInvalidCredentials
+		}
+		userUUID = &parsed
+	}
+
+	return s.storage.GetAccessGrants(ctx, userUUID, activeOnly)
+}
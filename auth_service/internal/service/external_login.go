@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"github.com/NeF2le/anonix/auth_service/internal/domain"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxExternalLoginLen is the longest login the storage keeps; logins of identity
+// provider accounts are often e-mail addresses.
+const maxExternalLoginLen = 254
+
+// LoginExternal signs in the user of an identity provider account and returns the ID of
+// the user, a pair of tokens and whether the user was created. A user is created on
+// first sign-in and linked to the account by its issuer and subject, so later changes of
+// the login at the provider do not create another user. The roles, named as in the
+// catalog, and the clearance level mapped from the claims of the provider replace those
+// of the user on every sign-in.
+func (s *AuthService) LoginExternal(ctx context.Context, identity *domain.ExternalIdentity, roleNames []string,
+	clearanceLevel int) (string, string, string, bool, error) {
+
+	identity.Login = strings.TrimSpace(identity.Login)
+	if identity.Issuer == "" || identity.Subject == "" || identity.Login == "" ||
+		utf8.RuneCountInString(identity.Login) > maxExternalLoginLen || clearanceLevel < 1 || clearanceLevel > 4 {
+		return "", "", "", false, errs.ErrInvalidExternalLogin
+	}
+
+	roles, err := s.storage.GetRolesList(ctx)
+	if err != nil {
+		return "", "", "", false, err
+	}
+	roleIds := make([]int, 0, len(roleNames))
+	for _, name := range roleNames {
+		i := slices.IndexFunc(roles, func(r *domain.Role) bool { return r.Name == name })
+		if i < 0 {
+			return "", "", "", false, errs.ErrRoleNotFound
+		}
+		if !slices.Contains(roleIds, roles[i].ID) {
+			roleIds = append(roleIds, roles[i].ID)
+		}
+	}
+
+	user, created, err := s.storage.LoginExternalUser(ctx, identity, roleIds, clearanceLevel)
+	if err != nil {
+		return "", "", "", false, err
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return "", "", "", false, err
+	}
+	return user.ID, accessToken, refreshToken, created, nil
+}
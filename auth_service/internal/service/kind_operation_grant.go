@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"github.com/NeF2le/anonix/auth_service/internal/domain"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/google/uuid"
+	"slices"
+)
+
+// SetKindOperationGrant allows the user or role the operations on data of the kind,
+// replacing the operations granted before, and returns the IDs of the users the grant
+// applies to. The grant reaches them with their next access token.
+func (s *AuthService) SetKindOperationGrant(ctx context.Context, grant *domain.KindOperationGrant) ([]string, error) {
+	if _, err := uuid.Parse(grant.GrantedBy); err != nil {
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	if (grant.UserID == "") == (grant.RoleID == 0) || grant.RoleID < 0 || grant.KindID <= 0 ||
+		len(grant.Operations) == 0 {
+		return nil, errs.ErrInvalidKindOpGrant
+	}
+	if grant.UserID != "" {
+		if _, err := uuid.Parse(grant.UserID); err != nil {
+			return nil, errs.ErrInvalidCredentials
+		}
+	}
+	for _, op := range grant.Operations {
+		if !slices.Contains(domain.KindOperations, op) {
+			return nil, errs.ErrInvalidKindOpGrant
+		}
+	}
+	slices.Sort(grant.Operations)
+	grant.Operations = slices.Compact(grant.Operations)
+
+	return s.storage.SetKindOperationGrant(ctx, grant)
+}
+
+// DeleteKindOperationGrant deletes the grant and returns it with the IDs of the users it
+// applied to.
+func (s *AuthService) DeleteKindOperationGrant(ctx context.Context, grantId int) (*domain.KindOperationGrant, []string, error) {
+	return s.storage.DeleteKindOperationGrant(ctx, grantId)
+}
+
+// GetKindOperationGrants returns the grants of the user or role, or all grants if neither
+// is set. With effective, the grants of the user include those of their roles.
+func (s *AuthService) GetKindOperationGrants(ctx context.Context, userId string, roleId int, effective bool) (
+	[]*domain.KindOperationGrant, error) {
+	var userUUID *uuid.UUID
+	if userId != "" {
+		parsed, err := uuid.Parse(userId)
+		if err != nil {
+			return nil, errs.ErrInvalidCredentials
+		}
+		userUUID = &parsed
+	}
+
+	return s.storage.GetKindOperationGrants(ctx, userUUID, roleId, effective)
+}
+
+// kindOperations merges the grants into the operations allowed on each kind.
+func kindOperations(grants []*domain.KindOperationGrant) map[int][]string {
+	if len(grants) == 0 {
+		return nil
+	}
+
+	operations := make(map[int][]string)
+	for _, g := range grants {
+		operations[g.KindID] = append(operations[g.KindID], g.Operations...)
+	}
+	for kindID, ops := range operations {
+		slices.Sort(ops)
+		operations[kindID] = slices.Compact(ops)
+	}
+	return operations
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"github.com/NeF2le/anonix/auth_service/internal/domain"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxRoleDescriptionLen is the longest role description the storage keeps.
+const maxRoleDescriptionLen = 200
+
+var roleNamePattern = regexp.MustCompile(`^[a-z0-9_-]{2,50}$`)
+
+// normalizeRole validates the custom role and leaves its permissions sorted and without
+// duplicates. Permissions missing from the catalog are rejected by the storage.
+func normalizeRole(role *domain.Role) error {
+	if !roleNamePattern.MatchString(role.Name) {
+		return errs.ErrInvalidRoleName
+	}
+
+	role.Description = strings.TrimSpace(role.Description)
+	if utf8.RuneCountInString(role.Description) > maxRoleDescriptionLen {
+		return errs.ErrInvalidRoleDesc
+	}
+
+	if len(role.Permissions) == 0 {
+		return errs.ErrRoleNoPermissions
+	}
+	slices.Sort(role.Permissions)
+	role.Permissions = slices.Compact(role.Permissions)
+	return nil
+}
+
+// GetPermissions returns the catalog of permissions roles are made of.
+func (s *AuthService) GetPermissions(ctx context.Context) ([]*domain.Permission, error) {
+	return s.storage.GetPermissions(ctx)
+}
+
+// CreateRole creates a custom role with the permissions and sets its ID.
+func (s *AuthService) CreateRole(ctx context.Context, role *domain.Role) error {
+	if err := normalizeRole(role); err != nil {
+		return err
+	}
+	role.BuiltIn = false
+
+	return s.storage.CreateRole(ctx, role)
+}
+
+// UpdateRole replaces the name, description and permissions of a custom role and returns
+// the IDs of the users holding it. The new permissions reach those users with their next
+// access token.
+func (s *AuthService) UpdateRole(ctx context.Context, role *domain.Role) ([]string, error) {
+	if err := normalizeRole(role); err != nil {
+		return nil, err
+	}
+
+	return s.storage.UpdateRole(ctx, role)
+}
+
+// DeleteRole deletes a custom role no user holds.
+func (s *AuthService) DeleteRole(ctx context.Context, roleId int) error {
+	return s.storage.DeleteRole(ctx, roleId)
+}
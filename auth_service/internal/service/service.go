@@ -16,11 +16,13 @@ import (
 )
 
 type AuthService struct {
-	storage           ports.StorageRepository
-	cache             ports.CacheRepository
-	jwtSecret         string
-	RefreshExpiration time.Duration
-	AccessExpiration  time.Duration
+	storage                ports.StorageRepository
+	cache                  ports.CacheRepository
+	jwtSecret              string
+	RefreshExpiration      time.Duration
+	AccessExpiration       time.Duration
+	AccessGrantMaxDuration time.Duration
+	ApiKeyMaxLifetime      time.Duration
 }
 
 func NewAuthService(
@@ -29,13 +31,17 @@ func NewAuthService(
 	jwtSecret string,
 	RefreshExpiration time.Duration,
 	AccessExpiration time.Duration,
+	AccessGrantMaxDuration time.Duration,
+	ApiKeyMaxLifetime time.Duration,
 ) *AuthService {
 	return &AuthService{
-		storage:           storage,
-		cache:             cache,
-		jwtSecret:         jwtSecret,
-		RefreshExpiration: RefreshExpiration,
-		AccessExpiration:  AccessExpiration,
+		storage:                storage,
+		cache:                  cache,
+		jwtSecret:              jwtSecret,
+		RefreshExpiration:      RefreshExpiration,
+		AccessExpiration:       AccessExpiration,
+		AccessGrantMaxDuration: AccessGrantMaxDuration,
+		ApiKeyMaxLifetime:      ApiKeyMaxLifetime,
 	}
 }
 
@@ -139,47 +145,55 @@ func (s *AuthService) Login(ctx context.Context, login string, pass string) (str
 		return "", "", "", err
 	}
 
+	// Users signing in through an identity provider have no password.
+	if len(user.PassHash) == 0 {
+		return "", "", "", errs.ErrInvalidCredentials
+	}
 	if err = bcrypt.CompareHashAndPassword(user.PassHash, []byte(pass)); err != nil {
 		return "", "", "", errs.ErrInvalidCredentials
 	}
 
-	userUUID, err := uuid.Parse(user.ID)
+	accessToken, refreshToken, err := s.issueTokens(ctx, user)
 	if err != nil {
-		return "", "", "", errs.ErrInvalidCredentials
+		return "", "", "", err
 	}
 
-	domainRoles, err := s.storage.GetUserRoles(ctx, userUUID)
+	return user.ID, accessToken, refreshToken, nil
+}
+
+// issueTokens issues a pair of access and refresh tokens to the signed-in user.
+func (s *AuthService) issueTokens(ctx context.Context, user *domain.User) (string, string, error) {
+	claims, err := s.getAccessClaims(ctx, user)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to get user roles: %w", err)
-	}
-	roleNames := make([]string, 0, len(domainRoles))
-	for _, r := range domainRoles {
-		roleNames = append(roleNames, r.Name)
+		return "", "", err
 	}
 
-	accessToken, err := utils.GenerateJWT(user.ID, s.AccessExpiration, s.jwtSecret, false, roleNames, user.ClearanceLevel)
+	accessToken, _, err := s.generateAccessToken(user, claims, nil)
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
-	refreshToken, err := utils.GenerateJWT(user.ID, s.RefreshExpiration, s.jwtSecret, true, roleNames, user.ClearanceLevel)
+	refreshToken, err := utils.GenerateJWT(user.ID, s.RefreshExpiration, s.jwtSecret, true, claims.roles, user.ClearanceLevel)
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
 
 	err = s.cache.SaveToken(ctx, accessToken, user.ID, false)
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
 	err = s.cache.SaveToken(ctx, refreshToken, user.ID, true)
 	if err != nil {
-		return "", "", "", err
+		return "", "", err
 	}
 
-	return user.ID, accessToken, refreshToken, nil
+	return accessToken, refreshToken, nil
 }
 
+// Refresh exchanges the refresh token for a new pair of tokens. The roles, clearance
+// level and grants of the new access token are read anew, so that changes made since the
+// last login reach the user.
 func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (string, string, error) {
-	sub, ifRefresh, exp, roleNames, clearanceLevel, err := utils.ParseJWT(refreshToken, s.jwtSecret)
+	sub, ifRefresh, exp, _, _, err := utils.ParseJWT(refreshToken, s.jwtSecret)
 	if err != nil {
 		if errors.Is(err, errs.ErrTokenExpired) {
 			return "", "", errs.ErrTokenExpired
@@ -213,12 +227,30 @@ func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (string,
 		return "", "", errs.ErrInvalidToken
 	}
 
-	accessToken, err := utils.GenerateJWT(userID, s.AccessExpiration, s.jwtSecret, true, roleNames, clearanceLevel)
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return "", "", errs.ErrInvalidToken
+	}
+
+	user, err := s.storage.GetUser(ctx, userUUID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return "", "", errs.ErrInvalidToken
+		}
+		return "", "", err
+	}
+
+	claims, err := s.getAccessClaims(ctx, user)
 	if err != nil {
 		return "", "", err
 	}
 
-	newRefreshToken, err := utils.GenerateJWT(userID, s.RefreshExpiration, s.jwtSecret, false, roleNames, clearanceLevel)
+	accessToken, _, err := s.generateAccessToken(user, claims, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	newRefreshToken, err := utils.GenerateJWT(userID, s.RefreshExpiration, s.jwtSecret, true, claims.roles, user.ClearanceLevel)
 	if err != nil {
 		return "", "", err
 	}
@@ -228,7 +260,7 @@ func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (string,
 		return "", "", err
 	}
 
-	err = s.cache.SaveToken(ctx, refreshToken, userID, true)
+	err = s.cache.SaveToken(ctx, newRefreshToken, userID, true)
 	if err != nil {
 		return "", "", err
 	}
@@ -241,6 +273,37 @@ func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (string,
 	return accessToken, newRefreshToken, nil
 }
 
+// IssueAccessToken issues an access token with the current roles, clearance level and
+// grants of the user. A token of a break-glass session carries the session and expires
+// with it.
+func (s *AuthService) IssueAccessToken(ctx context.Context, userId string, breakGlass *domain.BreakGlass) (string, time.Time, error) {
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return "", time.Time{}, errs.ErrInvalidCredentials
+	}
+
+	user, err := s.storage.GetUser(ctx, userUUID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	claims, err := s.getAccessClaims(ctx, user)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	accessToken, expiresAt, err := s.generateAccessToken(user, claims, breakGlass)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if err = s.cache.SaveToken(ctx, accessToken, user.ID, false); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return accessToken, expiresAt, nil
+}
+
 func (s *AuthService) GetUserRoles(ctx context.Context, userId string) ([]*domain.Role, error) {
 	userUUID, err := uuid.Parse(userId)
 	if err != nil {
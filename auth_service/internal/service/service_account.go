@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/auth_service/internal/domain"
+	"github.com/NeF2le/anonix/auth_service/internal/service/utils"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxServiceAccountDescriptionLen is the longest service account description the storage
+// keeps.
+const maxServiceAccountDescriptionLen = 200
+
+// CreateServiceAccount creates a service account and sets its ID. Its names follow the
+// rules of role names.
+func (s *AuthService) CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) error {
+	if _, err := uuid.Parse(account.CreatedBy); err != nil {
+		return errs.ErrInvalidCredentials
+	}
+	if !roleNamePattern.MatchString(account.Name) {
+		return errs.ErrInvalidSvcAccount
+	}
+	account.Description = strings.TrimSpace(account.Description)
+	if utf8.RuneCountInString(account.Description) > maxServiceAccountDescriptionLen {
+		return errs.ErrInvalidSvcAccountDesc
+	}
+
+	return s.storage.CreateServiceAccount(ctx, account)
+}
+
+// DeleteServiceAccount deletes the service account together with its API keys.
+func (s *AuthService) DeleteServiceAccount(ctx context.Context, accountId string) (*domain.ServiceAccount, error) {
+	accountUUID, err := uuid.Parse(accountId)
+	if err != nil {
+		return nil, errs.ErrSvcAccountNotFound
+	}
+
+	return s.storage.DeleteServiceAccount(ctx, accountUUID)
+}
+
+// GetServiceAccounts returns all service accounts ordered by name.
+func (s *AuthService) GetServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	return s.storage.GetServiceAccounts(ctx)
+}
+
+// normalizeApiKeyScope validates the scope of the key against the catalog of permissions
+// and leaves its permissions and kinds sorted and without duplicates and its addresses as
+// CIDR prefixes.
+func (s *AuthService) normalizeApiKeyScope(ctx context.Context, key *domain.ApiKey) error {
+	if len(key.Permissions) == 0 {
+		return errs.ErrInvalidApiKeyScope
+	}
+	catalog, err := s.storage.GetPermissions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get permissions: %w", err)
+	}
+	for _, p := range key.Permissions {
+		if !slices.ContainsFunc(catalog, func(c *domain.Permission) bool { return c.Name == p }) {
+			return errs.ErrInvalidApiKeyScope
+		}
+	}
+	slices.Sort(key.Permissions)
+	key.Permissions = slices.Compact(key.Permissions)
+
+	for _, id := range key.KindIDs {
+		if id <= 0 {
+			return errs.ErrInvalidApiKeyScope
+		}
+	}
+	slices.Sort(key.KindIDs)
+	key.KindIDs = slices.Compact(key.KindIDs)
+
+	allowed := make([]string, 0, len(key.AllowedIPs))
+	for _, ip := range key.AllowedIPs {
+		prefix, err := parseAllowedIP(ip)
+		if err != nil {
+			return errs.ErrInvalidApiKeyScope
+		}
+		allowed = append(allowed, prefix.String())
+	}
+	slices.Sort(allowed)
+	key.AllowedIPs = slices.Compact(allowed)
+	return nil
+}
+
+// parseAllowedIP parses a CIDR range or a single address as a prefix.
+func parseAllowedIP(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// issueApiKey generates the secret of the key and sets its prefix and hash.
+func issueApiKey(key *domain.ApiKey) (string, error) {
+	secret, prefix, hash, err := utils.GenerateApiKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key.Prefix, key.Hash = prefix, hash
+	return secret, nil
+}
+
+// CreateApiKey creates an API key of the service account and returns the key itself,
+// which is not kept and cannot be recovered later.
+func (s *AuthService) CreateApiKey(ctx context.Context, key *domain.ApiKey) (string, error) {
+	if _, err := uuid.Parse(key.ServiceAccountID); err != nil {
+		return "", errs.ErrSvcAccountNotFound
+	}
+	if _, err := uuid.Parse(key.CreatedBy); err != nil {
+		return "", errs.ErrInvalidCredentials
+	}
+
+	if err := s.normalizeApiKeyScope(ctx, key); err != nil {
+		return "", err
+	}
+	lifetime := time.Until(key.ExpiresAt)
+	if lifetime <= 0 || lifetime > s.ApiKeyMaxLifetime {
+		return "", errs.ErrApiKeyLifetime
+	}
+
+	secret, err := issueApiKey(key)
+	if err != nil {
+		return "", err
+	}
+	if err = s.storage.CreateApiKey(ctx, key); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// RotateApiKey replaces the active key with a new key of the same service account, scope
+// and lifetime, and returns the new key, its secret and the replaced key. The replaced
+// key keeps working for the grace period so that clients can switch, or is revoked at
+// once if grace is zero.
+func (s *AuthService) RotateApiKey(ctx context.Context, keyId string, grace time.Duration, rotatedBy string) (
+	*domain.ApiKey, string, *domain.ApiKey, error) {
+
+	keyUUID, err := uuid.Parse(keyId)
+	if err != nil {
+		return nil, "", nil, errs.ErrApiKeyNotFound
+	}
+	rotatedByUUID, err := uuid.Parse(rotatedBy)
+	if err != nil {
+		return nil, "", nil, errs.ErrInvalidCredentials
+	}
+
+	current, err := s.storage.GetApiKey(ctx, keyUUID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	now := time.Now()
+	if !current.IsActive(now) {
+		return nil, "", nil, errs.ErrApiKeyNotActive
+	}
+
+	lifetime := min(current.ExpiresAt.Sub(current.CreatedAt), s.ApiKeyMaxLifetime)
+	key := &domain.ApiKey{
+		ServiceAccountID: current.ServiceAccountID,
+		Permissions:      current.Permissions,
+		KindIDs:          current.KindIDs,
+		AllowedIPs:       current.AllowedIPs,
+		CreatedBy:        rotatedBy,
+		ExpiresAt:        now.Add(lifetime),
+	}
+	secret, err := issueApiKey(key)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	var graceUntil time.Time
+	if grace > 0 {
+		graceUntil = now.Add(grace)
+	}
+	previous, err := s.storage.RotateApiKey(ctx, keyUUID, key, graceUntil, rotatedByUUID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return key, secret, previous, nil
+}
+
+// RevokeApiKey ends the active key before it expires.
+func (s *AuthService) RevokeApiKey(ctx context.Context, keyId, revokedBy string) (*domain.ApiKey, error) {
+	keyUUID, err := uuid.Parse(keyId)
+	if err != nil {
+		return nil, errs.ErrApiKeyNotFound
+	}
+	revokedByUUID, err := uuid.Parse(revokedBy)
+	if err != nil {
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	return s.storage.RevokeApiKey(ctx, keyUUID, revokedByUUID)
+}
+
+// GetApiKeys returns the keys of the service account, or of all service accounts if
+// accountId is empty, newest first.
+func (s *AuthService) GetApiKeys(ctx context.Context, accountId string, activeOnly bool) ([]*domain.ApiKey, error) {
+	var accountUUID *uuid.UUID
+	if accountId != "" {
+		parsed, err := uuid.Parse(accountId)
+		if err != nil {
+			return nil, errs.ErrInvalidCredentials
+		}
+		accountUUID = &parsed
+	}
+
+	return s.storage.GetApiKeys(ctx, accountUUID, activeOnly)
+}
+
+// AuthenticateApiKey returns the active key presented from the address and its service
+// account, and records the use of the key. A malformed, unknown or wrong key is reported
+// as ErrInvalidApiKey alike.
+func (s *AuthService) AuthenticateApiKey(ctx context.Context, key, ip string) (*domain.ApiKey, *domain.ServiceAccount, error) {
+	prefix, ok := utils.ApiKeyLookupPrefix(key)
+	if !ok {
+		return nil, nil, errs.ErrInvalidApiKey
+	}
+
+	apiKey, account, err := s.storage.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, errs.ErrApiKeyNotFound) {
+			return nil, nil, errs.ErrInvalidApiKey
+		}
+		return nil, nil, err
+	}
+	if subtle.ConstantTimeCompare(apiKey.Hash, utils.HashApiKey(key)) != 1 {
+		return nil, nil, errs.ErrInvalidApiKey
+	}
+	if !apiKey.IsActive(time.Now()) {
+		return nil, nil, errs.ErrApiKeyNotActive
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !apiKey.AllowsAddress(addr) {
+		return nil, nil, errs.ErrApiKeyAddress
+	}
+
+	if err = s.storage.TouchApiKey(ctx, uuid.MustParse(apiKey.ID), addr.Unmap().String()); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to record api key use",
+			slog.String("keyId", apiKey.ID),
+			logger.Err(err))
+	}
+	return apiKey, account, nil
+}
+
+// IssueClientToken exchanges the credentials of a service account for an access token
+// (OAuth2 client credentials) and returns it with its expiry and scope. The secret is an
+// active API key of the service account presented from an address the key allows; the
+// token carries the requested permissions of the key, or all of them if scope is empty,
+// its kinds and the addresses it allows, and expires with the key at the latest.
+func (s *AuthService) IssueClientToken(ctx context.Context, clientId, secret string, scope []string, ip string) (
+	*domain.ServiceAccount, string, time.Time, []string, error) {
+
+	key, account, err := s.AuthenticateApiKey(ctx, secret, ip)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidApiKey) || errors.Is(err, errs.ErrApiKeyNotActive) {
+			return nil, "", time.Time{}, nil, errs.ErrInvalidClient
+		}
+		return nil, "", time.Time{}, nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(account.ID), []byte(clientId)) != 1 {
+		return nil, "", time.Time{}, nil, errs.ErrInvalidClient
+	}
+
+	permissions := key.Permissions
+	if len(scope) > 0 {
+		for _, p := range scope {
+			if !slices.Contains(key.Permissions, p) {
+				return nil, "", time.Time{}, nil, errs.ErrInvalidScope
+			}
+		}
+		permissions = slices.Clone(scope)
+		slices.Sort(permissions)
+		permissions = slices.Compact(permissions)
+	}
+
+	expiresAt := time.Now().Add(s.AccessExpiration)
+	if key.ExpiresAt.Before(expiresAt) {
+		expiresAt = key.ExpiresAt
+	}
+	token, err := utils.GenerateClientJWT(account.ID, time.Until(expiresAt), s.jwtSecret, permissions, key.KindIDs, key.AllowedIPs, account.Name)
+	if err != nil {
+		return nil, "", time.Time{}, nil, fmt.Errorf("failed to generate client token: %w", err)
+	}
+	return account, token, expiresAt, permissions, nil
+}
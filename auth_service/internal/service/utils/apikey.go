@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// ApiKeyPrefix starts every API key, so that leaked keys are easy to recognize.
+const ApiKeyPrefix = "anx_"
+
+const (
+	apiKeyIDBytes     = 8
+	apiKeySecretBytes = 32
+	// apiKeyLookupLen is the length of the lookup prefix of a key: ApiKeyPrefix followed
+	// by the hex-encoded random key ID.
+	apiKeyLookupLen = len(ApiKeyPrefix) + 2*apiKeyIDBytes
+)
+
+// GenerateApiKey generates an API key of the form anx_<id>_<secret> and returns it with
+// its lookup prefix (anx_<id>) and its hash.
+func GenerateApiKey() (key string, prefix string, hash []byte, err error) {
+	id := make([]byte, apiKeyIDBytes)
+	if _, err = rand.Read(id); err != nil {
+		return "", "", nil, err
+	}
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", nil, err
+	}
+
+	prefix = ApiKeyPrefix + hex.EncodeToString(id)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, prefix, HashApiKey(key), nil
+}
+
+// ApiKeyLookupPrefix returns the lookup prefix of the key, or false if the key is
+// malformed.
+func ApiKeyLookupPrefix(key string) (string, bool) {
+	if len(key) <= apiKeyLookupLen+1 || !strings.HasPrefix(key, ApiKeyPrefix) || key[apiKeyLookupLen] != '_' {
+		return "", false
+	}
+	prefix := key[:apiKeyLookupLen]
+	if _, err := hex.DecodeString(prefix[len(ApiKeyPrefix):]); err != nil {
+		return "", false
+	}
+	return prefix, true
+}
+
+// HashApiKey returns the hash of the key that is stored instead of it. Keys are random
+// and long, so a fast hash is enough and keeps authentication cheap.
+func HashApiKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestGenerateApiKey(t *testing.T) {
+	key, prefix, hash, err := GenerateApiKey()
+	if err != nil {
+		t.Fatalf("GenerateApiKey() error = %v", err)
+	}
+
+	assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	assert.True(t, bytes.Equal(hash, HashApiKey(key)))
+
+	lookup, ok := ApiKeyLookupPrefix(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, lookup)
+
+	other, _, _, err := GenerateApiKey()
+	if err != nil {
+		t.Fatalf("GenerateApiKey() error = %v", err)
+	}
+	assert.NotEqual(t, key, other)
+}
+
+func TestApiKeyLookupPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		prefix string
+		ok     bool
+	}{
+		{
+			name:   "valid key",
+			key:    "anx_0123456789abcdef_c2VjcmV0",
+			prefix: "anx_0123456789abcdef",
+			ok:     true,
+		},
+		{name: "empty", key: ""},
+		{name: "other scheme", key: "key_0123456789abcdef_c2VjcmV0"},
+		{name: "no secret", key: "anx_0123456789abcdef_"},
+		{name: "short ID", key: "anx_0123456789_c2VjcmV0c2VjcmV0"},
+		{name: "non-hex ID", key: "anx_0123456789abcdeg_c2VjcmV0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, ok := ApiKeyLookupPrefix(tt.key)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.prefix, prefix)
+		})
+	}
+}
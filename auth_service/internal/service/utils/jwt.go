@@ -8,8 +8,8 @@ import (
 	"time"
 )
 
-func GenerateJWT(userID string, ttl time.Duration, jwtSecret string, isRefresh bool, roles []string, clearanceLevel int) (string, error) {
-	claims := jwt.MapClaims{
+func jwtClaims(userID string, ttl time.Duration, isRefresh bool, roles []string, clearanceLevel int) jwt.MapClaims {
+	return jwt.MapClaims{
 		"exp":             time.Now().Add(ttl).Unix(),
 		"iat":             time.Now().Unix(),
 		"sub":             userID,
@@ -17,7 +17,90 @@ func GenerateJWT(userID string, ttl time.Duration, jwtSecret string, isRefresh b
 		"roles":           roles,
 		"clearance_level": clearanceLevel,
 	}
+}
+
+func GenerateJWT(userID string, ttl time.Duration, jwtSecret string, isRefresh bool, roles []string, clearanceLevel int) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims(userID, ttl, isRefresh, roles, clearanceLevel))
+	return token.SignedString([]byte(jwtSecret))
+}
 
+// GenerateAccessJWT generates an access token. The permissions of the user's roles are
+// carried in the permissions claim, the kinds the user was granted access to beyond
+// their clearance level in the kind_grants claim, and the operations allowed on kinds by
+// kind operation grants in the kind_operations claim, keyed by kind ID.
+func GenerateAccessJWT(
+	userID string,
+	ttl time.Duration,
+	jwtSecret string,
+	roles []string,
+	permissions []string,
+	clearanceLevel int,
+	kindGrants []int,
+	kindOperations map[int][]string,
+) (string, error) {
+	claims := jwtClaims(userID, ttl, false, roles, clearanceLevel)
+	claims["permissions"] = permissions
+	if len(kindGrants) > 0 {
+		claims["kind_grants"] = kindGrants
+	}
+	if len(kindOperations) > 0 {
+		claims["kind_operations"] = kindOperations
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(jwtSecret))
+}
+
+// GenerateBreakGlassJWT generates an access token of a break-glass session. The session
+// ID and justification are carried in the break_glass and break_glass_justification
+// claims, and the kind operation grants in the kind_operations claim as in an access
+// token.
+func GenerateBreakGlassJWT(
+	userID string,
+	ttl time.Duration,
+	jwtSecret string,
+	roles []string,
+	permissions []string,
+	clearanceLevel int,
+	kindOperations map[int][]string,
+	sessionID string,
+	justification string,
+) (string, error) {
+	claims := jwtClaims(userID, ttl, false, roles, clearanceLevel)
+	claims["permissions"] = permissions
+	if len(kindOperations) > 0 {
+		claims["kind_operations"] = kindOperations
+	}
+	claims["break_glass"] = sessionID
+	claims["break_glass_justification"] = justification
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(jwtSecret))
+}
+
+// GenerateClientJWT generates an access token of a service account issued for OAuth2
+// client credentials. It has no roles and clearance level 0: the permissions and kinds
+// of the API key are carried in the permissions and kind_grants claims, the addresses
+// the key may be used from in the allowed_ips claim, and the name of the service account
+// in the service_account claim.
+func GenerateClientJWT(
+	accountID string,
+	ttl time.Duration,
+	jwtSecret string,
+	permissions []string,
+	kindGrants []int,
+	allowedIPs []string,
+	accountName string,
+) (string, error) {
+	claims := jwtClaims(accountID, ttl, false, []string{}, 0)
+	claims["permissions"] = permissions
+	if len(kindGrants) > 0 {
+		claims["kind_grants"] = kindGrants
+	}
+	if len(allowedIPs) > 0 {
+		claims["allowed_ips"] = allowedIPs
+	}
+	claims["service_account"] = accountName
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(jwtSecret))
 }
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
@@ -110,3 +111,96 @@ func TestParseJWT(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateBreakGlassJWT(t *testing.T) {
+	secret := "test-secret"
+	tokenStr, err := GenerateBreakGlassJWT("user123", time.Hour, secret, []string{"admin"},
+		[]string{"tokenizer.detokenize"}, 2, map[int][]string{5: {"view"}}, "session-1", "incident 42")
+	if err != nil {
+		t.Fatalf("GenerateBreakGlassJWT() error = %v", err)
+	}
+
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) { return []byte(secret), nil })
+	if err != nil {
+		t.Fatalf("jwt.Parse() error = %v", err)
+	}
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, "session-1", claims["break_glass"])
+	assert.Equal(t, "incident 42", claims["break_glass_justification"])
+	assert.Equal(t, []interface{}{"tokenizer.detokenize"}, claims["permissions"])
+	assert.Equal(t, map[string]interface{}{"5": []interface{}{"view"}}, claims["kind_operations"])
+	assert.Equal(t, false, claims["is_refresh"])
+
+	userID, isRefresh, _, roles, clearanceLevel, err := ParseJWT(tokenStr, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "user123", userID)
+	assert.False(t, isRefresh)
+	assert.Equal(t, []string{"admin"}, roles)
+	assert.Equal(t, 2, clearanceLevel)
+}
+
+func TestGenerateAccessJWT(t *testing.T) {
+	secret := "test-secret"
+	tokenStr, err := GenerateAccessJWT("user123", time.Hour, secret, []string{"operator"},
+		[]string{"mappings.read", "tokenizer.tokenize"}, 3, []int{4, 7},
+		map[int][]string{2: {"detokenize", "tokenize"}, 9: {"delete"}})
+	if err != nil {
+		t.Fatalf("GenerateAccessJWT() error = %v", err)
+	}
+
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) { return []byte(secret), nil })
+	if err != nil {
+		t.Fatalf("jwt.Parse() error = %v", err)
+	}
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, []interface{}{float64(4), float64(7)}, claims["kind_grants"])
+	assert.Equal(t, []interface{}{"mappings.read", "tokenizer.tokenize"}, claims["permissions"])
+	assert.Equal(t, map[string]interface{}{
+		"2": []interface{}{"detokenize", "tokenize"},
+		"9": []interface{}{"delete"},
+	}, claims["kind_operations"])
+
+	userID, isRefresh, _, roles, clearanceLevel, err := ParseJWT(tokenStr, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "user123", userID)
+	assert.False(t, isRefresh)
+	assert.Equal(t, []string{"operator"}, roles)
+	assert.Equal(t, 3, clearanceLevel)
+
+	tokenStr, err = GenerateAccessJWT("user123", time.Hour, secret, nil, nil, 1, nil, nil)
+	if err != nil {
+		t.Fatalf("GenerateAccessJWT() error = %v", err)
+	}
+	token, err = jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) { return []byte(secret), nil })
+	if err != nil {
+		t.Fatalf("jwt.Parse() error = %v", err)
+	}
+	assert.NotContains(t, token.Claims.(jwt.MapClaims), "kind_grants")
+	assert.NotContains(t, token.Claims.(jwt.MapClaims), "kind_operations")
+}
+
+func TestGenerateClientJWT(t *testing.T) {
+	secret := "test-secret"
+	tokenStr, err := GenerateClientJWT("account123", time.Hour, secret,
+		[]string{"tokenizer.tokenize"}, []int{4}, []string{"10.0.0.0/8"}, "billing-batch")
+	if err != nil {
+		t.Fatalf("GenerateClientJWT() error = %v", err)
+	}
+
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) { return []byte(secret), nil })
+	if err != nil {
+		t.Fatalf("jwt.Parse() error = %v", err)
+	}
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, "billing-batch", claims["service_account"])
+	assert.Equal(t, []interface{}{"tokenizer.tokenize"}, claims["permissions"])
+	assert.Equal(t, []interface{}{float64(4)}, claims["kind_grants"])
+	assert.Equal(t, []interface{}{"10.0.0.0/8"}, claims["allowed_ips"])
+
+	userID, isRefresh, _, roles, clearanceLevel, err := ParseJWT(tokenStr, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "account123", userID)
+	assert.False(t, isRefresh)
+	assert.Empty(t, roles)
+	assert.Equal(t, 0, clearanceLevel)
+}
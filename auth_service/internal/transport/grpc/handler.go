@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"errors"
+	"github.com/NeF2le/anonix/auth_service/internal/domain"
 	"github.com/NeF2le/anonix/auth_service/internal/ports"
 	"github.com/NeF2le/anonix/auth_service/internal/transport/helpers"
 	errs "github.com/NeF2le/anonix/common/errors"
@@ -10,8 +11,10 @@ import (
 	"github.com/NeF2le/anonix/common/logger"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log/slog"
 	"strings"
+	"time"
 )
 
 type grpcAuthHandler struct {
@@ -368,10 +371,7 @@ func (s *grpcAuthHandler) GetRolesList(ctx context.Context, req *auth_service.Ge
 
 	result := make([]*auth_service.Role, 0, len(roles))
 	for _, r := range roles {
-		result = append(result, &auth_service.Role{
-			Id:   int32(r.ID),
-			Name: r.Name,
-		})
+		result = append(result, roleToProto(r))
 	}
 
 	return &auth_service.GetRolesListResponse{
@@ -398,13 +398,858 @@ func (s *grpcAuthHandler) GetUserRoles(ctx context.Context, req *auth_service.Ge
 
 	result := make([]*auth_service.Role, 0, len(roles))
 	for _, r := range roles {
-		result = append(result, &auth_service.Role{
-			Id:   int32(r.ID),
-			Name: r.Name,
-		})
+		result = append(result, roleToProto(r))
 	}
 
 	return &auth_service.GetUserRolesResponse{
 		Roles: result,
 	}, nil
 }
+
+func roleToProto(role *domain.Role) *auth_service.Role {
+	return &auth_service.Role{
+		Id:          int32(role.ID),
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		BuiltIn:     role.BuiltIn,
+	}
+}
+
+// roleStatus translates the errors of role management to gRPC statuses.
+func roleStatus(ctx context.Context, roleId int32, err error, msg string) error {
+	switch {
+	case errors.Is(err, errs.ErrRoleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errs.ErrRoleAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errs.ErrRoleInUse):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errs.ErrRoleBuiltIn):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errs.ErrInvalidRoleName), errors.Is(err, errs.ErrInvalidRoleDesc),
+		errors.Is(err, errs.ErrRoleNoPermissions), errors.Is(err, errs.ErrUnknownPermission):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	logger.GetLoggerFromCtx(ctx).Error(ctx, msg,
+		slog.Int("roleId", int(roleId)),
+		logger.Err(err),
+	)
+	return status.Error(codes.Internal, msg)
+}
+
+func (s *grpcAuthHandler) GetPermissions(ctx context.Context, req *auth_service.GetPermissionsRequest) (
+	*auth_service.GetPermissionsResponse, error) {
+
+	permissions, err := s.auth.GetPermissions(ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get permissions",
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to get permissions")
+	}
+
+	result := make([]*auth_service.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		result = append(result, &auth_service.Permission{Name: p.Name, Description: p.Description})
+	}
+
+	return &auth_service.GetPermissionsResponse{Permissions: result}, nil
+}
+
+func (s *grpcAuthHandler) CreateRole(ctx context.Context, req *auth_service.CreateRoleRequest) (
+	*auth_service.CreateRoleResponse, error) {
+
+	role := &domain.Role{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Permissions: req.GetPermissions(),
+	}
+	if err := s.auth.CreateRole(ctx, role); err != nil {
+		return nil, roleStatus(ctx, 0, err, "failed to create role")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"role created",
+		slog.Int("roleId", role.ID),
+		slog.String("name", role.Name),
+	)
+
+	return &auth_service.CreateRoleResponse{Role: roleToProto(role)}, nil
+}
+
+func (s *grpcAuthHandler) UpdateRole(ctx context.Context, req *auth_service.UpdateRoleRequest) (
+	*auth_service.UpdateRoleResponse, error) {
+
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "role ID must be greater than zero")
+	}
+
+	role := &domain.Role{
+		ID:          int(req.GetId()),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Permissions: req.GetPermissions(),
+	}
+	userIds, err := s.auth.UpdateRole(ctx, role)
+	if err != nil {
+		return nil, roleStatus(ctx, req.GetId(), err, "failed to update role")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"role updated",
+		slog.Int("roleId", role.ID),
+		slog.Int("users", len(userIds)),
+	)
+
+	return &auth_service.UpdateRoleResponse{Role: roleToProto(role), UserIds: userIds}, nil
+}
+
+func (s *grpcAuthHandler) DeleteRole(ctx context.Context, req *auth_service.DeleteRoleRequest) (
+	*auth_service.DeleteRoleResponse, error) {
+
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "role ID must be greater than zero")
+	}
+
+	if err := s.auth.DeleteRole(ctx, int(req.GetId())); err != nil {
+		return nil, roleStatus(ctx, req.GetId(), err, "failed to delete role")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"role deleted",
+		slog.Int("roleId", int(req.GetId())),
+	)
+
+	return &auth_service.DeleteRoleResponse{}, nil
+}
+
+func (s *grpcAuthHandler) IssueAccessToken(ctx context.Context, req *auth_service.IssueAccessTokenRequest) (
+	*auth_service.IssueAccessTokenResponse, error) {
+
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID required")
+	}
+
+	var breakGlass *domain.BreakGlass
+	if bg := req.GetBreakGlass(); bg != nil {
+		if bg.GetId() == "" || bg.GetJustification() == "" {
+			return nil, status.Error(codes.InvalidArgument, "break-glass session ID and justification required")
+		}
+		if bg.GetExpiresAt() == nil || !bg.GetExpiresAt().AsTime().After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "break-glass session expired")
+		}
+		breakGlass = &domain.BreakGlass{
+			ID:            bg.GetId(),
+			Justification: bg.GetJustification(),
+			ExpiresAt:     bg.GetExpiresAt().AsTime(),
+		}
+	}
+
+	accessToken, expiresAt, err := s.auth.IssueAccessToken(ctx, req.GetUserId(), breakGlass)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, errs.ErrInvalidCredentials) {
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to issue access token",
+			slog.String("userId", req.GetUserId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to issue access token")
+	}
+
+	if breakGlass != nil {
+		logger.GetLoggerFromCtx(ctx).Info(ctx,
+			"break-glass access token issued",
+			slog.String("userId", req.GetUserId()),
+			slog.String("breakGlassId", breakGlass.ID),
+		)
+	}
+
+	return &auth_service.IssueAccessTokenResponse{
+		AccessToken: accessToken,
+		ExpiresAt:   timestamppb.New(expiresAt),
+	}, nil
+}
+
+func accessGrantToProto(grant *domain.AccessGrant) *auth_service.AccessGrant {
+	result := &auth_service.AccessGrant{
+		Id:             grant.ID,
+		UserId:         grant.UserID,
+		ClearanceLevel: int32(grant.ClearanceLevel),
+		KindId:         int32(grant.KindID),
+		Reason:         grant.Reason,
+		GrantedBy:      grant.GrantedBy,
+		CreatedAt:      timestamppb.New(grant.CreatedAt),
+		ExpiresAt:      timestamppb.New(grant.ExpiresAt),
+		RevokedBy:      grant.RevokedBy,
+		Active:         grant.IsActive(time.Now()),
+	}
+	if grant.RevokedAt != nil {
+		result.RevokedAt = timestamppb.New(*grant.RevokedAt)
+	}
+	return result
+}
+
+func (s *grpcAuthHandler) CreateAccessGrant(ctx context.Context, req *auth_service.CreateAccessGrantRequest) (
+	*auth_service.CreateAccessGrantResponse, error) {
+
+	if req.GetUserId() == "" || req.GetGrantedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID and granted by required")
+	}
+	if strings.TrimSpace(req.GetReason()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason required")
+	}
+	if req.GetExpiresAt() == nil {
+		return nil, status.Error(codes.InvalidArgument, "expires at required")
+	}
+
+	grant := &domain.AccessGrant{
+		UserID:         req.GetUserId(),
+		ClearanceLevel: int(req.GetClearanceLevel()),
+		KindID:         int(req.GetKindId()),
+		Reason:         req.GetReason(),
+		GrantedBy:      req.GetGrantedBy(),
+		ExpiresAt:      req.GetExpiresAt().AsTime(),
+	}
+
+	if err := s.auth.CreateAccessGrant(ctx, grant); err != nil {
+		switch {
+		case errors.Is(err, errs.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errs.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		case errors.Is(err, errs.ErrInvalidAccessGrant):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "invalid access grant",
+				slog.String("userId", req.GetUserId()),
+				logger.Err(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, errs.ErrAccessGrantDuration):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "invalid access grant duration",
+				slog.String("userId", req.GetUserId()),
+				logger.Err(err))
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to create access grant",
+			slog.String("userId", req.GetUserId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to create access grant")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"access grant created",
+		slog.String("userId", grant.UserID),
+		slog.String("grantId", grant.ID),
+		slog.String("grantedBy", grant.GrantedBy),
+	)
+
+	return &auth_service.CreateAccessGrantResponse{Grant: accessGrantToProto(grant)}, nil
+}
+
+func (s *grpcAuthHandler) RevokeAccessGrant(ctx context.Context, req *auth_service.RevokeAccessGrantRequest) (
+	*auth_service.RevokeAccessGrantResponse, error) {
+
+	if req.GetId() == "" || req.GetRevokedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "grant ID and revoked by required")
+	}
+
+	grant, err := s.auth.RevokeAccessGrant(ctx, req.GetId(), req.GetRevokedBy())
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrAccessGrantNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errs.ErrAccessGrantNotActive):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, errs.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to revoke access grant",
+			slog.String("grantId", req.GetId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to revoke access grant")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"access grant revoked",
+		slog.String("userId", grant.UserID),
+		slog.String("grantId", grant.ID),
+		slog.String("revokedBy", grant.RevokedBy),
+	)
+
+	return &auth_service.RevokeAccessGrantResponse{Grant: accessGrantToProto(grant)}, nil
+}
+
+func (s *grpcAuthHandler) GetAccessGrants(ctx context.Context, req *auth_service.GetAccessGrantsRequest) (
+	*auth_service.GetAccessGrantsResponse, error) {
+
+	grants, err := s.auth.GetAccessGrants(ctx, req.GetUserId(), req.GetActiveOnly())
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidCredentials) {
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get access grants",
+			slog.String("userId", req.GetUserId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to get access grants")
+	}
+
+	result := make([]*auth_service.AccessGrant, 0, len(grants))
+	for _, g := range grants {
+		result = append(result, accessGrantToProto(g))
+	}
+
+	return &auth_service.GetAccessGrantsResponse{Grants: result}, nil
+}
+
+func kindOperationGrantToProto(grant *domain.KindOperationGrant) *auth_service.KindOperationGrant {
+	return &auth_service.KindOperationGrant{
+		Id:         int32(grant.ID),
+		UserId:     grant.UserID,
+		RoleId:     int32(grant.RoleID),
+		KindId:     int32(grant.KindID),
+		Operations: grant.Operations,
+		GrantedBy:  grant.GrantedBy,
+		CreatedAt:  timestamppb.New(grant.CreatedAt),
+	}
+}
+
+func (s *grpcAuthHandler) SetKindOperationGrant(ctx context.Context, req *auth_service.SetKindOperationGrantRequest) (
+	*auth_service.SetKindOperationGrantResponse, error) {
+
+	if req.GetGrantedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "granted by required")
+	}
+
+	grant := &domain.KindOperationGrant{
+		UserID:     req.GetUserId(),
+		RoleID:     int(req.GetRoleId()),
+		KindID:     int(req.GetKindId()),
+		Operations: req.GetOperations(),
+		GrantedBy:  req.GetGrantedBy(),
+	}
+
+	userIds, err := s.auth.SetKindOperationGrant(ctx, grant)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrUserNotFound), errors.Is(err, errs.ErrRoleNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errs.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		case errors.Is(err, errs.ErrInvalidKindOpGrant):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "invalid kind operation grant",
+				slog.String("userId", req.GetUserId()),
+				slog.Int("roleId", int(req.GetRoleId())),
+				logger.Err(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to set kind operation grant",
+			slog.String("userId", req.GetUserId()),
+			slog.Int("roleId", int(req.GetRoleId())),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to set kind operation grant")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"kind operation grant set",
+		slog.Int("grantId", grant.ID),
+		slog.String("userId", grant.UserID),
+		slog.Int("roleId", grant.RoleID),
+		slog.Int("kindId", grant.KindID),
+		slog.String("grantedBy", grant.GrantedBy),
+	)
+
+	return &auth_service.SetKindOperationGrantResponse{Grant: kindOperationGrantToProto(grant), UserIds: userIds}, nil
+}
+
+func (s *grpcAuthHandler) DeleteKindOperationGrant(ctx context.Context, req *auth_service.DeleteKindOperationGrantRequest) (
+	*auth_service.DeleteKindOperationGrantResponse, error) {
+
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "grant ID required")
+	}
+
+	grant, userIds, err := s.auth.DeleteKindOperationGrant(ctx, int(req.GetId()))
+	if err != nil {
+		if errors.Is(err, errs.ErrKindOpGrantNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to delete kind operation grant",
+			slog.Int("grantId", int(req.GetId())),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to delete kind operation grant")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"kind operation grant deleted",
+		slog.Int("grantId", grant.ID),
+		slog.String("userId", grant.UserID),
+		slog.Int("roleId", grant.RoleID),
+	)
+
+	return &auth_service.DeleteKindOperationGrantResponse{Grant: kindOperationGrantToProto(grant), UserIds: userIds}, nil
+}
+
+func (s *grpcAuthHandler) GetKindOperationGrants(ctx context.Context, req *auth_service.GetKindOperationGrantsRequest) (
+	*auth_service.GetKindOperationGrantsResponse, error) {
+
+	grants, err := s.auth.GetKindOperationGrants(ctx, req.GetUserId(), int(req.GetRoleId()), req.GetEffective())
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidCredentials) {
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get kind operation grants",
+			slog.String("userId", req.GetUserId()),
+			slog.Int("roleId", int(req.GetRoleId())),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to get kind operation grants")
+	}
+
+	result := make([]*auth_service.KindOperationGrant, 0, len(grants))
+	for _, g := range grants {
+		result = append(result, kindOperationGrantToProto(g))
+	}
+
+	return &auth_service.GetKindOperationGrantsResponse{Grants: result}, nil
+}
+
+func serviceAccountToProto(account *domain.ServiceAccount) *auth_service.ServiceAccount {
+	return &auth_service.ServiceAccount{
+		Id:          account.ID,
+		Name:        account.Name,
+		Description: account.Description,
+		CreatedBy:   account.CreatedBy,
+		CreatedAt:   timestamppb.New(account.CreatedAt),
+	}
+}
+
+func apiKeyToProto(key *domain.ApiKey) *auth_service.ApiKey {
+	result := &auth_service.ApiKey{
+		Id:               key.ID,
+		ServiceAccountId: key.ServiceAccountID,
+		Prefix:           key.Prefix,
+		Permissions:      key.Permissions,
+		KindIds:          make([]int32, 0, len(key.KindIDs)),
+		AllowedIps:       key.AllowedIPs,
+		CreatedBy:        key.CreatedBy,
+		CreatedAt:        timestamppb.New(key.CreatedAt),
+		ExpiresAt:        timestamppb.New(key.ExpiresAt),
+		LastUsedIp:       key.LastUsedIP,
+		RevokedBy:        key.RevokedBy,
+		Active:           key.IsActive(time.Now()),
+	}
+	for _, id := range key.KindIDs {
+		result.KindIds = append(result.KindIds, int32(id))
+	}
+	if key.LastUsedAt != nil {
+		result.LastUsedAt = timestamppb.New(*key.LastUsedAt)
+	}
+	if key.RevokedAt != nil {
+		result.RevokedAt = timestamppb.New(*key.RevokedAt)
+	}
+	return result
+}
+
+func (s *grpcAuthHandler) CreateServiceAccount(ctx context.Context, req *auth_service.CreateServiceAccountRequest) (
+	*auth_service.CreateServiceAccountResponse, error) {
+
+	if req.GetName() == "" || req.GetCreatedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and created by required")
+	}
+
+	account := &domain.ServiceAccount{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		CreatedBy:   req.GetCreatedBy(),
+	}
+
+	if err := s.auth.CreateServiceAccount(ctx, account); err != nil {
+		switch {
+		case errors.Is(err, errs.ErrSvcAccountExists):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, errs.ErrInvalidSvcAccount), errors.Is(err, errs.ErrInvalidSvcAccountDesc):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, errs.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to create service account",
+			slog.String("name", req.GetName()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to create service account")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"service account created",
+		slog.String("serviceAccountId", account.ID),
+		slog.String("name", account.Name),
+		slog.String("createdBy", account.CreatedBy),
+	)
+
+	return &auth_service.CreateServiceAccountResponse{ServiceAccount: serviceAccountToProto(account)}, nil
+}
+
+func (s *grpcAuthHandler) DeleteServiceAccount(ctx context.Context, req *auth_service.DeleteServiceAccountRequest) (
+	*auth_service.DeleteServiceAccountResponse, error) {
+
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "service account ID required")
+	}
+
+	account, err := s.auth.DeleteServiceAccount(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, errs.ErrSvcAccountNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to delete service account",
+			slog.String("serviceAccountId", req.GetId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to delete service account")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"service account deleted",
+		slog.String("serviceAccountId", account.ID),
+		slog.String("name", account.Name),
+	)
+
+	return &auth_service.DeleteServiceAccountResponse{ServiceAccount: serviceAccountToProto(account)}, nil
+}
+
+func (s *grpcAuthHandler) GetServiceAccounts(ctx context.Context, _ *auth_service.GetServiceAccountsRequest) (
+	*auth_service.GetServiceAccountsResponse, error) {
+
+	accounts, err := s.auth.GetServiceAccounts(ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get service accounts",
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to get service accounts")
+	}
+
+	result := make([]*auth_service.ServiceAccount, 0, len(accounts))
+	for _, a := range accounts {
+		result = append(result, serviceAccountToProto(a))
+	}
+
+	return &auth_service.GetServiceAccountsResponse{ServiceAccounts: result}, nil
+}
+
+func (s *grpcAuthHandler) CreateApiKey(ctx context.Context, req *auth_service.CreateApiKeyRequest) (
+	*auth_service.CreateApiKeyResponse, error) {
+
+	if req.GetServiceAccountId() == "" || req.GetCreatedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "service account ID and created by required")
+	}
+	if req.GetExpiresAt() == nil {
+		return nil, status.Error(codes.InvalidArgument, "expires at required")
+	}
+
+	key := &domain.ApiKey{
+		ServiceAccountID: req.GetServiceAccountId(),
+		Permissions:      req.GetPermissions(),
+		KindIDs:          make([]int, 0, len(req.GetKindIds())),
+		AllowedIPs:       req.GetAllowedIps(),
+		CreatedBy:        req.GetCreatedBy(),
+		ExpiresAt:        req.GetExpiresAt().AsTime(),
+	}
+	for _, id := range req.GetKindIds() {
+		key.KindIDs = append(key.KindIDs, int(id))
+	}
+
+	secret, err := s.auth.CreateApiKey(ctx, key)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrSvcAccountNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errs.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		case errors.Is(err, errs.ErrInvalidApiKeyScope):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "invalid api key scope",
+				slog.String("serviceAccountId", req.GetServiceAccountId()),
+				logger.Err(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, errs.ErrApiKeyLifetime):
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to create api key",
+			slog.String("serviceAccountId", req.GetServiceAccountId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to create api key")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"api key created",
+		slog.String("keyId", key.ID),
+		slog.String("prefix", key.Prefix),
+		slog.String("serviceAccountId", key.ServiceAccountID),
+		slog.String("createdBy", key.CreatedBy),
+	)
+
+	return &auth_service.CreateApiKeyResponse{ApiKey: apiKeyToProto(key), Key: secret}, nil
+}
+
+func (s *grpcAuthHandler) RotateApiKey(ctx context.Context, req *auth_service.RotateApiKeyRequest) (
+	*auth_service.RotateApiKeyResponse, error) {
+
+	if req.GetId() == "" || req.GetRotatedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "api key ID and rotated by required")
+	}
+	if req.GetGraceSeconds() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "grace must not be negative")
+	}
+
+	key, secret, previous, err := s.auth.RotateApiKey(ctx, req.GetId(),
+		time.Duration(req.GetGraceSeconds())*time.Second, req.GetRotatedBy())
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrApiKeyNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errs.ErrApiKeyNotActive):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, errs.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to rotate api key",
+			slog.String("keyId", req.GetId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to rotate api key")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"api key rotated",
+		slog.String("previousKeyId", previous.ID),
+		slog.String("keyId", key.ID),
+		slog.String("serviceAccountId", key.ServiceAccountID),
+		slog.String("rotatedBy", req.GetRotatedBy()),
+	)
+
+	return &auth_service.RotateApiKeyResponse{
+		ApiKey:   apiKeyToProto(key),
+		Key:      secret,
+		Previous: apiKeyToProto(previous),
+	}, nil
+}
+
+func (s *grpcAuthHandler) RevokeApiKey(ctx context.Context, req *auth_service.RevokeApiKeyRequest) (
+	*auth_service.RevokeApiKeyResponse, error) {
+
+	if req.GetId() == "" || req.GetRevokedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "api key ID and revoked by required")
+	}
+
+	key, err := s.auth.RevokeApiKey(ctx, req.GetId(), req.GetRevokedBy())
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrApiKeyNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errs.ErrApiKeyNotActive):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, errs.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to revoke api key",
+			slog.String("keyId", req.GetId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to revoke api key")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"api key revoked",
+		slog.String("keyId", key.ID),
+		slog.String("serviceAccountId", key.ServiceAccountID),
+		slog.String("revokedBy", key.RevokedBy),
+	)
+
+	return &auth_service.RevokeApiKeyResponse{ApiKey: apiKeyToProto(key)}, nil
+}
+
+func (s *grpcAuthHandler) GetApiKeys(ctx context.Context, req *auth_service.GetApiKeysRequest) (
+	*auth_service.GetApiKeysResponse, error) {
+
+	keys, err := s.auth.GetApiKeys(ctx, req.GetServiceAccountId(), req.GetActiveOnly())
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidCredentials) {
+			return nil, status.Error(codes.InvalidArgument, "invalid service account ID")
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get api keys",
+			slog.String("serviceAccountId", req.GetServiceAccountId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to get api keys")
+	}
+
+	result := make([]*auth_service.ApiKey, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, apiKeyToProto(k))
+	}
+
+	return &auth_service.GetApiKeysResponse{ApiKeys: result}, nil
+}
+
+func (s *grpcAuthHandler) AuthenticateApiKey(ctx context.Context, req *auth_service.AuthenticateApiKeyRequest) (
+	*auth_service.AuthenticateApiKeyResponse, error) {
+
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.Unauthenticated, errs.ErrInvalidApiKey.Error())
+	}
+
+	key, account, err := s.auth.AuthenticateApiKey(ctx, req.GetKey(), req.GetIp())
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrInvalidApiKey), errors.Is(err, errs.ErrApiKeyNotActive):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, errs.ErrApiKeyAddress):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "api key used from a disallowed address",
+				slog.String("ip", req.GetIp()),
+				logger.Err(err))
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to authenticate api key",
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to authenticate api key")
+	}
+
+	return &auth_service.AuthenticateApiKeyResponse{
+		ApiKey:         apiKeyToProto(key),
+		ServiceAccount: serviceAccountToProto(account),
+	}, nil
+}
+
+func (s *grpcAuthHandler) IssueClientToken(ctx context.Context, req *auth_service.IssueClientTokenRequest) (
+	*auth_service.IssueClientTokenResponse, error) {
+
+	if req.GetClientId() == "" || req.GetClientSecret() == "" {
+		return nil, status.Error(codes.Unauthenticated, errs.ErrInvalidClient.Error())
+	}
+
+	account, token, expiresAt, scope, err := s.auth.IssueClientToken(ctx, req.GetClientId(), req.GetClientSecret(),
+		req.GetScope(), req.GetIp())
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrInvalidClient):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "invalid client credentials",
+				slog.String("clientId", req.GetClientId()))
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, errs.ErrApiKeyAddress):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "client credentials used from a disallowed address",
+				slog.String("clientId", req.GetClientId()),
+				slog.String("ip", req.GetIp()))
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, errs.ErrInvalidScope):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to issue client token",
+			slog.String("clientId", req.GetClientId()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to issue client token")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"client token issued",
+		slog.String("serviceAccountId", account.ID),
+	)
+	return &auth_service.IssueClientTokenResponse{
+		AccessToken:    token,
+		ExpiresAt:      timestamppb.New(expiresAt),
+		Scope:          scope,
+		ServiceAccount: serviceAccountToProto(account),
+	}, nil
+}
+
+func (s *grpcAuthHandler) LoginExternal(ctx context.Context, req *auth_service.LoginExternalRequest) (
+	*auth_service.LoginExternalResponse, error) {
+
+	identity := &domain.ExternalIdentity{
+		Issuer:  req.GetIssuer(),
+		Subject: req.GetSubject(),
+		Login:   req.GetLogin(),
+	}
+	userID, accessToken, refreshToken, created, err := s.auth.LoginExternal(ctx, identity, req.GetRoles(),
+		int(req.GetClearanceLevel()))
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrInvalidExternalLogin):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, errs.ErrRoleNotFound):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "external login mapped to unknown role",
+				slog.String("issuer", req.GetIssuer()),
+				slog.Any("roles", req.GetRoles()))
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errs.ErrExternalLoginTaken):
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "external login is taken by another user",
+				slog.String("issuer", req.GetIssuer()),
+				slog.String("login", req.GetLogin()))
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to login external user",
+			slog.String("issuer", req.GetIssuer()),
+			slog.String("login", req.GetLogin()),
+			logger.Err(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to login")
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"external user logged in successfully",
+		slog.String("issuer", req.GetIssuer()),
+		slog.String("login", identity.Login),
+		slog.String("userID", userID),
+		slog.Bool("created", created),
+	)
+	return &auth_service.LoginExternalResponse{
+		UserId:       userID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Created:      created,
+	}, nil
+}
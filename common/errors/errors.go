@@ -5,22 +5,67 @@ import (
 )
 
 var (
-	ErrMappingNotFound      = errors.New("mapping not found")
-	ErrMappingAlreadyExists = errors.New("mapping already exists")
-	ErrInvalidToken         = errors.New("invalid token")
-	ErrUserNotFound         = errors.New("user not found")
-	ErrUserAlreadyExists    = errors.New("user already exists")
-	ErrInvalidCredentials   = errors.New("invalid credentials")
-	ErrMappingExpired       = errors.New("mapping expired")
-	ErrTokenExpired         = errors.New("token expired")
-	ErrPasswordTooShort     = errors.New("password must be at least 8 characters long")
-	ErrPasswordTooLong      = errors.New("password must not exceed 72 bytes")
-	ErrPasswordNonASCII     = errors.New("password must contain only ASCII characters (no Cyrillic or Unicode)")
-	ErrPasswordWeak         = errors.New("password must contain at least one letter and one number")
-	ErrUnauthorized         = errors.New("unauthorized")
-	ErrInvalidAccessToken   = errors.New("invalid access token")
-	ErrNotFound             = errors.New("not found")
-	ErrKindAlreadyExists    = errors.New("kind already exists")
-	ErrKindNotFound         = errors.New("kind not found")
-	ErrKindInUse            = errors.New("kind is in use")
+	ErrMappingNotFound       = errors.New("mapping not found")
+	ErrMappingAlreadyExists  = errors.New("mapping already exists")
+	ErrInvalidToken          = errors.New("invalid token")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrUserAlreadyExists     = errors.New("user already exists")
+	ErrInvalidCredentials    = errors.New("invalid credentials")
+	ErrMappingExpired        = errors.New("mapping expired")
+	ErrTokenExpired          = errors.New("token expired")
+	ErrPasswordTooShort      = errors.New("password must be at least 8 characters long")
+	ErrPasswordTooLong       = errors.New("password must not exceed 72 bytes")
+	ErrPasswordNonASCII      = errors.New("password must contain only ASCII characters (no Cyrillic or Unicode)")
+	ErrPasswordWeak          = errors.New("password must contain at least one letter and one number")
+	ErrUnauthorized          = errors.New("unauthorized")
+	ErrInvalidAccessToken    = errors.New("invalid access token")
+	ErrNotFound              = errors.New("not found")
+	ErrKindAlreadyExists     = errors.New("kind already exists")
+	ErrKindNotFound          = errors.New("kind not found")
+	ErrKindInUse             = errors.New("kind is in use")
+	ErrPurposeNotFound       = errors.New("purpose not found")
+	ErrPurposeAlreadyExists  = errors.New("purpose already exists")
+	ErrPurposeInUse          = errors.New("purpose is in use")
+	ErrMappingOnHold         = errors.New("mapping is under legal hold")
+	ErrSubjectChanged        = errors.New("subject has mappings not checked for erasure")
+	ErrLegalHoldNotFound     = errors.New("legal hold not found")
+	ErrLegalHoldExists       = errors.New("legal hold already exists")
+	ErrLegalHoldReleased     = errors.New("legal hold already released")
+	ErrApprovalNotFound      = errors.New("approval not found")
+	ErrApprovalNotPending    = errors.New("approval is not pending")
+	ErrApprovalNotApproved   = errors.New("approval is not approved")
+	ErrApprovalExpired       = errors.New("approval expired")
+	ErrSelfApproval          = errors.New("requester cannot approve own request")
+	ErrBreakGlassNotFound    = errors.New("break-glass session not found")
+	ErrBreakGlassActive      = errors.New("break-glass session already active")
+	ErrBreakGlassNotActive   = errors.New("break-glass session is not active")
+	ErrBreakGlassSelfReview  = errors.New("user cannot acknowledge own break-glass session")
+	ErrAccessGrantNotFound   = errors.New("access grant not found")
+	ErrAccessGrantNotActive  = errors.New("access grant is not active")
+	ErrInvalidAccessGrant    = errors.New("access grant must raise the clearance level or name a kind")
+	ErrAccessGrantDuration   = errors.New("access grant must expire in the future and within the maximum duration")
+	ErrRoleNotFound          = errors.New("role not found")
+	ErrRoleAlreadyExists     = errors.New("role already exists")
+	ErrRoleInUse             = errors.New("role is assigned to users")
+	ErrRoleBuiltIn           = errors.New("built-in role cannot be changed")
+	ErrInvalidRoleName       = errors.New("role name must be 2 to 50 lowercase latin letters, digits, '_' or '-'")
+	ErrInvalidRoleDesc       = errors.New("role description must not exceed 200 characters")
+	ErrUnknownPermission     = errors.New("unknown permission")
+	ErrRoleNoPermissions     = errors.New("role must have at least one permission")
+	ErrInvalidKindOpGrant    = errors.New("kind operation grant must name a kind, either a user or a role, and known operations")
+	ErrKindOpGrantNotFound   = errors.New("kind operation grant not found")
+	ErrSvcAccountNotFound    = errors.New("service account not found")
+	ErrSvcAccountExists      = errors.New("service account already exists")
+	ErrInvalidSvcAccount     = errors.New("service account name must be 2 to 50 lowercase latin letters, digits, '_' or '-'")
+	ErrInvalidSvcAccountDesc = errors.New("service account description must not exceed 200 characters")
+	ErrInvalidApiKey         = errors.New("invalid api key")
+	ErrInvalidApiKeyScope    = errors.New("api key must have known permissions, valid kinds and valid addresses")
+	ErrApiKeyLifetime        = errors.New("api key must expire in the future and within the maximum lifetime")
+	ErrApiKeyNotFound        = errors.New("api key not found")
+	ErrApiKeyNotActive       = errors.New("api key is not active")
+	ErrApiKeyAddress         = errors.New("api key is not allowed from this address")
+	ErrInvalidClient         = errors.New("invalid client credentials")
+	ErrInvalidScope          = errors.New("scope must be a subset of the api key permissions")
+	ErrInvalidExternalLogin  = errors.New("external login must have an issuer, a subject, a login of at most 254 characters and a clearance level from 1 to 4")
+	ErrExternalLoginTaken    = errors.New("login is taken by another user")
 )
@@ -108,18 +108,23 @@ func (x *Kind) GetShortName() string {
 }
 
 type MappingModel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CipherText    []byte                 `protobuf:"bytes,2,opt,name=cipher_text,json=cipherText,proto3" json:"cipher_text,omitempty"`
-	DekWrapped    []byte                 `protobuf:"bytes,3,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
-	TokenTtl      *durationpb.Duration   `protobuf:"bytes,4,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Deterministic bool                   `protobuf:"varint,6,opt,name=deterministic,proto3" json:"deterministic,omitempty"`
-	Kind          *Kind                  `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`
-	Token         string                 `protobuf:"bytes,8,opt,name=token,proto3" json:"token,omitempty"`
-	AlgoName      string                 `protobuf:"bytes,9,opt,name=algo_name,json=algoName,proto3" json:"algo_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CipherText       []byte                 `protobuf:"bytes,2,opt,name=cipher_text,json=cipherText,proto3" json:"cipher_text,omitempty"`
+	DekWrapped       []byte                 `protobuf:"bytes,3,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
+	TokenTtl         *durationpb.Duration   `protobuf:"bytes,4,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Deterministic    bool                   `protobuf:"varint,6,opt,name=deterministic,proto3" json:"deterministic,omitempty"`
+	Kind             *Kind                  `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`
+	Token            string                 `protobuf:"bytes,8,opt,name=token,proto3" json:"token,omitempty"`
+	AlgoName         string                 `protobuf:"bytes,9,opt,name=algo_name,json=algoName,proto3" json:"algo_name,omitempty"`
+	SubjectRef       string                 `protobuf:"bytes,10,opt,name=subject_ref,json=subjectRef,proto3" json:"subject_ref,omitempty"`
+	Purposes         []string               `protobuf:"bytes,11,rep,name=purposes,proto3" json:"purposes,omitempty"`
+	LegalBasis       string                 `protobuf:"bytes,12,opt,name=legal_basis,json=legalBasis,proto3" json:"legal_basis,omitempty"`
+	ConsentRef       string                 `protobuf:"bytes,13,opt,name=consent_ref,json=consentRef,proto3" json:"consent_ref,omitempty"`
+	ConsentExpiresAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=consent_expires_at,json=consentExpiresAt,proto3" json:"consent_expires_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *MappingModel) Reset() {
@@ -215,17 +220,57 @@ func (x *MappingModel) GetAlgoName() string {
 	return ""
 }
 
+func (x *MappingModel) GetSubjectRef() string {
+	if x != nil {
+		return x.SubjectRef
+	}
+	return ""
+}
+
+func (x *MappingModel) GetPurposes() []string {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+func (x *MappingModel) GetLegalBasis() string {
+	if x != nil {
+		return x.LegalBasis
+	}
+	return ""
+}
+
+func (x *MappingModel) GetConsentRef() string {
+	if x != nil {
+		return x.ConsentRef
+	}
+	return ""
+}
+
+func (x *MappingModel) GetConsentExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConsentExpiresAt
+	}
+	return nil
+}
+
 type CreateMappingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CipherText    []byte                 `protobuf:"bytes,1,opt,name=cipher_text,json=cipherText,proto3" json:"cipher_text,omitempty"`
-	DekWrapped    []byte                 `protobuf:"bytes,2,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
-	TokenTtl      *durationpb.Duration   `protobuf:"bytes,3,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"`
-	Deterministic bool                   `protobuf:"varint,4,opt,name=deterministic,proto3" json:"deterministic,omitempty"`
-	Kind          *Kind                  `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
-	Token         string                 `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
-	AlgoName      string                 `protobuf:"bytes,7,opt,name=algo_name,json=algoName,proto3" json:"algo_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CipherText       []byte                 `protobuf:"bytes,1,opt,name=cipher_text,json=cipherText,proto3" json:"cipher_text,omitempty"`
+	DekWrapped       []byte                 `protobuf:"bytes,2,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
+	TokenTtl         *durationpb.Duration   `protobuf:"bytes,3,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"`
+	Deterministic    bool                   `protobuf:"varint,4,opt,name=deterministic,proto3" json:"deterministic,omitempty"`
+	Kind             *Kind                  `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	Token            string                 `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	AlgoName         string                 `protobuf:"bytes,7,opt,name=algo_name,json=algoName,proto3" json:"algo_name,omitempty"`
+	SubjectRef       string                 `protobuf:"bytes,8,opt,name=subject_ref,json=subjectRef,proto3" json:"subject_ref,omitempty"`
+	Purposes         []string               `protobuf:"bytes,9,rep,name=purposes,proto3" json:"purposes,omitempty"`
+	LegalBasis       string                 `protobuf:"bytes,10,opt,name=legal_basis,json=legalBasis,proto3" json:"legal_basis,omitempty"`
+	ConsentRef       string                 `protobuf:"bytes,11,opt,name=consent_ref,json=consentRef,proto3" json:"consent_ref,omitempty"`
+	ConsentExpiresAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=consent_expires_at,json=consentExpiresAt,proto3" json:"consent_expires_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *CreateMappingRequest) Reset() {
@@ -307,6 +352,41 @@ func (x *CreateMappingRequest) GetAlgoName() string {
 	return ""
 }
 
+func (x *CreateMappingRequest) GetSubjectRef() string {
+	if x != nil {
+		return x.SubjectRef
+	}
+	return ""
+}
+
+func (x *CreateMappingRequest) GetPurposes() []string {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+func (x *CreateMappingRequest) GetLegalBasis() string {
+	if x != nil {
+		return x.LegalBasis
+	}
+	return ""
+}
+
+func (x *CreateMappingRequest) GetConsentRef() string {
+	if x != nil {
+		return x.ConsentRef
+	}
+	return ""
+}
+
+func (x *CreateMappingRequest) GetConsentExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConsentExpiresAt
+	}
+	return nil
+}
+
 type GetMappingByTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -398,6 +478,7 @@ func (x *CreateMappingResponse) GetMappingModel() *MappingModel {
 type DeleteMappingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -439,6 +520,13 @@ func (x *DeleteMappingRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteMappingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type DeleteMappingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -660,9 +748,10 @@ func (x *GetMappingResponse) GetMappingModel() *MappingModel {
 }
 
 type GetMappingListRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaxAccessLevel int32                  `protobuf:"varint,1,opt,name=max_access_level,json=maxAccessLevel,proto3" json:"max_access_level,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetMappingListRequest) Reset() {
@@ -695,6 +784,13 @@ func (*GetMappingListRequest) Descriptor() ([]byte, []int) {
 	return file_api_mapping_proto_rawDescGZIP(), []int{11}
 }
 
+func (x *GetMappingListRequest) GetMaxAccessLevel() int32 {
+	if x != nil {
+		return x.MaxAccessLevel
+	}
+	return 0
+}
+
 type GetMappingListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MappingModels []*MappingModel        `protobuf:"bytes,1,rep,name=mappingModels,proto3" json:"mappingModels,omitempty"`
@@ -1331,6 +1427,17 @@ type AuditLogEntry struct {
 	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
 	Kind          *Kind                  `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Purpose       string                 `protobuf:"bytes,7,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	LegalHold     string                 `protobuf:"bytes,8,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	Seq           int64                  `protobuf:"varint,9,opt,name=seq,proto3" json:"seq,omitempty"`
+	PrevHash      string                 `protobuf:"bytes,10,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	RowHash       string                 `protobuf:"bytes,11,opt,name=row_hash,json=rowHash,proto3" json:"row_hash,omitempty"`
+	Outcome       string                 `protobuf:"bytes,12,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Reason        string                 `protobuf:"bytes,13,opt,name=reason,proto3" json:"reason,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,14,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,15,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	RequestId     string                 `protobuf:"bytes,16,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Justification string                 `protobuf:"bytes,17,opt,name=justification,proto3" json:"justification,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1407,12 +1514,96 @@ func (x *AuditLogEntry) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AuditLogEntry) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetLegalHold() string {
+	if x != nil {
+		return x.LegalHold
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditLogEntry) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetRowHash() string {
+	if x != nil {
+		return x.RowHash
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
 type CreateAuditLogRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
 	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
 	KindId        int32                  `protobuf:"varint,4,opt,name=kind_id,json=kindId,proto3" json:"kind_id,omitempty"`
+	Purpose       string                 `protobuf:"bytes,5,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Outcome       string                 `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,8,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,9,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	RequestId     string                 `protobuf:"bytes,10,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Justification string                 `protobuf:"bytes,11,opt,name=justification,proto3" json:"justification,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1475,6 +1666,55 @@ func (x *CreateAuditLogRequest) GetKindId() int32 {
 	return 0
 }
 
+func (x *CreateAuditLogRequest) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *CreateAuditLogRequest) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *CreateAuditLogRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateAuditLogRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *CreateAuditLogRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *CreateAuditLogRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *CreateAuditLogRequest) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
 type CreateAuditLogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Entry         *AuditLogEntry         `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
@@ -1519,26 +1759,36 @@ func (x *CreateAuditLogResponse) GetEntry() *AuditLogEntry {
 	return nil
 }
 
-type GetAuditLogListRequest struct {
+type AuditLogFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	KindId        int32                  `protobuf:"varint,4,opt,name=kind_id,json=kindId,proto3" json:"kind_id,omitempty"`
+	AccessLevel   int32                  `protobuf:"varint,5,opt,name=access_level,json=accessLevel,proto3" json:"access_level,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
+	Outcome       string                 `protobuf:"bytes,8,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	RequestId     string                 `protobuf:"bytes,9,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	BreakGlass    bool                   `protobuf:"varint,10,opt,name=break_glass,json=breakGlass,proto3" json:"break_glass,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAuditLogListRequest) Reset() {
-	*x = GetAuditLogListRequest{}
+func (x *AuditLogFilter) Reset() {
+	*x = AuditLogFilter{}
 	mi := &file_api_mapping_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAuditLogListRequest) String() string {
+func (x *AuditLogFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAuditLogListRequest) ProtoMessage() {}
+func (*AuditLogFilter) ProtoMessage() {}
 
-func (x *GetAuditLogListRequest) ProtoReflect() protoreflect.Message {
+func (x *AuditLogFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_api_mapping_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1550,32 +1800,106 @@ func (x *GetAuditLogListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAuditLogListRequest.ProtoReflect.Descriptor instead.
-func (*GetAuditLogListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuditLogFilter.ProtoReflect.Descriptor instead.
+func (*AuditLogFilter) Descriptor() ([]byte, []int) {
 	return file_api_mapping_proto_rawDescGZIP(), []int{28}
 }
 
-type GetAuditLogListResponse struct {
+func (x *AuditLogFilter) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditLogFilter) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditLogFilter) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AuditLogFilter) GetKindId() int32 {
+	if x != nil {
+		return x.KindId
+	}
+	return 0
+}
+
+func (x *AuditLogFilter) GetAccessLevel() int32 {
+	if x != nil {
+		return x.AccessLevel
+	}
+	return 0
+}
+
+func (x *AuditLogFilter) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *AuditLogFilter) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *AuditLogFilter) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuditLogFilter) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditLogFilter) GetBreakGlass() bool {
+	if x != nil {
+		return x.BreakGlass
+	}
+	return false
+}
+
+type GetAuditLogListRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entries       []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Filter        *AuditLogFilter        `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	SortBy        string                 `protobuf:"bytes,2,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Ascending     bool                   `protobuf:"varint,3,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAuditLogListResponse) Reset() {
-	*x = GetAuditLogListResponse{}
+func (x *GetAuditLogListRequest) Reset() {
+	*x = GetAuditLogListRequest{}
 	mi := &file_api_mapping_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAuditLogListResponse) String() string {
+func (x *GetAuditLogListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAuditLogListResponse) ProtoMessage() {}
+func (*GetAuditLogListRequest) ProtoMessage() {}
 
-func (x *GetAuditLogListResponse) ProtoReflect() protoreflect.Message {
+func (x *GetAuditLogListRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_mapping_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1587,41 +1911,1503 @@ func (x *GetAuditLogListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAuditLogListResponse.ProtoReflect.Descriptor instead.
-func (*GetAuditLogListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAuditLogListRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditLogListRequest) Descriptor() ([]byte, []int) {
 	return file_api_mapping_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GetAuditLogListResponse) GetEntries() []*AuditLogEntry {
+func (x *GetAuditLogListRequest) GetFilter() *AuditLogFilter {
 	if x != nil {
-		return x.Entries
+		return x.Filter
 	}
 	return nil
 }
 
-type UpdateMappingDekRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	DekWrapped    []byte                 `protobuf:"bytes,2,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetAuditLogListRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *GetAuditLogListRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *GetAuditLogListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAuditLogListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetAuditLogListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogListResponse) Reset() {
+	*x = GetAuditLogListResponse{}
+	mi := &file_api_mapping_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogListResponse) ProtoMessage() {}
+
+func (x *GetAuditLogListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogListResponse.ProtoReflect.Descriptor instead.
+func (*GetAuditLogListResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetAuditLogListResponse) GetEntries() []*AuditLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAuditLogListResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type UpdateMappingDekRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DekWrapped    []byte                 `protobuf:"bytes,2,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateMappingDekRequest) Reset() {
 	*x = UpdateMappingDekRequest{}
-	mi := &file_api_mapping_proto_msgTypes[30]
+	mi := &file_api_mapping_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMappingDekRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMappingDekRequest) ProtoMessage() {}
+
+func (x *UpdateMappingDekRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMappingDekRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMappingDekRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateMappingDekRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateMappingDekRequest) GetDekWrapped() []byte {
+	if x != nil {
+		return x.DekWrapped
+	}
+	return nil
+}
+
+type UpdateMappingDekResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMappingDekResponse) Reset() {
+	*x = UpdateMappingDekResponse{}
+	mi := &file_api_mapping_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMappingDekResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMappingDekResponse) ProtoMessage() {}
+
+func (x *UpdateMappingDekResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMappingDekResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMappingDekResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{32}
+}
+
+type UpdateMappingCryptoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DekWrapped    []byte                 `protobuf:"bytes,2,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
+	CipherText    []byte                 `protobuf:"bytes,3,opt,name=cipher_text,json=cipherText,proto3" json:"cipher_text,omitempty"`
+	AlgoName      string                 `protobuf:"bytes,4,opt,name=algo_name,json=algoName,proto3" json:"algo_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMappingCryptoRequest) Reset() {
+	*x = UpdateMappingCryptoRequest{}
+	mi := &file_api_mapping_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMappingCryptoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMappingCryptoRequest) ProtoMessage() {}
+
+func (x *UpdateMappingCryptoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMappingCryptoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMappingCryptoRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateMappingCryptoRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateMappingCryptoRequest) GetDekWrapped() []byte {
+	if x != nil {
+		return x.DekWrapped
+	}
+	return nil
+}
+
+func (x *UpdateMappingCryptoRequest) GetCipherText() []byte {
+	if x != nil {
+		return x.CipherText
+	}
+	return nil
+}
+
+func (x *UpdateMappingCryptoRequest) GetAlgoName() string {
+	if x != nil {
+		return x.AlgoName
+	}
+	return ""
+}
+
+type UpdateMappingCryptoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMappingCryptoResponse) Reset() {
+	*x = UpdateMappingCryptoResponse{}
+	mi := &file_api_mapping_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMappingCryptoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMappingCryptoResponse) ProtoMessage() {}
+
+func (x *UpdateMappingCryptoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMappingCryptoResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMappingCryptoResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{34}
+}
+
+type ListSubjectMappingsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubjectRef     string                 `protobuf:"bytes,1,opt,name=subject_ref,json=subjectRef,proto3" json:"subject_ref,omitempty"`
+	MaxAccessLevel int32                  `protobuf:"varint,2,opt,name=max_access_level,json=maxAccessLevel,proto3" json:"max_access_level,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSubjectMappingsRequest) Reset() {
+	*x = ListSubjectMappingsRequest{}
+	mi := &file_api_mapping_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubjectMappingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubjectMappingsRequest) ProtoMessage() {}
+
+func (x *ListSubjectMappingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubjectMappingsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubjectMappingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListSubjectMappingsRequest) GetSubjectRef() string {
+	if x != nil {
+		return x.SubjectRef
+	}
+	return ""
+}
+
+func (x *ListSubjectMappingsRequest) GetMaxAccessLevel() int32 {
+	if x != nil {
+		return x.MaxAccessLevel
+	}
+	return 0
+}
+
+type ListSubjectMappingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MappingModels []*MappingModel        `protobuf:"bytes,1,rep,name=mappingModels,proto3" json:"mappingModels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubjectMappingsResponse) Reset() {
+	*x = ListSubjectMappingsResponse{}
+	mi := &file_api_mapping_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubjectMappingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubjectMappingsResponse) ProtoMessage() {}
+
+func (x *ListSubjectMappingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubjectMappingsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubjectMappingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListSubjectMappingsResponse) GetMappingModels() []*MappingModel {
+	if x != nil {
+		return x.MappingModels
+	}
+	return nil
+}
+
+type EraseSubjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubjectRef    string                 `protobuf:"bytes,1,opt,name=subject_ref,json=subjectRef,proto3" json:"subject_ref,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MappingIds    []string               `protobuf:"bytes,3,rep,name=mapping_ids,json=mappingIds,proto3" json:"mapping_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseSubjectRequest) Reset() {
+	*x = EraseSubjectRequest{}
+	mi := &file_api_mapping_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseSubjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseSubjectRequest) ProtoMessage() {}
+
+func (x *EraseSubjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseSubjectRequest.ProtoReflect.Descriptor instead.
+func (*EraseSubjectRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *EraseSubjectRequest) GetSubjectRef() string {
+	if x != nil {
+		return x.SubjectRef
+	}
+	return ""
+}
+
+func (x *EraseSubjectRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EraseSubjectRequest) GetMappingIds() []string {
+	if x != nil {
+		return x.MappingIds
+	}
+	return nil
+}
+
+type ErasureReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubjectRef    string                 `protobuf:"bytes,1,opt,name=subject_ref,json=subjectRef,proto3" json:"subject_ref,omitempty"`
+	ErasedBy      string                 `protobuf:"bytes,2,opt,name=erased_by,json=erasedBy,proto3" json:"erased_by,omitempty"`
+	ErasedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	Erased        []*MappingModel        `protobuf:"bytes,4,rep,name=erased,proto3" json:"erased,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErasureReport) Reset() {
+	*x = ErasureReport{}
+	mi := &file_api_mapping_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErasureReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErasureReport) ProtoMessage() {}
+
+func (x *ErasureReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErasureReport.ProtoReflect.Descriptor instead.
+func (*ErasureReport) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ErasureReport) GetSubjectRef() string {
+	if x != nil {
+		return x.SubjectRef
+	}
+	return ""
+}
+
+func (x *ErasureReport) GetErasedBy() string {
+	if x != nil {
+		return x.ErasedBy
+	}
+	return ""
+}
+
+func (x *ErasureReport) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+func (x *ErasureReport) GetErased() []*MappingModel {
+	if x != nil {
+		return x.Erased
+	}
+	return nil
+}
+
+type EraseSubjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *ErasureReport         `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseSubjectResponse) Reset() {
+	*x = EraseSubjectResponse{}
+	mi := &file_api_mapping_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseSubjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseSubjectResponse) ProtoMessage() {}
+
+func (x *EraseSubjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseSubjectResponse.ProtoReflect.Descriptor instead.
+func (*EraseSubjectResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *EraseSubjectResponse) GetReport() *ErasureReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type Purpose struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Purpose) Reset() {
+	*x = Purpose{}
+	mi := &file_api_mapping_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Purpose) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Purpose) ProtoMessage() {}
+
+func (x *Purpose) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Purpose.ProtoReflect.Descriptor instead.
+func (*Purpose) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Purpose) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Purpose) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Purpose) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Purpose) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreatePurposeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePurposeRequest) Reset() {
+	*x = CreatePurposeRequest{}
+	mi := &file_api_mapping_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePurposeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePurposeRequest) ProtoMessage() {}
+
+func (x *CreatePurposeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePurposeRequest.ProtoReflect.Descriptor instead.
+func (*CreatePurposeRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreatePurposeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePurposeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CreatePurposeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purpose       *Purpose               `protobuf:"bytes,1,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePurposeResponse) Reset() {
+	*x = CreatePurposeResponse{}
+	mi := &file_api_mapping_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePurposeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePurposeResponse) ProtoMessage() {}
+
+func (x *CreatePurposeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePurposeResponse.ProtoReflect.Descriptor instead.
+func (*CreatePurposeResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreatePurposeResponse) GetPurpose() *Purpose {
+	if x != nil {
+		return x.Purpose
+	}
+	return nil
+}
+
+type ListPurposesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPurposesRequest) Reset() {
+	*x = ListPurposesRequest{}
+	mi := &file_api_mapping_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurposesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurposesRequest) ProtoMessage() {}
+
+func (x *ListPurposesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurposesRequest.ProtoReflect.Descriptor instead.
+func (*ListPurposesRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{43}
+}
+
+type ListPurposesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purposes      []*Purpose             `protobuf:"bytes,1,rep,name=purposes,proto3" json:"purposes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPurposesResponse) Reset() {
+	*x = ListPurposesResponse{}
+	mi := &file_api_mapping_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurposesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurposesResponse) ProtoMessage() {}
+
+func (x *ListPurposesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurposesResponse.ProtoReflect.Descriptor instead.
+func (*ListPurposesResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListPurposesResponse) GetPurposes() []*Purpose {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+type DeletePurposeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePurposeRequest) Reset() {
+	*x = DeletePurposeRequest{}
+	mi := &file_api_mapping_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePurposeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePurposeRequest) ProtoMessage() {}
+
+func (x *DeletePurposeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePurposeRequest.ProtoReflect.Descriptor instead.
+func (*DeletePurposeRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeletePurposeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeletePurposeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePurposeResponse) Reset() {
+	*x = DeletePurposeResponse{}
+	mi := &file_api_mapping_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePurposeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePurposeResponse) ProtoMessage() {}
+
+func (x *DeletePurposeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePurposeResponse.ProtoReflect.Descriptor instead.
+func (*DeletePurposeResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{46}
+}
+
+type LegalHoldTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegalHoldTarget) Reset() {
+	*x = LegalHoldTarget{}
+	mi := &file_api_mapping_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegalHoldTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegalHoldTarget) ProtoMessage() {}
+
+func (x *LegalHoldTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegalHoldTarget.ProtoReflect.Descriptor instead.
+func (*LegalHoldTarget) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *LegalHoldTarget) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LegalHoldTarget) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type LegalHold struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Targets       []*LegalHoldTarget     `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ReleasedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"`
+	ReleasedBy    string                 `protobuf:"bytes,10,opt,name=released_by,json=releasedBy,proto3" json:"released_by,omitempty"`
+	Active        bool                   `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegalHold) Reset() {
+	*x = LegalHold{}
+	mi := &file_api_mapping_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegalHold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegalHold) ProtoMessage() {}
+
+func (x *LegalHold) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegalHold.ProtoReflect.Descriptor instead.
+func (*LegalHold) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *LegalHold) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LegalHold) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LegalHold) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LegalHold) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *LegalHold) GetTargets() []*LegalHoldTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *LegalHold) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *LegalHold) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LegalHold) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LegalHold) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+func (x *LegalHold) GetReleasedBy() string {
+	if x != nil {
+		return x.ReleasedBy
+	}
+	return ""
+}
+
+func (x *LegalHold) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type CreateLegalHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Owner         string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Targets       []*LegalHoldTarget     `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLegalHoldRequest) Reset() {
+	*x = CreateLegalHoldRequest{}
+	mi := &file_api_mapping_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLegalHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLegalHoldRequest) ProtoMessage() {}
+
+func (x *CreateLegalHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLegalHoldRequest.ProtoReflect.Descriptor instead.
+func (*CreateLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateLegalHoldRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateLegalHoldRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateLegalHoldRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *CreateLegalHoldRequest) GetTargets() []*LegalHoldTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *CreateLegalHoldRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateLegalHoldRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CreateLegalHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *LegalHold             `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLegalHoldResponse) Reset() {
+	*x = CreateLegalHoldResponse{}
+	mi := &file_api_mapping_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLegalHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLegalHoldResponse) ProtoMessage() {}
+
+func (x *CreateLegalHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLegalHoldResponse.ProtoReflect.Descriptor instead.
+func (*CreateLegalHoldResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateLegalHoldResponse) GetHold() *LegalHold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+type ListLegalHoldsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLegalHoldsRequest) Reset() {
+	*x = ListLegalHoldsRequest{}
+	mi := &file_api_mapping_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLegalHoldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLegalHoldsRequest) ProtoMessage() {}
+
+func (x *ListLegalHoldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLegalHoldsRequest.ProtoReflect.Descriptor instead.
+func (*ListLegalHoldsRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListLegalHoldsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListLegalHoldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holds         []*LegalHold           `protobuf:"bytes,1,rep,name=holds,proto3" json:"holds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLegalHoldsResponse) Reset() {
+	*x = ListLegalHoldsResponse{}
+	mi := &file_api_mapping_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLegalHoldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLegalHoldsResponse) ProtoMessage() {}
+
+func (x *ListLegalHoldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLegalHoldsResponse.ProtoReflect.Descriptor instead.
+func (*ListLegalHoldsResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListLegalHoldsResponse) GetHolds() []*LegalHold {
+	if x != nil {
+		return x.Holds
+	}
+	return nil
+}
+
+type ReleaseLegalHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseLegalHoldRequest) Reset() {
+	*x = ReleaseLegalHoldRequest{}
+	mi := &file_api_mapping_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseLegalHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseLegalHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseLegalHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseLegalHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseLegalHoldRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ReleaseLegalHoldRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReleaseLegalHoldRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ReleaseLegalHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hold          *LegalHold             `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseLegalHoldResponse) Reset() {
+	*x = ReleaseLegalHoldResponse{}
+	mi := &file_api_mapping_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseLegalHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseLegalHoldResponse) ProtoMessage() {}
+
+func (x *ReleaseLegalHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseLegalHoldResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseLegalHoldResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ReleaseLegalHoldResponse) GetHold() *LegalHold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+type DestructionEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MappingId       string                 `protobuf:"bytes,2,opt,name=mapping_id,json=mappingId,proto3" json:"mapping_id,omitempty"`
+	Token           string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	KindId          int32                  `protobuf:"varint,4,opt,name=kind_id,json=kindId,proto3" json:"kind_id,omitempty"`
+	KindName        string                 `protobuf:"bytes,5,opt,name=kind_name,json=kindName,proto3" json:"kind_name,omitempty"`
+	KindRussianName string                 `protobuf:"bytes,6,opt,name=kind_russian_name,json=kindRussianName,proto3" json:"kind_russian_name,omitempty"`
+	Reason          string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Initiator       string                 `protobuf:"bytes,8,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	DestroyedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=destroyed_at,json=destroyedAt,proto3" json:"destroyed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DestructionEvent) Reset() {
+	*x = DestructionEvent{}
+	mi := &file_api_mapping_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestructionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestructionEvent) ProtoMessage() {}
+
+func (x *DestructionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestructionEvent.ProtoReflect.Descriptor instead.
+func (*DestructionEvent) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DestructionEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DestructionEvent) GetMappingId() string {
+	if x != nil {
+		return x.MappingId
+	}
+	return ""
+}
+
+func (x *DestructionEvent) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DestructionEvent) GetKindId() int32 {
+	if x != nil {
+		return x.KindId
+	}
+	return 0
+}
+
+func (x *DestructionEvent) GetKindName() string {
+	if x != nil {
+		return x.KindName
+	}
+	return ""
+}
+
+func (x *DestructionEvent) GetKindRussianName() string {
+	if x != nil {
+		return x.KindRussianName
+	}
+	return ""
+}
+
+func (x *DestructionEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DestructionEvent) GetInitiator() string {
+	if x != nil {
+		return x.Initiator
+	}
+	return ""
+}
+
+func (x *DestructionEvent) GetDestroyedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DestroyedAt
+	}
+	return nil
+}
+
+type DestructionActGroup struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	KindId          int32                  `protobuf:"varint,1,opt,name=kind_id,json=kindId,proto3" json:"kind_id,omitempty"`
+	KindName        string                 `protobuf:"bytes,2,opt,name=kind_name,json=kindName,proto3" json:"kind_name,omitempty"`
+	KindRussianName string                 `protobuf:"bytes,3,opt,name=kind_russian_name,json=kindRussianName,proto3" json:"kind_russian_name,omitempty"`
+	Events          []*DestructionEvent    `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DestructionActGroup) Reset() {
+	*x = DestructionActGroup{}
+	mi := &file_api_mapping_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMappingDekRequest) String() string {
+func (x *DestructionActGroup) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMappingDekRequest) ProtoMessage() {}
+func (*DestructionActGroup) ProtoMessage() {}
 
-func (x *UpdateMappingDekRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_mapping_proto_msgTypes[30]
+func (x *DestructionActGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1632,46 +3418,69 @@ func (x *UpdateMappingDekRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMappingDekRequest.ProtoReflect.Descriptor instead.
-func (*UpdateMappingDekRequest) Descriptor() ([]byte, []int) {
-	return file_api_mapping_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use DestructionActGroup.ProtoReflect.Descriptor instead.
+func (*DestructionActGroup) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *UpdateMappingDekRequest) GetId() string {
+func (x *DestructionActGroup) GetKindId() int32 {
 	if x != nil {
-		return x.Id
+		return x.KindId
+	}
+	return 0
+}
+
+func (x *DestructionActGroup) GetKindName() string {
+	if x != nil {
+		return x.KindName
 	}
 	return ""
 }
 
-func (x *UpdateMappingDekRequest) GetDekWrapped() []byte {
+func (x *DestructionActGroup) GetKindRussianName() string {
 	if x != nil {
-		return x.DekWrapped
+		return x.KindRussianName
+	}
+	return ""
+}
+
+func (x *DestructionActGroup) GetEvents() []*DestructionEvent {
+	if x != nil {
+		return x.Events
 	}
 	return nil
 }
 
-type UpdateMappingDekResponse struct {
+type DestructionAct struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeriodFrom    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=period_from,json=periodFrom,proto3" json:"period_from,omitempty"`
+	PeriodTo      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=period_to,json=periodTo,proto3" json:"period_to,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	GeneratedBy   string                 `protobuf:"bytes,4,opt,name=generated_by,json=generatedBy,proto3" json:"generated_by,omitempty"`
+	Total         int32                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Groups        []*DestructionActGroup `protobuf:"bytes,6,rep,name=groups,proto3" json:"groups,omitempty"`
+	Digest        string                 `protobuf:"bytes,7,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signature     string                 `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	SigningKey    string                 `protobuf:"bytes,9,opt,name=signing_key,json=signingKey,proto3" json:"signing_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMappingDekResponse) Reset() {
-	*x = UpdateMappingDekResponse{}
-	mi := &file_api_mapping_proto_msgTypes[31]
+func (x *DestructionAct) Reset() {
+	*x = DestructionAct{}
+	mi := &file_api_mapping_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMappingDekResponse) String() string {
+func (x *DestructionAct) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMappingDekResponse) ProtoMessage() {}
+func (*DestructionAct) ProtoMessage() {}
 
-func (x *UpdateMappingDekResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_mapping_proto_msgTypes[31]
+func (x *DestructionAct) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1682,36 +3491,98 @@ func (x *UpdateMappingDekResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMappingDekResponse.ProtoReflect.Descriptor instead.
-func (*UpdateMappingDekResponse) Descriptor() ([]byte, []int) {
-	return file_api_mapping_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use DestructionAct.ProtoReflect.Descriptor instead.
+func (*DestructionAct) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{57}
 }
 
-type UpdateMappingCryptoRequest struct {
+func (x *DestructionAct) GetPeriodFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodFrom
+	}
+	return nil
+}
+
+func (x *DestructionAct) GetPeriodTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodTo
+	}
+	return nil
+}
+
+func (x *DestructionAct) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *DestructionAct) GetGeneratedBy() string {
+	if x != nil {
+		return x.GeneratedBy
+	}
+	return ""
+}
+
+func (x *DestructionAct) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *DestructionAct) GetGroups() []*DestructionActGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *DestructionAct) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *DestructionAct) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *DestructionAct) GetSigningKey() string {
+	if x != nil {
+		return x.SigningKey
+	}
+	return ""
+}
+
+type GetDestructionActRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	DekWrapped    []byte                 `protobuf:"bytes,2,opt,name=dek_wrapped,json=dekWrapped,proto3" json:"dek_wrapped,omitempty"`
-	CipherText    []byte                 `protobuf:"bytes,3,opt,name=cipher_text,json=cipherText,proto3" json:"cipher_text,omitempty"`
-	AlgoName      string                 `protobuf:"bytes,4,opt,name=algo_name,json=algoName,proto3" json:"algo_name,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMappingCryptoRequest) Reset() {
-	*x = UpdateMappingCryptoRequest{}
-	mi := &file_api_mapping_proto_msgTypes[32]
+func (x *GetDestructionActRequest) Reset() {
+	*x = GetDestructionActRequest{}
+	mi := &file_api_mapping_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMappingCryptoRequest) String() string {
+func (x *GetDestructionActRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMappingCryptoRequest) ProtoMessage() {}
+func (*GetDestructionActRequest) ProtoMessage() {}
 
-func (x *UpdateMappingCryptoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_mapping_proto_msgTypes[32]
+func (x *GetDestructionActRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1722,60 +3593,227 @@ func (x *UpdateMappingCryptoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMappingCryptoRequest.ProtoReflect.Descriptor instead.
-func (*UpdateMappingCryptoRequest) Descriptor() ([]byte, []int) {
-	return file_api_mapping_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use GetDestructionActRequest.ProtoReflect.Descriptor instead.
+func (*GetDestructionActRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *UpdateMappingCryptoRequest) GetId() string {
+func (x *GetDestructionActRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.From
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateMappingCryptoRequest) GetDekWrapped() []byte {
+func (x *GetDestructionActRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.DekWrapped
+		return x.To
 	}
 	return nil
 }
 
-func (x *UpdateMappingCryptoRequest) GetCipherText() []byte {
+func (x *GetDestructionActRequest) GetUserId() string {
 	if x != nil {
-		return x.CipherText
+		return x.UserId
+	}
+	return ""
+}
+
+type GetDestructionActResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Act           *DestructionAct        `protobuf:"bytes,1,opt,name=act,proto3" json:"act,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDestructionActResponse) Reset() {
+	*x = GetDestructionActResponse{}
+	mi := &file_api_mapping_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDestructionActResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDestructionActResponse) ProtoMessage() {}
+
+func (x *GetDestructionActResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDestructionActResponse.ProtoReflect.Descriptor instead.
+func (*GetDestructionActResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetDestructionActResponse) GetAct() *DestructionAct {
+	if x != nil {
+		return x.Act
 	}
 	return nil
 }
 
-func (x *UpdateMappingCryptoRequest) GetAlgoName() string {
+type VerifyAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAuditLogRequest) Reset() {
+	*x = VerifyAuditLogRequest{}
+	mi := &file_api_mapping_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditLogRequest) ProtoMessage() {}
+
+func (x *VerifyAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[60]
 	if x != nil {
-		return x.AlgoName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{60}
+}
+
+type VerifyAuditLogResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Valid               bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Checked             int64                  `protobuf:"varint,2,opt,name=checked,proto3" json:"checked,omitempty"`
+	LastSeq             int64                  `protobuf:"varint,3,opt,name=last_seq,json=lastSeq,proto3" json:"last_seq,omitempty"`
+	CheckpointsVerified int32                  `protobuf:"varint,4,opt,name=checkpoints_verified,json=checkpointsVerified,proto3" json:"checkpoints_verified,omitempty"`
+	BrokenSeq           int64                  `protobuf:"varint,5,opt,name=broken_seq,json=brokenSeq,proto3" json:"broken_seq,omitempty"`
+	BrokenEntryId       string                 `protobuf:"bytes,6,opt,name=broken_entry_id,json=brokenEntryId,proto3" json:"broken_entry_id,omitempty"`
+	Reason              string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *VerifyAuditLogResponse) Reset() {
+	*x = VerifyAuditLogResponse{}
+	mi := &file_api_mapping_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAuditLogResponse) ProtoMessage() {}
+
+func (x *VerifyAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*VerifyAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *VerifyAuditLogResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyAuditLogResponse) GetChecked() int64 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *VerifyAuditLogResponse) GetLastSeq() int64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+func (x *VerifyAuditLogResponse) GetCheckpointsVerified() int32 {
+	if x != nil {
+		return x.CheckpointsVerified
+	}
+	return 0
+}
+
+func (x *VerifyAuditLogResponse) GetBrokenSeq() int64 {
+	if x != nil {
+		return x.BrokenSeq
+	}
+	return 0
+}
+
+func (x *VerifyAuditLogResponse) GetBrokenEntryId() string {
+	if x != nil {
+		return x.BrokenEntryId
 	}
 	return ""
 }
 
-type UpdateMappingCryptoResponse struct {
+func (x *VerifyAuditLogResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ExportAuditLogRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *AuditLogFilter        `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMappingCryptoResponse) Reset() {
-	*x = UpdateMappingCryptoResponse{}
-	mi := &file_api_mapping_proto_msgTypes[33]
+func (x *ExportAuditLogRequest) Reset() {
+	*x = ExportAuditLogRequest{}
+	mi := &file_api_mapping_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMappingCryptoResponse) String() string {
+func (x *ExportAuditLogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMappingCryptoResponse) ProtoMessage() {}
+func (*ExportAuditLogRequest) ProtoMessage() {}
 
-func (x *UpdateMappingCryptoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_mapping_proto_msgTypes[33]
+func (x *ExportAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_mapping_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1786,9 +3824,23 @@ func (x *UpdateMappingCryptoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMappingCryptoResponse.ProtoReflect.Descriptor instead.
-func (*UpdateMappingCryptoResponse) Descriptor() ([]byte, []int) {
-	return file_api_mapping_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ExportAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ExportAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_api_mapping_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ExportAuditLogRequest) GetFilter() *AuditLogFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ExportAuditLogRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
 var File_api_mapping_proto protoreflect.FileDescriptor
@@ -1803,7 +3855,7 @@ const file_api_mapping_proto_rawDesc = "" +
 	"\faccess_level\x18\x04 \x01(\x05R\vaccessLevel\x12\x12\n" +
 	"\x04mask\x18\x05 \x01(\tR\x04mask\x12\x1d\n" +
 	"\n" +
-	"short_name\x18\x06 \x01(\tR\tshortName\"\xcf\x02\n" +
+	"short_name\x18\x06 \x01(\tR\tshortName\"\x98\x04\n" +
 	"\fMappingModel\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vcipher_text\x18\x02 \x01(\fR\n" +
@@ -1816,7 +3868,16 @@ const file_api_mapping_proto_rawDesc = "" +
 	"\rdeterministic\x18\x06 \x01(\bR\rdeterministic\x12!\n" +
 	"\x04kind\x18\a \x01(\v2\r.mapping.KindR\x04kind\x12\x14\n" +
 	"\x05token\x18\b \x01(\tR\x05token\x12\x1b\n" +
-	"\talgo_name\x18\t \x01(\tR\balgoName\"\x8c\x02\n" +
+	"\talgo_name\x18\t \x01(\tR\balgoName\x12\x1f\n" +
+	"\vsubject_ref\x18\n" +
+	" \x01(\tR\n" +
+	"subjectRef\x12\x1a\n" +
+	"\bpurposes\x18\v \x03(\tR\bpurposes\x12\x1f\n" +
+	"\vlegal_basis\x18\f \x01(\tR\n" +
+	"legalBasis\x12\x1f\n" +
+	"\vconsent_ref\x18\r \x01(\tR\n" +
+	"consentRef\x12H\n" +
+	"\x12consent_expires_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x10consentExpiresAt\"\xd5\x03\n" +
 	"\x14CreateMappingRequest\x12\x1f\n" +
 	"\vcipher_text\x18\x01 \x01(\fR\n" +
 	"cipherText\x12\x1f\n" +
@@ -1826,13 +3887,23 @@ const file_api_mapping_proto_rawDesc = "" +
 	"\rdeterministic\x18\x04 \x01(\bR\rdeterministic\x12!\n" +
 	"\x04kind\x18\x05 \x01(\v2\r.mapping.KindR\x04kind\x12\x14\n" +
 	"\x05token\x18\x06 \x01(\tR\x05token\x12\x1b\n" +
-	"\talgo_name\x18\a \x01(\tR\balgoName\"0\n" +
+	"\talgo_name\x18\a \x01(\tR\balgoName\x12\x1f\n" +
+	"\vsubject_ref\x18\b \x01(\tR\n" +
+	"subjectRef\x12\x1a\n" +
+	"\bpurposes\x18\t \x03(\tR\bpurposes\x12\x1f\n" +
+	"\vlegal_basis\x18\n" +
+	" \x01(\tR\n" +
+	"legalBasis\x12\x1f\n" +
+	"\vconsent_ref\x18\v \x01(\tR\n" +
+	"consentRef\x12H\n" +
+	"\x12consent_expires_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x10consentExpiresAt\"0\n" +
 	"\x18GetMappingByTokenRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\"R\n" +
 	"\x15CreateMappingResponse\x129\n" +
-	"\fmappingModel\x18\x01 \x01(\v2\x15.mapping.MappingModelR\fmappingModel\"&\n" +
+	"\fmappingModel\x18\x01 \x01(\v2\x15.mapping.MappingModelR\fmappingModel\"?\n" +
 	"\x14DeleteMappingRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x17\n" +
 	"\x15DeleteMappingResponse\"^\n" +
 	"\x14UpdateMappingRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x126\n" +
@@ -1842,8 +3913,9 @@ const file_api_mapping_proto_rawDesc = "" +
 	"\x11GetMappingRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
 	"\x12GetMappingResponse\x129\n" +
-	"\fmappingModel\x18\x01 \x01(\v2\x15.mapping.MappingModelR\fmappingModel\"\x17\n" +
-	"\x15GetMappingListRequest\"U\n" +
+	"\fmappingModel\x18\x01 \x01(\v2\x15.mapping.MappingModelR\fmappingModel\"A\n" +
+	"\x15GetMappingListRequest\x12(\n" +
+	"\x10max_access_level\x18\x01 \x01(\x05R\x0emaxAccessLevel\"U\n" +
 	"\x16GetMappingListResponse\x12;\n" +
 	"\rmappingModels\x18\x01 \x03(\v2\x15.mapping.MappingModelR\rmappingModels\"\xa0\x01\n" +
 	"\x11CreateKindRequest\x12\x12\n" +
@@ -1878,7 +3950,7 @@ const file_api_mapping_proto_rawDesc = "" +
 	"\x14GetKindByNameRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\":\n" +
 	"\x15GetKindByNameResponse\x12!\n" +
-	"\x04kind\x18\x01 \x01(\v2\r.mapping.KindR\x04kind\"\xc4\x01\n" +
+	"\x04kind\x18\x01 \x01(\v2\r.mapping.KindR\x04kind\"\xfa\x03\n" +
 	"\rAuditLogEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
@@ -1886,17 +3958,63 @@ const file_api_mapping_proto_rawDesc = "" +
 	"\x05token\x18\x04 \x01(\tR\x05token\x12!\n" +
 	"\x04kind\x18\x05 \x01(\v2\r.mapping.KindR\x04kind\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"w\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x18\n" +
+	"\apurpose\x18\a \x01(\tR\apurpose\x12\x1d\n" +
+	"\n" +
+	"legal_hold\x18\b \x01(\tR\tlegalHold\x12\x10\n" +
+	"\x03seq\x18\t \x01(\x03R\x03seq\x12\x1b\n" +
+	"\tprev_hash\x18\n" +
+	" \x01(\tR\bprevHash\x12\x19\n" +
+	"\brow_hash\x18\v \x01(\tR\arowHash\x12\x18\n" +
+	"\aoutcome\x18\f \x01(\tR\aoutcome\x12\x16\n" +
+	"\x06reason\x18\r \x01(\tR\x06reason\x12\x1b\n" +
+	"\tclient_ip\x18\x0e \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x0f \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x10 \x01(\tR\trequestId\x12$\n" +
+	"\rjustification\x18\x11 \x01(\tR\rjustification\"\xc4\x02\n" +
 	"\x15CreateAuditLogRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06action\x18\x02 \x01(\tR\x06action\x12\x14\n" +
 	"\x05token\x18\x03 \x01(\tR\x05token\x12\x17\n" +
-	"\akind_id\x18\x04 \x01(\x05R\x06kindId\"F\n" +
+	"\akind_id\x18\x04 \x01(\x05R\x06kindId\x12\x18\n" +
+	"\apurpose\x18\x05 \x01(\tR\apurpose\x12\x18\n" +
+	"\aoutcome\x18\x06 \x01(\tR\aoutcome\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1b\n" +
+	"\tclient_ip\x18\b \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\t \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\n" +
+	" \x01(\tR\trequestId\x12$\n" +
+	"\rjustification\x18\v \x01(\tR\rjustification\"F\n" +
 	"\x16CreateAuditLogResponse\x12,\n" +
-	"\x05entry\x18\x01 \x01(\v2\x16.mapping.AuditLogEntryR\x05entry\"\x18\n" +
-	"\x16GetAuditLogListRequest\"K\n" +
+	"\x05entry\x18\x01 \x01(\v2\x16.mapping.AuditLogEntryR\x05entry\"\xc9\x02\n" +
+	"\x0eAuditLogFilter\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x17\n" +
+	"\akind_id\x18\x04 \x01(\x05R\x06kindId\x12!\n" +
+	"\faccess_level\x18\x05 \x01(\x05R\vaccessLevel\x12.\n" +
+	"\x04from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x18\n" +
+	"\aoutcome\x18\b \x01(\tR\aoutcome\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\t \x01(\tR\trequestId\x12\x1f\n" +
+	"\vbreak_glass\x18\n" +
+	" \x01(\bR\n" +
+	"breakGlass\"\xae\x01\n" +
+	"\x16GetAuditLogListRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.mapping.AuditLogFilterR\x06filter\x12\x17\n" +
+	"\asort_by\x18\x02 \x01(\tR\x06sortBy\x12\x1c\n" +
+	"\tascending\x18\x03 \x01(\bR\tascending\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"l\n" +
 	"\x17GetAuditLogListResponse\x120\n" +
-	"\aentries\x18\x01 \x03(\v2\x16.mapping.AuditLogEntryR\aentries\"J\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.mapping.AuditLogEntryR\aentries\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"J\n" +
 	"\x17UpdateMappingDekRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vdek_wrapped\x18\x02 \x01(\fR\n" +
@@ -1909,7 +4027,132 @@ const file_api_mapping_proto_rawDesc = "" +
 	"\vcipher_text\x18\x03 \x01(\fR\n" +
 	"cipherText\x12\x1b\n" +
 	"\talgo_name\x18\x04 \x01(\tR\balgoName\"\x1d\n" +
-	"\x1bUpdateMappingCryptoResponse2\xf3\t\n" +
+	"\x1bUpdateMappingCryptoResponse\"g\n" +
+	"\x1aListSubjectMappingsRequest\x12\x1f\n" +
+	"\vsubject_ref\x18\x01 \x01(\tR\n" +
+	"subjectRef\x12(\n" +
+	"\x10max_access_level\x18\x02 \x01(\x05R\x0emaxAccessLevel\"Z\n" +
+	"\x1bListSubjectMappingsResponse\x12;\n" +
+	"\rmappingModels\x18\x01 \x03(\v2\x15.mapping.MappingModelR\rmappingModels\"p\n" +
+	"\x13EraseSubjectRequest\x12\x1f\n" +
+	"\vsubject_ref\x18\x01 \x01(\tR\n" +
+	"subjectRef\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vmapping_ids\x18\x03 \x03(\tR\n" +
+	"mappingIds\"\xb5\x01\n" +
+	"\rErasureReport\x12\x1f\n" +
+	"\vsubject_ref\x18\x01 \x01(\tR\n" +
+	"subjectRef\x12\x1b\n" +
+	"\terased_by\x18\x02 \x01(\tR\berasedBy\x127\n" +
+	"\terased_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\berasedAt\x12-\n" +
+	"\x06erased\x18\x04 \x03(\v2\x15.mapping.MappingModelR\x06erased\"F\n" +
+	"\x14EraseSubjectResponse\x12.\n" +
+	"\x06report\x18\x01 \x01(\v2\x16.mapping.ErasureReportR\x06report\"\x8a\x01\n" +
+	"\aPurpose\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"L\n" +
+	"\x14CreatePurposeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"C\n" +
+	"\x15CreatePurposeResponse\x12*\n" +
+	"\apurpose\x18\x01 \x01(\v2\x10.mapping.PurposeR\apurpose\"\x15\n" +
+	"\x13ListPurposesRequest\"D\n" +
+	"\x14ListPurposesResponse\x12,\n" +
+	"\bpurposes\x18\x01 \x03(\v2\x10.mapping.PurposeR\bpurposes\"&\n" +
+	"\x14DeletePurposeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x17\n" +
+	"\x15DeletePurposeResponse\";\n" +
+	"\x0fLegalHoldTarget\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x9c\x03\n" +
+	"\tLegalHold\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x122\n" +
+	"\atargets\x18\x05 \x03(\v2\x18.mapping.LegalHoldTargetR\atargets\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12;\n" +
+	"\vreleased_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\x12\x1f\n" +
+	"\vreleased_by\x18\n" +
+	" \x01(\tR\n" +
+	"releasedBy\x12\x16\n" +
+	"\x06active\x18\v \x01(\bR\x06active\"\xe2\x01\n" +
+	"\x16CreateLegalHoldRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x122\n" +
+	"\atargets\x18\x04 \x03(\v2\x18.mapping.LegalHoldTargetR\atargets\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\"A\n" +
+	"\x17CreateLegalHoldResponse\x12&\n" +
+	"\x04hold\x18\x01 \x01(\v2\x12.mapping.LegalHoldR\x04hold\"8\n" +
+	"\x15ListLegalHoldsRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\"B\n" +
+	"\x16ListLegalHoldsResponse\x12(\n" +
+	"\x05holds\x18\x01 \x03(\v2\x12.mapping.LegalHoldR\x05holds\"B\n" +
+	"\x17ReleaseLegalHoldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"B\n" +
+	"\x18ReleaseLegalHoldResponse\x12&\n" +
+	"\x04hold\x18\x01 \x01(\v2\x12.mapping.LegalHoldR\x04hold\"\xae\x02\n" +
+	"\x10DestructionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"mapping_id\x18\x02 \x01(\tR\tmappingId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x17\n" +
+	"\akind_id\x18\x04 \x01(\x05R\x06kindId\x12\x1b\n" +
+	"\tkind_name\x18\x05 \x01(\tR\bkindName\x12*\n" +
+	"\x11kind_russian_name\x18\x06 \x01(\tR\x0fkindRussianName\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1c\n" +
+	"\tinitiator\x18\b \x01(\tR\tinitiator\x12=\n" +
+	"\fdestroyed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vdestroyedAt\"\xaa\x01\n" +
+	"\x13DestructionActGroup\x12\x17\n" +
+	"\akind_id\x18\x01 \x01(\x05R\x06kindId\x12\x1b\n" +
+	"\tkind_name\x18\x02 \x01(\tR\bkindName\x12*\n" +
+	"\x11kind_russian_name\x18\x03 \x01(\tR\x0fkindRussianName\x121\n" +
+	"\x06events\x18\x04 \x03(\v2\x19.mapping.DestructionEventR\x06events\"\x8b\x03\n" +
+	"\x0eDestructionAct\x12;\n" +
+	"\vperiod_from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"periodFrom\x127\n" +
+	"\tperiod_to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bperiodTo\x12=\n" +
+	"\fgenerated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12!\n" +
+	"\fgenerated_by\x18\x04 \x01(\tR\vgeneratedBy\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x05R\x05total\x124\n" +
+	"\x06groups\x18\x06 \x03(\v2\x1c.mapping.DestructionActGroupR\x06groups\x12\x16\n" +
+	"\x06digest\x18\a \x01(\tR\x06digest\x12\x1c\n" +
+	"\tsignature\x18\b \x01(\tR\tsignature\x12\x1f\n" +
+	"\vsigning_key\x18\t \x01(\tR\n" +
+	"signingKey\"\x8f\x01\n" +
+	"\x18GetDestructionActRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"F\n" +
+	"\x19GetDestructionActResponse\x12)\n" +
+	"\x03act\x18\x01 \x01(\v2\x17.mapping.DestructionActR\x03act\"\x17\n" +
+	"\x15VerifyAuditLogRequest\"\xf5\x01\n" +
+	"\x16VerifyAuditLogResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x18\n" +
+	"\achecked\x18\x02 \x01(\x03R\achecked\x12\x19\n" +
+	"\blast_seq\x18\x03 \x01(\x03R\alastSeq\x121\n" +
+	"\x14checkpoints_verified\x18\x04 \x01(\x05R\x13checkpointsVerified\x12\x1d\n" +
+	"\n" +
+	"broken_seq\x18\x05 \x01(\x03R\tbrokenSeq\x12&\n" +
+	"\x0fbroken_entry_id\x18\x06 \x01(\tR\rbrokenEntryId\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\"a\n" +
+	"\x15ExportAuditLogRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.mapping.AuditLogFilterR\x06filter\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId2\x8c\x11\n" +
 	"\aMapping\x12N\n" +
 	"\rCreateMapping\x12\x1d.mapping.CreateMappingRequest\x1a\x1e.mapping.CreateMappingResponse\x12N\n" +
 	"\rDeleteMapping\x12\x1d.mapping.DeleteMappingRequest\x1a\x1e.mapping.DeleteMappingResponse\x12N\n" +
@@ -1928,9 +4171,20 @@ const file_api_mapping_proto_rawDesc = "" +
 	"DeleteKind\x12\x1a.mapping.DeleteKindRequest\x1a\x1b.mapping.DeleteKindResponse\x12N\n" +
 	"\rGetKindByName\x12\x1d.mapping.GetKindByNameRequest\x1a\x1e.mapping.GetKindByNameResponse\x12Q\n" +
 	"\x0eCreateAuditLog\x12\x1e.mapping.CreateAuditLogRequest\x1a\x1f.mapping.CreateAuditLogResponse\x12T\n" +
-	"\x0fGetAuditLogList\x12\x1f.mapping.GetAuditLogListRequest\x1a .mapping.GetAuditLogListResponse\x12W\n" +
+	"\x0fGetAuditLogList\x12\x1f.mapping.GetAuditLogListRequest\x1a .mapping.GetAuditLogListResponse\x12J\n" +
+	"\x0eExportAuditLog\x12\x1e.mapping.ExportAuditLogRequest\x1a\x16.mapping.AuditLogEntry0\x01\x12W\n" +
 	"\x10UpdateMappingDek\x12 .mapping.UpdateMappingDekRequest\x1a!.mapping.UpdateMappingDekResponse\x12`\n" +
-	"\x13UpdateMappingCrypto\x12#.mapping.UpdateMappingCryptoRequest\x1a$.mapping.UpdateMappingCryptoResponseB\x14Z\x12common/gen/mappingb\x06proto3"
+	"\x13UpdateMappingCrypto\x12#.mapping.UpdateMappingCryptoRequest\x1a$.mapping.UpdateMappingCryptoResponse\x12`\n" +
+	"\x13ListSubjectMappings\x12#.mapping.ListSubjectMappingsRequest\x1a$.mapping.ListSubjectMappingsResponse\x12K\n" +
+	"\fEraseSubject\x12\x1c.mapping.EraseSubjectRequest\x1a\x1d.mapping.EraseSubjectResponse\x12N\n" +
+	"\rCreatePurpose\x12\x1d.mapping.CreatePurposeRequest\x1a\x1e.mapping.CreatePurposeResponse\x12K\n" +
+	"\fListPurposes\x12\x1c.mapping.ListPurposesRequest\x1a\x1d.mapping.ListPurposesResponse\x12N\n" +
+	"\rDeletePurpose\x12\x1d.mapping.DeletePurposeRequest\x1a\x1e.mapping.DeletePurposeResponse\x12T\n" +
+	"\x0fCreateLegalHold\x12\x1f.mapping.CreateLegalHoldRequest\x1a .mapping.CreateLegalHoldResponse\x12Q\n" +
+	"\x0eListLegalHolds\x12\x1e.mapping.ListLegalHoldsRequest\x1a\x1f.mapping.ListLegalHoldsResponse\x12W\n" +
+	"\x10ReleaseLegalHold\x12 .mapping.ReleaseLegalHoldRequest\x1a!.mapping.ReleaseLegalHoldResponse\x12Z\n" +
+	"\x11GetDestructionAct\x12!.mapping.GetDestructionActRequest\x1a\".mapping.GetDestructionActResponse\x12Q\n" +
+	"\x0eVerifyAuditLog\x12\x1e.mapping.VerifyAuditLogRequest\x1a\x1f.mapping.VerifyAuditLogResponseB\x14Z\x12common/gen/mappingb\x06proto3"
 
 var (
 	file_api_mapping_proto_rawDescOnce sync.Once
@@ -1944,7 +4198,7 @@ func file_api_mapping_proto_rawDescGZIP() []byte {
 	return file_api_mapping_proto_rawDescData
 }
 
-var file_api_mapping_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_api_mapping_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_api_mapping_proto_goTypes = []any{
 	(*Kind)(nil),                        // 0: mapping.Kind
 	(*MappingModel)(nil),                // 1: mapping.MappingModel
@@ -1974,72 +4228,154 @@ var file_api_mapping_proto_goTypes = []any{
 	(*AuditLogEntry)(nil),               // 25: mapping.AuditLogEntry
 	(*CreateAuditLogRequest)(nil),       // 26: mapping.CreateAuditLogRequest
 	(*CreateAuditLogResponse)(nil),      // 27: mapping.CreateAuditLogResponse
-	(*GetAuditLogListRequest)(nil),      // 28: mapping.GetAuditLogListRequest
-	(*GetAuditLogListResponse)(nil),     // 29: mapping.GetAuditLogListResponse
-	(*UpdateMappingDekRequest)(nil),     // 30: mapping.UpdateMappingDekRequest
-	(*UpdateMappingDekResponse)(nil),    // 31: mapping.UpdateMappingDekResponse
-	(*UpdateMappingCryptoRequest)(nil),  // 32: mapping.UpdateMappingCryptoRequest
-	(*UpdateMappingCryptoResponse)(nil), // 33: mapping.UpdateMappingCryptoResponse
-	(*durationpb.Duration)(nil),         // 34: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),       // 35: google.protobuf.Timestamp
+	(*AuditLogFilter)(nil),              // 28: mapping.AuditLogFilter
+	(*GetAuditLogListRequest)(nil),      // 29: mapping.GetAuditLogListRequest
+	(*GetAuditLogListResponse)(nil),     // 30: mapping.GetAuditLogListResponse
+	(*UpdateMappingDekRequest)(nil),     // 31: mapping.UpdateMappingDekRequest
+	(*UpdateMappingDekResponse)(nil),    // 32: mapping.UpdateMappingDekResponse
+	(*UpdateMappingCryptoRequest)(nil),  // 33: mapping.UpdateMappingCryptoRequest
+	(*UpdateMappingCryptoResponse)(nil), // 34: mapping.UpdateMappingCryptoResponse
+	(*ListSubjectMappingsRequest)(nil),  // 35: mapping.ListSubjectMappingsRequest
+	(*ListSubjectMappingsResponse)(nil), // 36: mapping.ListSubjectMappingsResponse
+	(*EraseSubjectRequest)(nil),         // 37: mapping.EraseSubjectRequest
+	(*ErasureReport)(nil),               // 38: mapping.ErasureReport
+	(*EraseSubjectResponse)(nil),        // 39: mapping.EraseSubjectResponse
+	(*Purpose)(nil),                     // 40: mapping.Purpose
+	(*CreatePurposeRequest)(nil),        // 41: mapping.CreatePurposeRequest
+	(*CreatePurposeResponse)(nil),       // 42: mapping.CreatePurposeResponse
+	(*ListPurposesRequest)(nil),         // 43: mapping.ListPurposesRequest
+	(*ListPurposesResponse)(nil),        // 44: mapping.ListPurposesResponse
+	(*DeletePurposeRequest)(nil),        // 45: mapping.DeletePurposeRequest
+	(*DeletePurposeResponse)(nil),       // 46: mapping.DeletePurposeResponse
+	(*LegalHoldTarget)(nil),             // 47: mapping.LegalHoldTarget
+	(*LegalHold)(nil),                   // 48: mapping.LegalHold
+	(*CreateLegalHoldRequest)(nil),      // 49: mapping.CreateLegalHoldRequest
+	(*CreateLegalHoldResponse)(nil),     // 50: mapping.CreateLegalHoldResponse
+	(*ListLegalHoldsRequest)(nil),       // 51: mapping.ListLegalHoldsRequest
+	(*ListLegalHoldsResponse)(nil),      // 52: mapping.ListLegalHoldsResponse
+	(*ReleaseLegalHoldRequest)(nil),     // 53: mapping.ReleaseLegalHoldRequest
+	(*ReleaseLegalHoldResponse)(nil),    // 54: mapping.ReleaseLegalHoldResponse
+	(*DestructionEvent)(nil),            // 55: mapping.DestructionEvent
+	(*DestructionActGroup)(nil),         // 56: mapping.DestructionActGroup
+	(*DestructionAct)(nil),              // 57: mapping.DestructionAct
+	(*GetDestructionActRequest)(nil),    // 58: mapping.GetDestructionActRequest
+	(*GetDestructionActResponse)(nil),   // 59: mapping.GetDestructionActResponse
+	(*VerifyAuditLogRequest)(nil),       // 60: mapping.VerifyAuditLogRequest
+	(*VerifyAuditLogResponse)(nil),      // 61: mapping.VerifyAuditLogResponse
+	(*ExportAuditLogRequest)(nil),       // 62: mapping.ExportAuditLogRequest
+	(*durationpb.Duration)(nil),         // 63: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),       // 64: google.protobuf.Timestamp
 }
 var file_api_mapping_proto_depIdxs = []int32{
-	34, // 0: mapping.MappingModel.token_ttl:type_name -> google.protobuf.Duration
-	35, // 1: mapping.MappingModel.created_at:type_name -> google.protobuf.Timestamp
+	63, // 0: mapping.MappingModel.token_ttl:type_name -> google.protobuf.Duration
+	64, // 1: mapping.MappingModel.created_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: mapping.MappingModel.kind:type_name -> mapping.Kind
-	34, // 3: mapping.CreateMappingRequest.token_ttl:type_name -> google.protobuf.Duration
-	0,  // 4: mapping.CreateMappingRequest.kind:type_name -> mapping.Kind
-	1,  // 5: mapping.CreateMappingResponse.mappingModel:type_name -> mapping.MappingModel
-	34, // 6: mapping.UpdateMappingRequest.token_ttl:type_name -> google.protobuf.Duration
-	1,  // 7: mapping.UpdateMappingResponse.mappingModel:type_name -> mapping.MappingModel
-	1,  // 8: mapping.GetMappingResponse.mappingModel:type_name -> mapping.MappingModel
-	1,  // 9: mapping.GetMappingListResponse.mappingModels:type_name -> mapping.MappingModel
-	0,  // 10: mapping.CreateKindResponse.kind:type_name -> mapping.Kind
-	0,  // 11: mapping.GetKindResponse.kind:type_name -> mapping.Kind
-	0,  // 12: mapping.ListKindsResponse.kinds:type_name -> mapping.Kind
-	0,  // 13: mapping.UpdateKindResponse.kind:type_name -> mapping.Kind
-	0,  // 14: mapping.GetKindByNameResponse.kind:type_name -> mapping.Kind
-	0,  // 15: mapping.AuditLogEntry.kind:type_name -> mapping.Kind
-	35, // 16: mapping.AuditLogEntry.created_at:type_name -> google.protobuf.Timestamp
-	25, // 17: mapping.CreateAuditLogResponse.entry:type_name -> mapping.AuditLogEntry
-	25, // 18: mapping.GetAuditLogListResponse.entries:type_name -> mapping.AuditLogEntry
-	2,  // 19: mapping.Mapping.CreateMapping:input_type -> mapping.CreateMappingRequest
-	5,  // 20: mapping.Mapping.DeleteMapping:input_type -> mapping.DeleteMappingRequest
-	7,  // 21: mapping.Mapping.UpdateMapping:input_type -> mapping.UpdateMappingRequest
-	9,  // 22: mapping.Mapping.GetMapping:input_type -> mapping.GetMappingRequest
-	3,  // 23: mapping.Mapping.GetMappingByToken:input_type -> mapping.GetMappingByTokenRequest
-	11, // 24: mapping.Mapping.GetMappingList:input_type -> mapping.GetMappingListRequest
-	13, // 25: mapping.Mapping.CreateKind:input_type -> mapping.CreateKindRequest
-	15, // 26: mapping.Mapping.GetKind:input_type -> mapping.GetKindRequest
-	17, // 27: mapping.Mapping.ListKinds:input_type -> mapping.ListKindsRequest
-	19, // 28: mapping.Mapping.UpdateKind:input_type -> mapping.UpdateKindRequest
-	21, // 29: mapping.Mapping.DeleteKind:input_type -> mapping.DeleteKindRequest
-	23, // 30: mapping.Mapping.GetKindByName:input_type -> mapping.GetKindByNameRequest
-	26, // 31: mapping.Mapping.CreateAuditLog:input_type -> mapping.CreateAuditLogRequest
-	28, // 32: mapping.Mapping.GetAuditLogList:input_type -> mapping.GetAuditLogListRequest
-	30, // 33: mapping.Mapping.UpdateMappingDek:input_type -> mapping.UpdateMappingDekRequest
-	32, // 34: mapping.Mapping.UpdateMappingCrypto:input_type -> mapping.UpdateMappingCryptoRequest
-	4,  // 35: mapping.Mapping.CreateMapping:output_type -> mapping.CreateMappingResponse
-	6,  // 36: mapping.Mapping.DeleteMapping:output_type -> mapping.DeleteMappingResponse
-	8,  // 37: mapping.Mapping.UpdateMapping:output_type -> mapping.UpdateMappingResponse
-	10, // 38: mapping.Mapping.GetMapping:output_type -> mapping.GetMappingResponse
-	10, // 39: mapping.Mapping.GetMappingByToken:output_type -> mapping.GetMappingResponse
-	12, // 40: mapping.Mapping.GetMappingList:output_type -> mapping.GetMappingListResponse
-	14, // 41: mapping.Mapping.CreateKind:output_type -> mapping.CreateKindResponse
-	16, // 42: mapping.Mapping.GetKind:output_type -> mapping.GetKindResponse
-	18, // 43: mapping.Mapping.ListKinds:output_type -> mapping.ListKindsResponse
-	20, // 44: mapping.Mapping.UpdateKind:output_type -> mapping.UpdateKindResponse
-	22, // 45: mapping.Mapping.DeleteKind:output_type -> mapping.DeleteKindResponse
-	24, // 46: mapping.Mapping.GetKindByName:output_type -> mapping.GetKindByNameResponse
-	27, // 47: mapping.Mapping.CreateAuditLog:output_type -> mapping.CreateAuditLogResponse
-	29, // 48: mapping.Mapping.GetAuditLogList:output_type -> mapping.GetAuditLogListResponse
-	31, // 49: mapping.Mapping.UpdateMappingDek:output_type -> mapping.UpdateMappingDekResponse
-	33, // 50: mapping.Mapping.UpdateMappingCrypto:output_type -> mapping.UpdateMappingCryptoResponse
-	35, // [35:51] is the sub-list for method output_type
-	19, // [19:35] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	64, // 3: mapping.MappingModel.consent_expires_at:type_name -> google.protobuf.Timestamp
+	63, // 4: mapping.CreateMappingRequest.token_ttl:type_name -> google.protobuf.Duration
+	0,  // 5: mapping.CreateMappingRequest.kind:type_name -> mapping.Kind
+	64, // 6: mapping.CreateMappingRequest.consent_expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 7: mapping.CreateMappingResponse.mappingModel:type_name -> mapping.MappingModel
+	63, // 8: mapping.UpdateMappingRequest.token_ttl:type_name -> google.protobuf.Duration
+	1,  // 9: mapping.UpdateMappingResponse.mappingModel:type_name -> mapping.MappingModel
+	1,  // 10: mapping.GetMappingResponse.mappingModel:type_name -> mapping.MappingModel
+	1,  // 11: mapping.GetMappingListResponse.mappingModels:type_name -> mapping.MappingModel
+	0,  // 12: mapping.CreateKindResponse.kind:type_name -> mapping.Kind
+	0,  // 13: mapping.GetKindResponse.kind:type_name -> mapping.Kind
+	0,  // 14: mapping.ListKindsResponse.kinds:type_name -> mapping.Kind
+	0,  // 15: mapping.UpdateKindResponse.kind:type_name -> mapping.Kind
+	0,  // 16: mapping.GetKindByNameResponse.kind:type_name -> mapping.Kind
+	0,  // 17: mapping.AuditLogEntry.kind:type_name -> mapping.Kind
+	64, // 18: mapping.AuditLogEntry.created_at:type_name -> google.protobuf.Timestamp
+	25, // 19: mapping.CreateAuditLogResponse.entry:type_name -> mapping.AuditLogEntry
+	64, // 20: mapping.AuditLogFilter.from:type_name -> google.protobuf.Timestamp
+	64, // 21: mapping.AuditLogFilter.to:type_name -> google.protobuf.Timestamp
+	28, // 22: mapping.GetAuditLogListRequest.filter:type_name -> mapping.AuditLogFilter
+	25, // 23: mapping.GetAuditLogListResponse.entries:type_name -> mapping.AuditLogEntry
+	1,  // 24: mapping.ListSubjectMappingsResponse.mappingModels:type_name -> mapping.MappingModel
+	64, // 25: mapping.ErasureReport.erased_at:type_name -> google.protobuf.Timestamp
+	1,  // 26: mapping.ErasureReport.erased:type_name -> mapping.MappingModel
+	38, // 27: mapping.EraseSubjectResponse.report:type_name -> mapping.ErasureReport
+	64, // 28: mapping.Purpose.created_at:type_name -> google.protobuf.Timestamp
+	40, // 29: mapping.CreatePurposeResponse.purpose:type_name -> mapping.Purpose
+	40, // 30: mapping.ListPurposesResponse.purposes:type_name -> mapping.Purpose
+	47, // 31: mapping.LegalHold.targets:type_name -> mapping.LegalHoldTarget
+	64, // 32: mapping.LegalHold.created_at:type_name -> google.protobuf.Timestamp
+	64, // 33: mapping.LegalHold.expires_at:type_name -> google.protobuf.Timestamp
+	64, // 34: mapping.LegalHold.released_at:type_name -> google.protobuf.Timestamp
+	47, // 35: mapping.CreateLegalHoldRequest.targets:type_name -> mapping.LegalHoldTarget
+	64, // 36: mapping.CreateLegalHoldRequest.expires_at:type_name -> google.protobuf.Timestamp
+	48, // 37: mapping.CreateLegalHoldResponse.hold:type_name -> mapping.LegalHold
+	48, // 38: mapping.ListLegalHoldsResponse.holds:type_name -> mapping.LegalHold
+	48, // 39: mapping.ReleaseLegalHoldResponse.hold:type_name -> mapping.LegalHold
+	64, // 40: mapping.DestructionEvent.destroyed_at:type_name -> google.protobuf.Timestamp
+	55, // 41: mapping.DestructionActGroup.events:type_name -> mapping.DestructionEvent
+	64, // 42: mapping.DestructionAct.period_from:type_name -> google.protobuf.Timestamp
+	64, // 43: mapping.DestructionAct.period_to:type_name -> google.protobuf.Timestamp
+	64, // 44: mapping.DestructionAct.generated_at:type_name -> google.protobuf.Timestamp
+	56, // 45: mapping.DestructionAct.groups:type_name -> mapping.DestructionActGroup
+	64, // 46: mapping.GetDestructionActRequest.from:type_name -> google.protobuf.Timestamp
+	64, // 47: mapping.GetDestructionActRequest.to:type_name -> google.protobuf.Timestamp
+	57, // 48: mapping.GetDestructionActResponse.act:type_name -> mapping.DestructionAct
+	28, // 49: mapping.ExportAuditLogRequest.filter:type_name -> mapping.AuditLogFilter
+	2,  // 50: mapping.Mapping.CreateMapping:input_type -> mapping.CreateMappingRequest
+	5,  // 51: mapping.Mapping.DeleteMapping:input_type -> mapping.DeleteMappingRequest
+	7,  // 52: mapping.Mapping.UpdateMapping:input_type -> mapping.UpdateMappingRequest
+	9,  // 53: mapping.Mapping.GetMapping:input_type -> mapping.GetMappingRequest
+	3,  // 54: mapping.Mapping.GetMappingByToken:input_type -> mapping.GetMappingByTokenRequest
+	11, // 55: mapping.Mapping.GetMappingList:input_type -> mapping.GetMappingListRequest
+	13, // 56: mapping.Mapping.CreateKind:input_type -> mapping.CreateKindRequest
+	15, // 57: mapping.Mapping.GetKind:input_type -> mapping.GetKindRequest
+	17, // 58: mapping.Mapping.ListKinds:input_type -> mapping.ListKindsRequest
+	19, // 59: mapping.Mapping.UpdateKind:input_type -> mapping.UpdateKindRequest
+	21, // 60: mapping.Mapping.DeleteKind:input_type -> mapping.DeleteKindRequest
+	23, // 61: mapping.Mapping.GetKindByName:input_type -> mapping.GetKindByNameRequest
+	26, // 62: mapping.Mapping.CreateAuditLog:input_type -> mapping.CreateAuditLogRequest
+	29, // 63: mapping.Mapping.GetAuditLogList:input_type -> mapping.GetAuditLogListRequest
+	62, // 64: mapping.Mapping.ExportAuditLog:input_type -> mapping.ExportAuditLogRequest
+	31, // 65: mapping.Mapping.UpdateMappingDek:input_type -> mapping.UpdateMappingDekRequest
+	33, // 66: mapping.Mapping.UpdateMappingCrypto:input_type -> mapping.UpdateMappingCryptoRequest
+	35, // 67: mapping.Mapping.ListSubjectMappings:input_type -> mapping.ListSubjectMappingsRequest
+	37, // 68: mapping.Mapping.EraseSubject:input_type -> mapping.EraseSubjectRequest
+	41, // 69: mapping.Mapping.CreatePurpose:input_type -> mapping.CreatePurposeRequest
+	43, // 70: mapping.Mapping.ListPurposes:input_type -> mapping.ListPurposesRequest
+	45, // 71: mapping.Mapping.DeletePurpose:input_type -> mapping.DeletePurposeRequest
+	49, // 72: mapping.Mapping.CreateLegalHold:input_type -> mapping.CreateLegalHoldRequest
+	51, // 73: mapping.Mapping.ListLegalHolds:input_type -> mapping.ListLegalHoldsRequest
+	53, // 74: mapping.Mapping.ReleaseLegalHold:input_type -> mapping.ReleaseLegalHoldRequest
+	58, // 75: mapping.Mapping.GetDestructionAct:input_type -> mapping.GetDestructionActRequest
+	60, // 76: mapping.Mapping.VerifyAuditLog:input_type -> mapping.VerifyAuditLogRequest
+	4,  // 77: mapping.Mapping.CreateMapping:output_type -> mapping.CreateMappingResponse
+	6,  // 78: mapping.Mapping.DeleteMapping:output_type -> mapping.DeleteMappingResponse
+	8,  // 79: mapping.Mapping.UpdateMapping:output_type -> mapping.UpdateMappingResponse
+	10, // 80: mapping.Mapping.GetMapping:output_type -> mapping.GetMappingResponse
+	10, // 81: mapping.Mapping.GetMappingByToken:output_type -> mapping.GetMappingResponse
+	12, // 82: mapping.Mapping.GetMappingList:output_type -> mapping.GetMappingListResponse
+	14, // 83: mapping.Mapping.CreateKind:output_type -> mapping.CreateKindResponse
+	16, // 84: mapping.Mapping.GetKind:output_type -> mapping.GetKindResponse
+	18, // 85: mapping.Mapping.ListKinds:output_type -> mapping.ListKindsResponse
+	20, // 86: mapping.Mapping.UpdateKind:output_type -> mapping.UpdateKindResponse
+	22, // 87: mapping.Mapping.DeleteKind:output_type -> mapping.DeleteKindResponse
+	24, // 88: mapping.Mapping.GetKindByName:output_type -> mapping.GetKindByNameResponse
+	27, // 89: mapping.Mapping.CreateAuditLog:output_type -> mapping.CreateAuditLogResponse
+	30, // 90: mapping.Mapping.GetAuditLogList:output_type -> mapping.GetAuditLogListResponse
+	25, // 91: mapping.Mapping.ExportAuditLog:output_type -> mapping.AuditLogEntry
+	32, // 92: mapping.Mapping.UpdateMappingDek:output_type -> mapping.UpdateMappingDekResponse
+	34, // 93: mapping.Mapping.UpdateMappingCrypto:output_type -> mapping.UpdateMappingCryptoResponse
+	36, // 94: mapping.Mapping.ListSubjectMappings:output_type -> mapping.ListSubjectMappingsResponse
+	39, // 95: mapping.Mapping.EraseSubject:output_type -> mapping.EraseSubjectResponse
+	42, // 96: mapping.Mapping.CreatePurpose:output_type -> mapping.CreatePurposeResponse
+	44, // 97: mapping.Mapping.ListPurposes:output_type -> mapping.ListPurposesResponse
+	46, // 98: mapping.Mapping.DeletePurpose:output_type -> mapping.DeletePurposeResponse
+	50, // 99: mapping.Mapping.CreateLegalHold:output_type -> mapping.CreateLegalHoldResponse
+	52, // 100: mapping.Mapping.ListLegalHolds:output_type -> mapping.ListLegalHoldsResponse
+	54, // 101: mapping.Mapping.ReleaseLegalHold:output_type -> mapping.ReleaseLegalHoldResponse
+	59, // 102: mapping.Mapping.GetDestructionAct:output_type -> mapping.GetDestructionActResponse
+	61, // 103: mapping.Mapping.VerifyAuditLog:output_type -> mapping.VerifyAuditLogResponse
+	77, // [77:104] is the sub-list for method output_type
+	50, // [50:77] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_api_mapping_proto_init() }
@@ -2053,7 +4389,7 @@ func file_api_mapping_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_mapping_proto_rawDesc), len(file_api_mapping_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   34,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -33,8 +33,19 @@ const (
 	Mapping_GetKindByName_FullMethodName       = "/mapping.Mapping/GetKindByName"
 	Mapping_CreateAuditLog_FullMethodName      = "/mapping.Mapping/CreateAuditLog"
 	Mapping_GetAuditLogList_FullMethodName     = "/mapping.Mapping/GetAuditLogList"
+	Mapping_ExportAuditLog_FullMethodName      = "/mapping.Mapping/ExportAuditLog"
 	Mapping_UpdateMappingDek_FullMethodName    = "/mapping.Mapping/UpdateMappingDek"
 	Mapping_UpdateMappingCrypto_FullMethodName = "/mapping.Mapping/UpdateMappingCrypto"
+	Mapping_ListSubjectMappings_FullMethodName = "/mapping.Mapping/ListSubjectMappings"
+	Mapping_EraseSubject_FullMethodName        = "/mapping.Mapping/EraseSubject"
+	Mapping_CreatePurpose_FullMethodName       = "/mapping.Mapping/CreatePurpose"
+	Mapping_ListPurposes_FullMethodName        = "/mapping.Mapping/ListPurposes"
+	Mapping_DeletePurpose_FullMethodName       = "/mapping.Mapping/DeletePurpose"
+	Mapping_CreateLegalHold_FullMethodName     = "/mapping.Mapping/CreateLegalHold"
+	Mapping_ListLegalHolds_FullMethodName      = "/mapping.Mapping/ListLegalHolds"
+	Mapping_ReleaseLegalHold_FullMethodName    = "/mapping.Mapping/ReleaseLegalHold"
+	Mapping_GetDestructionAct_FullMethodName   = "/mapping.Mapping/GetDestructionAct"
+	Mapping_VerifyAuditLog_FullMethodName      = "/mapping.Mapping/VerifyAuditLog"
 )
 
 // MappingClient is the client API for Mapping service.
@@ -55,8 +66,19 @@ type MappingClient interface {
 	GetKindByName(ctx context.Context, in *GetKindByNameRequest, opts ...grpc.CallOption) (*GetKindByNameResponse, error)
 	CreateAuditLog(ctx context.Context, in *CreateAuditLogRequest, opts ...grpc.CallOption) (*CreateAuditLogResponse, error)
 	GetAuditLogList(ctx context.Context, in *GetAuditLogListRequest, opts ...grpc.CallOption) (*GetAuditLogListResponse, error)
+	ExportAuditLog(ctx context.Context, in *ExportAuditLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditLogEntry], error)
 	UpdateMappingDek(ctx context.Context, in *UpdateMappingDekRequest, opts ...grpc.CallOption) (*UpdateMappingDekResponse, error)
 	UpdateMappingCrypto(ctx context.Context, in *UpdateMappingCryptoRequest, opts ...grpc.CallOption) (*UpdateMappingCryptoResponse, error)
+	ListSubjectMappings(ctx context.Context, in *ListSubjectMappingsRequest, opts ...grpc.CallOption) (*ListSubjectMappingsResponse, error)
+	EraseSubject(ctx context.Context, in *EraseSubjectRequest, opts ...grpc.CallOption) (*EraseSubjectResponse, error)
+	CreatePurpose(ctx context.Context, in *CreatePurposeRequest, opts ...grpc.CallOption) (*CreatePurposeResponse, error)
+	ListPurposes(ctx context.Context, in *ListPurposesRequest, opts ...grpc.CallOption) (*ListPurposesResponse, error)
+	DeletePurpose(ctx context.Context, in *DeletePurposeRequest, opts ...grpc.CallOption) (*DeletePurposeResponse, error)
+	CreateLegalHold(ctx context.Context, in *CreateLegalHoldRequest, opts ...grpc.CallOption) (*CreateLegalHoldResponse, error)
+	ListLegalHolds(ctx context.Context, in *ListLegalHoldsRequest, opts ...grpc.CallOption) (*ListLegalHoldsResponse, error)
+	ReleaseLegalHold(ctx context.Context, in *ReleaseLegalHoldRequest, opts ...grpc.CallOption) (*ReleaseLegalHoldResponse, error)
+	GetDestructionAct(ctx context.Context, in *GetDestructionActRequest, opts ...grpc.CallOption) (*GetDestructionActResponse, error)
+	VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error)
 }
 
 type mappingClient struct {
@@ -207,6 +229,25 @@ func (c *mappingClient) GetAuditLogList(ctx context.Context, in *GetAuditLogList
 	return out, nil
 }
 
+func (c *mappingClient) ExportAuditLog(ctx context.Context, in *ExportAuditLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditLogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Mapping_ServiceDesc.Streams[0], Mapping_ExportAuditLog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAuditLogRequest, AuditLogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Mapping_ExportAuditLogClient = grpc.ServerStreamingClient[AuditLogEntry]
+
 func (c *mappingClient) UpdateMappingDek(ctx context.Context, in *UpdateMappingDekRequest, opts ...grpc.CallOption) (*UpdateMappingDekResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateMappingDekResponse)
@@ -227,6 +268,106 @@ func (c *mappingClient) UpdateMappingCrypto(ctx context.Context, in *UpdateMappi
 	return out, nil
 }
 
+func (c *mappingClient) ListSubjectMappings(ctx context.Context, in *ListSubjectMappingsRequest, opts ...grpc.CallOption) (*ListSubjectMappingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubjectMappingsResponse)
+	err := c.cc.Invoke(ctx, Mapping_ListSubjectMappings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) EraseSubject(ctx context.Context, in *EraseSubjectRequest, opts ...grpc.CallOption) (*EraseSubjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseSubjectResponse)
+	err := c.cc.Invoke(ctx, Mapping_EraseSubject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) CreatePurpose(ctx context.Context, in *CreatePurposeRequest, opts ...grpc.CallOption) (*CreatePurposeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePurposeResponse)
+	err := c.cc.Invoke(ctx, Mapping_CreatePurpose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) ListPurposes(ctx context.Context, in *ListPurposesRequest, opts ...grpc.CallOption) (*ListPurposesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPurposesResponse)
+	err := c.cc.Invoke(ctx, Mapping_ListPurposes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) DeletePurpose(ctx context.Context, in *DeletePurposeRequest, opts ...grpc.CallOption) (*DeletePurposeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePurposeResponse)
+	err := c.cc.Invoke(ctx, Mapping_DeletePurpose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) CreateLegalHold(ctx context.Context, in *CreateLegalHoldRequest, opts ...grpc.CallOption) (*CreateLegalHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateLegalHoldResponse)
+	err := c.cc.Invoke(ctx, Mapping_CreateLegalHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) ListLegalHolds(ctx context.Context, in *ListLegalHoldsRequest, opts ...grpc.CallOption) (*ListLegalHoldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLegalHoldsResponse)
+	err := c.cc.Invoke(ctx, Mapping_ListLegalHolds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) ReleaseLegalHold(ctx context.Context, in *ReleaseLegalHoldRequest, opts ...grpc.CallOption) (*ReleaseLegalHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseLegalHoldResponse)
+	err := c.cc.Invoke(ctx, Mapping_ReleaseLegalHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) GetDestructionAct(ctx context.Context, in *GetDestructionActRequest, opts ...grpc.CallOption) (*GetDestructionActResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDestructionActResponse)
+	err := c.cc.Invoke(ctx, Mapping_GetDestructionAct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mappingClient) VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyAuditLogResponse)
+	err := c.cc.Invoke(ctx, Mapping_VerifyAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MappingServer is the server API for Mapping service.
 // All implementations must embed UnimplementedMappingServer
 // for forward compatibility.
@@ -245,8 +386,19 @@ type MappingServer interface {
 	GetKindByName(context.Context, *GetKindByNameRequest) (*GetKindByNameResponse, error)
 	CreateAuditLog(context.Context, *CreateAuditLogRequest) (*CreateAuditLogResponse, error)
 	GetAuditLogList(context.Context, *GetAuditLogListRequest) (*GetAuditLogListResponse, error)
+	ExportAuditLog(*ExportAuditLogRequest, grpc.ServerStreamingServer[AuditLogEntry]) error
 	UpdateMappingDek(context.Context, *UpdateMappingDekRequest) (*UpdateMappingDekResponse, error)
 	UpdateMappingCrypto(context.Context, *UpdateMappingCryptoRequest) (*UpdateMappingCryptoResponse, error)
+	ListSubjectMappings(context.Context, *ListSubjectMappingsRequest) (*ListSubjectMappingsResponse, error)
+	EraseSubject(context.Context, *EraseSubjectRequest) (*EraseSubjectResponse, error)
+	CreatePurpose(context.Context, *CreatePurposeRequest) (*CreatePurposeResponse, error)
+	ListPurposes(context.Context, *ListPurposesRequest) (*ListPurposesResponse, error)
+	DeletePurpose(context.Context, *DeletePurposeRequest) (*DeletePurposeResponse, error)
+	CreateLegalHold(context.Context, *CreateLegalHoldRequest) (*CreateLegalHoldResponse, error)
+	ListLegalHolds(context.Context, *ListLegalHoldsRequest) (*ListLegalHoldsResponse, error)
+	ReleaseLegalHold(context.Context, *ReleaseLegalHoldRequest) (*ReleaseLegalHoldResponse, error)
+	GetDestructionAct(context.Context, *GetDestructionActRequest) (*GetDestructionActResponse, error)
+	VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error)
 	mustEmbedUnimplementedMappingServer()
 }
 
@@ -299,12 +451,45 @@ func (UnimplementedMappingServer) CreateAuditLog(context.Context, *CreateAuditLo
 func (UnimplementedMappingServer) GetAuditLogList(context.Context, *GetAuditLogListRequest) (*GetAuditLogListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLogList not implemented")
 }
+func (UnimplementedMappingServer) ExportAuditLog(*ExportAuditLogRequest, grpc.ServerStreamingServer[AuditLogEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditLog not implemented")
+}
 func (UnimplementedMappingServer) UpdateMappingDek(context.Context, *UpdateMappingDekRequest) (*UpdateMappingDekResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateMappingDek not implemented")
 }
 func (UnimplementedMappingServer) UpdateMappingCrypto(context.Context, *UpdateMappingCryptoRequest) (*UpdateMappingCryptoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateMappingCrypto not implemented")
 }
+func (UnimplementedMappingServer) ListSubjectMappings(context.Context, *ListSubjectMappingsRequest) (*ListSubjectMappingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubjectMappings not implemented")
+}
+func (UnimplementedMappingServer) EraseSubject(context.Context, *EraseSubjectRequest) (*EraseSubjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseSubject not implemented")
+}
+func (UnimplementedMappingServer) CreatePurpose(context.Context, *CreatePurposeRequest) (*CreatePurposeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurpose not implemented")
+}
+func (UnimplementedMappingServer) ListPurposes(context.Context, *ListPurposesRequest) (*ListPurposesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPurposes not implemented")
+}
+func (UnimplementedMappingServer) DeletePurpose(context.Context, *DeletePurposeRequest) (*DeletePurposeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePurpose not implemented")
+}
+func (UnimplementedMappingServer) CreateLegalHold(context.Context, *CreateLegalHoldRequest) (*CreateLegalHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLegalHold not implemented")
+}
+func (UnimplementedMappingServer) ListLegalHolds(context.Context, *ListLegalHoldsRequest) (*ListLegalHoldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLegalHolds not implemented")
+}
+func (UnimplementedMappingServer) ReleaseLegalHold(context.Context, *ReleaseLegalHoldRequest) (*ReleaseLegalHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLegalHold not implemented")
+}
+func (UnimplementedMappingServer) GetDestructionAct(context.Context, *GetDestructionActRequest) (*GetDestructionActResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDestructionAct not implemented")
+}
+func (UnimplementedMappingServer) VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuditLog not implemented")
+}
 func (UnimplementedMappingServer) mustEmbedUnimplementedMappingServer() {}
 func (UnimplementedMappingServer) testEmbeddedByValue()                 {}
 
@@ -578,6 +763,17 @@ func _Mapping_GetAuditLogList_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mapping_ExportAuditLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAuditLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MappingServer).ExportAuditLog(m, &grpc.GenericServerStream[ExportAuditLogRequest, AuditLogEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Mapping_ExportAuditLogServer = grpc.ServerStreamingServer[AuditLogEntry]
+
 func _Mapping_UpdateMappingDek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateMappingDekRequest)
 	if err := dec(in); err != nil {
@@ -614,6 +810,186 @@ func _Mapping_UpdateMappingCrypto_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mapping_ListSubjectMappings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubjectMappingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).ListSubjectMappings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_ListSubjectMappings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).ListSubjectMappings(ctx, req.(*ListSubjectMappingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_EraseSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).EraseSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_EraseSubject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).EraseSubject(ctx, req.(*EraseSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_CreatePurpose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).CreatePurpose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_CreatePurpose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).CreatePurpose(ctx, req.(*CreatePurposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_ListPurposes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPurposesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).ListPurposes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_ListPurposes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).ListPurposes(ctx, req.(*ListPurposesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_DeletePurpose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePurposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).DeletePurpose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_DeletePurpose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).DeletePurpose(ctx, req.(*DeletePurposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_CreateLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).CreateLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_CreateLegalHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).CreateLegalHold(ctx, req.(*CreateLegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_ListLegalHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLegalHoldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).ListLegalHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_ListLegalHolds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).ListLegalHolds(ctx, req.(*ListLegalHoldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_ReleaseLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).ReleaseLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_ReleaseLegalHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).ReleaseLegalHold(ctx, req.(*ReleaseLegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_GetDestructionAct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDestructionActRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).GetDestructionAct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_GetDestructionAct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).GetDestructionAct(ctx, req.(*GetDestructionActRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mapping_VerifyAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MappingServer).VerifyAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mapping_VerifyAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MappingServer).VerifyAuditLog(ctx, req.(*VerifyAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Mapping_ServiceDesc is the grpc.ServiceDesc for Mapping service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -685,7 +1061,53 @@ var Mapping_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateMappingCrypto",
 			Handler:    _Mapping_UpdateMappingCrypto_Handler,
 		},
+		{
+			MethodName: "ListSubjectMappings",
+			Handler:    _Mapping_ListSubjectMappings_Handler,
+		},
+		{
+			MethodName: "EraseSubject",
+			Handler:    _Mapping_EraseSubject_Handler,
+		},
+		{
+			MethodName: "CreatePurpose",
+			Handler:    _Mapping_CreatePurpose_Handler,
+		},
+		{
+			MethodName: "ListPurposes",
+			Handler:    _Mapping_ListPurposes_Handler,
+		},
+		{
+			MethodName: "DeletePurpose",
+			Handler:    _Mapping_DeletePurpose_Handler,
+		},
+		{
+			MethodName: "CreateLegalHold",
+			Handler:    _Mapping_CreateLegalHold_Handler,
+		},
+		{
+			MethodName: "ListLegalHolds",
+			Handler:    _Mapping_ListLegalHolds_Handler,
+		},
+		{
+			MethodName: "ReleaseLegalHold",
+			Handler:    _Mapping_ReleaseLegalHold_Handler,
+		},
+		{
+			MethodName: "GetDestructionAct",
+			Handler:    _Mapping_GetDestructionAct_Handler,
+		},
+		{
+			MethodName: "VerifyAuditLog",
+			Handler:    _Mapping_VerifyAuditLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAuditLog",
+			Handler:       _Mapping_ExportAuditLog_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/mapping.proto",
 }
@@ -11,11 +11,15 @@ type WriterConfig struct {
 	Brokers []string `yaml:"brokers" env:"BROKERS" env-separator:","`
 }
 
+// NewWriter creates a writer that waits for all in-sync replicas to acknowledge every
+// message. Messages with the same key go to the same partition, so their order is kept;
+// messages without a key are spread round robin.
 func NewWriter(ctx context.Context, cfg *WriterConfig, topic string) *kafka.Writer {
 	w := &kafka.Writer{
-		Addr:     kafka.TCP(cfg.Brokers...),
-		Topic:    topic,
-		Balancer: &kafka.LeastBytes{},
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
 	}
 	logger.GetLoggerFromCtx(ctx).Info(ctx, "kafka writer initialized",
 		slog.String("topic", topic),
@@ -5,15 +5,25 @@ import (
 	"crypto/tls"
 	"fmt"
 	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/common/redis"
 	"github.com/NeF2le/anonix/common/tls_helpers"
 	"github.com/NeF2le/anonix/gateway/internal/config"
 	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
 	"github.com/NeF2le/anonix/gateway/internal/handlers/http_handlers"
 	"github.com/NeF2le/anonix/gateway/internal/handlers/middlewares"
+	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/anomaly_adapters"
+	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/approval_adapters"
 	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/auth_service_adapters"
+	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/break_glass_adapters"
 	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/mapping_service_adapters"
+	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/rate_limit_adapters"
+	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/token_revocation_adapters"
 	"github.com/NeF2le/anonix/gateway/internal/ports/adapters/tokenizer_service_adapters"
 	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/NeF2le/anonix/gateway/internal/services/oidc"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/NeF2le/anonix/gateway/policies"
 	_ "github.com/NeF2le/anonix/gateway/swagger"
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
@@ -93,20 +103,192 @@ func main() {
 		mainConfig.GrpcPool.BaseRetryDelayMilliseconds,
 	)
 
-	tokenizerServiceHandler := http_handlers.NewTokenizerServiceHandler(tokenizerService, mappingService)
-	mappingServiceHandler := http_handlers.NewMappingServiceHandler(mappingService)
-	authServiceHandler := http_handlers.NewAuthServiceHandler(authService)
-	keyRotationHandler := http_handlers.NewKeyRotationHandler(tokenizerService, mappingService)
+	var breakGlassService *services.BreakGlassService
+	if breakGlassCfg := mainConfig.BreakGlass; breakGlassCfg.Enabled {
+		redisClient, err := redis.NewRedisClient(ctx, &mainConfig.Redis, breakGlassCfg.RedisDB)
+		if err != nil {
+			panic(err)
+		}
+		breakGlassService, err = services.NewBreakGlassService(
+			break_glass_adapters.NewBreakGlassAdapterRedis(redisClient),
+			breakGlassCfg.MaxDuration,
+			breakGlassCfg.MinJustificationLength,
+			breakGlassCfg.Retention,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	var tokenRevocation *services.TokenRevocation
+	if revocationCfg := mainConfig.Revocation; revocationCfg.Enabled {
+		redisClient, err := redis.NewRedisClient(ctx, &mainConfig.Redis, revocationCfg.RedisDB)
+		if err != nil {
+			panic(err)
+		}
+		tokenRevocation, err = services.NewTokenRevocation(
+			token_revocation_adapters.NewTokenRevocationAdapterRedis(redisClient),
+			revocationCfg.TTL,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	policyEngine, err := policy.NewEngine(policies.Default, mainConfig.Policy.File, domain.PolicyActions)
+	if err != nil {
+		panic(err)
+	}
+	go policyEngine.Watch(ctx, mainConfig.Policy.ReloadInterval)
+	authorizer := helpers.NewAuthorizer(policyEngine)
+
+	auditor, err := helpers.NewAuditor(mappingService, mainConfig.Audit.DefaultPolicy, mainConfig.Audit.Policies, breakGlassService)
+	if err != nil {
+		panic(err)
+	}
+
+	var anomalyDetector *services.AnomalyDetector
+	if anomalyCfg := mainConfig.Anomaly; anomalyCfg.Enabled {
+		redisClient, err := redis.NewRedisClient(ctx, &mainConfig.Redis, anomalyCfg.RedisDB)
+		if err != nil {
+			panic(err)
+		}
+		anomalyDetector, err = services.NewAnomalyDetector(
+			anomaly_adapters.NewAnomalyAdapterRedis(redisClient),
+			anomalyCfg.Window,
+			anomalyCfg.Baseline,
+			anomalyCfg.LevelBaselines,
+			anomalyCfg.KindBaselines,
+			anomalyCfg.ThresholdFactor,
+			anomalyCfg.Action,
+			anomalyCfg.BlockDuration,
+			anomalyCfg.FailOpen,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	var rateLimiter *services.RateLimiter
+	if rateLimitCfg := mainConfig.RateLimit; rateLimitCfg.Enabled {
+		redisClient, err := redis.NewRedisClient(ctx, &mainConfig.Redis, rateLimitCfg.RedisDB)
+		if err != nil {
+			panic(err)
+		}
+		rateLimiter, err = services.NewRateLimiter(
+			rate_limit_adapters.NewRateLimitAdapterRedis(redisClient),
+			rateLimitCfg.GroupLimits,
+			rateLimitCfg.RoleLimits,
+			rateLimitCfg.UserLimits,
+			rateLimitCfg.DetokenizeQuotas,
+			rateLimitCfg.LoginPerIP,
+			rateLimitCfg.LoginPerLogin,
+			rateLimitCfg.FailOpen,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	var oidcProvider *oidc.Provider
+	var oidcClaimMapping *oidc.ClaimMapping
+	if oidcCfg := mainConfig.OIDC; oidcCfg.Enabled {
+		oidcProvider, err = oidc.NewProvider(
+			oidcCfg.ProviderName,
+			oidcCfg.IssuerURL,
+			oidcCfg.ClientID,
+			oidcCfg.ClientSecret,
+			oidcCfg.RedirectURL,
+			oidcCfg.Scopes,
+			oidcCfg.Timeout,
+		)
+		if err != nil {
+			panic(err)
+		}
+		oidcClaimMapping, err = oidc.NewClaimMapping(
+			oidcCfg.LoginClaim,
+			oidcCfg.GroupsClaim,
+			oidcCfg.RoleMapping,
+			oidcCfg.ClearanceMapping,
+			oidcCfg.DefaultRoles,
+			oidcCfg.DefaultClearance,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
 
 	authMiddleware := middlewares.NewAuthMiddleware(
 		mainConfig.JWTSecret,
 		authService,
 		mainConfig.AccessTokenCookieTTL,
 		mainConfig.RefreshTokenCookieTTL,
+		anomalyDetector,
+		breakGlassService,
+		tokenRevocation,
+	)
+
+	var approvalService *services.ApprovalService
+	var approvalGate *helpers.ApprovalGate
+	if approvalCfg := mainConfig.Approval; approvalCfg.Enabled {
+		redisClient, err := redis.NewRedisClient(ctx, &mainConfig.Redis, approvalCfg.RedisDB)
+		if err != nil {
+			panic(err)
+		}
+		approvalService, err = services.NewApprovalService(
+			approval_adapters.NewApprovalAdapterRedis(redisClient),
+			approvalCfg.Operations,
+			approvalCfg.DetokenizeMinLevel,
+			approvalCfg.Window,
+			approvalCfg.Retention,
+		)
+		if err != nil {
+			panic(err)
+		}
+		approvalGate = helpers.NewApprovalGate(approvalService, auditor, authorizer, authMiddleware)
+	}
+
+	tokenizerServiceHandler := http_handlers.NewTokenizerServiceHandler(tokenizerService, mappingService, auditor, authorizer, anomalyDetector, rateLimiter, approvalGate)
+	mappingServiceHandler := http_handlers.NewMappingServiceHandler(mappingService, auditor, authorizer, mainConfig.IncludeCryptoAllowed)
+	authServiceHandler := http_handlers.NewAuthServiceHandler(authService, auditor, anomalyDetector, rateLimiter, tokenRevocation)
+	keyRotationHandler := http_handlers.NewKeyRotationHandler(tokenizerService, mappingService, auditor)
+	subjectHandler := http_handlers.NewSubjectHandler(mappingService, auditor, authorizer)
+	legalHoldHandler := http_handlers.NewLegalHoldHandler(mappingService, auditor)
+	reportHandler := http_handlers.NewReportHandler(mappingService)
+	anomalyHandler := http_handlers.NewAnomalyHandler(anomalyDetector, auditor)
+	approvalHandler := http_handlers.NewApprovalHandler(approvalService, approvalGate, auditor, authorizer)
+	breakGlassHandler := http_handlers.NewBreakGlassHandler(breakGlassService, authService, auditor, mainConfig.AccessTokenCookieTTL)
+	accessGrantHandler := http_handlers.NewAccessGrantHandler(authService, mappingService, auditor, tokenRevocation)
+	kindOperationGrantHandler := http_handlers.NewKindOperationGrantHandler(authService, mappingService, auditor, tokenRevocation)
+	roleHandler := http_handlers.NewRoleHandler(authService, auditor, tokenRevocation)
+	serviceAccountHandler := http_handlers.NewServiceAccountHandler(authService, mappingService, authorizer, auditor, tokenRevocation)
+	policyHandler := http_handlers.NewPolicyHandler(policyEngine, authorizer)
+	oauthHandler := http_handlers.NewOAuthHandler(authService, auditor)
+	oidcHandler := http_handlers.NewOidcHandler(
+		oidcProvider,
+		oidcClaimMapping,
+		authService,
+		auditor,
+		anomalyDetector,
+		mainConfig.JWTSecret,
+		mainConfig.OIDC.StateTTL,
+		mainConfig.OIDC.PostLoginURL,
+		mainConfig.OIDC.ErrorURL,
+		mainConfig.AccessTokenCookieTTL,
+		mainConfig.RefreshTokenCookieTTL,
 	)
-	rbacMiddleware := middlewares.NewRBACMiddleware()
+
+	policyMiddleware := middlewares.NewPolicyMiddleware(authorizer, auditor)
+	rateLimitMiddleware := middlewares.NewRateLimitMiddleware(rateLimiter)
+	approvalMiddleware := middlewares.NewApprovalMiddleware(approvalGate)
 
 	app := echo.New()
+	// Client addresses are recorded in the audit log and checked against the address
+	// allowlists of API keys: X-Forwarded-For is only trusted from the configured proxies.
+	app.IPExtractor, err = helpers.NewIPExtractor(mainConfig.Gateway.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
 	app.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	adminGroup := app.Group("/admin")
@@ -137,78 +319,210 @@ func main() {
 	v1Group.Use(middlewares.LoggingMiddleware)
 
 	tokenizerGroup := v1Group.Group("/tokenizer")
-	tokenizerGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin, domain.RoleSpecialist))
+	tokenizerGroup.Use(authMiddleware.CheckAuth)
 	{
-		tokenizerGroup.POST("/tokenize", tokenizerServiceHandler.Tokenize)
-		tokenizerGroup.POST("/detokenize", tokenizerServiceHandler.Detokenize)
+		tokenizerGroup.POST("/tokenize", tokenizerServiceHandler.Tokenize,
+			policyMiddleware.Authorize(domain.PolicyActionTokenize),
+			rateLimitMiddleware.Limit(domain.RateGroupTokenize))
+		tokenizerGroup.POST("/detokenize", tokenizerServiceHandler.Detokenize,
+			policyMiddleware.Authorize(domain.PolicyActionDetokenize),
+			rateLimitMiddleware.Limit(domain.RateGroupDetokenize),
+			approvalMiddleware.Require(domain.AuditActionDetokenize, tokenizerServiceHandler.Detokenize))
 	}
 
 	mappingReadGroup := v1Group.Group("/mappings")
-	mappingReadGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin, domain.RoleSpecialist, domain.RoleAuditor))
+	mappingReadGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionMappingRead))
 	{
 		mappingReadGroup.GET("/:id", mappingServiceHandler.GetMapping)
 		mappingReadGroup.GET("/", mappingServiceHandler.GetMappingList)
 	}
 
 	mappingWriteGroup := v1Group.Group("/mappings")
-	mappingWriteGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin, domain.RoleSpecialist))
+	mappingWriteGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionMappingWrite))
 	{
-		mappingWriteGroup.DELETE("/:id", mappingServiceHandler.DeleteMapping)
+		mappingWriteGroup.DELETE("/:id", mappingServiceHandler.DeleteMapping, approvalMiddleware.Require(domain.AuditActionMappingDelete, mappingServiceHandler.DeleteMapping))
 		mappingWriteGroup.PATCH("/:id", mappingServiceHandler.UpdateMapping)
 	}
 
+	subjectGroup := v1Group.Group("/subjects")
+	subjectGroup.Use(authMiddleware.CheckAuth)
+	{
+		subjectGroup.GET("/:ref/mappings", subjectHandler.GetSubjectMappings, policyMiddleware.Authorize(domain.PolicyActionSubjectRead))
+		subjectGroup.DELETE("/:ref", subjectHandler.EraseSubject, policyMiddleware.Authorize(domain.PolicyActionSubjectErase), approvalMiddleware.Require(domain.AuditActionErase, subjectHandler.EraseSubject))
+	}
+
+	legalHoldReadGroup := v1Group.Group("/legal-holds")
+	legalHoldReadGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionHoldRead))
+	{
+		legalHoldReadGroup.GET("/", legalHoldHandler.GetLegalHolds)
+	}
+
+	legalHoldWriteGroup := v1Group.Group("/legal-holds")
+	legalHoldWriteGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionHoldWrite), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
+	{
+		legalHoldWriteGroup.POST("/", legalHoldHandler.CreateLegalHold)
+		legalHoldWriteGroup.POST("/:id/release", legalHoldHandler.ReleaseLegalHold, approvalMiddleware.Require(domain.AuditActionHoldRelease, legalHoldHandler.ReleaseLegalHold))
+	}
+
+	reportGroup := v1Group.Group("/reports")
+	reportGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionReportRead))
+	{
+		reportGroup.GET("/destruction", reportHandler.GetDestructionAct)
+	}
+
 	kindReadGroup := v1Group.Group("/kinds")
-	kindReadGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin, domain.RoleSpecialist, domain.RoleAuditor))
+	kindReadGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionKindRead))
 	{
 		kindReadGroup.GET("/:id", mappingServiceHandler.GetKind)
 		kindReadGroup.GET("/", mappingServiceHandler.GetKindList)
 	}
 
 	kindWriteGroup := v1Group.Group("/kinds")
-	kindWriteGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin))
+	kindWriteGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionKindWrite), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
 	{
 		kindWriteGroup.POST("/", mappingServiceHandler.CreateKind)
 		kindWriteGroup.PATCH("/:id", mappingServiceHandler.UpdateKind)
-		kindWriteGroup.DELETE("/:id", mappingServiceHandler.DeleteKind)
+		kindWriteGroup.DELETE("/:id", mappingServiceHandler.DeleteKind, approvalMiddleware.Require(domain.AuditActionKindDelete, mappingServiceHandler.DeleteKind))
+	}
+
+	purposeReadGroup := v1Group.Group("/purposes")
+	purposeReadGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionPurposeRead))
+	{
+		purposeReadGroup.GET("/", mappingServiceHandler.GetPurposeList)
+	}
+
+	purposeWriteGroup := v1Group.Group("/purposes")
+	purposeWriteGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionPurposeWrite), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
+	{
+		purposeWriteGroup.POST("/", mappingServiceHandler.CreatePurpose)
+		purposeWriteGroup.DELETE("/:id", mappingServiceHandler.DeletePurpose)
 	}
 
 	authGroup := v1Group.Group("/auth")
+	authGroup.Use(rateLimitMiddleware.Limit(domain.RateGroupAuth))
 	{
 		authGroup.POST("/signIn", authServiceHandler.Login)
 		authGroup.POST("/signUp", authServiceHandler.Register)
 		authGroup.POST("/refresh", authServiceHandler.Refresh)
 		authGroup.GET("/me", authServiceHandler.GetMe, authMiddleware.CheckAuth)
+		authGroup.GET("/oidc", oidcHandler.GetOidcInfo)
+		authGroup.GET("/oidc/login", oidcHandler.Login)
+		authGroup.GET("/oidc/callback", oidcHandler.Callback)
+	}
+
+	oauthGroup := v1Group.Group("/oauth")
+	oauthGroup.Use(rateLimitMiddleware.Limit(domain.RateGroupAuth))
+	{
+		oauthGroup.POST("/token", oauthHandler.Token)
 	}
 
 	userGroup := v1Group.Group("/user")
-	userGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin))
+	userGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionUserManage), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
 	{
 		userGroup.POST("/isAdmin", authServiceHandler.IsAdmin)
 		userGroup.GET("/list", authServiceHandler.GetUsers)
-		userGroup.DELETE("/delete", authServiceHandler.DeleteUser)
+		userGroup.DELETE("/delete", authServiceHandler.DeleteUser, approvalMiddleware.Require(domain.AuditActionUserDelete, authServiceHandler.DeleteUser))
 		userGroup.GET("/roles", authServiceHandler.GetUserRoles)
-		userGroup.POST("/assignRole", authServiceHandler.AssignRole)
+		userGroup.POST("/assignRole", authServiceHandler.AssignRole, approvalMiddleware.Require(domain.AuditActionRoleAssign, authServiceHandler.AssignRole))
 		userGroup.DELETE("/removeRole", authServiceHandler.RemoveRole)
-		userGroup.PATCH("/clearance", authServiceHandler.UpdateClearance)
+		userGroup.PATCH("/clearance", authServiceHandler.UpdateClearance, approvalMiddleware.Require(domain.AuditActionClearanceUpdate, authServiceHandler.UpdateClearance))
+		userGroup.GET("/grants", accessGrantHandler.GetAccessGrants)
+		userGroup.POST("/grants", accessGrantHandler.CreateAccessGrant, approvalMiddleware.Require(domain.AuditActionGrantCreate, accessGrantHandler.CreateAccessGrant))
+		userGroup.DELETE("/grants/:id", accessGrantHandler.RevokeAccessGrant)
+		userGroup.GET("/kind-operations", kindOperationGrantHandler.GetKindOperationGrants)
+		userGroup.PUT("/kind-operations", kindOperationGrantHandler.SetKindOperationGrant, approvalMiddleware.Require(domain.AuditActionKindOperationGrantSet, kindOperationGrantHandler.SetKindOperationGrant))
+		userGroup.DELETE("/kind-operations/:id", kindOperationGrantHandler.DeleteKindOperationGrant)
 	}
 
 	roleGroup := v1Group.Group("/role")
-	roleGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin))
+	roleGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionRoleRead), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
 	{
 		roleGroup.GET("/list", authServiceHandler.GetRolesList)
+		roleGroup.GET("/permissions", roleHandler.GetPermissions)
+		roleGroup.POST("/", roleHandler.CreateRole, policyMiddleware.Authorize(domain.PolicyActionRoleManage), approvalMiddleware.Require(domain.AuditActionRoleCreate, roleHandler.CreateRole))
+		roleGroup.PUT("/:id", roleHandler.UpdateRole, policyMiddleware.Authorize(domain.PolicyActionRoleManage), approvalMiddleware.Require(domain.AuditActionRoleUpdate, roleHandler.UpdateRole))
+		roleGroup.DELETE("/:id", roleHandler.DeleteRole, policyMiddleware.Authorize(domain.PolicyActionRoleManage))
+	}
+
+	serviceAccountGroup := v1Group.Group("/service-accounts")
+	serviceAccountGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionServiceAccountManage), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
+	{
+		serviceAccountGroup.GET("/", serviceAccountHandler.GetServiceAccounts)
+		serviceAccountGroup.POST("/", serviceAccountHandler.CreateServiceAccount)
+		serviceAccountGroup.DELETE("/:id", serviceAccountHandler.DeleteServiceAccount)
+		serviceAccountGroup.GET("/keys", serviceAccountHandler.GetApiKeys)
+		serviceAccountGroup.POST("/:id/keys", serviceAccountHandler.CreateApiKey)
+		serviceAccountGroup.POST("/keys/:id/rotate", serviceAccountHandler.RotateApiKey)
+		serviceAccountGroup.DELETE("/keys/:id", serviceAccountHandler.RevokeApiKey)
 	}
 
 	auditGroup := v1Group.Group("/audit")
-	auditGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin, domain.RoleAuditor))
+	auditGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionAuditRead))
 	{
 		auditGroup.GET("/", mappingServiceHandler.GetAuditLogList)
+		auditGroup.GET("/verify", mappingServiceHandler.VerifyAuditLog)
+		auditGroup.GET("/export", mappingServiceHandler.ExportAuditLog)
 	}
 
 	keysGroup := v1Group.Group("/admin/keys")
-	keysGroup.Use(authMiddleware.CheckAuth, rbacMiddleware.CheckRole(domain.RoleAdmin))
+	keysGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionKeyRotate), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
+	{
+		keysGroup.POST("/rotate-master", keyRotationHandler.RotateMasterKey, approvalMiddleware.Require(domain.AuditActionRotateMasterKey, keyRotationHandler.RotateMasterKey))
+		keysGroup.POST("/rotate-deks", keyRotationHandler.RotateAllDeks, approvalMiddleware.Require(domain.AuditActionRotateDeks, keyRotationHandler.RotateAllDeks))
+	}
+
+	policyGroup := v1Group.Group("/policies")
+	policyGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionPolicyRead))
+	{
+		policyGroup.GET("/", policyHandler.GetPolicy)
+		policyGroup.POST("/explain", policyHandler.ExplainPolicy)
+	}
+
+	securityGroup := v1Group.Group("/security")
+	securityGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionSecurityManage), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
 	{
-		keysGroup.POST("/rotate-master", keyRotationHandler.RotateMasterKey)
-		keysGroup.POST("/rotate-deks", keyRotationHandler.RotateAllDeks)
+		securityGroup.GET("/blocks", anomalyHandler.GetBlocks)
+		securityGroup.DELETE("/blocks/:user_id", anomalyHandler.Unblock)
+	}
+
+	if approvalGate != nil {
+		if err = approvalGate.CheckOperations(); err != nil {
+			panic(err)
+		}
+
+		approvalReadGroup := v1Group.Group("/approvals")
+		approvalReadGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionApprovalUse))
+		{
+			approvalReadGroup.GET("/", approvalHandler.GetApprovals)
+			approvalReadGroup.GET("/:id", approvalHandler.GetApproval)
+			approvalReadGroup.POST("/:id/execute", approvalHandler.ExecuteApproval)
+		}
+
+		approvalWriteGroup := v1Group.Group("/approvals")
+		approvalWriteGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionApprovalReview), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
+		{
+			approvalWriteGroup.POST("/:id/approve", approvalHandler.ApproveApproval)
+			approvalWriteGroup.POST("/:id/reject", approvalHandler.RejectApproval)
+			approvalWriteGroup.POST("/expire", approvalHandler.ExpireApprovals)
+		}
+	}
+
+	if breakGlassService != nil {
+		breakGlassGroup := v1Group.Group("/break-glass")
+		breakGlassGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionBreakGlassUse), rateLimitMiddleware.Limit(domain.RateGroupAdmin))
+		{
+			breakGlassGroup.GET("/", breakGlassHandler.GetCurrentBreakGlass)
+			breakGlassGroup.POST("/", breakGlassHandler.StartBreakGlass)
+			breakGlassGroup.DELETE("/", breakGlassHandler.EndBreakGlass)
+		}
+
+		breakGlassReviewGroup := v1Group.Group("/break-glass/sessions")
+		breakGlassReviewGroup.Use(authMiddleware.CheckAuth, policyMiddleware.Authorize(domain.PolicyActionBreakGlassReview))
+		{
+			breakGlassReviewGroup.GET("/", breakGlassHandler.GetBreakGlassSessions)
+			breakGlassReviewGroup.GET("/:id", breakGlassHandler.GetBreakGlassSession)
+			breakGlassReviewGroup.POST("/:id/acknowledge", breakGlassHandler.AcknowledgeBreakGlassSession, policyMiddleware.Authorize(domain.PolicyActionBreakGlassAcknowledge))
+		}
 	}
 
 	if tlsCfg.Enabled {
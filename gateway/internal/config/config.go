@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"github.com/NeF2le/anonix/common/redis"
 	"github.com/NeF2le/anonix/common/tls_helpers"
 	"github.com/ilyakaznacheev/cleanenv"
 	"time"
@@ -32,8 +33,130 @@ type GrpcPoolConfig struct {
 	BaseRetryDelayMilliseconds time.Duration `yaml:"base_retry_delay_milliseconds" env:"BASE_RETRY_DELAY_MILLISECONDS" env-default:"200ms"`
 }
 
+// Gateway configures the HTTP server. TrustedProxies lists the addresses or CIDRs of
+// the reverse proxies whose X-Forwarded-For header gives the client address; without
+// them the address of the peer is used.
 type Gateway struct {
-	Host string `yaml:"host" env:"HOST" env-required:"true"`
+	Host           string   `yaml:"host" env:"HOST" env-required:"true"`
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+}
+
+// AuditConfig sets the audit policy of gateway actions: fail_closed actions are not
+// performed if their audit entry cannot be written, best_effort ones only log the
+// failure. Policies maps actions to policies as "action:policy,action:policy".
+type AuditConfig struct {
+	DefaultPolicy string            `yaml:"default_policy" env:"DEFAULT_POLICY" env-default:"best_effort"`
+	Policies      map[string]string `yaml:"policies" env:"POLICIES" env-default:"tokenize:fail_closed,detokenize:fail_closed,mapping_read:fail_closed,mapping_list:fail_closed,mapping_update:fail_closed,mapping_delete:fail_closed,subject_read:fail_closed"`
+}
+
+// AnomalyConfig configures the detector of mass detokenization. A user exceeding
+// ThresholdFactor times the baseline of a dimension within Window is restricted
+// according to Action ("block" or "reauth") for BlockDuration. LevelBaselines maps
+// access levels and KindBaselines kind names to baselines as "key:baseline,...".
+// FailOpen lets requests through while restrictions cannot be checked; by default they
+// are rejected.
+type AnomalyConfig struct {
+	Enabled         bool              `yaml:"enabled" env:"ENABLED" env-default:"true"`
+	RedisDB         int               `yaml:"redis_db" env:"REDIS_DB" env-default:"2"`
+	Window          time.Duration     `yaml:"window" env:"WINDOW" env-default:"1h"`
+	Baseline        int64             `yaml:"baseline" env:"BASELINE" env-default:"100"`
+	LevelBaselines  map[string]string `yaml:"level_baselines" env:"LEVEL_BASELINES" env-default:"1:100,2:50,3:20,4:5"`
+	KindBaselines   map[string]string `yaml:"kind_baselines" env:"KIND_BASELINES"`
+	ThresholdFactor float64           `yaml:"threshold_factor" env:"THRESHOLD_FACTOR" env-default:"3"`
+	Action          string            `yaml:"action" env:"ACTION" env-default:"block"`
+	BlockDuration   time.Duration     `yaml:"block_duration" env:"BLOCK_DURATION" env-default:"1h"`
+	FailOpen        bool              `yaml:"fail_open" env:"FAIL_OPEN" env-default:"false"`
+}
+
+// RateLimitConfig configures the rate limiter. Limits are written as "requests/period"
+// (e.g. "600/1m"): GroupLimits maps route groups (tokenize, detokenize, admin, auth) to
+// limits per user, RoleLimits and UserLimits override them for "group.role" and
+// "group.userID". DetokenizeQuotas maps access levels to daily detokenizations per user.
+// LoginPerIP and LoginPerLogin limit login attempts; empty disables the limit.
+// FailOpen lets detokenizations through while their daily quota cannot be checked; by
+// default they are rejected. Request rate limits are not enforced while Redis is
+// unavailable either way.
+type RateLimitConfig struct {
+	Enabled          bool              `yaml:"enabled" env:"ENABLED" env-default:"true"`
+	RedisDB          int               `yaml:"redis_db" env:"REDIS_DB" env-default:"3"`
+	GroupLimits      map[string]string `yaml:"group_limits" env:"GROUPS" env-default:"tokenize:600/1m,detokenize:120/1m,admin:60/1m,auth:30/1m"`
+	RoleLimits       map[string]string `yaml:"role_limits" env:"ROLES"`
+	UserLimits       map[string]string `yaml:"user_limits" env:"USERS"`
+	DetokenizeQuotas map[string]string `yaml:"detokenize_quotas" env:"DETOKENIZE_DAILY_QUOTAS" env-default:"1:5000,2:1000,3:200,4:50"`
+	LoginPerIP       string            `yaml:"login_per_ip" env:"LOGIN_PER_IP" env-default:"20/1m"`
+	LoginPerLogin    string            `yaml:"login_per_login" env:"LOGIN_PER_LOGIN" env-default:"5/1m"`
+	FailOpen         bool              `yaml:"fail_open" env:"FAIL_OPEN" env-default:"false"`
+}
+
+// ApprovalConfig configures the four-eyes approval of sensitive operations. Operations
+// are named by their audit actions; detokenization requires approval only for kinds of
+// at least DetokenizeMinLevel. A request must be approved within Window; approved
+// detokenizations must be executed within Window after approval. Decided requests are
+// kept for Retention.
+type ApprovalConfig struct {
+	Enabled            bool          `yaml:"enabled" env:"ENABLED" env-default:"true"`
+	RedisDB            int           `yaml:"redis_db" env:"REDIS_DB" env-default:"4"`
+	Operations         []string      `yaml:"operations" env:"OPERATIONS" env-default:"rotate_master_key,rotate_deks,kind_delete,detokenize"`
+	DetokenizeMinLevel int32         `yaml:"detokenize_min_level" env:"DETOKENIZE_MIN_LEVEL" env-default:"4"`
+	Window             time.Duration `yaml:"window" env:"WINDOW" env-default:"24h"`
+	Retention          time.Duration `yaml:"retention" env:"RETENTION" env-default:"720h"`
+}
+
+// BreakGlassConfig configures emergency access above the clearance level. Users the
+// access policy allows may start a session lasting at most MaxDuration with a
+// justification of at least MinJustificationLength characters. Sessions are kept for Retention after
+// they expire, for review by an auditor.
+type BreakGlassConfig struct {
+	Enabled                bool          `yaml:"enabled" env:"ENABLED" env-default:"true"`
+	RedisDB                int           `yaml:"redis_db" env:"REDIS_DB" env-default:"5"`
+	MaxDuration            time.Duration `yaml:"max_duration" env:"MAX_DURATION" env-default:"1h"`
+	MinJustificationLength int           `yaml:"min_justification_length" env:"MIN_JUSTIFICATION_LENGTH" env-default:"20"`
+	Retention              time.Duration `yaml:"retention" env:"RETENTION" env-default:"2160h"`
+}
+
+// RevocationConfig configures the revocation of access tokens issued before the
+// access of their user was reduced, e.g. by revoking a grant. A revocation is kept for
+// TTL, which must be at least the access token lifetime of the auth service.
+type RevocationConfig struct {
+	Enabled bool          `yaml:"enabled" env:"ENABLED" env-default:"true"`
+	RedisDB int           `yaml:"redis_db" env:"REDIS_DB" env-default:"6"`
+	TTL     time.Duration `yaml:"ttl" env:"TTL" env-default:"24h"`
+}
+
+// PolicyConfig configures the access policy. The policy is read from File, or the
+// built-in one is used if File is empty; the file is checked for changes every
+// ReloadInterval, and 0 disables reloading.
+type PolicyConfig struct {
+	File           string        `yaml:"file" env:"FILE"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"RELOAD_INTERVAL" env-default:"30s"`
+}
+
+// OIDCConfig configures sign-in through an OpenID Connect provider with the
+// authorization code flow and PKCE. The gateway is the client ClientID of the provider
+// IssuerURL; RedirectURL is its callback, /api/v1/auth/oidc/callback. Users are created
+// with the login in LoginClaim on first sign-in. On every sign-in they get DefaultRoles
+// and the roles RoleMapping maps their groups in GroupsClaim to, and the highest of
+// DefaultClearance and the levels ClearanceMapping maps their groups to. Mappings are
+// written as "group:role" and "group:level". After sign-in the browser is sent to
+// PostLoginURL, or to ErrorURL with an sso_error query parameter.
+type OIDCConfig struct {
+	Enabled          bool              `yaml:"enabled" env:"ENABLED" env-default:"false"`
+	ProviderName     string            `yaml:"provider_name" env:"PROVIDER_NAME" env-default:"SSO"`
+	IssuerURL        string            `yaml:"issuer_url" env:"ISSUER_URL"`
+	ClientID         string            `yaml:"client_id" env:"CLIENT_ID"`
+	ClientSecret     string            `yaml:"client_secret" env:"CLIENT_SECRET"`
+	RedirectURL      string            `yaml:"redirect_url" env:"REDIRECT_URL"`
+	Scopes           []string          `yaml:"scopes" env:"SCOPES" env-default:"openid,profile,email"`
+	LoginClaim       string            `yaml:"login_claim" env:"LOGIN_CLAIM" env-default:"preferred_username"`
+	GroupsClaim      string            `yaml:"groups_claim" env:"GROUPS_CLAIM" env-default:"groups"`
+	RoleMapping      map[string]string `yaml:"role_mapping" env:"ROLE_MAPPING"`
+	ClearanceMapping map[string]string `yaml:"clearance_mapping" env:"CLEARANCE_MAPPING"`
+	DefaultRoles     []string          `yaml:"default_roles" env:"DEFAULT_ROLES"`
+	DefaultClearance int               `yaml:"default_clearance" env:"DEFAULT_CLEARANCE" env-default:"1"`
+	PostLoginURL     string            `yaml:"post_login_url" env:"POST_LOGIN_URL" env-default:"/admin/#menu"`
+	ErrorURL         string            `yaml:"error_url" env:"ERROR_URL" env-default:"/admin/#login"`
+	StateTTL         time.Duration     `yaml:"state_ttl" env:"STATE_TTL" env-default:"10m"`
+	Timeout          time.Duration     `yaml:"timeout" env:"TIMEOUT" env-default:"10s"`
 }
 
 type Config struct {
@@ -44,12 +167,22 @@ type Config struct {
 	HTTPPort      int                 `yaml:"http_port" env:"HTTP_PORT" env-default:"8080"`
 	Gateway       Gateway             `yaml:"gateway" env-prefix:"GATEWAY_"`
 	TLS           tls_helpers.Config  `yaml:"tls" env-prefix:"TLS_"`
+	Audit         AuditConfig         `yaml:"audit" env-prefix:"AUDIT_"`
+	Anomaly       AnomalyConfig       `yaml:"anomaly" env-prefix:"ANOMALY_"`
+	RateLimit     RateLimitConfig     `yaml:"rate_limit" env-prefix:"RATE_LIMIT_"`
+	Approval      ApprovalConfig      `yaml:"approval" env-prefix:"APPROVAL_"`
+	BreakGlass    BreakGlassConfig    `yaml:"break_glass" env-prefix:"BREAK_GLASS_"`
+	Revocation    RevocationConfig    `yaml:"token_revocation" env-prefix:"TOKEN_REVOCATION_"`
+	Policy        PolicyConfig        `yaml:"policy" env-prefix:"POLICY_"`
+	OIDC          OIDCConfig          `yaml:"oidc" env-prefix:"OIDC_"`
+	Redis         redis.Config        `yaml:"redis" env-prefix:"REDIS_"`
 
 	LogLevel              string `yaml:"log_level" env:"LOG_LEVEL" env-default:"debug"`
 	JWTSecret             string `yaml:"jwt_secret" env:"JWT_SECRET" env-default:"secret"`
 	AccessTokenCookieTTL  int    `yaml:"access_token_cookie_ttl" env:"ACCESS_TOKEN_COOKIE_TTL" env-default:"3600"`
 	RefreshTokenCookieTTL int    `yaml:"refresh_token_cookie_ttl" env:"REFRESH_TOKEN_COOKIE_TTL" env-default:"36000"`
 	Mode                  string `yaml:"mode" env:"MODE" env-required:"true"`
+	IncludeCryptoAllowed  bool   `yaml:"include_crypto_allowed" env:"INCLUDE_CRYPTO_ALLOWED" env-default:"false"`
 }
 
 func NewConfig() (Config, error) {
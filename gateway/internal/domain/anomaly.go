@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Responses to a user exceeding a detokenization threshold: AnomalyActionBlock rejects
+// every request of the user until the block expires or an admin lifts it,
+// AnomalyActionReauth until the user logs in again.
+const (
+	AnomalyActionBlock  = "block"
+	AnomalyActionReauth = "reauth"
+)
+
+// AnomalyBlock restricts a user whose detokenization volume in the sliding window
+// exceeded the threshold of Dimension: "all" for the total volume, "level:N" for
+// mappings of access level N and "kind:NAME" for mappings of a kind.
+type AnomalyBlock struct {
+	UserID    string    `json:"user_id"`
+	Action    string    `json:"action"`
+	Dimension string    `json:"dimension"`
+	Count     int64     `json:"count"`
+	Threshold int64     `json:"threshold"`
+	Window    string    `json:"window"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
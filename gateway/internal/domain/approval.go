@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// Statuses of an approval request. A pending request becomes approved, rejected or
+// expired; an approved one becomes executed when the gateway runs the operation, or
+// expired if its requester does not run it in time.
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+	ApprovalStatusExpired  = "expired"
+	ApprovalStatusExecuted = "executed"
+)
+
+// Approval is a sensitive operation held until a second admin approves it. The
+// operation is named by its audit action and kept as the HTTP request that invoked it,
+// so it can be executed later on behalf of the requester. The access of the requester
+// is not kept: the operation is executed with the access they have at that time.
+type Approval struct {
+	ID        string `json:"id"`
+	Operation string `json:"operation"`
+	Status    string `json:"status"`
+	// Target identifies the object of the operation, e.g. a token or a kind ID.
+	Target string `json:"target"`
+
+	RequesterID string `json:"requester_id"`
+	// PolicyActions are the actions of the route the access policy allowed the
+	// requester; they are authorized again with the current access of the requester
+	// before the operation is executed.
+	PolicyActions []string `json:"policy_actions,omitempty"`
+
+	Method      string   `json:"method"`
+	URI         string   `json:"uri"`
+	Route       string   `json:"route"`
+	ParamNames  []string `json:"param_names"`
+	ParamValues []string `json:"param_values"`
+	Body        []byte   `json:"body"`
+
+	ApproverID   string `json:"approver_id,omitempty"`
+	RejectReason string `json:"reject_reason,omitempty"`
+	// ResultCode is the HTTP status of the executed operation.
+	ResultCode int `json:"result_code,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	DecidedAt  *time.Time `json:"decided_at,omitempty"`
+	ExecutedAt *time.Time `json:"executed_at,omitempty"`
+}
+
+// IsOpen reports whether the approval still awaits a decision or execution.
+func (a *Approval) IsOpen() bool {
+	return a.Status == ApprovalStatusPending || a.Status == ApprovalStatusApproved
+}
@@ -0,0 +1,108 @@
+package domain
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeDenied  = "denied"
+	AuditOutcomeError   = "error"
+)
+
+// Actions of the audit entries written by the gateway. The token of an entry is the
+// token the action was performed on or, when there is none, the identifier of the
+// mapping, subject, kind, purpose or legal hold. Entries of authentication actions
+// hold the login, and entries of user management actions the user ID followed by the
+// role ID or clearance level after a colon.
+const (
+	AuditActionTokenize        = "tokenize"
+	AuditActionAnonymize       = "anonymize"
+	AuditActionDetokenize      = "detokenize"
+	AuditActionMappingRead     = "mapping_read"
+	AuditActionMappingList     = "mapping_list"
+	AuditActionMappingUpdate   = "mapping_update"
+	AuditActionMappingDelete   = "mapping_delete"
+	AuditActionSubjectRead     = "subject_read"
+	AuditActionHoldCreate      = "hold_create"
+	AuditActionHoldRelease     = "hold_release"
+	AuditActionErase           = "erase"
+	AuditActionKindCreate      = "kind_create"
+	AuditActionKindUpdate      = "kind_update"
+	AuditActionKindDelete      = "kind_delete"
+	AuditActionPurposeCreate   = "purpose_create"
+	AuditActionPurposeDelete   = "purpose_delete"
+	AuditActionRotateMasterKey = "rotate_master_key"
+	AuditActionRotateDeks      = "rotate_deks"
+	AuditActionAccess          = "access"
+
+	AuditActionLogin           = "login"
+	AuditActionRegister        = "register"
+	AuditActionTokenRefresh    = "token_refresh"
+	AuditActionUserDelete      = "user_delete"
+	AuditActionRoleAssign      = "role_assign"
+	AuditActionRoleRemove      = "role_remove"
+	AuditActionClearanceUpdate = "clearance_update"
+
+	// AuditActionSsoLogin is recorded when a user signs in through the identity provider;
+	// the token is the login, or the subject if the provider did not return a login.
+	AuditActionSsoLogin = "sso_login"
+
+	// Management of custom roles; the token is the role name, or the role ID of a
+	// deleted role.
+	AuditActionRoleCreate = "role_create"
+	AuditActionRoleUpdate = "role_update"
+	AuditActionRoleDelete = "role_delete"
+
+	// Management of kind operation grants; the kind is the kind of the grant. On set the
+	// token is the user ID or "role:<id>", on delete it is the grant ID.
+	AuditActionKindOperationGrantSet    = "kind_operation_grant_set"
+	AuditActionKindOperationGrantDelete = "kind_operation_grant_delete"
+
+	// Management of service accounts and their API keys. The token of a created service
+	// account is its name and that of a created key the service account ID; otherwise it
+	// is the ID of the service account or key. Requests made with an API key are audited
+	// under their own actions with the service account ID as the user.
+	AuditActionServiceAccountCreate = "service_account_create"
+	AuditActionServiceAccountDelete = "service_account_delete"
+	AuditActionApiKeyCreate         = "api_key_create"
+	AuditActionApiKeyRotate         = "api_key_rotate"
+	AuditActionApiKeyRevoke         = "api_key_revoke"
+
+	// AuditActionClientToken is recorded when a client exchanges an API key for an access
+	// token by the client credentials grant; the token is the client ID.
+	AuditActionClientToken = "client_token"
+
+	// AuditActionAnomalyBlock is recorded when the anomaly detector restricts a user; the
+	// token is the exceeded dimension. AuditActionAnomalyUnblock is recorded when an admin
+	// lifts the restriction; the token is the user ID.
+	AuditActionAnomalyBlock   = "anomaly_block"
+	AuditActionAnomalyUnblock = "anomaly_unblock"
+
+	// Steps of the four-eyes approval of a sensitive operation; the token is the ID of
+	// the approval request. The operation itself is audited under its own action when
+	// it is executed on behalf of the requester.
+	AuditActionApprovalRequest = "approval_request"
+	AuditActionApprovalApprove = "approval_approve"
+	AuditActionApprovalReject  = "approval_reject"
+	AuditActionApprovalExpire  = "approval_expire"
+
+	// Steps of a break-glass session; the token is the ID of the session. The
+	// operations performed during the session are audited under their own actions with
+	// the justification of the session.
+	AuditActionBreakGlassStart       = "break_glass_start"
+	AuditActionBreakGlassEnd         = "break_glass_end"
+	AuditActionBreakGlassAcknowledge = "break_glass_acknowledge"
+
+	// Time-bound access grants. The token of a created grant is the user ID followed by
+	// the granted clearance level after a colon, or the user ID alone with the kind ID of
+	// the entry set to the granted kind; the token of a revoked grant is its ID.
+	AuditActionGrantCreate = "grant_create"
+	AuditActionGrantRevoke = "grant_revoke"
+)
+
+// AuditPolicy decides what happens to an operation whose audit entry cannot be written:
+// under AuditFailClosed it is not performed, under AuditBestEffort the failure is only
+// logged.
+type AuditPolicy string
+
+const (
+	AuditFailClosed AuditPolicy = "fail_closed"
+	AuditBestEffort AuditPolicy = "best_effort"
+)
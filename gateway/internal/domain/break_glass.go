@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// BreakGlassSession is the emergency access of a user to data above their clearance
+// level. It is started with a written justification for a limited duration and ends
+// when it expires or when the user ends it. Every operation performed during the
+// session is audited with the justification and kept for review by an auditor.
+type BreakGlassSession struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	Justification string     `json:"justification"`
+	StartedAt     time.Time  `json:"started_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	EndedAt       *time.Time `json:"ended_at,omitempty"`
+
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+
+	// Operations is the number of operations performed during the session. It is
+	// counted apart from the session and not stored with it.
+	Operations int64 `json:"-"`
+}
+
+// IsActive reports whether the session grants emergency access at the time.
+func (s *BreakGlassSession) IsActive(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// BreakGlassOperation is an audited operation performed during a break-glass session.
+type BreakGlassOperation struct {
+	Action  string    `json:"action"`
+	Token   string    `json:"token,omitempty"`
+	KindID  int32     `json:"kind_id,omitempty"`
+	Outcome string    `json:"outcome"`
+	At      time.Time `json:"at"`
+}
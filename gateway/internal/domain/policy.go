@@ -0,0 +1,88 @@
+package domain
+
+// Actions checked against the access policy. The permissions of roles are named after
+// the actions they allow. Routes are authorized by the action of their group;
+// PolicyActionDataAccess is checked for every piece of data of a kind
+// with the kind, its access level and the operation on it as the resource, and PolicyActionApprovalView for
+// approval requests with their requester as the owner.
+const (
+	PolicyActionTokenize              = "tokenizer.tokenize"
+	PolicyActionDetokenize            = "tokenizer.detokenize"
+	PolicyActionDataAccess            = "data.access"
+	PolicyActionMappingRead           = "mappings.read"
+	PolicyActionMappingWrite          = "mappings.write"
+	PolicyActionMappingCrypto         = "mappings.crypto"
+	PolicyActionSubjectRead           = "subjects.read"
+	PolicyActionSubjectErase          = "subjects.erase"
+	PolicyActionHoldRead              = "legal_holds.read"
+	PolicyActionHoldWrite             = "legal_holds.write"
+	PolicyActionReportRead            = "reports.read"
+	PolicyActionKindRead              = "kinds.read"
+	PolicyActionKindWrite             = "kinds.write"
+	PolicyActionPurposeRead           = "purposes.read"
+	PolicyActionPurposeWrite          = "purposes.write"
+	PolicyActionUserManage            = "users.manage"
+	PolicyActionRoleRead              = "roles.read"
+	PolicyActionRoleManage            = "roles.manage"
+	PolicyActionAuditRead             = "audit.read"
+	PolicyActionKeyRotate             = "keys.rotate"
+	PolicyActionSecurityManage        = "security.manage"
+	PolicyActionApprovalUse           = "approvals.use"
+	PolicyActionApprovalView          = "approvals.view"
+	PolicyActionApprovalReview        = "approvals.review"
+	PolicyActionBreakGlassUse         = "break_glass.use"
+	PolicyActionBreakGlassReview      = "break_glass.review"
+	PolicyActionBreakGlassAcknowledge = "break_glass.acknowledge"
+	PolicyActionPolicyRead            = "policies.read"
+	PolicyActionServiceAccountManage  = "service_accounts.manage"
+)
+
+// PolicyActions lists every action checked against the access policy.
+var PolicyActions = []string{
+	PolicyActionTokenize,
+	PolicyActionDetokenize,
+	PolicyActionDataAccess,
+	PolicyActionMappingRead,
+	PolicyActionMappingWrite,
+	PolicyActionMappingCrypto,
+	PolicyActionSubjectRead,
+	PolicyActionSubjectErase,
+	PolicyActionHoldRead,
+	PolicyActionHoldWrite,
+	PolicyActionReportRead,
+	PolicyActionKindRead,
+	PolicyActionKindWrite,
+	PolicyActionPurposeRead,
+	PolicyActionPurposeWrite,
+	PolicyActionUserManage,
+	PolicyActionRoleRead,
+	PolicyActionRoleManage,
+	PolicyActionAuditRead,
+	PolicyActionKeyRotate,
+	PolicyActionSecurityManage,
+	PolicyActionApprovalUse,
+	PolicyActionApprovalView,
+	PolicyActionApprovalReview,
+	PolicyActionBreakGlassUse,
+	PolicyActionBreakGlassReview,
+	PolicyActionBreakGlassAcknowledge,
+	PolicyActionPolicyRead,
+	PolicyActionServiceAccountManage,
+}
+
+// Operations on data of a kind, checked as the resource of PolicyActionDataAccess and
+// allowed on single kinds by kind operation grants.
+const (
+	KindOperationTokenize   = "tokenize"
+	KindOperationDetokenize = "detokenize"
+	KindOperationView       = "view"
+	KindOperationDelete     = "delete"
+)
+
+// KindOperations lists every operation kind operation grants may allow.
+var KindOperations = []string{
+	KindOperationTokenize,
+	KindOperationDetokenize,
+	KindOperationView,
+	KindOperationDelete,
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Route groups with their own rate limits.
+const (
+	RateGroupTokenize   = "tokenize"
+	RateGroupDetokenize = "detokenize"
+	RateGroupAdmin      = "admin"
+	RateGroupAuth       = "auth"
+)
+
+// RateLimit is a token bucket holding up to Burst requests and refilled at Burst
+// requests per Period.
+type RateLimit struct {
+	Burst  int64
+	Period time.Duration
+}
+
+// Exceeds reports whether the limit allows more requests per unit of time than other.
+func (l RateLimit) Exceeds(other RateLimit) bool {
+	return float64(l.Burst)/l.Period.Seconds() > float64(other.Burst)/other.Period.Seconds()
+}
@@ -1,9 +0,0 @@
-package domain
-
-type RoleName string
-
-const (
-	RoleAdmin      RoleName = "admin"
-	RoleSpecialist RoleName = "specialist"
-	RoleAuditor    RoleName = "auditor"
-)
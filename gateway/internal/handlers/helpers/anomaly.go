@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// RejectAnomalyBlock answers a request of a user restricted by the anomaly detector.
+func RejectAnomalyBlock(ctx echo.Context, block *domain.AnomalyBlock) error {
+	if block.Action == domain.AnomalyActionReauth {
+		return ReauthenticationRequired(ctx)
+	}
+	return Forbidden(ctx, "account temporarily blocked")
+}
+
+// AnomalyBlockReason describes why the user was restricted, for the audit entry.
+func AnomalyBlockReason(block *domain.AnomalyBlock) string {
+	return fmt.Sprintf("%d detokenizations in %s exceed threshold %d of %s",
+		block.Count, block.Window, block.Threshold, block.Dimension)
+}
@@ -0,0 +1,212 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const (
+	approvalKey     = "approval"
+	approvalHeldKey = "approvalHeld"
+	requestBodyKey  = "requestBody"
+)
+
+// ErrRequesterDenied is returned by an Authenticator when the requester of an approval
+// can no longer be authenticated, e.g. because they were deleted or blocked.
+var ErrRequesterDenied = errors.New("requester cannot be authenticated")
+
+// Authenticator authenticates the requester of an approval executed by another user. It
+// sets the identity of the user on c as the auth middleware does for their requests,
+// with their current roles, clearance level and grants.
+type Authenticator interface {
+	AuthenticateAs(c echo.Context, userID string) error
+}
+
+// ApprovalGate holds sensitive operations for a four-eyes approval and executes them on
+// behalf of their requester once approved. An operation is executed by replaying the
+// request that invoked it to the handler of its route, registered with Register.
+type ApprovalGate struct {
+	approvals     *services.ApprovalService
+	auditor       *Auditor
+	authorizer    *Authorizer
+	authenticator Authenticator
+	executors     map[string]echo.HandlerFunc
+}
+
+func NewApprovalGate(
+	approvals *services.ApprovalService,
+	auditor *Auditor,
+	authorizer *Authorizer,
+	authenticator Authenticator) *ApprovalGate {
+	return &ApprovalGate{
+		approvals:     approvals,
+		auditor:       auditor,
+		authorizer:    authorizer,
+		authenticator: authenticator,
+		executors:     make(map[string]echo.HandlerFunc),
+	}
+}
+
+// Register makes handler the executor of the operation. Routes are registered before
+// the server starts, so the executors are not guarded against concurrent access.
+func (g *ApprovalGate) Register(operation string, handler echo.HandlerFunc) {
+	g.executors[operation] = handler
+}
+
+// CheckOperations returns an error if an operation requiring approval has no executor,
+// so a misspelt operation is not silently left without approval.
+func (g *ApprovalGate) CheckOperations() error {
+	var missing []string
+	for _, operation := range g.approvals.Operations() {
+		if _, ok := g.executors[operation]; !ok {
+			missing = append(missing, operation)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("operations cannot require approval: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Requires reports whether the operation on data of the access level must be approved
+// before it is executed. Requests executing an approval never do.
+func (g *ApprovalGate) Requires(c echo.Context, operation string, accessLevel int32) bool {
+	return GetApproval(c) == nil && g.approvals.Requires(operation, accessLevel)
+}
+
+// BufferBody keeps the request body, so the request can be held after its handler has
+// read the body.
+func BufferBody(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+	c.Request().Body = io.NopCloser(bytes.NewReader(body))
+	c.Set(requestBodyKey, body)
+	return nil
+}
+
+// GetApproval returns the approval executed by the request, or nil.
+func GetApproval(c echo.Context) *domain.Approval {
+	approval, _ := c.Get(approvalKey).(*domain.Approval)
+	return approval
+}
+
+// Hold saves the request as a pending approval of the operation on target and answers
+// 202 with it. Deferred audit entries of the held operation are skipped; the request is
+// audited as approval_request instead.
+func (g *ApprovalGate) Hold(c echo.Context, operation, target string, kindID int32) error {
+	reqCtx := c.Request().Context()
+
+	approval := &domain.Approval{
+		ID:            uuid.NewString(),
+		Operation:     operation,
+		Target:        target,
+		RequesterID:   GetUserID(c),
+		PolicyActions: append([]string(nil), GetPolicyActions(c)...),
+		Method:        c.Request().Method,
+		URI:           c.Request().RequestURI,
+		Route:         c.Path(),
+		// Echo reuses the parameters of pooled contexts.
+		ParamNames:  append([]string(nil), c.ParamNames()...),
+		ParamValues: append([]string(nil), c.ParamValues()...),
+	}
+	approval.Body, _ = c.Get(requestBodyKey).([]byte)
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionApprovalRequest, Token: approval.ID, KindId: kindID}
+	defer g.auditor.Audit(c, audit)
+	c.Set(approvalHeldKey, audit)
+
+	if err := g.auditor.Record(c, audit); err != nil {
+		return InternalServerError(c, "failed to write audit log")
+	}
+	if err := g.approvals.Create(reqCtx, approval); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to create approval",
+			slog.String("operation", operation),
+			logger.Err(err))
+		return InternalServerError(c, "failed to create approval")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "operation held for approval",
+		slog.String("operation", operation),
+		slog.String("approval_id", approval.ID))
+
+	return c.JSON(http.StatusAccepted, ApprovalToSchema(approval))
+}
+
+// identityKeys are the context keys the auth middleware sets the identity of the caller
+// under.
+var identityKeys = []string{
+	"userID", "roles", "clearanceLevel", "permissions", "kindGrants", "kindOperations",
+	"serviceAccount", "breakGlass",
+}
+
+// Execute replays the approved request to the handler of its operation on behalf of
+// the requester, with the access the requester has now rather than at the time of the
+// request. The requester executing their own approval is already authenticated by c;
+// otherwise they are authenticated anew, so a revoked or blocked requester is rejected.
+// The policy actions of the route are authorized again before the handler runs. The
+// response of the operation is written to c.
+func (g *ApprovalGate) Execute(c echo.Context, approval *domain.Approval) error {
+	reqCtx := c.Request().Context()
+
+	handler, ok := g.executors[approval.Operation]
+	if !ok {
+		return InternalServerError(c, "failed to execute approval")
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, approval.Method, approval.URI, bytes.NewReader(approval.Body))
+	if err != nil {
+		return InternalServerError(c, "failed to execute approval")
+	}
+	req.Header = c.Request().Header.Clone()
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.RemoteAddr = c.Request().RemoteAddr
+
+	// Writing through c.Response() keeps the status of the operation visible to the
+	// deferred audit of the request executing it.
+	ec := c.Echo().NewContext(req, c.Response())
+	ec.SetPath(approval.Route)
+	ec.SetParamNames(approval.ParamNames...)
+	ec.SetParamValues(approval.ParamValues...)
+
+	if GetUserID(c) == approval.RequesterID {
+		for _, key := range identityKeys {
+			if value := c.Get(key); value != nil {
+				ec.Set(key, value)
+			}
+		}
+	} else if err = g.authenticator.AuthenticateAs(ec, approval.RequesterID); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to authenticate approval requester",
+			slog.String("approval_id", approval.ID),
+			logger.Err(err))
+		if errors.Is(err, ErrRequesterDenied) {
+			return Forbidden(c, "requester access revoked")
+		}
+		return InternalServerError(c, "failed to execute approval")
+	}
+
+	for _, action := range approval.PolicyActions {
+		if !g.authorizer.Allowed(ec, action, policy.Resource{}) {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "approval requester no longer allowed",
+				slog.String("approval_id", approval.ID),
+				slog.String("action", action))
+			return Forbidden(c, "requester access revoked")
+		}
+	}
+
+	ec.Set(approvalKey, approval)
+	return handler(ec)
+}
@@ -0,0 +1,218 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/proto"
+	"log/slog"
+	"net/http"
+)
+
+// ErrAuditNotRecorded is returned by Auditor.Record when the entry of a fail-closed
+// action could not be written and the operation must not be performed.
+var ErrAuditNotRecorded = errors.New("audit entry not recorded")
+
+const auditRecordedKey = "auditRecorded"
+
+// AuditLogWriter is the part of the mapping service audit entries are written through.
+type AuditLogWriter interface {
+	CreateAuditLog(ctx context.Context, req *mapping.CreateAuditLogRequest) (*mapping.CreateAuditLogResponse, error)
+}
+
+// GetRequestID returns the ID assigned to the request by the logging middleware.
+func GetRequestID(c echo.Context) string {
+	requestID, _ := c.Request().Context().Value(logger.KeyForRequestID).(string)
+	return requestID
+}
+
+// ResponseOutcome classifies the response written for the request: 2xx is a success,
+// 401, 403 and 429 are denials and anything else, including no response, is an error.
+// The reason is the error message of the response.
+func ResponseOutcome(c echo.Context) (outcome string, reason string) {
+	code := c.Response().Status
+	if !c.Response().Committed {
+		code = http.StatusInternalServerError
+	}
+
+	switch {
+	case code >= 200 && code < 300:
+		return domain.AuditOutcomeSuccess, ""
+	case code == http.StatusUnauthorized || code == http.StatusForbidden || code == http.StatusTooManyRequests:
+		outcome = domain.AuditOutcomeDenied
+	default:
+		outcome = domain.AuditOutcomeError
+	}
+
+	reason, _ = c.Get(errorMessageKey).(string)
+	if reason == "" {
+		reason = http.StatusText(code)
+	}
+	return outcome, reason
+}
+
+// Auditor writes the audit entries of gateway requests and applies the audit policy of
+// their actions. Entries written during a break-glass session carry its justification
+// and are added to the session for review by an auditor.
+type Auditor struct {
+	writer        AuditLogWriter
+	defaultPolicy domain.AuditPolicy
+	policies      map[string]domain.AuditPolicy
+	// breakGlass keeps the operations of break-glass sessions; nil if they are disabled.
+	breakGlass *services.BreakGlassService
+}
+
+func NewAuditor(
+	writer AuditLogWriter,
+	defaultPolicy string,
+	policies map[string]string,
+	breakGlass *services.BreakGlassService) (*Auditor, error) {
+	a := &Auditor{
+		writer:        writer,
+		defaultPolicy: domain.AuditPolicy(defaultPolicy),
+		policies:      make(map[string]domain.AuditPolicy, len(policies)),
+		breakGlass:    breakGlass,
+	}
+	if !isValidAuditPolicy(a.defaultPolicy) {
+		return nil, fmt.Errorf("invalid default audit policy %q", defaultPolicy)
+	}
+	for action, policy := range policies {
+		if !isValidAuditPolicy(domain.AuditPolicy(policy)) {
+			return nil, fmt.Errorf("invalid audit policy %q for action %q", policy, action)
+		}
+		a.policies[action] = domain.AuditPolicy(policy)
+	}
+	return a, nil
+}
+
+func isValidAuditPolicy(policy domain.AuditPolicy) bool {
+	return policy == domain.AuditFailClosed || policy == domain.AuditBestEffort
+}
+
+// Policy returns the audit policy of action.
+func (a *Auditor) Policy(action string) domain.AuditPolicy {
+	if policy, ok := a.policies[action]; ok {
+		return policy
+	}
+	return a.defaultPolicy
+}
+
+// Record writes the successful entry of the operation before it takes effect: before a
+// change is made or before data is returned to the caller. It returns
+// ErrAuditNotRecorded if the entry could not be written and the action is fail-closed;
+// the caller must then abort. A deferred Audit of the same entry is skipped if the
+// request succeeds and records the error otherwise.
+func (a *Auditor) Record(c echo.Context, entry *mapping.CreateAuditLogRequest) error {
+	entry.Outcome = domain.AuditOutcomeSuccess
+	if err := a.write(c, entry); err != nil {
+		if a.Policy(entry.Action) == domain.AuditFailClosed {
+			return ErrAuditNotRecorded
+		}
+		return nil
+	}
+	c.Set(auditRecordedKey, entry)
+	return nil
+}
+
+// Audit writes entry on behalf of the caller with the outcome of the response already
+// written, so handlers defer it. If the entry has been recorded by Record, it is only
+// followed by an entry with the error outcome when the request failed afterwards. A
+// request held for approval is only audited by its approval_request entry. A failed
+// write is logged and does not fail the request.
+func (a *Auditor) Audit(c echo.Context, entry *mapping.CreateAuditLogRequest) {
+	if held, _ := c.Get(approvalHeldKey).(*mapping.CreateAuditLogRequest); held != nil && held != entry {
+		return
+	}
+
+	outcome, reason := ResponseOutcome(c)
+	if recorded, _ := c.Get(auditRecordedKey).(*mapping.CreateAuditLogRequest); recorded == entry {
+		if outcome == domain.AuditOutcomeSuccess {
+			return
+		}
+		entry = proto.Clone(entry).(*mapping.CreateAuditLogRequest)
+	}
+
+	entry.Outcome, entry.Reason = outcome, reason
+	_ = a.write(c, entry)
+}
+
+// AuditFailure writes entry like Audit, but only if the request did not succeed. It is
+// deferred by handlers whose successful operations the mapping service audits itself,
+// in the same transaction as the operation.
+func (a *Auditor) AuditFailure(c echo.Context, entry *mapping.CreateAuditLogRequest) {
+	if outcome, _ := ResponseOutcome(c); outcome == domain.AuditOutcomeSuccess {
+		return
+	}
+	a.Audit(c, entry)
+}
+
+// Deny writes the entry of a request rejected before reaching its handler.
+func (a *Auditor) Deny(c echo.Context, entry *mapping.CreateAuditLogRequest) {
+	entry.Outcome = domain.AuditOutcomeDenied
+	_ = a.write(c, entry)
+}
+
+// AuditOutcome writes entry with the given outcome and reason. It is used for responses
+// whose status does not tell the outcome, such as redirects.
+func (a *Auditor) AuditOutcome(c echo.Context, entry *mapping.CreateAuditLogRequest, outcome, reason string) {
+	entry.Outcome, entry.Reason = outcome, reason
+	_ = a.write(c, entry)
+}
+
+// write fills in the caller and the request context and writes the entry.
+func (a *Auditor) write(c echo.Context, entry *mapping.CreateAuditLogRequest) error {
+	reqCtx := c.Request().Context()
+
+	if session := GetBreakGlass(c); session != nil {
+		entry.Justification = session.Justification
+		defer a.recordBreakGlass(c, session, entry)
+	}
+
+	if entry.UserId == "" {
+		entry.UserId = GetUserID(c)
+	}
+	if entry.UserId == "" {
+		entry.UserId = uuid.Nil.String()
+	}
+	entry.ClientIp = c.RealIP()
+	entry.UserAgent = c.Request().UserAgent()
+	entry.RequestId = GetRequestID(c)
+
+	if _, err := a.writer.CreateAuditLog(reqCtx, entry); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to write audit log",
+			slog.String("action", entry.Action),
+			slog.String("outcome", entry.Outcome),
+			slog.String("policy", string(a.Policy(entry.Action))),
+			logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// recordBreakGlass adds the operation of entry to the break-glass session. A failure is
+// logged: the entry itself is already in the audit log.
+func (a *Auditor) recordBreakGlass(c echo.Context, session *domain.BreakGlassSession, entry *mapping.CreateAuditLogRequest) {
+	if a.breakGlass == nil {
+		return
+	}
+
+	reqCtx := c.Request().Context()
+	operation := &domain.BreakGlassOperation{
+		Action:  entry.Action,
+		Token:   entry.Token,
+		KindID:  entry.KindId,
+		Outcome: entry.Outcome,
+	}
+	if err := a.breakGlass.RecordOperation(reqCtx, session.ID, operation); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to record break-glass operation",
+			slog.String("session_id", session.ID),
+			slog.String("action", entry.Action),
+			logger.Err(err))
+	}
+}
@@ -0,0 +1,233 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// fakeAuditWriter keeps the entries written, or fails every write with err.
+type fakeAuditWriter struct {
+	entries []*mapping.CreateAuditLogRequest
+	err     error
+}
+
+func (w *fakeAuditWriter) CreateAuditLog(_ context.Context, req *mapping.CreateAuditLogRequest) (*mapping.CreateAuditLogResponse, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	w.entries = append(w.entries, req)
+	return &mapping.CreateAuditLogResponse{}, nil
+}
+
+func newAuditor(t *testing.T, writer *fakeAuditWriter, policies map[string]string) *Auditor {
+	t.Helper()
+	auditor, err := NewAuditor(writer, string(domain.AuditFailClosed), policies, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return auditor
+}
+
+func newContext(e *echo.Echo, req *http.Request) echo.Context {
+	return e.NewContext(req, httptest.NewRecorder())
+}
+
+func TestResponseOutcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		respond     func(c echo.Context) error
+		wantOutcome string
+		wantReason  string
+	}{
+		{"success", func(c echo.Context) error { return c.NoContent(http.StatusCreated) },
+			domain.AuditOutcomeSuccess, ""},
+		{"forbidden", func(c echo.Context) error { return Forbidden(c, "insufficient clearance") },
+			domain.AuditOutcomeDenied, "insufficient clearance"},
+		{"unauthorized", Unauthorized, domain.AuditOutcomeDenied, "please log in first"},
+		{"rate limited", func(c echo.Context) error { return c.NoContent(http.StatusTooManyRequests) },
+			domain.AuditOutcomeDenied, "Too Many Requests"},
+		{"bad request", func(c echo.Context) error { return BadRequest(c, "invalid token") },
+			domain.AuditOutcomeError, "invalid token"},
+		// A handler that returned without responding failed.
+		{"no response", func(echo.Context) error { return nil },
+			domain.AuditOutcomeError, "Internal Server Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newContext(echo.New(), httptest.NewRequest(http.MethodGet, "/", nil))
+			if err := tt.respond(c); err != nil {
+				t.Fatal(err)
+			}
+			if outcome, reason := ResponseOutcome(c); outcome != tt.wantOutcome || reason != tt.wantReason {
+				t.Errorf("got %q, %q, want %q, %q", outcome, reason, tt.wantOutcome, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestAuditor_RequestContext(t *testing.T) {
+	userID := uuid.NewString()
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		userID     string
+		wantIP     string
+		wantUserID string
+	}{
+		{"behind a trusted proxy", "10.0.0.1:41000", userID, "203.0.113.7", userID},
+		// The forwarded address of a client that is not a trusted proxy is ignored.
+		{"direct client", "198.51.100.2:41000", userID, "198.51.100.2", userID},
+		{"anonymous caller", "198.51.100.2:41000", "", "198.51.100.2", uuid.Nil.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			extractor, err := NewIPExtractor([]string{"10.0.0.1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			e.IPExtractor = extractor
+
+			req := httptest.NewRequest(http.MethodPost, "/tokenizer/detokenize", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.7")
+			req.Header.Set("User-Agent", "audit-test/1.0")
+			req = req.WithContext(context.WithValue(req.Context(), logger.KeyForRequestID, "request-1"))
+			c := newContext(e, req)
+			if tt.userID != "" {
+				c.Set("userID", tt.userID)
+			}
+
+			writer := &fakeAuditWriter{}
+			newAuditor(t, writer, nil).Deny(c, &mapping.CreateAuditLogRequest{Action: "detokenize", Reason: "insufficient clearance"})
+
+			if len(writer.entries) != 1 {
+				t.Fatalf("got %d entries", len(writer.entries))
+			}
+			got := writer.entries[0]
+			if got.Outcome != domain.AuditOutcomeDenied || got.Reason != "insufficient clearance" ||
+				got.ClientIp != tt.wantIP || got.UserAgent != "audit-test/1.0" || got.RequestId != "request-1" ||
+				got.UserId != tt.wantUserID {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestAuditor_Audit(t *testing.T) {
+	tests := []struct {
+		name        string
+		respond     func(c echo.Context) error
+		wantOutcome string
+		wantReason  string
+	}{
+		{"success", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, domain.AuditOutcomeSuccess, ""},
+		{"denied", func(c echo.Context) error { return Forbidden(c, "purpose not allowed") },
+			domain.AuditOutcomeDenied, "purpose not allowed"},
+		{"error", func(c echo.Context) error { return InternalServerError(c, "failed to detokenize") },
+			domain.AuditOutcomeError, "failed to detokenize"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newContext(echo.New(), httptest.NewRequest(http.MethodPost, "/", nil))
+			writer := &fakeAuditWriter{}
+			auditor := newAuditor(t, writer, nil)
+
+			func() {
+				defer auditor.Audit(c, &mapping.CreateAuditLogRequest{Action: "detokenize", Token: "fio_1"})
+				if err := tt.respond(c); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			if len(writer.entries) != 1 {
+				t.Fatalf("got %d entries", len(writer.entries))
+			}
+			if got := writer.entries[0]; got.Outcome != tt.wantOutcome || got.Reason != tt.wantReason || got.Token != "fio_1" {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestNewAuditor_InvalidPolicy(t *testing.T) {
+	if _, err := NewAuditor(&fakeAuditWriter{}, "sometimes", nil, nil); err == nil {
+		t.Error("invalid default policy accepted")
+	}
+	if _, err := NewAuditor(&fakeAuditWriter{}, string(domain.AuditBestEffort),
+		map[string]string{"detokenize": "never"}, nil); err == nil {
+		t.Error("invalid action policy accepted")
+	}
+}
+
+func TestAuditor_Record(t *testing.T) {
+	// Detokenize is fail-closed by default; mapping reads are configured best-effort.
+	policies := map[string]string{domain.AuditActionMappingRead: string(domain.AuditBestEffort)}
+	writeErr := errors.New("mapping service unavailable")
+
+	tests := []struct {
+		name        string
+		action      string
+		writeErr    error
+		respond     func(c echo.Context) error
+		wantErr     error
+		wantEntries []string
+	}{
+		{"recorded", domain.AuditActionDetokenize, nil,
+			func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+			nil, []string{domain.AuditOutcomeSuccess}},
+		// The operation failed after its entry was recorded: the failure follows it.
+		{"failed after recording", domain.AuditActionDetokenize, nil,
+			func(c echo.Context) error { return InternalServerError(c, "failed to decrypt") },
+			nil, []string{domain.AuditOutcomeSuccess, domain.AuditOutcomeError}},
+		{"fail-closed action not recorded", domain.AuditActionDetokenize, writeErr, nil, ErrAuditNotRecorded, nil},
+		{"best-effort action not recorded", domain.AuditActionMappingRead, writeErr,
+			func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+			nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newContext(echo.New(), httptest.NewRequest(http.MethodPost, "/", nil))
+			writer := &fakeAuditWriter{err: tt.writeErr}
+			auditor := newAuditor(t, writer, policies)
+
+			entry := &mapping.CreateAuditLogRequest{Action: tt.action, Token: "fio_1"}
+			err := func() error {
+				defer auditor.Audit(c, entry)
+				if err := auditor.Record(c, entry); err != nil {
+					return err
+				}
+				return tt.respond(c)
+			}()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			var outcomes []string
+			for _, e := range writer.entries {
+				outcomes = append(outcomes, e.Outcome)
+			}
+			if !slices.Equal(outcomes, tt.wantEntries) {
+				t.Errorf("got entries %v, want %v", outcomes, tt.wantEntries)
+			}
+			// The recorded entry is not rewritten by the failure that follows it.
+			if len(writer.entries) > 0 && writer.entries[0].Outcome != domain.AuditOutcomeSuccess {
+				t.Errorf("recorded entry changed to %+v", writer.entries[0])
+			}
+		})
+	}
+}
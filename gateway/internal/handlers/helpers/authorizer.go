@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/labstack/echo/v4"
+	"time"
+)
+
+// maxAccessLevel is the highest access level of a kind.
+const maxAccessLevel = 4
+
+// Authorizer checks actions of the caller against the access policy.
+type Authorizer struct {
+	engine *policy.Engine
+}
+
+func NewAuthorizer(engine *policy.Engine) *Authorizer {
+	return &Authorizer{engine: engine}
+}
+
+// Input describes the action of the caller on the resource for the policy.
+func (a *Authorizer) Input(c echo.Context, action string, resource policy.Resource) *policy.Input {
+	kindGrants := GetKindGrants(c)
+	grants := make([]int64, 0, len(kindGrants))
+	for _, id := range kindGrants {
+		grants = append(grants, int64(id))
+	}
+	kindOperations := GetKindOperations(c)
+	operations := make(map[int64][]string, len(kindOperations))
+	for id, ops := range kindOperations {
+		operations[int64(id)] = ops
+	}
+
+	return &policy.Input{
+		Subject: policy.Subject{
+			ID:             GetUserID(c),
+			Roles:          GetRoleNames(c),
+			Permissions:    GetPermissions(c),
+			Clearance:      int64(GetClearanceLevel(c)),
+			KindGrants:     grants,
+			KindOperations: operations,
+			BreakGlass:     GetBreakGlass(c) != nil,
+			ServiceAccount: GetServiceAccount(c) != nil,
+		},
+		Action:   action,
+		Resource: resource,
+		Context:  policy.Context{IP: c.RealIP(), Time: time.Now()},
+	}
+}
+
+// Decide evaluates the action of the caller on the resource.
+func (a *Authorizer) Decide(c echo.Context, action string, resource policy.Resource) *policy.Decision {
+	return a.engine.Decide(a.Input(c, action, resource))
+}
+
+// Allowed reports whether the caller may perform the action on the resource.
+func (a *Authorizer) Allowed(c echo.Context, action string, resource policy.Resource) bool {
+	return a.Decide(c, action, resource).Allowed
+}
+
+// CanAccessKind reports whether the caller may perform the operation, one of
+// domain.KindOperations, on data of the given kind. Mappings without a kind are
+// accessible to everyone.
+func (a *Authorizer) CanAccessKind(c echo.Context, kind *mapping.Kind, operation string) bool {
+	if kind == nil {
+		return true
+	}
+	return a.Allowed(c, domain.PolicyActionDataAccess, policy.Resource{
+		Kind:        int64(kind.Id),
+		AccessLevel: int64(kind.AccessLevel),
+		Operation:   operation,
+	})
+}
+
+// MaxAccessLevel returns the highest kind access level of the data the caller may
+// perform the operation on, 0 if lists cannot be narrowed by level alone, or
+// policy.NoAccessLevel if the caller may access no kind at all. It only narrows lists
+// before their items are filtered with CanAccessKind.
+func (a *Authorizer) MaxAccessLevel(c echo.Context, operation string) int32 {
+	in := a.Input(c, domain.PolicyActionDataAccess, policy.Resource{Operation: operation})
+	return int32(a.engine.Policy().MaxAccessLevel(*in, maxAccessLevel))
+}
@@ -22,17 +22,61 @@ func GetUserID(c echo.Context) string {
 	return userID
 }
 
-func HasRole(c echo.Context, role domain.RoleName) bool {
+// GetRoleNames returns the names of the caller's roles.
+func GetRoleNames(c echo.Context) []string {
 	roles, ok := c.Get("roles").([]*auth_service.Role)
 	if !ok {
-		return false
+		return nil
 	}
 
+	names := make([]string, 0, len(roles))
 	for _, r := range roles {
-		if r.Name == string(role) {
-			return true
-		}
+		names = append(names, r.Name)
 	}
+	return names
+}
+
+// GetPermissions returns the permissions of the caller's roles.
+func GetPermissions(c echo.Context) []string {
+	permissions, _ := c.Get("permissions").([]string)
+	return permissions
+}
+
+// GetBreakGlass returns the active break-glass session of the caller, or nil.
+func GetBreakGlass(c echo.Context) *domain.BreakGlassSession {
+	session, _ := c.Get("breakGlass").(*domain.BreakGlassSession)
+	return session
+}
+
+// GetKindGrants returns the IDs of the kinds the caller was granted access to beyond
+// their clearance level.
+func GetKindGrants(c echo.Context) []int32 {
+	kindIDs, _ := c.Get("kindGrants").([]int32)
+	return kindIDs
+}
+
+// GetServiceAccount returns the service account the caller authenticated as with an API
+// key, or nil for a user.
+func GetServiceAccount(c echo.Context) *auth_service.ServiceAccount {
+	account, _ := c.Get("serviceAccount").(*auth_service.ServiceAccount)
+	return account
+}
+
+// GetKindOperations returns the operations allowed to the caller on data of each kind by
+// kind operation grants, keyed by kind ID.
+func GetKindOperations(c echo.Context) map[int32][]string {
+	operations, _ := c.Get("kindOperations").(map[int32][]string)
+	return operations
+}
+
+// AddPolicyAction records that the access policy allowed the caller the action of the
+// route, so an operation held for approval is authorized again before it is executed.
+func AddPolicyAction(c echo.Context, action string) {
+	c.Set("policyActions", append(GetPolicyActions(c), action))
+}
 
-	return false
+// GetPolicyActions returns the actions the access policy allowed the caller on the route.
+func GetPolicyActions(c echo.Context) []string {
+	actions, _ := c.Get("policyActions").([]string)
+	return actions
 }
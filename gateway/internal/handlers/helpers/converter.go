@@ -1,14 +1,21 @@
 package helpers
 
 import (
+	"cmp"
 	"encoding/base64"
+	"encoding/json"
 	"github.com/NeF2le/anonix/common/gen/auth_service"
 	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
 	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"slices"
 	"time"
 )
 
-func ProtoMappingToSchema(m *mapping.MappingModel) *schemas.MappingSchema {
+// ProtoMappingToSchema converts a mapping to its public representation. Crypto material
+// (cipher_text and dek_wrapped) is only projected when includeCrypto is set.
+func ProtoMappingToSchema(m *mapping.MappingModel, includeCrypto bool) *schemas.MappingSchema {
 	ttl := ""
 	if m.TokenTtl != nil {
 		ttl = m.TokenTtl.AsDuration().String()
@@ -17,12 +24,24 @@ func ProtoMappingToSchema(m *mapping.MappingModel) *schemas.MappingSchema {
 	result := &schemas.MappingSchema{
 		Id:            m.Id,
 		Token:         m.Token,
-		CipherText:    base64.StdEncoding.EncodeToString(m.CipherText),
-		DekWrapped:    base64.StdEncoding.EncodeToString(m.DekWrapped),
 		Deterministic: m.Deterministic,
 		TokenTtl:      ttl,
 		CreatedAt:     m.CreatedAt.AsTime().Format(time.RFC3339),
 		AlgoName:      m.AlgoName,
+		SubjectRef:    m.SubjectRef,
+
+		Purposes:   m.Purposes,
+		LegalBasis: m.LegalBasis,
+		ConsentRef: m.ConsentRef,
+	}
+
+	if m.ConsentExpiresAt != nil {
+		result.ConsentExpiresAt = m.ConsentExpiresAt.AsTime().Format(time.RFC3339)
+	}
+
+	if includeCrypto {
+		result.CipherText = base64.StdEncoding.EncodeToString(m.CipherText)
+		result.DekWrapped = base64.StdEncoding.EncodeToString(m.DekWrapped)
 	}
 
 	if m.Kind != nil {
@@ -32,10 +51,28 @@ func ProtoMappingToSchema(m *mapping.MappingModel) *schemas.MappingSchema {
 	return result
 }
 
+func ProtoErasureReportToSchema(r *mapping.ErasureReport) *schemas.ErasureReportSchema {
+	erased := make([]*schemas.MappingSchema, 0, len(r.Erased))
+	for _, m := range r.Erased {
+		erased = append(erased, ProtoMappingToSchema(m, false))
+	}
+
+	return &schemas.ErasureReportSchema{
+		SubjectRef:  r.SubjectRef,
+		ErasedBy:    r.ErasedBy,
+		ErasedAt:    r.ErasedAt.AsTime().Format(time.RFC3339),
+		ErasedCount: len(erased),
+		Erased:      erased,
+	}
+}
+
 func ProtoRoleToSchema(r *auth_service.Role) *schemas.RoleSchema {
 	return &schemas.RoleSchema{
-		Id:   r.Id,
-		Name: r.Name,
+		Id:          r.Id,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.Permissions,
+		BuiltIn:     r.BuiltIn,
 	}
 }
 
@@ -53,6 +90,83 @@ func ProtoUserToSchema(u *auth_service.User) *schemas.UserSchema {
 	}
 }
 
+func ProtoAccessGrantToSchema(g *auth_service.AccessGrant) *schemas.AccessGrantSchema {
+	result := &schemas.AccessGrantSchema{
+		Id:             g.Id,
+		UserId:         g.UserId,
+		ClearanceLevel: g.ClearanceLevel,
+		KindId:         g.KindId,
+		Reason:         g.Reason,
+		GrantedBy:      g.GrantedBy,
+		CreatedAt:      g.CreatedAt.AsTime().Format(time.RFC3339),
+		ExpiresAt:      g.ExpiresAt.AsTime().Format(time.RFC3339),
+		RevokedBy:      g.RevokedBy,
+		Active:         g.Active,
+	}
+	if g.RevokedAt != nil {
+		result.RevokedAt = g.RevokedAt.AsTime().Format(time.RFC3339)
+	}
+	return result
+}
+
+func ProtoServiceAccountToSchema(a *auth_service.ServiceAccount) *schemas.ServiceAccountSchema {
+	return &schemas.ServiceAccountSchema{
+		Id:          a.Id,
+		Name:        a.Name,
+		Description: a.Description,
+		CreatedBy:   a.CreatedBy,
+		CreatedAt:   a.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+func ProtoApiKeyToSchema(k *auth_service.ApiKey) *schemas.ApiKeySchema {
+	result := &schemas.ApiKeySchema{
+		Id:               k.Id,
+		ServiceAccountId: k.ServiceAccountId,
+		Prefix:           k.Prefix,
+		Permissions:      k.Permissions,
+		KindIds:          k.KindIds,
+		AllowedIps:       k.AllowedIps,
+		CreatedBy:        k.CreatedBy,
+		CreatedAt:        k.CreatedAt.AsTime().Format(time.RFC3339),
+		ExpiresAt:        k.ExpiresAt.AsTime().Format(time.RFC3339),
+		LastUsedIp:       k.LastUsedIp,
+		RevokedBy:        k.RevokedBy,
+		Active:           k.Active,
+	}
+	if k.LastUsedAt != nil {
+		result.LastUsedAt = k.LastUsedAt.AsTime().Format(time.RFC3339)
+	}
+	if k.RevokedAt != nil {
+		result.RevokedAt = k.RevokedAt.AsTime().Format(time.RFC3339)
+	}
+	return result
+}
+
+func ProtoKindOperationGrantToSchema(g *auth_service.KindOperationGrant) *schemas.KindOperationGrantSchema {
+	return &schemas.KindOperationGrantSchema{
+		Id:         g.Id,
+		UserId:     g.UserId,
+		RoleId:     g.RoleId,
+		KindId:     g.KindId,
+		Operations: g.Operations,
+		GrantedBy:  g.GrantedBy,
+		CreatedAt:  g.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// KindOperationsToSchema lists the operations allowed on each kind ordered by kind ID.
+func KindOperationsToSchema(operations map[int32][]string) []*schemas.KindOperationsSchema {
+	result := make([]*schemas.KindOperationsSchema, 0, len(operations))
+	for kindID, ops := range operations {
+		result = append(result, &schemas.KindOperationsSchema{KindId: kindID, Operations: ops})
+	}
+	slices.SortFunc(result, func(a, b *schemas.KindOperationsSchema) int {
+		return cmp.Compare(a.KindId, b.KindId)
+	})
+	return result
+}
+
 func ProtoKindToSchema(k *mapping.Kind) *schemas.KindSchema {
 	if k == nil {
 		return nil
@@ -68,13 +182,32 @@ func ProtoKindToSchema(k *mapping.Kind) *schemas.KindSchema {
 	}
 }
 
+func ProtoPurposeToSchema(p *mapping.Purpose) *schemas.PurposeSchema {
+	return &schemas.PurposeSchema{
+		Id:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedAt:   p.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
 func ProtoAuditLogEntryToSchema(e *mapping.AuditLogEntry) *schemas.AuditLogEntrySchema {
 	result := &schemas.AuditLogEntrySchema{
-		Id:        e.Id,
-		UserId:    e.UserId,
-		Action:    e.Action,
-		Token:     e.Token,
-		CreatedAt: e.CreatedAt.AsTime().Format(time.RFC3339),
+		Id:            e.Id,
+		UserId:        e.UserId,
+		Action:        e.Action,
+		Token:         e.Token,
+		CreatedAt:     e.CreatedAt.AsTime().Format(time.RFC3339),
+		Purpose:       e.Purpose,
+		LegalHold:     e.LegalHold,
+		Seq:           e.Seq,
+		RowHash:       e.RowHash,
+		Outcome:       e.Outcome,
+		Reason:        e.Reason,
+		ClientIp:      e.ClientIp,
+		UserAgent:     e.UserAgent,
+		RequestId:     e.RequestId,
+		Justification: e.Justification,
 	}
 
 	if e.Kind != nil {
@@ -83,3 +216,171 @@ func ProtoAuditLogEntryToSchema(e *mapping.AuditLogEntry) *schemas.AuditLogEntry
 
 	return result
 }
+
+func ProtoLegalHoldToSchema(h *mapping.LegalHold) *schemas.LegalHoldSchema {
+	targets := make([]*schemas.LegalHoldTargetSchema, 0, len(h.Targets))
+	for _, t := range h.Targets {
+		targets = append(targets, &schemas.LegalHoldTargetSchema{Type: t.Type, Value: t.Value})
+	}
+
+	result := &schemas.LegalHoldSchema{
+		Id:         h.Id,
+		Name:       h.Name,
+		Reason:     h.Reason,
+		Owner:      h.Owner,
+		Targets:    targets,
+		CreatedBy:  h.CreatedBy,
+		CreatedAt:  h.CreatedAt.AsTime().Format(time.RFC3339),
+		ReleasedBy: h.ReleasedBy,
+		Active:     h.Active,
+	}
+
+	if h.ExpiresAt != nil {
+		result.ExpiresAt = h.ExpiresAt.AsTime().Format(time.RFC3339)
+	}
+	if h.ReleasedAt != nil {
+		result.ReleasedAt = h.ReleasedAt.AsTime().Format(time.RFC3339)
+	}
+
+	return result
+}
+
+// ProtoDestructionActToSchema keeps timestamps with nanoseconds, so the digest of the act
+// can be recomputed from its JSON.
+func ProtoDestructionActToSchema(a *mapping.DestructionAct) *schemas.DestructionActSchema {
+	result := &schemas.DestructionActSchema{
+		PeriodFrom:  a.PeriodFrom.AsTime().Format(time.RFC3339Nano),
+		PeriodTo:    a.PeriodTo.AsTime().Format(time.RFC3339Nano),
+		GeneratedAt: a.GeneratedAt.AsTime().Format(time.RFC3339Nano),
+		GeneratedBy: a.GeneratedBy,
+		Total:       a.Total,
+		Groups:      make([]*schemas.DestructionActGroupSchema, 0, len(a.Groups)),
+		Digest:      a.Digest,
+		Signature:   a.Signature,
+		SigningKey:  a.SigningKey,
+	}
+
+	for _, g := range a.Groups {
+		group := &schemas.DestructionActGroupSchema{
+			Total:  len(g.Events),
+			Events: make([]*schemas.DestructionEventSchema, 0, len(g.Events)),
+		}
+		if g.KindId != 0 {
+			group.Kind = &schemas.KindSchema{
+				Id:          g.KindId,
+				Name:        g.KindName,
+				RussianName: g.KindRussianName,
+			}
+		}
+		for _, e := range g.Events {
+			group.Events = append(group.Events, &schemas.DestructionEventSchema{
+				Id:          e.Id,
+				MappingId:   e.MappingId,
+				Token:       e.Token,
+				Reason:      e.Reason,
+				Initiator:   e.Initiator,
+				DestroyedAt: e.DestroyedAt.AsTime().Format(time.RFC3339Nano),
+			})
+		}
+		result.Groups = append(result.Groups, group)
+	}
+
+	return result
+}
+
+func ApprovalToSchema(a *domain.Approval) *schemas.ApprovalSchema {
+	result := &schemas.ApprovalSchema{
+		Id:           a.ID,
+		Operation:    a.Operation,
+		Status:       a.Status,
+		Target:       a.Target,
+		RequesterId:  a.RequesterID,
+		Method:       a.Method,
+		Uri:          a.URI,
+		ApproverId:   a.ApproverID,
+		RejectReason: a.RejectReason,
+		ResultCode:   a.ResultCode,
+		CreatedAt:    a.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:    a.ExpiresAt.Format(time.RFC3339),
+	}
+
+	if json.Valid(a.Body) {
+		result.Body = a.Body
+	}
+	if a.DecidedAt != nil {
+		result.DecidedAt = a.DecidedAt.Format(time.RFC3339)
+	}
+	if a.ExecutedAt != nil {
+		result.ExecutedAt = a.ExecutedAt.Format(time.RFC3339)
+	}
+
+	return result
+}
+
+func BreakGlassSessionToSchema(s *domain.BreakGlassSession) *schemas.BreakGlassSessionSchema {
+	result := &schemas.BreakGlassSessionSchema{
+		Id:             s.ID,
+		UserId:         s.UserID,
+		Justification:  s.Justification,
+		Active:         s.IsActive(time.Now()),
+		StartedAt:      s.StartedAt.Format(time.RFC3339),
+		ExpiresAt:      s.ExpiresAt.Format(time.RFC3339),
+		Operations:     s.Operations,
+		AcknowledgedBy: s.AcknowledgedBy,
+	}
+
+	if s.EndedAt != nil {
+		result.EndedAt = s.EndedAt.Format(time.RFC3339)
+	}
+	if s.AcknowledgedAt != nil {
+		result.AcknowledgedAt = s.AcknowledgedAt.Format(time.RFC3339)
+	}
+
+	return result
+}
+
+func BreakGlassOperationToSchema(o *domain.BreakGlassOperation) *schemas.BreakGlassOperationSchema {
+	return &schemas.BreakGlassOperationSchema{
+		Action:  o.Action,
+		Token:   o.Token,
+		KindId:  o.KindID,
+		Outcome: o.Outcome,
+		At:      o.At.Format(time.RFC3339),
+	}
+}
+
+func PolicyToSchema(p *policy.Policy) *schemas.PolicySchema {
+	rules := make([]*schemas.PolicyRuleSchema, 0, len(p.Rules))
+	for _, r := range p.Rules {
+		rules = append(rules, &schemas.PolicyRuleSchema{
+			Name:        r.Name,
+			Description: r.Description,
+			Effect:      r.Effect,
+			Actions:     r.Actions,
+			When:        r.When,
+		})
+	}
+
+	return &schemas.PolicySchema{
+		Version:  p.Version,
+		Source:   p.Source,
+		LoadedAt: p.LoadedAt.Format(time.RFC3339),
+		Actions:  p.Actions,
+		Rules:    rules,
+	}
+}
+
+func PolicyDecisionToSchema(d *policy.Decision, action, version string) *schemas.PolicyDecisionSchema {
+	rules := make([]*schemas.PolicyRuleResultSchema, 0, len(d.Rules))
+	for _, r := range d.Rules {
+		rules = append(rules, &schemas.PolicyRuleResultSchema{Name: r.Name, Effect: r.Effect, Matched: r.Matched})
+	}
+
+	return &schemas.PolicyDecisionSchema{
+		Allowed:   d.Allowed,
+		DecidedBy: d.Rule,
+		Reason:    d.Reason(action),
+		Version:   version,
+		Rules:     rules,
+	}
+}
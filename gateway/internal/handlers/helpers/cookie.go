@@ -30,3 +30,35 @@ func SetRefreshTokenCookie(c echo.Context, refreshToken string, maxAge int) {
 	c.SetCookie(cookie)
 	return
 }
+
+// oidcStateCookiePath limits the login state cookie to the sign-in endpoints of the
+// identity provider.
+const oidcStateCookiePath = "/api/v1/auth/oidc"
+
+// SetOidcStateCookie keeps the sealed login state of a sign-in through the identity
+// provider until its callback. The cookie is Lax, so the browser sends it on the
+// redirect back from the provider.
+func SetOidcStateCookie(c echo.Context, sealedState string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     "oidc_state",
+		Value:    sealedState,
+		Path:     oidcStateCookiePath,
+		HttpOnly: true,
+		MaxAge:   maxAge,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// GetOidcStateCookie returns the sealed login state, or "" if there is none.
+func GetOidcStateCookie(c echo.Context) string {
+	cookie, err := c.Cookie("oidc_state")
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// ClearOidcStateCookie removes the login state: a state is used once.
+func ClearOidcStateCookie(c echo.Context) {
+	SetOidcStateCookie(c, "", -1)
+}
@@ -2,33 +2,80 @@ package helpers
 
 import (
 	"github.com/labstack/echo/v4"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+const errorMessageKey = "errorMessage"
+
+// errorJSON writes the error response and keeps its message for the audit entry of the
+// request.
+func errorJSON(ctx echo.Context, code int, err string) error {
+	ctx.Set(errorMessageKey, err)
+	return ctx.JSON(code, map[string]string{"error": err})
+}
+
 func InternalServerError(ctx echo.Context, err string) error {
-	return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err})
+	return errorJSON(ctx, http.StatusInternalServerError, err)
 }
 
 func BadRequest(ctx echo.Context, err string) error {
-	return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err})
+	return errorJSON(ctx, http.StatusBadRequest, err)
 }
 
 func NotFound(ctx echo.Context, err string) error {
-	return ctx.JSON(http.StatusNotFound, map[string]string{"error": err})
+	return errorJSON(ctx, http.StatusNotFound, err)
 }
 
 func Conflict(ctx echo.Context, err string) error {
-	return ctx.JSON(http.StatusConflict, map[string]string{"error": err})
+	return errorJSON(ctx, http.StatusConflict, err)
 }
 
 func Forbidden(ctx echo.Context, err string) error {
-	return ctx.JSON(http.StatusForbidden, map[string]string{"error": err})
+	return errorJSON(ctx, http.StatusForbidden, err)
+}
+
+// InvalidPolicy rejects a policy document that does not compile; details tell its
+// author what to fix.
+func InvalidPolicy(ctx echo.Context, details string) error {
+	ctx.Set(errorMessageKey, "invalid policy")
+	return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid policy", "details": details})
 }
 
 func Unauthorized(ctx echo.Context) error {
-	return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "please log in first"})
+	return errorJSON(ctx, http.StatusUnauthorized, "please log in first")
 }
 
 func InvalidCredentials(ctx echo.Context) error {
-	return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+	return errorJSON(ctx, http.StatusUnauthorized, "invalid credentials")
+}
+
+func ReauthenticationRequired(ctx echo.Context) error {
+	return errorJSON(ctx, http.StatusUnauthorized, "re-authentication required")
+}
+
+// ServiceUnavailable rejects the request while a check it depends on cannot be made.
+func ServiceUnavailable(ctx echo.Context, err string) error {
+	return errorJSON(ctx, http.StatusServiceUnavailable, err)
+}
+
+// TooManyRequests rejects the request over a rate limit or quota; Retry-After tells the
+// client in whole seconds when to try again.
+func TooManyRequests(ctx echo.Context, retryAfter time.Duration, err string) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	ctx.Response().Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	return errorJSON(ctx, http.StatusTooManyRequests, err)
+}
+
+// OAuthError rejects a request to the OAuth token endpoint with an error code of
+// RFC 6749, section 5.2; description tells the client developer what went wrong.
+func OAuthError(ctx echo.Context, code int, oauthErr, description string) error {
+	ctx.Set(errorMessageKey, oauthErr)
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(code, map[string]string{"error": oauthErr, "error_description": description})
 }
@@ -1,6 +1,25 @@
 package helpers
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"regexp"
+)
+
+// subjectRefPattern restricts data subject references to opaque identifiers, so that
+// names, emails and other personal data cannot be used as a reference by mistake.
+var subjectRefPattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,100}$`)
+
+var purposeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// legalBases are the grounds for processing accepted for pseudonymized data.
+var legalBases = map[string]bool{
+	"consent":              true,
+	"contract":             true,
+	"legal_obligation":     true,
+	"vital_interests":      true,
+	"public_task":          true,
+	"legitimate_interests": true,
+}
 
 func ParseUUID(value string) (uuid.UUID, error) {
 	uuidField, err := uuid.Parse(value)
@@ -9,3 +28,15 @@ func ParseUUID(value string) (uuid.UUID, error) {
 	}
 	return uuidField, nil
 }
+
+func IsValidSubjectRef(value string) bool {
+	return subjectRefPattern.MatchString(value)
+}
+
+func IsValidPurposeName(value string) bool {
+	return purposeNamePattern.MatchString(value)
+}
+
+func IsValidLegalBasis(value string) bool {
+	return legalBases[value]
+}
@@ -0,0 +1,253 @@
+package http_handlers
+
+import (
+	"fmt"
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxAccessGrantReasonLen is the longest reason the auth service keeps.
+const maxAccessGrantReasonLen = 500
+
+type AccessGrantHandler struct {
+	authService    *services.AuthService
+	mappingService *services.MappingService
+	auditor        *helpers.Auditor
+	// tokenRevocation makes revoked grants take effect at once; nil leaves them in the
+	// access tokens already issued until those expire.
+	tokenRevocation *services.TokenRevocation
+}
+
+func NewAccessGrantHandler(
+	authService *services.AuthService,
+	mappingService *services.MappingService,
+	auditor *helpers.Auditor,
+	tokenRevocation *services.TokenRevocation) *AccessGrantHandler {
+	return &AccessGrantHandler{
+		authService:     authService,
+		mappingService:  mappingService,
+		auditor:         auditor,
+		tokenRevocation: tokenRevocation,
+	}
+}
+
+// CreateAccessGrant godoc
+// @Summary Выдать временный доступ
+// @Description Временно повышает уровень допуска пользователя (clearance_level) или открывает ему доступ к одному виду данных (kind_id) на duration_hours часов.
+// @Description Указывается ровно одно из полей clearance_level и kind_id. Длительность — не более AUTH_SERVICE_ACCESS_GRANT_MAX_DURATION.
+// @Description Доступ действует со следующего access-токена пользователя и прекращается автоматически; выдать доступ самому себе нельзя.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param body body schemas.CreateAccessGrantSchema true "Пользователь, доступ, основание и длительность"
+// @Success 200 {object} schemas.AccessGrantSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
+// @Failure 400 "invalid request body / invalid reason / invalid duration / invalid access grant"
+// @Failure 403 "user cannot grant access to themselves"
+// @Failure 404 "user not found / kind not found"
+// @Failure 500 "failed to create access grant / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /user/grants [post]
+func (h *AccessGrantHandler) CreateAccessGrant(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var body schemas.CreateAccessGrantSchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if body.UserId == "" {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if (body.ClearanceLevel == 0) == (body.KindId == 0) || body.ClearanceLevel < 0 || body.ClearanceLevel > 4 {
+		return helpers.BadRequest(ctx, "invalid access grant")
+	}
+
+	reason := strings.TrimSpace(body.Reason)
+	if n := utf8.RuneCountInString(reason); n == 0 || n > maxAccessGrantReasonLen {
+		return helpers.BadRequest(ctx, "invalid reason")
+	}
+	if body.DurationHours <= 0 {
+		return helpers.BadRequest(ctx, "invalid duration")
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionGrantCreate, Token: body.UserId, KindId: body.KindId}
+	if body.ClearanceLevel != 0 {
+		audit.Token = fmt.Sprintf("%s:%d", body.UserId, body.ClearanceLevel)
+	}
+	defer h.auditor.Audit(ctx, audit)
+
+	userID := helpers.GetUserID(ctx)
+	if body.UserId == userID {
+		return helpers.Forbidden(ctx, "user cannot grant access to themselves")
+	}
+
+	if body.KindId != 0 {
+		if _, err := h.mappingService.GetKind(reqCtx, &mapping.GetKindRequest{Id: body.KindId}); err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+				return helpers.NotFound(ctx, "kind not found")
+			}
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get kind",
+				slog.Int("kind_id", int(body.KindId)),
+				logger.Err(err))
+			return helpers.InternalServerError(ctx, "failed to create access grant")
+		}
+	}
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.CreateAccessGrant(reqCtx, &auth_service.CreateAccessGrantRequest{
+		UserId:         body.UserId,
+		ClearanceLevel: body.ClearanceLevel,
+		KindId:         body.KindId,
+		Reason:         reason,
+		GrantedBy:      userID,
+		ExpiresAt:      timestamppb.New(time.Now().Add(time.Duration(body.DurationHours) * time.Hour)),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "user not found")
+			case codes.InvalidArgument:
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "invalid access grant",
+					slog.String("user ID", body.UserId),
+					logger.Err(err))
+				return helpers.BadRequest(ctx, "invalid access grant")
+			case codes.OutOfRange:
+				return helpers.BadRequest(ctx, "invalid duration")
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to create access grant",
+			slog.String("user ID", body.UserId),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to create access grant")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "access grant created",
+		slog.String("grant_id", resp.Grant.Id),
+		slog.String("user_id", resp.Grant.UserId),
+		slog.String("granted_by", userID))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoAccessGrantToSchema(resp.Grant))
+}
+
+// RevokeAccessGrant godoc
+// @Summary Отозвать временный доступ
+// @Description Досрочно прекращает действующий временный доступ. Ранее выданные пользователю access-токены перестают действовать
+// @Description и заменяются при следующем запросе токенами без отозванного доступа.
+// @Tags Users
+// @Produce json
+// @Param id path string true "ID временного доступа"
+// @Success 200 {object} schemas.AccessGrantSchema
+// @Failure 404 "access grant not found"
+// @Failure 409 "access grant is not active"
+// @Failure 500 "failed to revoke access grant / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /user/grants/{id} [delete]
+func (h *AccessGrantHandler) RevokeAccessGrant(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionGrantRevoke, Token: ctx.Param("id")}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.RevokeAccessGrant(reqCtx, &auth_service.RevokeAccessGrantRequest{
+		Id:        ctx.Param("id"),
+		RevokedBy: helpers.GetUserID(ctx),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "access grant not found")
+			case codes.FailedPrecondition:
+				return helpers.Conflict(ctx, "access grant is not active")
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to revoke access grant",
+			slog.String("grant_id", ctx.Param("id")),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to revoke access grant")
+	}
+
+	if h.tokenRevocation != nil {
+		if err = h.tokenRevocation.Revoke(reqCtx, resp.Grant.UserId); err != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to revoke access tokens",
+				slog.String("user_id", resp.Grant.UserId),
+				logger.Err(err))
+		}
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "access grant revoked",
+		slog.String("grant_id", resp.Grant.Id),
+		slog.String("user_id", resp.Grant.UserId))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoAccessGrantToSchema(resp.Grant))
+}
+
+// GetAccessGrants godoc
+// @Summary Получить временные доступы
+// @Description Возвращает историю временных доступов пользователя или всех пользователей, начиная с новых.
+// @Tags Users
+// @Produce json
+// @Param user_id query string false "ID пользователя"
+// @Param active query bool false "Только действующие"
+// @Success 200 {array} schemas.AccessGrantSchema
+// @Failure 400 "invalid user ID / invalid active"
+// @Failure 500 "failed to get access grants"
+// @Security ApiKeyAuth
+// @Router /user/grants [get]
+func (h *AccessGrantHandler) GetAccessGrants(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var active bool
+	if v := ctx.QueryParam("active"); v != "" {
+		var err error
+		if active, err = strconv.ParseBool(v); err != nil {
+			return helpers.BadRequest(ctx, "invalid active")
+		}
+	}
+
+	resp, err := h.authService.GetAccessGrants(reqCtx, &auth_service.GetAccessGrantsRequest{
+		UserId:     ctx.QueryParam("user_id"),
+		ActiveOnly: active,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return helpers.BadRequest(ctx, "invalid user ID")
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get access grants",
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get access grants")
+	}
+
+	grants := make([]*schemas.AccessGrantSchema, 0, len(resp.Grants))
+	for _, g := range resp.Grants {
+		grants = append(grants, helpers.ProtoAccessGrantToSchema(g))
+	}
+	return ctx.JSON(http.StatusOK, grants)
+}
@@ -0,0 +1,119 @@
+package http_handlers
+
+import (
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+)
+
+type AnomalyHandler struct {
+	anomalyDetector *services.AnomalyDetector
+	auditor         *helpers.Auditor
+}
+
+// NewAnomalyHandler creates the handler of anomaly blocks; anomalyDetector is nil when
+// detection is disabled, and there are no blocks then.
+func NewAnomalyHandler(anomalyDetector *services.AnomalyDetector, auditor *helpers.Auditor) *AnomalyHandler {
+	return &AnomalyHandler{anomalyDetector: anomalyDetector, auditor: auditor}
+}
+
+// GetBlocks godoc
+// @Summary Получить активные блокировки
+// @Description Возвращает пользователей, ограниченных детектором массовой детокенизации, начиная с новых.
+// @Tags Security
+// @Produce json
+// @Success 200 {array} schemas.AnomalyBlockSchema
+// @Failure 401 "unauthorized"
+// @Failure 500 "failed to get blocks"
+// @Security ApiKeyAuth
+// @Router /security/blocks [get]
+func (a *AnomalyHandler) GetBlocks(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	blocks := make([]*schemas.AnomalyBlockSchema, 0)
+	if a.anomalyDetector == nil {
+		return ctx.JSON(http.StatusOK, blocks)
+	}
+
+	active, err := a.anomalyDetector.ListBlocks(reqCtx)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get anomaly blocks", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get blocks")
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].CreatedAt.After(active[j].CreatedAt) })
+
+	for _, block := range active {
+		blocks = append(blocks, &schemas.AnomalyBlockSchema{
+			UserId:    block.UserID,
+			Action:    block.Action,
+			Dimension: block.Dimension,
+			Count:     block.Count,
+			Threshold: block.Threshold,
+			Window:    block.Window,
+			CreatedAt: block.CreatedAt.Format(time.RFC3339),
+			ExpiresAt: block.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, blocks)
+}
+
+// Unblock godoc
+// @Summary Снять блокировку
+// @Description Снимает ограничение пользователя и сбрасывает его счётчики детокенизации.
+// @Description Снятие фиксируется в журнале аудита.
+// @Tags Security
+// @Produce json
+// @Param user_id path string true "ID пользователя"
+// @Success 200 {string} string "OK"
+// @Failure 401 "unauthorized"
+// @Failure 400 "invalid user_id"
+// @Failure 404 "block not found"
+// @Failure 500 "failed to unblock user / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /security/blocks/{user_id} [delete]
+func (a *AnomalyHandler) Unblock(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionAnomalyUnblock, Token: ctx.Param("user_id")}
+	defer a.auditor.Audit(ctx, audit)
+
+	userID, err := helpers.ParseUUID(ctx.Param("user_id"))
+	if err != nil {
+		return helpers.BadRequest(ctx, "invalid user_id")
+	}
+	if a.anomalyDetector == nil {
+		return helpers.NotFound(ctx, "block not found")
+	}
+
+	block, err := a.anomalyDetector.GetBlock(reqCtx, userID.String())
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get anomaly block", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to unblock user")
+	}
+	if block == nil {
+		return helpers.NotFound(ctx, "block not found")
+	}
+
+	if err = a.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+	if err = a.anomalyDetector.Unblock(reqCtx, userID.String()); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to unblock user", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to unblock user")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "anomaly block lifted",
+		slog.String("user_id", userID.String()),
+		slog.String("by", helpers.GetUserID(ctx)))
+
+	return ctx.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,278 @@
+package http_handlers
+
+import (
+	"errors"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+)
+
+type ApprovalHandler struct {
+	approvalService *services.ApprovalService
+	approvalGate    *helpers.ApprovalGate
+	auditor         *helpers.Auditor
+	authorizer      *helpers.Authorizer
+}
+
+func NewApprovalHandler(
+	approvalService *services.ApprovalService,
+	approvalGate *helpers.ApprovalGate,
+	auditor *helpers.Auditor,
+	authorizer *helpers.Authorizer) *ApprovalHandler {
+	return &ApprovalHandler{approvalService: approvalService, approvalGate: approvalGate, auditor: auditor, authorizer: authorizer}
+}
+
+// approvalError answers the error of an approval step.
+func approvalError(ctx echo.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, errs.ErrApprovalNotFound):
+		return helpers.NotFound(ctx, "approval not found")
+	case errors.Is(err, errs.ErrApprovalNotPending):
+		return helpers.Conflict(ctx, "approval is not pending")
+	case errors.Is(err, errs.ErrApprovalNotApproved):
+		return helpers.Conflict(ctx, "approval is not approved")
+	case errors.Is(err, errs.ErrApprovalExpired):
+		return helpers.Conflict(ctx, "approval expired")
+	case errors.Is(err, errs.ErrSelfApproval):
+		return helpers.Forbidden(ctx, "requester cannot approve own request")
+	}
+
+	reqCtx := ctx.Request().Context()
+	logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, msg, logger.Err(err))
+	return helpers.InternalServerError(ctx, msg)
+}
+
+// getVisibleApproval returns the approval if the policy lets the caller see it, by
+// default if the caller is an admin or its requester.
+func (a *ApprovalHandler) getVisibleApproval(ctx echo.Context) (*domain.Approval, error) {
+	approval, err := a.approvalService.Get(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+	if !a.authorizer.Allowed(ctx, domain.PolicyActionApprovalView, policy.Resource{Owner: approval.RequesterID}) {
+		return nil, errs.ErrApprovalNotFound
+	}
+	return approval, nil
+}
+
+// execute marks the approval executed and executes it on behalf of its requester.
+func (a *ApprovalHandler) execute(ctx echo.Context, approval *domain.Approval) error {
+	reqCtx := ctx.Request().Context()
+
+	if err := a.approvalService.MarkExecuted(reqCtx, approval); err != nil {
+		return approvalError(ctx, err, "failed to execute approval")
+	}
+
+	execErr := a.approvalGate.Execute(ctx, approval)
+	if err := a.approvalService.SetResult(reqCtx, approval, ctx.Response().Status); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to save approval result",
+			slog.String("approval_id", approval.ID),
+			logger.Err(err))
+	}
+	return execErr
+}
+
+// GetApprovals godoc
+// @Summary Получить запросы на согласование
+// @Description Возвращает запросы на согласование, начиная с новых. Администратор видит все запросы, остальные пользователи — только свои.
+// @Tags Approvals
+// @Produce json
+// @Param status query string false "Статус: pending, approved, rejected, expired или executed"
+// @Success 200 {array} schemas.ApprovalSchema
+// @Failure 400 "invalid status"
+// @Failure 401 "unauthorized"
+// @Failure 500 "failed to get approvals"
+// @Security ApiKeyAuth
+// @Router /approvals/ [get]
+func (a *ApprovalHandler) GetApprovals(ctx echo.Context) error {
+	status := ctx.QueryParam("status")
+	switch status {
+	case "", domain.ApprovalStatusPending, domain.ApprovalStatusApproved, domain.ApprovalStatusRejected,
+		domain.ApprovalStatusExpired, domain.ApprovalStatusExecuted:
+	default:
+		return helpers.BadRequest(ctx, "invalid status")
+	}
+
+	var requesterID string
+	if !a.authorizer.Allowed(ctx, domain.PolicyActionApprovalView, policy.Resource{}) {
+		requesterID = helpers.GetUserID(ctx)
+	}
+
+	approvals, err := a.approvalService.List(ctx.Request().Context(), status, requesterID)
+	if err != nil {
+		return approvalError(ctx, err, "failed to get approvals")
+	}
+
+	result := make([]*schemas.ApprovalSchema, 0, len(approvals))
+	for _, approval := range approvals {
+		result = append(result, helpers.ApprovalToSchema(approval))
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// GetApproval godoc
+// @Summary Получить запрос на согласование
+// @Tags Approvals
+// @Produce json
+// @Param id path string true "ID запроса"
+// @Success 200 {object} schemas.ApprovalSchema
+// @Failure 401 "unauthorized"
+// @Failure 404 "approval not found"
+// @Failure 500 "failed to get approval"
+// @Security ApiKeyAuth
+// @Router /approvals/{id} [get]
+func (a *ApprovalHandler) GetApproval(ctx echo.Context) error {
+	approval, err := a.getVisibleApproval(ctx)
+	if err != nil {
+		return approvalError(ctx, err, "failed to get approval")
+	}
+	return ctx.JSON(http.StatusOK, helpers.ApprovalToSchema(approval))
+}
+
+// ApproveApproval godoc
+// @Summary Одобрить запрос
+// @Description Одобряет запрос другого пользователя. Операция сразу выполняется от имени инициатора с его текущими ролями, уровнем допуска и разрешениями, и в ответе возвращается её результат;
+// @Description детокенизация выполняется только по запросу инициатора (`POST /approvals/{id}/execute`), и в ответе возвращается сам запрос.
+// @Tags Approvals
+// @Produce json
+// @Param id path string true "ID запроса"
+// @Success 200 {object} schemas.ApprovalSchema
+// @Failure 401 "unauthorized"
+// @Failure 403 "requester cannot approve own request / requester access revoked"
+// @Failure 404 "approval not found"
+// @Failure 409 "approval is not pending / approval expired"
+// @Failure 500 "failed to approve / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /approvals/{id}/approve [post]
+func (a *ApprovalHandler) ApproveApproval(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionApprovalApprove, Token: ctx.Param("id")}
+	defer a.auditor.Audit(ctx, audit)
+
+	approval, err := a.approvalService.Get(reqCtx, ctx.Param("id"))
+	if err != nil {
+		return approvalError(ctx, err, "failed to approve")
+	}
+	if approval.RequesterID == helpers.GetUserID(ctx) {
+		return approvalError(ctx, errs.ErrSelfApproval, "failed to approve")
+	}
+
+	if err = a.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+	approval, err = a.approvalService.Approve(reqCtx, approval.ID, helpers.GetUserID(ctx))
+	if err != nil {
+		return approvalError(ctx, err, "failed to approve")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "approval approved",
+		slog.String("approval_id", approval.ID),
+		slog.String("operation", approval.Operation))
+
+	if !a.approvalService.ExecutesOnApproval(approval.Operation) {
+		return ctx.JSON(http.StatusOK, helpers.ApprovalToSchema(approval))
+	}
+	return a.execute(ctx, approval)
+}
+
+// RejectApproval godoc
+// @Summary Отклонить запрос
+// @Tags Approvals
+// @Accept json
+// @Produce json
+// @Param id path string true "ID запроса"
+// @Param body body schemas.RejectApprovalSchema false "Причина отклонения"
+// @Success 200 {object} schemas.ApprovalSchema
+// @Failure 400 "invalid request body"
+// @Failure 401 "unauthorized"
+// @Failure 404 "approval not found"
+// @Failure 409 "approval is not pending / approval expired"
+// @Failure 500 "failed to reject / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /approvals/{id}/reject [post]
+func (a *ApprovalHandler) RejectApproval(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionApprovalReject, Token: ctx.Param("id")}
+	defer a.auditor.Audit(ctx, audit)
+
+	var body schemas.RejectApprovalSchema
+	if err := ctx.Bind(&body); err != nil || len(body.Reason) > 500 {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+
+	if err := a.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+	approval, err := a.approvalService.Reject(reqCtx, ctx.Param("id"), helpers.GetUserID(ctx), body.Reason)
+	if err != nil {
+		return approvalError(ctx, err, "failed to reject")
+	}
+
+	return ctx.JSON(http.StatusOK, helpers.ApprovalToSchema(approval))
+}
+
+// ExecuteApproval godoc
+// @Summary Выполнить одобренную детокенизацию
+// @Description Выполняет одобренную детокенизацию от имени инициатора; вызвать может только инициатор запроса. В ответе — результат детокенизации.
+// @Description Операция выполняется с текущими ролями, уровнем допуска и разрешениями инициатора, а политика доступа маршрута проверяется заново.
+// @Tags Approvals
+// @Produce json
+// @Param id path string true "ID запроса"
+// @Success 200 {object} schemas.DetokenizeRespSchema
+// @Failure 401 "unauthorized"
+// @Failure 403 "requester access revoked"
+// @Failure 404 "approval not found"
+// @Failure 409 "approval is not approved / approval expired"
+// @Failure 500 "failed to execute approval"
+// @Security ApiKeyAuth
+// @Router /approvals/{id}/execute [post]
+func (a *ApprovalHandler) ExecuteApproval(ctx echo.Context) error {
+	approval, err := a.approvalService.Get(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return approvalError(ctx, err, "failed to execute approval")
+	}
+	if approval.RequesterID != helpers.GetUserID(ctx) {
+		return approvalError(ctx, errs.ErrApprovalNotFound, "failed to execute approval")
+	}
+	if a.approvalService.ExecutesOnApproval(approval.Operation) {
+		return approvalError(ctx, errs.ErrApprovalNotApproved, "failed to execute approval")
+	}
+
+	return a.execute(ctx, approval)
+}
+
+// ExpireApprovals godoc
+// @Summary Завершить просроченные запросы
+// @Description Переводит в статус expired запросы, не одобренные или не выполненные в срок, и записывает истечение каждого в журнал аудита.
+// @Tags Approvals
+// @Produce json
+// @Success 200 {object} schemas.ExpireApprovalsRespSchema
+// @Failure 401 "unauthorized"
+// @Failure 500 "failed to expire approvals"
+// @Security ApiKeyAuth
+// @Router /approvals/expire [post]
+func (a *ApprovalHandler) ExpireApprovals(ctx echo.Context) error {
+	expired, err := a.approvalService.ExpireOverdue(ctx.Request().Context())
+	if err != nil {
+		return approvalError(ctx, err, "failed to expire approvals")
+	}
+
+	for _, approval := range expired {
+		_ = a.auditor.Record(ctx, &mapping.CreateAuditLogRequest{
+			Action: domain.AuditActionApprovalExpire,
+			Token:  approval.ID,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &schemas.ExpireApprovalsRespSchema{ExpiredCount: len(expired)})
+}
@@ -0,0 +1,264 @@
+package http_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/NeF2le/anonix/gateway/policies"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeApprovalRepo keeps copies of the approvals, as the Redis adapter keeps them
+// serialized.
+type fakeApprovalRepo struct {
+	approvals map[string]domain.Approval
+}
+
+func (r *fakeApprovalRepo) CreateApproval(_ context.Context, approval *domain.Approval, _ time.Duration) error {
+	r.approvals[approval.ID] = *approval
+	return nil
+}
+
+func (r *fakeApprovalRepo) GetApproval(_ context.Context, id string) (*domain.Approval, error) {
+	approval, ok := r.approvals[id]
+	if !ok {
+		return nil, nil
+	}
+	return &approval, nil
+}
+
+func (r *fakeApprovalRepo) ListApprovals(context.Context) ([]*domain.Approval, error) {
+	approvals := make([]*domain.Approval, 0, len(r.approvals))
+	for _, approval := range r.approvals {
+		approvals = append(approvals, &approval)
+	}
+	return approvals, nil
+}
+
+func (r *fakeApprovalRepo) UpdateApproval(_ context.Context, approval *domain.Approval, from string, _ time.Duration) (bool, error) {
+	if stored, ok := r.approvals[approval.ID]; !ok || stored.Status != from {
+		return false, nil
+	}
+	r.approvals[approval.ID] = *approval
+	return true, nil
+}
+
+// fakeAuthenticator authenticates the requester with the identity they have now, or
+// fails with err.
+type fakeAuthenticator struct {
+	current caller
+	err     error
+	calls   []string
+}
+
+func (a *fakeAuthenticator) AuthenticateAs(c echo.Context, userID string) error {
+	a.calls = append(a.calls, userID)
+	if a.err != nil {
+		return a.err
+	}
+	a.current.id = userID
+	a.current.set(c)
+	return nil
+}
+
+// approvalFixture is an approval handler whose operations are executed by an executor
+// that records the identity it ran with.
+type approvalFixture struct {
+	repo          *fakeApprovalRepo
+	service       *services.ApprovalService
+	handler       *ApprovalHandler
+	authenticator *fakeAuthenticator
+	executedAs    []string
+}
+
+func newApprovalFixture(t *testing.T) *approvalFixture {
+	t.Helper()
+	f := &approvalFixture{
+		repo:          &fakeApprovalRepo{approvals: make(map[string]domain.Approval)},
+		authenticator: &fakeAuthenticator{},
+	}
+
+	var err error
+	f.service, err = services.NewApprovalService(f.repo,
+		[]string{domain.AuditActionRotateDeks, domain.AuditActionDetokenize}, 4, time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine, err := policy.NewEngine(policies.Default, "", domain.PolicyActions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditor, err := helpers.NewAuditor(&fakeMappingRepo{}, string(domain.AuditFailClosed), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorizer := helpers.NewAuthorizer(engine)
+
+	gate := helpers.NewApprovalGate(f.service, auditor, authorizer, f.authenticator)
+	executor := func(c echo.Context) error {
+		f.executedAs = append(f.executedAs, fmt.Sprintf("%s %v", helpers.GetUserID(c), helpers.GetPermissions(c)))
+		return c.NoContent(http.StatusOK)
+	}
+	gate.Register(domain.AuditActionRotateDeks, executor)
+	gate.Register(domain.AuditActionDetokenize, executor)
+
+	f.handler = NewApprovalHandler(f.service, gate, auditor, authorizer)
+	return f
+}
+
+// create holds the operation as the requester would, allowed the policy action.
+func (f *approvalFixture) create(t *testing.T, operation, action string) *domain.Approval {
+	t.Helper()
+	approval := &domain.Approval{
+		Operation:     operation,
+		RequesterID:   "requester",
+		PolicyActions: []string{action},
+		Method:        http.MethodPost,
+		URI:           "/operation",
+		Route:         "/operation",
+	}
+	if err := f.service.Create(context.Background(), approval); err != nil {
+		t.Fatal(err)
+	}
+	return approval
+}
+
+func (f *approvalFixture) overdue(id string) {
+	approval := f.repo.approvals[id]
+	approval.ExpiresAt = time.Now().Add(-time.Second)
+	f.repo.approvals[id] = approval
+}
+
+func approvalRequest(id string, u caller) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := newRequest(http.MethodPost, "/approvals/"+id)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	u.set(c)
+	return c, rec
+}
+
+func responseError(rec *httptest.ResponseRecorder) string {
+	var body map[string]string
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	return body["error"]
+}
+
+func TestApproveApproval(t *testing.T) {
+	admin := caller{id: "approver", clearance: 4, permissions: []string{domain.PolicyActionApprovalReview}}
+	rotator := caller{permissions: []string{domain.PolicyActionKeyRotate}}
+
+	tests := []struct {
+		name         string
+		approver     caller
+		current      caller
+		authErr      error
+		overdue      bool
+		wantStatus   int
+		wantError    string
+		wantExecuted []string
+	}{
+		// The operation runs as the requester with the permissions they have now.
+		{"executed as the requester", admin, rotator, nil, false, http.StatusOK, "",
+			[]string{"requester [keys.rotate]"}},
+		{"self-approval", caller{id: "requester", permissions: admin.permissions}, rotator, nil, false,
+			http.StatusForbidden, "requester cannot approve own request", nil},
+		{"requester lost the permission", admin, caller{}, nil, false,
+			http.StatusForbidden, "requester access revoked", nil},
+		{"requester blocked", admin, rotator, fmt.Errorf("blocked: %w", helpers.ErrRequesterDenied), false,
+			http.StatusForbidden, "requester access revoked", nil},
+		{"auth service unavailable", admin, rotator, errors.New("connection refused"), false,
+			http.StatusInternalServerError, "failed to execute approval", nil},
+		{"not approved in time", admin, rotator, nil, true, http.StatusConflict, "approval expired", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newApprovalFixture(t)
+			f.authenticator.current, f.authenticator.err = tt.current, tt.authErr
+			approval := f.create(t, domain.AuditActionRotateDeks, domain.PolicyActionKeyRotate)
+			if tt.overdue {
+				f.overdue(approval.ID)
+			}
+
+			c, rec := approvalRequest(approval.ID, tt.approver)
+			if err := f.handler.ApproveApproval(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if rec.Code != tt.wantStatus || responseError(rec) != tt.wantError {
+				t.Fatalf("got %d %s, want %d %q", rec.Code, rec.Body, tt.wantStatus, tt.wantError)
+			}
+			if !slices.Equal(f.executedAs, tt.wantExecuted) {
+				t.Errorf("executed as %v, want %v", f.executedAs, tt.wantExecuted)
+			}
+			stored := f.repo.approvals[approval.ID]
+			if len(f.authenticator.calls) > 0 && stored.ResultCode != tt.wantStatus {
+				t.Errorf("result %d recorded, want %d", stored.ResultCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestExecuteApproval(t *testing.T) {
+	requester := caller{id: "requester", clearance: 4, permissions: []string{domain.PolicyActionDetokenize}}
+
+	tests := []struct {
+		name         string
+		executor     caller
+		approve      bool
+		overdue      bool
+		wantStatus   int
+		wantError    string
+		wantExecuted []string
+	}{
+		// The requester is authenticated by the request itself.
+		{"by the requester", requester, true, false, http.StatusOK, "", []string{"requester [tokenizer.detokenize]"}},
+		// The plaintext is only ever returned to the requester.
+		{"by another user", caller{id: "approver", clearance: 4, permissions: requester.permissions}, true, false,
+			http.StatusNotFound, "approval not found", nil},
+		{"not approved", requester, false, false, http.StatusConflict, "approval is not approved", nil},
+		{"not executed in time", requester, true, true, http.StatusConflict, "approval expired", nil},
+		{"requester lost the permission", caller{id: "requester", clearance: 4}, true, false,
+			http.StatusForbidden, "requester access revoked", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newApprovalFixture(t)
+			approval := f.create(t, domain.AuditActionDetokenize, domain.PolicyActionDetokenize)
+			if tt.approve {
+				if _, err := f.service.Approve(context.Background(), approval.ID, "approver"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.overdue {
+				f.overdue(approval.ID)
+			}
+
+			c, rec := approvalRequest(approval.ID, tt.executor)
+			if err := f.handler.ExecuteApproval(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if rec.Code != tt.wantStatus || responseError(rec) != tt.wantError {
+				t.Fatalf("got %d %s, want %d %q", rec.Code, rec.Body, tt.wantStatus, tt.wantError)
+			}
+			if !slices.Equal(f.executedAs, tt.wantExecuted) {
+				t.Errorf("executed as %v, want %v", f.executedAs, tt.wantExecuted)
+			}
+			if len(f.authenticator.calls) != 0 {
+				t.Errorf("requester authenticated anew: %v", f.authenticator.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package http_handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// auditExportFlushEvery is how many exported entries are buffered before they are
+// flushed to the client.
+const auditExportFlushEvery = 100
+
+var auditExportCSVHeader = []string{
+	"seq", "id", "created_at", "user_id", "action", "token",
+	"kind_id", "kind_name", "access_level", "purpose", "legal_hold",
+	"outcome", "reason", "client_ip", "user_agent", "request_id", "justification", "row_hash",
+}
+
+// parseAuditTime accepts an RFC 3339 time or a date; a date used as the end of a range
+// covers the whole day.
+func parseAuditTime(value string, end bool) (*timestamppb.Timestamp, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamppb.New(t), nil
+	}
+	t, err := time.Parse(reportDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return timestamppb.New(t), nil
+}
+
+// parseAuditLogFilter reads the audit log filter from the query parameters user_id,
+// action, token, kind_id, access_level, outcome, request_id, break_glass, from and to.
+func parseAuditLogFilter(ctx echo.Context) (*mapping.AuditLogFilter, error) {
+	filter := &mapping.AuditLogFilter{
+		Action:    ctx.QueryParam("action"),
+		Token:     ctx.QueryParam("token"),
+		RequestId: ctx.QueryParam("request_id"),
+	}
+
+	switch outcome := ctx.QueryParam("outcome"); outcome {
+	case "", domain.AuditOutcomeSuccess, domain.AuditOutcomeDenied, domain.AuditOutcomeError:
+		filter.Outcome = outcome
+	default:
+		return nil, errors.New("invalid outcome")
+	}
+
+	if v := ctx.QueryParam("user_id"); v != "" {
+		id, err := helpers.ParseUUID(v)
+		if err != nil {
+			return nil, errors.New("invalid user_id")
+		}
+		filter.UserId = id.String()
+	}
+	if v := ctx.QueryParam("kind_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id <= 0 {
+			return nil, errors.New("invalid kind_id")
+		}
+		filter.KindId = int32(id)
+	}
+	if v := ctx.QueryParam("access_level"); v != "" {
+		level, err := strconv.Atoi(v)
+		if err != nil || level <= 0 {
+			return nil, errors.New("invalid access_level")
+		}
+		filter.AccessLevel = int32(level)
+	}
+	if v := ctx.QueryParam("break_glass"); v != "" {
+		breakGlass, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid break_glass")
+		}
+		filter.BreakGlass = breakGlass
+	}
+	if v := ctx.QueryParam("from"); v != "" {
+		from, err := parseAuditTime(v, false)
+		if err != nil {
+			return nil, errors.New("invalid from")
+		}
+		filter.From = from
+	}
+	if v := ctx.QueryParam("to"); v != "" {
+		to, err := parseAuditTime(v, true)
+		if err != nil {
+			return nil, errors.New("invalid to")
+		}
+		filter.To = to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.AsTime().Before(filter.To.AsTime()) {
+		return nil, errors.New("invalid period")
+	}
+
+	return filter, nil
+}
+
+func auditEntryCSVRecord(e *mapping.AuditLogEntry) []string {
+	var kindID, kindName, accessLevel string
+	if e.Kind != nil {
+		kindID = strconv.Itoa(int(e.Kind.Id))
+		kindName = e.Kind.Name
+		accessLevel = strconv.Itoa(int(e.Kind.AccessLevel))
+	}
+	return []string{
+		strconv.FormatInt(e.Seq, 10),
+		e.Id,
+		e.CreatedAt.AsTime().Format(time.RFC3339Nano),
+		e.UserId,
+		e.Action,
+		e.Token,
+		kindID,
+		kindName,
+		accessLevel,
+		e.Purpose,
+		e.LegalHold,
+		e.Outcome,
+		e.Reason,
+		e.ClientIp,
+		e.UserAgent,
+		e.RequestId,
+		e.Justification,
+		e.RowHash,
+	}
+}
+
+// ExportAuditLog godoc
+// @Summary Выгрузить журнал аудита
+// @Description Потоково выгружает записи журнала аудита в порядке цепочки в формате CSV или JSON Lines.
+// @Description Принимает те же фильтры, что и GET /audit/. Сама выгрузка фиксируется в журнале аудита (действие audit_export);
+// @Description если зафиксировать её не удалось, выгрузка не выполняется.
+// @Tags Audit
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "csv (по умолчанию) или jsonl"
+// @Param user_id query string false "ID пользователя"
+// @Param action query string false "Действие"
+// @Param token query string false "Токен"
+// @Param kind_id query int false "ID вида данных"
+// @Param access_level query int false "Уровень доступа вида данных"
+// @Param outcome query string false "Результат: success, denied или error"
+// @Param request_id query string false "ID запроса"
+// @Param break_glass query bool false "Только операции в режиме экстренного доступа"
+// @Param from query string false "Начало периода (RFC 3339 или YYYY-MM-DD)"
+// @Param to query string false "Конец периода (RFC 3339, не включая, или YYYY-MM-DD включительно)"
+// @Success 200 {string} string "CSV или JSON Lines"
+// @Failure 400 "invalid format / invalid filter"
+// @Failure 500 "failed to export audit log"
+// @Security ApiKeyAuth
+// @Router /audit/export [get]
+func (m *MappingServiceHandler) ExportAuditLog(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "jsonl" {
+		return helpers.BadRequest(ctx, "invalid format")
+	}
+
+	filter, err := parseAuditLogFilter(ctx)
+	if err != nil {
+		return helpers.BadRequest(ctx, err.Error())
+	}
+
+	res := ctx.Response()
+	csvWriter := csv.NewWriter(res)
+	jsonEncoder := json.NewEncoder(res)
+	written := 0
+
+	// Headers are sent with the first entry, so errors raised before it still get a
+	// proper status.
+	start := func() error {
+		contentType := "text/csv; charset=utf-8"
+		if format == "jsonl" {
+			contentType = "application/x-ndjson"
+		}
+		res.Header().Set(echo.HeaderContentType, contentType)
+		res.Header().Set(echo.HeaderContentDisposition,
+			fmt.Sprintf(`attachment; filename="audit-%s.%s"`, time.Now().UTC().Format("20060102-150405"), format))
+		res.WriteHeader(http.StatusOK)
+		if format == "csv" {
+			return csvWriter.Write(auditExportCSVHeader)
+		}
+		return nil
+	}
+
+	err = m.mappingService.ExportAuditLog(reqCtx, &mapping.ExportAuditLogRequest{
+		Filter: filter,
+		UserId: helpers.GetUserID(ctx),
+	}, func(entry *mapping.AuditLogEntry) error {
+		if written == 0 {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if format == "csv" {
+			err = csvWriter.Write(auditEntryCSVRecord(entry))
+		} else {
+			err = jsonEncoder.Encode(helpers.ProtoAuditLogEntryToSchema(entry))
+		}
+		if err != nil {
+			return err
+		}
+
+		written++
+		if written%auditExportFlushEvery == 0 {
+			csvWriter.Flush()
+			res.Flush()
+		}
+		return nil
+	})
+
+	if err != nil && written == 0 {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return helpers.BadRequest(ctx, st.Message())
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to export audit log", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to export audit log")
+	}
+	if err != nil {
+		// The status is already sent; the client sees a truncated file.
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "audit log export interrupted",
+			slog.Int("written", written),
+			logger.Err(err))
+		csvWriter.Flush()
+		return nil
+	}
+
+	if written == 0 {
+		if err = start(); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "audit log exported",
+		slog.String("format", format),
+		slog.Int("entries", written),
+		slog.String("userID", helpers.GetUserID(ctx)))
+
+	return nil
+}
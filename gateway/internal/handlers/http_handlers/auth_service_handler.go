@@ -1,8 +1,11 @@
 package http_handlers
 
 import (
+	"fmt"
 	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
 	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
 	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
 	"github.com/NeF2le/anonix/gateway/internal/schemas"
 	"github.com/NeF2le/anonix/gateway/internal/services"
@@ -11,16 +14,49 @@ import (
 	"google.golang.org/grpc/status"
 	"log/slog"
 	"net/http"
+	"slices"
 )
 
 type AuthServiceHandler struct {
 	authService        *services.AuthService
+	auditor            *helpers.Auditor
+	anomalyDetector    *services.AnomalyDetector
+	rateLimiter        *services.RateLimiter
 	accessTokenMaxAge  int
 	refreshTokenMaxAge int
+	// tokenRevocation makes the access tokens of users whose access was reduced stale;
+	// nil leaves them valid until they expire.
+	tokenRevocation *services.TokenRevocation
 }
 
-func NewAuthServiceHandler(authService *services.AuthService) *AuthServiceHandler {
-	return &AuthServiceHandler{authService: authService}
+func NewAuthServiceHandler(
+	authService *services.AuthService,
+	auditor *helpers.Auditor,
+	anomalyDetector *services.AnomalyDetector,
+	rateLimiter *services.RateLimiter,
+	tokenRevocation *services.TokenRevocation) *AuthServiceHandler {
+	return &AuthServiceHandler{
+		authService:     authService,
+		auditor:         auditor,
+		anomalyDetector: anomalyDetector,
+		rateLimiter:     rateLimiter,
+		tokenRevocation: tokenRevocation,
+	}
+}
+
+// revokeTokens makes the access tokens issued to the user so far stale, so that their
+// next request carries their reduced access.
+func (a *AuthServiceHandler) revokeTokens(ctx echo.Context, userID string) {
+	if a.tokenRevocation == nil {
+		return
+	}
+
+	reqCtx := ctx.Request().Context()
+	if err := a.tokenRevocation.Revoke(reqCtx, userID); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to revoke access tokens",
+			slog.String("user ID", userID),
+			logger.Err(err))
+	}
 }
 
 // Register godoc
@@ -36,6 +72,8 @@ func NewAuthServiceHandler(authService *services.AuthService) *AuthServiceHandle
 // @Router /auth/signUp [post]
 func (a *AuthServiceHandler) Register(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRegister}
+	defer a.auditor.Audit(ctx, audit)
 
 	var registerSchema *schemas.RegisterSchema
 	err := ctx.Bind(&registerSchema)
@@ -44,6 +82,7 @@ func (a *AuthServiceHandler) Register(ctx echo.Context) error {
 			logger.Err(err))
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
+	audit.Token = registerSchema.Login
 
 	registerReq := &auth_service.RegisterRequest{
 		Login:    registerSchema.Login,
@@ -84,22 +123,26 @@ func (a *AuthServiceHandler) Register(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
+	audit.UserId = registerResp.UserId
 	return ctx.JSON(http.StatusOK, &schemas.RegisterRespSchema{UserId: registerResp.UserId})
 }
 
 // Login godoc
 // @Summary Авторизация
-// @Description Возвращает токен доступа и токен обновления
+// @Description Возвращает токен доступа, токен обновления, роли и разрешения пользователя
 // @Tags Auth
 // @Produce json
 // @Param body body schemas.LoginSchema true "Данные для авторизации"
 // @Success 200 {object} schemas.LoginRespSchema
 // @Failure 400 "invalid request body"
 // @Failure 401 "invalid credentials"
+// @Failure 429 "too many login attempts"
 // @Failure 500 "failed to log in"
 // @Router /auth/signIn [post]
 func (a *AuthServiceHandler) Login(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionLogin}
+	defer a.auditor.Audit(ctx, audit)
 
 	var loginSchema *schemas.LoginSchema
 	err := ctx.Bind(&loginSchema)
@@ -108,6 +151,20 @@ func (a *AuthServiceHandler) Login(ctx echo.Context) error {
 			logger.Err(err))
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
+	audit.Token = loginSchema.Login
+
+	if a.rateLimiter != nil {
+		allowed, retryAfter, err := a.rateLimiter.AllowLogin(reqCtx, ctx.RealIP(), loginSchema.Login)
+		if err != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to check login rate limit",
+				slog.String("login", loginSchema.Login),
+				logger.Err(err))
+		} else if !allowed {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "too many login attempts",
+				slog.String("login", loginSchema.Login))
+			return helpers.TooManyRequests(ctx, retryAfter, "too many login attempts")
+		}
+	}
 
 	loginReq := &auth_service.LoginRequest{
 		Login:    loginSchema.Login,
@@ -143,6 +200,14 @@ func (a *AuthServiceHandler) Login(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
+	audit.UserId = loginResp.UserId
+	if a.anomalyDetector != nil {
+		if err = a.anomalyDetector.Reauthenticated(reqCtx, loginResp.UserId); err != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to lift re-authentication block",
+				slog.String("login", loginReq.Login),
+				logger.Err(err))
+		}
+	}
 	helpers.SetAccessTokenCookie(ctx, loginResp.AccessToken, a.accessTokenMaxAge)
 	helpers.SetRefreshTokenCookie(ctx, loginResp.RefreshToken, a.refreshTokenMaxAge)
 
@@ -152,15 +217,19 @@ func (a *AuthServiceHandler) Login(ctx echo.Context) error {
 	}
 
 	roleSchemas := make([]*schemas.RoleSchema, 0, len(rolesResp.Roles))
+	var permissions []string
 	for _, r := range rolesResp.Roles {
 		roleSchemas = append(roleSchemas, &schemas.RoleSchema{Id: r.Id, Name: r.Name})
+		permissions = append(permissions, r.Permissions...)
 	}
+	slices.Sort(permissions)
 
 	return ctx.JSON(http.StatusOK, &schemas.LoginRespSchema{
 		AccessToken:  loginResp.AccessToken,
 		RefreshToken: loginResp.RefreshToken,
 		UserId:       loginResp.UserId,
 		Roles:        roleSchemas,
+		Permissions:  slices.Compact(permissions),
 	})
 }
 
@@ -177,6 +246,8 @@ func (a *AuthServiceHandler) Login(ctx echo.Context) error {
 // @Router /auth/refresh [post]
 func (a *AuthServiceHandler) Refresh(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionTokenRefresh}
+	defer a.auditor.Audit(ctx, audit)
 
 	var refreshSchema *schemas.RefreshSchema
 	err := ctx.Bind(&refreshSchema)
@@ -268,7 +339,7 @@ func (a *AuthServiceHandler) IsAdmin(ctx echo.Context) error {
 
 // GetMe godoc
 // @Summary Получить роли текущего пользователя
-// @Description Возвращает роли авторизованного пользователя из токена доступа
+// @Description Возвращает ID, роли, разрешения и операции над видами данных авторизованного пользователя из токена доступа
 // @Tags Auth
 // @Produce json
 // @Success 200 {object} schemas.GetUserRolesRespSchema
@@ -282,7 +353,12 @@ func (a *AuthServiceHandler) GetMe(ctx echo.Context) error {
 		roles = append(roles, helpers.ProtoRoleToSchema(r))
 	}
 
-	return ctx.JSON(http.StatusOK, &schemas.GetUserRolesRespSchema{Roles: roles})
+	return ctx.JSON(http.StatusOK, &schemas.GetUserRolesRespSchema{
+		UserId:         helpers.GetUserID(ctx),
+		Roles:          roles,
+		Permissions:    helpers.GetPermissions(ctx),
+		KindOperations: helpers.KindOperationsToSchema(helpers.GetKindOperations(ctx)),
+	})
 }
 
 func (a *AuthServiceHandler) GetUsers(ctx echo.Context) error {
@@ -315,6 +391,8 @@ func (a *AuthServiceHandler) GetUsers(ctx echo.Context) error {
 
 func (a *AuthServiceHandler) DeleteUser(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionUserDelete}
+	defer a.auditor.Audit(ctx, audit)
 
 	var schema *schemas.DeleteUserSchema
 	err := ctx.Bind(&schema)
@@ -323,6 +401,7 @@ func (a *AuthServiceHandler) DeleteUser(ctx echo.Context) error {
 			logger.Err(err))
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
+	audit.Token = schema.UserId
 
 	req := &auth_service.DeleteUserRequest{UserId: schema.UserId}
 
@@ -355,11 +434,15 @@ func (a *AuthServiceHandler) DeleteUser(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
+	a.revokeTokens(ctx, req.UserId)
+
 	return ctx.JSON(http.StatusOK, &schemas.DeleteUserRespSchema{})
 }
 
 func (a *AuthServiceHandler) AssignRole(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRoleAssign}
+	defer a.auditor.Audit(ctx, audit)
 
 	var schema *schemas.AssignRoleSchema
 	err := ctx.Bind(&schema)
@@ -368,6 +451,7 @@ func (a *AuthServiceHandler) AssignRole(ctx echo.Context) error {
 			logger.Err(err))
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
+	audit.Token = fmt.Sprintf("%s:%d", schema.UserId, schema.RoleId)
 
 	req := &auth_service.AssignRoleRequest{UserId: schema.UserId, RoleId: schema.RoleId}
 
@@ -405,6 +489,8 @@ func (a *AuthServiceHandler) AssignRole(ctx echo.Context) error {
 
 func (a *AuthServiceHandler) RemoveRole(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRoleRemove}
+	defer a.auditor.Audit(ctx, audit)
 
 	var schema *schemas.RemoveRoleSchema
 	err := ctx.Bind(&schema)
@@ -413,6 +499,7 @@ func (a *AuthServiceHandler) RemoveRole(ctx echo.Context) error {
 			logger.Err(err))
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
+	audit.Token = fmt.Sprintf("%s:%d", schema.UserId, schema.RoleId)
 
 	req := &auth_service.RemoveRoleRequest{UserId: schema.UserId, RoleId: schema.RoleId}
 
@@ -445,11 +532,15 @@ func (a *AuthServiceHandler) RemoveRole(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
+	a.revokeTokens(ctx, req.UserId)
+
 	return ctx.JSON(http.StatusOK, &schemas.RemoveRoleRespSchema{})
 }
 
 func (a *AuthServiceHandler) UpdateClearance(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionClearanceUpdate}
+	defer a.auditor.Audit(ctx, audit)
 
 	var schema *schemas.UpdateClearanceSchema
 	err := ctx.Bind(&schema)
@@ -458,6 +549,7 @@ func (a *AuthServiceHandler) UpdateClearance(ctx echo.Context) error {
 			logger.Err(err))
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
+	audit.Token = fmt.Sprintf("%s:%d", schema.UserId, schema.ClearanceLevel)
 
 	if schema.ClearanceLevel < 1 || schema.ClearanceLevel > 4 {
 		return helpers.BadRequest(ctx, "invalid request body")
@@ -494,6 +586,8 @@ func (a *AuthServiceHandler) UpdateClearance(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
+	a.revokeTokens(ctx, req.UserId)
+
 	return ctx.JSON(http.StatusOK, &schemas.UpdateClearanceRespSchema{})
 }
 
@@ -0,0 +1,322 @@
+package http_handlers
+
+import (
+	"errors"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxBreakGlassJustificationLen is the longest justification the audit log keeps.
+const maxBreakGlassJustificationLen = 500
+
+type BreakGlassHandler struct {
+	breakGlassService *services.BreakGlassService
+	authService       *services.AuthService
+	auditor           *helpers.Auditor
+	accessTokenMaxAge int
+}
+
+func NewBreakGlassHandler(
+	breakGlassService *services.BreakGlassService,
+	authService *services.AuthService,
+	auditor *helpers.Auditor,
+	accessTokenMaxAge int) *BreakGlassHandler {
+	return &BreakGlassHandler{
+		breakGlassService: breakGlassService,
+		authService:       authService,
+		auditor:           auditor,
+		accessTokenMaxAge: accessTokenMaxAge,
+	}
+}
+
+// breakGlassError answers the error of a break-glass step.
+func breakGlassError(ctx echo.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, errs.ErrBreakGlassNotFound):
+		return helpers.NotFound(ctx, "break-glass session not found")
+	case errors.Is(err, errs.ErrBreakGlassActive):
+		return helpers.Conflict(ctx, "break-glass session already active")
+	case errors.Is(err, errs.ErrBreakGlassNotActive):
+		return helpers.Conflict(ctx, "break-glass session is not active")
+	case errors.Is(err, errs.ErrBreakGlassSelfReview):
+		return helpers.Forbidden(ctx, "user cannot acknowledge own break-glass session")
+	}
+
+	reqCtx := ctx.Request().Context()
+	logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, msg, logger.Err(err))
+	return helpers.InternalServerError(ctx, msg)
+}
+
+// StartBreakGlass godoc
+// @Summary Включить экстренный доступ
+// @Description Открывает сессию экстренного доступа (break-glass) с письменным обоснованием на ограниченный срок и выдаёт access-токен с признаком сессии.
+// @Description В течение сессии проверка уровня допуска не выполняется; каждая операция фиксируется в журнале аудита с обоснованием
+// @Description и передаётся на проверку аудитору. Обоснование — от BREAK_GLASS_MIN_JUSTIFICATION_LENGTH до 500 символов;
+// @Description длительность — не более BREAK_GLASS_MAX_DURATION, по умолчанию максимальная.
+// @Tags BreakGlass
+// @Accept json
+// @Produce json
+// @Param body body schemas.StartBreakGlassSchema true "Обоснование и длительность"
+// @Success 200 {object} schemas.StartBreakGlassRespSchema
+// @Failure 400 "invalid request body / invalid justification / invalid duration"
+// @Failure 401 "unauthorized"
+// @Failure 409 "break-glass session already active"
+// @Failure 500 "failed to start break-glass session / failed to issue access token / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /break-glass/ [post]
+func (b *BreakGlassHandler) StartBreakGlass(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+	userID := helpers.GetUserID(ctx)
+
+	var body schemas.StartBreakGlassSchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+
+	justification := strings.TrimSpace(body.Justification)
+	if n := utf8.RuneCountInString(justification); n < b.breakGlassService.MinJustificationLength() || n > maxBreakGlassJustificationLen {
+		return helpers.BadRequest(ctx, "invalid justification")
+	}
+
+	duration := b.breakGlassService.MaxDuration()
+	if body.DurationMinutes != 0 {
+		duration = time.Duration(body.DurationMinutes) * time.Minute
+		if duration < 0 || duration > b.breakGlassService.MaxDuration() {
+			return helpers.BadRequest(ctx, "invalid duration")
+		}
+	}
+
+	active, err := b.breakGlassService.ActiveForUser(reqCtx, userID)
+	if err != nil {
+		return breakGlassError(ctx, err, "failed to start break-glass session")
+	}
+	if active != nil {
+		return breakGlassError(ctx, errs.ErrBreakGlassActive, "failed to start break-glass session")
+	}
+
+	session := &domain.BreakGlassSession{ID: uuid.NewString(), UserID: userID, Justification: justification}
+	audit := &mapping.CreateAuditLogRequest{
+		Action:        domain.AuditActionBreakGlassStart,
+		Token:         session.ID,
+		Justification: justification,
+	}
+	defer b.auditor.Audit(ctx, audit)
+
+	if err = b.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+	if err = b.breakGlassService.Start(reqCtx, session, duration); err != nil {
+		return breakGlassError(ctx, err, "failed to start break-glass session")
+	}
+
+	resp, err := b.authService.IssueAccessToken(reqCtx, &auth_service.IssueAccessTokenRequest{
+		UserId: userID,
+		BreakGlass: &auth_service.BreakGlass{
+			Id:            session.ID,
+			Justification: justification,
+			ExpiresAt:     timestamppb.New(session.ExpiresAt),
+		},
+	})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to issue break-glass access token",
+			logger.Err(err))
+		if endErr := b.breakGlassService.End(reqCtx, session); endErr != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to end break-glass session",
+				slog.String("session_id", session.ID),
+				logger.Err(endErr))
+		}
+		return helpers.InternalServerError(ctx, "failed to issue access token")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "break-glass session started",
+		slog.String("session_id", session.ID),
+		slog.String("user_id", userID),
+		slog.Time("expires_at", session.ExpiresAt))
+
+	helpers.SetAccessTokenCookie(ctx, resp.AccessToken, int(time.Until(session.ExpiresAt).Seconds()))
+
+	return ctx.JSON(http.StatusOK, &schemas.StartBreakGlassRespSchema{
+		Session:     helpers.BreakGlassSessionToSchema(session),
+		AccessToken: resp.AccessToken,
+	})
+}
+
+// EndBreakGlass godoc
+// @Summary Завершить экстренный доступ
+// @Description Досрочно завершает активную сессию экстренного доступа и выдаёт обычный access-токен. Токен сессии перестаёт давать экстренный доступ.
+// @Tags BreakGlass
+// @Produce json
+// @Success 200 {object} schemas.BreakGlassSessionSchema
+// @Failure 401 "unauthorized"
+// @Failure 409 "break-glass session is not active"
+// @Failure 500 "failed to end break-glass session / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /break-glass/ [delete]
+func (b *BreakGlassHandler) EndBreakGlass(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+	userID := helpers.GetUserID(ctx)
+
+	session, err := b.breakGlassService.ActiveForUser(reqCtx, userID)
+	if err != nil {
+		return breakGlassError(ctx, err, "failed to end break-glass session")
+	}
+	if session == nil {
+		return breakGlassError(ctx, errs.ErrBreakGlassNotActive, "failed to end break-glass session")
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionBreakGlassEnd, Token: session.ID}
+	defer b.auditor.Audit(ctx, audit)
+
+	if err = b.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+	if err = b.breakGlassService.End(reqCtx, session); err != nil {
+		return breakGlassError(ctx, err, "failed to end break-glass session")
+	}
+
+	// The session token no longer grants emergency access, so a failure to replace it
+	// only costs the caller a new sign-in when it expires.
+	resp, err := b.authService.IssueAccessToken(reqCtx, &auth_service.IssueAccessTokenRequest{UserId: userID})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to issue access token",
+			logger.Err(err))
+	} else {
+		helpers.SetAccessTokenCookie(ctx, resp.AccessToken, b.accessTokenMaxAge)
+	}
+
+	return ctx.JSON(http.StatusOK, helpers.BreakGlassSessionToSchema(session))
+}
+
+// GetCurrentBreakGlass godoc
+// @Summary Получить активную сессию экстренного доступа
+// @Description Возвращает активную сессию экстренного доступа текущего пользователя или null.
+// @Tags BreakGlass
+// @Produce json
+// @Success 200 {object} schemas.BreakGlassSessionSchema
+// @Failure 401 "unauthorized"
+// @Failure 500 "failed to get break-glass session"
+// @Security ApiKeyAuth
+// @Router /break-glass/ [get]
+func (b *BreakGlassHandler) GetCurrentBreakGlass(ctx echo.Context) error {
+	session, err := b.breakGlassService.ActiveForUser(ctx.Request().Context(), helpers.GetUserID(ctx))
+	if err != nil {
+		return breakGlassError(ctx, err, "failed to get break-glass session")
+	}
+	if session == nil {
+		return ctx.JSON(http.StatusOK, nil)
+	}
+	return ctx.JSON(http.StatusOK, helpers.BreakGlassSessionToSchema(session))
+}
+
+// GetBreakGlassSessions godoc
+// @Summary Получить сессии экстренного доступа
+// @Description Возвращает сессии экстренного доступа, начиная с новых, для проверки аудитором.
+// @Tags BreakGlass
+// @Produce json
+// @Param unacknowledged query bool false "Только не проверенные аудитором"
+// @Success 200 {array} schemas.BreakGlassSessionSchema
+// @Failure 400 "invalid unacknowledged"
+// @Failure 401 "unauthorized"
+// @Failure 500 "failed to get break-glass sessions"
+// @Security ApiKeyAuth
+// @Router /break-glass/sessions [get]
+func (b *BreakGlassHandler) GetBreakGlassSessions(ctx echo.Context) error {
+	var unacknowledged bool
+	if v := ctx.QueryParam("unacknowledged"); v != "" {
+		var err error
+		if unacknowledged, err = strconv.ParseBool(v); err != nil {
+			return helpers.BadRequest(ctx, "invalid unacknowledged")
+		}
+	}
+
+	sessions, err := b.breakGlassService.List(ctx.Request().Context(), unacknowledged)
+	if err != nil {
+		return breakGlassError(ctx, err, "failed to get break-glass sessions")
+	}
+
+	result := make([]*schemas.BreakGlassSessionSchema, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, helpers.BreakGlassSessionToSchema(session))
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// GetBreakGlassSession godoc
+// @Summary Получить сессию экстренного доступа
+// @Description Возвращает сессию экстренного доступа с операциями, выполненными в её течение.
+// @Tags BreakGlass
+// @Produce json
+// @Param id path string true "ID сессии"
+// @Success 200 {object} schemas.BreakGlassSessionDetailsSchema
+// @Failure 401 "unauthorized"
+// @Failure 404 "break-glass session not found"
+// @Failure 500 "failed to get break-glass session"
+// @Security ApiKeyAuth
+// @Router /break-glass/sessions/{id} [get]
+func (b *BreakGlassHandler) GetBreakGlassSession(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	session, err := b.breakGlassService.Get(reqCtx, ctx.Param("id"))
+	if err != nil {
+		return breakGlassError(ctx, err, "failed to get break-glass session")
+	}
+	operations, err := b.breakGlassService.Operations(reqCtx, session.ID)
+	if err != nil {
+		return breakGlassError(ctx, err, "failed to get break-glass session")
+	}
+
+	result := &schemas.BreakGlassSessionDetailsSchema{
+		BreakGlassSessionSchema: *helpers.BreakGlassSessionToSchema(session),
+		OperationList:           make([]*schemas.BreakGlassOperationSchema, 0, len(operations)),
+	}
+	for _, operation := range operations {
+		result.OperationList = append(result.OperationList, helpers.BreakGlassOperationToSchema(operation))
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// AcknowledgeBreakGlassSession godoc
+// @Summary Подтвердить проверку сессии экстренного доступа
+// @Description Отмечает завершённую сессию экстренного доступа как проверенную аудитором. Свою сессию подтвердить нельзя.
+// @Tags BreakGlass
+// @Produce json
+// @Param id path string true "ID сессии"
+// @Success 200 {object} schemas.BreakGlassSessionSchema
+// @Failure 401 "unauthorized"
+// @Failure 403 "user cannot acknowledge own break-glass session"
+// @Failure 404 "break-glass session not found"
+// @Failure 409 "break-glass session already active"
+// @Failure 500 "failed to acknowledge break-glass session / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /break-glass/sessions/{id}/acknowledge [post]
+func (b *BreakGlassHandler) AcknowledgeBreakGlassSession(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionBreakGlassAcknowledge, Token: ctx.Param("id")}
+	defer b.auditor.Audit(ctx, audit)
+
+	if err := b.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+	session, err := b.breakGlassService.Acknowledge(reqCtx, ctx.Param("id"), helpers.GetUserID(ctx))
+	if err != nil {
+		return breakGlassError(ctx, err, "failed to acknowledge break-glass session")
+	}
+
+	return ctx.JSON(http.StatusOK, helpers.BreakGlassSessionToSchema(session))
+}
@@ -5,10 +5,13 @@ import (
 	"github.com/NeF2le/anonix/common/gen/mapping"
 	"github.com/NeF2le/anonix/common/gen/tokenizer"
 	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
 	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
 	"github.com/NeF2le/anonix/gateway/internal/schemas"
 	"github.com/NeF2le/anonix/gateway/internal/services"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"log/slog"
 	"net/http"
 )
@@ -16,29 +19,40 @@ import (
 type KeyRotationHandler struct {
 	tokenizerService *services.TokenizerService
 	mappingService   *services.MappingService
+	auditor          *helpers.Auditor
 }
 
 func NewKeyRotationHandler(
 	tokenizerService *services.TokenizerService,
-	mappingService *services.MappingService) *KeyRotationHandler {
+	mappingService *services.MappingService,
+	auditor *helpers.Auditor) *KeyRotationHandler {
 	return &KeyRotationHandler{
 		tokenizerService: tokenizerService,
 		mappingService:   mappingService,
+		auditor:          auditor,
 	}
 }
 
 // RotateMasterKey godoc
 // @Summary Ротация мастер-ключа (KEK)
-// @Description Создаёт новую версию мастер-ключа Vault Transit и перешифровывает обёртки DEK всех маппингов последней версией ключа. Данные не изменяются.
+// @Description Создаёт новую версию мастер-ключа Vault Transit и перешифровывает обёртки DEK всех маппингов последней версией ключа. Данные не изменяются. Маппинги на удержании пропускаются.
 // @Tags Security
 // @Produce json
 // @Success 200 {object} schemas.KeyRotationResultSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
 // @Failure 500 "internal error"
 // @Security ApiKeyAuth
 // @Router /admin/keys/rotate-master [post]
 func (k *KeyRotationHandler) RotateMasterKey(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRotateMasterKey}
+	defer k.auditor.Audit(ctx, audit)
+
+	if err := k.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
 	if _, err := k.tokenizerService.RotateMasterKey(reqCtx, &tokenizer.RotateMasterKeyRequest{}); err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to rotate master key", logger.Err(err))
 		return helpers.InternalServerError(ctx, "failed to rotate master key")
@@ -50,9 +64,13 @@ func (k *KeyRotationHandler) RotateMasterKey(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "failed to get mapping list")
 	}
 
-	var updated, failed int32
+	var updated, failed, held int32
 	for _, mp := range listResp.GetMappingModels() {
 		if rotateErr := k.rewrapMappingDek(reqCtx, mp); rotateErr != nil {
+			if isOnHold(rotateErr) {
+				held++
+				continue
+			}
 			logger.GetLoggerFromCtx(reqCtx).Debug(reqCtx, "failed to rewrap mapping dek",
 				slog.String("id", mp.GetId()),
 				logger.Err(rotateErr))
@@ -62,14 +80,18 @@ func (k *KeyRotationHandler) RotateMasterKey(ctx echo.Context) error {
 		updated++
 	}
 
-	if _, auditErr := k.mappingService.CreateAuditLog(reqCtx, &mapping.CreateAuditLogRequest{
-		UserId: helpers.GetUserID(ctx),
-		Action: "rotate_master_key",
-	}); auditErr != nil {
-		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to write audit log", logger.Err(auditErr))
-	}
+	return ctx.JSON(http.StatusOK, &schemas.KeyRotationResultSchema{
+		UpdatedCount: updated,
+		FailedCount:  failed,
+		HeldCount:    held,
+	})
+}
 
-	return ctx.JSON(http.StatusOK, &schemas.KeyRotationResultSchema{UpdatedCount: updated, FailedCount: failed})
+// isOnHold reports whether the mapping service refused to replace the crypto material
+// of a mapping under legal hold.
+func isOnHold(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.FailedPrecondition
 }
 
 func (k *KeyRotationHandler) rewrapMappingDek(ctx context.Context, mp *mapping.MappingModel) error {
@@ -87,25 +109,37 @@ func (k *KeyRotationHandler) rewrapMappingDek(ctx context.Context, mp *mapping.M
 
 // RotateAllDeks godoc
 // @Summary Ротация ключей шифрования данных (DEK)
-// @Description Полностью перешифровывает данные всех маппингов новыми DEK. Значения токенов, видимые пользователям, не меняются.
+// @Description Полностью перешифровывает данные всех маппингов новыми DEK. Значения токенов, видимые пользователям, не меняются. Маппинги на удержании пропускаются.
 // @Tags Security
 // @Produce json
 // @Success 200 {object} schemas.KeyRotationResultSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
 // @Failure 500 "internal error"
 // @Security ApiKeyAuth
 // @Router /admin/keys/rotate-deks [post]
 func (k *KeyRotationHandler) RotateAllDeks(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRotateDeks}
+	defer k.auditor.Audit(ctx, audit)
+
+	if err := k.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
 	listResp, err := k.mappingService.GetMappingList(reqCtx, &mapping.GetMappingListRequest{})
 	if err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get mapping list", logger.Err(err))
 		return helpers.InternalServerError(ctx, "failed to get mapping list")
 	}
 
-	var updated, failed int32
+	var updated, failed, held int32
 	for _, mp := range listResp.GetMappingModels() {
 		if rotateErr := k.rotateMappingDek(reqCtx, mp); rotateErr != nil {
+			if isOnHold(rotateErr) {
+				held++
+				continue
+			}
 			logger.GetLoggerFromCtx(reqCtx).Debug(reqCtx, "failed to rotate mapping dek",
 				slog.String("id", mp.GetId()),
 				logger.Err(rotateErr))
@@ -115,14 +149,11 @@ func (k *KeyRotationHandler) RotateAllDeks(ctx echo.Context) error {
 		updated++
 	}
 
-	if _, auditErr := k.mappingService.CreateAuditLog(reqCtx, &mapping.CreateAuditLogRequest{
-		UserId: helpers.GetUserID(ctx),
-		Action: "rotate_deks",
-	}); auditErr != nil {
-		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to write audit log", logger.Err(auditErr))
-	}
-
-	return ctx.JSON(http.StatusOK, &schemas.KeyRotationResultSchema{UpdatedCount: updated, FailedCount: failed})
+	return ctx.JSON(http.StatusOK, &schemas.KeyRotationResultSchema{
+		UpdatedCount: updated,
+		FailedCount:  failed,
+		HeldCount:    held,
+	})
 }
 
 func (k *KeyRotationHandler) rotateMappingDek(ctx context.Context, mp *mapping.MappingModel) error {
@@ -0,0 +1,271 @@
+package http_handlers
+
+import (
+	"fmt"
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+type KindOperationGrantHandler struct {
+	authService    *services.AuthService
+	mappingService *services.MappingService
+	auditor        *helpers.Auditor
+	// tokenRevocation makes changed grants take effect at once; nil leaves the previous
+	// operations in the access tokens already issued until those expire.
+	tokenRevocation *services.TokenRevocation
+}
+
+func NewKindOperationGrantHandler(
+	authService *services.AuthService,
+	mappingService *services.MappingService,
+	auditor *helpers.Auditor,
+	tokenRevocation *services.TokenRevocation) *KindOperationGrantHandler {
+	return &KindOperationGrantHandler{
+		authService:     authService,
+		mappingService:  mappingService,
+		auditor:         auditor,
+		tokenRevocation: tokenRevocation,
+	}
+}
+
+// SetKindOperationGrant godoc
+// @Summary Разрешить операции над видом данных
+// @Description Разрешает пользователю (user_id) или всем владельцам роли (role_id) операции над данными одного вида: tokenize, detokenize, view, delete.
+// @Description Указывается ровно одно из полей user_id и role_id. Повторный вызов для того же вида заменяет список операций.
+// @Description Операции действуют со следующего access-токена; разрешить операции самому себе или своей роли нельзя.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param body body schemas.SetKindOperationGrantSchema true "Пользователь или роль, вид данных и операции"
+// @Success 200 {object} schemas.KindOperationGrantSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
+// @Failure 400 "invalid request body / invalid kind operation grant"
+// @Failure 403 "user cannot grant access to themselves"
+// @Failure 404 "user not found / role not found / kind not found"
+// @Failure 500 "failed to set kind operation grant / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /user/kind-operations [put]
+func (h *KindOperationGrantHandler) SetKindOperationGrant(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var body schemas.SetKindOperationGrantSchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if (body.UserId == "") == (body.RoleId == 0) || body.RoleId < 0 || body.KindId <= 0 || len(body.Operations) == 0 {
+		return helpers.BadRequest(ctx, "invalid kind operation grant")
+	}
+	for _, op := range body.Operations {
+		if !slices.Contains(domain.KindOperations, op) {
+			return helpers.BadRequest(ctx, "invalid kind operation grant")
+		}
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionKindOperationGrantSet, Token: body.UserId, KindId: body.KindId}
+	if body.RoleId != 0 {
+		audit.Token = fmt.Sprintf("role:%d", body.RoleId)
+	}
+	defer h.auditor.Audit(ctx, audit)
+
+	userID := helpers.GetUserID(ctx)
+	if body.UserId == userID || h.holdsRole(ctx, body.RoleId) {
+		return helpers.Forbidden(ctx, "user cannot grant access to themselves")
+	}
+
+	if _, err := h.mappingService.GetKind(reqCtx, &mapping.GetKindRequest{Id: body.KindId}); err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return helpers.NotFound(ctx, "kind not found")
+		}
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get kind",
+			slog.Int("kind_id", int(body.KindId)),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to set kind operation grant")
+	}
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.SetKindOperationGrant(reqCtx, &auth_service.SetKindOperationGrantRequest{
+		UserId:     body.UserId,
+		RoleId:     body.RoleId,
+		KindId:     body.KindId,
+		Operations: body.Operations,
+		GrantedBy:  userID,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				if body.RoleId != 0 {
+					return helpers.NotFound(ctx, "role not found")
+				}
+				return helpers.NotFound(ctx, "user not found")
+			case codes.InvalidArgument:
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "invalid kind operation grant",
+					slog.String("user ID", body.UserId),
+					slog.Int("role ID", int(body.RoleId)),
+					logger.Err(err))
+				return helpers.BadRequest(ctx, "invalid kind operation grant")
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to set kind operation grant",
+			slog.String("user ID", body.UserId),
+			slog.Int("role ID", int(body.RoleId)),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to set kind operation grant")
+	}
+
+	h.revokeTokens(ctx, resp.UserIds)
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "kind operation grant set",
+		slog.Int("grant_id", int(resp.Grant.Id)),
+		slog.String("user_id", resp.Grant.UserId),
+		slog.Int("role_id", int(resp.Grant.RoleId)),
+		slog.String("granted_by", userID))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoKindOperationGrantToSchema(resp.Grant))
+}
+
+// DeleteKindOperationGrant godoc
+// @Summary Удалить разрешение операций над видом данных
+// @Description Удаляет разрешение операций. Ранее выданные затронутым пользователям access-токены перестают действовать
+// @Description и заменяются при следующем запросе токенами без удалённых операций.
+// @Tags Users
+// @Produce json
+// @Param id path int true "ID разрешения"
+// @Success 200 {object} schemas.KindOperationGrantSchema
+// @Failure 400 "invalid grant ID"
+// @Failure 404 "kind operation grant not found"
+// @Failure 500 "failed to delete kind operation grant / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /user/kind-operations/{id} [delete]
+func (h *KindOperationGrantHandler) DeleteKindOperationGrant(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 32)
+	if err != nil || id <= 0 {
+		return helpers.BadRequest(ctx, "invalid grant ID")
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionKindOperationGrantDelete, Token: ctx.Param("id")}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err = h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.DeleteKindOperationGrant(reqCtx, &auth_service.DeleteKindOperationGrantRequest{Id: int32(id)})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return helpers.NotFound(ctx, "kind operation grant not found")
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to delete kind operation grant",
+			slog.Int64("grant_id", id),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to delete kind operation grant")
+	}
+	audit.KindId = resp.Grant.KindId
+
+	h.revokeTokens(ctx, resp.UserIds)
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "kind operation grant deleted",
+		slog.Int64("grant_id", id),
+		slog.String("user_id", resp.Grant.UserId),
+		slog.Int("role_id", int(resp.Grant.RoleId)))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoKindOperationGrantToSchema(resp.Grant))
+}
+
+// GetKindOperationGrants godoc
+// @Summary Получить разрешения операций над видами данных
+// @Description Возвращает разрешения пользователя, роли или все разрешения, упорядоченные по виду данных.
+// @Description С effective=true для пользователя возвращаются также разрешения его ролей.
+// @Tags Users
+// @Produce json
+// @Param user_id query string false "ID пользователя"
+// @Param role_id query int false "ID роли"
+// @Param effective query bool false "Включить разрешения ролей пользователя"
+// @Success 200 {array} schemas.KindOperationGrantSchema
+// @Failure 400 "invalid user ID / invalid role ID / invalid effective"
+// @Failure 500 "failed to get kind operation grants"
+// @Security ApiKeyAuth
+// @Router /user/kind-operations [get]
+func (h *KindOperationGrantHandler) GetKindOperationGrants(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var roleID int64
+	if v := ctx.QueryParam("role_id"); v != "" {
+		var err error
+		if roleID, err = strconv.ParseInt(v, 10, 32); err != nil || roleID <= 0 {
+			return helpers.BadRequest(ctx, "invalid role ID")
+		}
+	}
+
+	var effective bool
+	if v := ctx.QueryParam("effective"); v != "" {
+		var err error
+		if effective, err = strconv.ParseBool(v); err != nil {
+			return helpers.BadRequest(ctx, "invalid effective")
+		}
+	}
+
+	resp, err := h.authService.GetKindOperationGrants(reqCtx, &auth_service.GetKindOperationGrantsRequest{
+		UserId:    ctx.QueryParam("user_id"),
+		RoleId:    int32(roleID),
+		Effective: effective,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return helpers.BadRequest(ctx, "invalid user ID")
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get kind operation grants",
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get kind operation grants")
+	}
+
+	grants := make([]*schemas.KindOperationGrantSchema, 0, len(resp.Grants))
+	for _, g := range resp.Grants {
+		grants = append(grants, helpers.ProtoKindOperationGrantToSchema(g))
+	}
+	return ctx.JSON(http.StatusOK, grants)
+}
+
+// holdsRole reports whether the caller holds the role with the given ID.
+func (h *KindOperationGrantHandler) holdsRole(ctx echo.Context, roleID int32) bool {
+	if roleID == 0 {
+		return false
+	}
+	roles, _ := ctx.Get("roles").([]*auth_service.Role)
+	return slices.ContainsFunc(roles, func(r *auth_service.Role) bool { return r.Id == roleID })
+}
+
+// revokeTokens makes the changed grant take effect for the affected users at once.
+func (h *KindOperationGrantHandler) revokeTokens(ctx echo.Context, userIDs []string) {
+	if h.tokenRevocation == nil {
+		return
+	}
+	reqCtx := ctx.Request().Context()
+	for _, id := range userIDs {
+		if err := h.tokenRevocation.Revoke(reqCtx, id); err != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to revoke access tokens",
+				slog.String("user_id", id),
+				logger.Err(err))
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package http_handlers
+
+import (
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type LegalHoldHandler struct {
+	mappingService *services.MappingService
+	auditor        *helpers.Auditor
+}
+
+func NewLegalHoldHandler(mappingService *services.MappingService, auditor *helpers.Auditor) *LegalHoldHandler {
+	return &LegalHoldHandler{mappingService: mappingService, auditor: auditor}
+}
+
+// validateLegalHold returns the error message for the first invalid field of body, if any.
+func validateLegalHold(body *schemas.CreateLegalHoldSchema) string {
+	switch {
+	case body.Name == "" || len(body.Name) > 100:
+		return "invalid name"
+	case body.Reason == "":
+		return "reason is required"
+	case body.Owner == "" || len(body.Owner) > 100:
+		return "invalid owner"
+	case len(body.Targets) == 0:
+		return "targets are required"
+	case body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()):
+		return "expires_at must be in the future"
+	}
+
+	for _, target := range body.Targets {
+		if target == nil {
+			return "invalid target"
+		}
+		switch target.Type {
+		case "token":
+			if target.Value == "" || len(target.Value) > 100 {
+				return "invalid token target"
+			}
+		case "subject":
+			if !helpers.IsValidSubjectRef(target.Value) {
+				return "invalid subject target"
+			}
+		case "kind":
+			if id, err := strconv.Atoi(target.Value); err != nil || id <= 0 {
+				return "invalid kind target"
+			}
+		default:
+			return "invalid target type"
+		}
+	}
+
+	return ""
+}
+
+// GetLegalHolds godoc
+// @Summary Получить список удержаний
+// @Description Возвращает удержания (legal hold), начиная с новых. С параметром active=true — только действующие.
+// @Tags LegalHolds
+// @Produce json
+// @Param active query bool false "Только действующие удержания"
+// @Success 200 {array} schemas.LegalHoldSchema
+// @Failure 500 "failed to get legal holds"
+// @Security ApiKeyAuth
+// @Router /legal-holds/ [get]
+func (l *LegalHoldHandler) GetLegalHolds(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	activeOnly, _ := strconv.ParseBool(ctx.QueryParam("active"))
+
+	resp, err := l.mappingService.ListLegalHolds(reqCtx, &mapping.ListLegalHoldsRequest{ActiveOnly: activeOnly})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get legal holds", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get legal holds")
+	}
+
+	holds := make([]*schemas.LegalHoldSchema, 0, len(resp.Holds))
+	for _, h := range resp.Holds {
+		holds = append(holds, helpers.ProtoLegalHoldToSchema(h))
+	}
+
+	return ctx.JSON(http.StatusOK, holds)
+}
+
+// CreateLegalHold godoc
+// @Summary Создать удержание
+// @Description Создаёт удержание (legal hold) токенов, всех токенов субъекта или всех токенов вида данных (value — ID вида).
+// @Description Пока удержание действует, такие токены не удаляются ни по TTL, ни вручную, ни при удалении данных субъекта.
+// @Description Создание фиксируется в журнале аудита.
+// @Tags LegalHolds
+// @Accept json
+// @Produce json
+// @Param body body schemas.CreateLegalHoldSchema true "Удержание"
+// @Success 200 {object} schemas.LegalHoldSchema
+// @Failure 400 "invalid request body / invalid target"
+// @Failure 409 "legal hold already exists"
+// @Failure 500 "failed to create legal hold"
+// @Security ApiKeyAuth
+// @Router /legal-holds/ [post]
+func (l *LegalHoldHandler) CreateLegalHold(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionHoldCreate}
+	defer l.auditor.AuditFailure(ctx, audit)
+
+	var body schemas.CreateLegalHoldSchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	audit.Token = body.Name
+	if msg := validateLegalHold(&body); msg != "" {
+		return helpers.BadRequest(ctx, msg)
+	}
+
+	req := &mapping.CreateLegalHoldRequest{
+		Name:   body.Name,
+		Reason: body.Reason,
+		Owner:  body.Owner,
+		UserId: helpers.GetUserID(ctx),
+	}
+	for _, target := range body.Targets {
+		req.Targets = append(req.Targets, &mapping.LegalHoldTarget{Type: target.Type, Value: target.Value})
+	}
+	if body.ExpiresAt != nil {
+		req.ExpiresAt = timestamppb.New(*body.ExpiresAt)
+	}
+
+	resp, err := l.mappingService.CreateLegalHold(reqCtx, req)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.AlreadyExists:
+				return helpers.Conflict(ctx, "legal hold already exists")
+			case codes.InvalidArgument:
+				return helpers.BadRequest(ctx, st.Message())
+			}
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to create legal hold", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to create legal hold")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "legal hold created",
+		slog.String("id", resp.Hold.Id),
+		slog.String("userID", helpers.GetUserID(ctx)))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoLegalHoldToSchema(resp.Hold))
+}
+
+// ReleaseLegalHold godoc
+// @Summary Снять удержание
+// @Description Снимает удержание; токены, срок хранения которых истёк, будут удалены при следующей очистке.
+// @Description Снятие фиксируется в журнале аудита.
+// @Tags LegalHolds
+// @Produce json
+// @Param id path string true "ID удержания"
+// @Success 200 {object} schemas.LegalHoldSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
+// @Failure 400 "invalid legal hold ID"
+// @Failure 404 "legal hold not found"
+// @Failure 409 "legal hold already released"
+// @Failure 500 "failed to release legal hold"
+// @Security ApiKeyAuth
+// @Router /legal-holds/{id}/release [post]
+func (l *LegalHoldHandler) ReleaseLegalHold(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	defer l.auditor.AuditFailure(ctx, &mapping.CreateAuditLogRequest{
+		Action: domain.AuditActionHoldRelease,
+		Token:  ctx.Param("id"),
+	})
+
+	id, err := helpers.ParseUUID(ctx.Param("id"))
+	if err != nil {
+		return helpers.BadRequest(ctx, "invalid legal hold ID")
+	}
+
+	resp, err := l.mappingService.ReleaseLegalHold(reqCtx, &mapping.ReleaseLegalHoldRequest{
+		Id:     id.String(),
+		UserId: helpers.GetUserID(ctx),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "legal hold not found")
+			case codes.FailedPrecondition:
+				return helpers.Conflict(ctx, "legal hold already released")
+			case codes.InvalidArgument:
+				return helpers.BadRequest(ctx, "invalid request")
+			}
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to release legal hold", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to release legal hold")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "legal hold released",
+		slog.String("id", id.String()),
+		slog.String("userID", helpers.GetUserID(ctx)))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoLegalHoldToSchema(resp.Hold))
+}
@@ -3,9 +3,11 @@ package http_handlers
 import (
 	"github.com/NeF2le/anonix/common/gen/mapping"
 	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
 	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
 	"github.com/NeF2le/anonix/gateway/internal/schemas"
 	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
 	"github.com/labstack/echo/v4"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,11 +18,37 @@ import (
 )
 
 type MappingServiceHandler struct {
-	mappingService *services.MappingService
+	mappingService       *services.MappingService
+	auditor              *helpers.Auditor
+	authorizer           *helpers.Authorizer
+	includeCryptoAllowed bool
 }
 
-func NewMappingServiceHandler(mappingService *services.MappingService) *MappingServiceHandler {
-	return &MappingServiceHandler{mappingService: mappingService}
+func NewMappingServiceHandler(
+	mappingService *services.MappingService,
+	auditor *helpers.Auditor,
+	authorizer *helpers.Authorizer,
+	includeCryptoAllowed bool) *MappingServiceHandler {
+	return &MappingServiceHandler{
+		mappingService:       mappingService,
+		auditor:              auditor,
+		authorizer:           authorizer,
+		includeCryptoAllowed: includeCryptoAllowed,
+	}
+}
+
+// includeCrypto reports whether cipher_text and dek_wrapped should be returned and
+// whether the caller is permitted to ask for them. Crypto material is only exposed to
+// callers the policy allows it, by default admins, who explicitly request it, and only
+// if the gateway allows it at all.
+func (m *MappingServiceHandler) includeCrypto(ctx echo.Context) (include bool, permitted bool) {
+	if ctx.QueryParam("include_crypto") != "true" {
+		return false, true
+	}
+	if !m.includeCryptoAllowed || !m.authorizer.Allowed(ctx, domain.PolicyActionMappingCrypto, policy.Resource{}) {
+		return false, false
+	}
+	return true, true
 }
 
 // UpdateMapping godoc
@@ -38,6 +66,10 @@ func NewMappingServiceHandler(mappingService *services.MappingService) *MappingS
 // @Router /mappings/{id} [patch]
 func (m *MappingServiceHandler) UpdateMapping(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionMappingUpdate, Token: ctx.Param("id")}
+	defer m.auditor.Audit(ctx, audit)
+
 	id, err := helpers.ParseUUID(ctx.Param("id"))
 	if err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to parse token UUID",
@@ -58,6 +90,10 @@ func (m *MappingServiceHandler) UpdateMapping(ctx echo.Context) error {
 		TokenTtl: durationpb.New(updateMappingSchema.TokenTtl),
 	}
 
+	if err = m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
 	resp, err := m.mappingService.UpdateMapping(reqCtx, updateMappingReq)
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -85,7 +121,7 @@ func (m *MappingServiceHandler) UpdateMapping(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
-	return ctx.JSON(http.StatusOK, helpers.ProtoMappingToSchema(resp.MappingModel))
+	return ctx.JSON(http.StatusOK, helpers.ProtoMappingToSchema(resp.MappingModel, false))
 }
 
 // DeleteMapping godoc
@@ -95,15 +131,21 @@ func (m *MappingServiceHandler) UpdateMapping(ctx echo.Context) error {
 // @Produce json
 // @Param id path string true "ID маппинга"
 // @Success 200 {string} string "OK"
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
 // @Failure 400 "invalid token ID"
 // @Failure 401 "unauthorized"
-// @Failure 404 "mapping not found"
+// @Failure 403 "insufficient clearance level"
+// @Failure 404 "mapping not found / mapping expired"
+// @Failure 409 "mapping is under legal hold"
 // @Failure 500 "internal error"
 // @Security ApiKeyAuth
 // @Router /mappings/{id} [delete]
 func (m *MappingServiceHandler) DeleteMapping(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionMappingDelete, Token: ctx.Param("id")}
+	defer m.auditor.Audit(ctx, audit)
+
 	id, err := helpers.ParseUUID(ctx.Param("id"))
 	if err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to parse token UUID",
@@ -112,7 +154,36 @@ func (m *MappingServiceHandler) DeleteMapping(ctx echo.Context) error {
 		return helpers.BadRequest(ctx, "invalid token ID")
 	}
 
-	_, err = m.mappingService.DeleteMapping(reqCtx, &mapping.DeleteMappingRequest{Id: id.String()})
+	getResp, err := m.mappingService.GetMapping(reqCtx, &mapping.GetMappingRequest{Id: id.String()})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "mapping not found")
+			case codes.DeadlineExceeded:
+				return helpers.NotFound(ctx, "mapping expired")
+			}
+		}
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get mapping",
+			slog.String("ID", id.String()),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to delete mapping")
+	}
+	if kind := getResp.MappingModel.Kind; kind != nil {
+		audit.KindId = kind.Id
+	}
+	if !m.authorizer.CanAccessKind(ctx, getResp.MappingModel.Kind, domain.KindOperationDelete) {
+		return helpers.Forbidden(ctx, "insufficient clearance level")
+	}
+
+	if err = m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	_, err = m.mappingService.DeleteMapping(reqCtx, &mapping.DeleteMappingRequest{
+		Id:     id.String(),
+		UserId: helpers.GetUserID(ctx),
+	})
 	if err != nil {
 		st, ok := status.FromError(err)
 		if ok {
@@ -125,6 +196,10 @@ func (m *MappingServiceHandler) DeleteMapping(ctx echo.Context) error {
 				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "invalid arguments for delete mapping",
 					slog.String("ID", id.String()))
 				return helpers.BadRequest(ctx, "invalid arguments for get mapping")
+			case codes.FailedPrecondition:
+				logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "mapping is under legal hold",
+					slog.String("ID", id.String()))
+				return helpers.Conflict(ctx, "mapping is under legal hold")
 			default:
 				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to delete mapping",
 					slog.String("ID", id.String()),
@@ -148,14 +223,20 @@ func (m *MappingServiceHandler) DeleteMapping(ctx echo.Context) error {
 // @Tags Mappings
 // @Produce json
 // @Param id path string true "ID маппинга"
+// @Param include_crypto query bool false "Вернуть cipher_text и dek_wrapped (только admin)"
 // @Success 200 {object} schemas.MappingSchema
 // @Failure 400 "invalid token ID"
+// @Failure 403 "insufficient clearance level / include_crypto is not permitted"
 // @Failure 404 "mapping not found"
 // @Failure 500 "internal error"
 // @Security ApiKeyAuth
 // @Router /mappings/{id} [get]
 func (m *MappingServiceHandler) GetMapping(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionMappingRead, Token: ctx.Param("id")}
+	defer m.auditor.Audit(ctx, audit)
+
 	id, err := helpers.ParseUUID(ctx.Param("id"))
 	if err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to parse token UUID",
@@ -164,6 +245,13 @@ func (m *MappingServiceHandler) GetMapping(ctx echo.Context) error {
 		return helpers.BadRequest(ctx, "invalid token ID")
 	}
 
+	includeCrypto, permitted := m.includeCrypto(ctx)
+	if !permitted {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "include_crypto requested without permission",
+			slog.String("userID", helpers.GetUserID(ctx)))
+		return helpers.Forbidden(ctx, "include_crypto is not permitted")
+	}
+
 	resp, err := m.mappingService.GetMapping(reqCtx, &mapping.GetMappingRequest{Id: id.String()})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -196,22 +284,48 @@ func (m *MappingServiceHandler) GetMapping(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
-	return ctx.JSON(http.StatusOK, helpers.ProtoMappingToSchema(resp.MappingModel))
+	audit.Token = resp.MappingModel.Token
+	if kind := resp.MappingModel.Kind; kind != nil {
+		audit.KindId = kind.Id
+	}
+	if !m.authorizer.CanAccessKind(ctx, resp.MappingModel.Kind, domain.KindOperationView) {
+		return helpers.Forbidden(ctx, "insufficient clearance level")
+	}
+
+	if err = m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoMappingToSchema(resp.MappingModel, includeCrypto))
 }
 
 // GetMappingList godoc
 // @Summary Получить список маппингов
-// @Description Возвращает список маппингов
+// @Description Возвращает список маппингов, доступных по уровню допуска пользователя
 // @Tags Mappings
 // @Produce json
+// @Param include_crypto query bool false "Вернуть cipher_text и dek_wrapped (только admin)"
 // @Success 200 {array} schemas.MappingSchema
+// @Failure 403 "include_crypto is not permitted"
 // @Failure 500 "failed to get mapping list"
 // @Security ApiKeyAuth
 // @Router /mappings/ [get]
 func (m *MappingServiceHandler) GetMappingList(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
-	resp, err := m.mappingService.GetMappingList(reqCtx, &mapping.GetMappingListRequest{})
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionMappingList}
+	defer m.auditor.Audit(ctx, audit)
+
+	includeCrypto, permitted := m.includeCrypto(ctx)
+	if !permitted {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "include_crypto requested without permission",
+			slog.String("userID", helpers.GetUserID(ctx)))
+		return helpers.Forbidden(ctx, "include_crypto is not permitted")
+	}
+
+	resp, err := m.mappingService.GetMappingList(reqCtx, &mapping.GetMappingListRequest{
+		MaxAccessLevel: m.authorizer.MaxAccessLevel(ctx, domain.KindOperationView),
+	})
 	if err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get mapping list", logger.Err(err))
 		return ctx.JSON(http.StatusInternalServerError, "failed to get mapping list")
@@ -219,8 +333,16 @@ func (m *MappingServiceHandler) GetMappingList(ctx echo.Context) error {
 
 	var mappings []*schemas.MappingSchema
 	for _, mm := range resp.MappingModels {
-		mappings = append(mappings, helpers.ProtoMappingToSchema(mm))
+		if !m.authorizer.CanAccessKind(ctx, mm.Kind, domain.KindOperationView) {
+			continue
+		}
+		mappings = append(mappings, helpers.ProtoMappingToSchema(mm, includeCrypto))
+	}
+
+	if err = m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
 	}
+
 	return ctx.JSON(http.StatusOK, mappings)
 }
 
@@ -312,11 +434,19 @@ func (m *MappingServiceHandler) GetKindList(ctx echo.Context) error {
 func (m *MappingServiceHandler) CreateKind(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionKindCreate}
+	defer m.auditor.Audit(ctx, audit)
+
 	var body schemas.CreateKindSchema
 
 	if err := ctx.Bind(&body); err != nil {
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
+	audit.Token = body.Name
+
+	if err := m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
 
 	resp, err := m.mappingService.CreateKind(reqCtx, &mapping.CreateKindRequest{
 		Name:        body.Name,
@@ -361,6 +491,9 @@ func (m *MappingServiceHandler) CreateKind(ctx echo.Context) error {
 func (m *MappingServiceHandler) UpdateKind(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionKindUpdate, Token: ctx.Param("id")}
+	defer m.auditor.Audit(ctx, audit)
+
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		return helpers.BadRequest(ctx, "invalid kind ID")
@@ -372,6 +505,10 @@ func (m *MappingServiceHandler) UpdateKind(ctx echo.Context) error {
 		return helpers.BadRequest(ctx, "invalid request body")
 	}
 
+	if err = m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
 	resp, err := m.mappingService.UpdateKind(reqCtx, &mapping.UpdateKindRequest{
 		Id:          int32(id),
 		Name:        body.Name,
@@ -406,6 +543,7 @@ func (m *MappingServiceHandler) UpdateKind(ctx echo.Context) error {
 // @Produce json
 // @Param id path int true "ID вида данных"
 // @Success 200 {string} string "OK"
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
 // @Failure 400 "invalid kind ID"
 // @Failure 404 "kind not found"
 // @Failure 500 "internal error"
@@ -413,18 +551,64 @@ func (m *MappingServiceHandler) UpdateKind(ctx echo.Context) error {
 // @Router /kinds/{id} [delete]
 // GetAuditLogList godoc
 // @Summary Получить журнал аудита
-// @Description Возвращает журнал операций токенизации/детокенизации ПДн
+// @Description Возвращает страницу журнала операций с ПДн с фильтрами и сортировкой.
+// @Description Пагинация по курсору: если есть следующая страница, её курсор возвращается в заголовке X-Next-Cursor
+// @Description и передаётся в параметре cursor вместе с теми же фильтрами и сортировкой.
 // @Tags Audit
 // @Produce json
+// @Param user_id query string false "ID пользователя"
+// @Param action query string false "Действие"
+// @Param token query string false "Токен"
+// @Param kind_id query int false "ID вида данных"
+// @Param access_level query int false "Уровень доступа вида данных"
+// @Param outcome query string false "Результат: success, denied или error"
+// @Param request_id query string false "ID запроса"
+// @Param break_glass query bool false "Только операции в режиме экстренного доступа"
+// @Param from query string false "Начало периода (RFC 3339 или YYYY-MM-DD)"
+// @Param to query string false "Конец периода (RFC 3339, не включая, или YYYY-MM-DD включительно)"
+// @Param sort query string false "time (по умолчанию), user_id, action или token"
+// @Param order query string false "desc (по умолчанию) или asc"
+// @Param limit query int false "Размер страницы, по умолчанию 100, не более 1000"
+// @Param cursor query string false "Курсор следующей страницы"
 // @Success 200 {array} schemas.AuditLogEntrySchema
+// @Header 200 {string} X-Next-Cursor "Курсор следующей страницы"
+// @Failure 400 "invalid filter / invalid cursor"
 // @Failure 500 "failed to get audit log list"
 // @Security ApiKeyAuth
 // @Router /audit/ [get]
 func (m *MappingServiceHandler) GetAuditLogList(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
-	resp, err := m.mappingService.GetAuditLogList(reqCtx, &mapping.GetAuditLogListRequest{})
+	filter, err := parseAuditLogFilter(ctx)
 	if err != nil {
+		return helpers.BadRequest(ctx, err.Error())
+	}
+
+	req := &mapping.GetAuditLogListRequest{
+		Filter: filter,
+		SortBy: ctx.QueryParam("sort"),
+		Cursor: ctx.QueryParam("cursor"),
+	}
+	switch ctx.QueryParam("order") {
+	case "", "desc":
+	case "asc":
+		req.Ascending = true
+	default:
+		return helpers.BadRequest(ctx, "invalid order")
+	}
+	if v := ctx.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return helpers.BadRequest(ctx, "invalid limit")
+		}
+		req.Limit = int32(limit)
+	}
+
+	resp, err := m.mappingService.GetAuditLogList(reqCtx, req)
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return helpers.BadRequest(ctx, st.Message())
+		}
 		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx,
 			"failed to get audit log list",
 			logger.Err(err))
@@ -432,22 +616,73 @@ func (m *MappingServiceHandler) GetAuditLogList(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "failed to get audit log list")
 	}
 
-	var entries []*schemas.AuditLogEntrySchema
+	entries := make([]*schemas.AuditLogEntrySchema, 0, len(resp.Entries))
 	for _, e := range resp.Entries {
 		entries = append(entries, helpers.ProtoAuditLogEntryToSchema(e))
 	}
 
+	if resp.NextCursor != "" {
+		ctx.Response().Header().Set("X-Next-Cursor", resp.NextCursor)
+	}
+
 	return ctx.JSON(http.StatusOK, entries)
 }
 
+// VerifyAuditLog godoc
+// @Summary Проверить целостность журнала аудита
+// @Description Проходит по цепочке хешей журнала аудита от первой записи, пересчитывая хеш каждой записи и сверяя его
+// @Description со следующей записью, подписанными контрольными точками и вершиной цепочки.
+// @Description При нарушении возвращает номер первого нарушенного звена и причину.
+// @Tags Audit
+// @Produce json
+// @Success 200 {object} schemas.AuditVerifySchema
+// @Failure 500 "failed to verify audit log"
+// @Security ApiKeyAuth
+// @Router /audit/verify [get]
+func (m *MappingServiceHandler) VerifyAuditLog(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	resp, err := m.mappingService.VerifyAuditLog(reqCtx, &mapping.VerifyAuditLogRequest{})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx,
+			"failed to verify audit log",
+			logger.Err(err))
+
+		return helpers.InternalServerError(ctx, "failed to verify audit log")
+	}
+
+	if !resp.Valid {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "audit log chain is broken",
+			slog.Int64("seq", resp.BrokenSeq),
+			slog.String("reason", resp.Reason))
+	}
+
+	return ctx.JSON(http.StatusOK, &schemas.AuditVerifySchema{
+		Valid:               resp.Valid,
+		Checked:             resp.Checked,
+		LastSeq:             resp.LastSeq,
+		CheckpointsVerified: resp.CheckpointsVerified,
+		BrokenSeq:           resp.BrokenSeq,
+		BrokenEntryId:       resp.BrokenEntryId,
+		Reason:              resp.Reason,
+	})
+}
+
 func (m *MappingServiceHandler) DeleteKind(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionKindDelete, Token: ctx.Param("id")}
+	defer m.auditor.Audit(ctx, audit)
+
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		return helpers.BadRequest(ctx, "invalid kind ID")
 	}
 
+	if err = m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
 	_, err = m.mappingService.DeleteKind(reqCtx, &mapping.DeleteKindRequest{
 		Id: int32(id),
 	})
@@ -471,3 +706,140 @@ func (m *MappingServiceHandler) DeleteKind(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, nil)
 }
+
+// GetPurposeList godoc
+// @Summary Получить каталог целей обработки
+// @Description Возвращает список целей обработки ПДн, которые можно указывать при токенизации и детокенизации
+// @Tags Purposes
+// @Produce json
+// @Success 200 {array} schemas.PurposeSchema
+// @Failure 500 "failed to get purpose list"
+// @Security ApiKeyAuth
+// @Router /purposes/ [get]
+func (m *MappingServiceHandler) GetPurposeList(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	resp, err := m.mappingService.ListPurposes(reqCtx, &mapping.ListPurposesRequest{})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx,
+			"failed to get purpose list",
+			logger.Err(err))
+
+		return helpers.InternalServerError(ctx, "failed to get purpose list")
+	}
+
+	result := make([]*schemas.PurposeSchema, 0, len(resp.Purposes))
+	for _, purpose := range resp.Purposes {
+		result = append(result, helpers.ProtoPurposeToSchema(purpose))
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// CreatePurpose godoc
+// @Summary Добавить цель обработки
+// @Description Добавляет цель обработки ПДн в каталог
+// @Tags Purposes
+// @Accept json
+// @Produce json
+// @Param body body schemas.CreatePurposeSchema true "Цель обработки"
+// @Success 200 {object} schemas.PurposeSchema
+// @Failure 400 "invalid request"
+// @Failure 409 "purpose already exists"
+// @Failure 500 "internal error"
+// @Security ApiKeyAuth
+// @Router /purposes/ [post]
+func (m *MappingServiceHandler) CreatePurpose(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionPurposeCreate}
+	defer m.auditor.Audit(ctx, audit)
+
+	var body schemas.CreatePurposeSchema
+
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	audit.Token = body.Name
+	if !helpers.IsValidPurposeName(body.Name) {
+		return helpers.BadRequest(ctx, "invalid purpose name")
+	}
+
+	if err := m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := m.mappingService.CreatePurpose(reqCtx, &mapping.CreatePurposeRequest{
+		Name:        body.Name,
+		Description: body.Description,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.AlreadyExists:
+				return helpers.Conflict(ctx, "purpose already exists")
+			case codes.InvalidArgument:
+				return helpers.BadRequest(ctx, "invalid request")
+			default:
+				return helpers.InternalServerError(ctx, "failed to create purpose")
+			}
+		}
+
+		return helpers.InternalServerError(ctx, "unexpected error")
+	}
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoPurposeToSchema(resp.Purpose))
+}
+
+// DeletePurpose godoc
+// @Summary Удалить цель обработки
+// @Description Удаляет цель обработки из каталога, если она не назначена ни одному токену
+// @Tags Purposes
+// @Produce json
+// @Param id path int true "ID цели обработки"
+// @Success 200 {string} string "OK"
+// @Failure 400 "invalid purpose ID"
+// @Failure 404 "purpose not found"
+// @Failure 409 "purpose is in use and cannot be deleted"
+// @Failure 500 "internal error"
+// @Security ApiKeyAuth
+// @Router /purposes/{id} [delete]
+func (m *MappingServiceHandler) DeletePurpose(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionPurposeDelete, Token: ctx.Param("id")}
+	defer m.auditor.Audit(ctx, audit)
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return helpers.BadRequest(ctx, "invalid purpose ID")
+	}
+
+	if err = m.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	_, err = m.mappingService.DeletePurpose(reqCtx, &mapping.DeletePurposeRequest{
+		Id: int32(id),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "purpose not found")
+			case codes.InvalidArgument:
+				return helpers.BadRequest(ctx, "invalid request")
+			case codes.FailedPrecondition:
+				return helpers.Conflict(ctx, "purpose is in use and cannot be deleted")
+			default:
+				return helpers.InternalServerError(ctx, "failed to delete purpose")
+			}
+		}
+
+		return helpers.InternalServerError(ctx, "unexpected error")
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
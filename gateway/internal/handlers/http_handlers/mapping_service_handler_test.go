@@ -0,0 +1,213 @@
+package http_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/ports"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/NeF2le/anonix/gateway/policies"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// fakeMappingRepo returns mappings as the mapping service would before the gateway
+// filters them, and records the access level lists were narrowed to and the audit
+// entries written. Methods the tests do not call are left to the nil interface.
+type fakeMappingRepo struct {
+	ports.MappingServiceRepository
+	mappings       []*mapping.MappingModel
+	maxAccessLevel int32
+	audits         []*mapping.CreateAuditLogRequest
+}
+
+func (r *fakeMappingRepo) GetMappingList(_ context.Context, req *mapping.GetMappingListRequest) (*mapping.GetMappingListResponse, error) {
+	r.maxAccessLevel = req.MaxAccessLevel
+	return &mapping.GetMappingListResponse{MappingModels: r.mappings}, nil
+}
+
+func (r *fakeMappingRepo) CreateAuditLog(_ context.Context, req *mapping.CreateAuditLogRequest) (*mapping.CreateAuditLogResponse, error) {
+	r.audits = append(r.audits, req)
+	return &mapping.CreateAuditLogResponse{}, nil
+}
+
+func newMappingHandler(t *testing.T, repo *fakeMappingRepo, includeCryptoAllowed bool) *MappingServiceHandler {
+	t.Helper()
+	engine, err := policy.NewEngine(policies.Default, "", domain.PolicyActions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditor, err := helpers.NewAuditor(repo, string(domain.AuditFailClosed), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mappingService := services.NewMappingService(repo, 1, 0)
+	return NewMappingServiceHandler(mappingService, auditor, helpers.NewAuthorizer(engine), includeCryptoAllowed)
+}
+
+// caller is the identity the auth middleware would set for a request; id defaults to
+// "user".
+type caller struct {
+	id          string
+	clearance   int
+	permissions []string
+	kindGrants  []int32
+}
+
+func (u caller) set(c echo.Context) {
+	if u.id == "" {
+		u.id = "user"
+	}
+	c.Set("userID", u.id)
+	c.Set("roles", []*auth_service.Role{})
+	c.Set("clearanceLevel", u.clearance)
+	c.Set("permissions", u.permissions)
+	c.Set("kindGrants", u.kindGrants)
+}
+
+func mappingOfLevel(token string, kindID, accessLevel int32) *mapping.MappingModel {
+	m := &mapping.MappingModel{Token: token, CipherText: []byte("cipher"), DekWrapped: []byte("dek")}
+	if kindID != 0 {
+		m.Kind = &mapping.Kind{Id: kindID, AccessLevel: accessLevel}
+	}
+	return m
+}
+
+func TestGetMappingList_FiltersByClearance(t *testing.T) {
+	mappings := []*mapping.MappingModel{
+		mappingOfLevel("kindless", 0, 0),
+		mappingOfLevel("level-1", 1, 1),
+		mappingOfLevel("level-2", 2, 2),
+		mappingOfLevel("level-4", 4, 4),
+	}
+
+	tests := []struct {
+		name           string
+		caller         caller
+		maxAccessLevel int32
+		want           []string
+	}{
+		{"within clearance", caller{clearance: 2}, 2, []string{"kindless", "level-1", "level-2"}},
+		// Every level is allowed, so the list is not narrowed.
+		{"highest clearance", caller{clearance: 4}, 0, []string{"kindless", "level-1", "level-2", "level-4"}},
+		// A granted kind is above the clearance level, so the list cannot be narrowed by
+		// level and is filtered item by item.
+		{"granted kind", caller{clearance: 1, kindGrants: []int32{4}}, 0, []string{"kindless", "level-1", "level-4"}},
+		{"no clearance", caller{}, policy.NoAccessLevel, []string{"kindless"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeMappingRepo{mappings: mappings}
+			h := newMappingHandler(t, repo, false)
+
+			c, rec := newRequest(http.MethodGet, "/mappings/")
+			tt.caller.set(c)
+			if err := h.GetMappingList(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("got status %d: %s", rec.Code, rec.Body)
+			}
+			if repo.maxAccessLevel != tt.maxAccessLevel {
+				t.Errorf("list narrowed to level %d, want %d", repo.maxAccessLevel, tt.maxAccessLevel)
+			}
+			if got := responseTokens(t, rec); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if len(repo.audits) != 1 || repo.audits[0].Action != domain.AuditActionMappingList {
+				t.Errorf("got audit entries %v", repo.audits)
+			}
+		})
+	}
+}
+
+func TestGetMappingList_IncludeCrypto(t *testing.T) {
+	admin := caller{clearance: 4, permissions: []string{domain.PolicyActionMappingCrypto}}
+
+	tests := []struct {
+		name                 string
+		caller               caller
+		query                string
+		includeCryptoAllowed bool
+		wantStatus           int
+		wantCrypto           bool
+	}{
+		{"not requested", admin, "", true, http.StatusOK, false},
+		{"requested", admin, "?include_crypto=true", true, http.StatusOK, true},
+		{"without permission", caller{clearance: 4}, "?include_crypto=true", true, http.StatusForbidden, false},
+		{"disabled", admin, "?include_crypto=true", false, http.StatusForbidden, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeMappingRepo{mappings: []*mapping.MappingModel{mappingOfLevel("level-1", 1, 1)}}
+			h := newMappingHandler(t, repo, tt.includeCryptoAllowed)
+
+			c, rec := newRequest(http.MethodGet, "/mappings/"+tt.query)
+			tt.caller.set(c)
+			if err := h.GetMappingList(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var got []*schemas.MappingSchema
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || len(got) != 1 {
+				t.Fatalf("got %s, %v", rec.Body, err)
+			}
+			if hasCrypto := got[0].CipherText != "" || got[0].DekWrapped != ""; hasCrypto != tt.wantCrypto {
+				t.Errorf("got cipher_text %q and dek_wrapped %q, want crypto fields %v",
+					got[0].CipherText, got[0].DekWrapped, tt.wantCrypto)
+			}
+		})
+	}
+}
+
+func TestProtoMappingToSchema_IncludeCrypto(t *testing.T) {
+	m := mappingOfLevel("level-1", 1, 1)
+
+	redacted := helpers.ProtoMappingToSchema(m, false)
+	if redacted.CipherText != "" || redacted.DekWrapped != "" {
+		t.Errorf("crypto fields projected without includeCrypto: %+v", redacted)
+	}
+	if redacted.Token != "level-1" || redacted.Kind == nil || redacted.Kind.Id != 1 {
+		t.Errorf("got %+v", redacted)
+	}
+
+	full := helpers.ProtoMappingToSchema(m, true)
+	if full.CipherText != "Y2lwaGVy" || full.DekWrapped != "ZGVr" {
+		t.Errorf("got cipher_text %q and dek_wrapped %q", full.CipherText, full.DekWrapped)
+	}
+}
+
+func newRequest(method, target string) (echo.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(httptest.NewRequest(method, target, nil), rec), rec
+}
+
+func responseTokens(t *testing.T, rec *httptest.ResponseRecorder) []string {
+	t.Helper()
+	var mappings []*schemas.MappingSchema
+	if err := json.Unmarshal(rec.Body.Bytes(), &mappings); err != nil {
+		t.Fatalf("got %s: %v", rec.Body, err)
+	}
+	tokens := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		tokens = append(tokens, m.Token)
+	}
+	return tokens
+}
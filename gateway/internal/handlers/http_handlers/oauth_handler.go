@@ -0,0 +1,148 @@
+package http_handlers
+
+import (
+	"errors"
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// grantTypeClientCredentials is the only grant the token endpoint supports: users sign in
+// through the panel or the identity provider.
+const grantTypeClientCredentials = "client_credentials"
+
+type OAuthHandler struct {
+	authService *services.AuthService
+	auditor     *helpers.Auditor
+}
+
+func NewOAuthHandler(authService *services.AuthService, auditor *helpers.Auditor) *OAuthHandler {
+	return &OAuthHandler{
+		authService: authService,
+		auditor:     auditor,
+	}
+}
+
+// Token godoc
+// @Summary Выпустить токен доступа сервисного аккаунта
+// @Description Обменивает API-ключ на короткоживущий токен доступа по схеме OAuth 2.0 client credentials (RFC 6749, раздел 4.4).
+// @Description client_id — ID сервисного аккаунта, client_secret — его API-ключ; они передаются в заголовке Authorization: Basic или в теле формы, но не обоими способами сразу.
+// @Description scope — разрешения токена через пробел, не шире разрешений ключа; без scope токен получает все разрешения ключа.
+// @Description Токен действует не дольше ключа и перестаёт действовать при его отзыве. Ошибки возвращаются в формате RFC 6749, раздел 5.2.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "client_credentials"
+// @Param client_id formData string false "ID сервисного аккаунта"
+// @Param client_secret formData string false "API-ключ"
+// @Param scope formData string false "Разрешения через пробел"
+// @Success 200 {object} schemas.ClientTokenRespSchema
+// @Failure 400 "invalid_request / unsupported_grant_type / invalid_scope"
+// @Failure 401 "invalid_client"
+// @Failure 500 "server_error"
+// @Router /oauth/token [post]
+func (h *OAuthHandler) Token(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionClientToken}
+	defer h.auditor.Audit(ctx, audit)
+
+	switch grantType := ctx.FormValue("grant_type"); grantType {
+	case "":
+		return helpers.OAuthError(ctx, http.StatusBadRequest, "invalid_request", "grant_type is required")
+	case grantTypeClientCredentials:
+	default:
+		return helpers.OAuthError(ctx, http.StatusBadRequest, "unsupported_grant_type", "only client_credentials is supported")
+	}
+
+	clientID, clientSecret, basic, err := clientCredentials(ctx)
+	if err != nil {
+		return helpers.OAuthError(ctx, http.StatusBadRequest, "invalid_request", err.Error())
+	}
+	audit.Token = clientID
+	if clientID == "" || clientSecret == "" {
+		return invalidClient(ctx, basic, "client authentication is required")
+	}
+
+	resp, err := h.authService.IssueClientToken(reqCtx, &auth_service.IssueClientTokenRequest{
+		ClientId:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        strings.Fields(ctx.FormValue("scope")),
+		Ip:           ctx.RealIP(),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.Unauthenticated:
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "invalid client credentials",
+					slog.String("client_id", clientID))
+				return invalidClient(ctx, basic, "invalid client credentials")
+			case codes.PermissionDenied:
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "client address is not allowed",
+					slog.String("client_id", clientID),
+					slog.String("ip", ctx.RealIP()))
+				return invalidClient(ctx, basic, "the api key cannot be used from this address")
+			case codes.InvalidArgument:
+				return helpers.OAuthError(ctx, http.StatusBadRequest, "invalid_scope", st.Message())
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to issue client token",
+			slog.String("client_id", clientID),
+			logger.Err(err))
+		return helpers.OAuthError(ctx, http.StatusInternalServerError, "server_error", "failed to issue token")
+	}
+
+	audit.UserId = resp.ServiceAccount.Id
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "client token issued",
+		slog.String("service_account_id", resp.ServiceAccount.Id),
+		slog.Any("scope", resp.Scope))
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	return ctx.JSON(http.StatusOK, &schemas.ClientTokenRespSchema{
+		AccessToken: resp.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(resp.ExpiresAt.AsTime()).Seconds()),
+		Scope:       strings.Join(resp.Scope, " "),
+	})
+}
+
+// clientCredentials returns the client ID and secret of the request and whether they
+// were sent with HTTP Basic authentication, where RFC 6749 has them form-encoded.
+func clientCredentials(ctx echo.Context) (clientID, clientSecret string, basic bool, err error) {
+	user, password, basic := ctx.Request().BasicAuth()
+	if !basic {
+		return ctx.FormValue("client_id"), ctx.FormValue("client_secret"), false, nil
+	}
+	if ctx.FormValue("client_secret") != "" {
+		return "", "", true, errors.New("client credentials must be sent in one way only")
+	}
+
+	if clientID, err = url.QueryUnescape(user); err != nil {
+		return "", "", true, errors.New("malformed client credentials")
+	}
+	if clientSecret, err = url.QueryUnescape(password); err != nil {
+		return "", "", true, errors.New("malformed client credentials")
+	}
+	return clientID, clientSecret, true, nil
+}
+
+// invalidClient rejects the client credentials. A client that authenticated with HTTP
+// Basic is told the scheme, as RFC 6749 requires.
+func invalidClient(ctx echo.Context, basic bool, description string) error {
+	if basic {
+		ctx.Response().Header().Set("WWW-Authenticate", `Basic realm="anonix"`)
+	}
+	return helpers.OAuthError(ctx, http.StatusUnauthorized, "invalid_client", description)
+}
@@ -0,0 +1,247 @@
+package http_handlers
+
+import (
+	"crypto/subtle"
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/NeF2le/anonix/gateway/internal/services/oidc"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Error codes the panel is sent with after a failed sign-in through the identity
+// provider.
+const (
+	ssoErrorFailed       = "sso_failed"
+	ssoErrorDenied       = "sso_denied"
+	ssoErrorInvalidState = "sso_invalid_state"
+	ssoErrorNoRoles      = "sso_no_roles"
+	ssoErrorLoginTaken   = "sso_login_taken"
+)
+
+// OidcHandler signs users in through an OpenID Connect provider. Sign-in with a login
+// and password keeps working alongside it.
+type OidcHandler struct {
+	// provider is nil when sign-in through the identity provider is disabled.
+	provider           *oidc.Provider
+	claimMapping       *oidc.ClaimMapping
+	authService        *services.AuthService
+	auditor            *helpers.Auditor
+	anomalyDetector    *services.AnomalyDetector
+	stateSecret        string
+	stateTTL           time.Duration
+	postLoginURL       string
+	errorURL           string
+	accessTokenMaxAge  int
+	refreshTokenMaxAge int
+}
+
+func NewOidcHandler(
+	provider *oidc.Provider,
+	claimMapping *oidc.ClaimMapping,
+	authService *services.AuthService,
+	auditor *helpers.Auditor,
+	anomalyDetector *services.AnomalyDetector,
+	stateSecret string,
+	stateTTL time.Duration,
+	postLoginURL,
+	errorURL string,
+	accessTokenMaxAge,
+	refreshTokenMaxAge int) *OidcHandler {
+	return &OidcHandler{
+		provider:           provider,
+		claimMapping:       claimMapping,
+		authService:        authService,
+		auditor:            auditor,
+		anomalyDetector:    anomalyDetector,
+		stateSecret:        stateSecret,
+		stateTTL:           stateTTL,
+		postLoginURL:       postLoginURL,
+		errorURL:           errorURL,
+		accessTokenMaxAge:  accessTokenMaxAge,
+		refreshTokenMaxAge: refreshTokenMaxAge,
+	}
+}
+
+// GetOidcInfo godoc
+// @Summary Вход через поставщика удостоверений
+// @Description Сообщает, включён ли вход через OpenID Connect, и название поставщика для кнопки входа.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} schemas.OidcInfoSchema
+// @Router /auth/oidc [get]
+func (h *OidcHandler) GetOidcInfo(ctx echo.Context) error {
+	if h.provider == nil {
+		return ctx.JSON(http.StatusOK, &schemas.OidcInfoSchema{})
+	}
+	return ctx.JSON(http.StatusOK, &schemas.OidcInfoSchema{Enabled: true, ProviderName: h.provider.Name()})
+}
+
+// Login godoc
+// @Summary Войти через поставщика удостоверений
+// @Description Перенаправляет браузер к поставщику OpenID Connect (authorization code flow с PKCE). Состояние входа хранится в подписанной cookie до возврата на /auth/oidc/callback.
+// @Tags Auth
+// @Success 302
+// @Failure 404 "sso is not enabled"
+// @Router /auth/oidc/login [get]
+func (h *OidcHandler) Login(ctx echo.Context) error {
+	if h.provider == nil {
+		return helpers.NotFound(ctx, "sso is not enabled")
+	}
+	reqCtx := ctx.Request().Context()
+
+	state, err := oidc.NewLoginState()
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to generate sso login state",
+			logger.Err(err))
+		return h.redirectError(ctx, ssoErrorFailed)
+	}
+	sealed, err := state.Seal(h.stateSecret, h.stateTTL)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to seal sso login state",
+			logger.Err(err))
+		return h.redirectError(ctx, ssoErrorFailed)
+	}
+	authURL, err := h.provider.AuthCodeURL(reqCtx, state)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to reach identity provider",
+			logger.Err(err))
+		return h.redirectError(ctx, ssoErrorFailed)
+	}
+
+	helpers.SetOidcStateCookie(ctx, sealed, int(h.stateTTL.Seconds()))
+	return ctx.Redirect(http.StatusFound, authURL)
+}
+
+// Callback godoc
+// @Summary Завершить вход через поставщика удостоверений
+// @Description Принимает код авторизации от поставщика, проверяет state, nonce и подпись ID-токена и входит под пользователем поставщика.
+// @Description При первом входе пользователь создаётся без пароля; роли и уровень допуска назначаются по его группам у поставщика при каждом входе.
+// @Description Устанавливает cookie с токенами и перенаправляет в панель; при ошибке — на страницу входа с параметром sso_error.
+// @Tags Auth
+// @Param code query string false "Код авторизации"
+// @Param state query string false "State входа"
+// @Param error query string false "Ошибка поставщика"
+// @Success 302
+// @Failure 404 "sso is not enabled"
+// @Router /auth/oidc/callback [get]
+func (h *OidcHandler) Callback(ctx echo.Context) error {
+	if h.provider == nil {
+		return helpers.NotFound(ctx, "sso is not enabled")
+	}
+	reqCtx := ctx.Request().Context()
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionSsoLogin}
+
+	sealed := helpers.GetOidcStateCookie(ctx)
+	helpers.ClearOidcStateCookie(ctx)
+
+	if idpErr := ctx.QueryParam("error"); idpErr != "" {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "identity provider refused sign-in",
+			slog.String("error", idpErr),
+			slog.String("description", ctx.QueryParam("error_description")))
+		return h.fail(ctx, audit, domain.AuditOutcomeDenied, ssoErrorDenied, idpErr)
+	}
+
+	state, err := oidc.OpenLoginState(sealed, h.stateSecret)
+	if err != nil || subtle.ConstantTimeCompare([]byte(ctx.QueryParam("state")), []byte(state.State)) != 1 {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "invalid sso login state",
+			logger.Err(err))
+		return h.fail(ctx, audit, domain.AuditOutcomeDenied, ssoErrorInvalidState, "invalid state")
+	}
+
+	rawIDToken, err := h.provider.Exchange(reqCtx, ctx.QueryParam("code"), state.Verifier)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to redeem sso authorization code",
+			logger.Err(err))
+		return h.fail(ctx, audit, domain.AuditOutcomeError, ssoErrorFailed, "code exchange failed")
+	}
+	claims, err := h.provider.Verify(reqCtx, rawIDToken, state.Nonce)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "invalid sso id token",
+			logger.Err(err))
+		return h.fail(ctx, audit, domain.AuditOutcomeDenied, ssoErrorFailed, "invalid id token")
+	}
+	identity, err := h.claimMapping.Map(claims)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to map sso claims",
+			logger.Err(err))
+		return h.fail(ctx, audit, domain.AuditOutcomeDenied, ssoErrorFailed, "invalid claims")
+	}
+
+	audit.Token = identity.Login
+	if len(identity.Roles) == 0 {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "sso user has no mapped roles",
+			slog.String("login", identity.Login),
+			slog.Any("groups", identity.Groups))
+		return h.fail(ctx, audit, domain.AuditOutcomeDenied, ssoErrorNoRoles, "no mapped roles")
+	}
+
+	issuer, _ := claims.GetIssuer()
+	resp, err := h.authService.LoginExternal(reqCtx, &auth_service.LoginExternalRequest{
+		Issuer:         issuer,
+		Subject:        identity.Subject,
+		Login:          identity.Login,
+		Roles:          identity.Roles,
+		ClearanceLevel: int32(identity.Clearance),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "sso login is taken by another user",
+				slog.String("login", identity.Login))
+			return h.fail(ctx, audit, domain.AuditOutcomeDenied, ssoErrorLoginTaken, "login is taken")
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to sign in sso user",
+			slog.String("login", identity.Login),
+			slog.Any("roles", identity.Roles),
+			logger.Err(err))
+		return h.fail(ctx, audit, domain.AuditOutcomeError, ssoErrorFailed, "failed to log in")
+	}
+
+	audit.UserId = resp.UserId
+	if h.anomalyDetector != nil {
+		if err = h.anomalyDetector.Reauthenticated(reqCtx, resp.UserId); err != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to lift re-authentication block",
+				slog.String("login", identity.Login),
+				logger.Err(err))
+		}
+	}
+	helpers.SetAccessTokenCookie(ctx, resp.AccessToken, h.accessTokenMaxAge)
+	helpers.SetRefreshTokenCookie(ctx, resp.RefreshToken, h.refreshTokenMaxAge)
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "sso user signed in",
+		slog.String("user_id", resp.UserId),
+		slog.String("login", identity.Login),
+		slog.Bool("created", resp.Created))
+
+	h.auditor.AuditOutcome(ctx, audit, domain.AuditOutcomeSuccess, "")
+	return ctx.Redirect(http.StatusFound, h.postLoginURL)
+}
+
+// fail audits the failed sign-in and sends the browser back to the panel with the error
+// code: the callback is a browser navigation, not an API call.
+func (h *OidcHandler) fail(ctx echo.Context, audit *mapping.CreateAuditLogRequest, outcome, code, reason string) error {
+	h.auditor.AuditOutcome(ctx, audit, outcome, reason)
+	return h.redirectError(ctx, code)
+}
+
+func (h *OidcHandler) redirectError(ctx echo.Context, code string) error {
+	u, err := url.Parse(h.errorURL)
+	if err != nil {
+		return helpers.InternalServerError(ctx, "invalid sso error url")
+	}
+	query := u.Query()
+	query.Set("sso_error", code)
+	u.RawQuery = query.Encode()
+	return ctx.Redirect(http.StatusFound, u.String())
+}
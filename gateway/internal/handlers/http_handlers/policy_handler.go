@@ -0,0 +1,116 @@
+package http_handlers
+
+import (
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/netip"
+	"slices"
+	"time"
+)
+
+// draftPolicySource is the source of a draft policy evaluated by ExplainPolicy.
+const draftPolicySource = "draft"
+
+type PolicyHandler struct {
+	engine     *policy.Engine
+	authorizer *helpers.Authorizer
+}
+
+func NewPolicyHandler(engine *policy.Engine, authorizer *helpers.Authorizer) *PolicyHandler {
+	return &PolicyHandler{engine: engine, authorizer: authorizer}
+}
+
+// GetPolicy godoc
+// @Summary Получить политику доступа
+// @Description Возвращает действующую политику доступа: её версию, источник, время загрузки, проверяемые действия и правила.
+// @Tags Policies
+// @Produce json
+// @Success 200 {object} schemas.PolicySchema
+// @Failure 401 "unauthorized"
+// @Security ApiKeyAuth
+// @Router /policies/ [get]
+func (h *PolicyHandler) GetPolicy(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, helpers.PolicyToSchema(h.engine.Policy()))
+}
+
+// ExplainPolicy godoc
+// @Summary Объяснить решение политики
+// @Description Вычисляет решение политики для действия без его выполнения и возвращает правила, применимые к действию, с результатом их условий.
+// @Description Пользователь, IP-адрес и время по умолчанию берутся из запроса. В поле policy можно передать черновик политики в YAML, чтобы проверить его до применения.
+// @Tags Policies
+// @Accept json
+// @Produce json
+// @Param body body schemas.ExplainPolicySchema true "Действие, ресурс и контекст"
+// @Success 200 {object} schemas.PolicyDecisionSchema
+// @Failure 400 {object} schemas.PolicyErrorSchema "invalid request body / invalid action / invalid operation / invalid ip / invalid time / invalid policy"
+// @Failure 401 "unauthorized"
+// @Security ApiKeyAuth
+// @Router /policies/explain [post]
+func (h *PolicyHandler) ExplainPolicy(ctx echo.Context) error {
+	var body schemas.ExplainPolicySchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if !slices.Contains(domain.PolicyActions, body.Action) {
+		return helpers.BadRequest(ctx, "invalid action")
+	}
+	if body.Operation != "" && !slices.Contains(domain.KindOperations, body.Operation) {
+		return helpers.BadRequest(ctx, "invalid operation")
+	}
+
+	in := h.authorizer.Input(ctx, body.Action, policy.Resource{
+		Kind:        int64(body.KindId),
+		AccessLevel: int64(body.AccessLevel),
+		Owner:       body.Owner,
+		Operation:   body.Operation,
+	})
+	if s := body.Subject; s != nil {
+		grants := make([]int64, 0, len(s.KindGrants))
+		for _, id := range s.KindGrants {
+			grants = append(grants, int64(id))
+		}
+		operations := make(map[int64][]string, len(s.KindOperations))
+		for _, ko := range s.KindOperations {
+			if ko != nil {
+				operations[int64(ko.KindId)] = append(operations[int64(ko.KindId)], ko.Operations...)
+			}
+		}
+		in.Subject = policy.Subject{
+			ID:             s.UserId,
+			Roles:          s.Roles,
+			Permissions:    s.Permissions,
+			Clearance:      int64(s.ClearanceLevel),
+			KindGrants:     grants,
+			KindOperations: operations,
+			BreakGlass:     s.BreakGlass,
+			ServiceAccount: s.ServiceAccount,
+		}
+	}
+	if body.Ip != "" {
+		if _, err := netip.ParseAddr(body.Ip); err != nil {
+			return helpers.BadRequest(ctx, "invalid ip")
+		}
+		in.Context.IP = body.Ip
+	}
+	if body.Time != "" {
+		at, err := time.Parse(time.RFC3339, body.Time)
+		if err != nil {
+			return helpers.BadRequest(ctx, "invalid time")
+		}
+		in.Context.Time = at.Local()
+	}
+
+	p := h.engine.Policy()
+	if body.Policy != "" {
+		var err error
+		if p, err = h.engine.Compile([]byte(body.Policy), draftPolicySource); err != nil {
+			return helpers.InvalidPolicy(ctx, err.Error())
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, helpers.PolicyDecisionToSchema(p.Decide(in), body.Action, p.Version))
+}
@@ -0,0 +1,130 @@
+package http_handlers
+
+import (
+	"bytes"
+	_ "embed"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const reportDateLayout = "2006-01-02"
+
+//go:embed templates/destruction_act.html
+var destructionActTemplateText string
+
+var destructionReasonLabels = map[string]string{
+	"ttl":             "Истечение срока хранения",
+	"manual":          "Удаление пользователем",
+	"subject_erasure": "Удаление данных субъекта",
+	"unknown":         "Не указано",
+}
+
+var destructionActTemplate = template.Must(template.New("destruction_act").Funcs(template.FuncMap{
+	"reason": func(reason string) string {
+		if label, ok := destructionReasonLabels[reason]; ok {
+			return label
+		}
+		return reason
+	},
+	"date": func(value string) string {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return value
+		}
+		return t.Format("02.01.2006 15:04:05 MST")
+	},
+}).Parse(destructionActTemplateText))
+
+type ReportHandler struct {
+	mappingService *services.MappingService
+}
+
+func NewReportHandler(mappingService *services.MappingService) *ReportHandler {
+	return &ReportHandler{mappingService: mappingService}
+}
+
+// GetDestructionAct godoc
+// @Summary Сформировать акт уничтожения ПДн
+// @Description Возвращает акт об уничтожении токенов за период с from по to включительно (даты в UTC), сгруппированный по видам данных.
+// @Description В акт попадают удаления по истечении срока хранения, ручные удаления и удаления данных субъекта.
+// @Description Акт содержит SHA-256 дайджест канонического представления и подпись дайджеста ключом Vault transit.
+// @Description С format=html возвращается печатная форма акта (для PDF — печать из браузера).
+// @Tags Reports
+// @Produce json
+// @Produce html
+// @Param from query string true "Начало периода (YYYY-MM-DD)"
+// @Param to query string true "Конец периода включительно (YYYY-MM-DD)"
+// @Param format query string false "json (по умолчанию) или html"
+// @Success 200 {object} schemas.DestructionActSchema
+// @Failure 400 "invalid period / invalid format"
+// @Failure 500 "failed to get destruction act"
+// @Security ApiKeyAuth
+// @Router /reports/destruction [get]
+func (r *ReportHandler) GetDestructionAct(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "html" {
+		return helpers.BadRequest(ctx, "invalid format")
+	}
+
+	from, err := time.Parse(reportDateLayout, ctx.QueryParam("from"))
+	if err != nil {
+		return helpers.BadRequest(ctx, "invalid period")
+	}
+	to, err := time.Parse(reportDateLayout, ctx.QueryParam("to"))
+	if err != nil || to.Before(from) {
+		return helpers.BadRequest(ctx, "invalid period")
+	}
+
+	resp, err := r.mappingService.GetDestructionAct(reqCtx, &mapping.GetDestructionActRequest{
+		From:   timestamppb.New(from),
+		To:     timestamppb.New(to.AddDate(0, 0, 1)),
+		UserId: helpers.GetUserID(ctx),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.InvalidArgument {
+			return helpers.BadRequest(ctx, "invalid period")
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get destruction act", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get destruction act")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "destruction act generated",
+		slog.String("from", from.Format(reportDateLayout)),
+		slog.String("to", to.Format(reportDateLayout)),
+		slog.String("userID", helpers.GetUserID(ctx)))
+
+	act := helpers.ProtoDestructionActToSchema(resp.Act)
+	if format == "json" {
+		return ctx.JSON(http.StatusOK, act)
+	}
+
+	var page bytes.Buffer
+	err = destructionActTemplate.Execute(&page, struct {
+		*schemas.DestructionActSchema
+		From string
+		To   string
+	}{act, from.Format("02.01.2006"), to.Format("02.01.2006")})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to render destruction act", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get destruction act")
+	}
+
+	return ctx.HTMLBlob(http.StatusOK, page.Bytes())
+}
@@ -0,0 +1,287 @@
+package http_handlers
+
+import (
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxRoleDescriptionLen is the longest role description the auth service keeps.
+const maxRoleDescriptionLen = 200
+
+var roleNamePattern = regexp.MustCompile(`^[a-z0-9_-]{2,50}$`)
+
+type RoleHandler struct {
+	authService *services.AuthService
+	auditor     *helpers.Auditor
+	// tokenRevocation makes changed permissions of a role take effect at once; nil
+	// leaves the old permissions in the access tokens already issued until those expire.
+	tokenRevocation *services.TokenRevocation
+}
+
+func NewRoleHandler(
+	authService *services.AuthService,
+	auditor *helpers.Auditor,
+	tokenRevocation *services.TokenRevocation) *RoleHandler {
+	return &RoleHandler{
+		authService:     authService,
+		auditor:         auditor,
+		tokenRevocation: tokenRevocation,
+	}
+}
+
+// validateRole normalizes the custom role of a request body and returns the error to
+// answer with, or an empty string if the role is valid.
+func validateRole(body *schemas.RoleBodySchema) string {
+	body.Name = strings.TrimSpace(body.Name)
+	if !roleNamePattern.MatchString(body.Name) {
+		return "invalid role name"
+	}
+	body.Description = strings.TrimSpace(body.Description)
+	if utf8.RuneCountInString(body.Description) > maxRoleDescriptionLen {
+		return "invalid description"
+	}
+	if len(body.Permissions) == 0 {
+		return "role must have at least one permission"
+	}
+	return ""
+}
+
+// checkPermissions writes the rejection of role permissions that exceed the access of
+// the caller: every permission of the role must be one of the caller's.
+func checkPermissions(ctx echo.Context, permissions []string) error {
+	callerPermissions := helpers.GetPermissions(ctx)
+	for _, p := range permissions {
+		if !slices.Contains(callerPermissions, p) {
+			return helpers.Forbidden(ctx, "role permissions exceed caller access")
+		}
+	}
+	return nil
+}
+
+// roleError answers the error of the auth service on a role.
+func roleError(ctx echo.Context, err error, msg string) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			return helpers.BadRequest(ctx, "unknown permission")
+		case codes.NotFound:
+			return helpers.NotFound(ctx, "role not found")
+		case codes.AlreadyExists:
+			return helpers.Conflict(ctx, "role already exists")
+		case codes.FailedPrecondition:
+			return helpers.Conflict(ctx, "role is assigned to users")
+		case codes.PermissionDenied:
+			return helpers.Forbidden(ctx, "built-in role cannot be changed")
+		}
+	}
+
+	reqCtx := ctx.Request().Context()
+	logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, msg,
+		slog.String("role_id", ctx.Param("id")),
+		logger.Err(err))
+	return helpers.InternalServerError(ctx, msg)
+}
+
+// GetPermissions godoc
+// @Summary Получить каталог разрешений
+// @Description Возвращает разрешения, из которых составляются роли. Разрешение допускает действие политики доступа с тем же именем.
+// @Tags Roles
+// @Produce json
+// @Success 200 {array} schemas.PermissionSchema
+// @Failure 500 "failed to get permissions"
+// @Security ApiKeyAuth
+// @Router /role/permissions [get]
+func (h *RoleHandler) GetPermissions(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	resp, err := h.authService.GetPermissions(reqCtx, &auth_service.GetPermissionsRequest{})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get permissions",
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get permissions")
+	}
+
+	permissions := make([]*schemas.PermissionSchema, 0, len(resp.Permissions))
+	for _, p := range resp.Permissions {
+		permissions = append(permissions, &schemas.PermissionSchema{Name: p.Name, Description: p.Description})
+	}
+	return ctx.JSON(http.StatusOK, permissions)
+}
+
+// CreateRole godoc
+// @Summary Создать роль
+// @Description Создаёт пользовательскую роль из разрешений каталога; вызывающий может включить в роль только те разрешения, которые есть у него самого. Имя роли — от 2 до 50 строчных латинских букв, цифр, '_' или '-'.
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param body body schemas.RoleBodySchema true "Имя, описание и разрешения роли"
+// @Success 200 {object} schemas.RoleSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
+// @Failure 400 "invalid request body / invalid role name / invalid description / role must have at least one permission / unknown permission"
+// @Failure 403 "role permissions exceed caller access"
+// @Failure 409 "role already exists"
+// @Failure 500 "failed to create role / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /role/ [post]
+func (h *RoleHandler) CreateRole(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var body schemas.RoleBodySchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if msg := validateRole(&body); msg != "" {
+		return helpers.BadRequest(ctx, msg)
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRoleCreate, Token: body.Name}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err := checkPermissions(ctx, body.Permissions); err != nil {
+		return err
+	}
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.CreateRole(reqCtx, &auth_service.CreateRoleRequest{
+		Name:        body.Name,
+		Description: body.Description,
+		Permissions: body.Permissions,
+	})
+	if err != nil {
+		return roleError(ctx, err, "failed to create role")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "role created",
+		slog.Int("role_id", int(resp.Role.Id)),
+		slog.String("name", resp.Role.Name))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoRoleToSchema(resp.Role))
+}
+
+// UpdateRole godoc
+// @Summary Изменить роль
+// @Description Заменяет имя, описание и разрешения пользовательской роли. Встроенные роли admin, auditor и specialist изменить нельзя.
+// @Description Ранее выданные держателям роли access-токены перестают действовать и заменяются при следующем запросе токенами с новыми разрешениями.
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "ID роли"
+// @Param body body schemas.RoleBodySchema true "Имя, описание и разрешения роли"
+// @Success 200 {object} schemas.RoleSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
+// @Failure 400 "invalid role ID / invalid request body / invalid role name / invalid description / role must have at least one permission / unknown permission"
+// @Failure 403 "built-in role cannot be changed / role permissions exceed caller access"
+// @Failure 404 "role not found"
+// @Failure 409 "role already exists"
+// @Failure 500 "failed to update role / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /role/{id} [put]
+func (h *RoleHandler) UpdateRole(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 32)
+	if err != nil || id <= 0 {
+		return helpers.BadRequest(ctx, "invalid role ID")
+	}
+	var body schemas.RoleBodySchema
+	if err = ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if msg := validateRole(&body); msg != "" {
+		return helpers.BadRequest(ctx, msg)
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRoleUpdate, Token: body.Name}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err = checkPermissions(ctx, body.Permissions); err != nil {
+		return err
+	}
+
+	if err = h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.UpdateRole(reqCtx, &auth_service.UpdateRoleRequest{
+		Id:          int32(id),
+		Name:        body.Name,
+		Description: body.Description,
+		Permissions: body.Permissions,
+	})
+	if err != nil {
+		return roleError(ctx, err, "failed to update role")
+	}
+
+	if h.tokenRevocation != nil {
+		for _, userID := range resp.UserIds {
+			if err = h.tokenRevocation.Revoke(reqCtx, userID); err != nil {
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to revoke access tokens",
+					slog.String("user_id", userID),
+					logger.Err(err))
+			}
+		}
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "role updated",
+		slog.Int("role_id", int(resp.Role.Id)),
+		slog.Int("users", len(resp.UserIds)))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoRoleToSchema(resp.Role))
+}
+
+// DeleteRole godoc
+// @Summary Удалить роль
+// @Description Удаляет пользовательскую роль, которая не назначена ни одному пользователю. Встроенные роли удалить нельзя.
+// @Tags Roles
+// @Produce json
+// @Param id path int true "ID роли"
+// @Success 204
+// @Failure 400 "invalid role ID"
+// @Failure 403 "built-in role cannot be changed"
+// @Failure 404 "role not found"
+// @Failure 409 "role is assigned to users"
+// @Failure 500 "failed to delete role / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /role/{id} [delete]
+func (h *RoleHandler) DeleteRole(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 32)
+	if err != nil || id <= 0 {
+		return helpers.BadRequest(ctx, "invalid role ID")
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionRoleDelete, Token: ctx.Param("id")}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err = h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	if _, err = h.authService.DeleteRole(reqCtx, &auth_service.DeleteRoleRequest{Id: int32(id)}); err != nil {
+		return roleError(ctx, err, "failed to delete role")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "role deleted",
+		slog.Int64("role_id", id))
+
+	return ctx.NoContent(http.StatusNoContent)
+}
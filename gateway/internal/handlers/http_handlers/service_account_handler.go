@@ -0,0 +1,472 @@
+package http_handlers
+
+import (
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// maxApiKeyGraceHours is the longest time a rotated API key keeps working.
+const maxApiKeyGraceHours = 168
+
+type ServiceAccountHandler struct {
+	authService    *services.AuthService
+	mappingService *services.MappingService
+	authorizer     *helpers.Authorizer
+	auditor        *helpers.Auditor
+	// tokenRevocation ends the client tokens of a service account when its keys are
+	// revoked; nil leaves them valid until they expire.
+	tokenRevocation *services.TokenRevocation
+}
+
+func NewServiceAccountHandler(
+	authService *services.AuthService,
+	mappingService *services.MappingService,
+	authorizer *helpers.Authorizer,
+	auditor *helpers.Auditor,
+	tokenRevocation *services.TokenRevocation) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		authService:     authService,
+		mappingService:  mappingService,
+		authorizer:      authorizer,
+		auditor:         auditor,
+		tokenRevocation: tokenRevocation,
+	}
+}
+
+// revokeClientTokens ends the client tokens issued to the service account. Tokens are
+// revoked per account, so the clients of its other keys ask for new tokens as well.
+func (h *ServiceAccountHandler) revokeClientTokens(ctx echo.Context, accountID string) {
+	if h.tokenRevocation == nil {
+		return
+	}
+	reqCtx := ctx.Request().Context()
+	if err := h.tokenRevocation.Revoke(reqCtx, accountID); err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to revoke client tokens",
+			slog.String("service_account_id", accountID),
+			logger.Err(err))
+	}
+}
+
+// CreateServiceAccount godoc
+// @Summary Создать сервисный аккаунт
+// @Description Создаёт сервисный аккаунт — учётную запись внешней системы, которая обращается к API по API-ключам, а не по логину и паролю.
+// @Description Имя — от 2 до 50 строчных латинских букв, цифр, «_» или «-».
+// @Tags Service accounts
+// @Accept json
+// @Produce json
+// @Param body body schemas.CreateServiceAccountSchema true "Имя и описание"
+// @Success 200 {object} schemas.ServiceAccountSchema
+// @Failure 400 "invalid request body / invalid service account name / invalid description"
+// @Failure 409 "service account already exists"
+// @Failure 500 "failed to create service account / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /service-accounts/ [post]
+func (h *ServiceAccountHandler) CreateServiceAccount(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var body schemas.CreateServiceAccountSchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionServiceAccountCreate, Token: body.Name}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.CreateServiceAccount(reqCtx, &auth_service.CreateServiceAccountRequest{
+		Name:        body.Name,
+		Description: body.Description,
+		CreatedBy:   helpers.GetUserID(ctx),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.AlreadyExists:
+				return helpers.Conflict(ctx, "service account already exists")
+			case codes.InvalidArgument:
+				if st.Message() == "service account description must not exceed 200 characters" {
+					return helpers.BadRequest(ctx, "invalid description")
+				}
+				return helpers.BadRequest(ctx, "invalid service account name")
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to create service account",
+			slog.String("name", body.Name),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to create service account")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "service account created",
+		slog.String("service_account_id", resp.ServiceAccount.Id),
+		slog.String("name", resp.ServiceAccount.Name))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoServiceAccountToSchema(resp.ServiceAccount))
+}
+
+// DeleteServiceAccount godoc
+// @Summary Удалить сервисный аккаунт
+// @Description Удаляет сервисный аккаунт вместе с его API-ключами; ключи перестают действовать сразу. Записи журнала аудита сохраняются.
+// @Tags Service accounts
+// @Produce json
+// @Param id path string true "ID сервисного аккаунта"
+// @Success 200 {object} schemas.ServiceAccountSchema
+// @Failure 404 "service account not found"
+// @Failure 500 "failed to delete service account / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /service-accounts/{id} [delete]
+func (h *ServiceAccountHandler) DeleteServiceAccount(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionServiceAccountDelete, Token: ctx.Param("id")}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.DeleteServiceAccount(reqCtx, &auth_service.DeleteServiceAccountRequest{Id: ctx.Param("id")})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return helpers.NotFound(ctx, "service account not found")
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to delete service account",
+			slog.String("service_account_id", ctx.Param("id")),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to delete service account")
+	}
+
+	h.revokeClientTokens(ctx, resp.ServiceAccount.Id)
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "service account deleted",
+		slog.String("service_account_id", resp.ServiceAccount.Id),
+		slog.String("name", resp.ServiceAccount.Name))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoServiceAccountToSchema(resp.ServiceAccount))
+}
+
+// GetServiceAccounts godoc
+// @Summary Получить сервисные аккаунты
+// @Description Возвращает сервисные аккаунты, упорядоченные по имени.
+// @Tags Service accounts
+// @Produce json
+// @Success 200 {array} schemas.ServiceAccountSchema
+// @Failure 500 "failed to get service accounts"
+// @Security ApiKeyAuth
+// @Router /service-accounts/ [get]
+func (h *ServiceAccountHandler) GetServiceAccounts(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	resp, err := h.authService.GetServiceAccounts(reqCtx, &auth_service.GetServiceAccountsRequest{})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get service accounts",
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get service accounts")
+	}
+
+	accounts := make([]*schemas.ServiceAccountSchema, 0, len(resp.ServiceAccounts))
+	for _, a := range resp.ServiceAccounts {
+		accounts = append(accounts, helpers.ProtoServiceAccountToSchema(a))
+	}
+	return ctx.JSON(http.StatusOK, accounts)
+}
+
+// CreateApiKey godoc
+// @Summary Выпустить API-ключ
+// @Description Выпускает API-ключ сервисного аккаунта. Ключ даёт только перечисленные разрешения и доступ только к данным перечисленных видов, независимо от уровня допуска;
+// @Description с allowed_ips (адреса или CIDR-диапазоны) ключ принимается только с этих адресов. Срок действия — не более AUTH_SERVICE_API_KEY_MAX_LIFETIME.
+// @Description Ключ передаётся в заголовке «Authorization: ApiKey <ключ>» и возвращается только в этом ответе: сохраняется лишь его хеш.
+// @Description Выдать ключу разрешения или виды данных, которых нет у самого пользователя, нельзя.
+// @Tags Service accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "ID сервисного аккаунта"
+// @Param body body schemas.CreateApiKeySchema true "Разрешения, виды данных, адреса и срок действия"
+// @Success 200 {object} schemas.IssuedApiKeySchema
+// @Failure 400 "invalid request body / invalid api key scope / invalid expiry"
+// @Failure 403 "api key scope exceeds caller access"
+// @Failure 404 "service account not found / kind not found"
+// @Failure 500 "failed to create api key / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /service-accounts/{id}/keys [post]
+func (h *ServiceAccountHandler) CreateApiKey(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var body schemas.CreateApiKeySchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if len(body.Permissions) == 0 || slices.ContainsFunc(body.KindIds, func(id int32) bool { return id <= 0 }) {
+		return helpers.BadRequest(ctx, "invalid api key scope")
+	}
+	if body.ExpiresInDays <= 0 {
+		return helpers.BadRequest(ctx, "invalid expiry")
+	}
+
+	accountID := ctx.Param("id")
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionApiKeyCreate, Token: accountID}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err := h.checkScope(ctx, body.Permissions, body.KindIds); err != nil {
+		return err
+	}
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.CreateApiKey(reqCtx, &auth_service.CreateApiKeyRequest{
+		ServiceAccountId: accountID,
+		Permissions:      body.Permissions,
+		KindIds:          body.KindIds,
+		AllowedIps:       body.AllowedIps,
+		ExpiresAt:        timestamppb.New(time.Now().AddDate(0, 0, body.ExpiresInDays)),
+		CreatedBy:        helpers.GetUserID(ctx),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "service account not found")
+			case codes.InvalidArgument:
+				return helpers.BadRequest(ctx, "invalid api key scope")
+			case codes.OutOfRange:
+				return helpers.BadRequest(ctx, "invalid expiry")
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to create api key",
+			slog.String("service_account_id", accountID),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to create api key")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "api key created",
+		slog.String("key_id", resp.ApiKey.Id),
+		slog.String("prefix", resp.ApiKey.Prefix),
+		slog.String("service_account_id", accountID))
+
+	return ctx.JSON(http.StatusOK, &schemas.IssuedApiKeySchema{
+		ApiKey: helpers.ProtoApiKeyToSchema(resp.ApiKey),
+		Key:    resp.Key,
+	})
+}
+
+// checkScope writes the rejection of an API key scope that exceeds the access of the
+// caller: every permission of the key must be one of the caller's, and the caller must
+// have access to data of every kind of the key.
+func (h *ServiceAccountHandler) checkScope(ctx echo.Context, permissions []string, kindIDs []int32) error {
+	reqCtx := ctx.Request().Context()
+
+	callerPermissions := helpers.GetPermissions(ctx)
+	for _, p := range permissions {
+		if !slices.Contains(callerPermissions, p) {
+			return helpers.Forbidden(ctx, "api key scope exceeds caller access")
+		}
+	}
+
+	for _, id := range kindIDs {
+		kind, err := h.mappingService.GetKind(reqCtx, &mapping.GetKindRequest{Id: id})
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+				return helpers.NotFound(ctx, "kind not found")
+			}
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get kind",
+				slog.Int("kind_id", int(id)),
+				logger.Err(err))
+			return helpers.InternalServerError(ctx, "failed to create api key")
+		}
+		if !h.authorizer.CanAccessKind(ctx, kind.Kind, "") {
+			return helpers.Forbidden(ctx, "api key scope exceeds caller access")
+		}
+	}
+	return nil
+}
+
+// RotateApiKey godoc
+// @Summary Заменить API-ключ
+// @Description Выпускает новый ключ с теми же разрешениями, видами данных, адресами и сроком действия взамен действующего.
+// @Description Прежний ключ продолжает действовать grace_hours часов (не более 168), чтобы система успела перейти на новый, а при grace_hours=0 отзывается сразу.
+// @Description Новый ключ возвращается только в этом ответе.
+// @Tags Service accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "ID API-ключа"
+// @Param body body schemas.RotateApiKeySchema true "Переходный период"
+// @Success 200 {object} schemas.IssuedApiKeySchema
+// @Failure 400 "invalid request body / invalid grace period"
+// @Failure 404 "api key not found"
+// @Failure 409 "api key is not active"
+// @Failure 500 "failed to rotate api key / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /service-accounts/keys/{id}/rotate [post]
+func (h *ServiceAccountHandler) RotateApiKey(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var body schemas.RotateApiKeySchema
+	if err := ctx.Bind(&body); err != nil {
+		return helpers.BadRequest(ctx, "invalid request body")
+	}
+	if body.GraceHours < 0 || body.GraceHours > maxApiKeyGraceHours {
+		return helpers.BadRequest(ctx, "invalid grace period")
+	}
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionApiKeyRotate, Token: ctx.Param("id")}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.RotateApiKey(reqCtx, &auth_service.RotateApiKeyRequest{
+		Id:           ctx.Param("id"),
+		GraceSeconds: int64(body.GraceHours) * int64(time.Hour/time.Second),
+		RotatedBy:    helpers.GetUserID(ctx),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "api key not found")
+			case codes.FailedPrecondition:
+				return helpers.Conflict(ctx, "api key is not active")
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to rotate api key",
+			slog.String("key_id", ctx.Param("id")),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to rotate api key")
+	}
+
+	if body.GraceHours == 0 {
+		h.revokeClientTokens(ctx, resp.ApiKey.ServiceAccountId)
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "api key rotated",
+		slog.String("previous_key_id", resp.Previous.Id),
+		slog.String("key_id", resp.ApiKey.Id),
+		slog.String("service_account_id", resp.ApiKey.ServiceAccountId))
+
+	return ctx.JSON(http.StatusOK, &schemas.IssuedApiKeySchema{
+		ApiKey:   helpers.ProtoApiKeyToSchema(resp.ApiKey),
+		Key:      resp.Key,
+		Previous: helpers.ProtoApiKeyToSchema(resp.Previous),
+	})
+}
+
+// RevokeApiKey godoc
+// @Summary Отозвать API-ключ
+// @Description Отзывает действующий API-ключ; запросы с ним перестают приниматься сразу.
+// @Tags Service accounts
+// @Produce json
+// @Param id path string true "ID API-ключа"
+// @Success 200 {object} schemas.ApiKeySchema
+// @Failure 404 "api key not found"
+// @Failure 409 "api key is not active"
+// @Failure 500 "failed to revoke api key / failed to write audit log"
+// @Security ApiKeyAuth
+// @Router /service-accounts/keys/{id} [delete]
+func (h *ServiceAccountHandler) RevokeApiKey(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionApiKeyRevoke, Token: ctx.Param("id")}
+	defer h.auditor.Audit(ctx, audit)
+
+	if err := h.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	resp, err := h.authService.RevokeApiKey(reqCtx, &auth_service.RevokeApiKeyRequest{
+		Id:        ctx.Param("id"),
+		RevokedBy: helpers.GetUserID(ctx),
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return helpers.NotFound(ctx, "api key not found")
+			case codes.FailedPrecondition:
+				return helpers.Conflict(ctx, "api key is not active")
+			}
+		}
+
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to revoke api key",
+			slog.String("key_id", ctx.Param("id")),
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to revoke api key")
+	}
+
+	h.revokeClientTokens(ctx, resp.ApiKey.ServiceAccountId)
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "api key revoked",
+		slog.String("key_id", resp.ApiKey.Id),
+		slog.String("service_account_id", resp.ApiKey.ServiceAccountId))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoApiKeyToSchema(resp.ApiKey))
+}
+
+// GetApiKeys godoc
+// @Summary Получить API-ключи
+// @Description Возвращает API-ключи сервисного аккаунта или всех сервисных аккаунтов, начиная с новых, со временем и адресом последнего использования. Сами ключи не возвращаются.
+// @Tags Service accounts
+// @Produce json
+// @Param service_account_id query string false "ID сервисного аккаунта"
+// @Param active query bool false "Только действующие"
+// @Success 200 {array} schemas.ApiKeySchema
+// @Failure 400 "invalid service account ID / invalid active"
+// @Failure 500 "failed to get api keys"
+// @Security ApiKeyAuth
+// @Router /service-accounts/keys [get]
+func (h *ServiceAccountHandler) GetApiKeys(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	var active bool
+	if v := ctx.QueryParam("active"); v != "" {
+		var err error
+		if active, err = strconv.ParseBool(v); err != nil {
+			return helpers.BadRequest(ctx, "invalid active")
+		}
+	}
+
+	resp, err := h.authService.GetApiKeys(reqCtx, &auth_service.GetApiKeysRequest{
+		ServiceAccountId: ctx.QueryParam("service_account_id"),
+		ActiveOnly:       active,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return helpers.BadRequest(ctx, "invalid service account ID")
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get api keys",
+			logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get api keys")
+	}
+
+	keys := make([]*schemas.ApiKeySchema, 0, len(resp.ApiKeys))
+	for _, k := range resp.ApiKeys {
+		keys = append(keys, helpers.ProtoApiKeyToSchema(k))
+	}
+	return ctx.JSON(http.StatusOK, keys)
+}
@@ -0,0 +1,141 @@
+package http_handlers
+
+import (
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+)
+
+type SubjectHandler struct {
+	mappingService *services.MappingService
+	auditor        *helpers.Auditor
+	authorizer     *helpers.Authorizer
+}
+
+func NewSubjectHandler(mappingService *services.MappingService, auditor *helpers.Auditor, authorizer *helpers.Authorizer) *SubjectHandler {
+	return &SubjectHandler{mappingService: mappingService, auditor: auditor, authorizer: authorizer}
+}
+
+// GetSubjectMappings godoc
+// @Summary Токены субъекта данных
+// @Description Возвращает маппинги субъекта персональных данных, доступные по уровню допуска пользователя
+// @Tags Subjects
+// @Produce json
+// @Param ref path string true "Псевдонимный идентификатор субъекта"
+// @Success 200 {array} schemas.MappingSchema
+// @Failure 400 "invalid subject ref"
+// @Failure 500 "failed to get subject mappings"
+// @Security ApiKeyAuth
+// @Router /subjects/{ref}/mappings [get]
+func (s *SubjectHandler) GetSubjectMappings(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	subjectRef := ctx.Param("ref")
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionSubjectRead, Token: subjectRef}
+	defer s.auditor.Audit(ctx, audit)
+	if !helpers.IsValidSubjectRef(subjectRef) {
+		return helpers.BadRequest(ctx, "invalid subject ref")
+	}
+
+	resp, err := s.mappingService.ListSubjectMappings(reqCtx, &mapping.ListSubjectMappingsRequest{
+		SubjectRef:     subjectRef,
+		MaxAccessLevel: s.authorizer.MaxAccessLevel(ctx, domain.KindOperationView),
+	})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get subject mappings", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to get subject mappings")
+	}
+
+	mappings := make([]*schemas.MappingSchema, 0, len(resp.MappingModels))
+	for _, mm := range resp.MappingModels {
+		if !s.authorizer.CanAccessKind(ctx, mm.Kind, domain.KindOperationView) {
+			continue
+		}
+		mappings = append(mappings, helpers.ProtoMappingToSchema(mm, false))
+	}
+
+	if err = s.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
+
+	return ctx.JSON(http.StatusOK, mappings)
+}
+
+// EraseSubject godoc
+// @Summary Удаление данных субъекта
+// @Description Удаляет все маппинги субъекта персональных данных одной транзакцией (БД и кэш) и возвращает акт удаления.
+// @Description Каждый удалённый токен фиксируется в журнале аудита. Пользователь должен иметь допуск ко всем категориям данных субъекта.
+// @Tags Subjects
+// @Produce json
+// @Param ref path string true "Псевдонимный идентификатор субъекта"
+// @Success 200 {object} schemas.ErasureReportSchema
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
+// @Failure 400 "invalid subject ref"
+// @Failure 403 "insufficient clearance level"
+// @Failure 409 "subject has mappings under legal hold / subject mappings changed"
+// @Failure 500 "failed to erase subject"
+// @Security ApiKeyAuth
+// @Router /subjects/{ref} [delete]
+func (s *SubjectHandler) EraseSubject(ctx echo.Context) error {
+	reqCtx := ctx.Request().Context()
+
+	subjectRef := ctx.Param("ref")
+	defer s.auditor.AuditFailure(ctx, &mapping.CreateAuditLogRequest{
+		Action: domain.AuditActionErase,
+		Token:  subjectRef,
+	})
+	if !helpers.IsValidSubjectRef(subjectRef) {
+		return helpers.BadRequest(ctx, "invalid subject ref")
+	}
+
+	listResp, err := s.mappingService.ListSubjectMappings(reqCtx, &mapping.ListSubjectMappingsRequest{
+		SubjectRef: subjectRef,
+	})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to get subject mappings", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to erase subject")
+	}
+	// Only the checked mappings are erased; the mapping service rejects the erasure if
+	// the subject gained a mapping since they were listed.
+	mappingIDs := make([]string, 0, len(listResp.MappingModels))
+	for _, mm := range listResp.MappingModels {
+		if !s.authorizer.CanAccessKind(ctx, mm.Kind, domain.KindOperationDelete) {
+			return helpers.Forbidden(ctx, "insufficient clearance level")
+		}
+		mappingIDs = append(mappingIDs, mm.Id)
+	}
+
+	resp, err := s.mappingService.EraseSubject(reqCtx, &mapping.EraseSubjectRequest{
+		SubjectRef: subjectRef,
+		UserId:     helpers.GetUserID(ctx),
+		MappingIds: mappingIDs,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.FailedPrecondition:
+				logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "subject erasure blocked by legal hold")
+				return helpers.Conflict(ctx, "subject has mappings under legal hold")
+			case codes.Aborted:
+				logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "subject mappings changed during erasure")
+				return helpers.Conflict(ctx, "subject mappings changed")
+			}
+		}
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to erase subject", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to erase subject")
+	}
+
+	logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "data subject erased",
+		slog.String("userID", helpers.GetUserID(ctx)),
+		slog.Int("erased", len(resp.Report.GetErased())))
+
+	return ctx.JSON(http.StatusOK, helpers.ProtoErasureReportToSchema(resp.Report))
+}
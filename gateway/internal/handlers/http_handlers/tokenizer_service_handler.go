@@ -13,9 +13,11 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log/slog"
 	"net/http"
 	"regexp"
+	"slices"
 	"time"
 )
 
@@ -27,17 +29,82 @@ const (
 type TokenizerServiceHandler struct {
 	tokenizerService *services.TokenizerService
 	mappingService   *services.MappingService
+	auditor          *helpers.Auditor
+	authorizer       *helpers.Authorizer
+	// anomalyDetector counts detokenizations; nil disables detection.
+	anomalyDetector *services.AnomalyDetector
+	// rateLimiter enforces the daily detokenization quotas; nil disables them.
+	rateLimiter *services.RateLimiter
+	// approvalGate holds detokenizations that must be approved; nil disables approvals.
+	approvalGate *helpers.ApprovalGate
 }
 
 func NewTokenizerServiceHandler(
 	tokenizerService *services.TokenizerService,
-	mappingService *services.MappingService) *TokenizerServiceHandler {
+	mappingService *services.MappingService,
+	auditor *helpers.Auditor,
+	authorizer *helpers.Authorizer,
+	anomalyDetector *services.AnomalyDetector,
+	rateLimiter *services.RateLimiter,
+	approvalGate *helpers.ApprovalGate) *TokenizerServiceHandler {
 	return &TokenizerServiceHandler{
 		tokenizerService: tokenizerService,
 		mappingService:   mappingService,
+		auditor:          auditor,
+		authorizer:       authorizer,
+		anomalyDetector:  anomalyDetector,
+		rateLimiter:      rateLimiter,
+		approvalGate:     approvalGate,
 	}
 }
 
+// validateProcessingGrounds checks the purpose limitation and legal basis fields of a
+// tokenize request and returns the error message for the first violation, if any.
+func validateProcessingGrounds(s *schemas.TokenizeSchema, pseudonymize bool) string {
+	if len(s.Purposes) == 0 && s.LegalBasis == "" && s.ConsentRef == "" && s.ConsentExpiresAt == nil {
+		return ""
+	}
+	if !pseudonymize {
+		return "purposes and legal basis require pseudonymize mode"
+	}
+
+	for _, purpose := range s.Purposes {
+		if !helpers.IsValidPurposeName(purpose) {
+			return "invalid purpose"
+		}
+	}
+	if s.LegalBasis != "" && !helpers.IsValidLegalBasis(s.LegalBasis) {
+		return "invalid legal_basis"
+	}
+	if s.LegalBasis == "consent" && s.ConsentRef == "" {
+		return "consent_ref is required for consent legal basis"
+	}
+	if (s.ConsentRef != "" || s.ConsentExpiresAt != nil) && s.LegalBasis != "consent" {
+		return "consent fields require consent legal basis"
+	}
+	if len(s.ConsentRef) > 100 {
+		return "invalid consent_ref"
+	}
+	if s.ConsentExpiresAt != nil && !s.ConsentExpiresAt.After(time.Now()) {
+		return "consent_expires_at must be in the future"
+	}
+
+	return ""
+}
+
+// checkDetokenizeGrounds checks that the mapping may be detokenized for the purpose at
+// now and returns the error message for the violation, if any. Mappings without
+// purposes predate purpose limitation and may be detokenized for any purpose.
+func checkDetokenizeGrounds(mm *mapping.MappingModel, purpose string, now time.Time) string {
+	if len(mm.Purposes) > 0 && !slices.Contains(mm.Purposes, purpose) {
+		return "purpose not allowed"
+	}
+	if mm.ConsentExpiresAt != nil && !mm.ConsentExpiresAt.AsTime().After(now) {
+		return "consent expired"
+	}
+	return ""
+}
+
 // Tokenize godoc
 // @Summary Токенизация
 // @Description Принимает plaintext и параметры токенизации, возвращает созданный токен и метаданные.
@@ -50,14 +117,18 @@ func NewTokenizerServiceHandler(
 // @Param body body schemas.TokenizeSchema true "Данные для токенизации"
 // @Success 200 {object} schemas.MappingSchema "mode=pseudonymize"
 // @Success 200 {object} schemas.TokenizeResultSchema "mode=anonymize"
-// @Failure 400 "invalid request body / invalid arguments"
+// @Failure 400 "invalid request body / invalid arguments / invalid subject_ref / unknown purpose / invalid legal_basis"
 // @Failure 409 "token already exists"
-// @Failure 500 "failed to tokenize / unexpected error"
+// @Failure 429 "rate limit exceeded"
+// @Failure 500 "failed to tokenize / unexpected error / failed to write audit log"
 // @Security ApiKeyAuth
 // @Router /tokenize [post]
 func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionTokenize}
+	defer t.auditor.Audit(ctx, audit)
+
 	var tokenizeSchema *schemas.TokenizeSchema
 	if err := ctx.Bind(&tokenizeSchema); err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to bind tokenize schema",
@@ -69,6 +140,9 @@ func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 	if !pseudonymize && tokenizeSchema.Mode != modeAnonymize {
 		return helpers.BadRequest(ctx, "invalid mode")
 	}
+	if !pseudonymize {
+		audit.Action = domain.AuditActionAnonymize
+	}
 
 	switch tokenizeSchema.Algorithm {
 	case "", "aes-siv", "gost-kuznechik":
@@ -76,6 +150,19 @@ func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 		return helpers.BadRequest(ctx, "invalid algorithm")
 	}
 
+	if tokenizeSchema.SubjectRef != "" {
+		if !pseudonymize {
+			return helpers.BadRequest(ctx, "subject_ref requires pseudonymize mode")
+		}
+		if !helpers.IsValidSubjectRef(tokenizeSchema.SubjectRef) {
+			return helpers.BadRequest(ctx, "invalid subject_ref")
+		}
+	}
+
+	if msg := validateProcessingGrounds(tokenizeSchema, pseudonymize); msg != "" {
+		return helpers.BadRequest(ctx, msg)
+	}
+
 	var kind *mapping.Kind
 	if tokenizeSchema.KindId > 0 {
 		kindResp, err := t.mappingService.GetKind(reqCtx, &mapping.GetKindRequest{Id: int32(tokenizeSchema.KindId)})
@@ -88,8 +175,9 @@ func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 			return helpers.InternalServerError(ctx, "failed to tokenize")
 		}
 		kind = kindResp.Kind
+		audit.KindId = kind.Id
 
-		if !helpers.HasRole(ctx, domain.RoleAdmin) && helpers.GetClearanceLevel(ctx) < int(kind.AccessLevel) {
+		if !t.authorizer.CanAccessKind(ctx, kind, domain.KindOperationTokenize) {
 			return helpers.Forbidden(ctx, "insufficient clearance level")
 		}
 
@@ -129,6 +217,11 @@ func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 	if kind != nil && kind.ShortName != "" {
 		token = kind.ShortName + "_" + token
 	}
+	audit.Token = token
+
+	if err = t.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
+	}
 
 	if !pseudonymize {
 		return ctx.JSON(http.StatusOK, &schemas.TokenizeResultSchema{Token: token})
@@ -141,6 +234,13 @@ func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 		Deterministic: tokenizeResp.Deterministic,
 		TokenTtl:      durationpb.New(time.Duration(tokenizeSchema.TokenTTL) * time.Second),
 		AlgoName:      tokenizeResp.AlgoName,
+		SubjectRef:    tokenizeSchema.SubjectRef,
+		Purposes:      tokenizeSchema.Purposes,
+		LegalBasis:    tokenizeSchema.LegalBasis,
+		ConsentRef:    tokenizeSchema.ConsentRef,
+	}
+	if tokenizeSchema.ConsentExpiresAt != nil {
+		mappingReq.ConsentExpiresAt = timestamppb.New(*tokenizeSchema.ConsentExpiresAt)
 	}
 	if kind != nil {
 		mappingReq.Kind = &mapping.Kind{Id: kind.Id}
@@ -155,7 +255,7 @@ func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 				return helpers.Conflict(ctx, "token already exists")
 			case codes.InvalidArgument:
 				logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "invalid arguments", logger.Err(err))
-				return helpers.BadRequest(ctx, "invalid arguments")
+				return helpers.BadRequest(ctx, st.Message())
 			default:
 				logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to create mapping",
 					logger.Err(err))
@@ -166,38 +266,59 @@ func (t *TokenizerServiceHandler) Tokenize(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
-	var kindID int32
-	if kind != nil {
-		kindID = kind.Id
+	return ctx.JSON(http.StatusOK, helpers.ProtoMappingToSchema(resp.MappingModel, false))
+}
+
+// checkPurpose writes the rejection of a detokenization purpose that is not in the
+// purpose catalog.
+func (t *TokenizerServiceHandler) checkPurpose(ctx echo.Context, purpose string) error {
+	reqCtx := ctx.Request().Context()
+
+	if !helpers.IsValidPurposeName(purpose) {
+		return helpers.BadRequest(ctx, "unknown purpose")
 	}
-	if _, auditErr := t.mappingService.CreateAuditLog(reqCtx, &mapping.CreateAuditLogRequest{
-		UserId: helpers.GetUserID(ctx),
-		Action: "tokenize",
-		Token:  token,
-		KindId: kindID,
-	}); auditErr != nil {
-		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to write audit log", logger.Err(auditErr))
+	resp, err := t.mappingService.ListPurposes(reqCtx, &mapping.ListPurposesRequest{})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Error(reqCtx, "failed to get purpose list", logger.Err(err))
+		return helpers.InternalServerError(ctx, "failed to detokenize")
 	}
-
-	return ctx.JSON(http.StatusOK, helpers.ProtoMappingToSchema(resp.MappingModel))
+	if !slices.ContainsFunc(resp.Purposes, func(p *mapping.Purpose) bool { return p.Name == purpose }) {
+		logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "unknown detokenize purpose",
+			slog.String("purpose", purpose))
+		return helpers.BadRequest(ctx, "unknown purpose")
+	}
+	return nil
 }
 
 // Detokenize godoc
 // @Summary Детокенизация
-// @Description Принимает токен, ищет соответствующий mapping и возвращает исходный plaintext
+// @Description Принимает токен и цель обработки, ищет соответствующий mapping и возвращает исходный plaintext.
+// @Description Если токену назначены цели обработки, указанная цель должна входить в их число;
+// @Description токен, выданный по согласию с истёкшим сроком, не детокенизируется.
+// @Description Plaintext возвращается только после записи в журнал аудита; если действие detokenize
+// @Description работает по политике fail_closed и запись не удалась, plaintext не возвращается.
+// @Description Детокенизация данных с уровнем доступа не ниже APPROVAL_DETOKENIZE_MIN_LEVEL требует согласования:
+// @Description возвращается 202 с запросом на согласование, выполнить который после одобрения можно через `POST /approvals/{id}/execute`.
 // @Tags Tokenizer
 // @Accept json
 // @Produce json
 // @Param body body schemas.DetokenizeSchema true "Токен для детокенизации"
 // @Success 200 {object} schemas.DetokenizeRespSchema
-// @Failure 400 "invalid request body / invalid arguments"
+// @Success 202 {object} schemas.ApprovalSchema "операция ожидает согласования"
+// @Failure 400 "invalid request body / invalid arguments / purpose is required / unknown purpose"
+// @Failure 403 "insufficient clearance level / purpose not allowed / consent expired"
 // @Failure 404 "token not found / token expired"
-// @Failure 500 "failed to detokenize / unexpected error"
+// @Failure 429 "rate limit exceeded / daily quota exceeded"
+// @Failure 503 "anomaly check unavailable / quota check unavailable"
+// @Failure 500 "failed to detokenize / unexpected error / failed to write audit log"
 // @Security ApiKeyAuth
 // @Router /detokenize [post]
 func (t *TokenizerServiceHandler) Detokenize(ctx echo.Context) error {
 	reqCtx := ctx.Request().Context()
 
+	audit := &mapping.CreateAuditLogRequest{Action: domain.AuditActionDetokenize}
+	defer t.auditor.Audit(ctx, audit)
+
 	var detokenizeSchema *schemas.DetokenizeSchema
 	if err := ctx.Bind(&detokenizeSchema); err != nil {
 		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx,
@@ -205,6 +326,14 @@ func (t *TokenizerServiceHandler) Detokenize(ctx echo.Context) error {
 			logger.Err(err))
 		return helpers.InternalServerError(ctx, "failed to detokenize")
 	}
+	audit.Token = detokenizeSchema.Token
+	audit.Purpose = detokenizeSchema.Purpose
+	if detokenizeSchema.Purpose == "" {
+		return helpers.BadRequest(ctx, "purpose is required")
+	}
+	if err := t.checkPurpose(ctx, detokenizeSchema.Purpose); err != nil {
+		return err
+	}
 
 	getMappingReq := &mapping.GetMappingByTokenRequest{Token: detokenizeSchema.Token}
 	getMappingResp, err := t.mappingService.GetMappingByToken(reqCtx, getMappingReq)
@@ -234,8 +363,65 @@ func (t *TokenizerServiceHandler) Detokenize(ctx echo.Context) error {
 	}
 
 	if kind := getMappingResp.MappingModel.Kind; kind != nil {
-		if !helpers.HasRole(ctx, domain.RoleAdmin) && helpers.GetClearanceLevel(ctx) < int(kind.AccessLevel) {
-			return helpers.Forbidden(ctx, "insufficient clearance level")
+		audit.KindId = kind.Id
+	}
+	if !t.authorizer.CanAccessKind(ctx, getMappingResp.MappingModel.Kind, domain.KindOperationDetokenize) {
+		return helpers.Forbidden(ctx, "insufficient clearance level")
+	}
+
+	mm := getMappingResp.MappingModel
+	if msg := checkDetokenizeGrounds(mm, detokenizeSchema.Purpose, time.Now()); msg != "" {
+		logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "detokenize not allowed on processing grounds",
+			slog.String("purpose", detokenizeSchema.Purpose),
+			slog.String("reason", msg))
+		return helpers.Forbidden(ctx, msg)
+	}
+
+	var kindName string
+	var accessLevel int32
+	if kind := mm.Kind; kind != nil {
+		kindName, accessLevel = kind.Name, kind.AccessLevel
+	}
+
+	// The data is only decrypted once the request may proceed: a held request is
+	// decrypted when it is executed after approval.
+	if t.approvalGate != nil && t.approvalGate.Requires(ctx, domain.AuditActionDetokenize, accessLevel) {
+		return t.approvalGate.Hold(ctx, domain.AuditActionDetokenize, detokenizeSchema.Token, audit.KindId)
+	}
+
+	if t.rateLimiter != nil {
+		allowed, retryAfter, err := t.rateLimiter.ConsumeDetokenizeQuota(reqCtx, helpers.GetUserID(ctx), accessLevel)
+		if err != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to check detokenize quota", logger.Err(err))
+			if !t.rateLimiter.FailOpen() {
+				return helpers.ServiceUnavailable(ctx, "quota check unavailable")
+			}
+		} else if !allowed {
+			logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "daily detokenize quota exceeded",
+				slog.Int("access_level", int(accessLevel)))
+			return helpers.TooManyRequests(ctx, retryAfter, "daily quota exceeded")
+		}
+	}
+
+	if t.anomalyDetector != nil {
+		block, err := t.anomalyDetector.Observe(reqCtx, helpers.GetUserID(ctx), kindName, accessLevel)
+		if err != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to count detokenization", logger.Err(err))
+			if !t.anomalyDetector.FailOpen() {
+				return helpers.ServiceUnavailable(ctx, "anomaly check unavailable")
+			}
+		} else if block != nil {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "mass detokenization detected",
+				slog.String("dimension", block.Dimension),
+				slog.Int64("count", block.Count),
+				slog.String("action", block.Action))
+			t.auditor.Deny(ctx, &mapping.CreateAuditLogRequest{
+				Action: domain.AuditActionAnomalyBlock,
+				Token:  block.Dimension,
+				KindId: audit.KindId,
+				Reason: helpers.AnomalyBlockReason(block),
+			})
+			return helpers.RejectAnomalyBlock(ctx, block)
 		}
 	}
 
@@ -265,17 +451,8 @@ func (t *TokenizerServiceHandler) Detokenize(ctx echo.Context) error {
 		return helpers.InternalServerError(ctx, "unexpected error")
 	}
 
-	var kindID int32
-	if kind := getMappingResp.MappingModel.Kind; kind != nil {
-		kindID = kind.Id
-	}
-	if _, auditErr := t.mappingService.CreateAuditLog(reqCtx, &mapping.CreateAuditLogRequest{
-		UserId: helpers.GetUserID(ctx),
-		Action: "detokenize",
-		Token:  detokenizeSchema.Token,
-		KindId: kindID,
-	}); auditErr != nil {
-		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to write audit log", logger.Err(auditErr))
+	if err = t.auditor.Record(ctx, audit); err != nil {
+		return helpers.InternalServerError(ctx, "failed to write audit log")
 	}
 
 	return ctx.JSON(http.StatusOK, &schemas.DetokenizeRespSchema{Plaintext: detokenizeResp.Plaintext})
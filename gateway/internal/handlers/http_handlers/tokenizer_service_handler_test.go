@@ -0,0 +1,85 @@
+package http_handlers
+
+import (
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/gateway/internal/schemas"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateProcessingGrounds(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		schema       schemas.TokenizeSchema
+		pseudonymize bool
+		want         string
+	}{
+		{"no grounds", schemas.TokenizeSchema{}, false, ""},
+		{"purposes", schemas.TokenizeSchema{Purposes: []string{"billing", "support_2"}}, true, ""},
+		{"consent", schemas.TokenizeSchema{
+			Purposes: []string{"billing"}, LegalBasis: "consent", ConsentRef: "consent-1", ConsentExpiresAt: &future,
+		}, true, ""},
+		{"contract", schemas.TokenizeSchema{LegalBasis: "contract"}, true, ""},
+		{"anonymize", schemas.TokenizeSchema{Purposes: []string{"billing"}}, false,
+			"purposes and legal basis require pseudonymize mode"},
+		{"invalid purpose", schemas.TokenizeSchema{Purposes: []string{"Billing"}}, true, "invalid purpose"},
+		{"unknown legal basis", schemas.TokenizeSchema{LegalBasis: "curiosity"}, true, "invalid legal_basis"},
+		{"consent without reference", schemas.TokenizeSchema{LegalBasis: "consent"}, true,
+			"consent_ref is required for consent legal basis"},
+		{"consent reference without consent", schemas.TokenizeSchema{LegalBasis: "contract", ConsentRef: "consent-1"}, true,
+			"consent fields require consent legal basis"},
+		{"consent expiry without consent", schemas.TokenizeSchema{ConsentExpiresAt: &future}, true,
+			"consent fields require consent legal basis"},
+		{"long consent reference", schemas.TokenizeSchema{LegalBasis: "consent", ConsentRef: strings.Repeat("c", 101)}, true,
+			"invalid consent_ref"},
+		{"expired consent", schemas.TokenizeSchema{LegalBasis: "consent", ConsentRef: "consent-1", ConsentExpiresAt: &past}, true,
+			"consent_expires_at must be in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateProcessingGrounds(&tt.schema, tt.pseudonymize); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDetokenizeGrounds(t *testing.T) {
+	now := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mapping *mapping.MappingModel
+		purpose string
+		want    string
+	}{
+		{"without purposes", &mapping.MappingModel{}, "marketing", ""},
+		{"allowed purpose", &mapping.MappingModel{Purposes: []string{"billing", "support"}}, "support", ""},
+		{"other purpose", &mapping.MappingModel{Purposes: []string{"billing"}}, "marketing", "purpose not allowed"},
+		{"consent in force", &mapping.MappingModel{
+			Purposes: []string{"billing"}, ConsentExpiresAt: timestamppb.New(now.Add(time.Second)),
+		}, "billing", ""},
+		{"consent expiring now", &mapping.MappingModel{ConsentExpiresAt: timestamppb.New(now)}, "billing", "consent expired"},
+		{"consent expired", &mapping.MappingModel{ConsentExpiresAt: timestamppb.New(now.Add(-time.Hour))}, "billing",
+			"consent expired"},
+		// The purpose is checked first: a request for another purpose learns nothing
+		// about the consent.
+		{"other purpose and expired consent", &mapping.MappingModel{
+			Purposes: []string{"billing"}, ConsentExpiresAt: timestamppb.New(now.Add(-time.Hour)),
+		}, "marketing", "purpose not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkDetokenizeGrounds(tt.mapping, tt.purpose, now); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/labstack/echo/v4"
+)
+
+type ApprovalMiddleware struct {
+	// approvalGate is nil when approvals are disabled.
+	approvalGate *helpers.ApprovalGate
+}
+
+func NewApprovalMiddleware(approvalGate *helpers.ApprovalGate) *ApprovalMiddleware {
+	return &ApprovalMiddleware{approvalGate: approvalGate}
+}
+
+// Require holds requests of the operation that must be approved and registers handler,
+// the handler of the route, as the executor of the operation. Echo applies route
+// middlewares on every request, so the executor is registered when the route is, not by
+// the middleware. It must be the last middleware of the route, after CheckAuth.
+// Handlers that know the access level of the data only after reading it hold the
+// request themselves.
+func (a *ApprovalMiddleware) Require(operation string, handler echo.HandlerFunc) echo.MiddlewareFunc {
+	if a.approvalGate == nil {
+		return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	}
+	a.approvalGate.Register(operation, handler)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			reqCtx := c.Request().Context()
+			if err := helpers.BufferBody(c); err != nil {
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to read request body", logger.Err(err))
+				return helpers.BadRequest(c, "invalid request body")
+			}
+
+			if a.approvalGate.Requires(c, operation, 0) {
+				var target string
+				if values := c.ParamValues(); len(values) > 0 {
+					target = values[0]
+				}
+				return a.approvalGate.Hold(c, operation, target, 0)
+			}
+			return next(c)
+		}
+	}
+}
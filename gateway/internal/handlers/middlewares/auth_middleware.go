@@ -6,10 +6,17 @@ import (
 	errs "github.com/NeF2le/anonix/common/errors"
 	"github.com/NeF2le/anonix/common/gen/auth_service"
 	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
 	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
 	"github.com/NeF2le/anonix/gateway/internal/services"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -19,24 +26,44 @@ type AuthMiddleware struct {
 	authService     *services.AuthService
 	accessTokenTTL  int
 	refreshTokenTTL int
+	// anomalyDetector rejects users it has restricted; nil disables the check.
+	anomalyDetector *services.AnomalyDetector
+	// breakGlass confirms that the break-glass session of a token has not been ended;
+	// nil disables break-glass access.
+	breakGlass *services.BreakGlassService
+	// tokenRevocation reports access tokens issued before the access of their user was
+	// taken away; nil trusts tokens until they expire.
+	tokenRevocation *services.TokenRevocation
 }
 
 func NewAuthMiddleware(
 	jwtSecret string,
 	authService *services.AuthService,
 	accessTokenTTL,
-	refreshTokenTTL int) *AuthMiddleware {
+	refreshTokenTTL int,
+	anomalyDetector *services.AnomalyDetector,
+	breakGlass *services.BreakGlassService,
+	tokenRevocation *services.TokenRevocation) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtSecret:       jwtSecret,
 		authService:     authService,
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
+		anomalyDetector: anomalyDetector,
+		breakGlass:      breakGlass,
+		tokenRevocation: tokenRevocation,
 	}
 }
 
+// apiKeyScheme is the authorization scheme service accounts present their API keys with.
+const apiKeyScheme = "ApiKey "
+
 func (a *AuthMiddleware) CheckAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		reqCtx := c.Request().Context()
+		if auth := c.Request().Header.Get("Authorization"); strings.HasPrefix(auth, apiKeyScheme) {
+			return a.checkApiKey(c, strings.TrimSpace(strings.TrimPrefix(auth, apiKeyScheme)), next)
+		}
 		accessToken := a.getAccessTokenFromRequest(c)
 
 		sub, finalAccess, roles, clearanceLevel, err := a.ensureValidAccessToken(c, accessToken)
@@ -53,17 +80,202 @@ func (a *AuthMiddleware) CheckAuth(next echo.HandlerFunc) echo.HandlerFunc {
 			}
 		}
 
+		claims := a.parseClaims(finalAccess, a.jwtSecret)
+		account := serviceAccountFromClaims(sub, claims)
+		if account != nil && !addressAllowed(claims, c.RealIP()) {
+			logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "client token used from a disallowed address",
+				slog.String("service_account_id", sub))
+			return helpers.Unauthorized(c)
+		}
+		revoked, err := a.isRevoked(c, sub, claims)
+		if err != nil {
+			return helpers.ServiceUnavailable(c, "token revocation check unavailable")
+		}
+		if revoked {
+			if account != nil {
+				// A client token has no refresh token: the client asks for a new one.
+				return helpers.Unauthorized(c)
+			}
+			newAccess, err := a.refreshAndSetCookies(c)
+			if err != nil {
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to refresh revoked access token",
+					logger.Err(err))
+				return helpers.Unauthorized(c)
+			}
+			var isRefresh bool
+			sub, isRefresh, _, roles, clearanceLevel, err = a.parseJwt(newAccess, a.jwtSecret)
+			if err != nil || isRefresh {
+				return helpers.Unauthorized(c)
+			}
+			finalAccess = newAccess
+			claims = a.parseClaims(finalAccess, a.jwtSecret)
+		}
+
 		if finalAccess != "" {
 			a.setAuthHeader(c, finalAccess)
 		}
-		c.Set("userID", sub)
-		c.Set("roles", roles)
-		c.Set("clearanceLevel", clearanceLevel)
+		setIdentity(c, sub, roles, clearanceLevel, claims)
+		if account != nil {
+			c.Set("serviceAccount", account)
+		}
+		if session := a.getBreakGlass(c, sub, claims); session != nil {
+			c.Set("breakGlass", session)
+		}
+
+		if block, err := a.getAnomalyBlock(c, sub); err != nil {
+			return helpers.ServiceUnavailable(c, "anomaly check unavailable")
+		} else if block != nil {
+			return helpers.RejectAnomalyBlock(c, block)
+		}
 
 		return next(c)
 	}
 }
 
+// AuthenticateAs authenticates the user on c with an access token issued for their
+// current roles, clearance level and grants, as CheckAuth does for their own requests.
+// It returns helpers.ErrRequesterDenied if the user no longer exists or is restricted by
+// the anomaly detector. Service accounts cannot be authenticated this way.
+func (a *AuthMiddleware) AuthenticateAs(c echo.Context, userID string) error {
+	reqCtx := c.Request().Context()
+
+	resp, err := a.authService.IssueAccessToken(reqCtx, &auth_service.IssueAccessTokenRequest{UserId: userID})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.InvalidArgument:
+			return fmt.Errorf("%w: %w", helpers.ErrRequesterDenied, err)
+		default:
+			return err
+		}
+	}
+
+	sub, isRefresh, _, roles, clearanceLevel, err := a.parseJwt(resp.AccessToken, a.jwtSecret)
+	if err != nil {
+		return err
+	}
+	if isRefresh || sub != userID {
+		return errs.ErrInvalidAccessToken
+	}
+	setIdentity(c, sub, roles, clearanceLevel, a.parseClaims(resp.AccessToken, a.jwtSecret))
+
+	block, err := a.getAnomalyBlock(c, sub)
+	if err != nil {
+		return err
+	}
+	if block != nil {
+		return fmt.Errorf("%w: blocked by the anomaly detector", helpers.ErrRequesterDenied)
+	}
+	return nil
+}
+
+// setIdentity sets the identity of the user of the access token on c.
+func setIdentity(c echo.Context, sub string, roles []*auth_service.Role, clearanceLevel int, claims jwt.MapClaims) {
+	c.Set("userID", sub)
+	c.Set("roles", roles)
+	c.Set("clearanceLevel", clearanceLevel)
+	c.Set("permissions", permissionsFromClaims(claims))
+	c.Set("kindGrants", kindGrantsFromClaims(claims))
+	c.Set("kindOperations", kindOperationsFromClaims(claims))
+}
+
+// checkApiKey authenticates a service account by its API key. The caller gets the
+// permissions of the key and access to its kinds only: a service account has no roles
+// and no clearance level. Requests are audited under the ID of the service account.
+func (a *AuthMiddleware) checkApiKey(c echo.Context, key string, next echo.HandlerFunc) error {
+	reqCtx := c.Request().Context()
+
+	resp, err := a.authService.AuthenticateApiKey(reqCtx, &auth_service.AuthenticateApiKeyRequest{
+		Key: key,
+		Ip:  c.RealIP(),
+	})
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "api key rejected",
+			logger.Err(err))
+		return helpers.Unauthorized(c)
+	}
+
+	account := resp.ServiceAccount
+	c.Set("userID", account.Id)
+	c.Set("roles", []*auth_service.Role{})
+	c.Set("clearanceLevel", 0)
+	c.Set("permissions", resp.ApiKey.Permissions)
+	c.Set("kindGrants", resp.ApiKey.KindIds)
+	c.Set("serviceAccount", account)
+
+	if block, err := a.getAnomalyBlock(c, account.Id); err != nil {
+		return helpers.ServiceUnavailable(c, "anomaly check unavailable")
+	} else if block != nil {
+		return helpers.RejectAnomalyBlock(c, block)
+	}
+
+	return next(c)
+}
+
+// getAnomalyBlock returns the restriction the anomaly detector has put on the caller, or
+// nil. If it cannot be checked, the error is returned unless the detector fails open.
+func (a *AuthMiddleware) getAnomalyBlock(c echo.Context, sub string) (*domain.AnomalyBlock, error) {
+	if a.anomalyDetector == nil {
+		return nil, nil
+	}
+
+	reqCtx := c.Request().Context()
+	block, err := a.anomalyDetector.GetBlock(reqCtx, sub)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to check anomaly block",
+			logger.Err(err))
+		if a.anomalyDetector.FailOpen() {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return block, nil
+}
+
+// getBreakGlass returns the break-glass session the access token was issued for if it
+// is still active. If the session cannot be checked, the caller gets no break-glass
+// access.
+func (a *AuthMiddleware) getBreakGlass(c echo.Context, sub string, claims jwt.MapClaims) *domain.BreakGlassSession {
+	if a.breakGlass == nil {
+		return nil
+	}
+	id, _ := claims["break_glass"].(string)
+	if id == "" {
+		return nil
+	}
+
+	reqCtx := c.Request().Context()
+	session, err := a.breakGlass.Active(reqCtx, id, sub)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to check break-glass session",
+			logger.Err(err))
+		return nil
+	}
+	return session
+}
+
+// isRevoked reports whether the access token was issued before the access of the user
+// was taken away; a token without an issue time counts as revoked. It fails if the
+// revocations cannot be read, and the request is rejected rather than answered with a
+// refresh that could not be checked either.
+func (a *AuthMiddleware) isRevoked(c echo.Context, sub string, claims jwt.MapClaims) (bool, error) {
+	if a.tokenRevocation == nil {
+		return false, nil
+	}
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return true, nil
+	}
+
+	reqCtx := c.Request().Context()
+	revoked, err := a.tokenRevocation.IsRevoked(reqCtx, sub, issuedAt.Time)
+	if err != nil {
+		logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to check token revocation",
+			logger.Err(err))
+		return false, err
+	}
+	return revoked, nil
+}
+
 func (a *AuthMiddleware) setAuthHeader(c echo.Context, token string) {
 	c.Request().Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 }
@@ -195,3 +407,105 @@ func (a *AuthMiddleware) parseJwt(rawToken, jwtSecret string) (string, bool, tim
 
 	return sub, isRefresh, time.Unix(exp.Unix(), 0), roles, clearanceLevel, nil
 }
+
+// parseClaims returns the claims of the valid token, or empty claims.
+func (a *AuthMiddleware) parseClaims(rawToken, jwtSecret string) jwt.MapClaims {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return []byte(jwtSecret), nil
+	})
+	if err != nil {
+		return jwt.MapClaims{}
+	}
+	return claims
+}
+
+// permissionsFromClaims returns the permissions of the roles of the user of the token.
+func permissionsFromClaims(claims jwt.MapClaims) []string {
+	raw, ok := claims["permissions"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	permissions := make([]string, 0, len(raw))
+	for _, p := range raw {
+		if name, ok := p.(string); ok {
+			permissions = append(permissions, name)
+		}
+	}
+	return permissions
+}
+
+// kindGrantsFromClaims returns the IDs of the kinds granted to the user of the token
+// beyond their clearance level.
+func kindGrantsFromClaims(claims jwt.MapClaims) []int32 {
+	raw, ok := claims["kind_grants"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	kindIDs := make([]int32, 0, len(raw))
+	for _, r := range raw {
+		if id, ok := r.(float64); ok {
+			kindIDs = append(kindIDs, int32(id))
+		}
+	}
+	return kindIDs
+}
+
+// serviceAccountFromClaims returns the service account a client token was issued to by
+// the client credentials grant, or nil for the token of a user.
+func serviceAccountFromClaims(sub string, claims jwt.MapClaims) *auth_service.ServiceAccount {
+	name, _ := claims["service_account"].(string)
+	if name == "" {
+		return nil
+	}
+	return &auth_service.ServiceAccount{Id: sub, Name: name}
+}
+
+// addressAllowed reports whether a client token may be used from the address: the
+// allowed_ips claim lists the CIDR ranges of its API key, and a token without it may be
+// used from anywhere.
+func addressAllowed(claims jwt.MapClaims, ip string) bool {
+	raw, ok := claims["allowed_ips"].([]interface{})
+	if !ok {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return slices.ContainsFunc(raw, func(r interface{}) bool {
+		allowed, _ := r.(string)
+		prefix, err := netip.ParsePrefix(allowed)
+		return err == nil && prefix.Contains(addr)
+	})
+}
+
+// kindOperationsFromClaims returns the operations allowed to the user of the token on
+// data of each kind by kind operation grants, keyed by kind ID.
+func kindOperationsFromClaims(claims jwt.MapClaims) map[int32][]string {
+	raw, ok := claims["kind_operations"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	operations := make(map[int32][]string, len(raw))
+	for key, value := range raw {
+		kindID, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			continue
+		}
+		ops, _ := value.([]interface{})
+		for _, op := range ops {
+			if name, ok := op.(string); ok {
+				operations[int32(kindID)] = append(operations[int32(kindID)], name)
+			}
+		}
+	}
+	return operations
+}
@@ -1,14 +1,28 @@
 package middlewares
 
 import (
+	"context"
 	"github.com/NeF2le/anonix/common/logger"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"log/slog"
+	"regexp"
 )
 
+// requestIDPattern limits the request IDs accepted from clients, as they end up in logs
+// and audit entries.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,100}$`)
+
 func LoggingMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
 		ctx := logger.New(c.Request().Context())
+		ctx = context.WithValue(ctx, logger.KeyForRequestID, requestID)
 
 		c.SetRequest(c.Request().WithContext(ctx))
 
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"fmt"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/labstack/echo/v4"
+)
+
+type PolicyMiddleware struct {
+	authorizer *helpers.Authorizer
+	auditor    *helpers.Auditor
+}
+
+func NewPolicyMiddleware(authorizer *helpers.Authorizer, auditor *helpers.Auditor) *PolicyMiddleware {
+	return &PolicyMiddleware{authorizer: authorizer, auditor: auditor}
+}
+
+// Authorize rejects requests whose action the access policy does not allow the caller
+// with 403. It must run after CheckAuth.
+func (p *PolicyMiddleware) Authorize(action string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			decision := p.authorizer.Decide(c, action, policy.Resource{})
+			if decision.Allowed {
+				helpers.AddPolicyAction(c, action)
+				return next(c)
+			}
+
+			p.auditDenial(c, decision.Reason(action))
+			return echo.ErrForbidden
+		}
+	}
+}
+
+// auditDenial records the rejected request with the route and the reason of the policy.
+func (p *PolicyMiddleware) auditDenial(c echo.Context, reason string) {
+	p.auditor.Deny(c, &mapping.CreateAuditLogRequest{
+		Action: domain.AuditActionAccess,
+		Reason: fmt.Sprintf("%s %s: %s", c.Request().Method, c.Path(), reason),
+	})
+}
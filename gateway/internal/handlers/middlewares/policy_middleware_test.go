@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"github.com/NeF2le/anonix/common/gen/auth_service"
+	"github.com/NeF2le/anonix/common/gen/mapping"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/services/policy"
+	"github.com/NeF2le/anonix/gateway/policies"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+type fakeAuditWriter struct {
+	entries []*mapping.CreateAuditLogRequest
+}
+
+func (w *fakeAuditWriter) CreateAuditLog(_ context.Context, req *mapping.CreateAuditLogRequest) (*mapping.CreateAuditLogResponse, error) {
+	w.entries = append(w.entries, req)
+	return &mapping.CreateAuditLogResponse{}, nil
+}
+
+func TestPolicyMiddleware_Authorize(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		wantAllowed bool
+		wantReason  string
+	}{
+		{"allowed", []string{domain.PolicyActionAuditRead}, true, ""},
+		{"denied", []string{domain.PolicyActionMappingRead}, false, "GET /audit/: no rule allows audit.read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := policy.NewEngine(policies.Default, "", domain.PolicyActions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writer := &fakeAuditWriter{}
+			auditor, err := helpers.NewAuditor(writer, string(domain.AuditFailClosed), nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := NewPolicyMiddleware(helpers.NewAuthorizer(engine), auditor)
+
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/audit/", nil), httptest.NewRecorder())
+			c.SetPath("/audit/")
+			c.Set("userID", "user")
+			c.Set("roles", []*auth_service.Role{})
+			c.Set("permissions", tt.permissions)
+
+			var called bool
+			err = p.Authorize(domain.PolicyActionAuditRead)(func(echo.Context) error {
+				called = true
+				return nil
+			})(c)
+
+			if called != tt.wantAllowed {
+				t.Fatalf("handler called: %v, want %v", called, tt.wantAllowed)
+			}
+			if tt.wantAllowed {
+				if err != nil || len(writer.entries) != 0 {
+					t.Errorf("got %v and audit entries %v", err, writer.entries)
+				}
+				if !slices.Equal(helpers.GetPolicyActions(c), []string{domain.PolicyActionAuditRead}) {
+					t.Errorf("policy actions %v not kept for approval replays", helpers.GetPolicyActions(c))
+				}
+				return
+			}
+
+			if !errors.Is(err, echo.ErrForbidden) {
+				t.Errorf("got %v, want 403", err)
+			}
+			if len(writer.entries) != 1 {
+				t.Fatalf("got %d audit entries", len(writer.entries))
+			}
+			if got := writer.entries[0]; got.Action != domain.AuditActionAccess || got.Outcome != domain.AuditOutcomeDenied ||
+				got.Reason != tt.wantReason || got.UserId != "user" {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/gateway/internal/handlers/helpers"
+	"github.com/NeF2le/anonix/gateway/internal/services"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+)
+
+type RateLimitMiddleware struct {
+	// rateLimiter is nil when rate limiting is disabled.
+	rateLimiter *services.RateLimiter
+}
+
+func NewRateLimitMiddleware(rateLimiter *services.RateLimiter) *RateLimitMiddleware {
+	return &RateLimitMiddleware{rateLimiter: rateLimiter}
+}
+
+// Limit rejects requests over the rate limit of the route group with 429. Authenticated
+// requests are limited per user, so it must run after CheckAuth on protected routes;
+// other requests are limited per client IP. Requests pass if Redis is unavailable.
+func (r *RateLimitMiddleware) Limit(group string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if r.rateLimiter == nil {
+				return next(c)
+			}
+
+			reqCtx := c.Request().Context()
+			allowed, retryAfter, err := r.rateLimiter.Allow(reqCtx, group,
+				helpers.GetUserID(c), helpers.GetRoleNames(c), c.RealIP())
+			if err != nil {
+				logger.GetLoggerFromCtx(reqCtx).Warn(reqCtx, "failed to check rate limit",
+					slog.String("group", group),
+					logger.Err(err))
+				return next(c)
+			}
+			if !allowed {
+				logger.GetLoggerFromCtx(reqCtx).Info(reqCtx, "rate limit exceeded",
+					slog.String("group", group),
+					slog.String("user_id", helpers.GetUserID(c)))
+				return helpers.TooManyRequests(c, retryAfter, "rate limit exceeded")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package anomaly_adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	anomalyKeyPrefix = "anomaly:"
+	blockIndexKey    = anomalyKeyPrefix + "blocks"
+)
+
+// countScript trims every sorted set in KEYS to the window ending at ARGV[1] (ms),
+// adds the detokenization ARGV[3] scored by its time and returns the sizes. The keys of
+// a user share a hash tag, so the script also runs on Redis Cluster.
+var countScript = redis.NewScript(`
+local counts = {}
+local since = tonumber(ARGV[1]) - tonumber(ARGV[2])
+for i, key in ipairs(KEYS) do
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', since)
+	redis.call('ZADD', key, ARGV[1], ARGV[3])
+	redis.call('PEXPIRE', key, ARGV[2])
+	counts[i] = redis.call('ZCARD', key)
+end
+return counts
+`)
+
+type AnomalyAdapterRedis struct {
+	client *redis.Client
+}
+
+func NewAnomalyAdapterRedis(client *redis.Client) *AnomalyAdapterRedis {
+	return &AnomalyAdapterRedis{client: client}
+}
+
+func counterKey(userID, key string) string {
+	return fmt.Sprintf("%sdetokenize:{%s}:%s", anomalyKeyPrefix, userID, key)
+}
+
+func blockKey(userID string) string {
+	return anomalyKeyPrefix + "block:" + userID
+}
+
+func (a *AnomalyAdapterRedis) CountDetokenize(ctx context.Context, userID string, keys []string, window time.Duration) ([]int64, error) {
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = counterKey(userID, key)
+	}
+
+	counts, err := countScript.Run(ctx, a.client, redisKeys,
+		time.Now().UnixMilli(), window.Milliseconds(), uuid.NewString()).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count detokenizations: %w", err)
+	}
+	return counts, nil
+}
+
+// ResetCounters drops all detokenization counters of the user.
+func (a *AnomalyAdapterRedis) ResetCounters(ctx context.Context, userID string) error {
+	iter := a.client.Scan(ctx, 0, counterKey(userID, "*"), 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan counters: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := a.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete counters: %w", err)
+	}
+	return nil
+}
+
+func (a *AnomalyAdapterRedis) SaveBlock(ctx context.Context, block *domain.AnomalyBlock) error {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("failed to marshal block: %w", err)
+	}
+
+	_, err = a.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, blockKey(block.UserID), data, time.Until(block.ExpiresAt))
+		pipe.SAdd(ctx, blockIndexKey, block.UserID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save block: %w", err)
+	}
+	return nil
+}
+
+// GetBlock returns the active block of the user, or nil if there is none.
+func (a *AnomalyAdapterRedis) GetBlock(ctx context.Context, userID string) (*domain.AnomalyBlock, error) {
+	data, err := a.client.Get(ctx, blockKey(userID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+
+	var block domain.AnomalyBlock
+	if err = json.Unmarshal(data, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
+	}
+	return &block, nil
+}
+
+// ListBlocks returns the active blocks and drops expired ones from the index.
+func (a *AnomalyAdapterRedis) ListBlocks(ctx context.Context) ([]*domain.AnomalyBlock, error) {
+	userIDs, err := a.client.SMembers(ctx, blockIndexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocks: %w", err)
+	}
+
+	blocks := make([]*domain.AnomalyBlock, 0, len(userIDs))
+	var expired []interface{}
+	for _, userID := range userIDs {
+		block, err := a.GetBlock(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			expired = append(expired, userID)
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+
+	if len(expired) > 0 {
+		a.client.SRem(ctx, blockIndexKey, expired...)
+	}
+	return blocks, nil
+}
+
+func (a *AnomalyAdapterRedis) DeleteBlock(ctx context.Context, userID string) error {
+	_, err := a.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, blockKey(userID))
+		pipe.SRem(ctx, blockIndexKey, userID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete block: %w", err)
+	}
+	return nil
+}
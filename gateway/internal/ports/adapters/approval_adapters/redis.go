@@ -0,0 +1,116 @@
+package approval_adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	approvalKeyPrefix = "approval:"
+	approvalIndexKey  = "approvals"
+)
+
+// updateScript replaces the approval KEYS[1] with the status ARGV[2] and the data ARGV[3]
+// expiring in ARGV[4] ms if its status is ARGV[1]. It returns 1 if it did.
+var updateScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'status') ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'status', ARGV[2], 'data', ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+
+type ApprovalAdapterRedis struct {
+	client *redis.Client
+}
+
+func NewApprovalAdapterRedis(client *redis.Client) *ApprovalAdapterRedis {
+	return &ApprovalAdapterRedis{client: client}
+}
+
+func approvalKey(id string) string {
+	return approvalKeyPrefix + id
+}
+
+func (a *ApprovalAdapterRedis) CreateApproval(ctx context.Context, approval *domain.Approval, ttl time.Duration) error {
+	data, err := json.Marshal(approval)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approval: %w", err)
+	}
+
+	_, err = a.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, approvalKey(approval.ID), "status", approval.Status, "data", data)
+		pipe.PExpire(ctx, approvalKey(approval.ID), ttl)
+		pipe.SAdd(ctx, approvalIndexKey, approval.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create approval: %w", err)
+	}
+	return nil
+}
+
+// GetApproval returns the approval, or nil if there is none.
+func (a *ApprovalAdapterRedis) GetApproval(ctx context.Context, id string) (*domain.Approval, error) {
+	data, err := a.client.HGet(ctx, approvalKey(id), "data").Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval: %w", err)
+	}
+
+	var approval domain.Approval
+	if err = json.Unmarshal(data, &approval); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal approval: %w", err)
+	}
+	return &approval, nil
+}
+
+// ListApprovals returns the kept approvals and drops the ones past their TTL from the
+// index.
+func (a *ApprovalAdapterRedis) ListApprovals(ctx context.Context) ([]*domain.Approval, error) {
+	ids, err := a.client.SMembers(ctx, approvalIndexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+
+	approvals := make([]*domain.Approval, 0, len(ids))
+	var dropped []interface{}
+	for _, id := range ids {
+		approval, err := a.GetApproval(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if approval == nil {
+			dropped = append(dropped, id)
+			continue
+		}
+		approvals = append(approvals, approval)
+	}
+
+	if len(dropped) > 0 {
+		a.client.SRem(ctx, approvalIndexKey, dropped...)
+	}
+	return approvals, nil
+}
+
+func (a *ApprovalAdapterRedis) UpdateApproval(ctx context.Context, approval *domain.Approval, from string, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(approval)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal approval: %w", err)
+	}
+
+	updated, err := updateScript.Run(ctx, a.client, []string{approvalKey(approval.ID)},
+		from, approval.Status, data, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("failed to update approval: %w", err)
+	}
+	return updated == 1, nil
+}
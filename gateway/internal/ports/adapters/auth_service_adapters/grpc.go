@@ -142,6 +142,384 @@ func (a *AuthServiceAdapterGRPC) GetUserRoles(ctx context.Context, req *auth_ser
 	return resp, nil
 }
 
+func (a *AuthServiceAdapterGRPC) GetPermissions(ctx context.Context, req *auth_service.GetPermissionsRequest) (*auth_service.GetPermissionsResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.GetPermissions(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) CreateRole(ctx context.Context, req *auth_service.CreateRoleRequest) (*auth_service.CreateRoleResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.CreateRole(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) UpdateRole(ctx context.Context, req *auth_service.UpdateRoleRequest) (*auth_service.UpdateRoleResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.UpdateRole(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) DeleteRole(ctx context.Context, req *auth_service.DeleteRoleRequest) (*auth_service.DeleteRoleResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.DeleteRole(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete role: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) IssueAccessToken(ctx context.Context, req *auth_service.IssueAccessTokenRequest) (*auth_service.IssueAccessTokenResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.IssueAccessToken(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) CreateAccessGrant(ctx context.Context, req *auth_service.CreateAccessGrantRequest) (*auth_service.CreateAccessGrantResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.CreateAccessGrant(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access grant: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) RevokeAccessGrant(ctx context.Context, req *auth_service.RevokeAccessGrantRequest) (*auth_service.RevokeAccessGrantResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.RevokeAccessGrant(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke access grant: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) GetAccessGrants(ctx context.Context, req *auth_service.GetAccessGrantsRequest) (*auth_service.GetAccessGrantsResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.GetAccessGrants(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access grants: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) SetKindOperationGrant(ctx context.Context, req *auth_service.SetKindOperationGrantRequest) (*auth_service.SetKindOperationGrantResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.SetKindOperationGrant(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set kind operation grant: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) DeleteKindOperationGrant(ctx context.Context, req *auth_service.DeleteKindOperationGrantRequest) (*auth_service.DeleteKindOperationGrantResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.DeleteKindOperationGrant(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete kind operation grant: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) GetKindOperationGrants(ctx context.Context, req *auth_service.GetKindOperationGrantsRequest) (*auth_service.GetKindOperationGrantsResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.GetKindOperationGrants(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kind operation grants: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) CreateServiceAccount(ctx context.Context, req *auth_service.CreateServiceAccountRequest) (*auth_service.CreateServiceAccountResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.CreateServiceAccount(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) DeleteServiceAccount(ctx context.Context, req *auth_service.DeleteServiceAccountRequest) (*auth_service.DeleteServiceAccountResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.DeleteServiceAccount(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete service account: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) GetServiceAccounts(ctx context.Context, req *auth_service.GetServiceAccountsRequest) (*auth_service.GetServiceAccountsResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.GetServiceAccounts(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service accounts: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) CreateApiKey(ctx context.Context, req *auth_service.CreateApiKeyRequest) (*auth_service.CreateApiKeyResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.CreateApiKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) RotateApiKey(ctx context.Context, req *auth_service.RotateApiKeyRequest) (*auth_service.RotateApiKeyResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.RotateApiKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) RevokeApiKey(ctx context.Context, req *auth_service.RevokeApiKeyRequest) (*auth_service.RevokeApiKeyResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.RevokeApiKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) GetApiKeys(ctx context.Context, req *auth_service.GetApiKeysRequest) (*auth_service.GetApiKeysResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.GetApiKeys(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) AuthenticateApiKey(ctx context.Context, req *auth_service.AuthenticateApiKeyRequest) (*auth_service.AuthenticateApiKeyResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.AuthenticateApiKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) IssueClientToken(ctx context.Context, req *auth_service.IssueClientTokenRequest) (*auth_service.IssueClientTokenResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.IssueClientToken(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue client token: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *AuthServiceAdapterGRPC) LoginExternal(ctx context.Context, req *auth_service.LoginExternalRequest) (*auth_service.LoginExternalResponse, error) {
+	conn, err := grpc.NewClient(a.address, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for auth service: %w", err)
+	}
+	defer conn.Close()
+
+	client := auth_service.NewAuthServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, a.dialTimeout)
+	defer cancel()
+
+	resp, err := client.LoginExternal(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to login external user: %w", err)
+	}
+	return resp, nil
+}
+
 func NewAuthServiceAdapterGRPC(address string, dialTimeout time.Duration) *AuthServiceAdapterGRPC {
 	return &AuthServiceAdapterGRPC{
 		address:     address,
@@ -0,0 +1,205 @@
+package break_glass_adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+const (
+	sessionKeyPrefix    = "breakglass:"
+	operationsKeySuffix = ":ops"
+	activeKeyPrefix     = "breakglass:user:"
+	sessionIndexKey     = "breakglasses"
+
+	// maxOperations caps the operations kept for a session; the audit log keeps them
+	// all, and the session keeps their count.
+	maxOperations = 1000
+)
+
+// clearActiveScript deletes KEYS[1] if its value is ARGV[1].
+var clearActiveScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type BreakGlassAdapterRedis struct {
+	client *redis.Client
+}
+
+func NewBreakGlassAdapterRedis(client *redis.Client) *BreakGlassAdapterRedis {
+	return &BreakGlassAdapterRedis{client: client}
+}
+
+func sessionKey(id string) string {
+	return sessionKeyPrefix + id
+}
+
+func operationsKey(id string) string {
+	return sessionKeyPrefix + id + operationsKeySuffix
+}
+
+func activeKey(userID string) string {
+	return activeKeyPrefix + userID
+}
+
+func (a *BreakGlassAdapterRedis) CreateSession(ctx context.Context, session *domain.BreakGlassSession, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal break-glass session: %w", err)
+	}
+
+	created, err := a.client.SetNX(ctx, activeKey(session.UserID), session.ID, time.Until(session.ExpiresAt)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to create break-glass session: %w", err)
+	}
+	if !created {
+		return errs.ErrBreakGlassActive
+	}
+
+	_, err = a.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey(session.ID), "data", data, "operations", 0)
+		pipe.PExpire(ctx, sessionKey(session.ID), ttl)
+		pipe.SAdd(ctx, sessionIndexKey, session.ID)
+		return nil
+	})
+	if err != nil {
+		a.client.Del(ctx, activeKey(session.UserID))
+		return fmt.Errorf("failed to create break-glass session: %w", err)
+	}
+	return nil
+}
+
+// GetSession returns the session, or nil if there is none.
+func (a *BreakGlassAdapterRedis) GetSession(ctx context.Context, id string) (*domain.BreakGlassSession, error) {
+	fields, err := a.client.HMGet(ctx, sessionKey(id), "data", "operations").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get break-glass session: %w", err)
+	}
+	data, ok := fields[0].(string)
+	if !ok {
+		return nil, nil
+	}
+
+	var session domain.BreakGlassSession
+	if err = json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal break-glass session: %w", err)
+	}
+	if operations, ok := fields[1].(string); ok {
+		session.Operations, _ = strconv.ParseInt(operations, 10, 64)
+	}
+	return &session, nil
+}
+
+// ListSessions returns the kept sessions and drops the ones past their TTL from the
+// index.
+func (a *BreakGlassAdapterRedis) ListSessions(ctx context.Context) ([]*domain.BreakGlassSession, error) {
+	ids, err := a.client.SMembers(ctx, sessionIndexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list break-glass sessions: %w", err)
+	}
+
+	sessions := make([]*domain.BreakGlassSession, 0, len(ids))
+	var dropped []interface{}
+	for _, id := range ids {
+		session, err := a.GetSession(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil {
+			dropped = append(dropped, id)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(dropped) > 0 {
+		a.client.SRem(ctx, sessionIndexKey, dropped...)
+	}
+	return sessions, nil
+}
+
+// UpdateSession replaces the stored session, keeping its TTL and operation count.
+func (a *BreakGlassAdapterRedis) UpdateSession(ctx context.Context, session *domain.BreakGlassSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal break-glass session: %w", err)
+	}
+	if err = a.client.HSet(ctx, sessionKey(session.ID), "data", data).Err(); err != nil {
+		return fmt.Errorf("failed to update break-glass session: %w", err)
+	}
+	return nil
+}
+
+// GetActiveSessionID returns the ID of the active session of the user, or "".
+func (a *BreakGlassAdapterRedis) GetActiveSessionID(ctx context.Context, userID string) (string, error) {
+	id, err := a.client.Get(ctx, activeKey(userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get active break-glass session: %w", err)
+	}
+	return id, nil
+}
+
+func (a *BreakGlassAdapterRedis) ClearActiveSession(ctx context.Context, userID, id string) error {
+	if err := clearActiveScript.Run(ctx, a.client, []string{activeKey(userID)}, id).Err(); err != nil {
+		return fmt.Errorf("failed to clear active break-glass session: %w", err)
+	}
+	return nil
+}
+
+// AddOperation appends the operation to the session and counts it. Operations share
+// the TTL of their session.
+func (a *BreakGlassAdapterRedis) AddOperation(ctx context.Context, id string, operation *domain.BreakGlassOperation) error {
+	data, err := json.Marshal(operation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal break-glass operation: %w", err)
+	}
+
+	ttl, err := a.client.PTTL(ctx, sessionKey(id)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to add break-glass operation: %w", err)
+	}
+	if ttl <= 0 {
+		return errs.ErrBreakGlassNotFound
+	}
+
+	_, err = a.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, sessionKey(id), "operations", 1)
+		pipe.RPush(ctx, operationsKey(id), data)
+		pipe.LTrim(ctx, operationsKey(id), -maxOperations, -1)
+		pipe.PExpire(ctx, operationsKey(id), ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add break-glass operation: %w", err)
+	}
+	return nil
+}
+
+func (a *BreakGlassAdapterRedis) ListOperations(ctx context.Context, id string) ([]*domain.BreakGlassOperation, error) {
+	items, err := a.client.LRange(ctx, operationsKey(id), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list break-glass operations: %w", err)
+	}
+
+	operations := make([]*domain.BreakGlassOperation, 0, len(items))
+	for _, item := range items {
+		var operation domain.BreakGlassOperation
+		if err = json.Unmarshal([]byte(item), &operation); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal break-glass operation: %w", err)
+		}
+		operations = append(operations, &operation)
+	}
+	return operations, nil
+}
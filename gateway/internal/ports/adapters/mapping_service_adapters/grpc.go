@@ -2,11 +2,13 @@ package mapping_service_adapters
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/NeF2le/anonix/common/gen/mapping"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"io"
 	"time"
 )
 
@@ -348,3 +350,230 @@ func (s *MappingServiceAdapterGRPC) GetAuditLogList(ctx context.Context, req *ma
 
 	return resp, nil
 }
+
+// ExportAuditLog streams the exported entries to fn. The dial timeout does not apply to
+// the stream, which lives as long as ctx.
+func (s *MappingServiceAdapterGRPC) ExportAuditLog(
+	ctx context.Context,
+	req *mapping.ExportAuditLogRequest,
+	fn func(entry *mapping.AuditLogEntry) error,
+) error {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.ExportAuditLog(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to export audit log: %w", err)
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export audit log: %w", err)
+		}
+		if err = fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *MappingServiceAdapterGRPC) ListSubjectMappings(ctx context.Context, req *mapping.ListSubjectMappingsRequest) (
+	*mapping.ListSubjectMappingsResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.ListSubjectMappings(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subject mappings: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) EraseSubject(ctx context.Context, req *mapping.EraseSubjectRequest) (
+	*mapping.EraseSubjectResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.EraseSubject(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase subject: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) CreatePurpose(ctx context.Context, req *mapping.CreatePurposeRequest) (
+	*mapping.CreatePurposeResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.CreatePurpose(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create purpose: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) ListPurposes(ctx context.Context, req *mapping.ListPurposesRequest) (
+	*mapping.ListPurposesResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.ListPurposes(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purposes: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) DeletePurpose(ctx context.Context, req *mapping.DeletePurposeRequest) (
+	*mapping.DeletePurposeResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.DeletePurpose(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete purpose: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) CreateLegalHold(ctx context.Context, req *mapping.CreateLegalHoldRequest) (
+	*mapping.CreateLegalHoldResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.CreateLegalHold(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create legal hold: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) ListLegalHolds(ctx context.Context, req *mapping.ListLegalHoldsRequest) (
+	*mapping.ListLegalHoldsResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.ListLegalHolds(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) ReleaseLegalHold(ctx context.Context, req *mapping.ReleaseLegalHoldRequest) (
+	*mapping.ReleaseLegalHoldResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.ReleaseLegalHold(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release legal hold: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) GetDestructionAct(ctx context.Context, req *mapping.GetDestructionActRequest) (
+	*mapping.GetDestructionActResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.GetDestructionAct(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destruction act: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MappingServiceAdapterGRPC) VerifyAuditLog(ctx context.Context, req *mapping.VerifyAuditLogRequest) (
+	*mapping.VerifyAuditLogResponse, error) {
+	conn, err := grpc.NewClient(s.address, s.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for mapping service: %w", err)
+	}
+	defer conn.Close()
+
+	client := mapping.NewMappingClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, s.dialTimeout)
+	defer cancel()
+
+	resp, err := client.VerifyAuditLog(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify audit log: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package rate_limit_adapters
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const rateLimitKeyPrefix = "ratelimit:"
+
+// takeTokenScript refills the bucket KEYS[1] of ARGV[1] requests per ARGV[2] ms by the
+// time passed since its last use and takes a request from it. It returns 0 if the
+// request is allowed and the wait in ms otherwise. The time is taken from Redis, so
+// gateway replicas with skewed clocks share the buckets correctly.
+var takeTokenScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + (now - ts) * burst / period)
+
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * period / burst)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], period)
+return wait
+`)
+
+// consumeQuotaScript increments the counter KEYS[1] expiring at ARGV[2] (unix ms) unless
+// it has reached ARGV[1]. It returns 1 if the request was counted.
+var consumeQuotaScript = redis.NewScript(`
+local used = tonumber(redis.call('GET', KEYS[1]) or '0')
+if used >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('INCR', KEYS[1])
+redis.call('PEXPIREAT', KEYS[1], ARGV[2])
+return 1
+`)
+
+type RateLimitAdapterRedis struct {
+	client *redis.Client
+}
+
+func NewRateLimitAdapterRedis(client *redis.Client) *RateLimitAdapterRedis {
+	return &RateLimitAdapterRedis{client: client}
+}
+
+func (r *RateLimitAdapterRedis) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error) {
+	wait, err := takeTokenScript.Run(ctx, r.client, []string{rateLimitKeyPrefix + "bucket:" + key},
+		limit.Burst, limit.Period.Milliseconds()).Int64()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to take token: %w", err)
+	}
+	if wait > 0 {
+		return false, time.Duration(wait) * time.Millisecond, nil
+	}
+	return true, 0, nil
+}
+
+func (r *RateLimitAdapterRedis) ConsumeQuota(ctx context.Context, key string, quota int64, resetAt time.Time) (bool, error) {
+	counted, err := consumeQuotaScript.Run(ctx, r.client, []string{rateLimitKeyPrefix + "quota:" + key},
+		quota, resetAt.UnixMilli()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("failed to consume quota: %w", err)
+	}
+	return counted == 1, nil
+}
@@ -0,0 +1,42 @@
+package token_revocation_adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const revokedKeyPrefix = "revoked:"
+
+type TokenRevocationAdapterRedis struct {
+	client *redis.Client
+}
+
+func NewTokenRevocationAdapterRedis(client *redis.Client) *TokenRevocationAdapterRedis {
+	return &TokenRevocationAdapterRedis{client: client}
+}
+
+func revokedKey(userID string) string {
+	return revokedKeyPrefix + userID
+}
+
+func (a *TokenRevocationAdapterRedis) SaveRevokedAt(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error {
+	if err := a.client.Set(ctx, revokedKey(userID), revokedAt.Unix(), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save token revocation: %w", err)
+	}
+	return nil
+}
+
+// GetRevokedAt returns the time saved for the user, or the zero time.
+func (a *TokenRevocationAdapterRedis) GetRevokedAt(ctx context.Context, userID string) (time.Time, error) {
+	unix, err := a.client.Get(ctx, revokedKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get token revocation: %w", err)
+	}
+	return time.Unix(unix, 0), nil
+}
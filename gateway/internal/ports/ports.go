@@ -5,6 +5,8 @@ import (
 	"github.com/NeF2le/anonix/common/gen/auth_service"
 	"github.com/NeF2le/anonix/common/gen/mapping"
 	"github.com/NeF2le/anonix/common/gen/tokenizer"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"time"
 )
 
 type TokenizerServiceRepository interface {
@@ -34,6 +36,21 @@ type MappingServiceRepository interface {
 
 	CreateAuditLog(ctx context.Context, req *mapping.CreateAuditLogRequest) (*mapping.CreateAuditLogResponse, error)
 	GetAuditLogList(ctx context.Context, req *mapping.GetAuditLogListRequest) (*mapping.GetAuditLogListResponse, error)
+	ExportAuditLog(ctx context.Context, req *mapping.ExportAuditLogRequest, fn func(entry *mapping.AuditLogEntry) error) error
+
+	ListSubjectMappings(ctx context.Context, req *mapping.ListSubjectMappingsRequest) (*mapping.ListSubjectMappingsResponse, error)
+	EraseSubject(ctx context.Context, req *mapping.EraseSubjectRequest) (*mapping.EraseSubjectResponse, error)
+
+	CreatePurpose(ctx context.Context, req *mapping.CreatePurposeRequest) (*mapping.CreatePurposeResponse, error)
+	ListPurposes(ctx context.Context, req *mapping.ListPurposesRequest) (*mapping.ListPurposesResponse, error)
+	DeletePurpose(ctx context.Context, req *mapping.DeletePurposeRequest) (*mapping.DeletePurposeResponse, error)
+
+	CreateLegalHold(ctx context.Context, req *mapping.CreateLegalHoldRequest) (*mapping.CreateLegalHoldResponse, error)
+	ListLegalHolds(ctx context.Context, req *mapping.ListLegalHoldsRequest) (*mapping.ListLegalHoldsResponse, error)
+	ReleaseLegalHold(ctx context.Context, req *mapping.ReleaseLegalHoldRequest) (*mapping.ReleaseLegalHoldResponse, error)
+
+	GetDestructionAct(ctx context.Context, req *mapping.GetDestructionActRequest) (*mapping.GetDestructionActResponse, error)
+	VerifyAuditLog(ctx context.Context, req *mapping.VerifyAuditLogRequest) (*mapping.VerifyAuditLogResponse, error)
 }
 
 type AuthServiceRepository interface {
@@ -50,4 +67,92 @@ type AuthServiceRepository interface {
 	UpdateClearanceLevel(ctx context.Context, req *auth_service.UpdateClearanceLevelRequest) (*auth_service.UpdateClearanceLevelResponse, error)
 	GetRolesList(ctx context.Context, req *auth_service.GetRolesListRequest) (*auth_service.GetRolesListResponse, error)
 	GetUserRoles(ctx context.Context, req *auth_service.GetUserRolesRequest) (*auth_service.GetUserRolesResponse, error)
+	GetPermissions(ctx context.Context, req *auth_service.GetPermissionsRequest) (*auth_service.GetPermissionsResponse, error)
+	CreateRole(ctx context.Context, req *auth_service.CreateRoleRequest) (*auth_service.CreateRoleResponse, error)
+	UpdateRole(ctx context.Context, req *auth_service.UpdateRoleRequest) (*auth_service.UpdateRoleResponse, error)
+	DeleteRole(ctx context.Context, req *auth_service.DeleteRoleRequest) (*auth_service.DeleteRoleResponse, error)
+
+	IssueAccessToken(ctx context.Context, req *auth_service.IssueAccessTokenRequest) (*auth_service.IssueAccessTokenResponse, error)
+
+	CreateAccessGrant(ctx context.Context, req *auth_service.CreateAccessGrantRequest) (*auth_service.CreateAccessGrantResponse, error)
+	RevokeAccessGrant(ctx context.Context, req *auth_service.RevokeAccessGrantRequest) (*auth_service.RevokeAccessGrantResponse, error)
+	GetAccessGrants(ctx context.Context, req *auth_service.GetAccessGrantsRequest) (*auth_service.GetAccessGrantsResponse, error)
+
+	SetKindOperationGrant(ctx context.Context, req *auth_service.SetKindOperationGrantRequest) (*auth_service.SetKindOperationGrantResponse, error)
+	DeleteKindOperationGrant(ctx context.Context, req *auth_service.DeleteKindOperationGrantRequest) (*auth_service.DeleteKindOperationGrantResponse, error)
+	GetKindOperationGrants(ctx context.Context, req *auth_service.GetKindOperationGrantsRequest) (*auth_service.GetKindOperationGrantsResponse, error)
+
+	CreateServiceAccount(ctx context.Context, req *auth_service.CreateServiceAccountRequest) (*auth_service.CreateServiceAccountResponse, error)
+	DeleteServiceAccount(ctx context.Context, req *auth_service.DeleteServiceAccountRequest) (*auth_service.DeleteServiceAccountResponse, error)
+	GetServiceAccounts(ctx context.Context, req *auth_service.GetServiceAccountsRequest) (*auth_service.GetServiceAccountsResponse, error)
+	CreateApiKey(ctx context.Context, req *auth_service.CreateApiKeyRequest) (*auth_service.CreateApiKeyResponse, error)
+	RotateApiKey(ctx context.Context, req *auth_service.RotateApiKeyRequest) (*auth_service.RotateApiKeyResponse, error)
+	RevokeApiKey(ctx context.Context, req *auth_service.RevokeApiKeyRequest) (*auth_service.RevokeApiKeyResponse, error)
+	GetApiKeys(ctx context.Context, req *auth_service.GetApiKeysRequest) (*auth_service.GetApiKeysResponse, error)
+	AuthenticateApiKey(ctx context.Context, req *auth_service.AuthenticateApiKeyRequest) (*auth_service.AuthenticateApiKeyResponse, error)
+	IssueClientToken(ctx context.Context, req *auth_service.IssueClientTokenRequest) (*auth_service.IssueClientTokenResponse, error)
+	LoginExternal(ctx context.Context, req *auth_service.LoginExternalRequest) (*auth_service.LoginExternalResponse, error)
+}
+
+// AnomalyRepository keeps the sliding-window detokenization counters and the blocks of
+// the anomaly detector.
+type AnomalyRepository interface {
+	// CountDetokenize adds a detokenization to the counters of keys and returns the number
+	// of detokenizations each of them holds within window.
+	CountDetokenize(ctx context.Context, userID string, keys []string, window time.Duration) ([]int64, error)
+	ResetCounters(ctx context.Context, userID string) error
+	SaveBlock(ctx context.Context, block *domain.AnomalyBlock) error
+	GetBlock(ctx context.Context, userID string) (*domain.AnomalyBlock, error)
+	ListBlocks(ctx context.Context) ([]*domain.AnomalyBlock, error)
+	DeleteBlock(ctx context.Context, userID string) error
+}
+
+// RateLimitRepository keeps the token buckets and daily quotas of the rate limiter.
+type RateLimitRepository interface {
+	// TakeToken takes a request from the bucket of key. If the bucket is empty, it returns
+	// false and the time until the next request is allowed.
+	TakeToken(ctx context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error)
+	// ConsumeQuota counts a request against the quota of key, which resets at resetAt. It
+	// returns false without counting the request if the quota is used up.
+	ConsumeQuota(ctx context.Context, key string, quota int64, resetAt time.Time) (bool, error)
+}
+
+// ApprovalRepository keeps the four-eyes approval requests. Approvals are dropped ttl
+// after they are saved.
+type ApprovalRepository interface {
+	CreateApproval(ctx context.Context, approval *domain.Approval, ttl time.Duration) error
+	// GetApproval returns the approval, or nil if there is none.
+	GetApproval(ctx context.Context, id string) (*domain.Approval, error)
+	ListApprovals(ctx context.Context) ([]*domain.Approval, error)
+	// UpdateApproval replaces the approval if its stored status is still from and
+	// reports whether it did, so concurrent decisions cannot both succeed.
+	UpdateApproval(ctx context.Context, approval *domain.Approval, from string, ttl time.Duration) (bool, error)
+}
+
+// BreakGlassRepository keeps the break-glass sessions and the operations performed
+// during them. Sessions are dropped ttl after they are saved.
+type BreakGlassRepository interface {
+	// CreateSession saves the session as the active session of its user until it
+	// expires. It returns errs.ErrBreakGlassActive if the user already has one.
+	CreateSession(ctx context.Context, session *domain.BreakGlassSession, ttl time.Duration) error
+	// GetSession returns the session, or nil if there is none.
+	GetSession(ctx context.Context, id string) (*domain.BreakGlassSession, error)
+	ListSessions(ctx context.Context) ([]*domain.BreakGlassSession, error)
+	UpdateSession(ctx context.Context, session *domain.BreakGlassSession) error
+	// GetActiveSessionID returns the ID of the active session of the user, or "".
+	GetActiveSessionID(ctx context.Context, userID string) (string, error)
+	// ClearActiveSession forgets the active session of the user if it is still id.
+	ClearActiveSession(ctx context.Context, userID, id string) error
+
+	AddOperation(ctx context.Context, id string, operation *domain.BreakGlassOperation) error
+	ListOperations(ctx context.Context, id string) ([]*domain.BreakGlassOperation, error)
+}
+
+// TokenRevocationRepository keeps, per user, the time before which their access tokens
+// were issued with access since taken away. The times are dropped ttl after they are
+// saved.
+type TokenRevocationRepository interface {
+	SaveRevokedAt(ctx context.Context, userID string, revokedAt time.Time, ttl time.Duration) error
+	// GetRevokedAt returns the time saved for the user, or the zero time.
+	GetRevokedAt(ctx context.Context, userID string) (time.Time, error)
 }
@@ -0,0 +1,23 @@
+package schemas
+
+type CreateAccessGrantSchema struct {
+	UserId         string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ClearanceLevel int32  `json:"clearance_level,omitempty" example:"3"`
+	KindId         int32  `json:"kind_id,omitempty" example:"0"`
+	Reason         string `json:"reason" example:"Проверка обращения субъекта по заявке REQ-2291"`
+	DurationHours  int    `json:"duration_hours" example:"24"`
+}
+
+type AccessGrantSchema struct {
+	Id             string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserId         string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ClearanceLevel int32  `json:"clearance_level,omitempty" example:"3"`
+	KindId         int32  `json:"kind_id,omitempty" example:"0"`
+	Reason         string `json:"reason" example:"Проверка обращения субъекта по заявке REQ-2291"`
+	GrantedBy      string `json:"granted_by" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt      string `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+	ExpiresAt      string `json:"expires_at" example:"2006-01-02T15:04:05Z07:00"`
+	RevokedAt      string `json:"revoked_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	RevokedBy      string `json:"revoked_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Active         bool   `json:"active" example:"true"`
+}
@@ -0,0 +1,12 @@
+package schemas
+
+type AnomalyBlockSchema struct {
+	UserId    string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Action    string `json:"action" example:"block"` // "block" | "reauth"
+	Dimension string `json:"dimension" example:"kind:passport"`
+	Count     int64  `json:"count" example:"61"`
+	Threshold int64  `json:"threshold" example:"60"`
+	Window    string `json:"window" example:"1h0m0s"`
+	CreatedAt string `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+	ExpiresAt string `json:"expires_at" example:"2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,29 @@
+package schemas
+
+import "encoding/json"
+
+type ApprovalSchema struct {
+	Id           string          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Operation    string          `json:"operation" example:"rotate_deks"`
+	Status       string          `json:"status" example:"pending"` // "pending" | "approved" | "rejected" | "expired" | "executed"
+	Target       string          `json:"target,omitempty" example:"12"`
+	RequesterId  string          `json:"requester_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Method       string          `json:"method" example:"DELETE"`
+	Uri          string          `json:"uri" example:"/api/v1/kinds/12"`
+	Body         json.RawMessage `json:"body,omitempty" swaggertype:"object"`
+	ApproverId   string          `json:"approver_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RejectReason string          `json:"reject_reason,omitempty" example:"Ротация не согласована с владельцем данных"`
+	ResultCode   int             `json:"result_code,omitempty" example:"200"`
+	CreatedAt    string          `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+	ExpiresAt    string          `json:"expires_at" example:"2006-01-02T15:04:05Z07:00"`
+	DecidedAt    string          `json:"decided_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	ExecutedAt   string          `json:"executed_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+}
+
+type RejectApprovalSchema struct {
+	Reason string `json:"reason" example:"Ротация не согласована с владельцем данных"`
+}
+
+type ExpireApprovalsRespSchema struct {
+	ExpiredCount int `json:"expired_count" example:"2"`
+}
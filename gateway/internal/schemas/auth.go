@@ -29,6 +29,7 @@ type LoginRespSchema struct {
 	AccessToken  string        `json:"access_token"`
 	RefreshToken string        `json:"refresh_token"`
 	Roles        []*RoleSchema `json:"roles"`
+	Permissions  []string      `json:"permissions,omitempty" example:"tokenizer.tokenize"`
 }
 
 type RefreshRespSchema struct {
@@ -65,8 +66,11 @@ type GetRolesListRespSchema struct {
 }
 
 type RoleSchema struct {
-	Id   int32  `json:"id"`
-	Name string `json:"name"`
+	Id          int32    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty" example:"Специалист: токенизация и работа с данными"`
+	Permissions []string `json:"permissions,omitempty" example:"tokenizer.tokenize"`
+	BuiltIn     bool     `json:"built_in,omitempty" example:"true"`
 }
 
 type UserSchema struct {
@@ -92,5 +96,8 @@ type GetUserRolesSchema struct {
 }
 
 type GetUserRolesRespSchema struct {
-	Roles []*RoleSchema `json:"roles"`
+	UserId         string                  `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Roles          []*RoleSchema           `json:"roles"`
+	Permissions    []string                `json:"permissions,omitempty" example:"tokenizer.tokenize"`
+	KindOperations []*KindOperationsSchema `json:"kind_operations,omitempty"`
 }
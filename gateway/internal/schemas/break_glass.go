@@ -0,0 +1,37 @@
+package schemas
+
+type StartBreakGlassSchema struct {
+	Justification   string `json:"justification" example:"Инцидент INC-1042: требуется проверка данных клиента по запросу прокуратуры"`
+	DurationMinutes int    `json:"duration_minutes" example:"30"`
+}
+
+type BreakGlassSessionSchema struct {
+	Id             string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserId         string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Justification  string `json:"justification" example:"Инцидент INC-1042: требуется проверка данных клиента по запросу прокуратуры"`
+	Active         bool   `json:"active" example:"true"`
+	StartedAt      string `json:"started_at" example:"2006-01-02T15:04:05Z07:00"`
+	ExpiresAt      string `json:"expires_at" example:"2006-01-02T15:04:05Z07:00"`
+	EndedAt        string `json:"ended_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	Operations     int64  `json:"operations" example:"3"`
+	AcknowledgedBy string `json:"acknowledged_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AcknowledgedAt string `json:"acknowledged_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+}
+
+type StartBreakGlassRespSchema struct {
+	Session     *BreakGlassSessionSchema `json:"session"`
+	AccessToken string                   `json:"access_token"`
+}
+
+type BreakGlassOperationSchema struct {
+	Action  string `json:"action" example:"detokenize"`
+	Token   string `json:"token,omitempty" example:"tok_4f9a..."`
+	KindId  int32  `json:"kind_id,omitempty" example:"3"`
+	Outcome string `json:"outcome" example:"success"`
+	At      string `json:"at" example:"2006-01-02T15:04:05Z07:00"`
+}
+
+type BreakGlassSessionDetailsSchema struct {
+	BreakGlassSessionSchema
+	OperationList []*BreakGlassOperationSchema `json:"operation_list"`
+}
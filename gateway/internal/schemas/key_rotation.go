@@ -3,4 +3,6 @@ package schemas
 type KeyRotationResultSchema struct {
 	UpdatedCount int32 `json:"updated_count"`
 	FailedCount  int32 `json:"failed_count"`
+	// HeldCount is the number of mappings skipped because they are under legal hold.
+	HeldCount int32 `json:"held_count"`
 }
@@ -0,0 +1,26 @@
+package schemas
+
+// SetKindOperationGrantSchema allows a user or a role operations on data of a kind.
+// Exactly one of UserId and RoleId is set.
+type SetKindOperationGrantSchema struct {
+	UserId     string   `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RoleId     int32    `json:"role_id,omitempty" example:"0"`
+	KindId     int32    `json:"kind_id" example:"3"`
+	Operations []string `json:"operations" example:"detokenize,view"`
+}
+
+type KindOperationGrantSchema struct {
+	Id         int32    `json:"id" example:"1"`
+	UserId     string   `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RoleId     int32    `json:"role_id,omitempty" example:"0"`
+	KindId     int32    `json:"kind_id" example:"3"`
+	Operations []string `json:"operations" example:"detokenize,view"`
+	GrantedBy  string   `json:"granted_by" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt  string   `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+}
+
+// KindOperationsSchema lists the operations allowed on data of a kind.
+type KindOperationsSchema struct {
+	KindId     int32    `json:"kind_id" example:"3"`
+	Operations []string `json:"operations" example:"detokenize,view"`
+}
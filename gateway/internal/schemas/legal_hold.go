@@ -0,0 +1,30 @@
+package schemas
+
+import "time"
+
+type LegalHoldTargetSchema struct {
+	Type  string `json:"type" example:"subject"` // "token" | "subject" | "kind"
+	Value string `json:"value" example:"subj_4f1c9e"`
+}
+
+type CreateLegalHoldSchema struct {
+	Name      string                   `json:"name" example:"case-2024-117"`
+	Reason    string                   `json:"reason" example:"Судебное разбирательство"`
+	Owner     string                   `json:"owner" example:"Юридический отдел"`
+	Targets   []*LegalHoldTargetSchema `json:"targets"`
+	ExpiresAt *time.Time               `json:"expires_at,omitempty" example:"2030-01-02T15:04:05Z"`
+}
+
+type LegalHoldSchema struct {
+	Id         string                   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name       string                   `json:"name" example:"case-2024-117"`
+	Reason     string                   `json:"reason" example:"Судебное разбирательство"`
+	Owner      string                   `json:"owner" example:"Юридический отдел"`
+	Targets    []*LegalHoldTargetSchema `json:"targets"`
+	CreatedBy  string                   `json:"created_by" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt  string                   `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+	ExpiresAt  string                   `json:"expires_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	ReleasedAt string                   `json:"released_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	ReleasedBy string                   `json:"released_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Active     bool                     `json:"active" example:"true"`
+}
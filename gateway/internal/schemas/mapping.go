@@ -33,6 +33,12 @@ type MappingSchema struct {
 	Deterministic bool        `json:"deterministic,omitempty" example:"true"`
 	Kind          *KindSchema `json:"kind,omitempty"`
 	AlgoName      string      `json:"algo_name,omitempty" example:"aes-256-siv"`
+	SubjectRef    string      `json:"subject_ref,omitempty" example:"subj_4f1c9e"`
+
+	Purposes         []string `json:"purposes,omitempty" example:"billing"`
+	LegalBasis       string   `json:"legal_basis,omitempty" example:"consent"`
+	ConsentRef       string   `json:"consent_ref,omitempty" example:"consent-2024-0117"`
+	ConsentExpiresAt string   `json:"consent_expires_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
 }
 
 type CreateKindSchema struct {
@@ -61,10 +67,42 @@ type KindSchema struct {
 }
 
 type AuditLogEntrySchema struct {
-	Id        string      `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	UserId    string      `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Action    string      `json:"action" example:"tokenize"`
-	Token     string      `json:"token" example:"fio_7f82a1c3"`
-	Kind      *KindSchema `json:"kind,omitempty"`
-	CreatedAt string      `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+	Id            string      `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserId        string      `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Action        string      `json:"action" example:"tokenize"`
+	Token         string      `json:"token" example:"fio_7f82a1c3"`
+	Kind          *KindSchema `json:"kind,omitempty"`
+	CreatedAt     string      `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+	Purpose       string      `json:"purpose,omitempty" example:"billing"`
+	LegalHold     string      `json:"legal_hold,omitempty" example:"case-2024-117"`
+	Seq           int64       `json:"seq" example:"42"`
+	RowHash       string      `json:"row_hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Outcome       string      `json:"outcome" example:"denied"`
+	Reason        string      `json:"reason,omitempty" example:"insufficient clearance level"`
+	ClientIp      string      `json:"client_ip,omitempty" example:"10.0.0.12"`
+	UserAgent     string      `json:"user_agent,omitempty" example:"Mozilla/5.0"`
+	RequestId     string      `json:"request_id,omitempty" example:"5f0c6a0e-1d7b-4c43-9c1e-2f4b3a8d9e10"`
+	Justification string      `json:"justification,omitempty" example:"Инцидент INC-1042: требуется проверка данных клиента по запросу прокуратуры"`
+}
+
+type AuditVerifySchema struct {
+	Valid               bool   `json:"valid" example:"false"`
+	Checked             int64  `json:"checked" example:"41"`
+	LastSeq             int64  `json:"last_seq" example:"41"`
+	CheckpointsVerified int32  `json:"checkpoints_verified" example:"3"`
+	BrokenSeq           int64  `json:"broken_seq,omitempty" example:"42"`
+	BrokenEntryId       string `json:"broken_entry_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Reason              string `json:"reason,omitempty" example:"row hash mismatch"`
+}
+
+type CreatePurposeSchema struct {
+	Name        string `json:"name" example:"billing"`
+	Description string `json:"description" example:"Выставление счетов"`
+}
+
+type PurposeSchema struct {
+	Id          int32  `json:"id" example:"1"`
+	Name        string `json:"name" example:"billing"`
+	Description string `json:"description,omitempty" example:"Выставление счетов"`
+	CreatedAt   string `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
 }
@@ -0,0 +1,17 @@
+package schemas
+
+// ClientTokenRespSchema is the access token response of the client credentials grant
+// (RFC 6749, section 5.1).
+type ClientTokenRespSchema struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type" example:"Bearer"`
+	ExpiresIn   int64  `json:"expires_in" example:"3600"`
+	Scope       string `json:"scope,omitempty" example:"tokenizer.tokenize tokenizer.detokenize"`
+}
+
+// OidcInfoSchema tells the panel whether users can sign in through the identity
+// provider.
+type OidcInfoSchema struct {
+	Enabled      bool   `json:"enabled"`
+	ProviderName string `json:"provider_name,omitempty" example:"Keycloak"`
+}
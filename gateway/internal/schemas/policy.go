@@ -0,0 +1,63 @@
+package schemas
+
+type PolicyRuleSchema struct {
+	Name        string   `json:"name" example:"clearance"`
+	Description string   `json:"description,omitempty" example:"Доступ к данным в пределах уровня допуска"`
+	Effect      string   `json:"effect" example:"allow"`
+	Actions     []string `json:"actions" example:"data.access"`
+	When        string   `json:"when,omitempty" example:"resource.access_level <= subject.clearance"`
+}
+
+type PolicySchema struct {
+	Version  string              `json:"version" example:"3f2a9c01b7e4"`
+	Source   string              `json:"source" example:"policies/policy.yaml"`
+	LoadedAt string              `json:"loaded_at" example:"2006-01-02T15:04:05Z07:00"`
+	Actions  []string            `json:"actions" example:"tokenizer.detokenize"`
+	Rules    []*PolicyRuleSchema `json:"rules"`
+}
+
+// PolicySubjectSchema describes the user whose action is explained.
+type PolicySubjectSchema struct {
+	UserId         string                  `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Roles          []string                `json:"roles" example:"specialist"`
+	Permissions    []string                `json:"permissions" example:"tokenizer.detokenize"`
+	ClearanceLevel int                     `json:"clearance_level" example:"2"`
+	KindGrants     []int32                 `json:"kind_grants" example:"3"`
+	KindOperations []*KindOperationsSchema `json:"kind_operations"`
+	BreakGlass     bool                    `json:"break_glass" example:"false"`
+	ServiceAccount bool                    `json:"service_account" example:"false"`
+}
+
+// ExplainPolicySchema describes the action to explain. The subject, IP address and
+// time default to those of the caller; Policy is a draft policy document to evaluate
+// instead of the policy in force.
+type ExplainPolicySchema struct {
+	Subject     *PolicySubjectSchema `json:"subject,omitempty"`
+	Action      string               `json:"action" example:"data.access"`
+	KindId      int32                `json:"kind_id" example:"3"`
+	AccessLevel int32                `json:"access_level" example:"3"`
+	Owner       string               `json:"owner" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Operation   string               `json:"operation" example:"detokenize"`
+	Ip          string               `json:"ip" example:"10.0.0.15"`
+	Time        string               `json:"time" example:"2006-01-02T15:04:05Z07:00"`
+	Policy      string               `json:"policy,omitempty"`
+}
+
+type PolicyRuleResultSchema struct {
+	Name    string `json:"name" example:"clearance"`
+	Effect  string `json:"effect" example:"allow"`
+	Matched bool   `json:"matched" example:"true"`
+}
+
+type PolicyDecisionSchema struct {
+	Allowed   bool                      `json:"allowed" example:"true"`
+	DecidedBy string                    `json:"decided_by,omitempty" example:"clearance"`
+	Reason    string                    `json:"reason" example:"allowed by rule clearance"`
+	Version   string                    `json:"version" example:"3f2a9c01b7e4"`
+	Rules     []*PolicyRuleResultSchema `json:"rules"`
+}
+
+type PolicyErrorSchema struct {
+	Error   string `json:"error" example:"invalid policy"`
+	Details string `json:"details" example:"rule clearance: unknown attribute \"subject.level\" at 0"`
+}
@@ -0,0 +1,28 @@
+package schemas
+
+type DestructionEventSchema struct {
+	Id          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	MappingId   string `json:"mapping_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Token       string `json:"token" example:"fio_7f82a1c3"`
+	Reason      string `json:"reason" example:"ttl"` // "ttl" | "manual" | "subject_erasure" | "unknown"
+	Initiator   string `json:"initiator" example:"ttl"`
+	DestroyedAt string `json:"destroyed_at" example:"2006-01-02T15:04:05.999999999Z"`
+}
+
+type DestructionActGroupSchema struct {
+	Kind   *KindSchema               `json:"kind,omitempty"`
+	Total  int                       `json:"total" example:"12"`
+	Events []*DestructionEventSchema `json:"events"`
+}
+
+type DestructionActSchema struct {
+	PeriodFrom  string                       `json:"period_from" example:"2024-01-01T00:00:00Z"`
+	PeriodTo    string                       `json:"period_to" example:"2024-02-01T00:00:00Z"`
+	GeneratedAt string                       `json:"generated_at" example:"2006-01-02T15:04:05.999999999Z"`
+	GeneratedBy string                       `json:"generated_by" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Total       int32                        `json:"total" example:"12"`
+	Groups      []*DestructionActGroupSchema `json:"groups"`
+	Digest      string                       `json:"digest" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Signature   string                       `json:"signature" example:"vault:v1:MEUCIQ..."`
+	SigningKey  string                       `json:"signing_key" example:"my-sign-key"`
+}
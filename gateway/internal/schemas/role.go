@@ -0,0 +1,14 @@
+package schemas
+
+// RoleBodySchema describes a custom role: its name, description and the permissions it
+// grants.
+type RoleBodySchema struct {
+	Name        string   `json:"name" example:"analyst"`
+	Description string   `json:"description" example:"Просмотр маппингов и журнала аудита"`
+	Permissions []string `json:"permissions" example:"mappings.read,audit.read"`
+}
+
+type PermissionSchema struct {
+	Name        string `json:"name" example:"tokenizer.detokenize"`
+	Description string `json:"description" example:"Детокенизация данных"`
+}
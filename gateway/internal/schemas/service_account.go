@@ -0,0 +1,52 @@
+package schemas
+
+type CreateServiceAccountSchema struct {
+	Name        string `json:"name" example:"billing-batch"`
+	Description string `json:"description" example:"Ночная выгрузка биллинга"`
+}
+
+type ServiceAccountSchema struct {
+	Id          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string `json:"name" example:"billing-batch"`
+	Description string `json:"description" example:"Ночная выгрузка биллинга"`
+	CreatedBy   string `json:"created_by" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt   string `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+}
+
+// CreateApiKeySchema scopes a new API key. Without allowed_ips the key can be used from
+// any address.
+type CreateApiKeySchema struct {
+	Permissions   []string `json:"permissions" example:"tokenizer.tokenize"`
+	KindIds       []int32  `json:"kind_ids" example:"3"`
+	AllowedIps    []string `json:"allowed_ips" example:"10.0.0.0/8"`
+	ExpiresInDays int      `json:"expires_in_days" example:"90"`
+}
+
+type RotateApiKeySchema struct {
+	GraceHours int `json:"grace_hours" example:"24"`
+}
+
+type ApiKeySchema struct {
+	Id               string   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ServiceAccountId string   `json:"service_account_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Prefix           string   `json:"prefix" example:"anx_3f9a0c1b7d2e4f68"`
+	Permissions      []string `json:"permissions" example:"tokenizer.tokenize"`
+	KindIds          []int32  `json:"kind_ids" example:"3"`
+	AllowedIps       []string `json:"allowed_ips" example:"10.0.0.0/8"`
+	CreatedBy        string   `json:"created_by" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt        string   `json:"created_at" example:"2006-01-02T15:04:05Z07:00"`
+	ExpiresAt        string   `json:"expires_at" example:"2006-01-02T15:04:05Z07:00"`
+	LastUsedAt       string   `json:"last_used_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	LastUsedIp       string   `json:"last_used_ip,omitempty" example:"10.1.2.3"`
+	RevokedAt        string   `json:"revoked_at,omitempty" example:"2006-01-02T15:04:05Z07:00"`
+	RevokedBy        string   `json:"revoked_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Active           bool     `json:"active" example:"true"`
+}
+
+// IssuedApiKeySchema holds a new API key. The key is shown only once; previous is the
+// key it replaced on rotation.
+type IssuedApiKeySchema struct {
+	ApiKey   *ApiKeySchema `json:"api_key"`
+	Key      string        `json:"key" example:"anx_3f9a0c1b7d2e4f68_Vq3sN1k0yJ8xZp2bW7uHc4dR9mT6aE5fL0gQ1iS2oXo"`
+	Previous *ApiKeySchema `json:"previous,omitempty"`
+}
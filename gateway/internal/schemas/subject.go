@@ -0,0 +1,9 @@
+package schemas
+
+type ErasureReportSchema struct {
+	SubjectRef  string           `json:"subject_ref" example:"subj_4f1c9e"`
+	ErasedBy    string           `json:"erased_by" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ErasedAt    string           `json:"erased_at" example:"2006-01-02T15:04:05Z07:00"`
+	ErasedCount int              `json:"erased_count" example:"3"`
+	Erased      []*MappingSchema `json:"erased"`
+}
@@ -1,5 +1,7 @@
 package schemas
 
+import "time"
+
 type TokenizeSchema struct {
 	Plaintext     []byte `json:"plaintext"`
 	Deterministic bool   `json:"deterministic"`
@@ -7,10 +9,17 @@ type TokenizeSchema struct {
 	TokenTTL      int64  `json:"token_ttl"`
 	KindId        int    `json:"kind_id"`
 	Algorithm     string `json:"algorithm" example:"aes-siv"` // "" | "aes-siv" | "gost-kuznechik"
+	SubjectRef    string `json:"subject_ref,omitempty" example:"subj_4f1c9e"`
+
+	Purposes         []string   `json:"purposes,omitempty" example:"billing"`
+	LegalBasis       string     `json:"legal_basis,omitempty" example:"consent"`
+	ConsentRef       string     `json:"consent_ref,omitempty" example:"consent-2024-0117"`
+	ConsentExpiresAt *time.Time `json:"consent_expires_at,omitempty" example:"2030-01-02T15:04:05Z"`
 }
 
 type DetokenizeSchema struct {
-	Token string `json:"token"`
+	Token   string `json:"token"`
+	Purpose string `json:"purpose" example:"billing"`
 }
 
 type DetokenizeRespSchema struct {
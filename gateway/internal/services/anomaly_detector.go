@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/ports"
+	"math"
+	"strconv"
+	"time"
+)
+
+// AnomalyDetector watches the detokenization volume of every user in a sliding window:
+// in total, per access level and per kind. Each dimension has a baseline, the volume
+// expected from a user in one window; exceeding the baseline times thresholdFactor
+// blocks the user or requires re-authentication.
+type AnomalyDetector struct {
+	repo            ports.AnomalyRepository
+	window          time.Duration
+	baseline        int64
+	levelBaselines  map[int32]int64
+	kindBaselines   map[string]int64
+	thresholdFactor float64
+	action          string
+	blockDuration   time.Duration
+	failOpen        bool
+}
+
+// NewAnomalyDetector creates a detector. levelBaselines maps access levels and
+// kindBaselines kind names to their baselines; a baseline of 0 disables a dimension.
+// failOpen lets requests through while the detector cannot be reached.
+func NewAnomalyDetector(
+	repo ports.AnomalyRepository,
+	window time.Duration,
+	baseline int64,
+	levelBaselines map[string]string,
+	kindBaselines map[string]string,
+	thresholdFactor float64,
+	action string,
+	blockDuration time.Duration,
+	failOpen bool,
+) (*AnomalyDetector, error) {
+	if action != domain.AnomalyActionBlock && action != domain.AnomalyActionReauth {
+		return nil, fmt.Errorf("invalid anomaly action %q", action)
+	}
+	if window <= 0 || blockDuration <= 0 {
+		return nil, fmt.Errorf("anomaly window and block duration must be positive")
+	}
+	if thresholdFactor < 1 {
+		return nil, fmt.Errorf("anomaly threshold factor must be at least 1, got %v", thresholdFactor)
+	}
+
+	d := &AnomalyDetector{
+		repo:            repo,
+		window:          window,
+		baseline:        baseline,
+		levelBaselines:  make(map[int32]int64, len(levelBaselines)),
+		kindBaselines:   make(map[string]int64, len(kindBaselines)),
+		thresholdFactor: thresholdFactor,
+		action:          action,
+		blockDuration:   blockDuration,
+		failOpen:        failOpen,
+	}
+	for level, value := range levelBaselines {
+		l, err := strconv.ParseInt(level, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access level %q in anomaly baselines", level)
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("invalid baseline %q of access level %s", value, level)
+		}
+		d.levelBaselines[int32(l)] = v
+	}
+	for kind, value := range kindBaselines {
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("invalid baseline %q of kind %s", value, kind)
+		}
+		d.kindBaselines[kind] = v
+	}
+	return d, nil
+}
+
+// FailOpen reports whether requests are let through while the detector cannot be
+// reached.
+func (d *AnomalyDetector) FailOpen() bool {
+	return d.failOpen
+}
+
+func (d *AnomalyDetector) threshold(baseline int64) int64 {
+	return int64(math.Ceil(float64(baseline) * d.thresholdFactor))
+}
+
+// Observe counts a detokenization of a mapping of the kind and access level by the user.
+// If it takes the user over a threshold, the user is blocked and the block is returned.
+func (d *AnomalyDetector) Observe(ctx context.Context, userID string, kindName string, accessLevel int32) (*domain.AnomalyBlock, error) {
+	var dimensions []string
+	var thresholds []int64
+	if d.baseline > 0 {
+		dimensions = append(dimensions, "all")
+		thresholds = append(thresholds, d.threshold(d.baseline))
+	}
+	if baseline := d.levelBaselines[accessLevel]; baseline > 0 {
+		dimensions = append(dimensions, fmt.Sprintf("level:%d", accessLevel))
+		thresholds = append(thresholds, d.threshold(baseline))
+	}
+	if baseline := d.kindBaselines[kindName]; kindName != "" && baseline > 0 {
+		dimensions = append(dimensions, "kind:"+kindName)
+		thresholds = append(thresholds, d.threshold(baseline))
+	}
+	if len(dimensions) == 0 {
+		return nil, nil
+	}
+
+	counts, err := d.repo.CountDetokenize(ctx, userID, dimensions, d.window)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, count := range counts {
+		if count <= thresholds[i] {
+			continue
+		}
+		now := time.Now().UTC()
+		block := &domain.AnomalyBlock{
+			UserID:    userID,
+			Action:    d.action,
+			Dimension: dimensions[i],
+			Count:     count,
+			Threshold: thresholds[i],
+			Window:    d.window.String(),
+			CreatedAt: now,
+			ExpiresAt: now.Add(d.blockDuration),
+		}
+		if err = d.repo.SaveBlock(ctx, block); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}
+	return nil, nil
+}
+
+// GetBlock returns the active block of the user, or nil if there is none.
+func (d *AnomalyDetector) GetBlock(ctx context.Context, userID string) (*domain.AnomalyBlock, error) {
+	return d.repo.GetBlock(ctx, userID)
+}
+
+func (d *AnomalyDetector) ListBlocks(ctx context.Context) ([]*domain.AnomalyBlock, error) {
+	return d.repo.ListBlocks(ctx)
+}
+
+// Unblock lifts the block of the user and resets the counters, so the user is not
+// blocked again by the detokenizations that caused it.
+func (d *AnomalyDetector) Unblock(ctx context.Context, userID string) error {
+	if err := d.repo.DeleteBlock(ctx, userID); err != nil {
+		return err
+	}
+	return d.repo.ResetCounters(ctx, userID)
+}
+
+// Reauthenticated lifts a block requiring re-authentication after the user logged in
+// again. Blocks of the block action are kept.
+func (d *AnomalyDetector) Reauthenticated(ctx context.Context, userID string) error {
+	block, err := d.repo.GetBlock(ctx, userID)
+	if err != nil || block == nil || block.Action != domain.AnomalyActionReauth {
+		return err
+	}
+	return d.Unblock(ctx, userID)
+}
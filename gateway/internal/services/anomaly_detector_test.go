@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeAnomalyRepo counts detokenizations without a window: every observation stays
+// within it.
+type fakeAnomalyRepo struct {
+	counts map[string]int64
+	calls  int
+	blocks map[string]*domain.AnomalyBlock
+}
+
+func newFakeAnomalyRepo() *fakeAnomalyRepo {
+	return &fakeAnomalyRepo{counts: make(map[string]int64), blocks: make(map[string]*domain.AnomalyBlock)}
+}
+
+func (r *fakeAnomalyRepo) CountDetokenize(_ context.Context, userID string, keys []string, _ time.Duration) ([]int64, error) {
+	r.calls++
+	counts := make([]int64, len(keys))
+	for i, key := range keys {
+		r.counts[userID+":"+key]++
+		counts[i] = r.counts[userID+":"+key]
+	}
+	return counts, nil
+}
+
+func (r *fakeAnomalyRepo) ResetCounters(_ context.Context, userID string) error {
+	for key := range r.counts {
+		if strings.HasPrefix(key, userID+":") {
+			delete(r.counts, key)
+		}
+	}
+	return nil
+}
+
+func (r *fakeAnomalyRepo) SaveBlock(_ context.Context, block *domain.AnomalyBlock) error {
+	r.blocks[block.UserID] = block
+	return nil
+}
+
+func (r *fakeAnomalyRepo) GetBlock(_ context.Context, userID string) (*domain.AnomalyBlock, error) {
+	return r.blocks[userID], nil
+}
+
+func (r *fakeAnomalyRepo) ListBlocks(context.Context) ([]*domain.AnomalyBlock, error) {
+	blocks := make([]*domain.AnomalyBlock, 0, len(r.blocks))
+	for _, block := range r.blocks {
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func (r *fakeAnomalyRepo) DeleteBlock(_ context.Context, userID string) error {
+	delete(r.blocks, userID)
+	return nil
+}
+
+func TestNewAnomalyDetector(t *testing.T) {
+	tests := []struct {
+		name            string
+		levelBaselines  map[string]string
+		kindBaselines   map[string]string
+		thresholdFactor float64
+		action          string
+		window          time.Duration
+		wantErr         bool
+	}{
+		{"valid", map[string]string{"4": "20"}, map[string]string{"passport": "10"}, 1.5, domain.AnomalyActionBlock, time.Hour, false},
+		{"re-authentication", nil, nil, 1, domain.AnomalyActionReauth, time.Hour, false},
+		{"unknown action", nil, nil, 1.5, "alert", time.Hour, true},
+		{"factor below 1", nil, nil, 0.5, domain.AnomalyActionBlock, time.Hour, true},
+		{"no window", nil, nil, 1.5, domain.AnomalyActionBlock, 0, true},
+		{"invalid access level", map[string]string{"secret": "20"}, nil, 1.5, domain.AnomalyActionBlock, time.Hour, true},
+		{"negative level baseline", map[string]string{"4": "-1"}, nil, 1.5, domain.AnomalyActionBlock, time.Hour, true},
+		{"invalid kind baseline", nil, map[string]string{"passport": "many"}, 1.5, domain.AnomalyActionBlock, time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAnomalyDetector(newFakeAnomalyRepo(), tt.window, 100, tt.levelBaselines, tt.kindBaselines,
+				tt.thresholdFactor, tt.action, time.Hour, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnomalyDetector_Threshold(t *testing.T) {
+	tests := []struct {
+		baseline int64
+		factor   float64
+		want     int64
+	}{
+		{10, 1, 10},
+		{10, 1.5, 15},
+		// Fractional thresholds are rounded up, so a baseline is never undercut.
+		{3, 1.5, 5},
+		{1, 1.01, 2},
+	}
+
+	for _, tt := range tests {
+		d := &AnomalyDetector{thresholdFactor: tt.factor}
+		if got := d.threshold(tt.baseline); got != tt.want {
+			t.Errorf("threshold(%d) with factor %v: got %d, want %d", tt.baseline, tt.factor, got, tt.want)
+		}
+	}
+}
+
+func TestAnomalyDetector_Observe(t *testing.T) {
+	// Thresholds: 15 in total, 3 at access level 4 and 5 for passports.
+	tests := []struct {
+		name          string
+		baseline      int64
+		kindName      string
+		accessLevel   int32
+		wantBlockedAt int
+		wantDimension string
+		wantThreshold int64
+	}{
+		{"access level", 10, "fio", 4, 4, "level:4", 3},
+		{"kind", 10, "passport", 1, 6, "kind:passport", 5},
+		{"total", 10, "fio", 1, 16, "all", 15},
+		// Every dimension of the detokenization is counted; the lowest threshold blocks.
+		{"level and kind", 10, "passport", 4, 4, "level:4", 3},
+		{"no baseline", 0, "fio", 1, 0, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeAnomalyRepo()
+			d, err := NewAnomalyDetector(repo, time.Hour, tt.baseline,
+				map[string]string{"4": "2"}, map[string]string{"passport": "3"},
+				1.5, domain.AnomalyActionReauth, 15*time.Minute, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			for i := 1; i <= 20; i++ {
+				block, err := d.Observe(ctx, "user", tt.kindName, tt.accessLevel)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if block == nil {
+					continue
+				}
+				if i != tt.wantBlockedAt {
+					t.Fatalf("blocked at detokenization %d, want %d", i, tt.wantBlockedAt)
+				}
+				if block.Dimension != tt.wantDimension || block.Count != int64(i) || block.Threshold != tt.wantThreshold ||
+					block.Action != domain.AnomalyActionReauth || block.Window != "1h0m0s" ||
+					block.ExpiresAt.Sub(block.CreatedAt) != 15*time.Minute {
+					t.Errorf("got block %+v", block)
+				}
+				if saved, _ := d.GetBlock(ctx, "user"); saved != block {
+					t.Errorf("block not saved: %+v", saved)
+				}
+				return
+			}
+
+			if tt.wantBlockedAt != 0 {
+				t.Fatalf("not blocked, want a block at detokenization %d", tt.wantBlockedAt)
+			}
+			if repo.calls != 0 {
+				t.Errorf("counted %d detokenizations without a dimension", repo.calls)
+			}
+		})
+	}
+}
+
+func TestAnomalyDetector_Reauthenticated(t *testing.T) {
+	tests := []struct {
+		action   string
+		wantKept bool
+	}{
+		{domain.AnomalyActionReauth, false},
+		// Only an administrator lifts a block.
+		{domain.AnomalyActionBlock, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			repo := newFakeAnomalyRepo()
+			d, err := NewAnomalyDetector(repo, time.Hour, 1, nil, nil, 1, tt.action, time.Hour, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			for i := 0; i < 2; i++ {
+				if _, err = d.Observe(ctx, "user", "fio", 1); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err = d.Reauthenticated(ctx, "user"); err != nil {
+				t.Fatal(err)
+			}
+
+			block, _ := d.GetBlock(ctx, "user")
+			if kept := block != nil; kept != tt.wantKept {
+				t.Fatalf("block kept: %v, want %v", kept, tt.wantKept)
+			}
+			// Lifting the block resets the counters, so the next detokenization does not
+			// block the user again.
+			if !tt.wantKept {
+				if block, _ = d.Observe(ctx, "user", "fio", 1); block != nil {
+					t.Errorf("blocked again after re-authentication: %+v", block)
+				}
+			}
+		})
+	}
+}
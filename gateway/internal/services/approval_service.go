@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/ports"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// ApprovalService runs the four-eyes approval of sensitive operations: a configured
+// operation is held as a pending approval that an admin other than the requester must
+// approve within the window. Detokenization is held only for kinds of at least
+// detokenizeMinLevel. Decided approvals are kept for retention.
+type ApprovalService struct {
+	repo               ports.ApprovalRepository
+	operations         map[string]struct{}
+	detokenizeMinLevel int32
+	window             time.Duration
+	retention          time.Duration
+}
+
+func NewApprovalService(
+	repo ports.ApprovalRepository,
+	operations []string,
+	detokenizeMinLevel int32,
+	window time.Duration,
+	retention time.Duration,
+) (*ApprovalService, error) {
+	if window <= 0 || retention <= 0 {
+		return nil, fmt.Errorf("approval window and retention must be positive")
+	}
+
+	s := &ApprovalService{
+		repo:               repo,
+		operations:         make(map[string]struct{}, len(operations)),
+		detokenizeMinLevel: detokenizeMinLevel,
+		window:             window,
+		retention:          retention,
+	}
+	for _, operation := range operations {
+		if operation != "" {
+			s.operations[operation] = struct{}{}
+		}
+	}
+	return s, nil
+}
+
+// Operations returns the operations that require approval.
+func (s *ApprovalService) Operations() []string {
+	operations := make([]string, 0, len(s.operations))
+	for operation := range s.operations {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	return operations
+}
+
+// Requires reports whether the operation on data of the access level must be approved.
+// The level is 0 when it is not known yet.
+func (s *ApprovalService) Requires(operation string, accessLevel int32) bool {
+	if _, ok := s.operations[operation]; !ok {
+		return false
+	}
+	return operation != domain.AuditActionDetokenize || accessLevel >= s.detokenizeMinLevel
+}
+
+// ExecutesOnApproval reports whether the operation is executed as soon as it is
+// approved. Detokenization is executed only when its requester asks for it, so that the
+// plaintext is returned to the requester and never to the approver.
+func (s *ApprovalService) ExecutesOnApproval(operation string) bool {
+	return operation != domain.AuditActionDetokenize
+}
+
+// Create saves the approval as pending. Its ID is generated unless already set.
+func (s *ApprovalService) Create(ctx context.Context, approval *domain.Approval) error {
+	if approval.ID == "" {
+		approval.ID = uuid.NewString()
+	}
+	approval.Status = domain.ApprovalStatusPending
+	approval.CreatedAt = time.Now().UTC()
+	approval.ExpiresAt = approval.CreatedAt.Add(s.window)
+	return s.repo.CreateApproval(ctx, approval, s.window+s.retention)
+}
+
+// Get returns the approval; an overdue one is returned as expired.
+func (s *ApprovalService) Get(ctx context.Context, id string) (*domain.Approval, error) {
+	approval, err := s.repo.GetApproval(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, errs.ErrApprovalNotFound
+	}
+	markOverdue(approval)
+	return approval, nil
+}
+
+// getPending returns the approval if it is pending.
+func (s *ApprovalService) getPending(ctx context.Context, id string) (*domain.Approval, error) {
+	approval, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch approval.Status {
+	case domain.ApprovalStatusPending:
+		return approval, nil
+	case domain.ApprovalStatusExpired:
+		return nil, errs.ErrApprovalExpired
+	default:
+		return nil, errs.ErrApprovalNotPending
+	}
+}
+
+// List returns the approvals, newest first, optionally only those of the status or of
+// the requester.
+func (s *ApprovalService) List(ctx context.Context, status, requesterID string) ([]*domain.Approval, error) {
+	all, err := s.repo.ListApprovals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := make([]*domain.Approval, 0, len(all))
+	for _, approval := range all {
+		markOverdue(approval)
+		if (status == "" || approval.Status == status) && (requesterID == "" || approval.RequesterID == requesterID) {
+			approvals = append(approvals, approval)
+		}
+	}
+	sort.Slice(approvals, func(i, j int) bool { return approvals[i].CreatedAt.After(approvals[j].CreatedAt) })
+	return approvals, nil
+}
+
+// Approve approves the pending approval by an approver other than its requester. An
+// operation not executed on approval may then be executed by its requester within the
+// window.
+func (s *ApprovalService) Approve(ctx context.Context, id, approverID string) (*domain.Approval, error) {
+	approval, err := s.getPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval.RequesterID == approverID {
+		return nil, errs.ErrSelfApproval
+	}
+
+	now := time.Now().UTC()
+	approval.Status = domain.ApprovalStatusApproved
+	approval.ApproverID = approverID
+	approval.DecidedAt = &now
+	if !s.ExecutesOnApproval(approval.Operation) {
+		approval.ExpiresAt = now.Add(s.window)
+	}
+	if err = s.update(ctx, approval, domain.ApprovalStatusPending, errs.ErrApprovalNotPending); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+// Reject rejects the pending approval.
+func (s *ApprovalService) Reject(ctx context.Context, id, approverID, reason string) (*domain.Approval, error) {
+	approval, err := s.getPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	approval.Status = domain.ApprovalStatusRejected
+	approval.ApproverID = approverID
+	approval.RejectReason = reason
+	approval.DecidedAt = &now
+	if err = s.update(ctx, approval, domain.ApprovalStatusPending, errs.ErrApprovalNotPending); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+// MarkExecuted marks the approved approval as executed, so it is executed only once.
+func (s *ApprovalService) MarkExecuted(ctx context.Context, approval *domain.Approval) error {
+	if approval.Status == domain.ApprovalStatusExpired {
+		return errs.ErrApprovalExpired
+	}
+
+	now := time.Now().UTC()
+	approval.Status = domain.ApprovalStatusExecuted
+	approval.ExecutedAt = &now
+	return s.update(ctx, approval, domain.ApprovalStatusApproved, errs.ErrApprovalNotApproved)
+}
+
+// SetResult records the HTTP status of the executed operation.
+func (s *ApprovalService) SetResult(ctx context.Context, approval *domain.Approval, code int) error {
+	approval.ResultCode = code
+	return s.update(ctx, approval, domain.ApprovalStatusExecuted, errs.ErrApprovalNotFound)
+}
+
+// ExpireOverdue stores the approvals not approved or executed in time as expired and
+// returns them.
+func (s *ApprovalService) ExpireOverdue(ctx context.Context) ([]*domain.Approval, error) {
+	all, err := s.repo.ListApprovals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []*domain.Approval
+	for _, approval := range all {
+		from, ok := markOverdue(approval)
+		if !ok {
+			continue
+		}
+		// An approval decided concurrently is left as decided.
+		if ok, err = s.repo.UpdateApproval(ctx, approval, from, s.retention); err != nil {
+			return nil, err
+		}
+		if ok {
+			expired = append(expired, approval)
+		}
+	}
+	return expired, nil
+}
+
+// markOverdue shows the approval as expired if it is open past its deadline and returns
+// its previous status and whether it did. The stored approval is expired by ExpireOverdue, so that the expiry is
+// audited.
+func markOverdue(approval *domain.Approval) (string, bool) {
+	if !approval.IsOpen() || time.Now().Before(approval.ExpiresAt) {
+		return "", false
+	}
+	from := approval.Status
+	approval.Status = domain.ApprovalStatusExpired
+	return from, true
+}
+
+// update saves the approval if its stored status is still from and returns conflict
+// otherwise. Open approvals are kept until their deadline, decided ones for retention.
+func (s *ApprovalService) update(ctx context.Context, approval *domain.Approval, from string, conflict error) error {
+	ttl := s.retention
+	if approval.IsOpen() {
+		ttl += time.Until(approval.ExpiresAt)
+	}
+
+	ok, err := s.repo.UpdateApproval(ctx, approval, from, ttl)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return conflict
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"testing"
+	"time"
+)
+
+// fakeApprovalRepo keeps copies of the approvals, as the Redis adapter keeps them
+// serialized, so changes reach it only through UpdateApproval.
+type fakeApprovalRepo struct {
+	approvals map[string]domain.Approval
+}
+
+func newFakeApprovalRepo() *fakeApprovalRepo {
+	return &fakeApprovalRepo{approvals: make(map[string]domain.Approval)}
+}
+
+func (r *fakeApprovalRepo) CreateApproval(_ context.Context, approval *domain.Approval, _ time.Duration) error {
+	r.approvals[approval.ID] = *approval
+	return nil
+}
+
+func (r *fakeApprovalRepo) GetApproval(_ context.Context, id string) (*domain.Approval, error) {
+	approval, ok := r.approvals[id]
+	if !ok {
+		return nil, nil
+	}
+	return &approval, nil
+}
+
+func (r *fakeApprovalRepo) ListApprovals(context.Context) ([]*domain.Approval, error) {
+	approvals := make([]*domain.Approval, 0, len(r.approvals))
+	for _, approval := range r.approvals {
+		approvals = append(approvals, &approval)
+	}
+	return approvals, nil
+}
+
+func (r *fakeApprovalRepo) UpdateApproval(_ context.Context, approval *domain.Approval, from string, _ time.Duration) (bool, error) {
+	if stored, ok := r.approvals[approval.ID]; !ok || stored.Status != from {
+		return false, nil
+	}
+	r.approvals[approval.ID] = *approval
+	return true, nil
+}
+
+// overdue moves the deadline of the stored approval into the past.
+func (r *fakeApprovalRepo) overdue(id string) {
+	approval := r.approvals[id]
+	approval.ExpiresAt = time.Now().Add(-time.Second)
+	r.approvals[id] = approval
+}
+
+func newApprovalService(t *testing.T, repo *fakeApprovalRepo) *ApprovalService {
+	t.Helper()
+	s, err := NewApprovalService(repo, []string{domain.AuditActionRotateDeks, domain.AuditActionDetokenize},
+		4, time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func createApproval(t *testing.T, s *ApprovalService, operation string) *domain.Approval {
+	t.Helper()
+	approval := &domain.Approval{Operation: operation, RequesterID: "requester"}
+	if err := s.Create(context.Background(), approval); err != nil {
+		t.Fatal(err)
+	}
+	return approval
+}
+
+func TestApprovalService_Requires(t *testing.T) {
+	s := newApprovalService(t, newFakeApprovalRepo())
+
+	tests := []struct {
+		operation   string
+		accessLevel int32
+		want        bool
+	}{
+		{domain.AuditActionRotateDeks, 0, true},
+		{domain.AuditActionDetokenize, 4, true},
+		{domain.AuditActionDetokenize, 3, false},
+		{domain.AuditActionKindDelete, 0, false},
+	}
+	for _, tt := range tests {
+		if got := s.Requires(tt.operation, tt.accessLevel); got != tt.want {
+			t.Errorf("Requires(%s, %d): got %v, want %v", tt.operation, tt.accessLevel, got, tt.want)
+		}
+	}
+}
+
+func TestApprovalService_Approve(t *testing.T) {
+	ctx := context.Background()
+	s := newApprovalService(t, newFakeApprovalRepo())
+	approval := createApproval(t, s, domain.AuditActionRotateDeks)
+
+	if _, err := s.Approve(ctx, approval.ID, "requester"); !errors.Is(err, errs.ErrSelfApproval) {
+		t.Fatalf("self-approval: got %v, want ErrSelfApproval", err)
+	}
+
+	approved, err := s.Approve(ctx, approval.ID, "approver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approved.Status != domain.ApprovalStatusApproved || approved.ApproverID != "approver" || approved.DecidedAt == nil {
+		t.Errorf("got %+v", approved)
+	}
+
+	// A decision is taken once.
+	if _, err = s.Approve(ctx, approval.ID, "other approver"); !errors.Is(err, errs.ErrApprovalNotPending) {
+		t.Errorf("second approval: got %v, want ErrApprovalNotPending", err)
+	}
+	if _, err = s.Reject(ctx, approval.ID, "other approver", ""); !errors.Is(err, errs.ErrApprovalNotPending) {
+		t.Errorf("rejection after approval: got %v, want ErrApprovalNotPending", err)
+	}
+
+	// The operation is executed once.
+	if err = s.MarkExecuted(ctx, approved); err != nil {
+		t.Fatal(err)
+	}
+	again, _ := s.Get(ctx, approval.ID)
+	if err = s.MarkExecuted(ctx, again); !errors.Is(err, errs.ErrApprovalNotApproved) {
+		t.Errorf("second execution: got %v, want ErrApprovalNotApproved", err)
+	}
+}
+
+func TestApprovalService_Window(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not approved in time", func(t *testing.T) {
+		repo := newFakeApprovalRepo()
+		s := newApprovalService(t, repo)
+		approval := createApproval(t, s, domain.AuditActionRotateDeks)
+		repo.overdue(approval.ID)
+
+		if _, err := s.Approve(ctx, approval.ID, "approver"); !errors.Is(err, errs.ErrApprovalExpired) {
+			t.Fatalf("got %v, want ErrApprovalExpired", err)
+		}
+		if got, _ := s.Get(ctx, approval.ID); got.Status != domain.ApprovalStatusExpired {
+			t.Errorf("got status %s, want expired", got.Status)
+		}
+
+		// The stored approval is expired once, so its expiry is audited once.
+		expired, err := s.ExpireOverdue(ctx)
+		if err != nil || len(expired) != 1 || expired[0].ID != approval.ID {
+			t.Fatalf("got %v, %v", expired, err)
+		}
+		if repo.approvals[approval.ID].Status != domain.ApprovalStatusExpired {
+			t.Errorf("stored with status %s", repo.approvals[approval.ID].Status)
+		}
+		if expired, err = s.ExpireOverdue(ctx); err != nil || len(expired) != 0 {
+			t.Errorf("expired again: %v, %v", expired, err)
+		}
+	})
+
+	t.Run("not executed in time", func(t *testing.T) {
+		repo := newFakeApprovalRepo()
+		s := newApprovalService(t, repo)
+		approval := createApproval(t, s, domain.AuditActionDetokenize)
+
+		// A detokenization is executed by its requester, who gets a new window for it.
+		approved, err := s.Approve(ctx, approval.ID, "approver")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if approved.ExpiresAt.Before(approval.ExpiresAt) {
+			t.Errorf("execution window ends at %v, before the approval window %v", approved.ExpiresAt, approval.ExpiresAt)
+		}
+
+		repo.overdue(approval.ID)
+		overdue, err := s.Get(ctx, approval.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = s.MarkExecuted(ctx, overdue); !errors.Is(err, errs.ErrApprovalExpired) {
+			t.Errorf("got %v, want ErrApprovalExpired", err)
+		}
+	})
+
+	t.Run("decided in time", func(t *testing.T) {
+		repo := newFakeApprovalRepo()
+		s := newApprovalService(t, repo)
+		approval := createApproval(t, s, domain.AuditActionRotateDeks)
+		if _, err := s.Reject(ctx, approval.ID, "approver", "not now"); err != nil {
+			t.Fatal(err)
+		}
+
+		// A decided approval does not expire.
+		repo.overdue(approval.ID)
+		if expired, err := s.ExpireOverdue(ctx); err != nil || len(expired) != 0 {
+			t.Errorf("got %v, %v", expired, err)
+		}
+	})
+}
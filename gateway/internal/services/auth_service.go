@@ -234,3 +234,402 @@ func (a *AuthService) GetRolesList(ctx context.Context, req *auth_service.GetRol
 
 	return <-resultChan, nil
 }
+
+func (a *AuthService) IssueAccessToken(ctx context.Context, req *auth_service.IssueAccessTokenRequest) (*auth_service.IssueAccessTokenResponse, error) {
+	resultChan := make(chan *auth_service.IssueAccessTokenResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.IssueAccessToken(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call IssueAccessToken: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) GetPermissions(ctx context.Context, req *auth_service.GetPermissionsRequest) (*auth_service.GetPermissionsResponse, error) {
+	resultChan := make(chan *auth_service.GetPermissionsResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.GetPermissions(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call GetPermissions: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) CreateRole(ctx context.Context, req *auth_service.CreateRoleRequest) (*auth_service.CreateRoleResponse, error) {
+	resultChan := make(chan *auth_service.CreateRoleResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.CreateRole(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CreateRole: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) UpdateRole(ctx context.Context, req *auth_service.UpdateRoleRequest) (*auth_service.UpdateRoleResponse, error) {
+	resultChan := make(chan *auth_service.UpdateRoleResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.UpdateRole(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call UpdateRole: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) DeleteRole(ctx context.Context, req *auth_service.DeleteRoleRequest) (*auth_service.DeleteRoleResponse, error) {
+	resultChan := make(chan *auth_service.DeleteRoleResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.DeleteRole(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call DeleteRole: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) CreateAccessGrant(ctx context.Context, req *auth_service.CreateAccessGrantRequest) (*auth_service.CreateAccessGrantResponse, error) {
+	resultChan := make(chan *auth_service.CreateAccessGrantResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.CreateAccessGrant(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CreateAccessGrant: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) RevokeAccessGrant(ctx context.Context, req *auth_service.RevokeAccessGrantRequest) (*auth_service.RevokeAccessGrantResponse, error) {
+	resultChan := make(chan *auth_service.RevokeAccessGrantResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.RevokeAccessGrant(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call RevokeAccessGrant: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) GetAccessGrants(ctx context.Context, req *auth_service.GetAccessGrantsRequest) (*auth_service.GetAccessGrantsResponse, error) {
+	resultChan := make(chan *auth_service.GetAccessGrantsResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.GetAccessGrants(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call GetAccessGrants: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) SetKindOperationGrant(ctx context.Context, req *auth_service.SetKindOperationGrantRequest) (*auth_service.SetKindOperationGrantResponse, error) {
+	resultChan := make(chan *auth_service.SetKindOperationGrantResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.SetKindOperationGrant(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call SetKindOperationGrant: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) DeleteKindOperationGrant(ctx context.Context, req *auth_service.DeleteKindOperationGrantRequest) (*auth_service.DeleteKindOperationGrantResponse, error) {
+	resultChan := make(chan *auth_service.DeleteKindOperationGrantResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.DeleteKindOperationGrant(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call DeleteKindOperationGrant: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) GetKindOperationGrants(ctx context.Context, req *auth_service.GetKindOperationGrantsRequest) (*auth_service.GetKindOperationGrantsResponse, error) {
+	resultChan := make(chan *auth_service.GetKindOperationGrantsResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.GetKindOperationGrants(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call GetKindOperationGrants: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) CreateServiceAccount(ctx context.Context, req *auth_service.CreateServiceAccountRequest) (*auth_service.CreateServiceAccountResponse, error) {
+	resultChan := make(chan *auth_service.CreateServiceAccountResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.CreateServiceAccount(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CreateServiceAccount: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) DeleteServiceAccount(ctx context.Context, req *auth_service.DeleteServiceAccountRequest) (*auth_service.DeleteServiceAccountResponse, error) {
+	resultChan := make(chan *auth_service.DeleteServiceAccountResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.DeleteServiceAccount(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call DeleteServiceAccount: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) GetServiceAccounts(ctx context.Context, req *auth_service.GetServiceAccountsRequest) (*auth_service.GetServiceAccountsResponse, error) {
+	resultChan := make(chan *auth_service.GetServiceAccountsResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.GetServiceAccounts(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call GetServiceAccounts: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) CreateApiKey(ctx context.Context, req *auth_service.CreateApiKeyRequest) (*auth_service.CreateApiKeyResponse, error) {
+	resultChan := make(chan *auth_service.CreateApiKeyResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.CreateApiKey(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CreateApiKey: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) RotateApiKey(ctx context.Context, req *auth_service.RotateApiKeyRequest) (*auth_service.RotateApiKeyResponse, error) {
+	resultChan := make(chan *auth_service.RotateApiKeyResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.RotateApiKey(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call RotateApiKey: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) RevokeApiKey(ctx context.Context, req *auth_service.RevokeApiKeyRequest) (*auth_service.RevokeApiKeyResponse, error) {
+	resultChan := make(chan *auth_service.RevokeApiKeyResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.RevokeApiKey(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call RevokeApiKey: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) GetApiKeys(ctx context.Context, req *auth_service.GetApiKeysRequest) (*auth_service.GetApiKeysResponse, error) {
+	resultChan := make(chan *auth_service.GetApiKeysResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.GetApiKeys(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call GetApiKeys: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) AuthenticateApiKey(ctx context.Context, req *auth_service.AuthenticateApiKeyRequest) (*auth_service.AuthenticateApiKeyResponse, error) {
+	resultChan := make(chan *auth_service.AuthenticateApiKeyResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.AuthenticateApiKey(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call AuthenticateApiKey: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) IssueClientToken(ctx context.Context, req *auth_service.IssueClientTokenRequest) (*auth_service.IssueClientTokenResponse, error) {
+	resultChan := make(chan *auth_service.IssueClientTokenResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.IssueClientToken(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call IssueClientToken: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (a *AuthService) LoginExternal(ctx context.Context, req *auth_service.LoginExternalRequest) (*auth_service.LoginExternalResponse, error) {
+	resultChan := make(chan *auth_service.LoginExternalResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := a.AuthServiceRepo.LoginExternal(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, a.MaxRetries, a.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call LoginExternal: %w", err)
+	}
+
+	return <-resultChan, nil
+}
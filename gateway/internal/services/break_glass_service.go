@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/ports"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// BreakGlassService runs the emergency access of users to data above their clearance
+// level. A session lasts at most maxDuration and needs a justification of at least
+// minJustificationLength characters. Sessions and their operations are kept for
+// retention after they expire, until an auditor reviews them.
+type BreakGlassService struct {
+	repo                   ports.BreakGlassRepository
+	maxDuration            time.Duration
+	minJustificationLength int
+	retention              time.Duration
+}
+
+func NewBreakGlassService(
+	repo ports.BreakGlassRepository,
+	maxDuration time.Duration,
+	minJustificationLength int,
+	retention time.Duration,
+) (*BreakGlassService, error) {
+	if maxDuration <= 0 || retention <= 0 {
+		return nil, fmt.Errorf("break-glass max duration and retention must be positive")
+	}
+
+	return &BreakGlassService{
+		repo:                   repo,
+		maxDuration:            maxDuration,
+		minJustificationLength: minJustificationLength,
+		retention:              retention,
+	}, nil
+}
+
+func (s *BreakGlassService) MaxDuration() time.Duration {
+	return s.maxDuration
+}
+
+func (s *BreakGlassService) MinJustificationLength() int {
+	return s.minJustificationLength
+}
+
+// Start starts the session of the user for the duration. Its ID is generated unless
+// already set. It returns errs.ErrBreakGlassActive if the user already has an active
+// session.
+func (s *BreakGlassService) Start(ctx context.Context, session *domain.BreakGlassSession, duration time.Duration) error {
+	if duration <= 0 || duration > s.maxDuration {
+		return fmt.Errorf("break-glass duration must be positive and at most %s", s.maxDuration)
+	}
+	if session.ID == "" {
+		session.ID = uuid.NewString()
+	}
+	session.StartedAt = time.Now().UTC()
+	session.ExpiresAt = session.StartedAt.Add(duration)
+	return s.repo.CreateSession(ctx, session, duration+s.retention)
+}
+
+// Get returns the session with its operation count.
+func (s *BreakGlassService) Get(ctx context.Context, id string) (*domain.BreakGlassSession, error) {
+	session, err := s.repo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errs.ErrBreakGlassNotFound
+	}
+	return session, nil
+}
+
+// Active returns the session if it is an active session of the user, or nil.
+func (s *BreakGlassService) Active(ctx context.Context, id, userID string) (*domain.BreakGlassSession, error) {
+	session, err := s.repo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.UserID != userID || !session.IsActive(time.Now()) {
+		return nil, nil
+	}
+	return session, nil
+}
+
+// ActiveForUser returns the active session of the user, or nil.
+func (s *BreakGlassService) ActiveForUser(ctx context.Context, userID string) (*domain.BreakGlassSession, error) {
+	id, err := s.repo.GetActiveSessionID(ctx, userID)
+	if err != nil || id == "" {
+		return nil, err
+	}
+	return s.Active(ctx, id, userID)
+}
+
+// End ends the active session of the user before it expires.
+func (s *BreakGlassService) End(ctx context.Context, session *domain.BreakGlassSession) error {
+	now := time.Now().UTC()
+	if !session.IsActive(now) {
+		return errs.ErrBreakGlassNotActive
+	}
+
+	session.EndedAt = &now
+	if err := s.repo.UpdateSession(ctx, session); err != nil {
+		return err
+	}
+	return s.repo.ClearActiveSession(ctx, session.UserID, session.ID)
+}
+
+// RecordOperation adds the audited operation to the session for review.
+func (s *BreakGlassService) RecordOperation(ctx context.Context, id string, operation *domain.BreakGlassOperation) error {
+	operation.At = time.Now().UTC()
+	return s.repo.AddOperation(ctx, id, operation)
+}
+
+// Operations returns the operations performed during the session, oldest first.
+func (s *BreakGlassService) Operations(ctx context.Context, id string) ([]*domain.BreakGlassOperation, error) {
+	return s.repo.ListOperations(ctx, id)
+}
+
+// List returns the sessions, newest first, optionally only those not yet acknowledged
+// by an auditor.
+func (s *BreakGlassService) List(ctx context.Context, unacknowledgedOnly bool) ([]*domain.BreakGlassSession, error) {
+	all, err := s.repo.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*domain.BreakGlassSession, 0, len(all))
+	for _, session := range all {
+		if !unacknowledgedOnly || session.AcknowledgedAt == nil {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartedAt.After(sessions[j].StartedAt) })
+	return sessions, nil
+}
+
+// Acknowledge marks the session reviewed by an auditor other than its user. Only
+// sessions that are over can be acknowledged, so that no operation is left unreviewed.
+func (s *BreakGlassService) Acknowledge(ctx context.Context, id, auditorID string) (*domain.BreakGlassSession, error) {
+	session, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID == auditorID {
+		return nil, errs.ErrBreakGlassSelfReview
+	}
+	if session.IsActive(time.Now()) {
+		return nil, errs.ErrBreakGlassActive
+	}
+
+	now := time.Now().UTC()
+	session.AcknowledgedBy = auditorID
+	session.AcknowledgedAt = &now
+	if err = s.repo.UpdateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
@@ -353,3 +353,215 @@ func (s *MappingService) GetAuditLogList(ctx context.Context, req *mapping.GetAu
 
 	return <-resultChan, nil
 }
+
+// ExportAuditLog is not retried: entries already passed to fn would be repeated.
+func (s *MappingService) ExportAuditLog(
+	ctx context.Context,
+	req *mapping.ExportAuditLogRequest,
+	fn func(entry *mapping.AuditLogEntry) error,
+) error {
+	if err := s.MappingServiceRepo.ExportAuditLog(ctx, req, fn); err != nil {
+		return fmt.Errorf("couldn't call ExportAuditLog: %w", err)
+	}
+	return nil
+}
+
+func (s *MappingService) ListSubjectMappings(ctx context.Context, req *mapping.ListSubjectMappingsRequest) (
+	*mapping.ListSubjectMappingsResponse, error) {
+	resultChan := make(chan *mapping.ListSubjectMappingsResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.ListSubjectMappings(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call ListSubjectMappings: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) EraseSubject(ctx context.Context, req *mapping.EraseSubjectRequest) (
+	*mapping.EraseSubjectResponse, error) {
+	resultChan := make(chan *mapping.EraseSubjectResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.EraseSubject(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call EraseSubject: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) CreatePurpose(ctx context.Context, req *mapping.CreatePurposeRequest) (
+	*mapping.CreatePurposeResponse, error) {
+	resultChan := make(chan *mapping.CreatePurposeResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.CreatePurpose(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CreatePurpose: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) ListPurposes(ctx context.Context, req *mapping.ListPurposesRequest) (
+	*mapping.ListPurposesResponse, error) {
+	resultChan := make(chan *mapping.ListPurposesResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.ListPurposes(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call ListPurposes: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) DeletePurpose(ctx context.Context, req *mapping.DeletePurposeRequest) (
+	*mapping.DeletePurposeResponse, error) {
+	resultChan := make(chan *mapping.DeletePurposeResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.DeletePurpose(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call DeletePurpose: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) CreateLegalHold(ctx context.Context, req *mapping.CreateLegalHoldRequest) (
+	*mapping.CreateLegalHoldResponse, error) {
+	resultChan := make(chan *mapping.CreateLegalHoldResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.CreateLegalHold(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CreateLegalHold: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) ListLegalHolds(ctx context.Context, req *mapping.ListLegalHoldsRequest) (
+	*mapping.ListLegalHoldsResponse, error) {
+	resultChan := make(chan *mapping.ListLegalHoldsResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.ListLegalHolds(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call ListLegalHolds: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) ReleaseLegalHold(ctx context.Context, req *mapping.ReleaseLegalHoldRequest) (
+	*mapping.ReleaseLegalHoldResponse, error) {
+	resultChan := make(chan *mapping.ReleaseLegalHoldResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.ReleaseLegalHold(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call ReleaseLegalHold: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) GetDestructionAct(ctx context.Context, req *mapping.GetDestructionActRequest) (
+	*mapping.GetDestructionActResponse, error) {
+	resultChan := make(chan *mapping.GetDestructionActResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.GetDestructionAct(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call GetDestructionAct: %w", err)
+	}
+
+	return <-resultChan, nil
+}
+
+func (s *MappingService) VerifyAuditLog(ctx context.Context, req *mapping.VerifyAuditLogRequest) (
+	*mapping.VerifyAuditLogResponse, error) {
+	resultChan := make(chan *mapping.VerifyAuditLogResponse, 1)
+
+	err := callers.Retry(func() error {
+		resp, err := s.MappingServiceRepo.VerifyAuditLog(ctx, req)
+		if err != nil {
+			return err
+		}
+		resultChan <- resp
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call VerifyAuditLog: %w", err)
+	}
+
+	return <-resultChan, nil
+}
@@ -0,0 +1,144 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Clearance levels a mapping may grant, as in the auth service.
+const (
+	minClearance = 1
+	maxClearance = 4
+)
+
+// Identity is the user an ID token signs in: the subject at the provider, the login it
+// gets in anonix and the roles and clearance level mapped from its groups.
+type Identity struct {
+	Subject   string
+	Login     string
+	Groups    []string
+	Roles     []string
+	Clearance int
+}
+
+// ClaimMapping maps the claims of an ID token to an Identity. Claims are named as in the
+// token, or by a dotted path into nested claims such as "realm_access.roles".
+type ClaimMapping struct {
+	loginClaim       string
+	groupsClaim      string
+	roleMapping      map[string]string
+	clearanceMapping map[string]int
+	defaultRoles     []string
+	defaultClearance int
+}
+
+// NewClaimMapping returns the mapping. Users get defaultRoles and, for each of their
+// groups, the role roleMapping maps it to; their clearance level is the highest of
+// defaultClearance and the levels clearanceMapping maps their groups to. Clearance
+// levels are written as numbers from 1 to 4.
+func NewClaimMapping(
+	loginClaim,
+	groupsClaim string,
+	roleMapping,
+	clearanceMapping map[string]string,
+	defaultRoles []string,
+	defaultClearance int) (*ClaimMapping, error) {
+	if defaultClearance < minClearance || defaultClearance > maxClearance {
+		return nil, fmt.Errorf("invalid default clearance level %d", defaultClearance)
+	}
+
+	levels := make(map[string]int, len(clearanceMapping))
+	for group, value := range clearanceMapping {
+		level, err := strconv.Atoi(value)
+		if err != nil || level < minClearance || level > maxClearance {
+			return nil, fmt.Errorf("invalid clearance level %q of group %q", value, group)
+		}
+		levels[group] = level
+	}
+
+	if loginClaim == "" {
+		loginClaim = "sub"
+	}
+	return &ClaimMapping{
+		loginClaim:       loginClaim,
+		groupsClaim:      groupsClaim,
+		roleMapping:      roleMapping,
+		clearanceMapping: levels,
+		defaultRoles:     defaultRoles,
+		defaultClearance: defaultClearance,
+	}, nil
+}
+
+// Map returns the identity of the claims. The login falls back to the subject if the
+// login claim is missing.
+func (m *ClaimMapping) Map(claims jwt.MapClaims) (*Identity, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("ID token has no subject")
+	}
+
+	identity := &Identity{Subject: subject, Clearance: m.defaultClearance}
+	if logins := claimStrings(claims, m.loginClaim); len(logins) > 0 && logins[0] != "" {
+		identity.Login = logins[0]
+	} else {
+		identity.Login = subject
+	}
+
+	roles := slices.Clone(m.defaultRoles)
+	if m.groupsClaim != "" {
+		identity.Groups = claimStrings(claims, m.groupsClaim)
+	}
+	for _, group := range identity.Groups {
+		if role, ok := m.roleMapping[group]; ok {
+			roles = append(roles, role)
+		}
+		if level, ok := m.clearanceMapping[group]; ok && level > identity.Clearance {
+			identity.Clearance = level
+		}
+	}
+	slices.Sort(roles)
+	identity.Roles = slices.Compact(roles)
+	return identity, nil
+}
+
+// claimStrings returns the claim name as a list of strings: a string claim is a list of
+// one. The claim is looked up by its full name first, since names such as
+// "https://example.com/groups" contain dots themselves.
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	value, ok := claims[name]
+	if !ok {
+		value = lookupPath(claims, name)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func lookupPath(claims map[string]any, path string) any {
+	var value any = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if value, ok = object[part]; !ok {
+			return nil
+		}
+	}
+	return value
+}
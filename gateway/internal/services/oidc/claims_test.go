@@ -0,0 +1,76 @@
+package oidc
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"slices"
+	"testing"
+)
+
+func TestClaimMapping_Map(t *testing.T) {
+	mapping, err := NewClaimMapping("preferred_username", "realm_access.roles",
+		map[string]string{"anonix-admins": "admin", "anonix-operators": "operator"},
+		map[string]string{"anonix-admins": "4", "dpo": "3"},
+		[]string{"viewer"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		claims        jwt.MapClaims
+		wantLogin     string
+		wantRoles     []string
+		wantClearance int
+	}{
+		{"nested groups", jwt.MapClaims{
+			"sub": "1", "preferred_username": "ivanov",
+			"realm_access": map[string]any{"roles": []any{"anonix-admins", "anonix-operators", "dpo", "other"}},
+		}, "ivanov", []string{"admin", "operator", "viewer"}, 4},
+		{"single group", jwt.MapClaims{
+			"sub": "1", "preferred_username": "petrov",
+			"realm_access": map[string]any{"roles": "dpo"},
+		}, "petrov", []string{"viewer"}, 3},
+		{"no groups", jwt.MapClaims{"sub": "1", "preferred_username": "sidorov"}, "sidorov", []string{"viewer"}, 1},
+		{"login falls back to subject", jwt.MapClaims{"sub": "f3a1c2"}, "f3a1c2", []string{"viewer"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := mapping.Map(tt.claims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identity.Login != tt.wantLogin || !slices.Equal(identity.Roles, tt.wantRoles) || identity.Clearance != tt.wantClearance {
+				t.Fatalf("got %s %v %d, want %s %v %d", identity.Login, identity.Roles, identity.Clearance,
+					tt.wantLogin, tt.wantRoles, tt.wantClearance)
+			}
+		})
+	}
+
+	if _, err = mapping.Map(jwt.MapClaims{"preferred_username": "ivanov"}); err == nil {
+		t.Fatal("claims without a subject mapped")
+	}
+}
+
+func TestClaimMapping_DottedClaimName(t *testing.T) {
+	mapping, err := NewClaimMapping("", "https://anonix.example/groups",
+		map[string]string{"ops": "operator"}, nil, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := mapping.Map(jwt.MapClaims{"sub": "1", "https://anonix.example/groups": []any{"ops"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(identity.Roles, []string{"operator"}) || identity.Login != "1" {
+		t.Fatalf("got %+v", identity)
+	}
+}
+
+func TestNewClaimMapping_InvalidClearance(t *testing.T) {
+	if _, err := NewClaimMapping("", "groups", nil, map[string]string{"dpo": "5"}, nil, 1); err == nil {
+		t.Fatal("clearance level 5 accepted")
+	}
+	if _, err := NewClaimMapping("", "groups", nil, nil, nil, 0); err == nil {
+		t.Fatal("default clearance level 0 accepted")
+	}
+}
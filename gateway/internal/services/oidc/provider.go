@@ -0,0 +1,278 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keysRefetchInterval is the shortest time between two fetches of the signing keys, so
+// that tokens signed with unknown keys cannot make the gateway hammer the provider.
+const keysRefetchInterval = time.Minute
+
+// maxResponseSize limits the documents read from the provider.
+const maxResponseSize = 1 << 20
+
+// idTokenMethods are the signature algorithms accepted on ID tokens. Symmetric
+// algorithms are not: the client secret must not be usable to forge a token.
+var idTokenMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// ErrUnknownKey is returned when an ID token is signed with a key the provider does not
+// publish.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Provider is an OpenID Connect provider users sign in through with the authorization
+// code flow and PKCE. Its metadata is discovered on first use and its signing keys are
+// cached, and fetched again when a token is signed with an unknown key.
+type Provider struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	client       *http.Client
+
+	mu          sync.Mutex
+	metadata    *metadata
+	keys        map[string]any
+	keysFetched time.Time
+}
+
+// metadata is the part of the provider metadata the gateway uses.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+// NewProvider returns the provider with the issuer issuerURL. The gateway is registered
+// with it as the client clientID, whose callback is redirectURL; the client secret may
+// be empty for a public client. Requests to the provider time out after timeout.
+func NewProvider(name, issuerURL, clientID, clientSecret, redirectURL string, scopes []string, timeout time.Duration) (*Provider, error) {
+	if issuerURL == "" || clientID == "" || redirectURL == "" {
+		return nil, errors.New("issuer URL, client ID and redirect URL are required")
+	}
+	if _, err := url.Parse(issuerURL); err != nil {
+		return nil, fmt.Errorf("invalid issuer URL: %w", err)
+	}
+	if name == "" {
+		name = issuerURL
+	}
+
+	return &Provider{
+		name:         name,
+		issuer:       strings.TrimSuffix(issuerURL, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		client:       &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name returns the name of the provider shown to users.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL returns the URL of the provider the user is sent to for signing in.
+func (p *Provider) AuthCodeURL(ctx context.Context, state *LoginState) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(md.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", p.redirectURL)
+	query.Set("scope", strings.Join(p.scopes, " "))
+	query.Set("state", state.State)
+	query.Set("nonce", state.Nonce)
+	query.Set("code_challenge", state.Challenge())
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Exchange redeems the authorization code of the callback and returns the ID token. The
+// client authenticates with HTTP Basic if it has a secret.
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.redirectURL},
+		"code_verifier": {verifier},
+		"client_id":     {p.clientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.do(req, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to redeem authorization code: %w", err)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d: %s %s", status, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", errors.New("token response has no ID token")
+	}
+	return body.IDToken, nil
+}
+
+// Verify checks the signature, issuer, audience, lifetime and nonce of the ID token and
+// returns its claims.
+func (p *Provider) Verify(ctx context.Context, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.key(ctx, md, kid)
+		},
+		jwt.WithValidMethods(idTokenMethods),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	got, _ := claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(got), []byte(nonce)) != 1 {
+		return nil, errors.New("invalid ID token: nonce mismatch")
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != p.clientID {
+		return nil, errors.New("invalid ID token: issued to another client")
+	}
+	return claims, nil
+}
+
+// discover returns the provider metadata, fetching it on first use. A failed discovery
+// is not cached, so the next sign-in tries again.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery request: %w", err)
+	}
+	var md metadata
+	status, err := p.do(req, &md)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover provider: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to discover provider: status %d", status)
+	}
+
+	if strings.TrimSuffix(md.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("provider issuer %q does not match %q", md.Issuer, p.issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JwksURI == "" {
+		return nil, errors.New("provider metadata lacks an endpoint")
+	}
+	p.metadata = &md
+	return p.metadata, nil
+}
+
+// key returns the signing key kid; an empty kid is accepted if the provider publishes a
+// single key.
+func (p *Provider) key(ctx context.Context, md *metadata, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := lookupKey(p.keys, kid); key != nil {
+		return key, nil
+	}
+	if p.keys != nil && time.Since(p.keysFetched) < keysRefetchInterval {
+		return nil, ErrUnknownKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, md.JwksURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build key set request: %w", err)
+	}
+	var set jsonWebKeySet
+	status, err := p.do(req, &set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch key set: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch key set: status %d", status)
+	}
+
+	p.keys, p.keysFetched = set.publicKeys(), time.Now()
+	if key := lookupKey(p.keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func lookupKey(keys map[string]any, kid string) any {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+	return keys[kid]
+}
+
+// do sends the request and decodes the JSON response into v whatever its status, since
+// error responses have a body too.
+func (p *Provider) do(req *http.Request, v any) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,320 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID     = "anonix"
+	testClientSecret = "s3cr/et"
+	testRedirectURL  = "https://anonix.example/api/v1/auth/oidc/callback"
+	testCode         = "auth-code"
+)
+
+// mockProvider is an identity provider serving discovery, its key set and a token
+// endpoint that checks the client and the PKCE verifier of testCode.
+type mockProvider struct {
+	server     *httptest.Server
+	rsaKey     *rsa.PrivateKey
+	ecKey      *ecdsa.PrivateKey
+	challenge  string
+	claims     jwt.MapClaims
+	keyFetches atomic.Int32
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &mockProvider{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize?tenant=main",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.keyFetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{
+				"kty": "RSA", "kid": "rsa-1", "use": "sig",
+				"n": b64(m.rsaKey.N.Bytes()),
+				"e": b64(big.NewInt(int64(m.rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC", "kid": "ec-1", "crv": "P-256",
+				"x": b64(m.ecKey.X.FillBytes(make([]byte, 32))),
+				"y": b64(m.ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+			{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+		sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		switch {
+		case id != testClientID || secret != testClientSecret:
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		case r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("code") != testCode ||
+			r.PostFormValue("redirect_uri") != testRedirectURL || b64(sum[:]) != m.challenge:
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{"id_token": m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", m.claims)})
+		}
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (m *mockProvider) idClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                m.server.URL,
+		"sub":                "f3a1c2",
+		"aud":                testClientID,
+		"exp":                time.Now().Add(5 * time.Minute).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              nonce,
+		"preferred_username": "ivanov",
+	}
+}
+
+func (m *mockProvider) sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (m *mockProvider) provider(t *testing.T) *Provider {
+	t.Helper()
+	p, err := NewProvider("Mock", m.server.URL+"/", testClientID, testClientSecret, testRedirectURL,
+		[]string{"openid", "profile"}, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.provider(t)
+	ctx := context.Background()
+
+	state, err := NewLoginState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := p.AuthCodeURL(ctx, state)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	u, _ := url.Parse(authURL)
+	query := u.Query()
+	if u.Path != "/authorize" || query.Get("tenant") != "main" {
+		t.Fatalf("authorization URL %s lost its endpoint", authURL)
+	}
+	want := map[string]string{
+		"response_type": "code", "client_id": testClientID, "redirect_uri": testRedirectURL,
+		"scope": "openid profile", "state": state.State, "nonce": state.Nonce,
+		"code_challenge": state.Challenge(), "code_challenge_method": "S256",
+	}
+	for k, v := range want {
+		if query.Get(k) != v {
+			t.Errorf("%s = %q, want %q", k, query.Get(k), v)
+		}
+	}
+
+	m.challenge = query.Get("code_challenge")
+	m.claims = m.idClaims(state.Nonce)
+
+	if _, err = p.Exchange(ctx, testCode, "another-verifier"); err == nil {
+		t.Fatal("exchange with a wrong PKCE verifier succeeded")
+	}
+	rawIDToken, err := p.Exchange(ctx, testCode, state.Verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	claims, err := p.Verify(ctx, rawIDToken, state.Nonce)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims["preferred_username"] != "ivanov" {
+		t.Fatalf("got claims %v", claims)
+	}
+	if _, err = p.Verify(ctx, rawIDToken, "another-nonce"); err == nil {
+		t.Fatal("token with another nonce accepted")
+	}
+}
+
+func TestProvider_Verify(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.provider(t)
+	ctx := context.Background()
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		token  func() string
+		wantOK bool
+	}{
+		{"rsa", func() string {
+			return m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", m.idClaims("n"))
+		}, true},
+		{"ec", func() string {
+			return m.sign(t, jwt.SigningMethodES256, m.ecKey, "ec-1", m.idClaims("n"))
+		}, true},
+		{"forged signature", func() string {
+			return m.sign(t, jwt.SigningMethodRS256, otherKey, "rsa-1", m.idClaims("n"))
+		}, false},
+		{"encryption key", func() string {
+			return m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "enc-1", m.idClaims("n"))
+		}, false},
+		{"client secret as hmac key", func() string {
+			return m.sign(t, jwt.SigningMethodHS256, []byte(testClientSecret), "rsa-1", m.idClaims("n"))
+		}, false},
+		{"other issuer", func() string {
+			claims := m.idClaims("n")
+			claims["iss"] = "https://evil.example"
+			return m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", claims)
+		}, false},
+		{"other audience", func() string {
+			claims := m.idClaims("n")
+			claims["aud"] = "another-client"
+			return m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", claims)
+		}, false},
+		{"other authorized party", func() string {
+			claims := m.idClaims("n")
+			claims["aud"] = []string{testClientID, "another-client"}
+			claims["azp"] = "another-client"
+			return m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", claims)
+		}, false},
+		{"expired", func() string {
+			claims := m.idClaims("n")
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", claims)
+		}, false},
+		{"no expiry", func() string {
+			claims := m.idClaims("n")
+			delete(claims, "exp")
+			return m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", claims)
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.Verify(ctx, tt.token(), "n")
+			if (err == nil) != tt.wantOK {
+				t.Fatalf("got err=%v, want ok=%v", err, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestProvider_UnknownKeyRefetchIsLimited(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.provider(t)
+	ctx := context.Background()
+
+	if _, err := p.Verify(ctx, m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rsa-1", m.idClaims("n")), "n"); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		_, err := p.Verify(ctx, m.sign(t, jwt.SigningMethodRS256, m.rsaKey, "rotated", m.idClaims("n")), "n")
+		if !errors.Is(err, ErrUnknownKey) {
+			t.Fatalf("got %v, want ErrUnknownKey", err)
+		}
+	}
+	if got := m.keyFetches.Load(); got != 1 {
+		t.Fatalf("key set fetched %d times, want 1", got)
+	}
+}
+
+func TestProvider_IssuerMismatch(t *testing.T) {
+	m := newMockProvider(t)
+	p, err := NewProvider("", strings.Replace(m.server.URL, "127.0.0.1", "localhost", 1), testClientID, "",
+		testRedirectURL, []string{"openid"}, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, _ := NewLoginState()
+	if _, err = p.AuthCodeURL(context.Background(), state); err == nil {
+		t.Fatal("provider with another issuer accepted")
+	}
+}
+
+func TestLoginState_Seal(t *testing.T) {
+	state, err := NewLoginState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := state.Seal("secret", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opened, err := OpenLoginState(sealed, "secret")
+	if err != nil {
+		t.Fatalf("OpenLoginState: %v", err)
+	}
+	if *opened != *state {
+		t.Fatalf("got %+v, want %+v", opened, state)
+	}
+
+	if _, err = OpenLoginState(sealed, "another secret"); err == nil {
+		t.Fatal("state sealed with another secret accepted")
+	}
+	expired, _ := state.Seal("secret", -time.Minute)
+	if _, err = OpenLoginState(expired, "secret"); err == nil {
+		t.Fatal("expired state accepted")
+	}
+	accessToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"state": "s", "nonce": "n", "verifier": "v", "exp": time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte("secret"))
+	if _, err = OpenLoginState(accessToken, "secret"); err == nil {
+		t.Fatal("token signed with the JWT secret itself accepted as state")
+	}
+}
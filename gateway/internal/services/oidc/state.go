@@ -0,0 +1,80 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+// LoginState is kept by the browser between the redirect to the provider and the
+// callback: State binds the callback to the browser that started the sign-in, Nonce
+// binds the ID token to it and Verifier is the PKCE code verifier (RFC 7636).
+type LoginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+type loginStateClaims struct {
+	LoginState
+	jwt.RegisteredClaims
+}
+
+// NewLoginState returns a random state for a new sign-in.
+func NewLoginState() (*LoginState, error) {
+	var s LoginState
+	for _, v := range []*string{&s.State, &s.Nonce, &s.Verifier} {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate login state: %w", err)
+		}
+		*v = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return &s, nil
+}
+
+// Challenge returns the S256 code challenge of the verifier.
+func (s *LoginState) Challenge() string {
+	sum := sha256.Sum256([]byte(s.Verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Seal signs the state so that it can be kept in a cookie for ttl. It is signed with a
+// key derived from secret, so a sealed state is never accepted as an access token.
+func (s *LoginState) Seal(secret string, ttl time.Duration) (string, error) {
+	claims := &loginStateClaims{
+		LoginState: *s,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(stateKey(secret))
+}
+
+// OpenLoginState returns the state sealed by Seal if it has not expired.
+func OpenLoginState(sealed, secret string) (*LoginState, error) {
+	var claims loginStateClaims
+	_, err := jwt.ParseWithClaims(sealed, &claims,
+		func(*jwt.Token) (any, error) { return stateKey(secret), nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid login state: %w", err)
+	}
+	if claims.State == "" || claims.Nonce == "" || claims.Verifier == "" {
+		return nil, errors.New("invalid login state: incomplete")
+	}
+	return &claims.LoginState, nil
+}
+
+func stateKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("oidc login state"))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeF2le/anonix/common/logger"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSource is the source of the built-in policy.
+const DefaultSource = "built-in"
+
+// Engine holds the policy in force. If it was loaded from a file, Watch replaces it
+// when the file changes; a changed file that does not compile leaves the policy in
+// force as it is.
+type Engine struct {
+	path    string
+	actions []string
+	current atomic.Pointer[Policy]
+	// seen is the version of the policy file last read by Reload.
+	seen string
+}
+
+// NewEngine compiles the policy in the file at path, or defaultPolicy if path is empty.
+// actions are the actions the gateway checks.
+func NewEngine(defaultPolicy []byte, path string, actions []string) (*Engine, error) {
+	e := &Engine{path: path, actions: actions}
+
+	data, source := defaultPolicy, DefaultSource
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read policy: %w", err)
+		}
+		source = path
+	}
+
+	p, err := Compile(data, source, actions)
+	if err != nil {
+		return nil, err
+	}
+	e.current.Store(p)
+	e.seen = p.Version
+	return e, nil
+}
+
+// Policy returns the policy in force.
+func (e *Engine) Policy() *Policy {
+	return e.current.Load()
+}
+
+// Compile compiles a policy document against the actions of the engine without putting
+// it in force, e.g. to check a draft.
+func (e *Engine) Compile(data []byte, source string) (*Policy, error) {
+	return Compile(data, source, e.actions)
+}
+
+// Decide evaluates the policy in force.
+func (e *Engine) Decide(in *Input) *Decision {
+	return e.Policy().Decide(in)
+}
+
+// Reload puts the policy file in force if its content changed since it was last read.
+// It reports whether the policy was replaced. It must not be called concurrently.
+func (e *Engine) Reload() (bool, error) {
+	if e.path == "" {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read policy: %w", err)
+	}
+	v := version(data)
+	if v == e.seen {
+		return false, nil
+	}
+	e.seen = v
+
+	p, err := Compile(data, e.path, e.actions)
+	if err != nil {
+		return false, err
+	}
+	e.current.Store(p)
+	return true, nil
+}
+
+// Watch reloads the policy file every interval until ctx is done.
+func (e *Engine) Watch(ctx context.Context, interval time.Duration) {
+	if e.path == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			replaced, err := e.Reload()
+			if err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to reload access policy, keeping the current one",
+					slog.String("path", e.path),
+					slog.String("version", e.Policy().Version),
+					logger.Err(err))
+				continue
+			}
+			if replaced {
+				logger.GetLoggerFromCtx(ctx).Info(ctx, "access policy reloaded",
+					slog.String("path", e.path),
+					slog.String("version", e.Policy().Version))
+			}
+		}
+	}
+}
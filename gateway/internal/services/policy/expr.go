@@ -0,0 +1,526 @@
+package policy
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// valueType is the type of an expression. Expressions are type-checked when the policy
+// is compiled, so a rule that compiles cannot fail when it is evaluated.
+type valueType int
+
+const (
+	typeBool valueType = iota
+	typeInt
+	typeString
+	typeIntList
+	typeStringList
+)
+
+func (t valueType) String() string {
+	switch t {
+	case typeBool:
+		return "bool"
+	case typeInt:
+		return "int"
+	case typeString:
+		return "string"
+	case typeIntList:
+		return "list of int"
+	case typeStringList:
+		return "list of string"
+	}
+	return "unknown"
+}
+
+// attribute is a value of the input that expressions refer to by name.
+type attribute struct {
+	typ valueType
+	get func(in *Input) any
+}
+
+var attributes = map[string]attribute{
+	"subject.id":              {typeString, func(in *Input) any { return in.Subject.ID }},
+	"subject.roles":           {typeStringList, func(in *Input) any { return in.Subject.Roles }},
+	"subject.permissions":     {typeStringList, func(in *Input) any { return in.Subject.Permissions }},
+	"subject.clearance":       {typeInt, func(in *Input) any { return in.Subject.Clearance }},
+	"subject.kind_grants":     {typeIntList, func(in *Input) any { return in.Subject.KindGrants }},
+	"subject.kind_operations": {typeStringList, func(in *Input) any { return kindOperations(in) }},
+	"subject.break_glass":     {typeBool, func(in *Input) any { return in.Subject.BreakGlass }},
+	"subject.service_account": {typeBool, func(in *Input) any { return in.Subject.ServiceAccount }},
+	"action":                  {typeString, func(in *Input) any { return in.Action }},
+	"resource.kind":           {typeInt, func(in *Input) any { return in.Resource.Kind }},
+	"resource.access_level":   {typeInt, func(in *Input) any { return in.Resource.AccessLevel }},
+	"resource.owner":          {typeString, func(in *Input) any { return in.Resource.Owner }},
+	"resource.operation":      {typeString, func(in *Input) any { return in.Resource.Operation }},
+	"context.ip":              {typeString, func(in *Input) any { return in.Context.IP }},
+	"context.hour":            {typeInt, func(in *Input) any { return int64(in.Context.Time.Hour()) }},
+	"context.weekday":         {typeInt, func(in *Input) any { return isoWeekday(in) }},
+}
+
+// kindOperations returns the operations granted to the subject on the kind of the
+// resource.
+func kindOperations(in *Input) []string {
+	return in.Subject.KindOperations[in.Resource.Kind]
+}
+
+// isoWeekday returns the day of the week of the request from 1 (Monday) to 7 (Sunday).
+func isoWeekday(in *Input) int64 {
+	if day := in.Context.Time.Weekday(); day != 0 {
+		return int64(day)
+	}
+	return 7
+}
+
+// unknown is the value of an attribute left out of a partial evaluation and of the
+// expressions whose value depends on it.
+type unknown struct{}
+
+type node interface {
+	typ() valueType
+	eval(in *Input) any
+}
+
+type literalNode struct {
+	t     valueType
+	value any
+}
+
+func (n *literalNode) typ() valueType    { return n.t }
+func (n *literalNode) eval(_ *Input) any { return n.value }
+
+type attributeNode struct {
+	name string
+	attr attribute
+}
+
+func (n *attributeNode) typ() valueType { return n.attr.typ }
+func (n *attributeNode) eval(in *Input) any {
+	if _, ok := in.unknown[n.name]; ok {
+		return unknown{}
+	}
+	return n.attr.get(in)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) typ() valueType { return typeBool }
+func (n *notNode) eval(in *Input) any {
+	if v, ok := n.operand.eval(in).(bool); ok {
+		return !v
+	}
+	return unknown{}
+}
+
+type logicalNode struct {
+	and         bool
+	left, right node
+}
+
+func (n *logicalNode) typ() valueType { return typeBool }
+
+// eval short-circuits on a known value deciding the result, so that "x and false" is
+// false and "x or true" is true even if x is unknown.
+func (n *logicalNode) eval(in *Input) any {
+	left := n.left.eval(in)
+	if v, ok := left.(bool); ok && v != n.and {
+		return v
+	}
+	right := n.right.eval(in)
+	if v, ok := right.(bool); ok && v != n.and {
+		return v
+	}
+	if _, ok := left.(unknown); ok {
+		return left
+	}
+	return right
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n *compareNode) typ() valueType { return typeBool }
+
+func (n *compareNode) eval(in *Input) any {
+	left, right := n.left.eval(in), n.right.eval(in)
+	if _, ok := right.(unknown); ok {
+		return right
+	}
+	if n.op == "in" && isEmptyList(right) {
+		return false
+	}
+	if _, ok := left.(unknown); ok {
+		return left
+	}
+
+	switch n.op {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	case "in":
+		switch list := right.(type) {
+		case []int64:
+			return slices.Contains(list, left.(int64))
+		case []string:
+			return slices.Contains(list, left.(string))
+		}
+		return false
+	}
+
+	l, r := left.(int64), right.(int64)
+	switch n.op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+func isEmptyList(v any) bool {
+	switch list := v.(type) {
+	case []int64:
+		return len(list) == 0
+	case []string:
+		return len(list) == 0
+	}
+	return false
+}
+
+// cidrNode reports whether an IP address belongs to a network given as a literal.
+type cidrNode struct {
+	ip     node
+	prefix netip.Prefix
+}
+
+func (n *cidrNode) typ() valueType { return typeBool }
+
+func (n *cidrNode) eval(in *Input) any {
+	ip, ok := n.ip.eval(in).(string)
+	if !ok {
+		return unknown{}
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	return n.prefix.Contains(addr.Unmap())
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(src) && src[j] != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				b.WriteByte(src[j])
+			}
+			if j == len(src) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: src[i : j+1], value: b.String(), pos: i})
+			i = j + 1
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			j := i + 1
+			for j < len(src) && src[j] >= '0' && src[j] <= '9' {
+				j++
+			}
+			n, err := strconv.ParseInt(src[i:j], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at %d", src[i:j], i)
+			}
+			tokens = append(tokens, token{kind: tokenInt, text: src[i:j], value: n, pos: i})
+			i = j
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[i:j], pos: i})
+			i = j
+		default:
+			op := string(c)
+			if i+1 < len(src) && slices.Contains([]string{"==", "!=", "<=", ">="}, src[i:i+2]) {
+				op = src[i : i+2]
+			} else if !strings.ContainsRune("<>()[],", rune(c)) {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of expression", pos: len(src)}), nil
+}
+
+// parser parses the condition of a rule:
+//
+//	expr    = and { "or" and }
+//	and     = unary { "and" unary }
+//	unary   = "not" unary | compare
+//	compare = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" ) operand ]
+//	operand = literal | attribute | list | "cidr" "(" operand "," string ")" | "(" expr ")"
+//	list    = "[" [ literal { "," literal } ] "]"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseExpr parses and type-checks a boolean expression.
+func parseExpr(src string) (node, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	if n.typ() != typeBool {
+		return nil, fmt.Errorf("condition is %s, not bool", n.typ())
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(word string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && t.text == word
+}
+
+func (p *parser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokenOp && t.text == op
+}
+
+func (p *parser) expectOp(op string) error {
+	if t := p.next(); t.kind != tokenOp || t.text != op {
+		return fmt.Errorf("expected %q at %d, got %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseLogical("or", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLogical("and", p.parseUnary)
+}
+
+func (p *parser) parseLogical(keyword string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(keyword) {
+		t := p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != typeBool || right.typ() != typeBool {
+			return nil, fmt.Errorf("operands of %q at %d must be bool", keyword, t.pos)
+		}
+		left = &logicalNode{and: keyword == "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if !p.isKeyword("not") {
+		return p.parseCompare()
+	}
+	t := p.next()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if operand.typ() != typeBool {
+		return nil, fmt.Errorf("operand of \"not\" at %d must be bool", t.pos)
+	}
+	return &notNode{operand: operand}, nil
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	isCompare := t.kind == tokenOp && slices.Contains([]string{"==", "!=", "<", "<=", ">", ">="}, t.text)
+	if !isCompare && !p.isKeyword("in") {
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.text {
+	case "==", "!=":
+		if left.typ() != right.typ() || left.typ() == typeIntList || left.typ() == typeStringList {
+			return nil, fmt.Errorf("cannot compare %s with %s at %d", left.typ(), right.typ(), t.pos)
+		}
+	case "in":
+		if !(left.typ() == typeInt && right.typ() == typeIntList) && !(left.typ() == typeString && right.typ() == typeStringList) {
+			return nil, fmt.Errorf("cannot look up %s in %s at %d", left.typ(), right.typ(), t.pos)
+		}
+	default:
+		if left.typ() != typeInt || right.typ() != typeInt {
+			return nil, fmt.Errorf("operands of %q at %d must be int", t.text, t.pos)
+		}
+	}
+	return &compareNode{op: t.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenInt:
+		return &literalNode{t: typeInt, value: t.value}, nil
+	case tokenString:
+		return &literalNode{t: typeString, value: t.value}, nil
+	case tokenOp:
+		switch t.text {
+		case "(":
+			n, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expectOp(")")
+		case "[":
+			return p.parseList(t)
+		}
+	case tokenIdent:
+		switch t.text {
+		case "true", "false":
+			return &literalNode{t: typeBool, value: t.text == "true"}, nil
+		case "cidr":
+			return p.parseCidr(t)
+		}
+		attr, ok := attributes[t.text]
+		if !ok {
+			return nil, fmt.Errorf("unknown attribute %q at %d", t.text, t.pos)
+		}
+		return &attributeNode{name: t.text, attr: attr}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+}
+
+func (p *parser) parseList(start token) (node, error) {
+	var (
+		ints    []int64
+		strs    []string
+		hasInts bool
+	)
+	for !p.isOp("]") {
+		if len(ints)+len(strs) > 0 {
+			if err := p.expectOp(","); err != nil {
+				return nil, err
+			}
+		}
+		t := p.next()
+		switch {
+		case t.kind == tokenInt && len(strs) == 0:
+			ints = append(ints, t.value.(int64))
+			hasInts = true
+		case t.kind == tokenString && !hasInts:
+			strs = append(strs, t.value.(string))
+		default:
+			return nil, fmt.Errorf("list at %d must hold literals of one type, got %q", start.pos, t.text)
+		}
+	}
+	p.next()
+
+	if hasInts {
+		return &literalNode{t: typeIntList, value: ints}, nil
+	}
+	if strs == nil {
+		strs = []string{}
+	}
+	return &literalNode{t: typeStringList, value: strs}, nil
+}
+
+func (p *parser) parseCidr(start token) (node, error) {
+	if err := p.expectOp("("); err != nil {
+		return nil, err
+	}
+	ip, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if ip.typ() != typeString {
+		return nil, fmt.Errorf("first argument of cidr at %d must be string", start.pos)
+	}
+	if err = p.expectOp(","); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	if t.kind != tokenString {
+		return nil, fmt.Errorf("second argument of cidr at %d must be a network literal", start.pos)
+	}
+	prefix, err := netip.ParsePrefix(t.value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid network %q at %d", t.value, t.pos)
+	}
+	if err = p.expectOp(")"); err != nil {
+		return nil, err
+	}
+	return &cidrNode{ip: ip, prefix: prefix.Masked()}, nil
+}
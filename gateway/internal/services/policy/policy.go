@@ -0,0 +1,272 @@
+// Package policy implements the access policy of the gateway: an ordered list of rules
+// that allow or deny actions depending on the subject performing them, the resource
+// they are performed on and the context of the request. A rule applies to an action if
+// one of its action patterns matches it and takes effect if its condition holds. Deny
+// rules take precedence over allow rules, and actions no rule allows are denied.
+package policy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// NoAccessLevel is returned by Policy.MaxAccessLevel when no access level is allowed.
+const NoAccessLevel = -1
+
+// Subject is the user performing the action. KindOperations holds the operations
+// allowed to the user on data of each kind by kind operation grants. A service account
+// authenticated with an API key has the permissions and kinds of the key as Permissions
+// and KindGrants, and no roles or clearance.
+type Subject struct {
+	ID             string
+	Roles          []string
+	Permissions    []string
+	Clearance      int64
+	KindGrants     []int64
+	KindOperations map[int64][]string
+	BreakGlass     bool
+	ServiceAccount bool
+}
+
+// Resource is what the action is performed on; its fields are zero when unknown.
+// Operation is what is done with data of the kind: tokenize, detokenize, view or delete.
+type Resource struct {
+	Kind        int64
+	AccessLevel int64
+	Owner       string
+	Operation   string
+}
+
+// Context is the request the action is performed in.
+type Context struct {
+	IP   string
+	Time time.Time
+}
+
+type Input struct {
+	Subject  Subject
+	Action   string
+	Resource Resource
+	Context  Context
+
+	// unknown holds the attributes left out of a partial evaluation.
+	unknown map[string]struct{}
+}
+
+// RuleSpec is a rule as it is written in the policy document. An empty condition always
+// holds. Action patterns are action names, "prefix.*" or "*".
+type RuleSpec struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description"`
+	Effect      string   `yaml:"effect" json:"effect"`
+	Actions     []string `yaml:"actions" json:"actions"`
+	When        string   `yaml:"when" json:"when"`
+}
+
+type document struct {
+	Rules []RuleSpec `yaml:"rules"`
+}
+
+type rule struct {
+	spec      *RuleSpec
+	condition node
+}
+
+// Policy is a compiled policy document. Version identifies its content.
+type Policy struct {
+	Source   string
+	Version  string
+	LoadedAt time.Time
+	Rules    []RuleSpec
+	Actions  []string
+
+	byAction map[string][]*rule
+}
+
+// RuleResult tells whether the condition of a rule applying to the action held.
+type RuleResult struct {
+	Name    string
+	Effect  string
+	Matched bool
+}
+
+// Decision is the outcome of evaluating the policy. Rule is the rule that decided it,
+// or empty if no rule allowed the action. Rules lists every rule applying to the
+// action in policy order.
+type Decision struct {
+	Allowed bool
+	Rule    string
+	Rules   []RuleResult
+}
+
+// Reason describes the decision for the audit log.
+func (d *Decision) Reason(action string) string {
+	if d.Rule == "" {
+		return fmt.Sprintf("no rule allows %s", action)
+	}
+	if d.Allowed {
+		return fmt.Sprintf("allowed by rule %s", d.Rule)
+	}
+	return fmt.Sprintf("denied by rule %s", d.Rule)
+}
+
+// Compile parses the policy document read from source. Every rule must have a unique
+// name, a valid effect and condition, and apply to at least one of actions, the actions
+// the gateway checks; action patterns matching none of them are rejected as typos.
+func Compile(data []byte, source string, actions []string) (*Policy, error) {
+	var doc document
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if len(doc.Rules) == 0 {
+		return nil, fmt.Errorf("policy has no rules")
+	}
+
+	p := &Policy{
+		Source:   source,
+		Version:  version(data),
+		LoadedAt: time.Now().UTC(),
+		Rules:    doc.Rules,
+		Actions:  actions,
+		byAction: make(map[string][]*rule, len(actions)),
+	}
+
+	names := make(map[string]struct{}, len(doc.Rules))
+	for i := range p.Rules {
+		spec := &p.Rules[i]
+		if spec.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i+1)
+		}
+		if _, ok := names[spec.Name]; ok {
+			return nil, fmt.Errorf("rule %s: duplicate name", spec.Name)
+		}
+		names[spec.Name] = struct{}{}
+
+		if spec.Effect != EffectAllow && spec.Effect != EffectDeny {
+			return nil, fmt.Errorf("rule %s: effect must be %s or %s", spec.Name, EffectAllow, EffectDeny)
+		}
+		if len(spec.Actions) == 0 {
+			return nil, fmt.Errorf("rule %s: no actions", spec.Name)
+		}
+
+		r := &rule{spec: spec, condition: &literalNode{t: typeBool, value: true}}
+		if strings.TrimSpace(spec.When) != "" {
+			condition, err := parseExpr(spec.When)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %w", spec.Name, err)
+			}
+			r.condition = condition
+		}
+
+		for _, pattern := range spec.Actions {
+			matched := false
+			for _, action := range actions {
+				if matchAction(pattern, action) {
+					matched = true
+					if !slices.Contains(p.byAction[action], r) {
+						p.byAction[action] = append(p.byAction[action], r)
+					}
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("rule %s: unknown action %q", spec.Name, pattern)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// version identifies the content of a policy document.
+func version(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:6])
+}
+
+func matchAction(pattern, action string) bool {
+	if pattern == "*" || pattern == action {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	return ok && strings.HasSuffix(prefix, ".") && strings.HasPrefix(action, prefix)
+}
+
+// Decide evaluates the rules applying to the action of in.
+func (p *Policy) Decide(in *Input) *Decision {
+	rules := p.byAction[in.Action]
+	decision := &Decision{Rules: make([]RuleResult, 0, len(rules))}
+
+	var allowedBy, deniedBy string
+	for _, r := range rules {
+		matched := r.condition.eval(in).(bool)
+		decision.Rules = append(decision.Rules, RuleResult{Name: r.spec.Name, Effect: r.spec.Effect, Matched: matched})
+		if !matched {
+			continue
+		}
+		if r.spec.Effect == EffectDeny && deniedBy == "" {
+			deniedBy = r.spec.Name
+		} else if r.spec.Effect == EffectAllow && allowedBy == "" {
+			allowedBy = r.spec.Name
+		}
+	}
+
+	switch {
+	case deniedBy != "":
+		decision.Rule = deniedBy
+	case allowedBy != "":
+		decision.Allowed, decision.Rule = true, allowedBy
+	}
+	return decision
+}
+
+// MaxAccessLevel returns the highest access level up to maxLevel of the resources the
+// action may be allowed on for the subject of in, so that lists can be narrowed before
+// the policy is checked for every item. The kind and owner of the resources, and so the
+// operations granted on their kind, are not known yet: a level counts as allowed unless
+// a deny rule holds for it whatever they are, and some allow rule may hold. It returns 0
+// if every level is allowed and NoAccessLevel if none is.
+func (p *Policy) MaxAccessLevel(in Input, maxLevel int64) int64 {
+	in.Resource = Resource{Operation: in.Resource.Operation}
+	in.unknown = map[string]struct{}{"resource.kind": {}, "resource.owner": {}}
+	if len(in.Subject.KindOperations) > 0 {
+		in.unknown["subject.kind_operations"] = struct{}{}
+	}
+
+	for level := maxLevel; level > 0; level-- {
+		in.Resource.AccessLevel = level
+		if p.mayAllow(&in) {
+			if level == maxLevel {
+				return 0
+			}
+			return level
+		}
+	}
+	return NoAccessLevel
+}
+
+func (p *Policy) mayAllow(in *Input) bool {
+	allowed := false
+	for _, r := range p.byAction[in.Action] {
+		matched := r.condition.eval(in)
+		if r.spec.Effect == EffectDeny && matched == true {
+			return false
+		}
+		if r.spec.Effect == EffectAllow && matched != false {
+			allowed = true
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,302 @@
+package policy
+
+import (
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/policies"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func compileDefault(t *testing.T) *Policy {
+	t.Helper()
+	p, err := Compile(policies.Default, DefaultSource, domain.PolicyActions)
+	if err != nil {
+		t.Fatalf("default policy does not compile: %v", err)
+	}
+	return p
+}
+
+func subjectWithRoles(roles ...string) Subject {
+	return Subject{ID: "user", Roles: roles, Clearance: 1}
+}
+
+func TestDefaultPolicy_Permissions(t *testing.T) {
+	p := compileDefault(t)
+
+	for _, action := range domain.PolicyActions {
+		if action == domain.PolicyActionDataAccess {
+			continue
+		}
+
+		granted := Subject{ID: "user", Roles: []string{"custom"}, Permissions: []string{action}}
+		if decision := p.Decide(&Input{Subject: granted, Action: action}); !decision.Allowed {
+			t.Errorf("%s with its permission: denied (%s)", action, decision.Reason(action))
+		}
+
+		other := Subject{ID: "user", Roles: []string{"admin"}, Permissions: []string{domain.PolicyActionPolicyRead}}
+		if action == domain.PolicyActionPolicyRead {
+			other.Permissions = []string{domain.PolicyActionAuditRead}
+		}
+		if decision := p.Decide(&Input{Subject: other, Action: action}); decision.Allowed {
+			t.Errorf("%s without its permission: allowed by %s", action, decision.Rule)
+		}
+	}
+}
+
+func TestDefaultPolicy_DataAccess(t *testing.T) {
+	p := compileDefault(t)
+
+	tests := []struct {
+		name     string
+		subject  Subject
+		resource Resource
+		want     bool
+	}{
+		{"within clearance", Subject{Clearance: 2}, Resource{Kind: 5, AccessLevel: 2}, true},
+		{"above clearance", Subject{Clearance: 2}, Resource{Kind: 5, AccessLevel: 3}, false},
+		{"granted kind", Subject{Clearance: 1, KindGrants: []int64{5}}, Resource{Kind: 5, AccessLevel: 4}, true},
+		{"other kind", Subject{Clearance: 1, KindGrants: []int64{6}}, Resource{Kind: 5, AccessLevel: 4}, false},
+		{"break-glass", Subject{Clearance: 1, BreakGlass: true}, Resource{Kind: 5, AccessLevel: 4}, true},
+		{"granted operation", Subject{Clearance: 1, KindOperations: map[int64][]string{5: {"detokenize", "view"}}},
+			Resource{Kind: 5, AccessLevel: 4, Operation: "detokenize"}, true},
+		{"other operation", Subject{Clearance: 1, KindOperations: map[int64][]string{5: {"detokenize", "view"}}},
+			Resource{Kind: 5, AccessLevel: 4, Operation: "delete"}, false},
+		{"operation on other kind", Subject{Clearance: 1, KindOperations: map[int64][]string{6: {"detokenize"}}},
+			Resource{Kind: 5, AccessLevel: 4, Operation: "detokenize"}, false},
+		{"api key kind", Subject{KindGrants: []int64{5}, ServiceAccount: true}, Resource{Kind: 5, AccessLevel: 1}, true},
+		{"kind outside api key", Subject{KindGrants: []int64{5}, ServiceAccount: true}, Resource{Kind: 6, AccessLevel: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := p.Decide(&Input{Subject: tt.subject, Action: domain.PolicyActionDataAccess, Resource: tt.resource})
+			if decision.Allowed != tt.want {
+				t.Fatalf("got allowed=%v want %v", decision.Allowed, tt.want)
+			}
+		})
+	}
+}
+
+// TestDecide_KindOperationsOnly checks a policy that allows data of kind 5 only to holders
+// of kind operation grants, whatever their clearance level.
+func TestDecide_KindOperationsOnly(t *testing.T) {
+	data := append(append([]byte{}, policies.Default...), `
+  - name: kind-5-by-grant-only
+    effect: deny
+    actions: [data.access]
+    when: resource.kind in [5] and not resource.operation in subject.kind_operations
+`...)
+	p, err := Compile(data, "test", domain.PolicyActions)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	subject := Subject{Clearance: 4, KindOperations: map[int64][]string{5: {"view"}}}
+	tests := []struct {
+		name     string
+		resource Resource
+		want     bool
+	}{
+		{"granted operation", Resource{Kind: 5, AccessLevel: 2, Operation: "view"}, true},
+		{"operation within clearance", Resource{Kind: 5, AccessLevel: 2, Operation: "detokenize"}, false},
+		{"other kind", Resource{Kind: 6, AccessLevel: 2, Operation: "detokenize"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := p.Decide(&Input{Subject: subject, Action: domain.PolicyActionDataAccess, Resource: tt.resource})
+			if decision.Allowed != tt.want {
+				t.Fatalf("got allowed=%v by %q, want %v", decision.Allowed, decision.Rule, tt.want)
+			}
+		})
+	}
+}
+
+// TestDecide_ServiceAccountNetwork checks a policy that only lets service accounts in
+// from the internal network.
+func TestDecide_ServiceAccountNetwork(t *testing.T) {
+	data := append(append([]byte{}, policies.Default...), `
+  - name: service-accounts-internal-only
+    effect: deny
+    actions: ['*']
+    when: subject.service_account and not cidr(context.ip, "10.0.0.0/8")
+`...)
+	p, err := Compile(data, "test", domain.PolicyActions)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	permissions := []string{domain.PolicyActionTokenize}
+	tests := []struct {
+		name    string
+		subject Subject
+		ip      string
+		want    bool
+	}{
+		{"service account inside", Subject{ID: "svc", Permissions: permissions, ServiceAccount: true}, "10.1.2.3", true},
+		{"service account outside", Subject{ID: "svc", Permissions: permissions, ServiceAccount: true}, "192.0.2.1", false},
+		{"user outside", Subject{ID: "user", Permissions: permissions}, "192.0.2.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &Input{Subject: tt.subject, Action: domain.PolicyActionTokenize, Context: Context{IP: tt.ip}}
+			if decision := p.Decide(in); decision.Allowed != tt.want {
+				t.Fatalf("got allowed=%v by %q, want %v", decision.Allowed, decision.Rule, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultPolicy_OwnApprovals(t *testing.T) {
+	p := compileDefault(t)
+	in := &Input{Subject: Subject{ID: "user", Permissions: []string{domain.PolicyActionApprovalUse}}, Action: domain.PolicyActionApprovalView}
+
+	in.Resource.Owner = "user"
+	if !p.Decide(in).Allowed {
+		t.Fatal("user cannot view own approval")
+	}
+	in.Resource.Owner = "other"
+	if p.Decide(in).Allowed {
+		t.Fatal("user can view approval of another user")
+	}
+}
+
+const testPolicy = `
+rules:
+  - name: office
+    effect: allow
+    actions: [kinds.*]
+    when: '"specialist" in subject.roles and cidr(context.ip, "10.0.0.0/8")'
+  - name: working-hours
+    effect: deny
+    actions: [kinds.write]
+    when: not (context.hour >= 9 and context.hour < 18) or context.weekday in [6, 7]
+`
+
+func TestDecide_DenyOverridesAllow(t *testing.T) {
+	p, err := Compile([]byte(testPolicy), "test", domain.PolicyActions)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	monday := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		action  string
+		ip      string
+		at      time.Time
+		want    bool
+		decided string
+	}{
+		{"read in office", domain.PolicyActionKindRead, "10.1.2.3", saturday, true, "office"},
+		{"read from outside", domain.PolicyActionKindRead, "192.168.1.1", monday, false, ""},
+		{"write in working hours", domain.PolicyActionKindWrite, "10.1.2.3", monday, true, "office"},
+		{"write on weekend", domain.PolicyActionKindWrite, "10.1.2.3", saturday, false, "working-hours"},
+		{"write at night", domain.PolicyActionKindWrite, "10.1.2.3", monday.Add(10 * time.Hour), false, "working-hours"},
+		{"other action", domain.PolicyActionMappingRead, "10.1.2.3", monday, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := p.Decide(&Input{
+				Subject: subjectWithRoles("specialist"),
+				Action:  tt.action,
+				Context: Context{IP: tt.ip, Time: tt.at},
+			})
+			if decision.Allowed != tt.want || decision.Rule != tt.decided {
+				t.Fatalf("got allowed=%v by %q, want %v by %q", decision.Allowed, decision.Rule, tt.want, tt.decided)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		want   string
+	}{
+		{"no rules", `rules: []`, "no rules"},
+		{"unknown field", "rules:\n  - name: a\n    effect: allow\n    actions: ['*']\n    condition: 'true'", "condition"},
+		{"duplicate name", "rules:\n  - {name: a, effect: allow, actions: ['*']}\n  - {name: a, effect: deny, actions: ['*']}", "duplicate"},
+		{"bad effect", "rules:\n  - {name: a, effect: permit, actions: ['*']}", "effect"},
+		{"unknown action", "rules:\n  - {name: a, effect: allow, actions: [kind.read]}", "unknown action"},
+		{"unknown attribute", "rules:\n  - {name: a, effect: allow, actions: ['*'], when: 'subject.role == \"admin\"'}", "unknown attribute"},
+		{"type mismatch", "rules:\n  - {name: a, effect: allow, actions: ['*'], when: 'subject.clearance == \"4\"'}", "cannot compare"},
+		{"not bool", "rules:\n  - {name: a, effect: allow, actions: ['*'], when: 'subject.clearance'}", "not bool"},
+		{"bad network", "rules:\n  - {name: a, effect: allow, actions: ['*'], when: 'cidr(context.ip, \"10.0.0/8\")'}", "invalid network"},
+		{"trailing input", "rules:\n  - {name: a, effect: allow, actions: ['*'], when: 'true true'}", "unexpected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile([]byte(tt.policy), "test", domain.PolicyActions)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got error %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxAccessLevel(t *testing.T) {
+	p := compileDefault(t)
+
+	tests := []struct {
+		name    string
+		subject Subject
+		want    int64
+	}{
+		{"clearance", Subject{Clearance: 2}, 2},
+		{"no clearance", Subject{}, NoAccessLevel},
+		{"top clearance", Subject{Clearance: 4}, 0},
+		{"kind grants", Subject{Clearance: 2, KindGrants: []int64{5}}, 0},
+		{"break-glass", Subject{Clearance: 1, BreakGlass: true}, 0},
+		{"kind operations", Subject{Clearance: 2, KindOperations: map[int64][]string{5: {"view"}}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := Input{Subject: tt.subject, Action: domain.PolicyActionDataAccess, Resource: Resource{Operation: "view"}}
+			got := p.MaxAccessLevel(in, 4)
+			if got != tt.want {
+				t.Fatalf("got %d want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	write := func(policy string) {
+		if err := os.WriteFile(path, []byte(policy), 0o600); err != nil {
+			t.Fatalf("write policy: %v", err)
+		}
+	}
+
+	write("rules:\n  - {name: a, effect: allow, actions: ['*']}")
+	e, err := NewEngine(policies.Default, path, domain.PolicyActions)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	version := e.Policy().Version
+
+	if replaced, err := e.Reload(); replaced || err != nil {
+		t.Fatalf("unchanged file: got replaced=%v err=%v", replaced, err)
+	}
+
+	write("rules:\n  - {name: a, effect: deny, actions: ['*']}\n  - {name: b")
+	if replaced, err := e.Reload(); replaced || err == nil {
+		t.Fatalf("invalid file: got replaced=%v err=%v", replaced, err)
+	}
+	if e.Policy().Version != version {
+		t.Fatal("invalid file replaced the policy")
+	}
+
+	write("rules:\n  - {name: a, effect: deny, actions: ['*']}")
+	if replaced, err := e.Reload(); !replaced || err != nil {
+		t.Fatalf("changed file: got replaced=%v err=%v", replaced, err)
+	}
+	if e.Decide(&Input{Action: domain.PolicyActionKindRead}).Allowed {
+		t.Fatal("reloaded policy is not in force")
+	}
+}
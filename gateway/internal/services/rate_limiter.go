@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"github.com/NeF2le/anonix/gateway/internal/ports"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimiter limits the requests of every caller with token buckets per route group,
+// the daily detokenizations per access level and the login attempts per client IP and
+// per login. A group, level or login limit that is not configured is not limited.
+type RateLimiter struct {
+	repo ports.RateLimitRepository
+	// groupLimits are the limits of route groups; roleLimits and userLimits override
+	// them for roles and users and are keyed by group, then by role name or user ID.
+	groupLimits      map[string]domain.RateLimit
+	roleLimits       map[string]map[string]domain.RateLimit
+	userLimits       map[string]map[string]domain.RateLimit
+	detokenizeQuotas map[int32]int64
+	loginIPLimit     domain.RateLimit
+	loginLimit       domain.RateLimit
+	// failOpen lets detokenizations through while their daily quota cannot be checked.
+	failOpen bool
+}
+
+// ParseRateLimit parses a limit written as "requests/period", e.g. "600/1m" or "10/s".
+func ParseRateLimit(value string) (domain.RateLimit, error) {
+	burst, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return domain.RateLimit{}, fmt.Errorf("invalid rate limit %q: expected requests/period", value)
+	}
+	n, err := strconv.ParseInt(burst, 10, 64)
+	if err != nil || n <= 0 {
+		return domain.RateLimit{}, fmt.Errorf("invalid number of requests in rate limit %q", value)
+	}
+	if period != "" && (period[0] < '0' || period[0] > '9') {
+		period = "1" + period
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d < time.Millisecond {
+		return domain.RateLimit{}, fmt.Errorf("invalid period in rate limit %q", value)
+	}
+	return domain.RateLimit{Burst: n, Period: d}, nil
+}
+
+// parseOverrides parses limits keyed by "group.name" into limits by group and name.
+func parseOverrides(overrides map[string]string) (map[string]map[string]domain.RateLimit, error) {
+	limits := make(map[string]map[string]domain.RateLimit)
+	for key, value := range overrides {
+		group, name, ok := strings.Cut(key, ".")
+		if !ok || group == "" || name == "" {
+			return nil, fmt.Errorf("invalid rate limit key %q: expected group.name", key)
+		}
+		limit, err := ParseRateLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		if limits[group] == nil {
+			limits[group] = make(map[string]domain.RateLimit)
+		}
+		limits[group][name] = limit
+	}
+	return limits, nil
+}
+
+// NewRateLimiter creates a rate limiter. groupLimits maps route groups to limits,
+// roleLimits and userLimits map "group.role" and "group.userID" to limits overriding
+// them, detokenizeQuotas maps access levels to daily quotas. Empty login limits disable
+// the limits of login attempts. failOpen lets detokenizations through while their quota
+// cannot be checked.
+func NewRateLimiter(
+	repo ports.RateLimitRepository,
+	groupLimits map[string]string,
+	roleLimits map[string]string,
+	userLimits map[string]string,
+	detokenizeQuotas map[string]string,
+	loginIPLimit string,
+	loginLimit string,
+	failOpen bool,
+) (*RateLimiter, error) {
+	r := &RateLimiter{
+		repo:             repo,
+		failOpen:         failOpen,
+		groupLimits:      make(map[string]domain.RateLimit, len(groupLimits)),
+		detokenizeQuotas: make(map[int32]int64, len(detokenizeQuotas)),
+	}
+
+	for group, value := range groupLimits {
+		limit, err := ParseRateLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		r.groupLimits[group] = limit
+	}
+
+	var err error
+	if r.roleLimits, err = parseOverrides(roleLimits); err != nil {
+		return nil, err
+	}
+	if r.userLimits, err = parseOverrides(userLimits); err != nil {
+		return nil, err
+	}
+
+	for level, value := range detokenizeQuotas {
+		l, err := strconv.ParseInt(level, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access level %q in detokenize quotas", level)
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("invalid detokenize quota %q of access level %s", value, level)
+		}
+		r.detokenizeQuotas[int32(l)] = v
+	}
+
+	if loginIPLimit != "" {
+		if r.loginIPLimit, err = ParseRateLimit(loginIPLimit); err != nil {
+			return nil, err
+		}
+	}
+	if loginLimit != "" {
+		if r.loginLimit, err = ParseRateLimit(loginLimit); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// FailOpen reports whether detokenizations are let through while their daily quota
+// cannot be checked.
+func (r *RateLimiter) FailOpen() bool {
+	return r.failOpen
+}
+
+// limitFor returns the limit of the group for the user: the user's own limit, else the
+// most generous limit of the user's roles, else the limit of the group.
+func (r *RateLimiter) limitFor(group, userID string, roles []string) (domain.RateLimit, bool) {
+	if limit, ok := r.userLimits[group][userID]; ok && userID != "" {
+		return limit, true
+	}
+
+	var best domain.RateLimit
+	found := false
+	for _, role := range roles {
+		if limit, ok := r.roleLimits[group][role]; ok && (!found || limit.Exceeds(best)) {
+			best, found = limit, true
+		}
+	}
+	if found {
+		return best, true
+	}
+
+	limit, ok := r.groupLimits[group]
+	return limit, ok
+}
+
+// Allow takes a request of the group from the bucket of the user, or of the client IP
+// for anonymous requests. If the request is over the limit, it returns false and the
+// time until the next request is allowed.
+func (r *RateLimiter) Allow(ctx context.Context, group, userID string, roles []string, clientIP string) (bool, time.Duration, error) {
+	limit, ok := r.limitFor(group, userID, roles)
+	if !ok {
+		return true, 0, nil
+	}
+
+	subject := "ip:" + clientIP
+	if userID != "" {
+		subject = "user:" + userID
+	}
+	return r.repo.TakeToken(ctx, group+":"+subject, limit)
+}
+
+// AllowLogin takes a login attempt from the buckets of the client IP and of the login.
+// Logins are trimmed and lowercased, so that variants of one login share a bucket, and
+// hashed, so they are not kept in Redis in clear.
+func (r *RateLimiter) AllowLogin(ctx context.Context, clientIP, login string) (bool, time.Duration, error) {
+	if r.loginIPLimit.Burst > 0 {
+		allowed, retryAfter, err := r.repo.TakeToken(ctx, "login:ip:"+clientIP, r.loginIPLimit)
+		if err != nil || !allowed {
+			return allowed, retryAfter, err
+		}
+	}
+	if r.loginLimit.Burst > 0 {
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(login))))
+		return r.repo.TakeToken(ctx, "login:login:"+hex.EncodeToString(sum[:]), r.loginLimit)
+	}
+	return true, 0, nil
+}
+
+// ConsumeDetokenizeQuota counts a detokenization of data of the access level against the
+// daily quota of the user. Quotas reset at midnight UTC; if the quota is used up, it
+// returns false and the time until the reset.
+func (r *RateLimiter) ConsumeDetokenizeQuota(ctx context.Context, userID string, accessLevel int32) (bool, time.Duration, error) {
+	quota, ok := r.detokenizeQuotas[accessLevel]
+	if !ok {
+		return true, 0, nil
+	}
+
+	now := time.Now()
+	day, resetAt := quotaDay(now)
+	key := fmt.Sprintf("detokenize:%s:level:%d:%s", userID, accessLevel, day)
+
+	allowed, err := r.repo.ConsumeQuota(ctx, key, quota, resetAt)
+	if err != nil || allowed {
+		return allowed, 0, err
+	}
+	return false, resetAt.Sub(now), nil
+}
+
+// quotaDay returns the UTC day the time counts against daily quotas on and the midnight
+// the day ends at.
+func quotaDay(now time.Time) (string, time.Time) {
+	now = now.UTC()
+	return now.Format(time.DateOnly), time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
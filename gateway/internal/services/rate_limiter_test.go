@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"github.com/NeF2le/anonix/gateway/internal/domain"
+	"testing"
+	"time"
+)
+
+// fakeRateLimitRepo records the keys taken from and allows requests while allowed is set.
+type fakeRateLimitRepo struct {
+	allowed bool
+	keys    []string
+	resetAt time.Time
+}
+
+func (r *fakeRateLimitRepo) TakeToken(_ context.Context, key string, _ domain.RateLimit) (bool, time.Duration, error) {
+	r.keys = append(r.keys, key)
+	if !r.allowed {
+		return false, time.Second, nil
+	}
+	return true, 0, nil
+}
+
+func (r *fakeRateLimitRepo) ConsumeQuota(_ context.Context, key string, _ int64, resetAt time.Time) (bool, error) {
+	r.keys = append(r.keys, key)
+	r.resetAt = resetAt
+	return r.allowed, nil
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    domain.RateLimit
+		wantErr bool
+	}{
+		{"600/1m", domain.RateLimit{Burst: 600, Period: time.Minute}, false},
+		{"10/s", domain.RateLimit{Burst: 10, Period: time.Second}, false},
+		{"5/h", domain.RateLimit{Burst: 5, Period: time.Hour}, false},
+		{"100/500ms", domain.RateLimit{Burst: 100, Period: 500 * time.Millisecond}, false},
+		{"600", domain.RateLimit{}, true},
+		{"0/1m", domain.RateLimit{}, true},
+		{"-1/1m", domain.RateLimit{}, true},
+		{"x/1m", domain.RateLimit{}, true},
+		{"10/", domain.RateLimit{}, true},
+		{"10/fortnight", domain.RateLimit{}, true},
+		{"10/1us", domain.RateLimit{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRateLimit(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got err=%v, want err=%v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_LimitFor(t *testing.T) {
+	r, err := NewRateLimiter(&fakeRateLimitRepo{},
+		map[string]string{"detokenize": "120/1m", "admin": "60/1m"},
+		map[string]string{"detokenize.operator": "300/1m", "detokenize.auditor": "10/s"},
+		map[string]string{"detokenize.u1": "5/1m"},
+		nil, "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		group  string
+		userID string
+		roles  []string
+		want   domain.RateLimit
+		wantOK bool
+	}{
+		{"group limit", "detokenize", "u2", []string{"viewer"}, domain.RateLimit{Burst: 120, Period: time.Minute}, true},
+		{"role overrides group", "detokenize", "u2", []string{"operator"}, domain.RateLimit{Burst: 300, Period: time.Minute}, true},
+		{"most generous role", "detokenize", "u2", []string{"operator", "auditor", "viewer"}, domain.RateLimit{Burst: 10, Period: time.Second}, true},
+		{"user overrides roles", "detokenize", "u1", []string{"operator"}, domain.RateLimit{Burst: 5, Period: time.Minute}, true},
+		{"anonymous caller", "detokenize", "", []string{"operator"}, domain.RateLimit{Burst: 300, Period: time.Minute}, true},
+		{"override of another group", "admin", "u1", []string{"operator"}, domain.RateLimit{Burst: 60, Period: time.Minute}, true},
+		{"group without limit", "tokenize", "u1", nil, domain.RateLimit{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.limitFor(tt.group, tt.userID, tt.roles)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("got %+v %v, want %+v %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewRateLimiter_InvalidOverride(t *testing.T) {
+	for _, roles := range []map[string]string{{"operator": "10/1m"}, {".operator": "10/1m"}, {"detokenize.operator": "10"}} {
+		if _, err := NewRateLimiter(&fakeRateLimitRepo{}, nil, roles, nil, nil, "", "", false); err == nil {
+			t.Errorf("role limits %v accepted", roles)
+		}
+	}
+}
+
+func TestRateLimiter_AllowLogin(t *testing.T) {
+	repo := &fakeRateLimitRepo{allowed: true}
+	r, err := NewRateLimiter(repo, nil, nil, nil, nil, "20/1m", "5/1m", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, login := range []string{"ivanov", "  Ivanov", "IVANOV\t"} {
+		if _, _, err = r.AllowLogin(ctx, "203.0.113.7", login); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(repo.keys) != 6 {
+		t.Fatalf("got keys %v, want 6", repo.keys)
+	}
+	if repo.keys[1] != repo.keys[3] || repo.keys[1] != repo.keys[5] {
+		t.Fatalf("variants of one login got buckets %s, %s and %s", repo.keys[1], repo.keys[3], repo.keys[5])
+	}
+	if repo.keys[0] != "login:ip:203.0.113.7" {
+		t.Fatalf("got ip bucket %s", repo.keys[0])
+	}
+
+	repo.allowed, repo.keys = false, nil
+	if allowed, _, _ := r.AllowLogin(ctx, "203.0.113.7", "ivanov"); allowed || len(repo.keys) != 1 {
+		t.Fatalf("login over the ip limit: allowed=%v, keys %v", allowed, repo.keys)
+	}
+}
+
+func TestQuotaDay(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantDay   string
+		wantReset time.Time
+	}{
+		{"midnight", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), "2026-03-01",
+			time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"last instant of the day", time.Date(2026, 2, 28, 23, 59, 59, 999999999, time.UTC), "2026-02-28",
+			time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of the year", time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC), "2026-12-31",
+			time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"local time after midnight", time.Date(2026, 3, 1, 1, 30, 0, 0, msk), "2026-02-28",
+			time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, resetAt := quotaDay(tt.now)
+			if day != tt.wantDay || !resetAt.Equal(tt.wantReset) {
+				t.Fatalf("got %s %v, want %s %v", day, resetAt, tt.wantDay, tt.wantReset)
+			}
+			if !resetAt.After(tt.now) || resetAt.Sub(tt.now) > 24*time.Hour {
+				t.Fatalf("reset at %v is not within a day after %v", resetAt, tt.now)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_ConsumeDetokenizeQuota(t *testing.T) {
+	repo := &fakeRateLimitRepo{allowed: true}
+	r, err := NewRateLimiter(repo, nil, nil, nil, map[string]string{"3": "200", "4": "0"}, "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if allowed, _, err := r.ConsumeDetokenizeQuota(ctx, "u1", 1); err != nil || !allowed || len(repo.keys) != 0 {
+		t.Fatalf("level without quota: allowed=%v err=%v keys %v", allowed, err, repo.keys)
+	}
+
+	day, _ := quotaDay(time.Now())
+	if allowed, _, err := r.ConsumeDetokenizeQuota(ctx, "u1", 3); err != nil || !allowed {
+		t.Fatalf("quota not used up: allowed=%v err=%v", allowed, err)
+	}
+	if want := "detokenize:u1:level:3:" + day; repo.keys[0] != want {
+		t.Fatalf("got key %s, want %s", repo.keys[0], want)
+	}
+
+	repo.allowed = false
+	allowed, retryAfter, err := r.ConsumeDetokenizeQuota(ctx, "u1", 4)
+	if err != nil || allowed {
+		t.Fatalf("quota used up: allowed=%v err=%v", allowed, err)
+	}
+	if retryAfter <= 0 || retryAfter > 24*time.Hour || repo.resetAt.Hour() != 0 || repo.resetAt.Location() != time.UTC {
+		t.Fatalf("got retry after %v, reset at %v", retryAfter, repo.resetAt)
+	}
+}
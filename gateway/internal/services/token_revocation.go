@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeF2le/anonix/gateway/internal/ports"
+	"time"
+)
+
+// TokenRevocation makes the access tokens of a user issued before their access was
+// taken away stale, so that the next request of the user gets a new token with their
+// current roles, clearance level and grants. A revocation is kept for ttl, which must
+// be at least the lifetime of access tokens.
+type TokenRevocation struct {
+	repo ports.TokenRevocationRepository
+	ttl  time.Duration
+}
+
+func NewTokenRevocation(repo ports.TokenRevocationRepository, ttl time.Duration) (*TokenRevocation, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("token revocation TTL must be positive")
+	}
+
+	return &TokenRevocation{repo: repo, ttl: ttl}, nil
+}
+
+// Revoke makes the access tokens of the user issued so far stale.
+func (r *TokenRevocation) Revoke(ctx context.Context, userID string) error {
+	return r.repo.SaveRevokedAt(ctx, userID, time.Now(), r.ttl)
+}
+
+// IsRevoked reports whether an access token of the user issued at issuedAt is stale.
+// Token times are kept to the second, so a token issued in the second of a revocation
+// is not.
+func (r *TokenRevocation) IsRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error) {
+	revokedAt, err := r.repo.GetRevokedAt(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return issuedAt.Unix() < revokedAt.Unix(), nil
+}
@@ -0,0 +1,9 @@
+// Package policies holds the built-in access policy of the gateway.
+package policies
+
+import _ "embed"
+
+// Default is the policy in force unless POLICY_FILE names another one.
+//
+//go:embed policy.yaml
+var Default []byte
@@ -5,7 +5,7 @@ const getBase = () => {
   catch { return '/api/v1'; }
 };
 
-const call = async (method, path, body = null) => {
+const send = async (method, path, body = null) => {
   const opts = {
     method,
     credentials: 'include',
@@ -16,19 +16,43 @@ const call = async (method, path, body = null) => {
   const res = await fetch(`${getBase()}${path}`, opts);
   if (!res.ok) {
     let msg = res.statusText;
-    try { const d = await res.json(); msg = d.error || d.message || msg; } catch {}
+    let details;
+    try { const d = await res.json(); msg = d.error || d.message || msg; details = d.details; } catch {}
     const err = new Error(translateError(msg) || `HTTP ${res.status}`);
-    err.status = res.status;
+    err.status  = res.status;
+    err.details = details;
     throw err;
   }
+  return res;
+};
+
+// Operations requiring four-eyes approval answer 202 with the pending approval instead
+// of their result.
+const call = async (method, path, body = null) => {
+  const res  = await send(method, path, body);
   const text = await res.text();
-  return text ? JSON.parse(text) : null;
+  const data = text ? JSON.parse(text) : null;
+  if (res.status === 202 && data) data.pending_approval = true;
+  return data;
+};
+
+export const isPendingApproval = (resp) => resp?.pending_approval === true;
+export const PENDING_APPROVAL_MESSAGE = 'Операция отправлена на согласование другому администратору';
+
+const query = (params) => {
+  const qs = new URLSearchParams();
+  Object.entries(params).forEach(([k, v]) => { if (v !== '' && v !== null && v !== undefined) qs.set(k, v); });
+  const str = qs.toString();
+  return str ? `?${str}` : '';
 };
 
 export const api = {
   login:    (login, password)           => call('POST',   '/auth/signIn', { login, password }),
   register: (login, password, role_id)  => call('POST',   '/auth/signUp', { login, password, role_id }),
   getMe:    ()                          => call('GET',    '/auth/me'),
+  getOidcInfo: ()                       => call('GET',    '/auth/oidc'),
+  // Sign-in through the identity provider is a browser navigation, not a fetch.
+  oidcLoginUrl: ()                      => `${getBase()}/auth/oidc/login`,
 
   getUsers:   ()               => call('GET',    '/user/list'),
   deleteUser: (userId)         => call('DELETE', '/user/delete',     { user_id: userId }),
@@ -36,7 +60,27 @@ export const api = {
   removeRole: (userId, roleId) => call('DELETE', '/user/removeRole', { user_id: userId, role_id: roleId }),
   updateClearance: (userId, level) => call('PATCH', '/user/clearance', { user_id: userId, clearance_level: level }),
 
-  getRoles: () => call('GET', '/role/list'),
+  getAccessGrants:   (userId = '', active = false) => call('GET',    `/user/grants${query({ user_id: userId, active: active || '' })}`),
+  createAccessGrant: (data)                        => call('POST',   '/user/grants', data),
+  revokeAccessGrant: (id)                          => call('DELETE', `/user/grants/${id}`),
+
+  getKindOperationGrants:   ()     => call('GET',    '/user/kind-operations'),
+  setKindOperationGrant:    (data) => call('PUT',    '/user/kind-operations', data),
+  deleteKindOperationGrant: (id)   => call('DELETE', `/user/kind-operations/${id}`),
+
+  getRoles:       ()         => call('GET',    '/role/list'),
+  getPermissions: ()         => call('GET',    '/role/permissions'),
+  createRole:     (data)     => call('POST',   '/role/', data),
+  updateRole:     (id, data) => call('PUT',    `/role/${id}`, data),
+  deleteRole:     (id)       => call('DELETE', `/role/${id}`),
+
+  getServiceAccounts:   ()                  => call('GET',    '/service-accounts/'),
+  createServiceAccount: (data)              => call('POST',   '/service-accounts/', data),
+  deleteServiceAccount: (id)                => call('DELETE', `/service-accounts/${id}`),
+  getApiKeys:           (accountId = '', active = false) => call('GET', `/service-accounts/keys${query({ service_account_id: accountId, active: active || '' })}`),
+  createApiKey:         (accountId, data)   => call('POST',   `/service-accounts/${accountId}/keys`, data),
+  rotateApiKey:         (id, graceHours)    => call('POST',   `/service-accounts/keys/${id}/rotate`, { grace_hours: graceHours }),
+  revokeApiKey:         (id)                => call('DELETE', `/service-accounts/keys/${id}`),
 
   getKinds:   ()          => call('GET',    '/kinds/'),
   createKind: (data)      => call('POST',   '/kinds/', data),
@@ -47,9 +91,29 @@ export const api = {
   deleteMapping:  (id)         => call('DELETE', `/mappings/${id}`),
   updateMapping:  (id, ttlNs)  => call('PATCH',  `/mappings/${id}`, { token_ttl: ttlNs }),
 
-  getAuditLog: () => call('GET', '/audit/'),
+  getAuditLog: async (params = {}) => {
+    const res     = await send('GET', `/audit/${query(params)}`);
+    const entries = await res.json();
+    return { entries: entries || [], nextCursor: res.headers.get('X-Next-Cursor') };
+  },
+  auditExportUrl: (params = {}, format = 'csv') => `${getBase()}/audit/export${query({ ...params, format })}`,
+  verifyAuditLog: () => call('GET', '/audit/verify'),
+
+  getPurposes:   ()     => call('GET',    '/purposes/'),
+  createPurpose: (data) => call('POST',   '/purposes/', data),
+  deletePurpose: (id)   => call('DELETE', `/purposes/${id}`),
+
+  getLegalHolds:    (activeOnly = false) => call('GET',  `/legal-holds/${activeOnly ? '?active=true' : ''}`),
+  createLegalHold:  (data)               => call('POST', '/legal-holds/', data),
+  releaseLegalHold: (id)                 => call('POST', `/legal-holds/${id}/release`, {}),
+
+  getDestructionAct:     (from, to) => call('GET', `/reports/destruction?from=${from}&to=${to}`),
+  destructionActHtmlUrl: (from, to) => `${getBase()}/reports/destruction?from=${from}&to=${to}&format=html`,
+
+  getSubjectMappings: (ref) => call('GET',    `/subjects/${encodeURIComponent(ref)}/mappings`),
+  eraseSubject:       (ref) => call('DELETE', `/subjects/${encodeURIComponent(ref)}`),
 
-  tokenize:    (plaintext, kindId, deterministic, mode, tokenTtlSec, algorithm) => {
+  tokenize:    (plaintext, kindId, deterministic, mode, tokenTtlSec, algorithm, subjectRef = '') => {
     const bytes = new TextEncoder().encode(plaintext);
     const b64   = btoa(String.fromCharCode(...bytes));
     return call('POST', '/tokenizer/tokenize', {
@@ -59,10 +123,30 @@ export const api = {
       mode:          mode,
       token_ttl:     tokenTtlSec,
       algorithm:     algorithm,
+      subject_ref:   subjectRef || undefined,
     });
   },
-  detokenize:  (token) => call('POST', '/tokenizer/detokenize', { token }),
+  detokenize:  (token, purpose) => call('POST', '/tokenizer/detokenize', { token, purpose }),
 
   rotateMasterKey: () => call('POST', '/admin/keys/rotate-master', {}),
   rotateAllDeks:   () => call('POST', '/admin/keys/rotate-deks', {}),
+
+  getAnomalyBlocks: ()       => call('GET',    '/security/blocks'),
+  unblockUser:      (userId) => call('DELETE', `/security/blocks/${userId}`),
+
+  getApprovals:    (status = '')    => call('GET',  `/approvals/${query({ status })}`),
+  approveApproval: (id)             => call('POST', `/approvals/${id}/approve`, {}),
+  rejectApproval:  (id, reason)     => call('POST', `/approvals/${id}/reject`, { reason }),
+  executeApproval: (id)             => call('POST', `/approvals/${id}/execute`, {}),
+  expireApprovals: ()               => call('POST', '/approvals/expire', {}),
+
+  getBreakGlass:   ()                                => call('GET',    '/break-glass/'),
+  startBreakGlass: (justification, durationMinutes) => call('POST',   '/break-glass/', { justification, duration_minutes: durationMinutes }),
+  endBreakGlass:   ()                                => call('DELETE', '/break-glass/'),
+  getBreakGlassSessions:        (unacknowledged = false) => call('GET',  `/break-glass/sessions/${query({ unacknowledged: unacknowledged || '' })}`),
+  getBreakGlassSession:         (id)                     => call('GET',  `/break-glass/sessions/${id}`),
+  acknowledgeBreakGlassSession: (id)                     => call('POST', `/break-glass/sessions/${id}/acknowledge`, {}),
+
+  getPolicy:     ()     => call('GET',  '/policies/'),
+  explainPolicy: (data) => call('POST', '/policies/explain', data),
 };
@@ -12,6 +12,10 @@ import KindsView  from './views/KindsView.js';
 import TokensView from './views/TokensView.js';
 import AuditView  from './views/AuditView.js';
 import SecurityView from './views/SecurityView.js';
+import ApprovalsView from './views/ApprovalsView.js';
+import BreakGlassView from './views/BreakGlassView.js';
+import PoliciesView from './views/PoliciesView.js';
+import ServiceAccountsView from './views/ServiceAccountsView.js';
 
 const MENU_ITEMS = [
   { id: 'users',    label: 'Пользователи'  },
@@ -20,13 +24,18 @@ const MENU_ITEMS = [
   { id: 'tokens',   label: 'Токены'        },
   { id: 'audit',    label: 'Аудит'         },
   { id: 'security', label: 'Безопасность'  },
+  { id: 'approvals', label: 'Согласования' },
+  { id: 'breakglass', label: 'Экстренный доступ' },
+  { id: 'policies', label: 'Политика доступа' },
+  { id: 'serviceaccounts', label: 'Сервисные аккаунты' },
 ];
 
 const App = {
   components: {
     AppHeader, AppModal, AppToasts,
     LoginView, MenuView,
-    UsersView, RolesView, KindsView, TokensView, AuditView, SecurityView,
+    UsersView, RolesView, KindsView, TokensView, AuditView, SecurityView, ApprovalsView, BreakGlassView,
+    PoliciesView, ServiceAccountsView,
   },
 
   setup() {
@@ -38,7 +47,7 @@ const App = {
     };
 
     const screen = ref(initialScreen());
-    const userRoles = ref([]);
+    const userPermissions = ref([]);
 
     const screenTitle = computed(() =>
       MENU_ITEMS.find(m => m.id === screen.value)?.label || ''
@@ -49,13 +58,13 @@ const App = {
       history.pushState({ screen: id }, '', '#' + id);
     };
 
-    const onLogin = (roles) => {
-      userRoles.value = roles || [];
+    const onLogin = (permissions) => {
+      userPermissions.value = permissions || [];
       navigate('menu');
     };
 
     const logout = () => {
-      userRoles.value = [];
+      userPermissions.value = [];
       screen.value = 'login';
       history.pushState({ screen: 'login' }, '', '#login');
     };
@@ -72,9 +81,9 @@ const App = {
       if (screen.value !== 'login') {
         try {
           const resp = await api.getMe();
-          userRoles.value = resp?.roles || [];
+          userPermissions.value = resp?.permissions || [];
         } catch {
-          userRoles.value = [];
+          userPermissions.value = [];
           screen.value = 'login';
           history.replaceState({ screen: 'login' }, '', '#login');
         }
@@ -85,7 +94,7 @@ const App = {
       window.removeEventListener('popstate', onPopState);
     });
 
-    return { screen, screenTitle, userRoles, navigate, onLogin, logout };
+    return { screen, screenTitle, userPermissions, navigate, onLogin, logout };
   },
 
   template: `
@@ -100,13 +109,17 @@ const App = {
           @logout="logout"
         />
         <main class="flex-1 p-6">
-          <MenuView   v-if="screen === 'menu'"            :roles="userRoles" @navigate="navigate" />
+          <MenuView   v-if="screen === 'menu'"            :permissions="userPermissions" @navigate="navigate" />
           <UsersView  v-else-if="screen === 'users'"  />
-          <RolesView  v-else-if="screen === 'roles'"  />
+          <RolesView  v-else-if="screen === 'roles'"  :permissions="userPermissions" />
           <KindsView  v-else-if="screen === 'kinds'"  />
           <TokensView v-else-if="screen === 'tokens'" />
           <AuditView  v-else-if="screen === 'audit'"  />
           <SecurityView v-else-if="screen === 'security'" />
+          <ApprovalsView v-else-if="screen === 'approvals'" :permissions="userPermissions" />
+          <BreakGlassView v-else-if="screen === 'breakglass'" :permissions="userPermissions" />
+          <PoliciesView v-else-if="screen === 'policies'" />
+          <ServiceAccountsView v-else-if="screen === 'serviceaccounts'" :permissions="userPermissions" />
         </main>
       </div>
 
@@ -63,6 +63,18 @@ export default {
                   class="w-full px-3 py-2.5 border border-slate-300 rounded-lg text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500">
                   <option v-for="opt in field.options" :key="opt.value" :value="opt.value">{{ opt.label }}</option>
                 </select>
+                <div v-else-if="field.type === 'checklist'"
+                  class="max-h-64 overflow-y-auto border border-slate-300 rounded-lg divide-y divide-slate-100">
+                  <label v-for="opt in field.options" :key="opt.value"
+                    class="flex items-start gap-2 px-3 py-2 text-sm cursor-pointer select-none hover:bg-slate-50">
+                    <input type="checkbox" :value="opt.value" v-model="modal.values[field.key]"
+                      class="mt-0.5 w-4 h-4 rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
+                    <span>
+                      <span class="font-mono text-xs text-slate-800">{{ opt.value }}</span>
+                      <span v-if="opt.label" class="block text-xs text-slate-500">{{ opt.label }}</span>
+                    </span>
+                  </label>
+                </div>
                 <label v-else-if="field.type === 'checkbox'" class="flex items-center gap-2 text-sm font-medium text-slate-700 cursor-pointer select-none">
                   <input type="checkbox" v-model="modal.values[field.key]"
                     class="w-4 h-4 rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
@@ -45,6 +45,123 @@ const TRANSLATIONS = {
   'please log in first':                  'Пожалуйста, войдите в систему',
   'invalid credentials':                  'Неверный логин или пароль',
   'plaintext is required':                'Необходимо указать исходные данные',
+  'purpose is required':                  'Необходимо указать цель обработки',
+  'purpose not allowed':                  'Токен не может использоваться для указанной цели обработки',
+  'consent expired':                      'Срок действия согласия субъекта истёк',
+  'unknown purpose':                      'Цель обработки отсутствует в каталоге',
+  'purpose already exists':               'Такая цель обработки уже существует',
+  'purpose not found':                    'Цель обработки не найдена',
+  'purpose is in use and cannot be deleted': 'Цель обработки назначена токенам и не может быть удалена',
+  'mapping is under legal hold':          'Токен находится на удержании и не может быть удалён',
+  'subject has mappings under legal hold': 'Часть токенов субъекта находится на удержании',
+  'subject mappings changed': 'Токены субъекта изменились во время удаления, повторите запрос',
+  'legal hold already exists':            'Удержание с таким названием уже существует',
+  'legal hold not found':                 'Удержание не найдено',
+  'legal hold already released':          'Удержание уже снято',
+  'invalid period':                       'Некорректный период',
+  'failed to get destruction act':        'Не удалось сформировать акт уничтожения',
+  'failed to verify audit log':           'Не удалось проверить целостность журнала аудита',
+  'failed to export audit log':           'Не удалось выгрузить журнал аудита',
+  'invalid user_id':                      'Некорректный ID пользователя',
+  'invalid from':                         'Некорректное начало периода',
+  'invalid to':                           'Некорректный конец периода',
+  'failed to write audit log':            'Не удалось записать операцию в журнал аудита, операция не выполнена',
+  'invalid outcome':                      'Некорректный результат операции',
+  'account temporarily blocked':          'Учётная запись временно заблокирована из-за аномального объёма детокенизации',
+  're-authentication required':           'Из-за аномального объёма детокенизации необходимо войти заново',
+  'block not found':                      'Блокировка не найдена',
+  'failed to get blocks':                 'Не удалось получить список блокировок',
+  'failed to unblock user':               'Не удалось снять блокировку',
+  'quota check unavailable':              'Проверка дневной квоты временно недоступна, повторите позже',
+  'token revocation check unavailable':   'Проверка отзыва токенов временно недоступна, повторите позже',
+  'anomaly check unavailable':            'Проверка аномалий временно недоступна, повторите позже',
+  'rate limit exceeded':                  'Слишком много запросов, повторите позже',
+  'daily quota exceeded':                 'Исчерпана дневная квота детокенизации для этого уровня доступа',
+  'too many login attempts':              'Слишком много попыток входа, повторите позже',
+  'approval not found':                   'Запрос на согласование не найден',
+  'approval is not pending':              'Запрос уже рассмотрен',
+  'approval is not approved':             'Запрос не одобрен',
+  'approval expired':                     'Срок запроса на согласование истёк',
+  'requester cannot approve own request': 'Нельзя одобрить собственный запрос',
+  'requester access revoked':             'У инициатора запроса больше нет доступа к этой операции',
+  'invalid status':                       'Некорректный статус',
+  'failed to get approvals':              'Не удалось получить запросы на согласование',
+  'failed to get approval':               'Не удалось получить запрос на согласование',
+  'failed to create approval':            'Не удалось создать запрос на согласование',
+  'failed to approve':                    'Не удалось одобрить запрос',
+  'failed to reject':                     'Не удалось отклонить запрос',
+  'failed to execute approval':           'Не удалось выполнить одобренную операцию',
+  'failed to expire approvals':           'Не удалось завершить просроченные запросы',
+  'break-glass session not found':        'Сессия экстренного доступа не найдена',
+  'break-glass session already active':   'Сессия экстренного доступа уже активна',
+  'break-glass session is not active':    'Сессия экстренного доступа не активна',
+  'user cannot acknowledge own break-glass session': 'Нельзя подтвердить проверку собственной сессии экстренного доступа',
+  'failed to start break-glass session':  'Не удалось включить экстренный доступ',
+  'failed to end break-glass session':    'Не удалось завершить экстренный доступ',
+  'failed to get break-glass session':    'Не удалось получить сессию экстренного доступа',
+  'failed to get break-glass sessions':   'Не удалось получить сессии экстренного доступа',
+  'failed to acknowledge break-glass session': 'Не удалось подтвердить проверку сессии',
+  'failed to issue access token':         'Не удалось выдать токен доступа',
+  'invalid justification':                'Обоснование слишком короткое или слишком длинное',
+  'invalid duration':                     'Длительность превышает допустимую',
+  'invalid break_glass':                  'Некорректный фильтр экстренного доступа',
+  'invalid access grant':                 'Укажите либо уровень допуска от 1 до 4, либо вид данных',
+  'invalid reason':                       'Основание должно быть заполнено и не длиннее 500 символов',
+  'invalid active':                       'Некорректный фильтр действующих доступов',
+  'user cannot grant access to themselves': 'Нельзя выдать доступ самому себе или своей роли',
+  'access grant not found':               'Временный доступ не найден',
+  'access grant is not active':           'Временный доступ уже истёк или отозван',
+  'failed to create access grant':        'Не удалось выдать временный доступ',
+  'failed to revoke access grant':        'Не удалось отозвать временный доступ',
+  'failed to get access grants':          'Не удалось получить временные доступы',
+  'invalid kind operation grant':         'Укажите пользователя или роль, вид данных и хотя бы одну операцию',
+  'invalid grant ID':                     'Некорректный ID разрешения',
+  'invalid effective':                    'Некорректный фильтр разрешений',
+  'invalid operation':                    'Неизвестная операция над видом данных',
+  'kind operation grant not found':       'Разрешение операций не найдено',
+  'failed to set kind operation grant':   'Не удалось разрешить операции',
+  'failed to delete kind operation grant': 'Не удалось удалить разрешение операций',
+  'failed to get kind operation grants':  'Не удалось получить разрешения операций',
+  'invalid service account name':         'Имя сервисного аккаунта — от 2 до 50 строчных латинских букв, цифр, «_» или «-»',
+  'invalid service account ID':           'Некорректный ID сервисного аккаунта',
+  'service account not found':            'Сервисный аккаунт не найден',
+  'service account already exists':       'Сервисный аккаунт с таким именем уже существует',
+  'failed to create service account':     'Не удалось создать сервисный аккаунт',
+  'failed to delete service account':     'Не удалось удалить сервисный аккаунт',
+  'failed to get service accounts':       'Не удалось получить сервисные аккаунты',
+  'invalid api key scope':                'Выберите хотя бы одно разрешение и укажите корректные виды данных и адреса',
+  'api key scope exceeds caller access':  'Нельзя выдать ключу разрешения или виды данных, которых нет у вас',
+  'role permissions exceed caller access': 'Нельзя включить в роль разрешения, которых нет у вас',
+  'invalid expiry':                       'Срок действия ключа должен быть положительным и не больше допустимого',
+  'invalid grace period':                 'Переходный период — от 0 до 168 часов',
+  'api key not found':                    'API-ключ не найден',
+  'api key is not active':                'API-ключ уже истёк или отозван',
+  'failed to create api key':             'Не удалось выпустить API-ключ',
+  'failed to rotate api key':             'Не удалось заменить API-ключ',
+  'failed to revoke api key':             'Не удалось отозвать API-ключ',
+  'failed to get api keys':               'Не удалось получить API-ключи',
+  'invalid policy':                       'Политика доступа содержит ошибки',
+  'invalid action':                       'Неизвестное действие политики',
+  'invalid ip':                           'Некорректный IP-адрес',
+  'invalid time':                         'Некорректное время',
+  'invalid role ID':                      'Некорректный ID роли',
+  'invalid role name':                    'Название роли — от 2 до 50 строчных латинских букв, цифр, «_» или «-»',
+  'invalid description':                  'Описание не должно превышать 200 символов',
+  'role must have at least one permission': 'Выберите хотя бы одно разрешение',
+  'unknown permission':                   'Неизвестное разрешение',
+  'role not found':                       'Роль не найдена',
+  'role already exists':                  'Роль с таким названием уже существует',
+  'role is assigned to users':            'Роль назначена пользователям',
+  'built-in role cannot be changed':      'Встроенную роль нельзя изменить',
+  'failed to get permissions':            'Не удалось получить каталог разрешений',
+  'failed to create role':                'Не удалось создать роль',
+  'failed to update role':                'Не удалось изменить роль',
+  'failed to delete role':                'Не удалось удалить роль',
+  'sso_failed':                           'Не удалось войти через поставщика удостоверений',
+  'sso_denied':                           'Поставщик удостоверений отклонил вход',
+  'sso_invalid_state':                    'Сеанс входа истёк или недействителен — попробуйте ещё раз',
+  'sso_no_roles':                         'Вашим группам у поставщика удостоверений не назначены роли в Anonix',
+  'sso_login_taken':                      'Логин занят другим пользователем Anonix — обратитесь к администратору',
 };
 
 export const translateError = (message) => TRANSLATIONS[message] || message;
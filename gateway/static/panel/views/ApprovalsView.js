@@ -0,0 +1,238 @@
+import { ref, computed, onMounted } from '../vue.js';
+import { api } from '../api.js';
+import { useToast } from '../composables/useToast.js';
+import { useModal } from '../composables/useModal.js';
+
+const STATUS_LABELS = {
+  pending:  'Ожидает',
+  approved: 'Одобрен',
+  rejected: 'Отклонён',
+  expired:  'Истёк',
+  executed: 'Выполнен',
+};
+
+const STATUS_CLASSES = {
+  pending:  'text-amber-700',
+  approved: 'text-indigo-700',
+  rejected: 'text-red-700',
+  expired:  'text-slate-500',
+  executed: 'text-green-700',
+};
+
+const OPERATION_LABELS = {
+  detokenize:        'Детокенизация',
+  mapping_delete:    'Удаление токена',
+  erase:             'Удаление данных субъекта',
+  hold_release:      'Снятие удержания',
+  kind_delete:       'Удаление вида данных',
+  rotate_master_key: 'Ротация мастер-ключа',
+  rotate_deks:       'Ротация ключей данных',
+  user_delete:       'Удаление пользователя',
+  role_assign:       'Назначение роли',
+  role_create:       'Создание роли',
+  role_update:       'Изменение роли',
+  clearance_update:  'Изменение уровня допуска',
+  kind_operation_grant_set: 'Разрешение операций над видом данных',
+};
+
+const b64ToText = (b64) => {
+  try {
+    const bytes = Uint8Array.from(atob(b64), c => c.charCodeAt(0));
+    return new TextDecoder().decode(bytes);
+  } catch {
+    return b64;
+  }
+};
+
+export default {
+  props: {
+    permissions: { type: Array, default: () => [] },
+  },
+  setup(props) {
+    const { show: toast }        = useToast();
+    const { modal, open, close } = useModal();
+
+    const approvals = ref([]);
+    const loading   = ref(false);
+    const error     = ref('');
+    const status    = ref('pending');
+    const myId      = ref('');
+
+    const canReview = computed(() => props.permissions.includes('approvals.review'));
+
+    const formatDate = (value) => value ? new Date(value).toLocaleString('ru-RU') : '—';
+
+    const load = async () => {
+      loading.value = true;
+      error.value   = '';
+      try {
+        approvals.value = (await api.getApprovals(status.value)) || [];
+      } catch (e) {
+        error.value = e.message;
+      } finally {
+        loading.value = false;
+      }
+    };
+
+    const openApproveModal = (approval) => {
+      open({
+        type:    'confirm',
+        title:   'Одобрение запроса',
+        message: `Одобрить операцию «${OPERATION_LABELS[approval.operation] || approval.operation}» пользователя ${approval.requester_id}? `
+          + (approval.operation === 'detokenize'
+            ? 'Инициатор сможет выполнить детокенизацию в течение окна согласования.'
+            : 'Операция будет выполнена сразу от имени инициатора.'),
+        confirmLabel: 'Одобрить',
+        confirmLoadingLabel: 'Выполняется...',
+        confirmClass: 'bg-indigo-600 hover:bg-indigo-700',
+        onConfirm: async () => {
+          modal.loading = true;
+          try {
+            await api.approveApproval(approval.id);
+            close();
+            toast('Запрос одобрен');
+            await load();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openRejectModal = (approval) => {
+      open({
+        type:  'form',
+        title: 'Отклонение запроса',
+        fields: [
+          { key: 'reason', label: 'Причина', type: 'text', required: false, placeholder: 'Необязательно' },
+        ],
+        values: { reason: '' },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            await api.rejectApproval(approval.id, modal.values.reason);
+            close();
+            toast('Запрос отклонён');
+            await load();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const execute = async (approval) => {
+      try {
+        const resp = await api.executeApproval(approval.id);
+        open({
+          type:       'result',
+          title:      'Результат дешифрования',
+          message:    'Исходные данные:',
+          resultText: b64ToText(resp.plaintext),
+        });
+        await load();
+      } catch (e) {
+        toast(e.message, 'error');
+      }
+    };
+
+    const expireOverdue = async () => {
+      try {
+        const resp = await api.expireApprovals();
+        toast(`Завершено просроченных запросов: ${resp.expired_count}`);
+        await load();
+      } catch (e) {
+        toast(e.message, 'error');
+      }
+    };
+
+    const canDecide  = (a) => canReview.value && a.status === 'pending' && a.requester_id !== myId.value;
+    const canExecute = (a) => a.status === 'approved' && a.operation === 'detokenize' && a.requester_id === myId.value;
+
+    onMounted(async () => {
+      try {
+        myId.value = (await api.getMe())?.user_id || '';
+      } catch {
+        myId.value = '';
+      }
+      await load();
+    });
+
+    return {
+      approvals, loading, error, status, canReview, load,
+      openApproveModal, openRejectModal, execute, expireOverdue, canDecide, canExecute, formatDate,
+      statusLabels: STATUS_LABELS,
+      statusLabel: (s) => STATUS_LABELS[s] || s,
+      statusClass: (s) => STATUS_CLASSES[s] || 'text-slate-600',
+      operationLabel: (op) => OPERATION_LABELS[op] || op,
+    };
+  },
+  template: `
+    <div class="max-w-5xl mx-auto">
+      <div class="flex items-center justify-between mb-5">
+        <h2 class="text-lg font-bold text-slate-900">Согласования</h2>
+        <div class="flex items-center gap-3">
+          <select v-model="status" @change="load"
+            class="px-3 py-2 border border-slate-300 rounded-lg text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500">
+            <option value="">Все</option>
+            <option v-for="(label, key) in statusLabels" :key="key" :value="key">{{ label }}</option>
+          </select>
+          <button v-if="canReview" @click="expireOverdue"
+            class="px-3 py-2 text-sm text-slate-600 hover:text-slate-900 border border-slate-300 rounded-lg transition">
+            Завершить просроченные
+          </button>
+        </div>
+      </div>
+      <p class="text-sm text-slate-500 mb-4">
+        Чувствительные операции выполняются только после одобрения другим администратором.
+        Одобренную детокенизацию выполняет сам инициатор.
+      </p>
+
+      <div v-if="loading" class="py-8 text-center text-slate-400 text-sm">Загрузка...</div>
+      <div v-else-if="error" class="p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">{{ error }}</div>
+      <div v-else class="bg-white rounded-xl border border-slate-200 overflow-hidden shadow-sm">
+        <table class="w-full text-sm">
+          <thead class="bg-slate-50 border-b border-slate-200">
+            <tr>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Операция</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Объект</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Инициатор</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Статус</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Создан</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Срок</th>
+              <th class="text-right px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
+            </tr>
+          </thead>
+          <tbody class="divide-y divide-slate-100">
+            <tr v-for="a in approvals" :key="a.id" class="hover:bg-slate-50 transition-colors">
+              <td class="px-4 py-3">{{ operationLabel(a.operation) }}</td>
+              <td class="px-4 py-3 font-mono text-xs text-slate-600">{{ a.target || '—' }}</td>
+              <td class="px-4 py-3 font-mono text-xs text-slate-400" :title="a.requester_id">{{ a.requester_id.substring(0,8) }}…</td>
+              <td :class="['px-4 py-3', statusClass(a.status)]" :title="a.reject_reason || ''">{{ statusLabel(a.status) }}</td>
+              <td class="px-4 py-3 text-slate-600 text-xs">{{ formatDate(a.created_at) }}</td>
+              <td class="px-4 py-3 text-slate-600 text-xs">{{ formatDate(a.expires_at) }}</td>
+              <td class="px-4 py-3 text-right whitespace-nowrap">
+                <template v-if="canDecide(a)">
+                  <button @click="openApproveModal(a)"
+                    class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">Одобрить</button>
+                  <button @click="openRejectModal(a)"
+                    class="text-red-500 hover:text-red-700 text-xs font-medium transition">Отклонить</button>
+                </template>
+                <button v-else-if="canExecute(a)" @click="execute(a)"
+                  class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition">Выполнить</button>
+              </td>
+            </tr>
+            <tr v-if="approvals.length === 0">
+              <td colspan="7" class="text-center py-10 text-slate-400 text-sm">Запросов нет</td>
+            </tr>
+          </tbody>
+        </table>
+      </div>
+    </div>
+  `,
+};
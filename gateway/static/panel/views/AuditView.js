@@ -1,4 +1,4 @@
-import { ref, onMounted } from '../vue.js';
+import { ref, reactive, onMounted } from '../vue.js';
 import { api } from '../api.js';
 import { usePagination } from '../composables/usePagination.js';
 import AppPagination from '../components/AppPagination.js';
@@ -16,8 +16,78 @@ const formatDate = (str) => {
 };
 
 const ACTION_LABELS = {
-  tokenize:   'Токенизация',
-  detokenize: 'Детокенизация',
+  tokenize:          'Токенизация',
+  anonymize:         'Анонимизация',
+  detokenize:        'Детокенизация',
+  mapping_read:      'Просмотр токена',
+  mapping_list:      'Просмотр списка токенов',
+  mapping_update:    'Изменение срока хранения',
+  mapping_delete:    'Удаление токена',
+  subject_read:      'Просмотр токенов субъекта',
+  erase:             'Удаление данных субъекта',
+  hold_create:       'Установка удержания',
+  hold_release:      'Снятие удержания',
+  kind_create:       'Создание вида данных',
+  kind_update:       'Изменение вида данных',
+  kind_delete:       'Удаление вида данных',
+  purpose_create:    'Добавление цели обработки',
+  purpose_delete:    'Удаление цели обработки',
+  rotate_master_key: 'Ротация мастер-ключа',
+  rotate_deks:       'Ротация ключей данных',
+  access:            'Доступ к разделу',
+  audit_export:      'Выгрузка журнала аудита',
+  login:             'Вход в систему',
+  sso_login:         'Вход через поставщика удостоверений',
+  register:          'Регистрация пользователя',
+  token_refresh:     'Обновление токена доступа',
+  user_delete:       'Удаление пользователя',
+  role_assign:       'Назначение роли',
+  role_remove:       'Снятие роли',
+  clearance_update:  'Изменение уровня допуска',
+  anomaly_block:     'Блокировка за аномальную детокенизацию',
+  anomaly_unblock:   'Снятие блокировки',
+  approval_request:  'Запрос на согласование',
+  approval_approve:  'Одобрение запроса',
+  approval_reject:   'Отклонение запроса',
+  approval_expire:   'Истечение запроса',
+  break_glass_start:       'Включение экстренного доступа',
+  break_glass_end:         'Завершение экстренного доступа',
+  break_glass_acknowledge: 'Проверка экстренного доступа',
+  grant_create:      'Выдача временного доступа',
+  grant_revoke:      'Отзыв временного доступа',
+  role_create:       'Создание роли',
+  role_update:       'Изменение роли',
+  role_delete:       'Удаление роли',
+  kind_operation_grant_set:    'Разрешение операций над видом данных',
+  kind_operation_grant_delete: 'Удаление разрешения операций',
+  service_account_create: 'Создание сервисного аккаунта',
+  service_account_delete: 'Удаление сервисного аккаунта',
+  api_key_create:    'Выпуск API-ключа',
+  api_key_rotate:    'Замена API-ключа',
+  api_key_revoke:    'Отзыв API-ключа',
+  client_token:      'Выпуск токена сервисного аккаунта',
+};
+
+const OUTCOME_LABELS = {
+  success: 'Успешно',
+  denied:  'Отказано',
+  error:   'Ошибка',
+};
+
+const OUTCOME_CLASSES = {
+  success: 'text-green-700',
+  denied:  'text-amber-700',
+  error:   'text-red-700',
+};
+
+const CHAIN_REASONS = {
+  'missing entry':                'запись отсутствует',
+  'previous hash mismatch':       'не совпадает хеш предыдущей записи',
+  'row hash mismatch':            'содержимое записи изменено',
+  'unknown chain version':        'неизвестная версия цепочки',
+  'checkpoint hash mismatch':     'запись не совпадает с контрольной точкой',
+  'invalid checkpoint signature': 'недействительная подпись контрольной точки',
+  'chain head mismatch':          'не совпадает вершина цепочки',
 };
 
 export default {
@@ -28,12 +98,41 @@ export default {
 
     const { page, totalPages, pageItems, setPage } = usePagination(entries);
 
+    const today   = new Date().toISOString().slice(0, 10);
+    const actFrom = ref(today.slice(0, 8) + '01');
+    const actTo   = ref(today);
+
+    const verification = ref(null);
+    const verifying    = ref(false);
+
+    const verifyChain = async () => {
+      verifying.value = true;
+      error.value     = '';
+      try {
+        verification.value = await api.verifyAuditLog();
+      } catch (e) {
+        error.value = e.message;
+      } finally {
+        verifying.value = false;
+      }
+    };
+
+    const openDestructionAct = () => {
+      window.open(api.destructionActHtmlUrl(actFrom.value, actTo.value), '_blank');
+    };
+
+    const filters = reactive({ action: '', outcome: '', token: '', user_id: '', request_id: '', break_glass: '', from: '', to: '' });
+    const nextCursor  = ref(null);
+    const loadingMore = ref(false);
+
     const loadEntries = async () => {
       loading.value = true;
       error.value   = '';
       try {
-        const data    = await api.getAuditLog();
-        entries.value = Array.isArray(data) ? data : [];
+        const data       = await api.getAuditLog({ ...filters });
+        entries.value    = data.entries;
+        nextCursor.value = data.nextCursor;
+        setPage(1);
       } catch (e) {
         error.value = e.message;
       } finally {
@@ -41,12 +140,38 @@ export default {
       }
     };
 
+    const loadMore = async () => {
+      loadingMore.value = true;
+      error.value       = '';
+      try {
+        const data       = await api.getAuditLog({ ...filters, cursor: nextCursor.value });
+        entries.value    = [...entries.value, ...data.entries];
+        nextCursor.value = data.nextCursor;
+      } catch (e) {
+        error.value = e.message;
+      } finally {
+        loadingMore.value = false;
+      }
+    };
+
+    const exportLog = (format) => {
+      window.open(api.auditExportUrl({ ...filters }, format), '_blank');
+    };
+
     onMounted(loadEntries);
 
     return {
       entries, loading, error,
       page, totalPages, pageItems, setPage,
       loadEntries, formatDate,
+      filters, nextCursor, loadingMore, loadMore, exportLog,
+      actionLabels: ACTION_LABELS,
+      outcomeLabels: OUTCOME_LABELS,
+      outcomeLabel: (outcome) => OUTCOME_LABELS[outcome] || outcome,
+      outcomeClass: (outcome) => OUTCOME_CLASSES[outcome] || 'text-slate-700',
+      actFrom, actTo, openDestructionAct,
+      verification, verifying, verifyChain,
+      chainReason: (reason) => CHAIN_REASONS[reason] || reason,
       actionLabel: (action) => ACTION_LABELS[action] || action,
     };
   },
@@ -55,13 +180,107 @@ export default {
     <div class="max-w-6xl mx-auto">
       <div class="flex items-center justify-between mb-5">
         <h2 class="text-lg font-bold text-slate-900">Аудит</h2>
+        <div class="flex items-center gap-2">
+          <button @click="verifyChain" :disabled="verifying"
+            class="text-sm text-slate-500 hover:text-slate-800 border border-slate-300 hover:border-slate-400 disabled:opacity-50 px-3 py-1.5 rounded-lg transition">
+            {{ verifying ? 'Проверка...' : 'Проверить целостность' }}
+          </button>
+          <button @click="loadEntries"
+            class="inline-flex items-center gap-1.5 text-sm text-slate-500 hover:text-slate-800 border border-slate-300 hover:border-slate-400 px-3 py-1.5 rounded-lg transition">
+            <svg class="w-4 h-4" fill="none" viewBox="0 0 24 24" stroke="currentColor">
+              <path stroke-linecap="round" stroke-linejoin="round" stroke-width="2"
+                d="M4 4v5h.582m15.356 2A8.001 8.001 0 004.582 9m0 0H9m11 11v-5h-.581m0 0a8.003 8.003 0 01-15.357-2m15.357 2H15"/>
+            </svg>
+            Обновить
+          </button>
+        </div>
+      </div>
+
+      <div v-if="verification" class="mb-5 p-4 rounded-xl text-sm border"
+        :class="verification.valid ? 'bg-green-50 border-green-200 text-green-700' : 'bg-red-50 border-red-200 text-red-700'">
+        <template v-if="verification.valid">
+          Цепочка журнала не нарушена: проверено записей — {{ verification.checked }}, контрольных точек — {{ verification.checkpoints_verified }}.
+        </template>
+        <template v-else>
+          Цепочка журнала нарушена на записи № {{ verification.broken_seq }}: {{ chainReason(verification.reason) }}.
+        </template>
+      </div>
+
+      <div class="flex flex-wrap items-end gap-3 mb-5 p-4 bg-white rounded-xl border border-slate-200 shadow-sm">
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">Акт уничтожения ПДн: с</label>
+          <input v-model="actFrom" type="date"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+        </div>
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">по</label>
+          <input v-model="actTo" type="date"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+        </div>
+        <button @click="openDestructionAct" :disabled="!actFrom || !actTo"
+          class="text-sm text-slate-700 border border-slate-300 hover:border-slate-400 disabled:opacity-50 px-3 py-1.5 rounded-lg transition">
+          Сформировать акт
+        </button>
+      </div>
+
+      <div class="flex flex-wrap items-end gap-3 mb-5 p-4 bg-white rounded-xl border border-slate-200 shadow-sm">
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">Действие</label>
+          <select v-model="filters.action"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500">
+            <option value="">Все</option>
+            <option v-for="(label, action) in actionLabels" :key="action" :value="action">{{ label }}</option>
+          </select>
+        </div>
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">Результат</label>
+          <select v-model="filters.outcome"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500">
+            <option value="">Все</option>
+            <option v-for="(label, outcome) in outcomeLabels" :key="outcome" :value="outcome">{{ label }}</option>
+          </select>
+        </div>
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">Токен</label>
+          <input v-model.trim="filters.token" type="text"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+        </div>
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">ID пользователя</label>
+          <input v-model.trim="filters.user_id" type="text"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+        </div>
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">ID запроса</label>
+          <input v-model.trim="filters.request_id" type="text"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+        </div>
+        <label class="flex items-center gap-2 text-sm text-slate-600 py-1.5">
+          <input v-model="filters.break_glass" type="checkbox" true-value="true" false-value=""
+            class="rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
+          Экстренный доступ
+        </label>
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">С</label>
+          <input v-model="filters.from" type="date"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+        </div>
+        <div>
+          <label class="block text-xs font-medium text-slate-500 mb-1">По</label>
+          <input v-model="filters.to" type="date"
+            class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+        </div>
         <button @click="loadEntries"
-          class="inline-flex items-center gap-1.5 text-sm text-slate-500 hover:text-slate-800 border border-slate-300 hover:border-slate-400 px-3 py-1.5 rounded-lg transition">
-          <svg class="w-4 h-4" fill="none" viewBox="0 0 24 24" stroke="currentColor">
-            <path stroke-linecap="round" stroke-linejoin="round" stroke-width="2"
-              d="M4 4v5h.582m15.356 2A8.001 8.001 0 004.582 9m0 0H9m11 11v-5h-.581m0 0a8.003 8.003 0 01-15.357-2m15.357 2H15"/>
-          </svg>
-          Обновить
+          class="text-sm text-white bg-indigo-600 hover:bg-indigo-700 px-3 py-1.5 rounded-lg transition">
+          Найти
+        </button>
+        <button @click="exportLog('csv')"
+          class="text-sm text-slate-700 border border-slate-300 hover:border-slate-400 px-3 py-1.5 rounded-lg transition">
+          CSV
+        </button>
+        <button @click="exportLog('jsonl')"
+          class="text-sm text-slate-700 border border-slate-300 hover:border-slate-400 px-3 py-1.5 rounded-lg transition">
+          JSONL
         </button>
       </div>
 
@@ -80,26 +299,42 @@ export default {
               <tr>
                 <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Время</th>
                 <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действие</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Результат</th>
                 <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Токен</th>
                 <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Вид данных</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Цель</th>
                 <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Пользователь</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">IP-адрес</th>
               </tr>
             </thead>
             <tbody class="divide-y divide-slate-100">
               <tr v-for="entry in pageItems" :key="entry.id" class="hover:bg-slate-50 transition-colors">
                 <td class="px-4 py-3 text-slate-600">{{ formatDate(entry.created_at) }}</td>
-                <td class="px-4 py-3 text-slate-700">{{ actionLabel(entry.action) }}</td>
-                <td class="px-4 py-3 font-mono text-xs text-slate-400">{{ entry.token }}</td>
+                <td class="px-4 py-3 text-slate-700">
+                  {{ actionLabel(entry.action) }}
+                  <span v-if="entry.justification" :title="entry.justification"
+                    class="ml-1 inline-block px-1.5 py-0.5 rounded bg-red-100 text-red-700 text-xs font-medium">break-glass</span>
+                </td>
+                <td class="px-4 py-3" :class="outcomeClass(entry.outcome)" :title="entry.reason">{{ outcomeLabel(entry.outcome) }}</td>
+                <td class="px-4 py-3 font-mono text-xs text-slate-400">{{ entry.token || entry.legal_hold }}</td>
                 <td class="px-4 py-3 text-slate-700">{{ entry.kind ? entry.kind.russian_name : '—' }}</td>
+                <td class="px-4 py-3 text-slate-700">{{ entry.purpose || '—' }}</td>
                 <td class="px-4 py-3 font-mono text-xs text-slate-400" :title="entry.user_id">{{ entry.user_id.substring(0,8) }}…</td>
+                <td class="px-4 py-3 font-mono text-xs text-slate-400" :title="[entry.user_agent, entry.request_id].filter(Boolean).join(' · ')">{{ entry.client_ip || '—' }}</td>
               </tr>
               <tr v-if="entries.length === 0">
-                <td colspan="5" class="text-center py-10 text-slate-400 text-sm">Записи не найдены</td>
+                <td colspan="8" class="text-center py-10 text-slate-400 text-sm">Записи не найдены</td>
               </tr>
             </tbody>
           </table>
         </div>
         <AppPagination :page="page" :total-pages="totalPages" @update:page="setPage" />
+        <div v-if="nextCursor" class="flex justify-center py-3 border-t border-slate-100">
+          <button @click="loadMore" :disabled="loadingMore"
+            class="text-sm text-slate-600 hover:text-slate-900 disabled:opacity-50">
+            {{ loadingMore ? 'Загрузка...' : 'Загрузить ещё' }}
+          </button>
+        </div>
       </div>
     </div>
   `,
@@ -0,0 +1,229 @@
+import { ref, reactive, computed, onMounted } from '../vue.js';
+import { api } from '../api.js';
+import { useToast } from '../composables/useToast.js';
+import { useModal } from '../composables/useModal.js';
+
+const OUTCOME_CLASSES = {
+  success: 'text-green-700',
+  denied:  'text-amber-700',
+  error:   'text-red-700',
+};
+
+export default {
+  props: {
+    permissions: { type: Array, default: () => [] },
+  },
+  setup(props) {
+    const { show: toast }        = useToast();
+    const { modal, open, close } = useModal();
+
+    const can       = (permission) => props.permissions.includes(permission);
+    const canReview = computed(() => can('break_glass.review'));
+
+    const current  = ref(null);
+    const canStart = ref(false);
+
+    const sessions       = ref([]);
+    const loading        = ref(false);
+    const error          = ref('');
+    const unacknowledged = ref(true);
+    const expanded       = reactive({});
+    const myId           = ref('');
+
+    const formatDate = (value) => value ? new Date(value).toLocaleString('ru-RU') : '—';
+
+    const loadCurrent = async () => {
+      try {
+        current.value  = await api.getBreakGlass();
+        canStart.value = true;
+      } catch {
+        current.value  = null;
+        canStart.value = false;
+      }
+    };
+
+    const loadSessions = async () => {
+      if (!canReview.value) return;
+      loading.value = true;
+      error.value   = '';
+      try {
+        sessions.value = (await api.getBreakGlassSessions(unacknowledged.value)) || [];
+      } catch (e) {
+        error.value = e.message;
+      } finally {
+        loading.value = false;
+      }
+    };
+
+    const openStartModal = () => {
+      open({
+        type:  'form',
+        title: 'Экстренный доступ',
+        fields: [
+          { key: 'justification', label: 'Обоснование', type: 'text', required: true,
+            placeholder: 'Номер инцидента и причина доступа' },
+          { key: 'duration_minutes', label: 'Длительность, мин', type: 'number', required: true },
+        ],
+        values: { justification: '', duration_minutes: 30 },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            const resp = await api.startBreakGlass(modal.values.justification, Number(modal.values.duration_minutes));
+            current.value = resp.session;
+            close();
+            toast('Экстренный доступ включён. Все операции передаются на проверку аудитору');
+            await loadSessions();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const endSession = async () => {
+      try {
+        await api.endBreakGlass();
+        current.value = null;
+        toast('Экстренный доступ завершён');
+        await loadSessions();
+      } catch (e) {
+        toast(e.message, 'error');
+      }
+    };
+
+    const toggle = async (session) => {
+      if (expanded[session.id]) {
+        delete expanded[session.id];
+        return;
+      }
+      try {
+        expanded[session.id] = (await api.getBreakGlassSession(session.id)).operation_list || [];
+      } catch (e) {
+        toast(e.message, 'error');
+      }
+    };
+
+    const acknowledge = async (session) => {
+      try {
+        await api.acknowledgeBreakGlassSession(session.id);
+        toast('Проверка сессии подтверждена');
+        await loadSessions();
+      } catch (e) {
+        toast(e.message, 'error');
+      }
+    };
+
+    const canAcknowledge = (s) => can('break_glass.acknowledge') && !s.active && !s.acknowledged_at && s.user_id !== myId.value;
+
+    onMounted(async () => {
+      try {
+        myId.value = (await api.getMe())?.user_id || '';
+      } catch {
+        myId.value = '';
+      }
+      await Promise.all([loadCurrent(), loadSessions()]);
+    });
+
+    return {
+      current, canStart, canReview, sessions, loading, error, unacknowledged, expanded,
+      openStartModal, endSession, loadSessions, toggle, acknowledge, canAcknowledge, formatDate,
+      outcomeClass: (outcome) => OUTCOME_CLASSES[outcome] || 'text-slate-600',
+    };
+  },
+  template: `
+    <div class="max-w-5xl mx-auto">
+      <h2 class="text-lg font-bold text-slate-900 mb-5">Экстренный доступ</h2>
+
+      <div v-if="canStart" class="mb-6 p-4 rounded-xl border shadow-sm"
+        :class="current ? 'bg-red-50 border-red-200' : 'bg-white border-slate-200'">
+        <template v-if="current">
+          <p class="text-sm text-red-700 font-medium mb-1">Экстренный доступ активен до {{ formatDate(current.expires_at) }}</p>
+          <p class="text-sm text-red-700 mb-3">Обоснование: {{ current.justification }}</p>
+          <button @click="endSession"
+            class="px-3 py-2 text-sm text-white bg-red-600 hover:bg-red-700 rounded-lg transition">
+            Завершить досрочно
+          </button>
+        </template>
+        <template v-else>
+          <p class="text-sm text-slate-600 mb-3">
+            Экстренный доступ снимает проверку уровня допуска на ограниченный срок. Он включается только с письменным
+            обоснованием, а каждая операция фиксируется в журнале аудита и передаётся на проверку аудитору.
+          </p>
+          <button @click="openStartModal"
+            class="px-3 py-2 text-sm text-white bg-red-600 hover:bg-red-700 rounded-lg transition">
+            Включить экстренный доступ
+          </button>
+        </template>
+      </div>
+
+      <template v-if="canReview">
+        <div class="flex items-center justify-between mb-3">
+          <h3 class="text-sm font-semibold text-slate-700">Сессии</h3>
+          <label class="flex items-center gap-2 text-sm text-slate-600">
+            <input v-model="unacknowledged" type="checkbox" @change="loadSessions"
+              class="rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
+            Только непроверенные
+          </label>
+        </div>
+
+        <div v-if="loading" class="py-8 text-center text-slate-400 text-sm">Загрузка...</div>
+        <div v-else-if="error" class="p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">{{ error }}</div>
+        <div v-else class="bg-white rounded-xl border border-slate-200 overflow-hidden shadow-sm">
+          <table class="w-full text-sm">
+            <thead class="bg-slate-50 border-b border-slate-200">
+              <tr>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Пользователь</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Обоснование</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Начало</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Окончание</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Операций</th>
+                <th class="text-right px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
+              </tr>
+            </thead>
+            <tbody class="divide-y divide-slate-100">
+              <template v-for="s in sessions" :key="s.id">
+                <tr class="hover:bg-slate-50 transition-colors">
+                  <td class="px-4 py-3 font-mono text-xs text-slate-400" :title="s.user_id">{{ s.user_id.substring(0,8) }}…</td>
+                  <td class="px-4 py-3 text-slate-700">{{ s.justification }}</td>
+                  <td class="px-4 py-3 text-slate-600 text-xs">{{ formatDate(s.started_at) }}</td>
+                  <td class="px-4 py-3 text-xs" :class="s.active ? 'text-red-700 font-medium' : 'text-slate-600'">
+                    {{ s.active ? 'Активна' : formatDate(s.ended_at || s.expires_at) }}
+                  </td>
+                  <td class="px-4 py-3 text-slate-700">{{ s.operations }}</td>
+                  <td class="px-4 py-3 text-right whitespace-nowrap">
+                    <button @click="toggle(s)"
+                      class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">
+                      {{ expanded[s.id] ? 'Скрыть' : 'Операции' }}
+                    </button>
+                    <button v-if="canAcknowledge(s)" @click="acknowledge(s)"
+                      class="text-green-600 hover:text-green-800 text-xs font-medium transition">Проверено</button>
+                    <span v-else-if="s.acknowledged_at" class="text-xs text-slate-400" :title="s.acknowledged_by">
+                      Проверено {{ formatDate(s.acknowledged_at) }}
+                    </span>
+                  </td>
+                </tr>
+                <tr v-if="expanded[s.id]">
+                  <td colspan="6" class="px-4 py-3 bg-slate-50">
+                    <div v-if="expanded[s.id].length === 0" class="text-xs text-slate-400">Операций нет</div>
+                    <div v-for="(op, i) in expanded[s.id]" :key="i" class="flex gap-4 text-xs py-0.5">
+                      <span class="text-slate-500">{{ formatDate(op.at) }}</span>
+                      <span class="text-slate-700">{{ op.action }}</span>
+                      <span class="font-mono text-slate-400">{{ op.token || '—' }}</span>
+                      <span :class="outcomeClass(op.outcome)">{{ op.outcome }}</span>
+                    </div>
+                  </td>
+                </tr>
+              </template>
+              <tr v-if="sessions.length === 0">
+                <td colspan="6" class="text-center py-10 text-slate-400 text-sm">Сессий нет</td>
+              </tr>
+            </tbody>
+          </table>
+        </div>
+      </template>
+    </div>
+  `,
+};
@@ -1,5 +1,5 @@
 import { ref, onMounted } from '../vue.js';
-import { api } from '../api.js';
+import { api, isPendingApproval, PENDING_APPROVAL_MESSAGE } from '../api.js';
 import { useToast } from '../composables/useToast.js';
 import { useModal } from '../composables/useModal.js';
 import { usePagination } from '../composables/usePagination.js';
@@ -100,8 +100,9 @@ export default {
         onConfirm: async () => {
           modal.loading = true;
           try {
-            await api.deleteKind(kind.id);
+            const resp = await api.deleteKind(kind.id);
             close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
             toast('Вид токена удалён');
             await loadKinds();
           } catch (e) {
@@ -1,5 +1,6 @@
-import { ref, reactive, computed } from '../vue.js';
+import { ref, reactive, computed, onMounted } from '../vue.js';
 import { api } from '../api.js';
+import { translateError } from '../errorMessages.js';
 
 export default {
   emits: ['login'],
@@ -13,6 +14,32 @@ export default {
 
     const isRegister = computed(() => mode.value === 'register');
 
+    // Sign-in through the identity provider, if the gateway has one
+    const sso = reactive({ enabled: false, providerName: '' });
+
+    onMounted(async () => {
+      // A failed sign-in through the identity provider comes back with ?sso_error=<code>
+      const params = new URLSearchParams(location.search);
+      const ssoError = params.get('sso_error');
+      if (ssoError) {
+        error.value = translateError(ssoError);
+        params.delete('sso_error');
+        const search = params.toString();
+        history.replaceState(history.state, '', location.pathname + (search ? `?${search}` : '') + location.hash);
+      }
+      try {
+        const info = await api.getOidcInfo();
+        sso.enabled      = !!info?.enabled;
+        sso.providerName = info?.provider_name || '';
+      } catch {
+        sso.enabled = false;
+      }
+    });
+
+    const ssoLogin = () => {
+      location.href = api.oidcLoginUrl();
+    };
+
     const reset = (newMode) => {
       mode.value          = newMode;
       error.value         = '';
@@ -27,7 +54,7 @@ export default {
       error.value   = '';
       try {
         const resp = await api.login(form.login, form.password);
-        emit('login', resp?.roles || []);
+        emit('login', resp?.permissions || []);
       } catch (e) {
         error.value = e.status === 401 ? 'Неверный логин или пароль' : (e.message || 'Ошибка входа');
       } finally {
@@ -54,7 +81,7 @@ export default {
       }
     };
 
-    return { mode, form, loading, error, success, isRegister, reset, login, register };
+    return { mode, form, loading, error, success, isRegister, sso, reset, login, register, ssoLogin };
   },
   template: `
     <div class="min-h-screen flex items-center justify-center p-4">
@@ -98,6 +125,15 @@ export default {
             </span>
             <span v-else>Войти</span>
           </button>
+          <template v-if="sso.enabled">
+            <div class="flex items-center gap-3 my-4 text-xs text-slate-400">
+              <div class="flex-1 border-t border-slate-200"></div>или<div class="flex-1 border-t border-slate-200"></div>
+            </div>
+            <button type="button" @click="ssoLogin"
+              class="w-full border border-slate-300 hover:border-indigo-300 hover:bg-indigo-50 text-slate-700 font-semibold py-2.5 rounded-lg text-sm transition">
+              Войти через {{ sso.providerName }}
+            </button>
+          </template>
           <p class="text-center text-sm text-slate-500 mt-5">
             Нет аккаунта?
             <button type="button" @click="reset('register')" class="text-indigo-600 hover:text-indigo-800 font-medium transition">
@@ -1,18 +1,22 @@
 import { useToast } from '../composables/useToast.js';
 
-// Roles required to access each section (matches backend RBAC)
-const REQUIRED_ROLES = {
-  users:    ['admin'],
-  roles:    ['admin'],
-  kinds:    ['admin'],
-  tokens:   ['admin', 'specialist'],
-  audit:    ['admin', 'auditor'],
-  security: ['admin'],
+// Permissions opening each section, any one of them is enough (matches the access policy)
+const REQUIRED_PERMISSIONS = {
+  users:    ['users.manage'],
+  roles:    ['roles.read'],
+  kinds:    ['kinds.write'],
+  tokens:   ['tokenizer.tokenize', 'tokenizer.detokenize'],
+  audit:    ['audit.read'],
+  security: ['security.manage'],
+  approvals: ['approvals.use'],
+  breakglass: ['break_glass.use', 'break_glass.review'],
+  policies: ['policies.read'],
+  serviceaccounts: ['service_accounts.manage'],
 };
 
 export default {
   props: {
-    roles: { type: Array, default: () => [] },
+    permissions: { type: Array, default: () => [] },
   },
   emits: ['navigate'],
   setup(props, { emit }) {
@@ -20,18 +24,21 @@ export default {
 
     const items = [
       { id: 'users',  label: 'Пользователи',   icon: '👤', desc: 'Учётные записи и роли'       },
-      { id: 'roles',  label: 'Роли',           icon: '🔑', desc: 'Список системных ролей'      },
+      { id: 'roles',  label: 'Роли',           icon: '🔑', desc: 'Роли и их разрешения'       },
       { id: 'kinds',  label: 'Виды токенов',   icon: '🏷️', desc: 'Типы данных для токенизации' },
       { id: 'tokens', label: 'Токены',         icon: '🔐', desc: 'Активные маппинги'            },
       { id: 'audit',  label: 'Аудит',          icon: '📋', desc: 'Журнал операций с ПДн'        },
-      { id: 'security', label: 'Безопасность', icon: '🛡️', desc: 'Ротация ключей шифрования'    },
+      { id: 'security', label: 'Безопасность', icon: '🛡️', desc: 'Ротация ключей и блокировки' },
+      { id: 'approvals', label: 'Согласования', icon: '✅', desc: 'Запросы на операции «четыре глаза»' },
+      { id: 'breakglass', label: 'Экстренный доступ', icon: '🚨', desc: 'Доступ сверх допуска и его проверка' },
+      { id: 'policies', label: 'Политика доступа', icon: '📜', desc: 'Правила доступа и проверка решений' },
+      { id: 'serviceaccounts', label: 'Сервисные аккаунты', icon: '🤖', desc: 'Учётные записи систем и их API-ключи' },
     ];
 
     const hasAccess = (id) => {
-      const required = REQUIRED_ROLES[id];
+      const required = REQUIRED_PERMISSIONS[id];
       if (!required) return true;
-      const names = props.roles.map(r => r.name);
-      return required.some(r => names.includes(r));
+      return required.some(p => props.permissions.includes(p));
     };
 
     const handleClick = (item) => {
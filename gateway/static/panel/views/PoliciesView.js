@@ -0,0 +1,296 @@
+import { ref, reactive, onMounted } from '../vue.js';
+import { api } from '../api.js';
+
+const splitList = (value) => value.split(',').map(s => s.trim()).filter(Boolean);
+
+// Operations on data of a kind that kind operation grants allow.
+const OPERATIONS = ['tokenize', 'detokenize', 'view', 'delete'];
+
+export default {
+  setup() {
+    const policy        = ref(null);
+    const policyLoading = ref(false);
+    const policyError   = ref('');
+
+    const form = reactive({
+      action:       '',
+      kind_id:      0,
+      access_level: 0,
+      owner:        '',
+      operation:    '',
+      ip:           '',
+      time:         '',
+    });
+    const subject = reactive({
+      custom:          false,
+      user_id:         '',
+      roles:           '',
+      permissions:     '',
+      clearance_level: 1,
+      kind_grants:     '',
+      kind_operations: '',
+      break_glass:     false,
+    });
+    const draft = ref('');
+
+    const decision       = ref(null);
+    const explaining     = ref(false);
+    const explainError   = ref('');
+    const explainDetails = ref('');
+
+    const formatDate = (value) => new Date(value).toLocaleString('ru-RU');
+    const effectLabel = (effect) => (effect === 'deny' ? 'Запрет' : 'Разрешение');
+
+    const loadPolicy = async () => {
+      policyLoading.value = true;
+      policyError.value   = '';
+      try {
+        policy.value = await api.getPolicy();
+        if (!form.action && policy.value.actions.length) form.action = policy.value.actions[0];
+      } catch (e) {
+        policyError.value = e.message;
+      } finally {
+        policyLoading.value = false;
+      }
+    };
+
+    const explain = async () => {
+      explaining.value     = true;
+      explainError.value   = '';
+      explainDetails.value = '';
+      decision.value       = null;
+      try {
+        const body = {
+          action:       form.action,
+          kind_id:      Number(form.kind_id) || 0,
+          access_level: Number(form.access_level) || 0,
+          owner:        form.owner,
+          operation:    form.operation,
+          ip:           form.ip,
+          time:         form.time ? new Date(form.time).toISOString() : '',
+          policy:       draft.value.trim() || undefined,
+        };
+        if (subject.custom) {
+          body.subject = {
+            user_id:         subject.user_id,
+            roles:           splitList(subject.roles),
+            permissions:     splitList(subject.permissions),
+            clearance_level: Number(subject.clearance_level) || 0,
+            kind_grants:     splitList(subject.kind_grants).map(Number).filter(Number.isInteger),
+            break_glass:     subject.break_glass,
+          };
+          // Operations entered for the subject apply to the kind of the resource.
+          const operations = splitList(subject.kind_operations);
+          if (operations.length && Number(form.kind_id) > 0) {
+            body.subject.kind_operations = [{ kind_id: Number(form.kind_id), operations }];
+          }
+        }
+        decision.value = await api.explainPolicy(body);
+      } catch (e) {
+        explainError.value   = e.message;
+        explainDetails.value = e.details || '';
+      } finally {
+        explaining.value = false;
+      }
+    };
+
+    const useCurrentAsDraft = () => {
+      if (!policy.value) return;
+      draft.value = 'rules:\n' + policy.value.rules.map(r => [
+        `  - name: ${r.name}`,
+        ...(r.description ? [`    description: ${JSON.stringify(r.description)}`] : []),
+        `    effect: ${r.effect}`,
+        `    actions: [${r.actions.join(', ')}]`,
+        ...(r.when ? [`    when: ${JSON.stringify(r.when)}`] : []),
+      ].join('\n')).join('\n');
+    };
+
+    onMounted(loadPolicy);
+
+    return {
+      policy, policyLoading, policyError,
+      form, subject, draft,
+      decision, explaining, explainError, explainDetails,
+      formatDate, effectLabel, loadPolicy, explain, useCurrentAsDraft,
+      operations: OPERATIONS,
+    };
+  },
+
+  template: `
+    <div class="max-w-5xl mx-auto">
+      <div class="flex items-center justify-between mb-5">
+        <h2 class="text-lg font-bold text-slate-900">Политика доступа</h2>
+        <button @click="loadPolicy"
+          class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition">Обновить</button>
+      </div>
+
+      <div v-if="policyLoading" class="py-8 text-center text-slate-400 text-sm">Загрузка...</div>
+      <div v-else-if="policyError" class="p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">{{ policyError }}</div>
+      <template v-else-if="policy">
+        <p class="text-sm text-slate-500 mb-4">
+          Версия <span class="font-mono text-slate-700">{{ policy.version }}</span>
+          из <span class="font-mono text-slate-700">{{ policy.source }}</span>,
+          загружена {{ formatDate(policy.loaded_at) }}.
+          Запрещающие правила имеют приоритет; действие, не разрешённое ни одним правилом, запрещено.
+        </p>
+
+        <div class="bg-white rounded-xl border border-slate-200 overflow-hidden shadow-sm">
+          <table class="w-full text-sm">
+            <thead class="bg-slate-50 border-b border-slate-200">
+              <tr>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Правило</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Эффект</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Условие</th>
+              </tr>
+            </thead>
+            <tbody class="divide-y divide-slate-100">
+              <tr v-for="rule in policy.rules" :key="rule.name" class="hover:bg-slate-50 transition-colors align-top">
+                <td class="px-4 py-3">
+                  <div class="font-medium text-slate-800">{{ rule.name }}</div>
+                  <div v-if="rule.description" class="text-xs text-slate-500">{{ rule.description }}</div>
+                </td>
+                <td class="px-4 py-3">
+                  <span :class="rule.effect === 'deny' ? 'bg-red-100 text-red-700' : 'bg-emerald-100 text-emerald-700'"
+                    class="px-2 py-0.5 rounded-full text-xs font-medium">{{ effectLabel(rule.effect) }}</span>
+                </td>
+                <td class="px-4 py-3 font-mono text-xs text-slate-600">{{ rule.actions.join(', ') }}</td>
+                <td class="px-4 py-3 font-mono text-xs text-slate-600">{{ rule.when || '—' }}</td>
+              </tr>
+            </tbody>
+          </table>
+        </div>
+      </template>
+
+      <h3 class="font-semibold text-slate-800 mt-8 mb-2">Проверка решения</h3>
+      <p class="text-sm text-slate-500 mb-4">
+        Решение вычисляется без выполнения действия. По умолчанию проверяется текущий пользователь
+        с его IP-адресом и временем запроса; в поле черновика можно проверить правила до их применения.
+      </p>
+
+      <div class="p-4 bg-white rounded-xl border border-slate-200 shadow-sm space-y-4">
+        <div class="flex flex-wrap items-end gap-3">
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Действие</label>
+            <select v-model="form.action"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500">
+              <option v-for="action in (policy ? policy.actions : [])" :key="action" :value="action">{{ action }}</option>
+            </select>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Вид данных</label>
+            <input v-model.number="form.kind_id" type="number" min="0"
+              class="w-24 border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Уровень доступа</label>
+            <input v-model.number="form.access_level" type="number" min="0" max="4"
+              class="w-24 border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Операция</label>
+            <select v-model="form.operation"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500">
+              <option value="">—</option>
+              <option v-for="op in operations" :key="op" :value="op">{{ op }}</option>
+            </select>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Владелец</label>
+            <input v-model.trim="form.owner" type="text"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">IP-адрес</label>
+            <input v-model.trim="form.ip" type="text" placeholder="из запроса"
+              class="w-36 border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Время</label>
+            <input v-model="form.time" type="datetime-local"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+        </div>
+
+        <label class="flex items-center gap-2 text-sm text-slate-600">
+          <input v-model="subject.custom" type="checkbox"
+            class="rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
+          Проверить для другого пользователя
+        </label>
+        <div v-if="subject.custom" class="flex flex-wrap items-end gap-3">
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">ID пользователя</label>
+            <input v-model.trim="subject.user_id" type="text"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Роли (через запятую)</label>
+            <input v-model="subject.roles" type="text" placeholder="specialist"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Разрешения (через запятую)</label>
+            <input v-model="subject.permissions" type="text" placeholder="tokenizer.detokenize"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Уровень допуска</label>
+            <input v-model.number="subject.clearance_level" type="number" min="0" max="4"
+              class="w-24 border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Доступ к видам (через запятую)</label>
+            <input v-model="subject.kind_grants" type="text"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <div>
+            <label class="block text-xs font-medium text-slate-500 mb-1">Операции над видом (через запятую)</label>
+            <input v-model="subject.kind_operations" type="text" placeholder="view, detokenize"
+              class="border border-slate-300 rounded-lg px-3 py-1.5 text-sm font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"/>
+          </div>
+          <label class="flex items-center gap-2 text-sm text-slate-600 py-1.5">
+            <input v-model="subject.break_glass" type="checkbox"
+              class="rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
+            Экстренный доступ
+          </label>
+        </div>
+
+        <div>
+          <div class="flex items-center justify-between mb-1">
+            <label class="block text-xs font-medium text-slate-500">Черновик политики (YAML, необязательно)</label>
+            <button @click="useCurrentAsDraft" :disabled="!policy"
+              class="text-indigo-600 hover:text-indigo-800 disabled:opacity-50 text-xs font-medium transition">Скопировать действующую</button>
+          </div>
+          <textarea v-model="draft" rows="6" spellcheck="false"
+            class="w-full border border-slate-300 rounded-lg px-3 py-2 text-xs font-mono focus:outline-none focus:ring-2 focus:ring-indigo-500"></textarea>
+        </div>
+
+        <button @click="explain" :disabled="explaining || !form.action"
+          class="px-4 py-2 bg-indigo-600 hover:bg-indigo-700 disabled:opacity-50 text-white text-sm font-medium rounded-lg transition">
+          {{ explaining ? 'Проверка...' : 'Проверить' }}
+        </button>
+      </div>
+
+      <div v-if="explainError" class="mt-4 p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">
+        {{ explainError }}
+        <div v-if="explainDetails" class="mt-1 font-mono text-xs">{{ explainDetails }}</div>
+      </div>
+      <div v-else-if="decision" class="mt-4 bg-white rounded-xl border border-slate-200 p-4 shadow-sm">
+        <div class="flex items-center gap-3 mb-3">
+          <span :class="decision.allowed ? 'bg-emerald-100 text-emerald-700' : 'bg-red-100 text-red-700'"
+            class="px-2 py-0.5 rounded-full text-xs font-medium">{{ decision.allowed ? 'Разрешено' : 'Запрещено' }}</span>
+          <span class="font-mono text-xs text-slate-600">{{ decision.reason }}</span>
+          <span class="ml-auto font-mono text-xs text-slate-400">{{ decision.version }}</span>
+        </div>
+        <p v-if="!decision.rules.length" class="text-sm text-slate-500">Ни одно правило не относится к этому действию.</p>
+        <ul v-else class="space-y-1 text-sm">
+          <li v-for="rule in decision.rules" :key="rule.name" class="flex items-center gap-2">
+            <span :class="rule.matched ? 'text-slate-800' : 'text-slate-400'">{{ rule.matched ? '●' : '○' }}</span>
+            <span :class="rule.name === decision.decided_by ? 'font-semibold text-slate-900' : 'text-slate-600'">{{ rule.name }}</span>
+            <span class="text-xs text-slate-400">{{ effectLabel(rule.effect) }}, {{ rule.matched ? 'условие выполнено' : 'условие не выполнено' }}</span>
+          </li>
+        </ul>
+      </div>
+    </div>
+  `,
+};
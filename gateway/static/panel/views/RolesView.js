@@ -1,22 +1,34 @@
-import { ref, onMounted } from '../vue.js';
-import { api } from '../api.js';
+import { ref, computed, onMounted } from '../vue.js';
+import { api, isPendingApproval, PENDING_APPROVAL_MESSAGE } from '../api.js';
+import { useToast } from '../composables/useToast.js';
+import { useModal } from '../composables/useModal.js';
 import { usePagination } from '../composables/usePagination.js';
 import AppPagination from '../components/AppPagination.js';
 
 export default {
-  setup() {
+  props: {
+    permissions: { type: Array, default: () => [] },
+  },
+  setup(props) {
+    const { show: toast }        = useToast();
+    const { modal, open, close } = useModal();
+
     const roles   = ref([]);
+    const catalog = ref([]);
     const loading = ref(false);
     const error   = ref('');
 
+    const canManage = computed(() => props.permissions.includes('roles.manage'));
+
     const { page, totalPages, pageItems, setPage } = usePagination(roles);
 
     const loadRoles = async () => {
       loading.value = true;
       error.value   = '';
       try {
-        const data  = await api.getRoles();
-        roles.value = data?.roles || [];
+        const [data, permissions] = await Promise.all([api.getRoles(), api.getPermissions()]);
+        roles.value   = data?.roles || [];
+        catalog.value = Array.isArray(permissions) ? permissions : [];
       } catch (e) {
         error.value = e.message;
       } finally {
@@ -24,14 +36,111 @@ export default {
       }
     };
 
+    const roleFields = () => [
+      { key: 'name',        label: 'Название',   type: 'text', required: true,  placeholder: 'analyst' },
+      { key: 'description', label: 'Описание',   type: 'text', required: false, placeholder: 'Просмотр маппингов и журнала аудита' },
+      { key: 'permissions', label: 'Разрешения', type: 'checklist',
+        options: catalog.value.map(p => ({ value: p.name, label: p.description })) },
+    ];
+
+    // Reads current form values and builds the API payload.
+    const payload = () => ({
+      name:        modal.values.name,
+      description: modal.values.description,
+      permissions: modal.values.permissions,
+    });
+
+    // Opens the role form; a preset fills it with the permissions of an existing role.
+    const openCreateModal = (preset = null) => {
+      open({
+        type:   'form',
+        title:  preset ? `Новая роль на основе «${preset.name}»` : 'Добавить роль',
+        fields: roleFields(),
+        values: { name: '', description: preset?.description || '', permissions: [...(preset?.permissions || [])] },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            const resp = await api.createRole(payload());
+            close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
+            toast('Роль создана');
+            await loadRoles();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openEditModal = (role) => {
+      open({
+        type:   'form',
+        title:  'Изменить роль',
+        fields: roleFields(),
+        values: { name: role.name, description: role.description || '', permissions: [...(role.permissions || [])] },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            const resp = await api.updateRole(role.id, payload());
+            close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
+            toast('Роль обновлена');
+            await loadRoles();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openDeleteModal = (role) => {
+      open({
+        type:    'confirm',
+        title:   'Удалить роль',
+        message: `Удалить роль «${role.name}»? Роль, назначенную пользователям, удалить нельзя.`,
+        onConfirm: async () => {
+          modal.loading = true;
+          try {
+            await api.deleteRole(role.id);
+            close();
+            toast('Роль удалена');
+            await loadRoles();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
     onMounted(loadRoles);
 
-    return { roles, loading, error, page, totalPages, pageItems, setPage };
+    return {
+      roles, loading, error, canManage,
+      page, totalPages, pageItems, setPage,
+      openCreateModal, openEditModal, openDeleteModal,
+    };
   },
   components: { AppPagination },
   template: `
-    <div class="max-w-2xl mx-auto">
-      <h2 class="text-lg font-bold text-slate-900 mb-5">Роли</h2>
+    <div class="max-w-5xl mx-auto">
+      <div class="flex items-center justify-between mb-5">
+        <h2 class="text-lg font-bold text-slate-900">Роли</h2>
+        <button v-if="canManage" @click="openCreateModal()"
+          class="inline-flex items-center gap-1.5 bg-indigo-600 hover:bg-indigo-700 text-white px-4 py-2 rounded-lg text-sm font-medium transition">
+          <svg class="w-4 h-4" fill="none" viewBox="0 0 24 24" stroke="currentColor">
+            <path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 4v16m8-8H4"/>
+          </svg>
+          Добавить
+        </button>
+      </div>
 
       <div v-if="loading" class="flex items-center justify-center py-16 text-slate-400">
         <svg class="animate-spin w-6 h-6 mr-2" fill="none" viewBox="0 0 24 24">
@@ -48,19 +157,42 @@ export default {
               <tr>
                 <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">ID</th>
                 <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Название</th>
+                <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Разрешения</th>
+                <th v-if="canManage" class="text-right px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
               </tr>
             </thead>
             <tbody class="divide-y divide-slate-100">
-              <tr v-for="role in pageItems" :key="role.id" class="hover:bg-slate-50 transition-colors">
+              <tr v-for="role in pageItems" :key="role.id" class="hover:bg-slate-50 transition-colors align-top">
                 <td class="px-4 py-3 text-slate-500">{{ role.id }}</td>
                 <td class="px-4 py-3">
                   <span class="inline-block bg-indigo-100 text-indigo-700 text-xs rounded-full px-2.5 py-0.5 font-medium">
                     {{ role.name }}
                   </span>
+                  <span v-if="role.built_in" class="ml-1 inline-block bg-slate-100 text-slate-600 text-xs rounded-full px-2.5 py-0.5 font-medium">
+                    встроенная
+                  </span>
+                  <div v-if="role.description" class="text-xs text-slate-500 mt-1">{{ role.description }}</div>
+                </td>
+                <td class="px-4 py-3">
+                  <div class="flex flex-wrap gap-1">
+                    <span v-for="p in role.permissions" :key="p"
+                      class="font-mono text-[11px] bg-slate-50 border border-slate-200 text-slate-700 rounded px-1.5 py-0.5">{{ p }}</span>
+                    <span v-if="!role.permissions || role.permissions.length === 0" class="text-slate-400 text-xs">—</span>
+                  </div>
+                </td>
+                <td v-if="canManage" class="px-4 py-3 text-right whitespace-nowrap">
+                  <button @click="openCreateModal(role)"
+                    class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">Копировать</button>
+                  <template v-if="!role.built_in">
+                    <button @click="openEditModal(role)"
+                      class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">Изменить</button>
+                    <button @click="openDeleteModal(role)"
+                      class="text-red-500 hover:text-red-700 text-xs font-medium transition">Удалить</button>
+                  </template>
                 </td>
               </tr>
               <tr v-if="roles.length === 0">
-                <td colspan="2" class="text-center py-10 text-slate-400 text-sm">Роли не найдены</td>
+                <td :colspan="canManage ? 4 : 3" class="text-center py-10 text-slate-400 text-sm">Роли не найдены</td>
               </tr>
             </tbody>
           </table>
@@ -1,4 +1,5 @@
-import { api } from '../api.js';
+import { ref, onMounted } from '../vue.js';
+import { api, isPendingApproval, PENDING_APPROVAL_MESSAGE } from '../api.js';
 import { useToast } from '../composables/useToast.js';
 import { useModal } from '../composables/useModal.js';
 
@@ -7,12 +8,56 @@ export default {
     const { show: toast }        = useToast();
     const { modal, open, close } = useModal();
 
+    const blocks        = ref([]);
+    const blocksLoading = ref(false);
+    const blocksError   = ref('');
+
+    const ACTION_LABELS = { block: 'Блокировка', reauth: 'Повторный вход' };
+    const actionLabel   = (action) => ACTION_LABELS[action] || action;
+    const formatDate    = (value) => new Date(value).toLocaleString('ru-RU');
+
+    const loadBlocks = async () => {
+      blocksLoading.value = true;
+      blocksError.value   = '';
+      try {
+        blocks.value = (await api.getAnomalyBlocks()) || [];
+      } catch (e) {
+        blocksError.value = e.message;
+      } finally {
+        blocksLoading.value = false;
+      }
+    };
+
+    const openUnblockModal = (block) => {
+      open({
+        type:    'confirm',
+        title:   'Снятие блокировки',
+        message: `Снять ограничение с пользователя ${block.user_id}? Счётчики детокенизации пользователя будут сброшены.`,
+        confirmLabel: 'Снять блокировку',
+        confirmLoadingLabel: 'Выполняется...',
+        confirmClass: 'bg-indigo-600 hover:bg-indigo-700',
+        onConfirm: async () => {
+          modal.loading = true;
+          try {
+            await api.unblockUser(block.user_id);
+            close();
+            toast('Блокировка снята');
+            await loadBlocks();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
     const showResult = (title, result) => {
       open({
         type:    'result',
         title,
         message: 'Результат операции:',
-        resultText: `Обновлено: ${result.updated_count}, ошибок: ${result.failed_count}`,
+        resultText: `Обновлено: ${result.updated_count}, ошибок: ${result.failed_count}, на удержании: ${result.held_count}`,
       });
     };
 
@@ -29,6 +74,7 @@ export default {
           try {
             const result = await api.rotateMasterKey();
             close();
+            if (isPendingApproval(result)) { toast(PENDING_APPROVAL_MESSAGE); return; }
             toast('Ротация мастер-ключа завершена');
             showResult('Ротация мастер-ключа', result);
           } catch (e) {
@@ -53,6 +99,7 @@ export default {
           try {
             const result = await api.rotateAllDeks();
             close();
+            if (isPendingApproval(result)) { toast(PENDING_APPROVAL_MESSAGE); return; }
             toast('Ротация ключей шифрования данных завершена');
             showResult('Ротация ключей шифрования данных', result);
           } catch (e) {
@@ -64,7 +111,12 @@ export default {
       });
     };
 
-    return { openRotateMasterKeyModal, openRotateDeksModal };
+    onMounted(loadBlocks);
+
+    return {
+      openRotateMasterKeyModal, openRotateDeksModal,
+      blocks, blocksLoading, blocksError, loadBlocks, openUnblockModal, actionLabel, formatDate,
+    };
   },
   template: `
     <div class="max-w-3xl mx-auto">
@@ -97,6 +149,49 @@ export default {
           </button>
         </div>
       </div>
+
+      <div class="flex items-center justify-between mt-8 mb-3">
+        <h3 class="font-semibold text-slate-800">Активные блокировки</h3>
+        <button @click="loadBlocks"
+          class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition">Обновить</button>
+      </div>
+      <p class="text-sm text-slate-500 mb-4">
+        Пользователи, превысившие порог объёма детокенизации за скользящее окно.
+        Ограничение снимается автоматически по истечении срока или вручную.
+      </p>
+
+      <div v-if="blocksLoading" class="py-8 text-center text-slate-400 text-sm">Загрузка...</div>
+      <div v-else-if="blocksError" class="p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">{{ blocksError }}</div>
+      <div v-else class="bg-white rounded-xl border border-slate-200 overflow-hidden shadow-sm">
+        <table class="w-full text-sm">
+          <thead class="bg-slate-50 border-b border-slate-200">
+            <tr>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Пользователь</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Мера</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Измерение</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Объём</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">До</th>
+              <th class="text-right px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
+            </tr>
+          </thead>
+          <tbody class="divide-y divide-slate-100">
+            <tr v-for="block in blocks" :key="block.user_id" class="hover:bg-slate-50 transition-colors">
+              <td class="px-4 py-3 font-mono text-xs text-slate-400" :title="block.user_id">{{ block.user_id.substring(0,8) }}…</td>
+              <td class="px-4 py-3">{{ actionLabel(block.action) }}</td>
+              <td class="px-4 py-3 font-mono text-xs text-slate-600">{{ block.dimension }}</td>
+              <td class="px-4 py-3 text-slate-600" :title="'Окно: ' + block.window">{{ block.count }} / {{ block.threshold }}</td>
+              <td class="px-4 py-3 text-slate-600 text-xs">{{ formatDate(block.expires_at) }}</td>
+              <td class="px-4 py-3 text-right">
+                <button @click="openUnblockModal(block)"
+                  class="text-red-500 hover:text-red-700 text-xs font-medium transition">Снять</button>
+              </td>
+            </tr>
+            <tr v-if="blocks.length === 0">
+              <td colspan="6" class="text-center py-10 text-slate-400 text-sm">Активных блокировок нет</td>
+            </tr>
+          </tbody>
+        </table>
+      </div>
     </div>
   `,
 };
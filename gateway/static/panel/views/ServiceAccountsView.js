@@ -0,0 +1,312 @@
+import { ref, onMounted } from '../vue.js';
+import { api } from '../api.js';
+import { useToast } from '../composables/useToast.js';
+import { useModal } from '../composables/useModal.js';
+
+// Splits a comma- or space-separated list of addresses.
+const splitList = (value) => (value || '').split(/[\s,]+/).filter(Boolean);
+
+export default {
+  props: {
+    permissions: { type: Array, default: () => [] },
+  },
+  setup(props) {
+    const { show: toast }        = useToast();
+    const { modal, open, close } = useModal();
+
+    const accounts   = ref([]);
+    const keys       = ref([]);
+    const kinds      = ref([]);
+    const activeOnly = ref(true);
+    const loading    = ref(false);
+    const error      = ref('');
+
+    const load = async () => {
+      loading.value = true;
+      error.value   = '';
+      try {
+        const [accs, ks] = await Promise.all([api.getServiceAccounts(), api.getApiKeys('', activeOnly.value)]);
+        accounts.value = accs || [];
+        keys.value     = ks || [];
+      } catch (e) {
+        error.value = e.message;
+      } finally {
+        loading.value = false;
+      }
+    };
+
+    // Kinds are only needed to scope keys; without access to them keys get no kinds.
+    const loadKinds = async () => {
+      try {
+        kinds.value = (await api.getKinds()) || [];
+      } catch {
+        kinds.value = [];
+      }
+    };
+
+    const keysOf = (account) => keys.value.filter(k => k.service_account_id === account.id);
+
+    const formatDate = (value) => value ? new Date(value).toLocaleString('ru-RU') : '—';
+    const kindName   = (id) => {
+      const kind = kinds.value.find(k => k.id === id);
+      return kind ? (kind.russian_name || kind.name) : `#${id}`;
+    };
+
+    // Shows the key issued by the gateway: it is not kept and cannot be shown again.
+    const showIssuedKey = (title, resp) => {
+      open({
+        type:       'result',
+        title,
+        message:    'API-ключ показывается только сейчас — сохраните его. Передавайте его в заголовке «Authorization: ApiKey <ключ>».',
+        resultText: resp.key,
+      });
+    };
+
+    // ── Modals ────────────────────────────────────────────────────────────────
+
+    const openCreateAccountModal = () => {
+      open({
+        type:   'form',
+        title:  'Добавить сервисный аккаунт',
+        fields: [
+          { key: 'name',        label: 'Имя',      type: 'text', required: true,  placeholder: 'billing-batch' },
+          { key: 'description', label: 'Описание', type: 'text', required: false, placeholder: 'Ночная выгрузка биллинга' },
+        ],
+        values: { name: '', description: '' },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            await api.createServiceAccount({ name: modal.values.name, description: modal.values.description });
+            close();
+            toast('Сервисный аккаунт создан');
+            await load();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openDeleteAccountModal = (account) => {
+      open({
+        type:    'confirm',
+        title:   'Удалить сервисный аккаунт',
+        message: `Удалить сервисный аккаунт «${account.name}»? Все его API-ключи перестанут действовать сразу.`,
+        onConfirm: async () => {
+          modal.loading = true;
+          try {
+            await api.deleteServiceAccount(account.id);
+            close();
+            toast('Сервисный аккаунт удалён');
+            await load();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openCreateKeyModal = async (account) => {
+      await loadKinds();
+      open({
+        type:   'form',
+        title:  `Новый API-ключ для «${account.name}»`,
+        fields: [
+          { key: 'permissions', label: 'Разрешения', type: 'checklist',
+            options: props.permissions.map(p => ({ value: p, label: '' })) },
+          { key: 'kind_ids', label: 'Виды данных', type: 'checklist',
+            options: kinds.value.map(k => ({ value: k.id, label: k.russian_name || k.name })) },
+          { key: 'allowed_ips', label: 'Разрешённые адреса (через запятую, пусто — любые)', type: 'text', required: false, placeholder: '10.0.0.0/8, 192.168.1.10' },
+          { key: 'expires_in_days', label: 'Срок действия, дней', type: 'number', required: true },
+        ],
+        values: { permissions: [], kind_ids: [], allowed_ips: '', expires_in_days: 90 },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            const resp = await api.createApiKey(account.id, {
+              permissions:     modal.values.permissions,
+              kind_ids:        modal.values.kind_ids,
+              allowed_ips:     splitList(modal.values.allowed_ips),
+              expires_in_days: parseInt(modal.values.expires_in_days) || 0,
+            });
+            close();
+            showIssuedKey('API-ключ выпущен', resp);
+            await load();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openRotateKeyModal = (key) => {
+      open({
+        type:   'form',
+        title:  `Заменить API-ключ ${key.prefix}`,
+        fields: [
+          { key: 'grace_hours', label: 'Сколько часов прежний ключ ещё действует (0 — отозвать сразу, не более 168)', type: 'number', required: true },
+        ],
+        values: { grace_hours: 24 },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            const resp = await api.rotateApiKey(key.id, parseInt(modal.values.grace_hours) || 0);
+            close();
+            showIssuedKey('API-ключ заменён', resp);
+            await load();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openRevokeKeyModal = (key) => {
+      open({
+        type:    'confirm',
+        title:   'Отозвать API-ключ',
+        message: `Отозвать API-ключ ${key.prefix}? Запросы с ним перестанут приниматься сразу.`,
+        onConfirm: async () => {
+          modal.loading = true;
+          try {
+            await api.revokeApiKey(key.id);
+            close();
+            toast('API-ключ отозван');
+            await load();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const toggleActiveOnly = async () => {
+      activeOnly.value = !activeOnly.value;
+      await load();
+    };
+
+    onMounted(async () => {
+      await Promise.all([load(), loadKinds()]);
+    });
+
+    return {
+      accounts, keys, activeOnly, loading, error,
+      keysOf, formatDate, kindName, toggleActiveOnly,
+      openCreateAccountModal, openDeleteAccountModal,
+      openCreateKeyModal, openRotateKeyModal, openRevokeKeyModal,
+    };
+  },
+  template: `
+    <div class="max-w-6xl mx-auto">
+      <div class="flex items-center justify-between mb-5">
+        <h2 class="text-lg font-bold text-slate-900">Сервисные аккаунты</h2>
+        <div class="flex items-center gap-4">
+          <label class="flex items-center gap-2 text-sm text-slate-600 cursor-pointer select-none">
+            <input type="checkbox" :checked="activeOnly" @change="toggleActiveOnly"
+              class="w-4 h-4 rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
+            Только действующие ключи
+          </label>
+          <button @click="openCreateAccountModal"
+            class="inline-flex items-center gap-1.5 bg-indigo-600 hover:bg-indigo-700 text-white px-4 py-2 rounded-lg text-sm font-medium transition">
+            <svg class="w-4 h-4" fill="none" viewBox="0 0 24 24" stroke="currentColor">
+              <path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 4v16m8-8H4"/>
+            </svg>
+            Добавить
+          </button>
+        </div>
+      </div>
+
+      <div v-if="loading" class="flex items-center justify-center py-16 text-slate-400">
+        <svg class="animate-spin w-6 h-6 mr-2" fill="none" viewBox="0 0 24 24">
+          <circle class="opacity-25" cx="12" cy="12" r="10" stroke="currentColor" stroke-width="4"/>
+          <path class="opacity-75" fill="currentColor" d="M4 12a8 8 0 018-8V0C5.373 0 0 5.373 0 12h4z"/>
+        </svg>
+        Загрузка...
+      </div>
+      <div v-else-if="error" class="p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">{{ error }}</div>
+      <template v-else>
+        <div v-if="accounts.length === 0" class="bg-white rounded-xl border border-slate-200 text-center py-10 text-slate-400 text-sm">
+          Сервисные аккаунты не найдены
+        </div>
+        <div v-for="account in accounts" :key="account.id"
+          class="bg-white rounded-xl border border-slate-200 overflow-hidden shadow-sm mb-4">
+          <div class="flex items-start justify-between px-4 py-3 border-b border-slate-100">
+            <div>
+              <span class="inline-block bg-indigo-100 text-indigo-700 text-xs rounded-full px-2.5 py-0.5 font-medium">{{ account.name }}</span>
+              <span class="ml-2 font-mono text-[11px] text-slate-400">{{ account.id }}</span>
+              <div v-if="account.description" class="text-xs text-slate-500 mt-1">{{ account.description }}</div>
+            </div>
+            <div class="whitespace-nowrap">
+              <button @click="openCreateKeyModal(account)"
+                class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">Выпустить ключ</button>
+              <button @click="openDeleteAccountModal(account)"
+                class="text-red-500 hover:text-red-700 text-xs font-medium transition">Удалить</button>
+            </div>
+          </div>
+          <table class="w-full text-sm">
+            <thead class="bg-slate-50 border-b border-slate-200">
+              <tr>
+                <th class="text-left px-4 py-2 font-semibold text-slate-600 text-xs uppercase tracking-wide">Ключ</th>
+                <th class="text-left px-4 py-2 font-semibold text-slate-600 text-xs uppercase tracking-wide">Разрешения и виды</th>
+                <th class="text-left px-4 py-2 font-semibold text-slate-600 text-xs uppercase tracking-wide">Адреса</th>
+                <th class="text-left px-4 py-2 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действует до</th>
+                <th class="text-left px-4 py-2 font-semibold text-slate-600 text-xs uppercase tracking-wide">Последнее использование</th>
+                <th class="text-right px-4 py-2 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
+              </tr>
+            </thead>
+            <tbody class="divide-y divide-slate-100">
+              <tr v-for="key in keysOf(account)" :key="key.id" class="hover:bg-slate-50 transition-colors align-top">
+                <td class="px-4 py-2">
+                  <span class="font-mono text-xs text-slate-800">{{ key.prefix }}</span>
+                  <span v-if="!key.active" class="ml-1 inline-block bg-slate-100 text-slate-600 text-xs rounded-full px-2 py-0.5">
+                    {{ key.revoked_at ? 'отозван' : 'истёк' }}
+                  </span>
+                </td>
+                <td class="px-4 py-2">
+                  <div class="flex flex-wrap gap-1">
+                    <span v-for="p in key.permissions" :key="p"
+                      class="font-mono text-[11px] bg-slate-50 border border-slate-200 text-slate-700 rounded px-1.5 py-0.5">{{ p }}</span>
+                    <span v-for="id in key.kind_ids" :key="'k' + id"
+                      class="text-[11px] bg-amber-50 border border-amber-200 text-amber-700 rounded px-1.5 py-0.5">{{ kindName(id) }}</span>
+                  </div>
+                </td>
+                <td class="px-4 py-2 font-mono text-xs text-slate-600">
+                  {{ key.allowed_ips && key.allowed_ips.length ? key.allowed_ips.join(', ') : 'любые' }}
+                </td>
+                <td class="px-4 py-2 text-xs text-slate-600 whitespace-nowrap">{{ formatDate(key.revoked_at || key.expires_at) }}</td>
+                <td class="px-4 py-2 text-xs text-slate-600 whitespace-nowrap">
+                  {{ formatDate(key.last_used_at) }}
+                  <div v-if="key.last_used_ip" class="font-mono text-slate-400">{{ key.last_used_ip }}</div>
+                </td>
+                <td class="px-4 py-2 text-right whitespace-nowrap">
+                  <template v-if="key.active">
+                    <button @click="openRotateKeyModal(key)"
+                      class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">Заменить</button>
+                    <button @click="openRevokeKeyModal(key)"
+                      class="text-red-500 hover:text-red-700 text-xs font-medium transition">Отозвать</button>
+                  </template>
+                </td>
+              </tr>
+              <tr v-if="keysOf(account).length === 0">
+                <td colspan="6" class="text-center py-4 text-slate-400 text-xs">Ключей нет</td>
+              </tr>
+            </tbody>
+          </table>
+        </div>
+      </template>
+    </div>
+  `,
+};
@@ -1,5 +1,5 @@
 import { ref, onMounted, onUnmounted } from '../vue.js';
-import { api } from '../api.js';
+import { api, isPendingApproval, PENDING_APPROVAL_MESSAGE } from '../api.js';
 import { useToast } from '../composables/useToast.js';
 import { useModal } from '../composables/useModal.js';
 import { usePagination } from '../composables/usePagination.js';
@@ -76,6 +76,7 @@ export default {
 
     const tokens  = ref([]);
     const kinds   = ref([]);
+    const purposes = ref([]);
     const loading = ref(false);
     const error   = ref('');
 
@@ -115,6 +116,13 @@ export default {
       } catch {}
     };
 
+    const loadPurposes = async () => {
+      try {
+        const data     = await api.getPurposes();
+        purposes.value = Array.isArray(data) ? data : [];
+      } catch {}
+    };
+
     // ── Tokenize ─────────────────────────────────────────────────────────────
     const openTokenizeModal = () => {
       const kindOptions = [OTHER_KIND_OPTION, ...kinds.value.map(k => ({ value: k.id, label: k.russian_name || k.name }))];
@@ -179,14 +187,21 @@ export default {
         type:   'form',
         title:  'Дешифровать токен',
         fields: [
-          { key: 'token', label: 'Токен', type: 'text', required: true, placeholder: 'Например, fio_7f82a1c3' },
+          { key: 'token',   label: 'Токен',           type: 'text',   required: true, placeholder: 'Например, fio_7f82a1c3' },
+          { key: 'purpose', label: 'Цель обработки', type: 'select', required: true,
+            options: purposes.value.map(p => ({ value: p.name, label: p.description || p.name })) },
         ],
-        values: { token: prefillToken },
+        values: { token: prefillToken, purpose: purposes.value[0]?.name ?? '' },
         onConfirm: async () => {
           modal.loading = true;
           modal.error   = '';
           try {
-            const resp = await api.detokenize(modal.values.token);
+            const resp = await api.detokenize(modal.values.token, modal.values.purpose);
+            if (isPendingApproval(resp)) {
+              close();
+              toast('Детокенизация отправлена на согласование. Выполните её в разделе «Согласования» после одобрения');
+              return;
+            }
             const text = b64ToText(resp.plaintext);
             close();
             open({
@@ -238,8 +253,9 @@ export default {
         onConfirm: async () => {
           modal.loading = true;
           try {
-            await api.deleteMapping(token.id);
+            const resp = await api.deleteMapping(token.id);
             close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
             toast('Токен удалён');
             await loadTokens();
           } catch (e) {
@@ -251,7 +267,7 @@ export default {
       });
     };
 
-    onMounted(() => { loadTokens(); loadKinds(); });
+    onMounted(() => { loadTokens(); loadKinds(); loadPurposes(); });
 
     return {
       tokens, loading, error,
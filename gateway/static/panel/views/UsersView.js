@@ -1,10 +1,19 @@
 import { ref, onMounted } from '../vue.js';
-import { api } from '../api.js';
+import { api, isPendingApproval, PENDING_APPROVAL_MESSAGE } from '../api.js';
 import { useToast } from '../composables/useToast.js';
 import { useModal } from '../composables/useModal.js';
 import { usePagination } from '../composables/usePagination.js';
 import AppPagination from '../components/AppPagination.js';
 
+const OPERATION_LABELS = {
+  tokenize:   'Токенизация',
+  detokenize: 'Детокенизация',
+  view:       'Просмотр токенов',
+  delete:     'Удаление токенов',
+};
+
+const operationLabel = (op) => OPERATION_LABELS[op] || op;
+
 export default {
   setup() {
     const { show: toast }         = useToast();
@@ -14,6 +23,14 @@ export default {
     const loading = ref(false);
     const error   = ref('');
     const roles   = ref([]);  // cached for role pickers
+    const kinds   = ref([]);  // cached for grant pickers
+
+    const grants       = ref([]);
+    const grantsError  = ref('');
+    const activeGrants = ref(true);
+
+    const kindOperationGrants      = ref([]);
+    const kindOperationGrantsError = ref('');
 
     const { page, totalPages, pageItems, setPage } = usePagination(users);
 
@@ -40,6 +57,42 @@ export default {
       } catch {}
     };
 
+    const loadKinds = async () => {
+      if (kinds.value.length > 0) return;
+      try {
+        const data  = await api.getKinds();
+        kinds.value = Array.isArray(data) ? data : [];
+      } catch {}
+    };
+
+    const loadGrants = async () => {
+      grantsError.value = '';
+      try {
+        grants.value = (await api.getAccessGrants('', activeGrants.value)) || [];
+      } catch (e) {
+        grantsError.value = e.message;
+      }
+    };
+
+    const loadKindOperationGrants = async () => {
+      kindOperationGrantsError.value = '';
+      try {
+        kindOperationGrants.value = (await api.getKindOperationGrants()) || [];
+      } catch (e) {
+        kindOperationGrantsError.value = e.message;
+      }
+    };
+
+    const formatDate = (value) => value ? new Date(value).toLocaleString('ru-RU') : '—';
+    const userLogin  = (id) => users.value.find(u => u.id === id)?.login || id.substring(0, 8) + '…';
+    const kindName   = (id) => {
+      const kind = kinds.value.find(k => k.id === id);
+      return kind ? (kind.russian_name || kind.name) : `#${id}`;
+    };
+    const grantTarget = (g) => g.kind_id ? `Вид: ${kindName(g.kind_id)}` : `Допуск ${g.clearance_level}`;
+    const roleName    = (id) => roles.value.find(r => r.id === id)?.name || `#${id}`;
+    const grantHolder = (g) => g.role_id ? `Роль: ${roleName(g.role_id)}` : userLogin(g.user_id);
+
     // ── Modals ────────────────────────────────────────────────────────────────
 
     const openCreateModal = async () => {
@@ -79,8 +132,9 @@ export default {
         onConfirm: async () => {
           modal.loading = true;
           try {
-            await api.deleteUser(user.id);
+            const resp = await api.deleteUser(user.id);
             close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
             toast('Пользователь удалён');
             await loadUsers();
           } catch (e) {
@@ -105,7 +159,12 @@ export default {
           modal.loading = true;
           modal.error   = '';
           try {
-            await api.assignRole(user.id, parseInt(roleId));
+            const resp = await api.assignRole(user.id, parseInt(roleId));
+            if (isPendingApproval(resp)) {
+              modal.selectedRole = '';
+              toast(PENDING_APPROVAL_MESSAGE);
+              return;
+            }
             const role = roles.value.find(r => r.id === parseInt(roleId));
             if (role) modal.userRoles.push(role);
             modal.selectedRole = '';
@@ -147,8 +206,9 @@ export default {
           modal.loading = true;
           modal.error   = '';
           try {
-            await api.updateClearance(user.id, parseInt(modal.values.clearance_level));
+            const resp = await api.updateClearance(user.id, parseInt(modal.values.clearance_level));
             close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
             toast('Уровень допуска обновлён');
             await loadUsers();
           } catch (e) {
@@ -160,12 +220,147 @@ export default {
       });
     };
 
-    onMounted(loadUsers);
+    const openGrantModal = async (user) => {
+      await loadKinds();
+      const levels = [2, 3, 4].filter(l => l > (user.clearance_level || 1));
+      open({
+        type:   'form',
+        title:  `Временный доступ: ${user.login}`,
+        fields: [
+          { key: 'target', label: 'Доступ', type: 'select', required: true,
+            options: [
+              ...levels.map(l => ({ value: `level:${l}`, label: `Уровень допуска ${l}` })),
+              ...kinds.value
+                .filter(k => k.access_level > (user.clearance_level || 1))
+                .map(k => ({ value: `kind:${k.id}`, label: `Вид данных: ${k.russian_name || k.name}` })),
+            ] },
+          { key: 'reason', label: 'Основание', type: 'text', required: true,
+            placeholder: 'Номер заявки и причина доступа' },
+          { key: 'duration_hours', label: 'Длительность, ч', type: 'number', required: true },
+        ],
+        values: { target: levels.length ? `level:${levels[0]}` : '', reason: '', duration_hours: 8 },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            const [type, value] = String(modal.values.target).split(':');
+            const resp = await api.createAccessGrant({
+              user_id:         user.id,
+              clearance_level: type === 'level' ? parseInt(value) : 0,
+              kind_id:         type === 'kind' ? parseInt(value) : 0,
+              reason:          modal.values.reason,
+              duration_hours:  parseInt(modal.values.duration_hours),
+            });
+            close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
+            toast('Временный доступ выдан');
+            await loadGrants();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openRevokeGrantModal = (grant) => {
+      open({
+        type:    'confirm',
+        title:   'Отозвать временный доступ',
+        message: `Отозвать доступ «${grantTarget(grant)}» у пользователя «${userLogin(grant.user_id)}»?`,
+        onConfirm: async () => {
+          modal.loading = true;
+          try {
+            await api.revokeAccessGrant(grant.id);
+            close();
+            toast('Временный доступ отозван');
+            await loadGrants();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    // Opens the kind operation grant form; setting operations for a kind the holder
+    // already has replaces them.
+    const openKindOperationsModal = async () => {
+      await Promise.all([loadRoles(), loadKinds()]);
+      open({
+        type:   'form',
+        title:  'Разрешить операции над видом данных',
+        fields: [
+          { key: 'holder', label: 'Кому', type: 'select', required: true,
+            options: [
+              ...users.value.map(u => ({ value: `user:${u.id}`, label: `Пользователь: ${u.login}` })),
+              ...roles.value.map(r => ({ value: `role:${r.id}`, label: `Роль: ${r.name}` })),
+            ] },
+          { key: 'kind_id', label: 'Вид данных', type: 'select', required: true,
+            options: kinds.value.map(k => ({ value: k.id, label: k.russian_name || k.name })) },
+          { key: 'operations', label: 'Операции', type: 'checklist',
+            options: Object.entries(OPERATION_LABELS).map(([value, label]) => ({ value, label })) },
+        ],
+        values: { holder: '', kind_id: kinds.value[0]?.id || '', operations: [] },
+        onConfirm: async () => {
+          modal.loading = true;
+          modal.error   = '';
+          try {
+            const [type, value] = String(modal.values.holder).split(':');
+            const resp = await api.setKindOperationGrant({
+              user_id:    type === 'user' ? value : '',
+              role_id:    type === 'role' ? parseInt(value) : 0,
+              kind_id:    parseInt(modal.values.kind_id),
+              operations: modal.values.operations,
+            });
+            close();
+            if (isPendingApproval(resp)) { toast(PENDING_APPROVAL_MESSAGE); return; }
+            toast('Операции разрешены');
+            await loadKindOperationGrants();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    const openDeleteKindOperationsModal = (grant) => {
+      open({
+        type:    'confirm',
+        title:   'Удалить разрешение операций',
+        message: `Запретить «${grantHolder(grant)}» операции над видом «${kindName(grant.kind_id)}»?`,
+        onConfirm: async () => {
+          modal.loading = true;
+          try {
+            await api.deleteKindOperationGrant(grant.id);
+            close();
+            toast('Разрешение удалено');
+            await loadKindOperationGrants();
+          } catch (e) {
+            modal.error = e.message;
+          } finally {
+            modal.loading = false;
+          }
+        },
+      });
+    };
+
+    onMounted(async () => {
+      await loadUsers();
+      await Promise.all([loadRoles(), loadKinds(), loadGrants(), loadKindOperationGrants()]);
+    });
 
     return {
       users, loading, error,
       page, totalPages, pageItems, setPage,
-      openCreateModal, openDeleteModal, openRolesModal, openClearanceModal,
+      grants, grantsError, activeGrants, loadGrants, formatDate, userLogin, grantTarget,
+      kindOperationGrants, kindOperationGrantsError, grantHolder, kindName, operationLabel,
+      openCreateModal, openDeleteModal, openRolesModal, openClearanceModal, openGrantModal, openRevokeGrantModal,
+      openKindOperationsModal, openDeleteKindOperationsModal,
     };
   },
   components: { AppPagination },
@@ -222,6 +417,8 @@ export default {
                 <td class="px-4 py-3 text-right">
                   <button @click="openRolesModal(user)"
                     class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">Роли</button>
+                  <button @click="openGrantModal(user)"
+                    class="text-indigo-600 hover:text-indigo-800 text-xs font-medium transition mr-3">Доступ</button>
                   <button @click="openDeleteModal(user)"
                     class="text-red-500 hover:text-red-700 text-xs font-medium transition">Удалить</button>
                 </td>
@@ -234,6 +431,89 @@ export default {
         </div>
         <AppPagination :page="page" :total-pages="totalPages" @update:page="setPage" />
       </div>
+
+      <div class="flex items-center justify-between mt-8 mb-3">
+        <h3 class="text-sm font-semibold text-slate-700">Временные доступы</h3>
+        <label class="flex items-center gap-2 text-sm text-slate-600">
+          <input v-model="activeGrants" type="checkbox" @change="loadGrants"
+            class="rounded border-slate-300 text-indigo-600 focus:ring-indigo-500"/>
+          Только действующие
+        </label>
+      </div>
+      <div v-if="grantsError" class="p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">{{ grantsError }}</div>
+      <div v-else class="bg-white rounded-xl border border-slate-200 overflow-hidden shadow-sm">
+        <table class="w-full text-sm">
+          <thead class="bg-slate-50 border-b border-slate-200">
+            <tr>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Пользователь</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Доступ</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Основание</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Выдан</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действует до</th>
+              <th class="text-right px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
+            </tr>
+          </thead>
+          <tbody class="divide-y divide-slate-100">
+            <tr v-for="g in grants" :key="g.id" class="hover:bg-slate-50 transition-colors">
+              <td class="px-4 py-3 font-medium text-slate-900">{{ userLogin(g.user_id) }}</td>
+              <td class="px-4 py-3 text-slate-700">{{ grantTarget(g) }}</td>
+              <td class="px-4 py-3 text-slate-700">{{ g.reason }}</td>
+              <td class="px-4 py-3 text-slate-600 text-xs" :title="g.granted_by">{{ formatDate(g.created_at) }}, {{ userLogin(g.granted_by) }}</td>
+              <td class="px-4 py-3 text-xs" :class="g.active ? 'text-green-700 font-medium' : 'text-slate-600'">
+                <template v-if="g.revoked_at">Отозван {{ formatDate(g.revoked_at) }}</template>
+                <template v-else>{{ formatDate(g.expires_at) }}</template>
+              </td>
+              <td class="px-4 py-3 text-right">
+                <button v-if="g.active" @click="openRevokeGrantModal(g)"
+                  class="text-red-500 hover:text-red-700 text-xs font-medium transition">Отозвать</button>
+              </td>
+            </tr>
+            <tr v-if="grants.length === 0">
+              <td colspan="6" class="text-center py-10 text-slate-400 text-sm">Временных доступов нет</td>
+            </tr>
+          </tbody>
+        </table>
+      </div>
+
+      <div class="flex items-center justify-between mt-8 mb-3">
+        <h3 class="text-sm font-semibold text-slate-700">Операции над видами данных</h3>
+        <button @click="openKindOperationsModal"
+          class="text-indigo-600 hover:text-indigo-800 text-sm font-medium transition">Разрешить</button>
+      </div>
+      <div v-if="kindOperationGrantsError" class="p-4 bg-red-50 border border-red-200 text-red-700 rounded-xl text-sm">{{ kindOperationGrantsError }}</div>
+      <div v-else class="bg-white rounded-xl border border-slate-200 overflow-hidden shadow-sm">
+        <table class="w-full text-sm">
+          <thead class="bg-slate-50 border-b border-slate-200">
+            <tr>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Кому</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Вид данных</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Операции</th>
+              <th class="text-left px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Выдано</th>
+              <th class="text-right px-4 py-3 font-semibold text-slate-600 text-xs uppercase tracking-wide">Действия</th>
+            </tr>
+          </thead>
+          <tbody class="divide-y divide-slate-100">
+            <tr v-for="g in kindOperationGrants" :key="g.id" class="hover:bg-slate-50 transition-colors">
+              <td class="px-4 py-3 font-medium text-slate-900">{{ grantHolder(g) }}</td>
+              <td class="px-4 py-3 text-slate-700">{{ kindName(g.kind_id) }}</td>
+              <td class="px-4 py-3">
+                <span v-for="op in g.operations" :key="op"
+                  class="inline-block bg-slate-100 text-slate-700 text-xs rounded-full px-2.5 py-0.5 mr-1 font-medium">
+                  {{ operationLabel(op) }}
+                </span>
+              </td>
+              <td class="px-4 py-3 text-slate-600 text-xs" :title="g.granted_by">{{ formatDate(g.created_at) }}, {{ userLogin(g.granted_by) }}</td>
+              <td class="px-4 py-3 text-right">
+                <button @click="openDeleteKindOperationsModal(g)"
+                  class="text-red-500 hover:text-red-700 text-xs font-medium transition">Удалить</button>
+              </td>
+            </tr>
+            <tr v-if="kindOperationGrants.length === 0">
+              <td colspan="5" class="text-center py-10 text-slate-400 text-sm">Разрешений нет</td>
+            </tr>
+          </tbody>
+        </table>
+      </div>
     </div>
   `,
 };
@@ -4,6 +4,8 @@ set -euo pipefail
 CONVERGENT_KEY="my-kek-convergent"
 RANDOM_KEY="my-kek-random"
 HMAC_KEY="my-hmac-key"
+CACHE_KEY="my-kek-cache"
+SIGN_KEY="my-sign-key"
 POLICY_NAME="tokenizer-policy"
 ROLE_NAME="my-app"
 
@@ -113,6 +115,10 @@ echo "Creating random key ${RANDOM_KEY}"
 vault write -f transit/keys/${RANDOM_KEY} type=aes256-gcm96 exportable=false >/dev/null 2>&1 || true
 echo "Creating hmac key ${HMAC_KEY}"
 vault write -f transit/keys/${HMAC_KEY} type=hmac exportable=false >/dev/null 2>&1 || true
+echo "Creating cache key ${CACHE_KEY}"
+vault write -f transit/keys/${CACHE_KEY} type=aes256-gcm96 exportable=false >/dev/null 2>&1 || true
+echo "Creating signing key ${SIGN_KEY}"
+vault write -f transit/keys/${SIGN_KEY} type=ed25519 exportable=false >/dev/null 2>&1 || true
 echo "Transit engine ready"
 
 # -----------------------------
@@ -148,6 +154,20 @@ path "transit/datakey/plaintext/${RANDOM_KEY}" {
 path "transit/hmac/${HMAC_KEY}" {
   capabilities = ["create"]
 }
+
+path "transit/datakey/plaintext/${CACHE_KEY}" {
+  capabilities = ["create", "update"]
+}
+path "transit/decrypt/${CACHE_KEY}" {
+  capabilities = ["update"]
+}
+
+path "transit/sign/${SIGN_KEY}" {
+  capabilities = ["update"]
+}
+path "transit/verify/${SIGN_KEY}" {
+  capabilities = ["update"]
+}
 EOF
 
 vault policy write "$POLICY_NAME" "$POLICY_FILE_HOST"
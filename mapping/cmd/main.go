@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 	"github.com/NeF2le/anonix/common/grpc/runner"
+	"github.com/NeF2le/anonix/common/kafka"
 	"github.com/NeF2le/anonix/common/logger"
 	"github.com/NeF2le/anonix/common/postgres"
 	"github.com/NeF2le/anonix/common/redis"
 	"github.com/NeF2le/anonix/common/tls_helpers"
+	"github.com/NeF2le/anonix/common/vault_agent"
 	"github.com/NeF2le/anonix/mapping/internal/config"
+	"github.com/NeF2le/anonix/mapping/internal/ports"
 	"github.com/NeF2le/anonix/mapping/internal/ports/adapters/cache"
+	"github.com/NeF2le/anonix/mapping/internal/ports/adapters/events"
+	"github.com/NeF2le/anonix/mapping/internal/ports/adapters/signer"
 	"github.com/NeF2le/anonix/mapping/internal/ports/adapters/storage"
+	"github.com/NeF2le/anonix/mapping/internal/ports/adapters/syslog"
+	"github.com/NeF2le/anonix/mapping/internal/ports/adapters/vault"
 	"github.com/NeF2le/anonix/mapping/internal/service"
 	transportgrpc "github.com/NeF2le/anonix/mapping/internal/transport/grpc"
 	"google.golang.org/grpc"
@@ -18,7 +25,9 @@ import (
 	"log"
 	"log/slog"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 )
 
 func main() {
@@ -50,14 +59,102 @@ func main() {
 		log.Fatal(err)
 	}
 
-	cacheAdapter := cache.NewRedisAdapter(redisClient)
+	vaultAgent, err := vault_agent.NewVaultAgent(ctx, &cfg.VaultAgent)
+	if err != nil {
+		panic(err)
+	}
+	hashicorpAdapter := vault.NewHashiCorpAdapter(vaultAgent)
+
+	var cacheKeyTtl time.Duration
+	if cfg.Mapping.CacheTtl != 0 {
+		cacheKeyTtl = cfg.Mapping.CacheKeyRotation + cfg.Mapping.CacheTtl
+	}
+	keyring := cache.NewKeyring(redisClient, hashicorpAdapter, cfg.Mapping.CacheKey, cacheKeyTtl)
+	if err = keyring.Rotate(ctx); err != nil {
+		panic(err)
+	}
+	go keyring.Run(ctx, cfg.Mapping.CacheKeyRotation)
+
+	cacheAdapter := cache.NewRedisAdapter(redisClient, keyring)
 	storageAdapter := storage.NewPostgresAdapter(postgresClient)
 
+	var documentSigner ports.Signer = vault.NewTransitSigner(vaultAgent, cfg.Mapping.SigningKey)
+	if cfg.Mapping.LocalSigningKey != "" {
+		documentSigner, err = signer.NewLocalSigner(cfg.Mapping.LocalSigningKey)
+		if err != nil {
+			panic(err)
+		}
+	}
+	var previousKeys []ports.Verifier
+	for _, keyName := range cfg.Mapping.PreviousSigningKeys {
+		if keyName = strings.TrimSpace(keyName); keyName != "" {
+			previousKeys = append(previousKeys, vault.NewTransitSigner(vaultAgent, keyName))
+		}
+	}
+	for _, publicKey := range cfg.Mapping.PreviousLocalVerifyKeys {
+		if publicKey = strings.TrimSpace(publicKey); publicKey == "" {
+			continue
+		}
+		verifier, err := signer.NewLocalVerifier(publicKey)
+		if err != nil {
+			panic(err)
+		}
+		previousKeys = append(previousKeys, verifier)
+	}
+
 	mappingService := service.NewMappingService(
 		storageAdapter,
 		cacheAdapter,
+		documentSigner,
+		previousKeys,
 		cfg.Mapping.CacheTtl,
+		cfg.Mapping.NegativeCacheTtl,
+		cfg.Mapping.EarlyRefreshBeta,
 	)
+	invalidationListener := storage.NewPostgresListener(postgresClient, cfg.Mapping.ListenerRetryDelay)
+	go invalidationListener.Listen(ctx, mappingService.InvalidateCache, mappingService.ResyncCache)
+	go mappingService.RunAuditCheckpoints(ctx, cfg.Mapping.AuditCheckpointInterval)
+
+	var eventPublishers []ports.EventPublisher
+	if len(cfg.Kafka.Brokers) > 0 {
+		kafkaPublisher := events.NewKafkaPublisher(kafka.NewWriter(ctx, &cfg.Kafka, cfg.Mapping.EventsTopic))
+		defer kafkaPublisher.Close()
+		eventPublishers = append(eventPublishers, kafkaPublisher)
+	}
+	if cfg.Syslog.Address != "" {
+		syslogBuffer, err := syslog.OpenDiskBuffer(cfg.Syslog.BufferDir, cfg.Syslog.BufferMaxBytes)
+		if err != nil {
+			panic(err)
+		}
+		syslogSink, err := syslog.NewSink(syslog.SinkConfig{
+			Network:      cfg.Syslog.Network,
+			Address:      cfg.Syslog.Address,
+			CAFile:       cfg.Syslog.CAFile,
+			Format:       cfg.Syslog.Format,
+			Facility:     cfg.Syslog.Facility,
+			AppName:      cfg.Syslog.AppName,
+			RetryDelay:   cfg.Syslog.RetryDelay,
+			WriteTimeout: cfg.Syslog.WriteTimeout,
+		}, syslogBuffer)
+		if err != nil {
+			panic(err)
+		}
+		defer syslogSink.Close()
+		go syslogSink.Run(ctx)
+		eventPublishers = append(eventPublishers, syslogSink)
+	}
+	if len(eventPublishers) > 0 {
+		outboxRelay := service.NewOutboxRelay(
+			storageAdapter,
+			eventPublishers,
+			cfg.Mapping.EventsBatchSize,
+			cfg.Mapping.EventsRetention,
+		)
+		go outboxRelay.Run(ctx, cfg.Mapping.EventsInterval)
+	} else {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "neither kafka brokers nor syslog address are configured, outbox events are not published")
+	}
+
 	grpcHandler := transportgrpc.NewGRPCMappingHandler(mappingService)
 
 	var grpcServer *grpc.Server
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"github.com/NeF2le/anonix/common/kafka"
 	"github.com/NeF2le/anonix/common/postgres"
 	"github.com/NeF2le/anonix/common/redis"
 	"github.com/NeF2le/anonix/common/tls_helpers"
+	"github.com/NeF2le/anonix/common/vault_agent"
 	"github.com/ilyakaznacheev/cleanenv"
 	"time"
 )
@@ -14,13 +16,59 @@ type MappingConfig struct {
 
 	RedisDB  int           `yaml:"redis_db" env:"REDIS_DB" env-required:"true"`
 	CacheTtl time.Duration `yaml:"cache_ttl" env:"CACHE_TTL" env-default:"10h"`
+
+	NegativeCacheTtl time.Duration `yaml:"negative_cache_ttl" env:"NEGATIVE_CACHE_TTL" env-default:"30s"`
+	EarlyRefreshBeta float64       `yaml:"early_refresh_beta" env:"EARLY_REFRESH_BETA" env-default:"1"`
+
+	ListenerRetryDelay time.Duration `yaml:"listener_retry_delay" env:"LISTENER_RETRY_DELAY" env-default:"5s"`
+
+	CacheKey         string        `yaml:"cache_key" env:"CACHE_KEY" env-required:"true"`
+	CacheKeyRotation time.Duration `yaml:"cache_key_rotation" env:"CACHE_KEY_ROTATION" env-default:"24h"`
+
+	// SigningKey is the Vault transit key that signs destruction acts and audit
+	// checkpoints. LocalSigningKey, a base64 ed25519 seed, replaces it when set.
+	// Checkpoints signed before a key change are verified with PreviousSigningKeys,
+	// Vault transit keys, and PreviousLocalVerifyKeys, base64 ed25519 public keys.
+	SigningKey              string   `yaml:"signing_key" env:"SIGNING_KEY" env-default:"my-sign-key"`
+	LocalSigningKey         string   `yaml:"local_signing_key" env:"LOCAL_SIGNING_KEY"`
+	PreviousSigningKeys     []string `yaml:"previous_signing_keys" env:"PREVIOUS_SIGNING_KEYS"`
+	PreviousLocalVerifyKeys []string `yaml:"previous_local_verify_keys" env:"PREVIOUS_LOCAL_VERIFY_KEYS"`
+
+	AuditCheckpointInterval time.Duration `yaml:"audit_checkpoint_interval" env:"AUDIT_CHECKPOINT_INTERVAL" env-default:"1h"`
+
+	// Audit entries and key lifecycle events are published to EventsTopic and audit
+	// entries to the syslog receiver through the outbox. The relay is disabled when
+	// neither Kafka brokers nor a syslog address are configured.
+	EventsTopic     string        `yaml:"events_topic" env:"EVENTS_TOPIC" env-default:"anonix.events"`
+	EventsInterval  time.Duration `yaml:"events_interval" env:"EVENTS_INTERVAL" env-default:"1s"`
+	EventsBatchSize uint64        `yaml:"events_batch_size" env:"EVENTS_BATCH_SIZE" env-default:"500"`
+	EventsRetention time.Duration `yaml:"events_retention" env:"EVENTS_RETENTION" env-default:"168h"`
+}
+
+// SyslogConfig configures forwarding of audit entries to a syslog receiver as RFC 5424
+// messages. Forwarding is disabled when Address is empty.
+type SyslogConfig struct {
+	Address  string `yaml:"address" env:"ADDRESS"`
+	Network  string `yaml:"network" env:"NETWORK" env-default:"tcp"`
+	CAFile   string `yaml:"ca_file" env:"CA_FILE"`
+	Format   string `yaml:"format" env:"FORMAT" env-default:"cef"`
+	Facility int    `yaml:"facility" env:"FACILITY" env-default:"13"`
+	AppName  string `yaml:"app_name" env:"APP_NAME" env-default:"anonix"`
+
+	BufferDir      string        `yaml:"buffer_dir" env:"BUFFER_DIR" env-default:"/var/lib/anonix/syslog"`
+	BufferMaxBytes int64         `yaml:"buffer_max_bytes" env:"BUFFER_MAX_BYTES" env-default:"67108864"`
+	RetryDelay     time.Duration `yaml:"retry_delay" env:"RETRY_DELAY" env-default:"5s"`
+	WriteTimeout   time.Duration `yaml:"write_timeout" env:"WRITE_TIMEOUT" env-default:"5s"`
 }
 
 type Config struct {
-	Postgres postgres.Config    `yaml:"postgres" env-prefix:"POSTGRES_"`
-	Redis    redis.Config       `yaml:"redis" env-prefix:"REDIS_"`
-	Mapping  MappingConfig      `yaml:"mapping" env-prefix:"MAPPING_"`
-	TLS      tls_helpers.Config `yaml:"tls" env-prefix:"TLS_"`
+	Postgres   postgres.Config    `yaml:"postgres" env-prefix:"POSTGRES_"`
+	Redis      redis.Config       `yaml:"redis" env-prefix:"REDIS_"`
+	Mapping    MappingConfig      `yaml:"mapping" env-prefix:"MAPPING_"`
+	VaultAgent vault_agent.Config `yaml:"vault_agent" env-prefix:"VAULT_AGENT_"`
+	TLS        tls_helpers.Config `yaml:"tls" env-prefix:"TLS_"`
+	Kafka      kafka.WriterConfig `yaml:"kafka" env-prefix:"KAFKA_"`
+	Syslog     SyslogConfig       `yaml:"syslog" env-prefix:"SYSLOG_"`
 
 	LogLevel       string `yaml:"log_level" env:"LOG_LEVEL" env-default:"debug"`
 	MigrationsPath string `yaml:"migrations_path" env:"MIGRATIONS_PATH" env-required:"true"`
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditChainGenesis is the previous hash of the first audit log entry.
+var AuditChainGenesis = strings.Repeat("0", 64)
+
+const auditChainFieldSeparator = "\x1f"
+
+// ComputeRowHash recomputes the chain hash of the entry from its content, as
+// mapping.audit_row_hash_v1, mapping.audit_row_hash_v2 and mapping.audit_row_hash_v3 do
+// on insert. It returns an error for unknown chain versions.
+func (e *AuditLogEntry) ComputeRowHash() (string, error) {
+	var kindID string
+	if e.Kind != nil {
+		kindID = strconv.Itoa(int(e.Kind.Id))
+	}
+
+	fields := []string{
+		strconv.FormatInt(e.Seq, 10),
+		e.ID.String(),
+		e.UserID.String(),
+		e.Action,
+		e.Token,
+		kindID,
+		e.Purpose,
+		e.LegalHold,
+	}
+	switch e.ChainVersion {
+	case 1:
+		fields = append([]string{"v1"}, fields...)
+	case 2:
+		fields = append([]string{"v2"}, fields...)
+		fields = append(fields, e.Outcome, e.Reason, e.ClientIP, e.UserAgent, e.RequestID)
+	case 3:
+		fields = append([]string{"v3"}, fields...)
+		fields = append(fields, e.Outcome, e.Reason, e.ClientIP, e.UserAgent, e.RequestID, e.Justification)
+	default:
+		return "", fmt.Errorf("unknown audit chain version %d", e.ChainVersion)
+	}
+	fields = append(fields, e.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000Z"), e.PrevHash)
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, auditChainFieldSeparator)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AuditChainHead is the last entry of the audit hash chain.
+type AuditChainHead struct {
+	Seq     int64
+	RowHash string
+}
+
+// AuditCheckpoint is a signature over the chain head at some moment.
+type AuditCheckpoint struct {
+	ID         uuid.UUID
+	Seq        int64
+	RowHash    string
+	Signature  string
+	SigningKey string
+	CreatedAt  time.Time
+}
+
+// SignedData is the byte representation the checkpoint signature is computed over.
+func (c *AuditCheckpoint) SignedData() []byte {
+	return []byte(fmt.Sprintf("audit-checkpoint\t%d\t%s", c.Seq, c.RowHash))
+}
+
+// AuditChainReport is the result of walking the audit hash chain. When Valid is false,
+// BrokenSeq is the sequence number of the first broken link and Reason describes it;
+// BrokenEntryID is uuid.Nil if that entry is missing.
+type AuditChainReport struct {
+	Valid               bool
+	Checked             int64
+	LastSeq             int64
+	CheckpointsVerified int
+	BrokenSeq           int64
+	BrokenEntryID       uuid.UUID
+	Reason              string
+}
+
+const (
+	AuditChainMissingEntry        = "missing entry"
+	AuditChainPrevHashMismatch    = "previous hash mismatch"
+	AuditChainRowHashMismatch     = "row hash mismatch"
+	AuditChainUnknownVersion      = "unknown chain version"
+	AuditChainCheckpointMismatch  = "checkpoint hash mismatch"
+	AuditChainCheckpointSignature = "invalid checkpoint signature"
+	AuditChainHeadMismatch        = "chain head mismatch"
+)
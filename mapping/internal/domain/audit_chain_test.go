@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+// The digests are those of mapping.audit_row_hash_v1 (migration 000013),
+// mapping.audit_row_hash_v2 (000015) and mapping.audit_row_hash_v3 (000017) for the same
+// rows; NULL columns are stored as empty strings in the entries.
+func TestAuditLogEntry_ComputeRowHash(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name  string
+		entry AuditLogEntry
+		want  string
+	}{
+		{"v1 first entry", AuditLogEntry{
+			ChainVersion: 1, Seq: 1,
+			ID:        uuid.MustParse("6f1c2b7e-3a4d-4e5f-8a9b-0c1d2e3f4a5b"),
+			UserID:    uuid.MustParse("0a1b2c3d-4e5f-4a7b-8c9d-0e1f2a3b4c5d"),
+			Action:    "tokenize",
+			Token:     "tok_4f9a2c",
+			CreatedAt: time.Date(2025, 3, 14, 9, 26, 53, 589793000, time.UTC),
+			PrevHash:  AuditChainGenesis,
+		}, "dc75a26138dc3cdfb13d12a4110b2207d50f8bc0c364aefda6acc631b4037530"},
+		{"v1 with kind, purpose and legal hold", AuditLogEntry{
+			ChainVersion: 1, Seq: 2,
+			ID:        uuid.MustParse("7a8b9c0d-1e2f-4a3b-9c4d-5e6f7a8b9c0d"),
+			UserID:    uuid.MustParse("0a1b2c3d-4e5f-4a7b-8c9d-0e1f2a3b4c5d"),
+			Action:    "detokenize",
+			Token:     "tok_4f9a2c",
+			Kind:      &Kind{Id: 3},
+			Purpose:   "billing",
+			LegalHold: "case-2025-17",
+			CreatedAt: time.Date(2025, 3, 14, 12, 0, 0, 0, msk),
+			PrevHash:  "dc75a26138dc3cdfb13d12a4110b2207d50f8bc0c364aefda6acc631b4037530",
+		}, "f8985dc7c3949c1a02e351a08934397605acb30a149bdb5759683cf865578206"},
+		{"v2 with request context", AuditLogEntry{
+			ChainVersion: 2, Seq: 3,
+			ID:        uuid.MustParse("8b9c0d1e-2f3a-4b4c-8d5e-6f7a8b9c0d1e"),
+			UserID:    uuid.MustParse("1b2c3d4e-5f6a-4b8c-9d0e-1f2a3b4c5d6e"),
+			Action:    "detokenize",
+			Token:     "tok_4f9a2c",
+			Kind:      &Kind{Id: 3},
+			Purpose:   "billing",
+			Outcome:   "denied",
+			Reason:    "purpose not allowed",
+			ClientIP:  "203.0.113.7",
+			UserAgent: "curl/8.5.0",
+			RequestID: "req-42",
+			CreatedAt: time.Date(2025, 3, 15, 23, 59, 59, 999999000, time.UTC),
+			PrevHash:  "f8985dc7c3949c1a02e351a08934397605acb30a149bdb5759683cf865578206",
+		}, "d66a8226d15a48ef24dfab40934045da62eff7557b3f2f7a91e73ee5bbf907da"},
+		{"v2 without optional fields", AuditLogEntry{
+			ChainVersion: 2, Seq: 4,
+			ID:        uuid.MustParse("9c0d1e2f-3a4b-4c5d-9e6f-7a8b9c0d1e2f"),
+			UserID:    uuid.Nil,
+			Action:    "login",
+			Token:     "ivanov",
+			Outcome:   "success",
+			CreatedAt: time.Date(2025, 3, 16, 0, 0, 0, 1000, time.UTC),
+			PrevHash:  "d66a8226d15a48ef24dfab40934045da62eff7557b3f2f7a91e73ee5bbf907da",
+		}, "9b8a8bd827878a08c39e2ae6a8a62e2726ca8d76c360c7478e152e56ab995b8b"},
+		{"v3 with break-glass justification", AuditLogEntry{
+			ChainVersion: 3, Seq: 5,
+			ID:            uuid.MustParse("ad1e2f3a-4b5c-4d6e-8f7a-8b9c0d1e2f3a"),
+			UserID:        uuid.MustParse("1b2c3d4e-5f6a-4b8c-9d0e-1f2a3b4c5d6e"),
+			Action:        "detokenize",
+			Token:         "тестовый токен",
+			Kind:          &Kind{Id: 4},
+			Purpose:       "fraud_check",
+			Outcome:       "success",
+			ClientIP:      "2001:db8::1",
+			UserAgent:     "Mozilla/5.0",
+			RequestID:     "req-43",
+			Justification: "инцидент INC-7: проверка мошеннической операции",
+			CreatedAt:     time.Date(2025, 3, 16, 8, 30, 15, 123456000, time.UTC),
+			PrevHash:      "9b8a8bd827878a08c39e2ae6a8a62e2726ca8d76c360c7478e152e56ab995b8b",
+		}, "93a76f4997966177ae86cacac3949143101b886bbb7a716f5e9c8983b854fd43"},
+		{"v3 outside of break-glass", AuditLogEntry{
+			ChainVersion: 3, Seq: 6,
+			ID:        uuid.MustParse("be2f3a4b-5c6d-4e7f-9a8b-9c0d1e2f3a4b"),
+			UserID:    uuid.MustParse("1b2c3d4e-5f6a-4b8c-9d0e-1f2a3b4c5d6e"),
+			Action:    "erase",
+			Token:     "subj-17",
+			Outcome:   "error",
+			Reason:    "failed to erase subject",
+			ClientIP:  "198.51.100.2",
+			RequestID: "req-44",
+			CreatedAt: time.Date(2025, 3, 16, 8, 30, 15, 123456000, msk),
+			PrevHash:  "93a76f4997966177ae86cacac3949143101b886bbb7a716f5e9c8983b854fd43",
+		}, "174d261929f37447c11511fdb3a615e2c0465c49f856ca8173038cd48c2eeade"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.entry.ComputeRowHash()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogEntry_ComputeRowHash_UnknownVersion(t *testing.T) {
+	for _, version := range []int16{0, 4} {
+		entry := AuditLogEntry{ChainVersion: version, PrevHash: AuditChainGenesis}
+		if _, err := entry.ComputeRowHash(); err == nil {
+			t.Errorf("chain version %d accepted", version)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"github.com/google/uuid"
 	"time"
 )
@@ -12,4 +14,119 @@ type AuditLogEntry struct {
 	Token     string    `json:"token"`
 	Kind      *Kind     `json:"kind"`
 	CreatedAt time.Time `json:"created_at"`
+	Purpose   string    `json:"purpose,omitempty"`
+	LegalHold string    `json:"legal_hold,omitempty"`
+
+	// Outcome is one of the AuditOutcome constants; Reason explains a denial or an error.
+	Outcome   string `json:"outcome"`
+	Reason    string `json:"reason,omitempty"`
+	ClientIP  string `json:"client_ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// Justification is the justification of the break-glass session the entry was
+	// written in; it is empty outside of break-glass sessions.
+	Justification string `json:"justification,omitempty"`
+
+	// Seq, PrevHash, RowHash and ChainVersion place the entry in the audit hash chain;
+	// they are assigned by the database on insert.
+	Seq          int64  `json:"seq"`
+	PrevHash     string `json:"prev_hash"`
+	RowHash      string `json:"row_hash"`
+	ChainVersion int16  `json:"chain_version"`
+}
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeDenied  = "denied"
+	AuditOutcomeError   = "error"
+)
+
+// IsValidAuditOutcome reports whether outcome is one of the AuditOutcome constants.
+func IsValidAuditOutcome(outcome string) bool {
+	switch outcome {
+	case AuditOutcomeSuccess, AuditOutcomeDenied, AuditOutcomeError:
+		return true
+	}
+	return false
+}
+
+// AuditActionExport is the action of the entry recorded for every audit log export.
+const AuditActionExport = "audit_export"
+
+// AuditActionAnomalyBlock is the action of the entry recorded by the gateway when it
+// restricts a user for mass detokenization; such entries are forwarded with high severity.
+const AuditActionAnomalyBlock = "anomaly_block"
+
+// AuditActionBreakGlassStart is the action of the entry recorded by the gateway when a
+// user starts a break-glass session; such entries are forwarded with high severity.
+const AuditActionBreakGlassStart = "break_glass_start"
+
+const (
+	AuditLogSortTime   = "time"
+	AuditLogSortUserID = "user_id"
+	AuditLogSortAction = "action"
+	AuditLogSortToken  = "token"
+)
+
+// AuditLogFilter selects audit log entries; zero fields match everything. To is
+// exclusive.
+type AuditLogFilter struct {
+	UserID      uuid.UUID
+	Action      string
+	Token       string
+	KindID      int32
+	AccessLevel int32
+	Outcome     string
+	RequestID   string
+	BreakGlass  bool
+	From        time.Time
+	To          time.Time
+}
+
+// AuditLogCursor is the position after which the next page starts: the sort value and
+// the chain sequence number of the last entry of the previous page.
+type AuditLogCursor struct {
+	Value string `json:"v,omitempty"`
+	Seq   int64  `json:"s"`
+}
+
+func (c *AuditLogCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeAuditLogCursor(cursor string) (*AuditLogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var c AuditLogCursor
+	if err = json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// AuditLogQuery is a page request. Entries are ordered by SortBy and then by sequence
+// number, both ascending or both descending.
+type AuditLogQuery struct {
+	Filter     AuditLogFilter
+	SortBy     string
+	Descending bool
+	Limit      int
+	After      *AuditLogCursor
+}
+
+// CursorFor returns the cursor pointing right after entry in the order of q.
+func (q *AuditLogQuery) CursorFor(entry *AuditLogEntry) *AuditLogCursor {
+	c := &AuditLogCursor{Seq: entry.Seq}
+	switch q.SortBy {
+	case AuditLogSortUserID:
+		c.Value = entry.UserID.String()
+	case AuditLogSortAction:
+		c.Value = entry.Action
+	case AuditLogSortToken:
+		c.Value = entry.Token
+	}
+	return c
 }
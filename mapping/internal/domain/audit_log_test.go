@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestAuditLogQuery_CursorFor(t *testing.T) {
+	entry := &AuditLogEntry{UserID: uuid.New(), Action: "detokenize", Token: "fio_1", Seq: 42}
+
+	tests := []struct {
+		sortBy    string
+		wantValue string
+	}{
+		{AuditLogSortTime, ""},
+		{"", ""},
+		{AuditLogSortUserID, entry.UserID.String()},
+		{AuditLogSortAction, "detokenize"},
+		{AuditLogSortToken, "fio_1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			query := &AuditLogQuery{SortBy: tt.sortBy}
+			cursor, err := DecodeAuditLogCursor(query.CursorFor(entry).Encode())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *cursor != (AuditLogCursor{Value: tt.wantValue, Seq: 42}) {
+				t.Errorf("got %+v", cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeAuditLogCursor_Malformed(t *testing.T) {
+	for _, cursor := range []string{"not base64!", "bm90IGpzb24"} {
+		if c, err := DecodeAuditLogCursor(cursor); err == nil {
+			t.Errorf("%q decoded to %+v", cursor, c)
+		}
+	}
+}
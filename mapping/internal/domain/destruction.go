@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	DestructionReasonTTL     = "ttl"
+	DestructionReasonManual  = "manual"
+	DestructionReasonErasure = "subject_erasure"
+
+	// DestructionInitiatorTTL is the initiator of deletions caused by an expired TTL.
+	DestructionInitiatorTTL = "ttl"
+)
+
+// DestructionCause describes why and by whom mappings are deleted. Initiator is a user
+// id or DestructionInitiatorTTL.
+type DestructionCause struct {
+	Reason    string
+	Initiator string
+}
+
+// DestructionEvent is recorded for every deleted mapping. The kind is a copy taken at
+// deletion time; KindID is 0 for mappings without a kind.
+type DestructionEvent struct {
+	ID              uuid.UUID `json:"id"`
+	MappingID       uuid.UUID `json:"mapping_id"`
+	Token           string    `json:"token"`
+	KindID          int32     `json:"kind_id"`
+	KindName        string    `json:"kind_name"`
+	KindRussianName string    `json:"kind_russian_name"`
+	Reason          string    `json:"reason"`
+	Initiator       string    `json:"initiator"`
+	DestroyedAt     time.Time `json:"destroyed_at"`
+}
+
+type DestructionActGroup struct {
+	KindID          int32               `json:"kind_id"`
+	KindName        string              `json:"kind_name"`
+	KindRussianName string              `json:"kind_russian_name"`
+	Events          []*DestructionEvent `json:"events"`
+}
+
+// DestructionAct lists the mappings destroyed in [PeriodFrom, PeriodTo), grouped by
+// kind. Digest is the hex SHA-256 of Canonical and Signature its signature by
+// SigningKey.
+type DestructionAct struct {
+	PeriodFrom  time.Time              `json:"period_from"`
+	PeriodTo    time.Time              `json:"period_to"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	GeneratedBy uuid.UUID              `json:"generated_by"`
+	Total       int                    `json:"total"`
+	Groups      []*DestructionActGroup `json:"groups"`
+	Digest      string                 `json:"digest"`
+	Signature   string                 `json:"signature"`
+	SigningKey  string                 `json:"signing_key"`
+}
+
+// Canonical is the byte representation the digest is computed over: a header line
+// followed by one line per event, in act order, with tab-separated fields and RFC 3339
+// timestamps in UTC with nanoseconds.
+func (a *DestructionAct) Canonical() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "destruction-act\t%s\t%s\t%s\t%s\t%d\n",
+		formatCanonicalTime(a.PeriodFrom),
+		formatCanonicalTime(a.PeriodTo),
+		formatCanonicalTime(a.GeneratedAt),
+		a.GeneratedBy,
+		a.Total)
+	for _, group := range a.Groups {
+		for _, e := range group.Events {
+			fmt.Fprintf(&b, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+				e.ID,
+				e.MappingID,
+				e.Token,
+				e.KindID,
+				e.KindName,
+				e.Reason,
+				e.Initiator,
+				formatCanonicalTime(e.DestroyedAt))
+		}
+	}
+	return []byte(b.String())
+}
+
+func formatCanonicalTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	InvalidationTableMappings = "mappings"
+	InvalidationTableKinds    = "kinds"
+)
+
+// CacheInvalidation is a change notification published by the mapping.mappings and
+// mapping.kinds triggers. MappingID and Token are set for mapping changes, KindID for
+// kind changes.
+type CacheInvalidation struct {
+	Table     string    `json:"table"`
+	Op        string    `json:"op"`
+	MappingID uuid.UUID `json:"mapping_id"`
+	Token     string    `json:"token"`
+	KindID    int32     `json:"kind_id"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	LegalHoldTargetToken   = "token"
+	LegalHoldTargetSubject = "subject"
+	LegalHoldTargetKind    = "kind"
+)
+
+// LegalHoldTarget is what a hold preserves: a token, every mapping of a data subject or
+// every mapping of a kind (Value is then the kind id).
+type LegalHoldTarget struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// LegalHold blocks deletion of the mappings it targets until it is released or its
+// ExpiresAt passes. A zero ExpiresAt means the hold lasts until released.
+type LegalHold struct {
+	ID         uuid.UUID         `json:"id"`
+	Name       string            `json:"name"`
+	Reason     string            `json:"reason"`
+	Owner      string            `json:"owner"`
+	Targets    []LegalHoldTarget `json:"targets"`
+	CreatedBy  uuid.UUID         `json:"created_by"`
+	CreatedAt  time.Time         `json:"created_at"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	ReleasedAt time.Time         `json:"released_at"`
+	ReleasedBy uuid.UUID         `json:"released_by"`
+}
+
+func (h *LegalHold) Active(now time.Time) bool {
+	return h.ReleasedAt.IsZero() && (h.ExpiresAt.IsZero() || h.ExpiresAt.After(now))
+}
@@ -15,4 +15,20 @@ type Mapping struct {
 	Deterministic bool          `json:"deterministic"`
 	Kind          *Kind         `json:"kind"`
 	AlgoName      string        `json:"algo_name"`
+	SubjectRef    string        `json:"subject_ref,omitempty"`
+
+	// Purposes lists the processing purposes the mapping may be detokenized for. An
+	// empty list means the mapping predates purpose limitation and is unrestricted.
+	Purposes         []string  `json:"purposes,omitempty"`
+	LegalBasis       string    `json:"legal_basis,omitempty"`
+	ConsentRef       string    `json:"consent_ref,omitempty"`
+	ConsentExpiresAt time.Time `json:"consent_expires_at,omitempty"`
+}
+
+// ErasureReport describes the result of erasing all mappings of a data subject.
+type ErasureReport struct {
+	SubjectRef string
+	ErasedBy   uuid.UUID
+	ErasedAt   time.Time
+	Erased     []*Mapping
 }
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Types of the events written to the outbox.
+const (
+	EventTypeAuditEntry   = "audit.entry"
+	EventTypeDekCreated   = "key.dek_created"
+	EventTypeDekRewrapped = "key.dek_rewrapped"
+	EventTypeDekRotated   = "key.dek_rotated"
+	EventTypeDekDestroyed = "key.dek_destroyed"
+)
+
+// EventEnvelopeVersion is the version of the envelope every published event is wrapped
+// in. SchemaVersion of the event itself versions its Data independently.
+const EventEnvelopeVersion = 1
+
+// OutboxEvent is an event waiting in the outbox to be published. Events sharing a
+// PartitionKey are published in ID order to the same partition.
+type OutboxEvent struct {
+	ID            int64
+	EventID       uuid.UUID
+	EventType     string
+	SchemaVersion int16
+	PartitionKey  string
+	Payload       json.RawMessage
+	CreatedAt     time.Time
+}
+
+// EventEnvelope is the published form of an OutboxEvent. Consumers deduplicate
+// redelivered events by EventID.
+type EventEnvelope struct {
+	EnvelopeVersion int             `json:"envelope_version"`
+	EventID         uuid.UUID       `json:"event_id"`
+	EventType       string          `json:"event_type"`
+	SchemaVersion   int16           `json:"schema_version"`
+	Source          string          `json:"source"`
+	Sequence        int64           `json:"sequence"`
+	OccurredAt      time.Time       `json:"occurred_at"`
+	Data            json.RawMessage `json:"data"`
+}
+
+func (e *OutboxEvent) Envelope() ([]byte, error) {
+	return json.Marshal(EventEnvelope{
+		EnvelopeVersion: EventEnvelopeVersion,
+		EventID:         e.EventID,
+		EventType:       e.EventType,
+		SchemaVersion:   e.SchemaVersion,
+		Source:          "anonix.mapping",
+		Sequence:        e.ID,
+		OccurredAt:      e.CreatedAt.UTC(),
+		Data:            e.Payload,
+	})
+}
+
+// AuditEntryEventData is the payload of an audit.entry event of schema version 1.
+type AuditEntryEventData struct {
+	ID            string    `json:"id"`
+	Seq           int64     `json:"seq"`
+	UserID        string    `json:"user_id"`
+	Action        string    `json:"action"`
+	Token         string    `json:"token"`
+	KindID        *int32    `json:"kind_id"`
+	Purpose       string    `json:"purpose"`
+	LegalHold     string    `json:"legal_hold"`
+	Outcome       string    `json:"outcome"`
+	Reason        string    `json:"reason"`
+	ClientIP      string    `json:"client_ip"`
+	UserAgent     string    `json:"user_agent"`
+	RequestID     string    `json:"request_id"`
+	Justification string    `json:"justification,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	PrevHash      string    `json:"prev_hash"`
+	RowHash       string    `json:"row_hash"`
+	ChainVersion  int16     `json:"chain_version"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOutboxEvent_Envelope(t *testing.T) {
+	event := &OutboxEvent{
+		ID:            42,
+		EventID:       uuid.New(),
+		EventType:     EventTypeDekRotated,
+		SchemaVersion: 2,
+		PartitionKey:  "fio_1",
+		Payload:       json.RawMessage(`{"token":"fio_1"}`),
+		CreatedAt:     time.Date(2026, 3, 14, 15, 0, 0, 0, time.FixedZone("MSK", 3*60*60)),
+	}
+
+	raw, err := event.Envelope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var envelope EventEnvelope
+	if err = json.Unmarshal(raw, &envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	want := EventEnvelope{
+		EnvelopeVersion: EventEnvelopeVersion,
+		EventID:         event.EventID,
+		EventType:       EventTypeDekRotated,
+		SchemaVersion:   2,
+		Source:          "anonix.mapping",
+		Sequence:        42,
+		OccurredAt:      time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC),
+		Data:            event.Payload,
+	}
+	if !reflect.DeepEqual(envelope, want) {
+		t.Errorf("got %+v, want %+v", envelope, want)
+	}
+}
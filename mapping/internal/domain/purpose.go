@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Purpose is an entry of the admin-managed processing purpose catalog.
+type Purpose struct {
+	Id          int32     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/mapping/internal/ports"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const cacheKeyBits = 256
+
+var errUnknownCacheKey = errors.New("unknown cache key")
+
+// envelope is what actually gets stored in Redis. Ciphertext is the AES-GCM sealed
+// mapping payload; the Redis key it is stored under is used as additional data, so an
+// entry cannot be moved to another key.
+type envelope struct {
+	KeyID      string `json:"kid"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ct"`
+}
+
+type cacheKey struct {
+	aead      cipher.AEAD
+	expiresAt time.Time
+}
+
+// Keyring holds the local AES keys used to encrypt mapping cache entries. Keys are
+// Vault data keys: the plaintext only ever lives in process memory, while the wrapped
+// form is published in Redis under its key id so that other replicas can unwrap it
+// through Vault. A Redis dump therefore cannot be decrypted without Vault access.
+type Keyring struct {
+	client  *redis.Client
+	vault   ports.VaultRepository
+	keyName string
+	keyTtl  time.Duration
+
+	mu        sync.RWMutex
+	currentID string
+	keys      map[string]*cacheKey
+}
+
+// NewKeyring creates a keyring backed by the Vault transit key keyName. keyTtl is how
+// long a retired key stays usable for decryption; it should cover the rotation period
+// plus the cache entry TTL. A keyTtl of 0 keeps keys forever.
+func NewKeyring(client *redis.Client, vault ports.VaultRepository, keyName string, keyTtl time.Duration) *Keyring {
+	return &Keyring{
+		client:  client,
+		vault:   vault,
+		keyName: keyName,
+		keyTtl:  keyTtl,
+		keys:    make(map[string]*cacheKey),
+	}
+}
+
+// Rotate generates a fresh data key in Vault, publishes its wrapped form and makes it
+// the key for new cache entries. Expired local keys are dropped.
+func (k *Keyring) Rotate(ctx context.Context) error {
+	wrapped, plaintext, err := k.vault.GenerateDEK(ctx, cacheKeyBits, k.keyName)
+	if err != nil {
+		return fmt.Errorf("Rotate: failed to generate cache key: %v", err)
+	}
+	aead, err := newAEAD(plaintext)
+	if err != nil {
+		return fmt.Errorf("Rotate: %v", err)
+	}
+
+	id := uuid.NewString()
+	if err = k.client.Set(ctx, keyringKey(id), wrapped, k.keyTtl).Err(); err != nil {
+		return fmt.Errorf("Rotate: failed to publish wrapped cache key: %v", err)
+	}
+
+	now := time.Now()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for keyID, key := range k.keys {
+		if !key.expiresAt.IsZero() && key.expiresAt.Before(now) {
+			delete(k.keys, keyID)
+		}
+	}
+	k.keys[id] = &cacheKey{aead: aead, expiresAt: k.expiresAt(now)}
+	k.currentID = id
+
+	return nil
+}
+
+// Run rotates the cache key every interval until ctx is done.
+func (k *Keyring) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.Rotate(ctx); err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to rotate cache key", logger.Err(err))
+				continue
+			}
+			logger.GetLoggerFromCtx(ctx).Info(ctx, "cache key rotated",
+				slog.String("key id", k.currentKeyID()))
+		}
+	}
+}
+
+func (k *Keyring) seal(payload []byte, redisKey string) (*envelope, error) {
+	k.mu.RLock()
+	id := k.currentID
+	key := k.keys[id]
+	k.mu.RUnlock()
+	if key == nil {
+		return nil, errors.New("cache key is not loaded")
+	}
+
+	nonce := make([]byte, key.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	return &envelope{
+		KeyID:      id,
+		Nonce:      nonce,
+		Ciphertext: key.aead.Seal(nil, nonce, payload, []byte(redisKey)),
+	}, nil
+}
+
+func (k *Keyring) open(ctx context.Context, env *envelope, redisKey string) ([]byte, error) {
+	key, err := k.key(ctx, env.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != key.aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	return key.aead.Open(nil, env.Nonce, env.Ciphertext, []byte(redisKey))
+}
+
+// key returns the key with the given id, unwrapping it through Vault if it was created
+// by another replica.
+func (k *Keyring) key(ctx context.Context, id string) (*cacheKey, error) {
+	k.mu.RLock()
+	key := k.keys[id]
+	k.mu.RUnlock()
+	if key != nil {
+		return key, nil
+	}
+
+	wrapped, err := k.client.Get(ctx, keyringKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errUnknownCacheKey
+		}
+		return nil, fmt.Errorf("failed to get wrapped cache key: %v", err)
+	}
+	plaintext, err := k.vault.UnwrapDEK(ctx, wrapped, k.keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap cache key: %v", err)
+	}
+	aead, err := newAEAD(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	key = &cacheKey{aead: aead, expiresAt: k.expiresAt(time.Now())}
+	k.mu.Lock()
+	k.keys[id] = key
+	k.mu.Unlock()
+
+	return key, nil
+}
+
+func (k *Keyring) currentKeyID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.currentID
+}
+
+func (k *Keyring) expiresAt(now time.Time) time.Time {
+	if k.keyTtl == 0 {
+		return time.Time{}
+	}
+	return now.Add(k.keyTtl)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %v", err)
+	}
+	return aead, nil
+}
+
+func keyringKey(id string) string {
+	return fmt.Sprintf("mapping:cache-key:%s", id)
+}
@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/google/uuid"
+	"strings"
+	"testing"
+)
+
+// storedEnvelope returns the envelope stored in Redis under key.
+func storedEnvelope(t *testing.T, f *fakeRedis, key string) *envelope {
+	t.Helper()
+	var env envelope
+	if err := json.Unmarshal([]byte(f.values[key]), &env); err != nil {
+		t.Fatalf("%s does not hold an envelope: %v", key, err)
+	}
+	return &env
+}
+
+func storeEnvelope(t *testing.T, f *fakeRedis, key string, env *envelope) {
+	t.Helper()
+	sealed, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.values[key] = string(sealed)
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	ctx := context.Background()
+	r, f := newAdapter(t)
+
+	mapping := newMapping("fio_1")
+	if err := r.SaveMapping(ctx, mapping, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := f.values[idKey(mapping.ID)]
+	for _, plaintext := range []string{"fio_1", "Y2lwaGVy", "aes-gcm"} {
+		if strings.Contains(stored, plaintext) {
+			t.Errorf("cache entry contains %q in the clear: %s", plaintext, stored)
+		}
+	}
+	if env := storedEnvelope(t, f, idKey(mapping.ID)); env.KeyID != r.keyring.currentKeyID() {
+		t.Errorf("sealed with key %q, want the current key %q", env.KeyID, r.keyring.currentKeyID())
+	}
+
+	got, _, err := r.GetMappingById(ctx, mapping.ID)
+	if err != nil || got == nil {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	if got.Token != "fio_1" || string(got.CipherText) != "cipher" || string(got.DekWrapped) != "dek" ||
+		!got.CreatedAt.Equal(mapping.CreatedAt) {
+		t.Errorf("got %+v, want %+v", got, mapping)
+	}
+}
+
+func TestKeyring_RejectsTamperedEntries(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		tamper func(t *testing.T, f *fakeRedis, id uuid.UUID, env *envelope) uuid.UUID
+	}{
+		{"flipped ciphertext", func(t *testing.T, f *fakeRedis, id uuid.UUID, env *envelope) uuid.UUID {
+			env.Ciphertext[0] ^= 1
+			storeEnvelope(t, f, idKey(id), env)
+			return id
+		}},
+		{"other nonce", func(t *testing.T, f *fakeRedis, id uuid.UUID, env *envelope) uuid.UUID {
+			env.Nonce[0] ^= 1
+			storeEnvelope(t, f, idKey(id), env)
+			return id
+		}},
+		{"short nonce", func(t *testing.T, f *fakeRedis, id uuid.UUID, env *envelope) uuid.UUID {
+			env.Nonce = env.Nonce[:4]
+			storeEnvelope(t, f, idKey(id), env)
+			return id
+		}},
+		// The Redis key is the additional data of the envelope, so an entry copied to
+		// the key of another mapping does not open.
+		{"moved to another key", func(t *testing.T, f *fakeRedis, _ uuid.UUID, env *envelope) uuid.UUID {
+			other := uuid.New()
+			storeEnvelope(t, f, idKey(other), env)
+			return other
+		}},
+		{"malformed", func(t *testing.T, f *fakeRedis, id uuid.UUID, _ *envelope) uuid.UUID {
+			f.values[idKey(id)] = "not an envelope"
+			return id
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, f := newAdapter(t)
+			mapping := newMapping("fio_1")
+			if err := r.SaveMapping(ctx, mapping, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			id := tt.tamper(t, f, mapping.ID, storedEnvelope(t, f, idKey(mapping.ID)))
+			if got, _, err := r.GetMappingById(ctx, id); got != nil || err != nil {
+				t.Fatalf("got %+v, %v, want a miss", got, err)
+			}
+		})
+	}
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	ctx := context.Background()
+	r, f := newAdapter(t)
+
+	before := newMapping("fio_1")
+	if err := r.SaveMapping(ctx, before, 0); err != nil {
+		t.Fatal(err)
+	}
+	previousID := r.keyring.currentKeyID()
+
+	if err := r.keyring.Rotate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if r.keyring.currentKeyID() == previousID {
+		t.Fatal("rotation kept the key id")
+	}
+	after := newMapping("fio_2")
+	if err := r.SaveMapping(ctx, after, 0); err != nil {
+		t.Fatal(err)
+	}
+	if env := storedEnvelope(t, f, idKey(after.ID)); env.KeyID != r.keyring.currentKeyID() {
+		t.Errorf("sealed with key %q after rotation", env.KeyID)
+	}
+
+	// Only wrapped keys are published.
+	for _, id := range []string{previousID, r.keyring.currentKeyID()} {
+		if wrapped := f.values[keyringKey(id)]; !strings.HasPrefix(wrapped, "wrapped:") {
+			t.Errorf("key %s published as %q", id, wrapped)
+		}
+	}
+
+	// Entries sealed with the previous key still open, on this replica and on another
+	// that unwraps both keys through Vault.
+	other := NewRedisAdapter(r.client, NewKeyring(r.client, &fakeVault{}, "cache", 0))
+	for _, adapter := range []*RedisAdapter{r, other} {
+		for _, mapping := range []*domain.Mapping{before, after} {
+			got, _, err := adapter.GetMappingById(ctx, mapping.ID)
+			if err != nil || got == nil || got.Token != mapping.Token {
+				t.Errorf("got %+v, %v, want %s", got, err, mapping.Token)
+			}
+		}
+	}
+
+	// An entry sealed with a key that is no longer published is a miss.
+	delete(f.values, keyringKey(previousID))
+	third := NewRedisAdapter(r.client, NewKeyring(r.client, &fakeVault{}, "cache", 0))
+	if got, _, err := third.GetMappingById(ctx, before.ID); got != nil || err != nil {
+		t.Errorf("unknown key: got %+v, %v, want a miss", got, err)
+	}
+}
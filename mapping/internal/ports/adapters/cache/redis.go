@@ -5,78 +5,266 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/common/logger"
 	"github.com/NeF2le/anonix/mapping/internal/domain"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"log/slog"
 	"time"
 )
 
 type mappingCache struct {
 	ID            uuid.UUID     `json:"id"`
+	Token         string        `json:"token"`
 	DekWrapped    []byte        `json:"dek_wrapped,omitempty"`
 	CipherText    []byte        `json:"cipher_text,omitempty"`
 	TokenTtl      time.Duration `json:"token_ttl,omitempty"`
 	CreatedAt     time.Time     `json:"created_at"`
 	Deterministic bool          `json:"deterministic"`
 	Kind          *domain.Kind  `json:"kind"`
+	AlgoName      string        `json:"algo_name"`
+	SubjectRef    string        `json:"subject_ref,omitempty"`
+
+	Purposes         []string  `json:"purposes,omitempty"`
+	LegalBasis       string    `json:"legal_basis,omitempty"`
+	ConsentRef       string    `json:"consent_ref,omitempty"`
+	ConsentExpiresAt time.Time `json:"consent_expires_at,omitempty"`
+}
+
+func (c *mappingCache) toDomain() *domain.Mapping {
+	return &domain.Mapping{
+		ID:            c.ID,
+		Token:         c.Token,
+		DekWrapped:    c.DekWrapped,
+		CipherText:    c.CipherText,
+		TokenTtl:      c.TokenTtl,
+		CreatedAt:     c.CreatedAt,
+		Deterministic: c.Deterministic,
+		Kind:          c.Kind,
+		AlgoName:      c.AlgoName,
+		SubjectRef:    c.SubjectRef,
+
+		Purposes:         c.Purposes,
+		LegalBasis:       c.LegalBasis,
+		ConsentRef:       c.ConsentRef,
+		ConsentExpiresAt: c.ConsentExpiresAt,
+	}
+}
+
+// missingTokenMarker is stored in the token index for tokens known not to exist.
+const missingTokenMarker = "-"
+
+func idKey(id uuid.UUID) string {
+	return fmt.Sprintf("mapping:id:%s", id)
+}
+
+// tokenKey is the secondary index used by the detokenize path. It only stores the
+// mapping id; the mapping itself always lives under idKey.
+func tokenKey(token string) string {
+	return fmt.Sprintf("mapping:token:%s", token)
 }
 
 type RedisAdapter struct {
-	client *redis.Client
+	client  *redis.Client
+	keyring *Keyring
 }
 
-func NewRedisAdapter(client *redis.Client) *RedisAdapter {
-	return &RedisAdapter{client: client}
+func NewRedisAdapter(client *redis.Client, keyring *Keyring) *RedisAdapter {
+	return &RedisAdapter{client: client, keyring: keyring}
 }
 
 func (r *RedisAdapter) SaveMapping(ctx context.Context, mapping *domain.Mapping, ttl time.Duration) error {
-	key := fmt.Sprintf("mapping:id:%v", mapping.ID)
-
 	cacheObj := &mappingCache{
 		ID:            mapping.ID,
+		Token:         mapping.Token,
 		DekWrapped:    mapping.DekWrapped,
 		CipherText:    mapping.CipherText,
 		TokenTtl:      mapping.TokenTtl,
 		CreatedAt:     mapping.CreatedAt,
 		Deterministic: mapping.Deterministic,
 		Kind:          mapping.Kind,
+		AlgoName:      mapping.AlgoName,
+		SubjectRef:    mapping.SubjectRef,
+
+		Purposes:         mapping.Purposes,
+		LegalBasis:       mapping.LegalBasis,
+		ConsentRef:       mapping.ConsentRef,
+		ConsentExpiresAt: mapping.ConsentExpiresAt,
 	}
 	payload, err := json.Marshal(cacheObj)
 	if err != nil {
 		return fmt.Errorf("SaveMapping: failed to marshal mapping: %v", err)
 	}
 
-	err = r.client.Set(ctx, key, string(payload), ttl).Err()
+	env, err := r.keyring.seal(payload, idKey(mapping.ID))
+	if err != nil {
+		return fmt.Errorf("SaveMapping: failed to encrypt mapping: %v", err)
+	}
+	sealed, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("SaveMapping: failed to marshal envelope: %v", err)
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, idKey(mapping.ID), string(sealed), ttl)
+		if mapping.Token != "" {
+			pipe.Set(ctx, tokenKey(mapping.Token), mapping.ID.String(), ttl)
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("SaveMapping: failed to save mapping in cache: %v", err)
 	}
 	return nil
 }
 
-func (r *RedisAdapter) GetMappingById(ctx context.Context, id uuid.UUID) (*domain.Mapping, error) {
-	result := r.client.Get(ctx, fmt.Sprintf("mapping:id:%s", id))
-	if err := result.Err(); err != nil {
+// GetMappingById returns the cached mapping and the remaining lifetime of its cache
+// entry (0 if the entry never expires), or nil if there is none. Entries that cannot
+// be decrypted (unknown or expired cache key, tampered payload) are misses.
+func (r *RedisAdapter) GetMappingById(ctx context.Context, id uuid.UUID) (*domain.Mapping, time.Duration, error) {
+	key := idKey(id)
+
+	var (
+		get  *redis.StringCmd
+		pttl *redis.DurationCmd
+	)
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return nil, nil
+			return nil, 0, nil
 		}
-		return nil, fmt.Errorf("GetMappingById: failed to get mapping: %v", err)
+		return nil, 0, fmt.Errorf("GetMappingById: failed to get mapping: %v", err)
+	}
+
+	var env envelope
+	if err = json.Unmarshal([]byte(get.Val()), &env); err != nil {
+		logger.GetLoggerFromCtx(ctx).Debug(ctx, "discarding malformed cache entry",
+			slog.String("id", id.String()))
+		return nil, 0, nil
+	}
+	payload, err := r.keyring.open(ctx, &env, key)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Debug(ctx, "discarding undecryptable cache entry",
+			slog.String("id", id.String()),
+			slog.String("key id", env.KeyID),
+			logger.Err(err))
+		return nil, 0, nil
+	}
+
+	var cacheObj mappingCache
+	if err = json.Unmarshal(payload, &cacheObj); err != nil {
+		return nil, 0, fmt.Errorf("GetMappingById: failed to unmarshal mapping: %v", err)
 	}
 
-	var mapping domain.Mapping
-	if err := json.Unmarshal([]byte(result.Val()), &mapping); err != nil {
-		return nil, fmt.Errorf("GetMappingById: failed to unmarshal mapping: %v", err)
+	ttl := pttl.Val()
+	if ttl < 0 {
+		ttl = 0
 	}
 
-	return &mapping, nil
+	return cacheObj.toDomain(), ttl, nil
 }
 
-func (r *RedisAdapter) DeleteMappingById(ctx context.Context, id uuid.UUID) error {
-	result := r.client.Del(ctx, fmt.Sprintf("mapping:id:%s", id))
+// GetMappingByToken resolves token through the token index. A dangling index entry
+// (the mapping itself is gone or belongs to another token) is removed and reported as
+// a cache miss. A token remembered by SaveMissingToken yields errs.ErrMappingNotFound.
+func (r *RedisAdapter) GetMappingByToken(ctx context.Context, token string) (*domain.Mapping, time.Duration, error) {
+	result := r.client.Get(ctx, tokenKey(token))
 	if err := result.Err(); err != nil {
 		if errors.Is(err, redis.Nil) {
-			return nil
+			return nil, 0, nil
 		}
+		return nil, 0, fmt.Errorf("GetMappingByToken: failed to get token index: %v", err)
+	}
+
+	if result.Val() == missingTokenMarker {
+		return nil, 0, errs.ErrMappingNotFound
+	}
+
+	id, err := uuid.Parse(result.Val())
+	if err != nil {
+		return nil, 0, r.deleteTokenIndex(ctx, token)
+	}
+
+	mapping, ttl, err := r.GetMappingById(ctx, id)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetMappingByToken: %v", err)
+	}
+	if mapping == nil || mapping.Token != token {
+		return nil, 0, r.deleteTokenIndex(ctx, token)
+	}
+
+	return mapping, ttl, nil
+}
+
+// SaveMissingToken remembers for ttl that token has no mapping. It never overwrites an
+// existing index entry, and a later SaveMapping for the token replaces it.
+func (r *RedisAdapter) SaveMissingToken(ctx context.Context, token string, ttl time.Duration) error {
+	if err := r.client.SetNX(ctx, tokenKey(token), missingTokenMarker, ttl).Err(); err != nil {
+		return fmt.Errorf("SaveMissingToken: failed to save token: %v", err)
+	}
+	return nil
+}
+
+// DeleteMappingById drops the cached mapping together with its token index entry. An
+// unreadable payload is still deleted; its index entry is then cleaned up lazily by
+// GetMappingByToken.
+func (r *RedisAdapter) DeleteMappingById(ctx context.Context, id uuid.UUID) error {
+	keys := []string{idKey(id)}
+
+	if mapping, _, _ := r.GetMappingById(ctx, id); mapping != nil && mapping.Token != "" {
+		keys = append(keys, tokenKey(mapping.Token))
+	}
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil && !errors.Is(err, redis.Nil) {
 		return fmt.Errorf("DeleteMappingById: failed to delete mapping: %v", err)
 	}
 	return nil
 }
+
+// DeleteMapping drops the cached mapping and its token index entry without reading the
+// cached payload, for invalidations where the token is already known.
+func (r *RedisAdapter) DeleteMapping(ctx context.Context, id uuid.UUID, token string) error {
+	if err := r.client.Del(ctx, idKey(id), tokenKey(token)).Err(); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("DeleteMapping: failed to delete mapping: %v", err)
+	}
+	return nil
+}
+
+// DeleteAllMappings drops every cached mapping and token index entry. Cache keys are
+// left in place.
+func (r *RedisAdapter) DeleteAllMappings(ctx context.Context) error {
+	for _, pattern := range []string{"mapping:id:*", "mapping:token:*"} {
+		iter := r.client.Scan(ctx, 0, pattern, 1000).Iterator()
+		var keys []string
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+			if len(keys) == 1000 {
+				if err := r.client.Unlink(ctx, keys...).Err(); err != nil {
+					return fmt.Errorf("DeleteAllMappings: failed to delete mappings: %v", err)
+				}
+				keys = keys[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("DeleteAllMappings: failed to scan mappings: %v", err)
+		}
+		if len(keys) > 0 {
+			if err := r.client.Unlink(ctx, keys...).Err(); err != nil {
+				return fmt.Errorf("DeleteAllMappings: failed to delete mappings: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *RedisAdapter) deleteTokenIndex(ctx context.Context, token string) error {
+	if err := r.client.Del(ctx, tokenKey(token)).Err(); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("failed to delete token index: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRedis answers the commands the cache sends from memory, so the adapters can be
+// tested without a Redis server. It is installed as a hook that never passes commands on
+// to the client. Entries do not expire; their TTL is only reported by PTTL.
+type fakeRedis struct {
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func newFakeRedis(t *testing.T) (*redis.Client, *fakeRedis) {
+	t.Helper()
+	f := &fakeRedis{values: make(map[string]string), ttls: make(map[string]time.Duration)}
+	client := redis.NewClient(&redis.Options{Addr: "fake:6379"})
+	client.AddHook(f)
+	t.Cleanup(func() { client.Close() })
+	return client, f
+}
+
+func (f *fakeRedis) DialHook(redis.DialHook) redis.DialHook {
+	return func(context.Context, string, string) (net.Conn, error) {
+		return nil, errors.New("fake redis does not dial")
+	}
+}
+
+func (f *fakeRedis) ProcessHook(redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		f.process(cmd)
+		return cmd.Err()
+	}
+}
+
+func (f *fakeRedis) ProcessPipelineHook(redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(_ context.Context, cmds []redis.Cmder) error {
+		var firstErr error
+		for _, cmd := range cmds {
+			f.process(cmd)
+			if err := cmd.Err(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+}
+
+func (f *fakeRedis) process(cmd redis.Cmder) {
+	args := make([]string, 0, len(cmd.Args()))
+	for _, arg := range cmd.Args() {
+		if b, ok := arg.([]byte); ok {
+			args = append(args, string(b))
+		} else {
+			args = append(args, fmt.Sprint(arg))
+		}
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "multi", "exec":
+	case "get":
+		value, ok := f.values[args[1]]
+		if !ok {
+			cmd.SetErr(redis.Nil)
+			return
+		}
+		cmd.(*redis.StringCmd).SetVal(value)
+	case "pttl":
+		if _, ok := f.values[args[1]]; !ok {
+			cmd.(*redis.DurationCmd).SetVal(-2)
+			return
+		}
+		cmd.(*redis.DurationCmd).SetVal(f.ttls[args[1]])
+	case "set", "setnx":
+		key, value := args[1], args[2]
+		var ttl time.Duration
+		nx := strings.ToLower(args[0]) == "setnx"
+		for i := 3; i < len(args); i++ {
+			switch strings.ToLower(args[i]) {
+			case "nx":
+				nx = true
+			case "px", "ex":
+				n, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(n) * time.Millisecond
+				if strings.ToLower(args[i]) == "ex" {
+					ttl = time.Duration(n) * time.Second
+				}
+				i++
+			}
+		}
+		if _, exists := f.values[key]; nx && exists {
+			if boolCmd, ok := cmd.(*redis.BoolCmd); ok {
+				boolCmd.SetVal(false)
+			}
+			return
+		}
+		f.values[key], f.ttls[key] = value, ttl
+		switch cmd := cmd.(type) {
+		case *redis.BoolCmd:
+			cmd.SetVal(true)
+		case *redis.StatusCmd:
+			cmd.SetVal("OK")
+		}
+	case "del", "unlink":
+		var n int64
+		for _, key := range args[1:] {
+			if _, ok := f.values[key]; ok {
+				delete(f.values, key)
+				n++
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(n)
+	case "scan":
+		var pattern string
+		for i := 2; i < len(args)-1; i++ {
+			if strings.ToLower(args[i]) == "match" {
+				pattern = args[i+1]
+			}
+		}
+		var keys []string
+		for key := range f.values {
+			if matched, _ := path.Match(pattern, key); matched {
+				keys = append(keys, key)
+			}
+		}
+		cmd.(*redis.ScanCmd).SetVal(keys, 0)
+	default:
+		cmd.SetErr(fmt.Errorf("fake redis: unsupported command %q", args[0]))
+	}
+}
+
+// fakeVault wraps data keys by prefixing them, so a wrapped key cannot be used as is.
+type fakeVault struct {
+	generated int
+}
+
+func (v *fakeVault) GenerateDEK(_ context.Context, bits int, _ string) ([]byte, []byte, error) {
+	v.generated++
+	plaintext := make([]byte, bits/8)
+	for i := range plaintext {
+		plaintext[i] = byte(v.generated + i)
+	}
+	return append([]byte("wrapped:"), plaintext...), plaintext, nil
+}
+
+func (v *fakeVault) UnwrapDEK(_ context.Context, wrapped []byte, _ string) ([]byte, error) {
+	plaintext, ok := strings.CutPrefix(string(wrapped), "wrapped:")
+	if !ok {
+		return nil, errors.New("not a wrapped key")
+	}
+	return []byte(plaintext), nil
+}
+
+// newAdapter returns an adapter over a fake Redis with a loaded cache key.
+func newAdapter(t *testing.T) (*RedisAdapter, *fakeRedis) {
+	t.Helper()
+	client, f := newFakeRedis(t)
+	keyring := NewKeyring(client, &fakeVault{}, "cache", 0)
+	if err := keyring.Rotate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return NewRedisAdapter(client, keyring), f
+}
+
+func newMapping(token string) *domain.Mapping {
+	return &domain.Mapping{
+		ID:         uuid.New(),
+		Token:      token,
+		DekWrapped: []byte("dek"),
+		CipherText: []byte("cipher"),
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		AlgoName:   "aes-gcm",
+	}
+}
+
+func TestRedisAdapter_GetMappingByToken(t *testing.T) {
+	ctx := context.Background()
+	r, f := newAdapter(t)
+
+	mapping := newMapping("fio_1")
+	if err := r.SaveMapping(ctx, mapping, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if f.values[tokenKey("fio_1")] != mapping.ID.String() {
+		t.Fatalf("token index holds %q, want the mapping id", f.values[tokenKey("fio_1")])
+	}
+
+	got, ttl, err := r.GetMappingByToken(ctx, "fio_1")
+	if err != nil || got == nil {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	if got.ID != mapping.ID || string(got.CipherText) != "cipher" || ttl != time.Minute {
+		t.Errorf("got %+v with ttl %v", got, ttl)
+	}
+
+	if got, _, err = r.GetMappingByToken(ctx, "fio_2"); got != nil || err != nil {
+		t.Errorf("unknown token: got %v, %v, want a miss", got, err)
+	}
+}
+
+func TestRedisAdapter_DanglingTokenIndex(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		dangle func(r *RedisAdapter, f *fakeRedis, mapping *domain.Mapping) error
+	}{
+		{"mapping evicted", func(_ *RedisAdapter, f *fakeRedis, mapping *domain.Mapping) error {
+			delete(f.values, idKey(mapping.ID))
+			return nil
+		}},
+		{"mapping of another token", func(r *RedisAdapter, _ *fakeRedis, mapping *domain.Mapping) error {
+			mapping.Token = "fio_1"
+			return r.SaveMapping(ctx, mapping, 0)
+		}},
+		{"malformed id", func(_ *RedisAdapter, f *fakeRedis, _ *domain.Mapping) error {
+			f.values[tokenKey("fio_2")] = "not-an-id"
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, f := newAdapter(t)
+			mapping := newMapping("fio_2")
+			if err := r.SaveMapping(ctx, mapping, 0); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.dangle(r, f, mapping); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, _, err := r.GetMappingByToken(ctx, "fio_2"); got != nil || err != nil {
+				t.Fatalf("got %v, %v, want a miss", got, err)
+			}
+			if _, ok := f.values[tokenKey("fio_2")]; ok {
+				t.Error("dangling token index entry not removed")
+			}
+		})
+	}
+}
+
+func TestRedisAdapter_MissingToken(t *testing.T) {
+	ctx := context.Background()
+	r, f := newAdapter(t)
+
+	if err := r.SaveMissingToken(ctx, "fio_1", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if f.values[tokenKey("fio_1")] != missingTokenMarker {
+		t.Fatalf("token index holds %q, want the missing token marker", f.values[tokenKey("fio_1")])
+	}
+	if _, _, err := r.GetMappingByToken(ctx, "fio_1"); !errors.Is(err, errs.ErrMappingNotFound) {
+		t.Fatalf("got %v, want ErrMappingNotFound", err)
+	}
+
+	// A mapping created for the token replaces the marker.
+	mapping := newMapping("fio_1")
+	if err := r.SaveMapping(ctx, mapping, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got, _, err := r.GetMappingByToken(ctx, "fio_1"); err != nil || got == nil || got.ID != mapping.ID {
+		t.Fatalf("after SaveMapping: got %v, %v", got, err)
+	}
+
+	// The marker never overwrites the index entry of a cached mapping.
+	if err := r.SaveMissingToken(ctx, "fio_1", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got, _, err := r.GetMappingByToken(ctx, "fio_1"); err != nil || got == nil {
+		t.Fatalf("after SaveMissingToken: got %v, %v", got, err)
+	}
+}
+
+func TestRedisAdapter_DeleteMappingById(t *testing.T) {
+	ctx := context.Background()
+	r, f := newAdapter(t)
+
+	mapping := newMapping("fio_1")
+	other := newMapping("fio_2")
+	for _, m := range []*domain.Mapping{mapping, other} {
+		if err := r.SaveMapping(ctx, m, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := r.DeleteMappingById(ctx, mapping.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{idKey(mapping.ID), tokenKey("fio_1")} {
+		if _, ok := f.values[key]; ok {
+			t.Errorf("%s left in the cache", key)
+		}
+	}
+
+	if err := r.DeleteAllMappings(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for key := range f.values {
+		if !strings.HasPrefix(key, "mapping:cache-key:") {
+			t.Errorf("%s left in the cache", key)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/segmentio/kafka-go"
+	"strconv"
+)
+
+// KafkaPublisher publishes outbox events to a Kafka topic keyed by their partition key.
+// The event type and schema version are also sent as headers, so consumers can route
+// events without decoding them.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(writer *kafka.Writer) *KafkaPublisher {
+	return &KafkaPublisher{writer: writer}
+}
+
+func (k *KafkaPublisher) Name() string {
+	return "kafka"
+}
+
+// Publish writes the events in one synchronous batch and returns once all of them are
+// acknowledged; a failed batch is retried as a whole.
+func (k *KafkaPublisher) Publish(ctx context.Context, events []*domain.OutboxEvent) (int, error) {
+	messages := make([]kafka.Message, 0, len(events))
+	for _, event := range events {
+		value, err := event.Envelope()
+		if err != nil {
+			return 0, fmt.Errorf("Publish: failed to encode event %d: %w", event.ID, err)
+		}
+		messages = append(messages, kafka.Message{
+			Key:   []byte(event.PartitionKey),
+			Value: value,
+			Headers: []kafka.Header{
+				{Key: "event_id", Value: []byte(event.EventID.String())},
+				{Key: "event_type", Value: []byte(event.EventType)},
+				{Key: "schema_version", Value: []byte(strconv.Itoa(int(event.SchemaVersion)))},
+			},
+			Time: event.CreatedAt,
+		})
+	}
+
+	if err := k.writer.WriteMessages(ctx, messages...); err != nil {
+		return 0, fmt.Errorf("Publish: failed to write messages: %w", err)
+	}
+	return len(events), nil
+}
+
+func (k *KafkaPublisher) Close() error {
+	return k.writer.Close()
+}
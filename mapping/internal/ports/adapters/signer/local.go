@@ -0,0 +1,89 @@
+package signer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const localSignaturePrefix = "local:ed25519:"
+
+// LocalSigner signs data with an ed25519 key held by the service, for deployments that
+// do not sign with Vault. Signatures have the "local:ed25519:<base64>" format.
+type LocalSigner struct {
+	key ed25519.PrivateKey
+	LocalVerifier
+}
+
+// NewLocalSigner creates a signer from a base64-encoded 32-byte ed25519 seed. The key
+// name is derived from the public key.
+func NewLocalSigner(seed string) (*LocalSigner, error) {
+	raw, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("NewLocalSigner: failed to decode seed: %w", err)
+	}
+	if len(raw) != ed25519.SeedSize {
+		return nil, fmt.Errorf("NewLocalSigner: seed must be %d bytes, got %d", ed25519.SeedSize, len(raw))
+	}
+
+	key := ed25519.NewKeyFromSeed(raw)
+	return &LocalSigner{
+		key:           key,
+		LocalVerifier: newLocalVerifier(key.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+func (l *LocalSigner) Sign(_ context.Context, data []byte) (string, error) {
+	return localSignaturePrefix + base64.StdEncoding.EncodeToString(ed25519.Sign(l.key, data)), nil
+}
+
+// LocalVerifier checks the signatures of a LocalSigner by its public key, so that what a
+// retired key signed can still be verified.
+type LocalVerifier struct {
+	publicKey ed25519.PublicKey
+	keyName   string
+}
+
+// NewLocalVerifier creates a verifier from a base64-encoded ed25519 public key. Its key
+// name is the one of the signer holding the private key.
+func NewLocalVerifier(publicKey string) (*LocalVerifier, error) {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("NewLocalVerifier: failed to decode public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("NewLocalVerifier: public key must be %d bytes, got %d", ed25519.PublicKeySize, len(raw))
+	}
+
+	verifier := newLocalVerifier(raw)
+	return &verifier, nil
+}
+
+func newLocalVerifier(publicKey ed25519.PublicKey) LocalVerifier {
+	fingerprint := sha256.Sum256(publicKey)
+	return LocalVerifier{
+		publicKey: publicKey,
+		keyName:   "local-" + hex.EncodeToString(fingerprint[:8]),
+	}
+}
+
+func (l *LocalVerifier) KeyName() string {
+	return l.keyName
+}
+
+func (l *LocalVerifier) Verify(_ context.Context, data []byte, signature string) (bool, error) {
+	encoded, ok := strings.CutPrefix(signature, localSignaturePrefix)
+	if !ok {
+		return false, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false, nil
+	}
+
+	return ed25519.Verify(l.publicKey, data, raw), nil
+}
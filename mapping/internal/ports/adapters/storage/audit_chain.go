@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func (p *PostgresAdapter) GetAuditChainHead(ctx context.Context) (*domain.AuditChainHead, error) {
+	var head domain.AuditChainHead
+	err := p.pool.QueryRow(ctx, "SELECT seq, row_hash FROM mapping.audit_chain_head WHERE id").
+		Scan(&head.Seq, &head.RowHash)
+	if err != nil {
+		return nil, fmt.Errorf("GetAuditChainHead: failed to scan head: %v", err)
+	}
+	return &head, nil
+}
+
+// WalkAuditChain streams the audit log in chain order to fn with the raw kind id, until
+// fn returns false.
+func (p *PostgresAdapter) WalkAuditChain(ctx context.Context, fn func(entry *domain.AuditLogEntry) bool) error {
+	sql, args, err := sq.
+		Select(
+			"seq",
+			"id",
+			"user_id",
+			"action",
+			"token",
+			"kind_id",
+			"purpose",
+			"legal_hold",
+			"outcome",
+			"COALESCE(reason, '')",
+			"COALESCE(client_ip, '')",
+			"COALESCE(user_agent, '')",
+			"COALESCE(request_id, '')",
+			"COALESCE(justification, '')",
+			"created_at",
+			"prev_hash",
+			"row_hash",
+			"chain_version",
+		).
+		From("mapping.audit_log").
+		OrderBy("seq").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("WalkAuditChain: failed to build sql: %v", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("WalkAuditChain: failed to execute sql: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry domain.AuditLogEntry
+		var (
+			kindID    *int32
+			purpose   *string
+			legalHold *string
+		)
+
+		err = rows.Scan(
+			&entry.Seq,
+			&entry.ID,
+			&entry.UserID,
+			&entry.Action,
+			&entry.Token,
+			&kindID,
+			&purpose,
+			&legalHold,
+			&entry.Outcome,
+			&entry.Reason,
+			&entry.ClientIP,
+			&entry.UserAgent,
+			&entry.RequestID,
+			&entry.Justification,
+			&entry.CreatedAt,
+			&entry.PrevHash,
+			&entry.RowHash,
+			&entry.ChainVersion,
+		)
+		if err != nil {
+			return fmt.Errorf("WalkAuditChain: failed to scan entry: %v", err)
+		}
+
+		if kindID != nil {
+			entry.Kind = &domain.Kind{Id: *kindID}
+		}
+		if purpose != nil {
+			entry.Purpose = *purpose
+		}
+		if legalHold != nil {
+			entry.LegalHold = *legalHold
+		}
+
+		if !fn(&entry) {
+			return nil
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("WalkAuditChain: rows iteration error: %v", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresAdapter) baseSelectAuditCheckpointReq() sq.SelectBuilder {
+	return sq.
+		Select("id", "seq", "row_hash", "signature", "signing_key", "created_at").
+		From("mapping.audit_checkpoints").
+		PlaceholderFormat(sq.Dollar)
+}
+
+func scanAuditCheckpoint(row pgx.Row) (*domain.AuditCheckpoint, error) {
+	var checkpoint domain.AuditCheckpoint
+	err := row.Scan(
+		&checkpoint.ID,
+		&checkpoint.Seq,
+		&checkpoint.RowHash,
+		&checkpoint.Signature,
+		&checkpoint.SigningKey,
+		&checkpoint.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// GetLastAuditCheckpoint returns the checkpoint with the highest sequence number, or nil
+// if there are none.
+func (p *PostgresAdapter) GetLastAuditCheckpoint(ctx context.Context) (*domain.AuditCheckpoint, error) {
+	sql, args, err := p.baseSelectAuditCheckpointReq().
+		OrderBy("seq DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("GetLastAuditCheckpoint: failed to build sql: %v", err)
+	}
+
+	checkpoint, err := scanAuditCheckpoint(p.pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("GetLastAuditCheckpoint: failed to scan checkpoint: %v", err)
+	}
+
+	return checkpoint, nil
+}
+
+func (p *PostgresAdapter) SelectAuditCheckpoints(ctx context.Context) ([]*domain.AuditCheckpoint, error) {
+	sql, args, err := p.baseSelectAuditCheckpointReq().
+		OrderBy("seq").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("SelectAuditCheckpoints: failed to build sql: %v", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("SelectAuditCheckpoints: failed to execute sql: %v", err)
+	}
+	defer rows.Close()
+
+	var checkpoints []*domain.AuditCheckpoint
+	for rows.Next() {
+		checkpoint, err := scanAuditCheckpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("SelectAuditCheckpoints: failed to scan checkpoint: %v", err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SelectAuditCheckpoints: rows iteration error: %v", err)
+	}
+
+	return checkpoints, nil
+}
+
+func (p *PostgresAdapter) CreateAuditCheckpoint(ctx context.Context, checkpoint *domain.AuditCheckpoint) (*domain.AuditCheckpoint, error) {
+	sql, args, err := sq.
+		Insert("mapping.audit_checkpoints").
+		Columns("seq", "row_hash", "signature", "signing_key").
+		Values(checkpoint.Seq, checkpoint.RowHash, checkpoint.Signature, checkpoint.SigningKey).
+		Suffix("ON CONFLICT (seq) DO NOTHING RETURNING id, created_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CreateAuditCheckpoint: failed to build sql: %v", err)
+	}
+
+	err = p.pool.QueryRow(ctx, sql, args...).Scan(&checkpoint.ID, &checkpoint.CreatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("CreateAuditCheckpoint: failed to scan id: %v", err)
+	}
+
+	return checkpoint, nil
+}
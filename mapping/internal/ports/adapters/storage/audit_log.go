@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// auditLogSortColumns maps the sort keys of domain.AuditLogQuery to columns and the
+// types their cursor values are cast to. Sorting by time uses the chain sequence alone.
+var auditLogSortColumns = map[string][2]string{
+	domain.AuditLogSortUserID: {"a.user_id", "uuid"},
+	domain.AuditLogSortAction: {"a.action", "text"},
+	domain.AuditLogSortToken:  {"a.token", "text"},
+}
+
+func applyAuditLogFilter(query sq.SelectBuilder, filter *domain.AuditLogFilter) sq.SelectBuilder {
+	if filter.UserID != uuid.Nil {
+		query = query.Where(sq.Eq{"a.user_id": filter.UserID})
+	}
+	if filter.Action != "" {
+		query = query.Where(sq.Eq{"a.action": filter.Action})
+	}
+	if filter.Token != "" {
+		query = query.Where(sq.Eq{"a.token": filter.Token})
+	}
+	if filter.Outcome != "" {
+		query = query.Where(sq.Eq{"a.outcome": filter.Outcome})
+	}
+	if filter.RequestID != "" {
+		query = query.Where(sq.Eq{"a.request_id": filter.RequestID})
+	}
+	if filter.BreakGlass {
+		query = query.Where(sq.NotEq{"a.justification": nil})
+	}
+	if filter.KindID != 0 {
+		query = query.Where(sq.Eq{"a.kind_id": filter.KindID})
+	}
+	if filter.AccessLevel != 0 {
+		query = query.Where(sq.Eq{"k.access_level": filter.AccessLevel})
+	}
+	if !filter.From.IsZero() {
+		query = query.Where(sq.GtOrEq{"a.created_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		query = query.Where(sq.Lt{"a.created_at": filter.To})
+	}
+	return query
+}
+
+// scanAuditLogEntry scans a row produced by baseSelectAuditLogReq.
+func scanAuditLogEntry(row pgx.Row) (*domain.AuditLogEntry, error) {
+	var entry domain.AuditLogEntry
+	var (
+		purpose     *string
+		legalHold   *string
+		kindID      *int32
+		kindName    *string
+		accessLevel *int32
+		russianName *string
+		shortName   *string
+	)
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.Action,
+		&entry.Token,
+		&entry.CreatedAt,
+		&purpose,
+		&legalHold,
+		&entry.Seq,
+		&entry.PrevHash,
+		&entry.RowHash,
+		&entry.ChainVersion,
+		&entry.Outcome,
+		&entry.Reason,
+		&entry.ClientIP,
+		&entry.UserAgent,
+		&entry.RequestID,
+		&entry.Justification,
+		&kindID,
+		&kindName,
+		&accessLevel,
+		&russianName,
+		&shortName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if purpose != nil {
+		entry.Purpose = *purpose
+	}
+	if legalHold != nil {
+		entry.LegalHold = *legalHold
+	}
+	if kindID != nil {
+		entry.Kind = &domain.Kind{
+			Id:          *kindID,
+			Name:        *kindName,
+			AccessLevel: *accessLevel,
+			RussianName: *russianName,
+			ShortName:   *shortName,
+		}
+	}
+
+	return &entry, nil
+}
+
+// withAuditLogPage limits builder to the page of entries matching query using keyset
+// pagination on the sort column and the chain sequence number.
+func withAuditLogPage(builder sq.SelectBuilder, query *domain.AuditLogQuery) sq.SelectBuilder {
+	direction, op := "ASC", ">"
+	if query.Descending {
+		direction, op = "DESC", "<"
+	}
+
+	builder = applyAuditLogFilter(builder, &query.Filter)
+	if column, ok := auditLogSortColumns[query.SortBy]; ok {
+		builder = builder.OrderBy(column[0]+" "+direction, "a.seq "+direction)
+		if query.After != nil {
+			builder = builder.Where(
+				sq.Expr(fmt.Sprintf("(%s, a.seq) %s (?::%s, ?)", column[0], op, column[1]), query.After.Value, query.After.Seq))
+		}
+	} else {
+		builder = builder.OrderBy("a.seq " + direction)
+		if query.After != nil {
+			builder = builder.Where(sq.Expr("a.seq "+op+" ?", query.After.Seq))
+		}
+	}
+	return builder.Limit(uint64(query.Limit))
+}
+
+// GetAuditLogList returns one page of the entries matching query.
+func (p *PostgresAdapter) GetAuditLogList(ctx context.Context, query *domain.AuditLogQuery) ([]*domain.AuditLogEntry, error) {
+	sql, args, err := withAuditLogPage(p.baseSelectAuditLogReq(), query).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("GetAuditLogList: failed to build sql: %v", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GetAuditLogList: failed to execute sql: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.AuditLogEntry
+	for rows.Next() {
+		entry, err := scanAuditLogEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("GetAuditLogList: failed to scan entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetAuditLogList: rows iteration error: %v", err)
+	}
+
+	return entries, nil
+}
+
+// WalkAuditLog streams the entries matching filter in chain order to fn, stopping at the
+// first error fn returns.
+func (p *PostgresAdapter) WalkAuditLog(
+	ctx context.Context,
+	filter *domain.AuditLogFilter,
+	fn func(entry *domain.AuditLogEntry) error,
+) error {
+	sql, args, err := applyAuditLogFilter(p.baseSelectAuditLogReq(), filter).
+		OrderBy("a.seq").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("WalkAuditLog: failed to build sql: %v", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("WalkAuditLog: failed to execute sql: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanAuditLogEntry(rows)
+		if err != nil {
+			return fmt.Errorf("WalkAuditLog: failed to scan entry: %v", err)
+		}
+		if err = fn(entry); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("WalkAuditLog: rows iteration error: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/google/uuid"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWithAuditLogPage(t *testing.T) {
+	userID := uuid.MustParse("7d3c8a4e-1f2b-4c5d-9e6f-0a1b2c3d4e5f")
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name     string
+		query    domain.AuditLogQuery
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"first page by time", domain.AuditLogQuery{Limit: 51},
+			"SELECT a.seq FROM mapping.audit_log a ORDER BY a.seq ASC LIMIT 51", nil},
+		{"next page by time, newest first", domain.AuditLogQuery{
+			Descending: true, Limit: 51, After: &domain.AuditLogCursor{Seq: 10},
+		}, "SELECT a.seq FROM mapping.audit_log a WHERE a.seq < ? ORDER BY a.seq DESC LIMIT 51", []any{int64(10)}},
+		// Entries with the same action are ordered by sequence number, so the page
+		// continues right after the last entry even if the action repeats.
+		{"next page by action", domain.AuditLogQuery{
+			SortBy: domain.AuditLogSortAction, Limit: 51, After: &domain.AuditLogCursor{Value: "detokenize", Seq: 10},
+		}, "SELECT a.seq FROM mapping.audit_log a WHERE (a.action, a.seq) > (?::text, ?) " +
+			"ORDER BY a.action ASC, a.seq ASC LIMIT 51", []any{"detokenize", int64(10)}},
+		{"next page by user, newest first", domain.AuditLogQuery{
+			SortBy: domain.AuditLogSortUserID, Descending: true, Limit: 2,
+			After: &domain.AuditLogCursor{Value: userID.String(), Seq: 3},
+		}, "SELECT a.seq FROM mapping.audit_log a WHERE (a.user_id, a.seq) < (?::uuid, ?) " +
+			"ORDER BY a.user_id DESC, a.seq DESC LIMIT 2", []any{userID.String(), int64(3)}},
+		{"filtered page", domain.AuditLogQuery{
+			Filter: domain.AuditLogFilter{UserID: userID, Outcome: domain.AuditOutcomeDenied, From: from, To: to},
+			Limit:  51, After: &domain.AuditLogCursor{Seq: 10},
+		}, "SELECT a.seq FROM mapping.audit_log a WHERE a.user_id = ? AND a.outcome = ? AND a.created_at >= ? " +
+			"AND a.created_at < ? AND a.seq > ? ORDER BY a.seq ASC LIMIT 51",
+			[]any{userID.String(), domain.AuditOutcomeDenied, from, to, int64(10)}},
+		// Only the known sort keys reach the query.
+		{"unknown sort key", domain.AuditLogQuery{SortBy: "a.seq; DROP TABLE mapping.audit_log", Limit: 51},
+			"SELECT a.seq FROM mapping.audit_log a ORDER BY a.seq ASC LIMIT 51", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := withAuditLogPage(sq.Select("a.seq").From("mapping.audit_log a"), &tt.query)
+			sql, args, err := query.ToSql()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != tt.wantSQL || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("got %q %v, want %q %v", sql, args, tt.wantSQL, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"time"
+)
+
+// SelectDestructionEvents returns the destruction events in [from, to), ordered by kind
+// and then by time.
+func (p *PostgresAdapter) SelectDestructionEvents(ctx context.Context, from, to time.Time) ([]*domain.DestructionEvent, error) {
+	sql, args, err := sq.
+		Select(
+			"id",
+			"mapping_id",
+			"token",
+			"kind_id",
+			"kind_name",
+			"kind_russian_name",
+			"reason",
+			"initiator",
+			"destroyed_at",
+		).
+		From("mapping.destruction_events").
+		Where(sq.GtOrEq{"destroyed_at": from}).
+		Where(sq.Lt{"destroyed_at": to}).
+		OrderBy("kind_id NULLS FIRST", "destroyed_at", "id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("SelectDestructionEvents: failed to build sql: %v", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("SelectDestructionEvents: failed to execute sql: %v", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.DestructionEvent
+	for rows.Next() {
+		var event domain.DestructionEvent
+		var (
+			kindID          *int32
+			kindName        *string
+			kindRussianName *string
+		)
+
+		err = rows.Scan(
+			&event.ID,
+			&event.MappingID,
+			&event.Token,
+			&kindID,
+			&kindName,
+			&kindRussianName,
+			&event.Reason,
+			&event.Initiator,
+			&event.DestroyedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("SelectDestructionEvents: failed to scan event: %v", err)
+		}
+
+		if kindID != nil {
+			event.KindID = *kindID
+		}
+		if kindName != nil {
+			event.KindName = *kindName
+		}
+		if kindRussianName != nil {
+			event.KindRussianName = *kindRussianName
+		}
+
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SelectDestructionEvents: rows iteration error: %v", err)
+	}
+
+	return events, nil
+}
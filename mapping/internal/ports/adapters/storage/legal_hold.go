@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+func (p *PostgresAdapter) baseSelectLegalHoldReq() sq.SelectBuilder {
+	return sq.
+		Select(
+			"h.id",
+			"h.name",
+			"h.reason",
+			"h.owner",
+			"h.created_by",
+			"h.created_at",
+			"h.expires_at",
+			"h.released_at",
+			"h.released_by",
+			`ARRAY(SELECT t.target_type FROM mapping.legal_hold_targets t
+				WHERE t.hold_id = h.id ORDER BY t.target_type, t.target_value) AS target_types`,
+			`ARRAY(SELECT t.target_value FROM mapping.legal_hold_targets t
+				WHERE t.hold_id = h.id ORDER BY t.target_type, t.target_value) AS target_values`,
+		).
+		From("mapping.legal_holds h").
+		PlaceholderFormat(sq.Dollar)
+}
+
+// scanLegalHold scans a row produced by baseSelectLegalHoldReq.
+func scanLegalHold(row pgx.Row) (*domain.LegalHold, error) {
+	var hold domain.LegalHold
+	var (
+		expiresAt    *time.Time
+		releasedAt   *time.Time
+		releasedBy   *uuid.UUID
+		targetTypes  []string
+		targetValues []string
+	)
+
+	err := row.Scan(
+		&hold.ID,
+		&hold.Name,
+		&hold.Reason,
+		&hold.Owner,
+		&hold.CreatedBy,
+		&hold.CreatedAt,
+		&expiresAt,
+		&releasedAt,
+		&releasedBy,
+		&targetTypes,
+		&targetValues,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt != nil {
+		hold.ExpiresAt = *expiresAt
+	}
+	if releasedAt != nil {
+		hold.ReleasedAt = *releasedAt
+	}
+	if releasedBy != nil {
+		hold.ReleasedBy = *releasedBy
+	}
+	for i := range targetTypes {
+		hold.Targets = append(hold.Targets, domain.LegalHoldTarget{Type: targetTypes[i], Value: targetValues[i]})
+	}
+
+	return &hold, nil
+}
+
+// CreateLegalHold stores the hold with its targets and the audit entry of its creation
+// in one transaction.
+func (p *PostgresAdapter) CreateLegalHold(ctx context.Context, hold *domain.LegalHold) (*domain.LegalHold, error) {
+	var expiresAt *time.Time
+	if !hold.ExpiresAt.IsZero() {
+		expiresAt = &hold.ExpiresAt
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("CreateLegalHold: failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := sq.
+		Insert("mapping.legal_holds").
+		Columns("name", "reason", "owner", "created_by", "expires_at").
+		Values(hold.Name, hold.Reason, hold.Owner, hold.CreatedBy, expiresAt).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CreateLegalHold: failed to build sql: %v", err)
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&hold.ID, &hold.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return nil, errs.ErrLegalHoldExists
+			}
+		}
+		return nil, fmt.Errorf("CreateLegalHold: failed to scan id: %v", err)
+	}
+
+	targets := sq.
+		Insert("mapping.legal_hold_targets").
+		Columns("hold_id", "target_type", "target_value").
+		Suffix("ON CONFLICT DO NOTHING").
+		PlaceholderFormat(sq.Dollar)
+	for _, target := range hold.Targets {
+		targets = targets.Values(hold.ID, target.Type, target.Value)
+	}
+	sql, args, err = targets.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CreateLegalHold: failed to build sql: %v", err)
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return nil, fmt.Errorf("CreateLegalHold: failed to insert targets: %v", err)
+	}
+
+	if err = insertLegalHoldAudit(ctx, tx, hold.CreatedBy, "hold_create", hold.Name); err != nil {
+		return nil, fmt.Errorf("CreateLegalHold: %v", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("CreateLegalHold: failed to commit transaction: %v", err)
+	}
+
+	return p.GetLegalHold(ctx, hold.ID)
+}
+
+func (p *PostgresAdapter) GetLegalHold(ctx context.Context, id uuid.UUID) (*domain.LegalHold, error) {
+	sql, args, err := p.baseSelectLegalHoldReq().
+		Where(sq.Eq{"h.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("GetLegalHold: failed to build sql: %v", err)
+	}
+
+	hold, err := scanLegalHold(p.pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrLegalHoldNotFound
+		}
+		return nil, fmt.Errorf("GetLegalHold: failed to scan hold: %v", err)
+	}
+
+	return hold, nil
+}
+
+// GetLegalHolds returns holds newest first; activeOnly skips released and expired ones.
+func (p *PostgresAdapter) GetLegalHolds(ctx context.Context, activeOnly bool) ([]*domain.LegalHold, error) {
+	query := p.baseSelectLegalHoldReq().OrderBy("h.created_at DESC")
+	if activeOnly {
+		query = query.
+			Where("h.released_at IS NULL").
+			Where("(h.expires_at IS NULL OR h.expires_at > now())")
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("GetLegalHolds: failed to build sql: %v", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GetLegalHolds: failed to execute sql: %v", err)
+	}
+	defer rows.Close()
+
+	var holds []*domain.LegalHold
+	for rows.Next() {
+		hold, err := scanLegalHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("GetLegalHolds: failed to scan hold: %v", err)
+		}
+		holds = append(holds, hold)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetLegalHolds: rows iteration error: %v", err)
+	}
+
+	return holds, nil
+}
+
+// ReleaseLegalHold marks the hold released and audits it in one transaction.
+func (p *PostgresAdapter) ReleaseLegalHold(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.LegalHold, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ReleaseLegalHold: failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := sq.
+		Update("mapping.legal_holds").
+		Set("released_at", sq.Expr("now()")).
+		Set("released_by", userID).
+		Where(sq.Eq{"id": id}).
+		Where("released_at IS NULL").
+		Suffix("RETURNING name").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ReleaseLegalHold: failed to build sql: %v", err)
+	}
+
+	var name string
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&name); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, err = p.GetLegalHold(ctx, id); err != nil {
+				return nil, err
+			}
+			return nil, errs.ErrLegalHoldReleased
+		}
+		return nil, fmt.Errorf("ReleaseLegalHold: failed to scan name: %v", err)
+	}
+
+	if err = insertLegalHoldAudit(ctx, tx, userID, "hold_release", name); err != nil {
+		return nil, fmt.Errorf("ReleaseLegalHold: %v", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("ReleaseLegalHold: failed to commit transaction: %v", err)
+	}
+
+	return p.GetLegalHold(ctx, id)
+}
+
+func insertLegalHoldAudit(ctx context.Context, tx pgx.Tx, userID uuid.UUID, action, holdName string) error {
+	sql, args, err := sq.
+		Insert("mapping.audit_log").
+		Columns("user_id", "action", "token", "legal_hold").
+		Values(userID, action, "", holdName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build audit sql: %v", err)
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("failed to insert audit entry: %v", err)
+	}
+	return nil
+}
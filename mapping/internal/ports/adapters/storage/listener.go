@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"time"
+)
+
+// InvalidationChannel is the NOTIFY channel used by the cache invalidation triggers.
+const InvalidationChannel = "mapping_cache_invalidation"
+
+type PostgresListener struct {
+	pool       *pgxpool.Pool
+	retryDelay time.Duration
+}
+
+func NewPostgresListener(pool *pgxpool.Pool, retryDelay time.Duration) *PostgresListener {
+	return &PostgresListener{pool: pool, retryDelay: retryDelay}
+}
+
+// Listen delivers cache invalidation notifications to handle until ctx is done. The
+// listening connection is re-established after failures; since notifications sent
+// while disconnected are lost, resync is called after every reconnect.
+func (l *PostgresListener) Listen(
+	ctx context.Context,
+	handle func(ctx context.Context, inv *domain.CacheInvalidation),
+	resync func(ctx context.Context),
+) {
+	connected := false
+	for {
+		err := l.listen(ctx, handle, func() {
+			if connected {
+				logger.GetLoggerFromCtx(ctx).Info(ctx, "invalidation listener reconnected, resyncing cache")
+				resync(ctx)
+			}
+			connected = true
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "invalidation listener disconnected",
+			slog.String("retry in", l.retryDelay.String()),
+			logger.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.retryDelay):
+		}
+	}
+}
+
+func (l *PostgresListener) listen(
+	ctx context.Context,
+	handle func(ctx context.Context, inv *domain.CacheInvalidation),
+	onConnect func(),
+) error {
+	poolConn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("listen: failed to acquire connection: %v", err)
+	}
+	// The connection stays in LISTEN mode, so it must never go back to the pool.
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{InvalidationChannel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: failed to listen: %v", err)
+	}
+	onConnect()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("listen: failed to wait for notification: %v", err)
+		}
+
+		var inv domain.CacheInvalidation
+		if err = json.Unmarshal([]byte(notification.Payload), &inv); err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to decode invalidation payload", logger.Err(err))
+			continue
+		}
+		handle(ctx, &inv)
+	}
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"time"
+)
+
+// RelayOutboxEvents passes up to limit events after the cursor of publisher in id order
+// to publish and moves the cursor past the events it accepted, all in one transaction.
+// publish returns the number of leading events accepted; with an error the cursor still
+// moves past them. A transaction-level advisory lock per publisher keeps concurrent
+// relays from publishing out of order; while another relay holds it, RelayOutboxEvents
+// returns 0 without calling publish. If the commit fails after publish, the events are
+// published again by the next call. A publisher without a cursor starts after the last
+// published event.
+func (p *PostgresAdapter) RelayOutboxEvents(
+	ctx context.Context,
+	publisher string,
+	limit uint64,
+	publish func(events []*domain.OutboxEvent) (int, error),
+) (int, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	err = tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock(hashtext('mapping.event_outbox:' || $1))", publisher).Scan(&locked)
+	if err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to acquire lock: %v", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	var lastID int64
+	err = tx.QueryRow(ctx, `INSERT INTO mapping.event_outbox_cursors (publisher, last_id)
+		SELECT $1, COALESCE(MAX(id), 0) FROM mapping.event_outbox WHERE published_at IS NOT NULL
+		ON CONFLICT (publisher) DO UPDATE SET publisher = EXCLUDED.publisher
+		RETURNING last_id`, publisher).Scan(&lastID)
+	if err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to get cursor: %v", err)
+	}
+
+	sql, args, err := sq.
+		Select("id", "event_id", "event_type", "schema_version", "partition_key", "payload", "created_at").
+		From("mapping.event_outbox").
+		Where(sq.Gt{"id": lastID}).
+		OrderBy("id").
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to build sql: %v", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to execute sql: %v", err)
+	}
+	var events []*domain.OutboxEvent
+	for rows.Next() {
+		var event domain.OutboxEvent
+		err = rows.Scan(
+			&event.ID,
+			&event.EventID,
+			&event.EventType,
+			&event.SchemaVersion,
+			&event.PartitionKey,
+			&event.Payload,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("RelayOutboxEvents: failed to scan event: %v", err)
+		}
+		events = append(events, &event)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: rows iteration error: %v", err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	accepted, publishErr := publish(events)
+	if accepted <= 0 {
+		return 0, publishErr
+	}
+	accepted = min(accepted, len(events))
+
+	sql, args, err = sq.
+		Update("mapping.event_outbox_cursors").
+		Set("last_id", events[accepted-1].ID).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"publisher": publisher}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to build sql: %v", err)
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to move cursor: %v", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("RelayOutboxEvents: failed to commit transaction: %v", err)
+	}
+
+	return accepted, publishErr
+}
+
+// MarkOutboxEventsPublished marks published the events that the cursors of all
+// publishers passed. Nothing is marked while one of them has no cursor yet.
+func (p *PostgresAdapter) MarkOutboxEventsPublished(ctx context.Context, publishers []string) (int64, error) {
+	sql, args, err := sq.
+		Update("mapping.event_outbox").
+		Set("published_at", sq.Expr("now()")).
+		Where("published_at IS NULL").
+		Where(sq.Expr(`id <= (SELECT MIN(last_id) FROM mapping.event_outbox_cursors WHERE publisher = ANY(?)
+			HAVING COUNT(*) = ?)`, publishers, len(publishers))).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("MarkOutboxEventsPublished: failed to build sql: %v", err)
+	}
+
+	tag, err := p.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("MarkOutboxEventsPublished: failed to execute sql: %v", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// PurgeOutboxEvents deletes events published before the given time.
+func (p *PostgresAdapter) PurgeOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	sql, args, err := sq.
+		Delete("mapping.event_outbox").
+		Where("published_at IS NOT NULL").
+		Where(sq.Lt{"published_at": before}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("PurgeOutboxEvents: failed to build sql: %v", err)
+	}
+
+	tag, err := p.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("PurgeOutboxEvents: failed to execute sql: %v", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -15,6 +15,10 @@ import (
 	"time"
 )
 
+// legalHoldViolationCode is the SQLSTATE raised when a mapping under legal hold is
+// deleted, see migration 000011_legal_hold.
+const legalHoldViolationCode = "AXH01"
+
 type PostgresAdapter struct {
 	pool *pgxpool.Pool
 }
@@ -23,6 +27,13 @@ func NewPostgresAdapter(pool *pgxpool.Pool) *PostgresAdapter {
 	return &PostgresAdapter{pool: pool}
 }
 
+// selectMappingPurposes aggregates the allowed purposes of the mapping aliased as m.
+const selectMappingPurposes = `ARRAY(
+	SELECT p.name FROM mapping.mapping_purposes mp
+	JOIN mapping.purposes p ON p.id = mp.purpose_id
+	WHERE mp.mapping_id = m.id
+	ORDER BY p.name) AS purposes`
+
 func (p *PostgresAdapter) baseSelectMappingReq() sq.SelectBuilder {
 	return sq.
 		Select(
@@ -34,6 +45,11 @@ func (p *PostgresAdapter) baseSelectMappingReq() sq.SelectBuilder {
 			"m.created_at",
 			"m.deterministic",
 			"m.algo_name",
+			"m.subject_ref",
+			"m.legal_basis",
+			"m.consent_ref",
+			"m.consent_expires_at",
+			selectMappingPurposes,
 			"k.id AS kind_id",
 			"k.name AS kind_name",
 			"k.access_level",
@@ -53,6 +69,18 @@ func (p *PostgresAdapter) baseSelectAuditLogReq() sq.SelectBuilder {
 			"a.action",
 			"a.token",
 			"a.created_at",
+			"a.purpose",
+			"a.legal_hold",
+			"a.seq",
+			"a.prev_hash",
+			"a.row_hash",
+			"a.chain_version",
+			"a.outcome",
+			"COALESCE(a.reason, '')",
+			"COALESCE(a.client_ip, '')",
+			"COALESCE(a.user_agent, '')",
+			"COALESCE(a.request_id, '')",
+			"COALESCE(a.justification, '')",
 			"k.id AS kind_id",
 			"k.name AS kind_name",
 			"k.access_level",
@@ -78,15 +106,137 @@ func (p *PostgresAdapter) baseSelectKindReq() sq.SelectBuilder {
 		PlaceholderFormat(sq.Dollar)
 }
 
+// scanMapping scans a row produced by baseSelectMappingReq.
+func scanMapping(row pgx.Row) (*domain.Mapping, error) {
+	var mapping domain.Mapping
+	var (
+		subjectRef       *string
+		legalBasis       *string
+		consentRef       *string
+		consentExpiresAt *time.Time
+		kindID           *int32
+		kindName         *string
+		accessLevel      *int32
+		russianName      *string
+		shortName        *string
+	)
+
+	err := row.Scan(
+		&mapping.ID,
+		&mapping.Token,
+		&mapping.DekWrapped,
+		&mapping.CipherText,
+		&mapping.TokenTtl,
+		&mapping.CreatedAt,
+		&mapping.Deterministic,
+		&mapping.AlgoName,
+		&subjectRef,
+		&legalBasis,
+		&consentRef,
+		&consentExpiresAt,
+		&mapping.Purposes,
+		&kindID,
+		&kindName,
+		&accessLevel,
+		&russianName,
+		&shortName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if subjectRef != nil {
+		mapping.SubjectRef = *subjectRef
+	}
+	if legalBasis != nil {
+		mapping.LegalBasis = *legalBasis
+	}
+	if consentRef != nil {
+		mapping.ConsentRef = *consentRef
+	}
+	if consentExpiresAt != nil {
+		mapping.ConsentExpiresAt = *consentExpiresAt
+	}
+	if kindID != nil {
+		mapping.Kind = &domain.Kind{
+			Id:          *kindID,
+			Name:        *kindName,
+			AccessLevel: *accessLevel,
+			RussianName: *russianName,
+			ShortName:   *shortName,
+		}
+	}
+
+	return &mapping, nil
+}
+
+// selectMappings runs a query built on baseSelectMappingReq and scans every row. op
+// names the caller in returned errors.
+func (p *PostgresAdapter) selectMappings(ctx context.Context, op string, query sq.SelectBuilder) ([]*domain.Mapping, error) {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql: %v", op, err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to execute sql: %v", op, err)
+	}
+	defer rows.Close()
+
+	var mappings []*domain.Mapping
+	for rows.Next() {
+		mapping, err := scanMapping(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan mappings: %v", op, err)
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows iteration error: %v", op, err)
+	}
+
+	return mappings, nil
+}
+
+// InsertMapping stores the mapping together with its allowed purposes in one
+// transaction. Purposes must already exist in the catalog, otherwise
+// errs.ErrPurposeNotFound is returned and nothing is stored.
 func (p *PostgresAdapter) InsertMapping(ctx context.Context, mapping *domain.Mapping) (*domain.Mapping, error) {
 	var kindID *int32
 	if mapping.Kind != nil {
 		kindID = &mapping.Kind.Id
 	}
+	var subjectRef, legalBasis, consentRef *string
+	if mapping.SubjectRef != "" {
+		subjectRef = &mapping.SubjectRef
+	}
+	if mapping.LegalBasis != "" {
+		legalBasis = &mapping.LegalBasis
+	}
+	if mapping.ConsentRef != "" {
+		consentRef = &mapping.ConsentRef
+	}
+	var consentExpiresAt *time.Time
+	if !mapping.ConsentExpiresAt.IsZero() {
+		consentExpiresAt = &mapping.ConsentExpiresAt
+	}
 
 	sql, args, err := sq.
 		Insert("mapping.mappings").
-		Columns("token", "cipher_text", "dek_wrapped", "deterministic", "kind_id", "token_ttl", "algo_name").
+		Columns(
+			"token",
+			"cipher_text",
+			"dek_wrapped",
+			"deterministic",
+			"kind_id",
+			"token_ttl",
+			"algo_name",
+			"subject_ref",
+			"legal_basis",
+			"consent_ref",
+			"consent_expires_at",
+		).
 		Values(
 			mapping.Token,
 			mapping.CipherText,
@@ -95,6 +245,10 @@ func (p *PostgresAdapter) InsertMapping(ctx context.Context, mapping *domain.Map
 			kindID,
 			mapping.TokenTtl,
 			mapping.AlgoName,
+			subjectRef,
+			legalBasis,
+			consentRef,
+			consentExpiresAt,
 		).
 		Suffix("RETURNING id, created_at").
 		PlaceholderFormat(sq.Dollar).
@@ -103,7 +257,13 @@ func (p *PostgresAdapter) InsertMapping(ctx context.Context, mapping *domain.Map
 		return nil, fmt.Errorf("InsertMapping: failed to build sql: %v", err)
 	}
 
-	err = p.pool.QueryRow(ctx, sql, args...).Scan(&mapping.ID, &mapping.CreatedAt)
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("InsertMapping: failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&mapping.ID, &mapping.CreatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -114,15 +274,56 @@ func (p *PostgresAdapter) InsertMapping(ctx context.Context, mapping *domain.Map
 		logger.GetLoggerFromCtx(ctx).Info(ctx, fmt.Sprintf("SQL: %s\nARGS: %v\n", sql, args))
 		return nil, fmt.Errorf("InsertMapping: failed to scan id:  %v", err)
 	}
+
+	if len(mapping.Purposes) > 0 {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO mapping.mapping_purposes (mapping_id, purpose_id)
+			SELECT $1, id FROM mapping.purposes WHERE name = ANY($2)`,
+			mapping.ID, mapping.Purposes)
+		if err != nil {
+			return nil, fmt.Errorf("InsertMapping: failed to insert purposes: %v", err)
+		}
+		if tag.RowsAffected() != int64(countUnique(mapping.Purposes)) {
+			return nil, errs.ErrPurposeNotFound
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("InsertMapping: failed to commit transaction: %v", err)
+	}
 	return mapping, nil
 }
 
-func (p *PostgresAdapter) DeleteMappingById(ctx context.Context, id uuid.UUID) error {
-	_, err := p.SelectMappingById(ctx, id)
-	if err != nil && errors.Is(err, pgx.ErrNoRows) {
-		return errs.ErrMappingNotFound
+// setDestructionCause makes the destruction events recorded by deletions in tx carry
+// cause, see migration 000012_destruction_events.
+func setDestructionCause(ctx context.Context, tx pgx.Tx, cause domain.DestructionCause) error {
+	_, err := tx.Exec(ctx,
+		"SELECT set_config('anonix.destruction_reason', $1, true), set_config('anonix.destruction_initiator', $2, true)",
+		cause.Reason, cause.Initiator)
+	if err != nil {
+		return fmt.Errorf("failed to set destruction cause: %v", err)
+	}
+	return nil
+}
+
+// isLegalHoldViolation reports whether err was raised by the triggers that protect
+// mappings under legal hold from deletion and from replacing their crypto material.
+func isLegalHoldViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == legalHoldViolationCode
+}
+
+func countUnique(values []string) int {
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		seen[v] = struct{}{}
 	}
+	return len(seen)
+}
 
+// DeleteMappingById deletes the mapping; the destruction event recorded for it carries
+// cause.
+func (p *PostgresAdapter) DeleteMappingById(ctx context.Context, id uuid.UUID, cause domain.DestructionCause) error {
 	sql, args, err := sq.
 		Delete("mapping.mappings").
 		Where(sq.Eq{"id": id}).
@@ -131,10 +332,31 @@ func (p *PostgresAdapter) DeleteMappingById(ctx context.Context, id uuid.UUID) e
 	if err != nil {
 		return fmt.Errorf("DeleteMappingById: failed to build sql: %v", err)
 	}
-	_, err = p.pool.Exec(ctx, sql, args...)
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("DeleteMappingById: failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setDestructionCause(ctx, tx, cause); err != nil {
+		return fmt.Errorf("DeleteMappingById: %v", err)
+	}
+
+	tag, err := tx.Exec(ctx, sql, args...)
 	if err != nil {
+		if isLegalHoldViolation(err) {
+			return errs.ErrMappingOnHold
+		}
 		return fmt.Errorf("DeleteMappingById: failed to execute sql: %v", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrMappingNotFound
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("DeleteMappingById: failed to commit transaction: %v", err)
+	}
 	return nil
 }
 
@@ -144,47 +366,15 @@ func (p *PostgresAdapter) SelectMappingById(ctx context.Context, id uuid.UUID) (
 		return nil, fmt.Errorf("GetMappingById: failed to build sql: %v", err)
 	}
 
-	var mapping domain.Mapping
-	var (
-		kindID      *int32
-		kindName    *string
-		accessLevel *int32
-		russianName *string
-		shortName   *string
-	)
-
-	err = p.pool.QueryRow(ctx, sql, args...).Scan(
-		&mapping.ID,
-		&mapping.Token,
-		&mapping.DekWrapped,
-		&mapping.CipherText,
-		&mapping.TokenTtl,
-		&mapping.CreatedAt,
-		&mapping.Deterministic,
-		&mapping.AlgoName,
-		&kindID,
-		&kindName,
-		&accessLevel,
-		&russianName,
-		&shortName,
-	)
+	mapping, err := scanMapping(p.pool.QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.ErrMappingNotFound
 		}
 		return nil, fmt.Errorf("GetMappingById: failed to scan mapping: %v", err)
 	}
-	if kindID != nil {
-		mapping.Kind = &domain.Kind{
-			Id:          *kindID,
-			Name:        *kindName,
-			AccessLevel: *accessLevel,
-			RussianName: *russianName,
-			ShortName:   *shortName,
-		}
-	}
 
-	return &mapping, nil
+	return mapping, nil
 }
 
 func (p *PostgresAdapter) SelectMappingByToken(ctx context.Context, token string) (*domain.Mapping, error) {
@@ -193,113 +383,125 @@ func (p *PostgresAdapter) SelectMappingByToken(ctx context.Context, token string
 		return nil, fmt.Errorf("SelectMappingByToken: failed to build sql: %v", err)
 	}
 
-	var mapping domain.Mapping
-	var (
-		kindID      *int32
-		kindName    *string
-		accessLevel *int32
-		russianName *string
-		shortName   *string
-	)
-
-	err = p.pool.QueryRow(ctx, sql, args...).Scan(
-		&mapping.ID,
-		&mapping.Token,
-		&mapping.DekWrapped,
-		&mapping.CipherText,
-		&mapping.TokenTtl,
-		&mapping.CreatedAt,
-		&mapping.Deterministic,
-		&mapping.AlgoName,
-		&kindID,
-		&kindName,
-		&accessLevel,
-		&russianName,
-		&shortName,
-	)
+	mapping, err := scanMapping(p.pool.QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.ErrMappingNotFound
 		}
 		return nil, fmt.Errorf("SelectMappingByToken: failed to scan mapping: %v", err)
 	}
-	if kindID != nil {
-		mapping.Kind = &domain.Kind{
-			Id:          *kindID,
-			Name:        *kindName,
-			AccessLevel: *accessLevel,
-			RussianName: *russianName,
-			ShortName:   *shortName,
-		}
+
+	return mapping, nil
+}
+
+// withAccessLevel limits query to mappings whose kind is visible at maxAccessLevel.
+// Mappings without a kind are always visible; a maxAccessLevel of 0 disables the filter
+// and a negative one leaves only them.
+func withAccessLevel(query sq.SelectBuilder, maxAccessLevel int32) sq.SelectBuilder {
+	if maxAccessLevel == 0 {
+		return query
+	}
+	return query.Where(sq.Or{
+		sq.Eq{"m.kind_id": nil},
+		sq.LtOrEq{"k.access_level": maxAccessLevel},
+	})
+}
+
+func (p *PostgresAdapter) SelectAllMappings(ctx context.Context, maxAccessLevel int32) ([]*domain.Mapping, error) {
+	mappings, err := p.selectMappings(ctx, "SelectAllMappings",
+		withAccessLevel(p.baseSelectMappingReq(), maxAccessLevel))
+	if err != nil {
+		return nil, err
 	}
 
-	return &mapping, nil
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+
+	return mappings, nil
+}
+
+func (p *PostgresAdapter) SelectMappingsBySubject(ctx context.Context, subjectRef string, maxAccessLevel int32) ([]*domain.Mapping, error) {
+	query := p.baseSelectMappingReq().
+		Where(sq.Eq{"m.subject_ref": subjectRef}).
+		OrderBy("m.created_at")
+	return p.selectMappings(ctx, "SelectMappingsBySubject", withAccessLevel(query, maxAccessLevel))
 }
 
-func (p *PostgresAdapter) SelectAllMappings(ctx context.Context) ([]*domain.Mapping, error) {
-	sql, args, err := p.baseSelectMappingReq().ToSql()
+// DeleteMappingsBySubject erases the mappings of the data subject among ids and writes
+// an "erase" audit entry per mapping in the same statement, so either both happen or
+// neither does. If the subject has any mapping not among ids, such as one created after
+// the caller checked them, nothing is erased and errs.ErrSubjectChanged is returned.
+func (p *PostgresAdapter) DeleteMappingsBySubject(
+	ctx context.Context,
+	subjectRef string,
+	ids []uuid.UUID,
+	userID uuid.UUID,
+) ([]*domain.Mapping, error) {
+	query := `
+		WITH erased AS (
+			DELETE FROM mapping.mappings
+			WHERE subject_ref = $1 AND id = ANY($3)
+			RETURNING id, token, dek_wrapped, cipher_text, token_ttl, created_at, deterministic, algo_name, subject_ref,
+				legal_basis, consent_ref, consent_expires_at, kind_id
+		), audited AS (
+			INSERT INTO mapping.audit_log (user_id, action, token, kind_id)
+			SELECT $2, 'erase', token, kind_id FROM erased
+		)
+		SELECT m.id, m.token, m.dek_wrapped, m.cipher_text, m.token_ttl, m.created_at, m.deterministic, m.algo_name,
+			m.subject_ref, m.legal_basis, m.consent_ref, m.consent_expires_at, ` + selectMappingPurposes + `,
+			k.id, k.name, k.access_level, k.russian_name, k.short_name
+		FROM erased m
+		LEFT JOIN mapping.kinds k ON k.id = m.kind_id
+		ORDER BY m.created_at`
+
+	tx, err := p.pool.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("SelectAllMappings: failed to build sql: %v", err)
+		return nil, fmt.Errorf("DeleteMappingsBySubject: failed to begin transaction: %v", err)
 	}
+	defer tx.Rollback(ctx)
 
-	rows, err := p.pool.Query(ctx, sql, args...)
+	cause := domain.DestructionCause{Reason: domain.DestructionReasonErasure, Initiator: userID.String()}
+	if err = setDestructionCause(ctx, tx, cause); err != nil {
+		return nil, fmt.Errorf("DeleteMappingsBySubject: %v", err)
+	}
+
+	rows, err := tx.Query(ctx, query, subjectRef, userID, ids)
 	if err != nil {
-		return nil, fmt.Errorf("SelectAllMappings: failed to execute sql: %v", err)
+		if isLegalHoldViolation(err) {
+			return nil, errs.ErrMappingOnHold
+		}
+		return nil, fmt.Errorf("DeleteMappingsBySubject: failed to execute sql: %v", err)
 	}
 	defer rows.Close()
 
 	var mappings []*domain.Mapping
 	for rows.Next() {
-		var mapping domain.Mapping
-		var (
-			kindID      *int32
-			kindName    *string
-			accessLevel *int32
-			russianName *string
-			shortName   *string
-		)
-
-		err = rows.Scan(
-			&mapping.ID,
-			&mapping.Token,
-			&mapping.DekWrapped,
-			&mapping.CipherText,
-			&mapping.TokenTtl,
-			&mapping.CreatedAt,
-			&mapping.Deterministic,
-			&mapping.AlgoName,
-			&kindID,
-			&kindName,
-			&accessLevel,
-			&russianName,
-			&shortName,
-		)
+		mapping, err := scanMapping(rows)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return nil, errs.ErrMappingNotFound
-			}
-			return nil, fmt.Errorf("SelectAllMappings: failed to scan mappings: %v", err)
+			return nil, fmt.Errorf("DeleteMappingsBySubject: failed to scan mappings: %v", err)
 		}
+		mappings = append(mappings, mapping)
+	}
 
-		if kindID != nil {
-			mapping.Kind = &domain.Kind{
-				Id:          *kindID,
-				Name:        *kindName,
-				AccessLevel: *accessLevel,
-				RussianName: *russianName,
-				ShortName:   *shortName,
-			}
+	if err = rows.Err(); err != nil {
+		if isLegalHoldViolation(err) {
+			return nil, errs.ErrMappingOnHold
 		}
-
-		mappings = append(mappings, &mapping)
+		return nil, fmt.Errorf("DeleteMappingsBySubject: rows iteration error: %v", err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("SelectAllMappings: rows iteration error: %v", err)
+	var left bool
+	err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM mapping.mappings WHERE subject_ref = $1)", subjectRef).Scan(&left)
+	if err != nil {
+		return nil, fmt.Errorf("DeleteMappingsBySubject: failed to check remaining mappings: %v", err)
+	}
+	if left {
+		return nil, errs.ErrSubjectChanged
 	}
 
-	if len(mappings) == 0 {
-		return nil, nil
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("DeleteMappingsBySubject: failed to commit transaction: %v", err)
 	}
 
 	return mappings, nil
@@ -341,6 +543,9 @@ func (p *PostgresAdapter) UpdateMappingDek(ctx context.Context, id uuid.UUID, de
 	}
 
 	if _, err = p.pool.Exec(ctx, sql, args...); err != nil {
+		if isLegalHoldViolation(err) {
+			return errs.ErrMappingOnHold
+		}
 		return fmt.Errorf("UpdateMappingDek: failed to execute sql: %v", err)
 	}
 
@@ -361,6 +566,9 @@ func (p *PostgresAdapter) UpdateMappingCrypto(ctx context.Context, id uuid.UUID,
 	}
 
 	if _, err = p.pool.Exec(ctx, sql, args...); err != nil {
+		if isLegalHoldViolation(err) {
+			return errs.ErrMappingOnHold
+		}
 		return fmt.Errorf("UpdateMappingCrypto: failed to execute sql: %v", err)
 	}
 
@@ -585,19 +793,26 @@ func (p *PostgresAdapter) CreateAuditLog(ctx context.Context, entry *domain.Audi
 	if entry.Kind != nil {
 		kindID = &entry.Kind.Id
 	}
+	if entry.Outcome == "" {
+		entry.Outcome = domain.AuditOutcomeSuccess
+	}
 
 	sql, args, err := sq.
 		Insert("mapping.audit_log").
-		Columns("user_id", "action", "token", "kind_id").
-		Values(entry.UserID, entry.Action, entry.Token, kindID).
-		Suffix("RETURNING id, created_at").
+		Columns("user_id", "action", "token", "kind_id", "purpose", "outcome", "reason", "client_ip",
+			"user_agent", "request_id", "justification").
+		Values(entry.UserID, entry.Action, entry.Token, kindID, nullIfEmpty(entry.Purpose), entry.Outcome,
+			nullIfEmpty(entry.Reason), nullIfEmpty(entry.ClientIP), nullIfEmpty(entry.UserAgent),
+			nullIfEmpty(entry.RequestID), nullIfEmpty(entry.Justification)).
+		Suffix("RETURNING id, created_at, seq, prev_hash, row_hash, chain_version").
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("CreateAuditLog: failed to build sql: %v", err)
 	}
 
-	err = p.pool.QueryRow(ctx, sql, args...).Scan(&entry.ID, &entry.CreatedAt)
+	err = p.pool.QueryRow(ctx, sql, args...).
+		Scan(&entry.ID, &entry.CreatedAt, &entry.Seq, &entry.PrevHash, &entry.RowHash, &entry.ChainVersion)
 	if err != nil {
 		return nil, fmt.Errorf("CreateAuditLog: failed to scan id: %v", err)
 	}
@@ -605,64 +820,98 @@ func (p *PostgresAdapter) CreateAuditLog(ctx context.Context, entry *domain.Audi
 	return entry, nil
 }
 
-func (p *PostgresAdapter) GetAuditLogList(ctx context.Context) ([]*domain.AuditLogEntry, error) {
-	sql, args, err := p.baseSelectAuditLogReq().
-		OrderBy("a.created_at DESC").
-		Limit(500).
+// nullIfEmpty stores empty optional strings as NULL.
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func (p *PostgresAdapter) CreatePurpose(ctx context.Context, purpose *domain.Purpose) (*domain.Purpose, error) {
+	sql, args, err := sq.
+		Insert("mapping.purposes").
+		Columns("name", "description").
+		Values(purpose.Name, purpose.Description).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CreatePurpose: failed to build sql: %v", err)
+	}
+
+	err = p.pool.QueryRow(ctx, sql, args...).Scan(&purpose.Id, &purpose.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23505" {
+				return nil, errs.ErrPurposeAlreadyExists
+			}
+		}
+		return nil, fmt.Errorf("CreatePurpose: failed to scan id: %v", err)
+	}
+
+	return purpose, nil
+}
+
+func (p *PostgresAdapter) GetAllPurposes(ctx context.Context) ([]*domain.Purpose, error) {
+	sql, args, err := sq.
+		Select("id", "name", "description", "created_at").
+		From("mapping.purposes").
+		OrderBy("name").
+		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("GetAuditLogList: failed to build sql: %v", err)
+		return nil, fmt.Errorf("GetAllPurposes: failed to build sql: %v", err)
 	}
 
 	rows, err := p.pool.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("GetAuditLogList: failed to execute sql: %v", err)
+		return nil, fmt.Errorf("GetAllPurposes: failed to execute sql: %v", err)
 	}
 	defer rows.Close()
 
-	var entries []*domain.AuditLogEntry
+	var purposes []*domain.Purpose
 	for rows.Next() {
-		var entry domain.AuditLogEntry
-		var (
-			kindID      *int32
-			kindName    *string
-			accessLevel *int32
-			russianName *string
-			shortName   *string
-		)
-
-		err = rows.Scan(
-			&entry.ID,
-			&entry.UserID,
-			&entry.Action,
-			&entry.Token,
-			&entry.CreatedAt,
-			&kindID,
-			&kindName,
-			&accessLevel,
-			&russianName,
-			&shortName,
-		)
+		var purpose domain.Purpose
+		err = rows.Scan(&purpose.Id, &purpose.Name, &purpose.Description, &purpose.CreatedAt)
 		if err != nil {
-			return nil, fmt.Errorf("GetAuditLogList: failed to scan entry: %v", err)
+			return nil, fmt.Errorf("GetAllPurposes: failed to scan purpose: %v", err)
 		}
+		purposes = append(purposes, &purpose)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetAllPurposes: rows iteration error: %v", err)
+	}
+
+	return purposes, nil
+}
+
+func (p *PostgresAdapter) DeletePurposeById(ctx context.Context, id int32) error {
+	sql, args, err := sq.
+		Delete("mapping.purposes").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("DeletePurposeById: failed to build sql: %v", err)
+	}
 
-		if kindID != nil {
-			entry.Kind = &domain.Kind{
-				Id:          *kindID,
-				Name:        *kindName,
-				AccessLevel: *accessLevel,
-				RussianName: *russianName,
-				ShortName:   *shortName,
+	tag, err := p.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == "23503" {
+				return errs.ErrPurposeInUse
 			}
 		}
-
-		entries = append(entries, &entry)
+		return fmt.Errorf("DeletePurposeById: failed to execute sql: %v", err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("GetAuditLogList: rows iteration error: %v", err)
+	if tag.RowsAffected() == 0 {
+		return errs.ErrPurposeNotFound
 	}
 
-	return entries, nil
+	return nil
 }
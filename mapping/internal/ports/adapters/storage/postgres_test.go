@@ -0,0 +1,37 @@
+package storage
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"slices"
+	"testing"
+)
+
+func TestWithAccessLevel(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxAccessLevel int32
+		wantSQL        string
+		wantArgs       []any
+	}{
+		{"every level", 0, "SELECT m.id FROM mapping.mappings m", nil},
+		{"up to a level", 2,
+			"SELECT m.id FROM mapping.mappings m WHERE (m.kind_id IS NULL OR k.access_level <= ?)", []any{int32(2)}},
+		// No level is allowed: only mappings without a kind pass, as no kind has a
+		// negative access level.
+		{"no level", -1,
+			"SELECT m.id FROM mapping.mappings m WHERE (m.kind_id IS NULL OR k.access_level <= ?)", []any{int32(-1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := withAccessLevel(sq.Select("m.id").From("mapping.mappings m"), tt.maxAccessLevel)
+			sql, args, err := query.ToSql()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != tt.wantSQL || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("got %q %v, want %q %v", sql, args, tt.wantSQL, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package syslog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrBufferFull is returned by DiskBuffer.Append when the messages do not fit into
+	// the buffer until some are delivered.
+	ErrBufferFull = errors.New("syslog buffer is full")
+	// ErrMessageTooLarge is returned by DiskBuffer.Append when the first message does not
+	// fit even into the empty buffer.
+	ErrMessageTooLarge = errors.New("syslog message is larger than the buffer")
+)
+
+const (
+	bufferStateFile  = "buffer.state"
+	bufferRecordHead = 4
+)
+
+// DiskBuffer is a bounded FIFO of messages persisted in a directory, so messages not yet
+// delivered survive restarts. Messages are appended as length-prefixed records to a data
+// file of the current generation; the state file holds the generation and the offset of
+// the first undelivered record. A drained data file is truncated. When the buffer runs
+// out of space, the undelivered records are moved to a data file of the next generation
+// and the state file is replaced atomically, so a crash never leaves the offset pointing
+// into the wrong file.
+type DiskBuffer struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+
+	file       *os.File
+	generation uint64
+	offset     int64
+	size       int64
+}
+
+// OpenDiskBuffer opens the buffer in dir, creating it if needed. maxBytes bounds the
+// size of the undelivered records. A record torn by a crash while appending is
+// discarded.
+func OpenDiskBuffer(dir string, maxBytes int64) (*DiskBuffer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("OpenDiskBuffer: failed to create directory: %w", err)
+	}
+
+	b := &DiskBuffer{dir: dir, maxBytes: maxBytes}
+	if err := b.readState(); err != nil {
+		return nil, fmt.Errorf("OpenDiskBuffer: %w", err)
+	}
+
+	file, err := os.OpenFile(b.dataPath(b.generation), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("OpenDiskBuffer: failed to open data file: %w", err)
+	}
+	b.file = file
+
+	if err = b.recover(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("OpenDiskBuffer: %w", err)
+	}
+	b.removeStale()
+
+	return b, nil
+}
+
+func (b *DiskBuffer) dataPath(generation uint64) string {
+	return filepath.Join(b.dir, fmt.Sprintf("buffer-%d.dat", generation))
+}
+
+func (b *DiskBuffer) readState() error {
+	raw, err := os.ReadFile(filepath.Join(b.dir, bufferStateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state: %w", err)
+	}
+
+	fields := strings.Fields(string(raw))
+	if len(fields) != 2 {
+		return fmt.Errorf("malformed state %q", raw)
+	}
+	if b.generation, err = strconv.ParseUint(fields[0], 10, 64); err != nil {
+		return fmt.Errorf("malformed state generation: %w", err)
+	}
+	if b.offset, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return fmt.Errorf("malformed state offset: %w", err)
+	}
+	return nil
+}
+
+func (b *DiskBuffer) writeState(generation uint64, offset int64) error {
+	path := filepath.Join(b.dir, bufferStateFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(fmt.Sprintf("%d %d\n", generation, offset)), 0o600); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace state: %w", err)
+	}
+	return nil
+}
+
+// recover finds the end of the last complete record and truncates anything after it.
+func (b *DiskBuffer) recover() error {
+	info, err := b.file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat data file: %w", err)
+	}
+	size := info.Size()
+	if b.offset > size {
+		b.offset = size
+	}
+
+	end := b.offset
+	head := make([]byte, bufferRecordHead)
+	for end+bufferRecordHead <= size {
+		if _, err = b.file.ReadAt(head, end); err != nil {
+			return fmt.Errorf("failed to read record: %w", err)
+		}
+		next := end + bufferRecordHead + int64(binary.BigEndian.Uint32(head))
+		if next > size {
+			break
+		}
+		end = next
+	}
+	if end != size {
+		if err = b.file.Truncate(end); err != nil {
+			return fmt.Errorf("failed to truncate torn record: %w", err)
+		}
+	}
+	b.size = end
+	return nil
+}
+
+// removeStale deletes data files of other generations left by an interrupted compaction.
+func (b *DiskBuffer) removeStale() {
+	matches, _ := filepath.Glob(filepath.Join(b.dir, "buffer-*.dat"))
+	current := b.dataPath(b.generation)
+	for _, match := range matches {
+		if match != current {
+			_ = os.Remove(match)
+		}
+	}
+}
+
+// Append adds the leading messages that fit to the end of the buffer atomically and
+// returns their number. If not all of them fit, it fails with ErrBufferFull, or with
+// ErrMessageTooLarge when the first message can never fit; on other errors nothing is
+// appended.
+func (b *DiskBuffer) Append(messages [][]byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(messages) == 0 {
+		return 0, nil
+	}
+	if bufferRecordHead+int64(len(messages[0])) > b.maxBytes {
+		return 0, ErrMessageTooLarge
+	}
+
+	var need int64
+	n := 0
+	for _, message := range messages {
+		record := bufferRecordHead + int64(len(message))
+		if b.size-b.offset+need+record > b.maxBytes {
+			break
+		}
+		need += record
+		n++
+	}
+	if n == 0 {
+		return 0, ErrBufferFull
+	}
+	if b.size+need > b.maxBytes {
+		if err := b.compact(); err != nil {
+			return 0, err
+		}
+	}
+
+	records := make([]byte, 0, need)
+	for _, message := range messages[:n] {
+		records = binary.BigEndian.AppendUint32(records, uint32(len(message)))
+		records = append(records, message...)
+	}
+	if _, err := b.file.WriteAt(records, b.size); err != nil {
+		_ = b.file.Truncate(b.size)
+		return 0, fmt.Errorf("Append: failed to write records: %w", err)
+	}
+	if err := b.file.Sync(); err != nil {
+		_ = b.file.Truncate(b.size)
+		return 0, fmt.Errorf("Append: failed to sync data file: %w", err)
+	}
+	b.size += need
+	if n < len(messages) {
+		return n, ErrBufferFull
+	}
+	return n, nil
+}
+
+// Peek returns the first undelivered message, or nil if the buffer is empty.
+func (b *DiskBuffer) Peek() ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.offset == b.size {
+		return nil, nil
+	}
+
+	head := make([]byte, bufferRecordHead)
+	if _, err := b.file.ReadAt(head, b.offset); err != nil {
+		return nil, fmt.Errorf("Peek: failed to read record head: %w", err)
+	}
+	message := make([]byte, binary.BigEndian.Uint32(head))
+	if _, err := b.file.ReadAt(message, b.offset+bufferRecordHead); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("Peek: failed to read record: %w", err)
+	}
+	return message, nil
+}
+
+// Ack removes the first message, returned by the last Peek, once it is delivered.
+func (b *DiskBuffer) Ack(message []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offset := b.offset + bufferRecordHead + int64(len(message))
+	if offset == b.size {
+		// A crash between the truncation and the state update leaves an offset past the
+		// end of the file, which OpenDiskBuffer clamps to the empty file.
+		if err := b.file.Truncate(0); err == nil {
+			b.size, b.offset = 0, 0
+			if err = b.writeState(b.generation, 0); err != nil {
+				return fmt.Errorf("Ack: %w", err)
+			}
+			return nil
+		}
+	}
+	if err := b.writeState(b.generation, offset); err != nil {
+		return fmt.Errorf("Ack: %w", err)
+	}
+	b.offset = offset
+	return nil
+}
+
+// Len returns the size of the undelivered records in bytes.
+func (b *DiskBuffer) Len() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size - b.offset
+}
+
+// compact moves the undelivered records to a data file of the next generation.
+func (b *DiskBuffer) compact() error {
+	pending := make([]byte, b.size-b.offset)
+	if _, err := b.file.ReadAt(pending, b.offset); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("compact: failed to read records: %w", err)
+	}
+
+	generation := b.generation + 1
+	file, err := os.OpenFile(b.dataPath(generation), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("compact: failed to create data file: %w", err)
+	}
+	if _, err = file.Write(pending); err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = b.writeState(generation, 0)
+	}
+	if err != nil {
+		file.Close()
+		_ = os.Remove(b.dataPath(generation))
+		return fmt.Errorf("compact: %w", err)
+	}
+
+	b.file.Close()
+	_ = os.Remove(b.dataPath(b.generation))
+	b.file = file
+	b.generation = generation
+	b.offset = 0
+	b.size = int64(len(pending))
+	return nil
+}
+
+func (b *DiskBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.file.Close()
+}
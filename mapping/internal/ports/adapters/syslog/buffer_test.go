@@ -0,0 +1,190 @@
+package syslog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openBuffer(t *testing.T, dir string, maxBytes int64) *DiskBuffer {
+	t.Helper()
+	b, err := OpenDiskBuffer(dir, maxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+// drain peeks and acknowledges every message left in b.
+func drain(t *testing.T, b *DiskBuffer) []string {
+	t.Helper()
+	var messages []string
+	for {
+		message, err := b.Peek()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if message == nil {
+			return messages
+		}
+		if err = b.Ack(message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, string(message))
+	}
+}
+
+func assertMessages(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got messages %q, want %q", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got messages %q, want %q", got, want)
+		}
+	}
+}
+
+func TestDiskBuffer_AppendPeekAck(t *testing.T) {
+	dir := t.TempDir()
+	b := openBuffer(t, dir, 1024)
+
+	if message, err := b.Peek(); err != nil || message != nil {
+		t.Fatalf("empty buffer: got %q, %v", message, err)
+	}
+	if n, err := b.Append([][]byte{[]byte("first"), []byte("second"), []byte("third")}); n != 3 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if want := int64(3*bufferRecordHead + len("firstsecondthird")); b.Len() != want {
+		t.Fatalf("got length %d, want %d", b.Len(), want)
+	}
+
+	message, err := b.Peek()
+	if err != nil || string(message) != "first" {
+		t.Fatalf("got %q, %v", message, err)
+	}
+	if message, err = b.Peek(); err != nil || string(message) != "first" {
+		t.Fatalf("peek again: got %q, %v", message, err)
+	}
+	if err = b.Ack(message); err != nil {
+		t.Fatal(err)
+	}
+	b.Close()
+
+	b = openBuffer(t, dir, 1024)
+	assertMessages(t, drain(t, b), "second", "third")
+	if b.Len() != 0 {
+		t.Fatalf("drained buffer has length %d", b.Len())
+	}
+	info, err := os.Stat(b.dataPath(b.generation))
+	if err != nil || info.Size() != 0 {
+		t.Fatalf("drained data file: %v, %v", info, err)
+	}
+}
+
+func TestDiskBuffer_Compact(t *testing.T) {
+	dir := t.TempDir()
+	// Room for two records of 10-byte messages.
+	b := openBuffer(t, dir, 2*(bufferRecordHead+10))
+
+	if _, err := b.Append([][]byte{[]byte("message-01"), []byte("message-02")}); err != nil {
+		t.Fatal(err)
+	}
+	message, err := b.Peek()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Ack(message); err != nil {
+		t.Fatal(err)
+	}
+	if b.generation != 0 {
+		t.Fatalf("compacted before running out of space: generation %d", b.generation)
+	}
+
+	if n, err := b.Append([][]byte{[]byte("message-03")}); n != 1 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if b.generation != 1 {
+		t.Fatalf("got generation %d, want 1", b.generation)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "buffer-0.dat")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("data file of the old generation left: %v", err)
+	}
+	b.Close()
+
+	b = openBuffer(t, dir, 2*(bufferRecordHead+10))
+	if b.generation != 1 {
+		t.Fatalf("reopened generation %d, want 1", b.generation)
+	}
+	assertMessages(t, drain(t, b), "message-02", "message-03")
+}
+
+func TestDiskBuffer_ReopenAfterTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	b := openBuffer(t, dir, 1024)
+	if _, err := b.Append([][]byte{[]byte("first"), []byte("second")}); err != nil {
+		t.Fatal(err)
+	}
+	length := b.Len()
+	path := b.dataPath(b.generation)
+	b.Close()
+
+	// A crash while appending leaves a record head announcing more bytes than written.
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torn := binary.BigEndian.AppendUint32(nil, 100)
+	torn = append(torn, "thi"...)
+	if _, err = file.Write(torn); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	b = openBuffer(t, dir, 1024)
+	if b.Len() != length {
+		t.Fatalf("got length %d, want %d", b.Len(), length)
+	}
+	if _, err = b.Append([][]byte{[]byte("third")}); err != nil {
+		t.Fatal(err)
+	}
+	assertMessages(t, drain(t, b), "first", "second", "third")
+}
+
+func TestDiskBuffer_Full(t *testing.T) {
+	b := openBuffer(t, t.TempDir(), 2*(bufferRecordHead+10)+2)
+
+	n, err := b.Append([][]byte{[]byte("message-01"), []byte("message-02"), []byte("message-03")})
+	if n != 2 || !errors.Is(err, ErrBufferFull) {
+		t.Fatalf("got %d, %v, want 2 messages and ErrBufferFull", n, err)
+	}
+	length := b.Len()
+	if n, err = b.Append([][]byte{[]byte("message-03")}); n != 0 || !errors.Is(err, ErrBufferFull) {
+		t.Fatalf("full buffer: got %d, %v", n, err)
+	}
+	if b.Len() != length {
+		t.Fatalf("full buffer changed length from %d to %d", length, b.Len())
+	}
+
+	large := bytes.Repeat([]byte("x"), 2*(bufferRecordHead+10))
+	if n, err = b.Append([][]byte{large, []byte("message-03")}); n != 0 || !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("message larger than the buffer: got %d, %v", n, err)
+	}
+
+	message, err := b.Peek()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Ack(message); err != nil {
+		t.Fatal(err)
+	}
+	if n, err = b.Append([][]byte{[]byte("message-03")}); n != 1 || err != nil {
+		t.Fatalf("after ack: got %d, %v", n, err)
+	}
+	assertMessages(t, drain(t, b), "message-02", "message-03")
+}
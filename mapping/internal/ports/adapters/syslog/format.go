@@ -0,0 +1,183 @@
+package syslog
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCEF  = "cef"
+	FormatJSON = "json"
+)
+
+const (
+	cefVendor        = "Anonix"
+	cefProduct       = "Anonix"
+	cefDeviceVersion = "1.0"
+)
+
+// Syslog severities of audit entries by outcome; operations of break-glass sessions are
+// raised at least as warnings, anomaly blocks and started break-glass sessions as alerts.
+const (
+	severityAlert   = 1
+	severityError   = 3
+	severityWarning = 4
+	severityNotice  = 5
+)
+
+// formatter renders audit entries as RFC 5424 syslog messages.
+type formatter struct {
+	format   string
+	facility int
+	hostname string
+	appName  string
+}
+
+// Format renders the audit.entry event. The message body is the event envelope in the
+// JSON format and a CEF record in the CEF format.
+func (f *formatter) Format(event *domain.OutboxEvent) ([]byte, error) {
+	var data domain.AuditEntryEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode audit entry: %w", err)
+	}
+
+	var body string
+	switch f.format {
+	case FormatJSON:
+		envelope, err := event.Envelope()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode event: %w", err)
+		}
+		body = string(envelope)
+	default:
+		body = formatCEF(&data)
+	}
+
+	severity := severityNotice
+	switch data.Outcome {
+	case domain.AuditOutcomeDenied:
+		severity = severityWarning
+	case domain.AuditOutcomeError:
+		severity = severityError
+	}
+	if data.Justification != "" && severity > severityWarning {
+		severity = severityWarning
+	}
+	if data.Action == domain.AuditActionAnomalyBlock || data.Action == domain.AuditActionBreakGlassStart {
+		severity = severityAlert
+	}
+
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		f.facility*8+severity,
+		data.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000Z"),
+		headerField(f.hostname, 255),
+		headerField(f.appName, 48),
+		headerField(data.Action, 32),
+		body,
+	)), nil
+}
+
+// headerField makes value a valid RFC 5424 header field: printable US-ASCII without
+// spaces, at most maxLen characters, "-" when empty.
+func headerField(value string, maxLen int) string {
+	var sb strings.Builder
+	for _, r := range value {
+		if r > 32 && r < 127 {
+			sb.WriteRune(r)
+		}
+		if sb.Len() == maxLen {
+			break
+		}
+	}
+	if sb.Len() == 0 {
+		return "-"
+	}
+	return sb.String()
+}
+
+// formatCEF renders the entry as an ArcSight Common Event Format record.
+func formatCEF(data *domain.AuditEntryEventData) string {
+	cefSeverity := 3
+	switch data.Outcome {
+	case domain.AuditOutcomeDenied:
+		cefSeverity = 7
+	case domain.AuditOutcomeError:
+		cefSeverity = 5
+	}
+	if data.Justification != "" && cefSeverity < 7 {
+		cefSeverity = 7
+	}
+	if data.Action == domain.AuditActionAnomalyBlock || data.Action == domain.AuditActionBreakGlassStart {
+		cefSeverity = 10
+	}
+
+	var ext []string
+	add := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+cefExtension(value))
+		}
+	}
+	add("rt", strconv.FormatInt(data.CreatedAt.UnixMilli(), 10))
+	add("suid", data.UserID)
+	add("act", data.Action)
+	add("outcome", data.Outcome)
+	add("reason", data.Reason)
+	add("src", data.ClientIP)
+	add("requestClientApplication", data.UserAgent)
+	add("externalId", data.ID)
+	add("cn1Label", "seq")
+	add("cn1", strconv.FormatInt(data.Seq, 10))
+	if data.KindID != nil {
+		add("cn2Label", "kindId")
+		add("cn2", strconv.Itoa(int(*data.KindID)))
+	}
+	if data.Token != "" {
+		add("cs1Label", "token")
+		add("cs1", data.Token)
+	}
+	if data.Purpose != "" {
+		add("cs2Label", "purpose")
+		add("cs2", data.Purpose)
+	}
+	if data.LegalHold != "" {
+		add("cs3Label", "legalHold")
+		add("cs3", data.LegalHold)
+	}
+	if data.RequestID != "" {
+		add("cs4Label", "requestId")
+		add("cs4", data.RequestID)
+	}
+	add("cs5Label", "rowHash")
+	add("cs5", data.RowHash)
+	if data.Justification != "" {
+		add("cs6Label", "breakGlassJustification")
+		add("cs6", data.Justification)
+	}
+
+	return fmt.Sprintf("CEF:0|%s|%s|%s|%s|%s|%d|%s",
+		cefHeader(cefVendor),
+		cefHeader(cefProduct),
+		cefHeader(cefDeviceVersion),
+		cefHeader(data.Action),
+		cefHeader(data.Action+" "+data.Outcome),
+		cefSeverity,
+		strings.Join(ext, " "),
+	)
+}
+
+var (
+	cefHeaderReplacer = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefExtReplacer    = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
+
+func cefHeader(value string) string {
+	return cefHeaderReplacer.Replace(value)
+}
+
+func cefExtension(value string) string {
+	return cefExtReplacer.Replace(value)
+}
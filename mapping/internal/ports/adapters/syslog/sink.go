@@ -0,0 +1,270 @@
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	NetworkTCP = "tcp"
+	NetworkTLS = "tls"
+	NetworkUDP = "udp"
+)
+
+// SinkConfig describes the syslog receiver and the message format of a Sink.
+type SinkConfig struct {
+	Network  string
+	Address  string
+	CAFile   string
+	Format   string
+	Facility int
+	AppName  string
+
+	RetryDelay   time.Duration
+	WriteTimeout time.Duration
+}
+
+// Sink forwards audit entries to a syslog receiver, such as a SIEM. Entries are first
+// appended to a disk buffer and then sent from it one by one, so they survive an outage
+// of the receiver and restarts of the service. When the buffer is full, Publish accepts
+// the entries that fit and the rest wait in the outbox until there is room again; an
+// entry larger than the whole buffer is dropped and logged.
+//
+// Over TCP and TLS messages are framed by octet counting (RFC 6587, RFC 5425); over UDP
+// every message is a datagram.
+type Sink struct {
+	cfg       SinkConfig
+	tlsConfig *tls.Config
+	formatter *formatter
+	buffer    *DiskBuffer
+	notify    chan struct{}
+}
+
+func NewSink(cfg SinkConfig, buffer *DiskBuffer) (*Sink, error) {
+	switch cfg.Network {
+	case NetworkTCP, NetworkTLS, NetworkUDP:
+	default:
+		return nil, fmt.Errorf("NewSink: unsupported network %q", cfg.Network)
+	}
+	switch cfg.Format {
+	case FormatCEF, FormatJSON:
+	default:
+		return nil, fmt.Errorf("NewSink: unsupported format %q", cfg.Format)
+	}
+	if cfg.Facility < 0 || cfg.Facility > 23 {
+		return nil, fmt.Errorf("NewSink: facility must be between 0 and 23, got %d", cfg.Facility)
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.Network == NetworkTLS {
+		host, _, err := net.SplitHostPort(cfg.Address)
+		if err != nil {
+			return nil, fmt.Errorf("NewSink: invalid address: %w", err)
+		}
+		tlsConfig = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+		if cfg.CAFile != "" {
+			pem, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("NewSink: failed to read CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("NewSink: no certificates in CA file %s", cfg.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = ""
+	}
+
+	return &Sink{
+		cfg:       cfg,
+		tlsConfig: tlsConfig,
+		formatter: &formatter{
+			format:   cfg.Format,
+			facility: cfg.Facility,
+			hostname: hostname,
+			appName:  cfg.AppName,
+		},
+		buffer: buffer,
+		notify: make(chan struct{}, 1),
+	}, nil
+}
+
+func (s *Sink) Name() string {
+	return "syslog"
+}
+
+// Publish buffers the audit entries among events; other events are skipped. It returns
+// the number of leading events buffered or skipped.
+func (s *Sink) Publish(ctx context.Context, events []*domain.OutboxEvent) (int, error) {
+	messages := make([][]byte, 0, len(events))
+	// indexes holds the index in events of every message.
+	indexes := make([]int, 0, len(events))
+	var formatErr error
+	for i, event := range events {
+		if event.EventType != domain.EventTypeAuditEntry {
+			continue
+		}
+		message, err := s.formatter.Format(event)
+		if err != nil {
+			// The events before it are still buffered, the rest are published again.
+			events = events[:i]
+			formatErr = fmt.Errorf("Publish: failed to format event %d: %w", event.ID, err)
+			break
+		}
+		messages = append(messages, message)
+		indexes = append(indexes, i)
+	}
+
+	buffered := 0
+	for buffered < len(messages) {
+		n, err := s.buffer.Append(messages[buffered:])
+		buffered += n
+		if errors.Is(err, ErrMessageTooLarge) {
+			event := events[indexes[buffered]]
+			logger.GetLoggerFromCtx(ctx).Error(ctx, "syslog message is larger than the buffer, dropped",
+				slog.Int64("event_id", event.ID),
+				slog.String("event_uuid", event.EventID.String()),
+				slog.Int("bytes", len(messages[buffered])))
+			buffered++
+			continue
+		}
+		if err != nil {
+			s.wake(buffered)
+			return indexes[buffered], fmt.Errorf("Publish: %w", err)
+		}
+	}
+	s.wake(buffered)
+	return len(events), formatErr
+}
+
+// wake signals Run when n messages were buffered.
+func (s *Sink) wake(n int) {
+	if n == 0 {
+		return
+	}
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends buffered messages to the receiver until ctx is done, reconnecting after
+// RetryDelay when the receiver is unavailable. A message is removed from the buffer
+// once it is written to the connection.
+func (s *Sink) Run(ctx context.Context) {
+	var conn net.Conn
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	wait := func(d time.Duration) bool {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return false
+		case <-s.notify:
+			return true
+		case <-timer.C:
+			return true
+		}
+	}
+	retry := func() bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(s.cfg.RetryDelay):
+			return true
+		}
+	}
+
+	for {
+		message, err := s.buffer.Peek()
+		if err != nil {
+			logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to read syslog buffer", logger.Err(err))
+			if !retry() {
+				return
+			}
+			continue
+		}
+		if message == nil {
+			if !wait(s.cfg.RetryDelay) {
+				return
+			}
+			continue
+		}
+
+		if conn == nil {
+			conn, err = s.dial(ctx)
+			if err != nil {
+				logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to connect to syslog receiver",
+					slog.String("address", s.cfg.Address),
+					slog.Int64("buffered_bytes", s.buffer.Len()),
+					logger.Err(err))
+				if !retry() {
+					return
+				}
+				continue
+			}
+		}
+
+		if err = s.write(conn, message); err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to send syslog message",
+				slog.String("address", s.cfg.Address),
+				logger.Err(err))
+			conn.Close()
+			conn = nil
+			if !retry() {
+				return
+			}
+			continue
+		}
+
+		if err = s.buffer.Ack(message); err != nil {
+			logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to acknowledge syslog message", logger.Err(err))
+		}
+	}
+}
+
+func (s *Sink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.cfg.WriteTimeout}
+	if s.cfg.Network == NetworkTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		return tlsDialer.DialContext(ctx, "tcp", s.cfg.Address)
+	}
+	return dialer.DialContext(ctx, s.cfg.Network, s.cfg.Address)
+}
+
+func (s *Sink) write(conn net.Conn, message []byte) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(s.cfg.WriteTimeout)); err != nil {
+		return err
+	}
+	if s.cfg.Network == NetworkUDP {
+		_, err := conn.Write(message)
+		return err
+	}
+	frame := append([]byte(strconv.Itoa(len(message))+" "), message...)
+	_, err := conn.Write(frame)
+	return err
+}
+
+func (s *Sink) Close() error {
+	return s.buffer.Close()
+}
@@ -0,0 +1,64 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+type HashiCorpAdapter struct {
+	client *vaultapi.Client
+}
+
+func NewHashiCorpAdapter(client *vaultapi.Client) *HashiCorpAdapter {
+	return &HashiCorpAdapter{client: client}
+}
+
+func (h *HashiCorpAdapter) GenerateDEK(ctx context.Context, bits int, keyName string) ([]byte, []byte, error) {
+	resp, err := h.client.Logical().WriteWithContext(ctx, fmt.Sprintf("transit/datakey/plaintext/%s", keyName), map[string]interface{}{
+		"bits": bits,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("hashiCorpAdapter.GenerateDEK: failed to generate datakey: %w", err)
+	}
+	if resp == nil || resp.Data == nil {
+		return nil, nil, fmt.Errorf("hashiCorpAdapter.GenerateDEK: empty response")
+	}
+	wrappedDek, ok := resp.Data["ciphertext"].(string)
+	if !ok {
+		return nil, nil, fmt.Errorf("hashiCorpAdapter.GenerateDEK: ciphertext not found in response")
+	}
+	plaintext, ok := resp.Data["plaintext"].(string)
+	if !ok {
+		return nil, nil, fmt.Errorf("hashiCorpAdapter.GenerateDEK: plaintext not found in response")
+	}
+	dek, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hashiCorpAdapter.GenerateDEK: failed to decode base64 plaintext: %w", err)
+	}
+
+	return []byte(wrappedDek), dek, nil
+}
+
+func (h *HashiCorpAdapter) UnwrapDEK(ctx context.Context, wrappedDek []byte, keyName string) ([]byte, error) {
+	secret, err := h.client.Logical().WriteWithContext(ctx, fmt.Sprintf("transit/decrypt/%s", keyName), map[string]interface{}{
+		"ciphertext": string(wrappedDek),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hashiCorpAdapter.UnwrapDEK: failed to unwrap dek: %w", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("hashiCorpAdapter.UnwrapDEK: empty response")
+	}
+	plaintext, ok := secret.Data["plaintext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("hashiCorpAdapter.UnwrapDEK: plaintext not found in response")
+	}
+	dek, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("hashiCorpAdapter.UnwrapDEK: failed to decode base64 plaintext: %w", err)
+	}
+
+	return dek, nil
+}
@@ -0,0 +1,60 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// TransitSigner signs data with a Vault transit signing key. Signatures have Vault's
+// "vault:v<version>:<base64>" format and can be checked with transit/verify.
+type TransitSigner struct {
+	client  *vaultapi.Client
+	keyName string
+}
+
+func NewTransitSigner(client *vaultapi.Client, keyName string) *TransitSigner {
+	return &TransitSigner{client: client, keyName: keyName}
+}
+
+func (t *TransitSigner) KeyName() string {
+	return t.keyName
+}
+
+func (t *TransitSigner) Sign(ctx context.Context, data []byte) (string, error) {
+	resp, err := t.client.Logical().WriteWithContext(ctx, fmt.Sprintf("transit/sign/%s", t.keyName), map[string]interface{}{
+		"input": base64.StdEncoding.EncodeToString(data),
+	})
+	if err != nil {
+		return "", fmt.Errorf("transitSigner.Sign: failed to sign: %w", err)
+	}
+	if resp == nil || resp.Data == nil {
+		return "", fmt.Errorf("transitSigner.Sign: empty response")
+	}
+	signature, ok := resp.Data["signature"].(string)
+	if !ok {
+		return "", fmt.Errorf("transitSigner.Sign: signature not found in response")
+	}
+
+	return signature, nil
+}
+
+func (t *TransitSigner) Verify(ctx context.Context, data []byte, signature string) (bool, error) {
+	resp, err := t.client.Logical().WriteWithContext(ctx, fmt.Sprintf("transit/verify/%s", t.keyName), map[string]interface{}{
+		"input":     base64.StdEncoding.EncodeToString(data),
+		"signature": signature,
+	})
+	if err != nil {
+		return false, fmt.Errorf("transitSigner.Verify: failed to verify: %w", err)
+	}
+	if resp == nil || resp.Data == nil {
+		return false, fmt.Errorf("transitSigner.Verify: empty response")
+	}
+	valid, ok := resp.Data["valid"].(bool)
+	if !ok {
+		return false, fmt.Errorf("transitSigner.Verify: verification result not found in response")
+	}
+
+	return valid, nil
+}
@@ -10,12 +10,14 @@ import (
 type StorageRepository interface {
 	SelectMappingById(ctx context.Context, id uuid.UUID) (*domain.Mapping, error)
 	SelectMappingByToken(ctx context.Context, token string) (*domain.Mapping, error)
-	SelectAllMappings(ctx context.Context) ([]*domain.Mapping, error)
+	SelectAllMappings(ctx context.Context, maxAccessLevel int32) ([]*domain.Mapping, error)
 	InsertMapping(ctx context.Context, mapping *domain.Mapping) (*domain.Mapping, error)
 	UpdateMapping(ctx context.Context, id uuid.UUID, tokenTtl time.Duration) (*domain.Mapping, error)
 	UpdateMappingDek(ctx context.Context, id uuid.UUID, dekWrapped []byte) error
 	UpdateMappingCrypto(ctx context.Context, id uuid.UUID, dekWrapped, cipherText []byte, algoName string) error
-	DeleteMappingById(ctx context.Context, id uuid.UUID) error
+	DeleteMappingById(ctx context.Context, id uuid.UUID, cause domain.DestructionCause) error
+	SelectMappingsBySubject(ctx context.Context, subjectRef string, maxAccessLevel int32) ([]*domain.Mapping, error)
+	DeleteMappingsBySubject(ctx context.Context, subjectRef string, ids []uuid.UUID, userID uuid.UUID) ([]*domain.Mapping, error)
 
 	GetKindById(ctx context.Context, id int32) (*domain.Kind, error)
 	GetKindByName(ctx context.Context, name string) (*domain.Kind, error)
@@ -24,25 +26,83 @@ type StorageRepository interface {
 	UpdateKind(ctx context.Context, kind *domain.Kind) (*domain.Kind, error)
 	DeleteKindById(ctx context.Context, id int32) error
 
+	CreatePurpose(ctx context.Context, purpose *domain.Purpose) (*domain.Purpose, error)
+	GetAllPurposes(ctx context.Context) ([]*domain.Purpose, error)
+	DeletePurposeById(ctx context.Context, id int32) error
+
+	CreateLegalHold(ctx context.Context, hold *domain.LegalHold) (*domain.LegalHold, error)
+	GetLegalHolds(ctx context.Context, activeOnly bool) ([]*domain.LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.LegalHold, error)
+
+	SelectDestructionEvents(ctx context.Context, from, to time.Time) ([]*domain.DestructionEvent, error)
+
 	CreateAuditLog(ctx context.Context, entry *domain.AuditLogEntry) (*domain.AuditLogEntry, error)
-	GetAuditLogList(ctx context.Context) ([]*domain.AuditLogEntry, error)
+	GetAuditLogList(ctx context.Context, query *domain.AuditLogQuery) ([]*domain.AuditLogEntry, error)
+	WalkAuditLog(ctx context.Context, filter *domain.AuditLogFilter, fn func(entry *domain.AuditLogEntry) error) error
+	GetAuditChainHead(ctx context.Context) (*domain.AuditChainHead, error)
+	WalkAuditChain(ctx context.Context, fn func(entry *domain.AuditLogEntry) bool) error
+	GetLastAuditCheckpoint(ctx context.Context) (*domain.AuditCheckpoint, error)
+	SelectAuditCheckpoints(ctx context.Context) ([]*domain.AuditCheckpoint, error)
+	CreateAuditCheckpoint(ctx context.Context, checkpoint *domain.AuditCheckpoint) (*domain.AuditCheckpoint, error)
+
+	RelayOutboxEvents(
+		ctx context.Context,
+		publisher string,
+		limit uint64,
+		publish func(events []*domain.OutboxEvent) (int, error),
+	) (int, error)
+	MarkOutboxEventsPublished(ctx context.Context, publishers []string) (int64, error)
+	PurgeOutboxEvents(ctx context.Context, before time.Time) (int64, error)
 }
 
 type CacheRepository interface {
-	GetMappingById(ctx context.Context, id uuid.UUID) (*domain.Mapping, error)
+	GetMappingById(ctx context.Context, id uuid.UUID) (*domain.Mapping, time.Duration, error)
+	GetMappingByToken(ctx context.Context, token string) (*domain.Mapping, time.Duration, error)
 	SaveMapping(ctx context.Context, mapping *domain.Mapping, ttl time.Duration) error
+	SaveMissingToken(ctx context.Context, token string, ttl time.Duration) error
 	DeleteMappingById(ctx context.Context, id uuid.UUID) error
+	DeleteMapping(ctx context.Context, id uuid.UUID, token string) error
+	DeleteAllMappings(ctx context.Context) error
+}
+
+type VaultRepository interface {
+	GenerateDEK(ctx context.Context, bits int, keyName string) ([]byte, []byte, error)
+	UnwrapDEK(ctx context.Context, wrappedDek []byte, keyName string) ([]byte, error)
+}
+
+// Verifier checks signatures made with the key it is named after.
+type Verifier interface {
+	KeyName() string
+	Verify(ctx context.Context, data []byte, signature string) (bool, error)
+}
+
+// Signer signs documents issued by the service, such as destruction acts and audit
+// checkpoints.
+type Signer interface {
+	Verifier
+	Sign(ctx context.Context, data []byte) (string, error)
+}
+
+// EventPublisher delivers outbox events to consumers outside the service. Name keys the
+// progress of the publisher through the outbox and must stay the same across restarts.
+// Publish returns the number of leading events accepted by the broker, which is less
+// than len(events) only together with an error.
+type EventPublisher interface {
+	Name() string
+	Publish(ctx context.Context, events []*domain.OutboxEvent) (int, error)
 }
 
 type MappingUseCase interface {
 	GetMappingById(ctx context.Context, id uuid.UUID) (*domain.Mapping, error)
 	GetMappingByToken(ctx context.Context, token string) (*domain.Mapping, error)
-	GetAllMappings(ctx context.Context) ([]*domain.Mapping, error)
+	GetAllMappings(ctx context.Context, maxAccessLevel int32) ([]*domain.Mapping, error)
 	CreateMapping(ctx context.Context, mapping *domain.Mapping) (*domain.Mapping, error)
 	UpdateMapping(ctx context.Context, id uuid.UUID, tokenTtl time.Duration) (*domain.Mapping, error)
 	UpdateMappingDek(ctx context.Context, id uuid.UUID, dekWrapped []byte) error
 	UpdateMappingCrypto(ctx context.Context, id uuid.UUID, dekWrapped, cipherText []byte, algoName string) error
-	DeleteMappingById(ctx context.Context, id uuid.UUID) error
+	DeleteMappingById(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	GetSubjectMappings(ctx context.Context, subjectRef string, maxAccessLevel int32) ([]*domain.Mapping, error)
+	EraseSubject(ctx context.Context, subjectRef string, ids []uuid.UUID, userID uuid.UUID) (*domain.ErasureReport, error)
 
 	GetKindById(ctx context.Context, id int32) (*domain.Kind, error)
 	GetKindByName(ctx context.Context, name string) (*domain.Kind, error)
@@ -51,6 +111,19 @@ type MappingUseCase interface {
 	UpdateKind(ctx context.Context, kind *domain.Kind) (*domain.Kind, error)
 	DeleteKindById(ctx context.Context, id int32) error
 
+	CreatePurpose(ctx context.Context, purpose *domain.Purpose) (*domain.Purpose, error)
+	GetAllPurposes(ctx context.Context) ([]*domain.Purpose, error)
+	DeletePurposeById(ctx context.Context, id int32) error
+
+	CreateLegalHold(ctx context.Context, hold *domain.LegalHold) (*domain.LegalHold, error)
+	GetLegalHolds(ctx context.Context, activeOnly bool) ([]*domain.LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.LegalHold, error)
+
+	GetDestructionAct(ctx context.Context, from, to time.Time, userID uuid.UUID) (*domain.DestructionAct, error)
+
 	CreateAuditLog(ctx context.Context, entry *domain.AuditLogEntry) (*domain.AuditLogEntry, error)
-	GetAuditLogList(ctx context.Context) ([]*domain.AuditLogEntry, error)
+	GetAuditLogList(ctx context.Context, query *domain.AuditLogQuery) ([]*domain.AuditLogEntry, *domain.AuditLogCursor, error)
+	ExportAuditLog(ctx context.Context, filter *domain.AuditLogFilter, userID uuid.UUID, fn func(entry *domain.AuditLogEntry) error) error
+	VerifyAuditLog(ctx context.Context) (*domain.AuditChainReport, error)
+	CheckpointAuditLog(ctx context.Context) error
 }
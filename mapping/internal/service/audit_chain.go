@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// VerifyAuditLog walks the audit hash chain from the first entry, recomputing every
+// entry hash and checking it against the next entry, the signed checkpoints and the
+// chain head. It stops at the first broken link.
+func (m *MappingService) VerifyAuditLog(ctx context.Context) (*domain.AuditChainReport, error) {
+	checkpoints, err := m.storage.SelectAuditCheckpoints(ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to select audit checkpoints", logger.Err(err))
+		return nil, err
+	}
+	// The head is read before the walk, so entries appended during the walk are not
+	// mistaken for a truncated chain.
+	head, err := m.storage.GetAuditChainHead(ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get audit chain head", logger.Err(err))
+		return nil, err
+	}
+	bySeq := make(map[int64]*domain.AuditCheckpoint, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		bySeq[checkpoint.Seq] = checkpoint
+	}
+
+	report := &domain.AuditChainReport{Valid: true}
+	broken := func(seq int64, id uuid.UUID, reason string) {
+		report.Valid = false
+		report.BrokenSeq = seq
+		report.BrokenEntryID = id
+		report.Reason = reason
+	}
+
+	prevHash := domain.AuditChainGenesis
+	headHash := domain.AuditChainGenesis
+	var verifyErr error
+	err = m.storage.WalkAuditChain(ctx, func(entry *domain.AuditLogEntry) bool {
+		if entry.Seq != report.LastSeq+1 {
+			broken(report.LastSeq+1, uuid.Nil, domain.AuditChainMissingEntry)
+			return false
+		}
+		if entry.PrevHash != prevHash {
+			broken(entry.Seq, entry.ID, domain.AuditChainPrevHashMismatch)
+			return false
+		}
+		rowHash, err := entry.ComputeRowHash()
+		if err != nil {
+			broken(entry.Seq, entry.ID, domain.AuditChainUnknownVersion)
+			return false
+		}
+		if rowHash != entry.RowHash {
+			broken(entry.Seq, entry.ID, domain.AuditChainRowHashMismatch)
+			return false
+		}
+
+		if checkpoint, ok := bySeq[entry.Seq]; ok {
+			if checkpoint.RowHash != entry.RowHash {
+				broken(entry.Seq, entry.ID, domain.AuditChainCheckpointMismatch)
+				return false
+			}
+			valid, err := m.verifyCheckpoint(ctx, checkpoint)
+			if err != nil {
+				verifyErr = err
+				return false
+			}
+			if !valid {
+				broken(entry.Seq, entry.ID, domain.AuditChainCheckpointSignature)
+				return false
+			}
+			report.CheckpointsVerified++
+		}
+
+		if entry.Seq == head.Seq {
+			headHash = entry.RowHash
+		}
+		prevHash = entry.RowHash
+		report.LastSeq = entry.Seq
+		report.Checked++
+		return true
+	})
+	if err == nil {
+		err = verifyErr
+	}
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to walk audit chain", logger.Err(err))
+		return nil, err
+	}
+
+	// Entries cut off the end of the chain are only visible in the head and in the
+	// checkpoints taken before they were removed.
+	if report.Valid {
+		switch {
+		case head.Seq > report.LastSeq:
+			broken(report.LastSeq+1, uuid.Nil, domain.AuditChainMissingEntry)
+		case len(checkpoints) > 0 && checkpoints[len(checkpoints)-1].Seq > report.LastSeq:
+			broken(report.LastSeq+1, uuid.Nil, domain.AuditChainMissingEntry)
+		case head.RowHash != headHash:
+			broken(head.Seq, uuid.Nil, domain.AuditChainHeadMismatch)
+		}
+	}
+
+	if !report.Valid {
+		logger.GetLoggerFromCtx(ctx).Error(ctx, "audit chain is broken",
+			slog.Int64("seq", report.BrokenSeq),
+			slog.String("reason", report.Reason))
+	}
+
+	return report, nil
+}
+
+// verifyCheckpoint checks the signature of the checkpoint with the key it was signed
+// by. A checkpoint signed by a key the service does not know is invalid.
+func (m *MappingService) verifyCheckpoint(ctx context.Context, checkpoint *domain.AuditCheckpoint) (bool, error) {
+	verifier, ok := m.verifiers[checkpoint.SigningKey]
+	if !ok {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "audit checkpoint is signed by an unknown key",
+			slog.Int64("seq", checkpoint.Seq),
+			slog.String("signing key", checkpoint.SigningKey))
+		return false, nil
+	}
+	return verifier.Verify(ctx, checkpoint.SignedData(), checkpoint.Signature)
+}
+
+// CheckpointAuditLog signs the current chain head unless it is already checkpointed.
+func (m *MappingService) CheckpointAuditLog(ctx context.Context) error {
+	head, err := m.storage.GetAuditChainHead(ctx)
+	if err != nil {
+		return err
+	}
+	if head.Seq == 0 {
+		return nil
+	}
+
+	last, err := m.storage.GetLastAuditCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if last != nil && last.Seq >= head.Seq {
+		return nil
+	}
+
+	checkpoint := &domain.AuditCheckpoint{
+		Seq:        head.Seq,
+		RowHash:    head.RowHash,
+		SigningKey: m.signer.KeyName(),
+	}
+	checkpoint.Signature, err = m.signer.Sign(ctx, checkpoint.SignedData())
+	if err != nil {
+		return err
+	}
+	if _, err = m.storage.CreateAuditCheckpoint(ctx, checkpoint); err != nil {
+		return err
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx, "audit checkpoint created",
+		slog.Int64("seq", checkpoint.Seq))
+
+	return nil
+}
+
+// RunAuditCheckpoints checkpoints the audit chain every interval until ctx is done.
+func (m *MappingService) RunAuditCheckpoints(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.CheckpointAuditLog(ctx); err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to checkpoint audit log", logger.Err(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/NeF2le/anonix/common/logger"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/NeF2le/anonix/mapping/internal/ports"
+	"log/slog"
+	"time"
+)
+
+// outboxPurgeInterval is how often published events older than the retention are
+// deleted from the outbox.
+const outboxPurgeInterval = time.Hour
+
+// OutboxRelay publishes the events of the outbox to every publisher. Each publisher
+// keeps its own cursor, so one that is unavailable or full falls behind without holding
+// back the others. Events are marked published, and eventually purged, only after all
+// publishers accepted them. Delivery is at least once: an event may be published again
+// after a failure, but never lost.
+type OutboxRelay struct {
+	storage    ports.StorageRepository
+	publishers []ports.EventPublisher
+	names      []string
+	batchSize  uint64
+	retention  time.Duration
+}
+
+// NewOutboxRelay creates a relay publishing up to batchSize events at a time. Published
+// events are kept for retention; 0 keeps them forever.
+func NewOutboxRelay(
+	storage ports.StorageRepository,
+	publishers []ports.EventPublisher,
+	batchSize uint64,
+	retention time.Duration,
+) *OutboxRelay {
+	names := make([]string, 0, len(publishers))
+	for _, publisher := range publishers {
+		names = append(names, publisher.Name())
+	}
+	return &OutboxRelay{
+		storage:    storage,
+		publishers: publishers,
+		names:      names,
+		batchSize:  batchSize,
+		retention:  retention,
+	}
+}
+
+// Relay publishes pending events to every publisher batch by batch until each has
+// drained the outbox or failed, then marks the events all of them accepted published and
+// returns their number.
+func (r *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	var errs []error
+	for _, publisher := range r.publishers {
+		if err := r.relayTo(ctx, publisher); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", publisher.Name(), err))
+		}
+	}
+
+	n, err := r.storage.MarkOutboxEventsPublished(ctx, r.names)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return int(n), errors.Join(errs...)
+}
+
+func (r *OutboxRelay) relayTo(ctx context.Context, publisher ports.EventPublisher) error {
+	for {
+		n, err := r.storage.RelayOutboxEvents(ctx, publisher.Name(), r.batchSize,
+			func(events []*domain.OutboxEvent) (int, error) {
+				return publisher.Publish(ctx, events)
+			})
+		if err != nil {
+			return err
+		}
+		if uint64(n) < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// Run relays pending events every interval and purges published events once their
+// retention has passed, until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(outboxPurgeInterval)
+	defer purgeTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.Relay(ctx)
+			if err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to relay outbox events",
+					slog.Int("published", n), logger.Err(err))
+			}
+		case <-purgeTicker.C:
+			if r.retention == 0 {
+				continue
+			}
+			n, err := r.storage.PurgeOutboxEvents(ctx, time.Now().Add(-r.retention))
+			if err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to purge outbox events", logger.Err(err))
+				continue
+			}
+			if n > 0 {
+				logger.GetLoggerFromCtx(ctx).Info(ctx, "purged published outbox events", slog.Int64("count", n))
+			}
+		}
+	}
+}
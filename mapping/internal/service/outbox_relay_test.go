@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/NeF2le/anonix/mapping/internal/ports"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeOutbox keeps the outbox and a cursor per publisher as the Postgres adapter does.
+type fakeOutbox struct {
+	ports.StorageRepository
+
+	events    []*domain.OutboxEvent
+	cursors   map[string]int64
+	published int64
+}
+
+func newFakeOutbox(n int) *fakeOutbox {
+	o := &fakeOutbox{cursors: make(map[string]int64)}
+	for i := 1; i <= n; i++ {
+		o.events = append(o.events, &domain.OutboxEvent{ID: int64(i), EventType: domain.EventTypeAuditEntry})
+	}
+	return o
+}
+
+func (o *fakeOutbox) RelayOutboxEvents(
+	_ context.Context,
+	publisher string,
+	limit uint64,
+	publish func(events []*domain.OutboxEvent) (int, error),
+) (int, error) {
+	var batch []*domain.OutboxEvent
+	for _, event := range o.events {
+		if event.ID > o.cursors[publisher] && uint64(len(batch)) < limit {
+			batch = append(batch, event)
+		}
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	accepted, err := publish(batch)
+	if accepted <= 0 {
+		return 0, err
+	}
+	o.cursors[publisher] = batch[accepted-1].ID
+	return accepted, err
+}
+
+func (o *fakeOutbox) MarkOutboxEventsPublished(_ context.Context, publishers []string) (int64, error) {
+	last := o.cursors[publishers[0]]
+	for _, publisher := range publishers {
+		last = min(last, o.cursors[publisher])
+	}
+	n := max(last-o.published, 0)
+	o.published += n
+	return n, nil
+}
+
+// fakePublisher accepts events until limit events have been published in total, and
+// fails from then on. A negative limit accepts everything.
+type fakePublisher struct {
+	name      string
+	limit     int
+	published []int64
+}
+
+func (p *fakePublisher) Name() string { return p.name }
+
+func (p *fakePublisher) Publish(_ context.Context, events []*domain.OutboxEvent) (int, error) {
+	for i, event := range events {
+		if p.limit >= 0 && len(p.published) >= p.limit {
+			return i, errors.New("broker unavailable")
+		}
+		p.published = append(p.published, event.ID)
+	}
+	return len(events), nil
+}
+
+func ids(from, to int64) []int64 {
+	var ids []int64
+	for id := from; id <= to; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	ctx := context.Background()
+	outbox := newFakeOutbox(7)
+	kafka := &fakePublisher{name: "kafka", limit: -1}
+	// The syslog publisher fails in the middle of the second batch.
+	syslog := &fakePublisher{name: "syslog", limit: 4}
+	relay := NewOutboxRelay(outbox, []ports.EventPublisher{kafka, syslog}, 3, 0)
+
+	n, err := relay.Relay(ctx)
+	if err == nil || !strings.HasPrefix(err.Error(), "syslog: ") {
+		t.Fatalf("got %v, want the error of syslog", err)
+	}
+	// A failing publisher does not hold back the others, but events are only marked
+	// published once all publishers accepted them.
+	if !slices.Equal(kafka.published, ids(1, 7)) || !slices.Equal(syslog.published, ids(1, 4)) {
+		t.Errorf("published %v to kafka and %v to syslog", kafka.published, syslog.published)
+	}
+	if n != 4 {
+		t.Errorf("marked %d events published, want 4", n)
+	}
+
+	// Once the publisher recovers, it continues after the last event it accepted.
+	syslog.limit = -1
+	if n, err = relay.Relay(ctx); err != nil || n != 3 {
+		t.Fatalf("got %d, %v, want the 3 remaining events", n, err)
+	}
+	if !slices.Equal(kafka.published, ids(1, 7)) || !slices.Equal(syslog.published, ids(1, 7)) {
+		t.Errorf("published %v to kafka and %v to syslog", kafka.published, syslog.published)
+	}
+
+	if n, err = relay.Relay(ctx); err != nil || n != 0 {
+		t.Errorf("drained outbox: got %d, %v", n, err)
+	}
+}
+
+func TestOutboxRelay_FullBatches(t *testing.T) {
+	// The outbox holds exactly two batches; the relay stops at the empty third one.
+	outbox := newFakeOutbox(4)
+	kafka := &fakePublisher{name: "kafka", limit: -1}
+	relay := NewOutboxRelay(outbox, []ports.EventPublisher{kafka}, 2, 0)
+
+	if n, err := relay.Relay(context.Background()); err != nil || n != 4 {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if !slices.Equal(kafka.published, ids(1, 4)) {
+		t.Errorf("published %v", kafka.published)
+	}
+}
@@ -2,20 +2,46 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	errs "github.com/NeF2le/anonix/common/errors"
 	"github.com/NeF2le/anonix/common/logger"
 	"github.com/NeF2le/anonix/mapping/internal/domain"
 	"github.com/NeF2le/anonix/mapping/internal/ports"
 	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
 	"time"
 )
 
+// loadTimeout bounds a storage load shared by concurrent requests, which runs detached
+// from the request that started it.
+const loadTimeout = 10 * time.Second
+
 type MappingService struct {
 	storage  ports.StorageRepository
 	cache    ports.CacheRepository
+	signer   ports.Signer
 	cacheTtl time.Duration
+
+	// verifiers check signatures by the name of their key: the signer and the keys that
+	// signed before it.
+	verifiers map[string]ports.Verifier
+
+	// negativeCacheTtl is how long unknown tokens are remembered; 0 disables it.
+	negativeCacheTtl time.Duration
+	// earlyRefreshBeta scales probabilistic early refresh of cache entries; 0 disables it.
+	earlyRefreshBeta float64
+
+	// group coalesces concurrent storage loads and expiry deletions of the same mapping.
+	group singleflight.Group
+	// fillTime is the duration of the last storage load in nanoseconds, used to decide
+	// when a cache entry is close enough to expiry to be refreshed early.
+	fillTime atomic.Int64
 }
 
 // isExpired reports whether mapping has outlived its TTL. A TokenTtl of 0 means the
@@ -24,18 +50,132 @@ func isExpired(mapping *domain.Mapping) bool {
 	return mapping.TokenTtl != 0 && mapping.CreatedAt.Add(mapping.TokenTtl).Before(time.Now())
 }
 
+// NewMappingService creates the service. previousKeys verify what was signed before
+// the signer took over, such as checkpoints signed before a key change.
 func NewMappingService(
 	storage ports.StorageRepository,
 	cache ports.CacheRepository,
+	signer ports.Signer,
+	previousKeys []ports.Verifier,
 	cacheTTL time.Duration,
+	negativeCacheTTL time.Duration,
+	earlyRefreshBeta float64,
 ) *MappingService {
+	verifiers := make(map[string]ports.Verifier, len(previousKeys)+1)
+	for _, verifier := range previousKeys {
+		verifiers[verifier.KeyName()] = verifier
+	}
+	verifiers[signer.KeyName()] = signer
+
 	return &MappingService{
-		storage:  storage,
-		cache:    cache,
-		cacheTtl: cacheTTL,
+		storage:          storage,
+		cache:            cache,
+		signer:           signer,
+		verifiers:        verifiers,
+		cacheTtl:         cacheTTL,
+		negativeCacheTtl: negativeCacheTTL,
+		earlyRefreshBeta: earlyRefreshBeta,
 	}
 }
 
+// loadMapping loads a mapping from storage and refills the cache with it. Concurrent
+// loads sharing the same key are coalesced into a single storage query. The query does
+// not depend on the context of any one caller, so a cancelled request fails alone
+// instead of failing every request waiting for the same mapping.
+func (m *MappingService) loadMapping(
+	ctx context.Context,
+	key string,
+	load func(ctx context.Context) (*domain.Mapping, error),
+) (*domain.Mapping, error) {
+	ch := m.group.DoChan("load:"+key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		start := time.Now()
+		mapping, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		m.fillTime.Store(int64(time.Since(start)))
+
+		if isExpired(mapping) {
+			return mapping, nil
+		}
+		if err = m.cache.SaveMapping(ctx, mapping, m.cacheTtl); err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to save mapping in cache",
+				slog.String("id", mapping.ID.String()),
+				logger.Err(err))
+		} else {
+			logger.GetLoggerFromCtx(ctx).Debug(ctx, "saved mapping in cache",
+				slog.String("id", mapping.ID.String()))
+		}
+		return mapping, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*domain.Mapping), nil
+	}
+}
+
+// refreshEarly reloads a cached mapping in the background if its cache entry is about
+// to expire. The probability grows as the remaining ttl approaches the time a storage
+// load takes (XFetch), so hot entries are refreshed by a single request before they
+// expire instead of by every request right after.
+func (m *MappingService) refreshEarly(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+	load func(ctx context.Context) (*domain.Mapping, error),
+) {
+	if m.earlyRefreshBeta <= 0 || ttl <= 0 {
+		return
+	}
+	delta := float64(m.fillTime.Load())
+	if delta*m.earlyRefreshBeta*-math.Log(1-rand.Float64()) < float64(ttl) {
+		return
+	}
+
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		if _, err := m.loadMapping(ctx, key, load); err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to refresh mapping in cache",
+				slog.String("key", key),
+				logger.Err(err))
+		}
+	}()
+}
+
+// expireMapping deletes an expired mapping from storage and cache. Mappings under legal
+// hold are kept. Concurrent calls for the same mapping are coalesced.
+func (m *MappingService) expireMapping(ctx context.Context, id uuid.UUID) {
+	_, _, _ = m.group.Do("expire:"+id.String(), func() (interface{}, error) {
+		logger.GetLoggerFromCtx(ctx).Debug(ctx, "mapping expired",
+			slog.String("id", id.String()))
+		cause := domain.DestructionCause{Reason: domain.DestructionReasonTTL, Initiator: domain.DestructionInitiatorTTL}
+		if err := m.storage.DeleteMappingById(ctx, id, cause); err != nil {
+			if errors.Is(err, errs.ErrMappingOnHold) {
+				logger.GetLoggerFromCtx(ctx).Debug(ctx, "expired mapping is under legal hold, keeping it",
+					slog.String("id", id.String()))
+				return nil, nil
+			}
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from storage",
+				slog.String("id", id.String()),
+				logger.Err(err))
+		}
+		if err := m.cache.DeleteMappingById(ctx, id); err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from cache",
+				slog.String("id", id.String()),
+				logger.Err(err))
+		}
+		return nil, nil
+	})
+}
+
 func (m *MappingService) CreateMapping(ctx context.Context, mapping *domain.Mapping) (*domain.Mapping, error) {
 	result, err := m.storage.InsertMapping(ctx, mapping)
 	if err != nil {
@@ -61,8 +201,9 @@ func (m *MappingService) CreateMapping(ctx context.Context, mapping *domain.Mapp
 	return result, nil
 }
 
-func (m *MappingService) DeleteMappingById(ctx context.Context, id uuid.UUID) error {
-	err := m.storage.DeleteMappingById(ctx, id)
+func (m *MappingService) DeleteMappingById(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	cause := domain.DestructionCause{Reason: domain.DestructionReasonManual, Initiator: userID.String()}
+	err := m.storage.DeleteMappingById(ctx, id, cause)
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping from storage",
 			slog.String("id", id.String()),
@@ -80,118 +221,78 @@ func (m *MappingService) DeleteMappingById(ctx context.Context, id uuid.UUID) er
 }
 
 func (m *MappingService) GetMappingById(ctx context.Context, id uuid.UUID) (*domain.Mapping, error) {
-	mapping, err := m.cache.GetMappingById(ctx, id)
+	load := func(ctx context.Context) (*domain.Mapping, error) {
+		return m.storage.SelectMappingById(ctx, id)
+	}
+
+	mapping, ttl, err := m.cache.GetMappingById(ctx, id)
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get mapping by id from cache", logger.Err(err))
 	}
 
 	if mapping != nil {
-		logger.GetLoggerFromCtx(ctx).Debug(ctx, "check mapping TTL",
-			slog.String("id", id.String()),
-			slog.String("now", time.Now().Format("2006-01-02 15:04:05")),
-			slog.String("created at", mapping.CreatedAt.Format("2006-01-02 15:04:05")),
-			slog.String("ttl", mapping.TokenTtl.String()),
-			slog.String("time to delete", mapping.CreatedAt.Add(mapping.TokenTtl).Format("2006-01-02 15:04:05")))
-	}
-
-	if mapping != nil && isExpired(mapping) {
-		logger.GetLoggerFromCtx(ctx).Debug(ctx, "mapping expired",
-			slog.String("id", id.String()))
-		err = m.storage.DeleteMappingById(ctx, id)
-		if err != nil {
-			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from storage",
-				slog.String("id", id.String()),
-				logger.Err(err))
-		}
-		err = m.cache.DeleteMappingById(ctx, id)
-		if err != nil {
-			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from cache",
-				slog.String("id", id.String()),
-				logger.Err(err))
-		}
-		return nil, errs.ErrMappingExpired
-	}
-
-	if mapping == nil {
-		mapping, err = m.storage.SelectMappingById(ctx, id)
+		m.refreshEarly(ctx, "id:"+id.String(), ttl, load)
+	} else {
+		mapping, err = m.loadMapping(ctx, "id:"+id.String(), load)
 		if err != nil {
 			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get mapping by id from storage",
 				slog.String("id", id.String()),
 				logger.Err(err))
 			return nil, err
 		}
+	}
 
-		if isExpired(mapping) {
-			logger.GetLoggerFromCtx(ctx).Debug(ctx, "mapping expired",
-				slog.String("id", id.String()))
-			err = m.storage.DeleteMappingById(ctx, id)
-			if err != nil {
-				logger.GetLoggerFromCtx(ctx).Debug(ctx, "failed to delete mapping by id from storage",
-					slog.String("id", id.String()),
-					logger.Err(err))
-			}
-			err = m.cache.DeleteMappingById(ctx, id)
-			if err != nil {
-				logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from cache",
-					slog.String("id", id.String()),
-					logger.Err(err))
-			}
-			return nil, errs.ErrMappingExpired
-		}
-
-		if err = m.cache.DeleteMappingById(ctx, id); err != nil {
-			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from cache",
-				slog.String("id", id.String()),
-				logger.Err(err))
-		}
-		if err = m.cache.SaveMapping(ctx, mapping, m.cacheTtl); err != nil {
-			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to save mapping in cache",
-				slog.String("id", id.String()),
-				logger.Err(err))
-		} else {
-			logger.GetLoggerFromCtx(ctx).Info(ctx, "saved mapping in cache",
-				slog.String("id", id.String()))
-		}
+	if isExpired(mapping) {
+		m.expireMapping(ctx, mapping.ID)
+		return nil, errs.ErrMappingExpired
 	}
 
 	return mapping, nil
 }
 
 func (m *MappingService) GetMappingByToken(ctx context.Context, token string) (*domain.Mapping, error) {
-	mapping, err := m.storage.SelectMappingByToken(ctx, token)
-	if err != nil {
-		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get mapping by token from storage",
-			logger.Err(err))
-		return nil, err
+	load := func(ctx context.Context) (*domain.Mapping, error) {
+		return m.storage.SelectMappingByToken(ctx, token)
 	}
 
-	if isExpired(mapping) {
-		logger.GetLoggerFromCtx(ctx).Debug(ctx, "mapping expired",
-			slog.String("id", mapping.ID.String()))
-		if err = m.storage.DeleteMappingById(ctx, mapping.ID); err != nil {
-			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from storage",
-				slog.String("id", mapping.ID.String()),
-				logger.Err(err))
+	mapping, ttl, err := m.cache.GetMappingByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, errs.ErrMappingNotFound) {
+			return nil, err
 		}
-		if err = m.cache.DeleteMappingById(ctx, mapping.ID); err != nil {
-			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from cache",
-				slog.String("id", mapping.ID.String()),
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get mapping by token from cache", logger.Err(err))
+	}
+
+	if mapping != nil {
+		m.refreshEarly(ctx, "token:"+token, ttl, load)
+	} else {
+		mapping, err = m.loadMapping(ctx, "token:"+token, load)
+		if err != nil {
+			if errors.Is(err, errs.ErrMappingNotFound) && m.negativeCacheTtl > 0 {
+				if err := m.cache.SaveMissingToken(ctx, token, m.negativeCacheTtl); err != nil {
+					logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to save missing token in cache",
+						logger.Err(err))
+				}
+			}
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get mapping by token from storage",
 				logger.Err(err))
+			return nil, err
 		}
-		return nil, errs.ErrMappingExpired
 	}
 
-	if err = m.cache.SaveMapping(ctx, mapping, m.cacheTtl); err != nil {
-		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to save mapping in cache",
-			slog.String("id", mapping.ID.String()),
-			logger.Err(err))
+	if isExpired(mapping) {
+		m.expireMapping(ctx, mapping.ID)
+		return nil, errs.ErrMappingExpired
 	}
 
 	return mapping, nil
 }
 
-func (m *MappingService) GetAllMappings(ctx context.Context) ([]*domain.Mapping, error) {
-	mappings, err := m.storage.SelectAllMappings(ctx)
+// GetAllMappings returns every mapping whose kind is visible at maxAccessLevel.
+// Mappings without a kind are always visible; a maxAccessLevel of 0 disables the filter
+// and a negative one hides every kind.
+func (m *MappingService) GetAllMappings(ctx context.Context, maxAccessLevel int32) ([]*domain.Mapping, error) {
+	mappings, err := m.storage.SelectAllMappings(ctx, maxAccessLevel)
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get mapping list", logger.Err(err))
 		return nil, err
@@ -199,11 +300,62 @@ func (m *MappingService) GetAllMappings(ctx context.Context) ([]*domain.Mapping,
 	return mappings, nil
 }
 
+// GetSubjectMappings returns the data subject's mappings visible at maxAccessLevel.
+func (m *MappingService) GetSubjectMappings(ctx context.Context, subjectRef string, maxAccessLevel int32) ([]*domain.Mapping, error) {
+	mappings, err := m.storage.SelectMappingsBySubject(ctx, subjectRef, maxAccessLevel)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to get subject mappings", logger.Err(err))
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// EraseSubject deletes every mapping of the data subject in a single transaction,
+// which also records an audit entry per erased token, and then evicts them from the
+// cache. ids are the mappings the caller checked; if the subject has any other,
+// nothing is erased. Erasing a subject without mappings yields an empty report.
+func (m *MappingService) EraseSubject(
+	ctx context.Context,
+	subjectRef string,
+	ids []uuid.UUID,
+	userID uuid.UUID,
+) (*domain.ErasureReport, error) {
+	erased, err := m.storage.DeleteMappingsBySubject(ctx, subjectRef, ids, userID)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to erase subject mappings", logger.Err(err))
+		return nil, err
+	}
+
+	for _, mapping := range erased {
+		if err = m.cache.DeleteMapping(ctx, mapping.ID, mapping.Token); err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete erased mapping from cache",
+				slog.String("id", mapping.ID.String()),
+				logger.Err(err))
+		}
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx, "data subject erased",
+		slog.String("user id", userID.String()),
+		slog.Int("erased", len(erased)))
+
+	return &domain.ErasureReport{
+		SubjectRef: subjectRef,
+		ErasedBy:   userID,
+		ErasedAt:   time.Now(),
+		Erased:     erased,
+	}, nil
+}
+
 func (m *MappingService) UpdateMapping(ctx context.Context, id uuid.UUID, tokenTtl time.Duration) (*domain.Mapping, error) {
 	mapping, err := m.storage.UpdateMapping(ctx, id, tokenTtl)
 	if err != nil {
 		return nil, err
 	}
+	if err = m.cache.DeleteMappingById(ctx, id); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to delete mapping by id from cache",
+			slog.String("id", id.String()),
+			logger.Err(err))
+	}
 	if err = m.cache.SaveMapping(ctx, mapping, m.cacheTtl); err != nil {
 		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to save mapping in cache",
 			slog.String("id", id.String()),
@@ -216,6 +368,32 @@ func (m *MappingService) UpdateMapping(ctx context.Context, id uuid.UUID, tokenT
 	return mapping, nil
 }
 
+// InvalidateCache evicts cache entries affected by a change notification. Cached
+// mappings embed their kind, so a kind change drops the whole mapping cache.
+func (m *MappingService) InvalidateCache(ctx context.Context, inv *domain.CacheInvalidation) {
+	switch inv.Table {
+	case domain.InvalidationTableMappings:
+		if err := m.cache.DeleteMapping(ctx, inv.MappingID, inv.Token); err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to invalidate mapping in cache",
+				slog.String("id", inv.MappingID.String()),
+				logger.Err(err))
+		}
+	case domain.InvalidationTableKinds:
+		logger.GetLoggerFromCtx(ctx).Debug(ctx, "kind changed, dropping mapping cache",
+			slog.Int("kind id", int(inv.KindID)),
+			slog.String("op", inv.Op))
+		m.ResyncCache(ctx)
+	}
+}
+
+// ResyncCache drops every cached mapping. It is used when invalidation notifications
+// may have been missed.
+func (m *MappingService) ResyncCache(ctx context.Context) {
+	if err := m.cache.DeleteAllMappings(ctx); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "failed to drop mapping cache", logger.Err(err))
+	}
+}
+
 func (m *MappingService) CreateKind(ctx context.Context, kind *domain.Kind) (*domain.Kind, error) {
 	result, err := m.storage.CreateKind(ctx, kind)
 	if err != nil {
@@ -298,6 +476,141 @@ func (m *MappingService) DeleteKindById(ctx context.Context, id int32) error {
 	return nil
 }
 
+func (m *MappingService) CreatePurpose(ctx context.Context, purpose *domain.Purpose) (*domain.Purpose, error) {
+	result, err := m.storage.CreatePurpose(ctx, purpose)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to insert purpose",
+			slog.String("name", purpose.Name),
+			logger.Err(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *MappingService) GetAllPurposes(ctx context.Context) ([]*domain.Purpose, error) {
+	purposes, err := m.storage.GetAllPurposes(ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to get purposes list",
+			logger.Err(err))
+		return nil, err
+	}
+
+	return purposes, nil
+}
+
+func (m *MappingService) DeletePurposeById(ctx context.Context, id int32) error {
+	err := m.storage.DeletePurposeById(ctx, id)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to delete purpose",
+			slog.Int("id", int(id)),
+			logger.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (m *MappingService) CreateLegalHold(ctx context.Context, hold *domain.LegalHold) (*domain.LegalHold, error) {
+	result, err := m.storage.CreateLegalHold(ctx, hold)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to create legal hold",
+			slog.String("name", hold.Name),
+			logger.Err(err))
+		return nil, err
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx, "legal hold created",
+		slog.String("id", result.ID.String()),
+		slog.String("user id", hold.CreatedBy.String()))
+
+	return result, nil
+}
+
+func (m *MappingService) GetLegalHolds(ctx context.Context, activeOnly bool) ([]*domain.LegalHold, error) {
+	holds, err := m.storage.GetLegalHolds(ctx, activeOnly)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to get legal holds",
+			logger.Err(err))
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (m *MappingService) ReleaseLegalHold(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.LegalHold, error) {
+	hold, err := m.storage.ReleaseLegalHold(ctx, id, userID)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to release legal hold",
+			slog.String("id", id.String()),
+			logger.Err(err))
+		return nil, err
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx, "legal hold released",
+		slog.String("id", id.String()),
+		slog.String("user id", userID.String()))
+
+	return hold, nil
+}
+
+// GetDestructionAct builds the act of destroyed mappings for [from, to), grouped by kind,
+// and signs its digest.
+func (m *MappingService) GetDestructionAct(ctx context.Context, from, to time.Time, userID uuid.UUID) (*domain.DestructionAct, error) {
+	events, err := m.storage.SelectDestructionEvents(ctx, from, to)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to select destruction events",
+			logger.Err(err))
+		return nil, err
+	}
+
+	act := &domain.DestructionAct{
+		PeriodFrom:  from,
+		PeriodTo:    to,
+		GeneratedAt: time.Now(),
+		GeneratedBy: userID,
+		Total:       len(events),
+		SigningKey:  m.signer.KeyName(),
+	}
+	for _, event := range events {
+		last := len(act.Groups) - 1
+		if last < 0 || act.Groups[last].KindID != event.KindID {
+			act.Groups = append(act.Groups, &domain.DestructionActGroup{
+				KindID:          event.KindID,
+				KindName:        event.KindName,
+				KindRussianName: event.KindRussianName,
+			})
+			last++
+		}
+		act.Groups[last].Events = append(act.Groups[last].Events, event)
+	}
+
+	digest := sha256.Sum256(act.Canonical())
+	act.Digest = hex.EncodeToString(digest[:])
+	act.Signature, err = m.signer.Sign(ctx, []byte(act.Digest))
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to sign destruction act",
+			logger.Err(err))
+		return nil, err
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx, "destruction act generated",
+		slog.String("from", from.Format(time.RFC3339)),
+		slog.String("to", to.Format(time.RFC3339)),
+		slog.Int("total", act.Total),
+		slog.String("user id", userID.String()))
+
+	return act, nil
+}
+
 func (m *MappingService) CreateAuditLog(ctx context.Context, entry *domain.AuditLogEntry) (*domain.AuditLogEntry, error) {
 	result, err := m.storage.CreateAuditLog(ctx, entry)
 	if err != nil {
@@ -310,16 +623,56 @@ func (m *MappingService) CreateAuditLog(ctx context.Context, entry *domain.Audit
 	return result, nil
 }
 
-func (m *MappingService) GetAuditLogList(ctx context.Context) ([]*domain.AuditLogEntry, error) {
-	entries, err := m.storage.GetAuditLogList(ctx)
+// GetAuditLogList returns a page of audit log entries and the cursor of the next page,
+// which is nil on the last page.
+func (m *MappingService) GetAuditLogList(ctx context.Context, query *domain.AuditLogQuery) (
+	[]*domain.AuditLogEntry, *domain.AuditLogCursor, error) {
+	limit := query.Limit
+	query.Limit++
+	entries, err := m.storage.GetAuditLogList(ctx, query)
+	query.Limit = limit
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Warn(ctx,
 			"failed to get audit log list",
 			logger.Err(err))
-		return nil, err
+		return nil, nil, err
+	}
+
+	if len(entries) <= limit {
+		return entries, nil, nil
+	}
+	entries = entries[:limit]
+	return entries, query.CursorFor(entries[limit-1]), nil
+}
+
+// ExportAuditLog records the export in the audit log and then streams the entries
+// matching filter to fn. Nothing is exported if the export cannot be audited.
+func (m *MappingService) ExportAuditLog(
+	ctx context.Context,
+	filter *domain.AuditLogFilter,
+	userID uuid.UUID,
+	fn func(entry *domain.AuditLogEntry) error,
+) error {
+	_, err := m.storage.CreateAuditLog(ctx, &domain.AuditLogEntry{
+		UserID: userID,
+		Action: domain.AuditActionExport,
+	})
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to audit audit log export",
+			logger.Err(err))
+		return err
 	}
 
-	return entries, nil
+	if err = m.storage.WalkAuditLog(ctx, filter, fn); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to export audit log",
+			slog.String("user id", userID.String()),
+			logger.Err(err))
+		return err
+	}
+
+	return nil
 }
 
 func (m *MappingService) UpdateMappingDek(ctx context.Context, id uuid.UUID, dekWrapped []byte) error {
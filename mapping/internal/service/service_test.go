@@ -0,0 +1,827 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	errs "github.com/NeF2le/anonix/common/errors"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/NeF2le/anonix/mapping/internal/ports"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeStorage keeps mappings by token. Loads wait for release while it is set, so
+// concurrent loads can be held open. Methods the tests do not call are left to the nil
+// interface.
+type fakeStorage struct {
+	ports.StorageRepository
+
+	mu       sync.Mutex
+	mappings map[string]*domain.Mapping
+	release  chan struct{}
+	loads    atomic.Int32
+	// deleteErr fails deletions, such as errs.ErrMappingOnHold for held mappings.
+	deleteErr error
+	// causes lists the causes of the single mapping deletions, failed ones included.
+	causes []domain.DestructionCause
+	events []*domain.DestructionEvent
+	// audit holds the audit log in chain order; auditErr fails the entries created.
+	audit    []*domain.AuditLogEntry
+	auditErr error
+}
+
+func newFakeStorage(mappings ...*domain.Mapping) *fakeStorage {
+	s := &fakeStorage{mappings: make(map[string]*domain.Mapping)}
+	for _, mapping := range mappings {
+		s.mappings[mapping.Token] = mapping
+	}
+	return s
+}
+
+func (s *fakeStorage) SelectMappingByToken(ctx context.Context, token string) (*domain.Mapping, error) {
+	s.loads.Add(1)
+	if s.release != nil {
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mapping, ok := s.mappings[token]
+	if !ok {
+		return nil, errs.ErrMappingNotFound
+	}
+	return mapping, nil
+}
+
+func (s *fakeStorage) DeleteMappingById(_ context.Context, id uuid.UUID, cause domain.DestructionCause) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.causes = append(s.causes, cause)
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	for token, mapping := range s.mappings {
+		if mapping.ID == id {
+			delete(s.mappings, token)
+		}
+	}
+	return nil
+}
+
+func (s *fakeStorage) SelectDestructionEvents(context.Context, time.Time, time.Time) ([]*domain.DestructionEvent, error) {
+	return s.events, nil
+}
+
+func (s *fakeStorage) CreateAuditLog(_ context.Context, entry *domain.AuditLogEntry) (*domain.AuditLogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.auditErr != nil {
+		return nil, s.auditErr
+	}
+	entry.Seq = int64(len(s.audit) + 1)
+	s.audit = append(s.audit, entry)
+	return entry, nil
+}
+
+// GetAuditLogList pages through the audit log in chain order, as the Postgres adapter
+// does when sorting by time.
+func (s *fakeStorage) GetAuditLogList(_ context.Context, query *domain.AuditLogQuery) ([]*domain.AuditLogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var page []*domain.AuditLogEntry
+	for _, entry := range s.audit {
+		if query.After != nil && entry.Seq <= query.After.Seq {
+			continue
+		}
+		if len(page) == query.Limit {
+			break
+		}
+		page = append(page, entry)
+	}
+	return page, nil
+}
+
+func (s *fakeStorage) WalkAuditLog(_ context.Context, _ *domain.AuditLogFilter, fn func(entry *domain.AuditLogEntry) error) error {
+	s.mu.Lock()
+	entries := slices.Clone(s.audit)
+	s.mu.Unlock()
+	for _, entry := range entries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteMappingsBySubject erases the subject's mappings if ids lists each of them, as
+// the transaction of the Postgres adapter does.
+func (s *fakeStorage) DeleteMappingsBySubject(_ context.Context, subjectRef string, ids []uuid.UUID, _ uuid.UUID) ([]*domain.Mapping, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deleteErr != nil {
+		return nil, s.deleteErr
+	}
+
+	var erased []*domain.Mapping
+	for _, mapping := range s.mappings {
+		if mapping.SubjectRef != subjectRef {
+			continue
+		}
+		if !slices.Contains(ids, mapping.ID) {
+			return nil, errs.ErrSubjectChanged
+		}
+		erased = append(erased, mapping)
+	}
+	for _, mapping := range erased {
+		delete(s.mappings, mapping.Token)
+	}
+	return erased, nil
+}
+
+// fakeCache keeps mappings by token and reports ttl as the remaining lifetime of every
+// entry.
+type fakeCache struct {
+	ports.CacheRepository
+
+	mu       sync.Mutex
+	ttl      time.Duration
+	mappings map[string]*domain.Mapping
+	missing  map[string]time.Duration
+	lookups  int
+	saved    chan *domain.Mapping
+	// deleted lists the deleted mappings as "id token", or "id" when deleted by id, and
+	// "*" for the whole cache.
+	deleted []string
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{
+		mappings: make(map[string]*domain.Mapping),
+		missing:  make(map[string]time.Duration),
+		saved:    make(chan *domain.Mapping, 16),
+	}
+}
+
+func (c *fakeCache) GetMappingByToken(_ context.Context, token string) (*domain.Mapping, time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lookups++
+	if _, ok := c.missing[token]; ok {
+		return nil, 0, errs.ErrMappingNotFound
+	}
+	if mapping, ok := c.mappings[token]; ok {
+		return mapping, c.ttl, nil
+	}
+	return nil, 0, nil
+}
+
+func (c *fakeCache) SaveMapping(_ context.Context, mapping *domain.Mapping, _ time.Duration) error {
+	c.mu.Lock()
+	c.mappings[mapping.Token] = mapping
+	delete(c.missing, mapping.Token)
+	c.mu.Unlock()
+	c.saved <- mapping
+	return nil
+}
+
+func (c *fakeCache) SaveMissingToken(_ context.Context, token string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.missing[token] = ttl
+	return nil
+}
+
+func (c *fakeCache) DeleteMapping(_ context.Context, id uuid.UUID, token string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.mappings, token)
+	c.deleted = append(c.deleted, id.String()+" "+token)
+	return nil
+}
+
+func (c *fakeCache) DeleteMappingById(_ context.Context, id uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for token, mapping := range c.mappings {
+		if mapping.ID == id {
+			delete(c.mappings, token)
+		}
+	}
+	c.deleted = append(c.deleted, id.String())
+	return nil
+}
+
+func (c *fakeCache) DeleteAllMappings(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.mappings)
+	c.deleted = append(c.deleted, "*")
+	return nil
+}
+
+func (c *fakeCache) lookupCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookups
+}
+
+// fakeSigner signs data by prefixing it, or fails with err.
+type fakeSigner struct {
+	ports.Signer
+	err error
+}
+
+func (fakeSigner) KeyName() string { return "test" }
+
+func (s fakeSigner) Sign(_ context.Context, data []byte) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	return "signed:" + string(data), nil
+}
+
+func newService(storage *fakeStorage, cache *fakeCache, negativeCacheTTL time.Duration, earlyRefreshBeta float64) *MappingService {
+	return NewMappingService(storage, cache, fakeSigner{}, nil, time.Hour, negativeCacheTTL, earlyRefreshBeta)
+}
+
+func newMapping(token string) *domain.Mapping {
+	return &domain.Mapping{ID: uuid.New(), Token: token, CreatedAt: time.Now()}
+}
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGetMappingByToken_CoalescesLoads(t *testing.T) {
+	const callers = 8
+	storage := newFakeStorage(newMapping("fio_1"))
+	storage.release = make(chan struct{})
+	cache := newFakeCache()
+	m := newService(storage, cache, 0, 0)
+
+	// The first caller is cancelled while the shared load is in flight; it fails alone.
+	cancelled, cancel := context.WithCancel(context.Background())
+	results := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		ctx := context.Background()
+		if i == 0 {
+			ctx = cancelled
+		}
+		go func() {
+			mapping, err := m.GetMappingByToken(ctx, "fio_1")
+			if err == nil && mapping.Token != "fio_1" {
+				err = errors.New("got mapping of token " + mapping.Token)
+			}
+			results <- err
+		}()
+	}
+
+	waitFor(t, "every caller to miss the cache", func() bool { return cache.lookupCount() == callers })
+	// Give the callers time to join the load after their cache miss.
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	close(storage.release)
+
+	var failed int
+	for i := 0; i < callers; i++ {
+		if err := <-results; err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("got %v", err)
+			}
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("%d callers failed, want only the cancelled one", failed)
+	}
+	if n := storage.loads.Load(); n != 1 {
+		t.Errorf("storage loaded %d times, want once", n)
+	}
+	if _, ok := cache.mappings["fio_1"]; !ok {
+		t.Error("loaded mapping not saved in cache")
+	}
+}
+
+func TestGetMappingByToken_NegativeCache(t *testing.T) {
+	tests := []struct {
+		name             string
+		negativeCacheTTL time.Duration
+		wantLoads        int32
+	}{
+		{"enabled", time.Minute, 1},
+		{"disabled", 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newFakeStorage()
+			cache := newFakeCache()
+			m := newService(storage, cache, tt.negativeCacheTTL, 0)
+
+			for i := 0; i < 2; i++ {
+				if _, err := m.GetMappingByToken(context.Background(), "fio_1"); !errors.Is(err, errs.ErrMappingNotFound) {
+					t.Fatalf("got %v, want ErrMappingNotFound", err)
+				}
+			}
+			if n := storage.loads.Load(); n != tt.wantLoads {
+				t.Errorf("storage loaded %d times, want %d", n, tt.wantLoads)
+			}
+			if ttl, ok := cache.missing["fio_1"]; ok != (tt.negativeCacheTTL > 0) || ttl != tt.negativeCacheTTL {
+				t.Errorf("missing token remembered for %v (%v), want %v", ttl, ok, tt.negativeCacheTTL)
+			}
+		})
+	}
+}
+
+func TestGetMappingByToken_RefreshEarly(t *testing.T) {
+	tests := []struct {
+		name        string
+		beta        float64
+		fillTime    time.Duration
+		ttl         time.Duration
+		wantRefresh bool
+	}{
+		{"disabled", 0, time.Hour, time.Millisecond, false},
+		{"entry without expiry", 1, time.Hour, 0, false},
+		// The probability is exp(-ttl / (fillTime * beta)).
+		{"far from expiry", 1, time.Millisecond, time.Hour, false},
+		{"about to expire", 1, time.Hour, time.Millisecond, true},
+		{"larger beta", 1e9, time.Millisecond, time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := newMapping("fio_1")
+			storage := newFakeStorage(mapping)
+			cache := newFakeCache()
+			cache.mappings["fio_1"] = mapping
+			cache.ttl = tt.ttl
+			m := newService(storage, cache, 0, tt.beta)
+			m.fillTime.Store(int64(tt.fillTime))
+
+			got, err := m.GetMappingByToken(context.Background(), "fio_1")
+			if err != nil || got != mapping {
+				t.Fatalf("got %v, %v, want the cached mapping", got, err)
+			}
+
+			select {
+			case <-cache.saved:
+				if !tt.wantRefresh {
+					t.Error("entry refreshed")
+				}
+			case <-time.After(50 * time.Millisecond):
+				if tt.wantRefresh {
+					t.Error("entry not refreshed")
+				}
+			}
+			var wantLoads int32
+			if tt.wantRefresh {
+				wantLoads = 1
+			}
+			if n := storage.loads.Load(); n != wantLoads {
+				t.Errorf("storage loaded %d times, want %d", n, wantLoads)
+			}
+		})
+	}
+}
+
+// The payloads are those built by the invalidation triggers of migration 000008.
+func TestInvalidateCache(t *testing.T) {
+	id := uuid.MustParse("6f1c2b7e-3a4d-4e5f-8a9b-0c1d2e3f4a5b")
+
+	tests := []struct {
+		name        string
+		payload     string
+		wantDeleted []string
+	}{
+		{"mapping updated",
+			`{"table" : "mappings", "op" : "UPDATE", "mapping_id" : "6f1c2b7e-3a4d-4e5f-8a9b-0c1d2e3f4a5b", "token" : "fio_1"}`,
+			[]string{id.String() + " fio_1"}},
+		{"mapping deleted",
+			`{"table" : "mappings", "op" : "DELETE", "mapping_id" : "6f1c2b7e-3a4d-4e5f-8a9b-0c1d2e3f4a5b", "token" : "fio_1"}`,
+			[]string{id.String() + " fio_1"}},
+		// Cached mappings embed their kind.
+		{"kind updated", `{"table" : "kinds", "op" : "UPDATE", "kind_id" : 3}`, []string{"*"}},
+		{"kind deleted", `{"table" : "kinds", "op" : "DELETE", "kind_id" : 3}`, []string{"*"}},
+		{"other table", `{"table" : "purposes", "op" : "DELETE"}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newFakeCache()
+			m := newService(newFakeStorage(), cache, 0, 0)
+
+			var inv domain.CacheInvalidation
+			if err := json.Unmarshal([]byte(tt.payload), &inv); err != nil {
+				t.Fatal(err)
+			}
+			m.InvalidateCache(context.Background(), &inv)
+
+			if !slices.Equal(cache.deleted, tt.wantDeleted) {
+				t.Errorf("deleted %v, want %v", cache.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestResyncCache(t *testing.T) {
+	cache := newFakeCache()
+	cache.mappings["fio_1"] = newMapping("fio_1")
+	m := newService(newFakeStorage(), cache, 0, 0)
+
+	m.ResyncCache(context.Background())
+
+	if len(cache.mappings) != 0 || !slices.Equal(cache.deleted, []string{"*"}) {
+		t.Errorf("cache left with %v after deleting %v", cache.mappings, cache.deleted)
+	}
+}
+
+func TestEraseSubject(t *testing.T) {
+	userID := uuid.New()
+
+	subjectMapping := func(token, subjectRef string) *domain.Mapping {
+		mapping := newMapping(token)
+		mapping.SubjectRef = subjectRef
+		return mapping
+	}
+	first, second := subjectMapping("fio_1", "subject-1"), subjectMapping("phone_1", "subject-1")
+	other := subjectMapping("fio_2", "subject-2")
+
+	tests := []struct {
+		name       string
+		subjectRef string
+		ids        []uuid.UUID
+		deleteErr  error
+		wantErr    error
+		wantErased []string
+	}{
+		{"every mapping checked", "subject-1", []uuid.UUID{first.ID, second.ID}, nil, nil, []string{"fio_1", "phone_1"}},
+		// A mapping created after the caller checked the subject's mappings.
+		{"unchecked mapping", "subject-1", []uuid.UUID{first.ID}, nil, errs.ErrSubjectChanged, nil},
+		{"mapping under legal hold", "subject-1", []uuid.UUID{first.ID, second.ID}, errs.ErrMappingOnHold, errs.ErrMappingOnHold, nil},
+		{"subject without mappings", "subject-3", nil, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newFakeStorage(first, second, other)
+			storage.deleteErr = tt.deleteErr
+			cache := newFakeCache()
+			m := newService(storage, cache, 0, 0)
+
+			report, err := m.EraseSubject(context.Background(), tt.subjectRef, tt.ids, userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(storage.mappings) != 3 || len(cache.deleted) != 0 {
+					t.Errorf("failed erasure left %d mappings and evicted %v", len(storage.mappings), cache.deleted)
+				}
+				return
+			}
+
+			if report.SubjectRef != tt.subjectRef || report.ErasedBy != userID || report.ErasedAt.IsZero() {
+				t.Errorf("got report %+v", report)
+			}
+			var erased, evicted []string
+			for _, mapping := range report.Erased {
+				erased = append(erased, mapping.Token)
+				evicted = append(evicted, mapping.ID.String()+" "+mapping.Token)
+			}
+			slices.Sort(erased)
+			if !slices.Equal(erased, tt.wantErased) {
+				t.Errorf("erased %v, want %v", erased, tt.wantErased)
+			}
+			slices.Sort(evicted)
+			slices.Sort(cache.deleted)
+			if !slices.Equal(cache.deleted, evicted) {
+				t.Errorf("evicted %v, want %v", cache.deleted, evicted)
+			}
+			if _, ok := storage.mappings["fio_2"]; !ok {
+				t.Error("mapping of another subject erased")
+			}
+		})
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		tokenTtl  time.Duration
+		want      bool
+	}{
+		{"without ttl", time.Now().Add(-24 * time.Hour), 0, false},
+		{"within ttl", time.Now().Add(-time.Minute), time.Hour, false},
+		{"ttl elapsed", time.Now().Add(-time.Hour), time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := &domain.Mapping{CreatedAt: tt.createdAt, TokenTtl: tt.tokenTtl}
+			if got := isExpired(mapping); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMappingByToken_Expired(t *testing.T) {
+	ttlCause := domain.DestructionCause{Reason: domain.DestructionReasonTTL, Initiator: domain.DestructionInitiatorTTL}
+
+	tests := []struct {
+		name      string
+		cached    bool
+		deleteErr error
+		wantKept  bool
+	}{
+		{"cached", true, nil, false},
+		{"loaded from storage", false, nil, false},
+		// A held mapping outlives its TTL; it stays in storage and in the cache and is
+		// reported as expired on every read until the hold is released.
+		{"under legal hold", true, errs.ErrMappingOnHold, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := newMapping("fio_1")
+			mapping.CreatedAt = time.Now().Add(-time.Hour)
+			mapping.TokenTtl = time.Minute
+			storage := newFakeStorage(mapping)
+			storage.deleteErr = tt.deleteErr
+			cache := newFakeCache()
+			if tt.cached {
+				cache.mappings[mapping.Token] = mapping
+			}
+			m := newService(storage, cache, 0, 0)
+
+			if _, err := m.GetMappingByToken(context.Background(), "fio_1"); !errors.Is(err, errs.ErrMappingExpired) {
+				t.Fatalf("got %v, want ErrMappingExpired", err)
+			}
+			if !slices.Equal(storage.causes, []domain.DestructionCause{ttlCause}) {
+				t.Errorf("deleted from storage with causes %v, want %v", storage.causes, ttlCause)
+			}
+			if _, stored := storage.mappings["fio_1"]; stored != tt.wantKept {
+				t.Errorf("kept in storage: %v, want %v", stored, tt.wantKept)
+			}
+			if _, cached := cache.mappings["fio_1"]; cached != tt.wantKept {
+				t.Errorf("kept in cache: %v, want %v", cached, tt.wantKept)
+			}
+			if wantEvicted := !tt.wantKept; slices.Equal(cache.deleted, []string{mapping.ID.String()}) != wantEvicted {
+				t.Errorf("evicted %v", cache.deleted)
+			}
+		})
+	}
+}
+
+func TestDeleteMappingById_UnderLegalHold(t *testing.T) {
+	userID := uuid.New()
+	mapping := newMapping("fio_1")
+	storage := newFakeStorage(mapping)
+	storage.deleteErr = errs.ErrMappingOnHold
+	cache := newFakeCache()
+	cache.mappings[mapping.Token] = mapping
+	m := newService(storage, cache, 0, 0)
+
+	if err := m.DeleteMappingById(context.Background(), mapping.ID, userID); !errors.Is(err, errs.ErrMappingOnHold) {
+		t.Fatalf("got %v, want ErrMappingOnHold", err)
+	}
+	want := domain.DestructionCause{Reason: domain.DestructionReasonManual, Initiator: userID.String()}
+	if !slices.Equal(storage.causes, []domain.DestructionCause{want}) {
+		t.Errorf("deleted with causes %v, want %v", storage.causes, want)
+	}
+	if len(cache.deleted) != 0 || cache.mappings["fio_1"] == nil {
+		t.Errorf("held mapping evicted from the cache: %v", cache.deleted)
+	}
+}
+
+func TestGetDestructionAct(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	event := func(token string, kindID int32, kindName, reason string) *domain.DestructionEvent {
+		return &domain.DestructionEvent{
+			ID:          uuid.New(),
+			MappingID:   uuid.New(),
+			Token:       token,
+			KindID:      kindID,
+			KindName:    kindName,
+			Reason:      reason,
+			Initiator:   domain.DestructionInitiatorTTL,
+			DestroyedAt: from.Add(time.Hour),
+		}
+	}
+	// Storage returns the events ordered by kind.
+	events := []*domain.DestructionEvent{
+		event("token_1", 0, "", domain.DestructionReasonTTL),
+		event("fio_1", 1, "fio", domain.DestructionReasonTTL),
+		event("fio_2", 1, "fio", domain.DestructionReasonManual),
+		event("phone_1", 2, "phone", domain.DestructionReasonErasure),
+	}
+
+	tests := []struct {
+		name       string
+		events     []*domain.DestructionEvent
+		wantGroups map[int32][]string
+	}{
+		{"events of several kinds", events, map[int32][]string{
+			0: {"token_1"},
+			1: {"fio_1", "fio_2"},
+			2: {"phone_1"},
+		}},
+		{"empty period", nil, map[int32][]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newFakeStorage()
+			storage.events = tt.events
+			m := newService(storage, newFakeCache(), 0, 0)
+
+			act, err := m.GetDestructionAct(context.Background(), from, to, userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !act.PeriodFrom.Equal(from) || !act.PeriodTo.Equal(to) || act.GeneratedBy != userID ||
+				act.Total != len(tt.events) || act.SigningKey != "test" {
+				t.Errorf("got act %+v", act)
+			}
+			groups := make(map[int32][]string)
+			for _, group := range act.Groups {
+				if _, ok := groups[group.KindID]; ok {
+					t.Errorf("kind %d split over several groups", group.KindID)
+				}
+				for _, e := range group.Events {
+					if e.KindID != group.KindID {
+						t.Errorf("event of kind %d in the group of kind %d", e.KindID, group.KindID)
+					}
+					groups[group.KindID] = append(groups[group.KindID], e.Token)
+				}
+			}
+			if len(groups) != len(tt.wantGroups) {
+				t.Errorf("got groups %v, want %v", groups, tt.wantGroups)
+			}
+			for kindID, tokens := range tt.wantGroups {
+				if !slices.Equal(groups[kindID], tokens) {
+					t.Errorf("kind %d: got %v, want %v", kindID, groups[kindID], tokens)
+				}
+			}
+
+			// The digest covers the whole act and is what gets signed, so changing any
+			// event afterwards breaks the signature.
+			digest := sha256.Sum256(act.Canonical())
+			if act.Digest != hex.EncodeToString(digest[:]) {
+				t.Errorf("digest %s does not match the canonical act", act.Digest)
+			}
+			if act.Signature != "signed:"+act.Digest {
+				t.Errorf("got signature %q of the digest %s", act.Signature, act.Digest)
+			}
+			if len(act.Groups) > 0 {
+				act.Groups[0].Events[0].Reason = domain.DestructionReasonManual
+				if tampered := sha256.Sum256(act.Canonical()); tampered == digest {
+					t.Error("digest does not cover the destruction reason")
+				}
+			}
+		})
+	}
+}
+
+func TestGetDestructionAct_SignerError(t *testing.T) {
+	signErr := errors.New("vault sealed")
+	m := NewMappingService(newFakeStorage(), newFakeCache(), fakeSigner{err: signErr}, nil, time.Hour, 0, 0)
+
+	act, err := m.GetDestructionAct(context.Background(), time.Now().AddDate(0, -1, 0), time.Now(), uuid.New())
+	if !errors.Is(err, signErr) || act != nil {
+		t.Fatalf("got %+v, %v, want %v", act, err, signErr)
+	}
+}
+
+// newAuditLog returns n entries of the detokenize action in chain order.
+func newAuditLog(n int) []*domain.AuditLogEntry {
+	entries := make([]*domain.AuditLogEntry, n)
+	for i := range entries {
+		entries[i] = &domain.AuditLogEntry{ID: uuid.New(), Action: "detokenize", Seq: int64(i + 1)}
+	}
+	return entries
+}
+
+func TestGetAuditLogList_Pages(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   int
+		wantPages [][]int64
+	}{
+		{"last page partial", 5, [][]int64{{1, 2}, {3, 4}, {5}}},
+		// A page that ends with the last entry has no cursor, so no empty page follows.
+		{"last page full", 4, [][]int64{{1, 2}, {3, 4}}},
+		{"empty log", 0, [][]int64{nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newFakeStorage()
+			storage.audit = newAuditLog(tt.entries)
+			m := newService(storage, newFakeCache(), 0, 0)
+
+			query := &domain.AuditLogQuery{Limit: 2}
+			var pages [][]int64
+			for {
+				entries, cursor, err := m.GetAuditLogList(context.Background(), query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if query.Limit != 2 {
+					t.Fatalf("query limit changed to %d", query.Limit)
+				}
+				var page []int64
+				for _, entry := range entries {
+					page = append(page, entry.Seq)
+				}
+				pages = append(pages, page)
+				if cursor == nil {
+					break
+				}
+				if len(pages) > tt.entries {
+					t.Fatalf("pagination does not end: %v", pages)
+				}
+
+				// The cursor survives the round trip through the API.
+				if query.After, err = domain.DecodeAuditLogCursor(cursor.Encode()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if !slices.EqualFunc(pages, tt.wantPages, slices.Equal) {
+				t.Errorf("got pages %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestExportAuditLog(t *testing.T) {
+	userID := uuid.New()
+	storageErr := errors.New("connection refused")
+	writeErr := errors.New("client gone")
+
+	tests := []struct {
+		name         string
+		auditErr     error
+		writeErr     error
+		wantErr      error
+		wantExported []int64
+	}{
+		// The export is audited before it starts, so its own entry is exported too.
+		{"exported", nil, nil, nil, []int64{1, 2, 3, 4}},
+		{"export not audited", storageErr, nil, storageErr, nil},
+		{"writer failure", nil, writeErr, writeErr, []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newFakeStorage()
+			storage.audit = newAuditLog(3)
+			storage.auditErr = tt.auditErr
+			m := newService(storage, newFakeCache(), 0, 0)
+
+			var exported []int64
+			err := m.ExportAuditLog(context.Background(), &domain.AuditLogFilter{}, userID,
+				func(entry *domain.AuditLogEntry) error {
+					exported = append(exported, entry.Seq)
+					return tt.writeErr
+				})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(exported, tt.wantExported) {
+				t.Errorf("exported %v, want %v", exported, tt.wantExported)
+			}
+			if tt.auditErr != nil {
+				return
+			}
+			if last := storage.audit[len(storage.audit)-1]; last.Action != domain.AuditActionExport || last.UserID != userID {
+				t.Errorf("export audited as %+v", last)
+			}
+		})
+	}
+}
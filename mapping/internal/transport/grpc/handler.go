@@ -33,6 +33,9 @@ func (m *grpcMappingHandler) CreateMapping(ctx context.Context, req *mapping.Cre
 		if errors.Is(err, errs.ErrMappingAlreadyExists) {
 			return nil, status.Error(codes.AlreadyExists, "mapping already exists")
 		}
+		if errors.Is(err, errs.ErrPurposeNotFound) {
+			return nil, status.Error(codes.InvalidArgument, "unknown purpose")
+		}
 		return nil, status.Error(codes.Internal, "failed to insert mapping")
 	}
 
@@ -94,12 +97,19 @@ func (m *grpcMappingHandler) DeleteMapping(ctx context.Context, req *mapping.Del
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "mapping id is invalid")
 	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user id is invalid")
+	}
 
-	err = m.mapping.DeleteMappingById(ctx, mappingUUID)
+	err = m.mapping.DeleteMappingById(ctx, mappingUUID, userID)
 	if err != nil {
 		if errors.Is(err, errs.ErrMappingNotFound) {
 			return nil, status.Error(codes.NotFound, "mapping not found")
 		}
+		if errors.Is(err, errs.ErrMappingOnHold) {
+			return nil, status.Error(codes.FailedPrecondition, "mapping is under legal hold")
+		}
 		return nil, status.Error(codes.Internal, "failed to delete mapping")
 	}
 
@@ -108,7 +118,7 @@ func (m *grpcMappingHandler) DeleteMapping(ctx context.Context, req *mapping.Del
 
 func (m *grpcMappingHandler) GetMappingList(ctx context.Context, req *mapping.GetMappingListRequest) (
 	*mapping.GetMappingListResponse, error) {
-	mappings, err := m.mapping.GetAllMappings(ctx)
+	mappings, err := m.mapping.GetAllMappings(ctx, req.GetMaxAccessLevel())
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to get mappings")
 	}
@@ -260,6 +270,58 @@ func (m *grpcMappingHandler) DeleteKind(ctx context.Context, req *mapping.Delete
 	return &mapping.DeleteKindResponse{}, nil
 }
 
+func (m *grpcMappingHandler) CreatePurpose(ctx context.Context, req *mapping.CreatePurposeRequest) (
+	*mapping.CreatePurposeResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	purpose, err := m.mapping.CreatePurpose(ctx, helpers.CreatePurposeRequestToModel(req))
+	if err != nil {
+		if errors.Is(err, errs.ErrPurposeAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, "purpose already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to insert purpose")
+	}
+
+	return &mapping.CreatePurposeResponse{Purpose: helpers.ModelToGRPCPurpose(purpose)}, nil
+}
+
+func (m *grpcMappingHandler) ListPurposes(ctx context.Context, req *mapping.ListPurposesRequest) (
+	*mapping.ListPurposesResponse, error) {
+	purposes, err := m.mapping.GetAllPurposes(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get purposes list")
+	}
+
+	var result []*mapping.Purpose
+	for _, purpose := range purposes {
+		result = append(result, helpers.ModelToGRPCPurpose(purpose))
+	}
+
+	return &mapping.ListPurposesResponse{Purposes: result}, nil
+}
+
+func (m *grpcMappingHandler) DeletePurpose(ctx context.Context, req *mapping.DeletePurposeRequest) (
+	*mapping.DeletePurposeResponse, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	err := m.mapping.DeletePurposeById(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, errs.ErrPurposeNotFound) {
+			return nil, status.Error(codes.NotFound, "purpose not found")
+		}
+		if errors.Is(err, errs.ErrPurposeInUse) {
+			return nil, status.Error(codes.FailedPrecondition, "purpose is in use")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete purpose")
+	}
+
+	return &mapping.DeletePurposeResponse{}, nil
+}
+
 func (m *grpcMappingHandler) CreateAuditLog(ctx context.Context, req *mapping.CreateAuditLogRequest) (
 	*mapping.CreateAuditLogResponse, error) {
 	if req.GetUserId() == "" {
@@ -268,10 +330,13 @@ func (m *grpcMappingHandler) CreateAuditLog(ctx context.Context, req *mapping.Cr
 	if req.GetAction() == "" {
 		return nil, status.Error(codes.InvalidArgument, "action is required")
 	}
+	if len(req.GetAction()) > 40 {
+		return nil, status.Error(codes.InvalidArgument, "action is too long")
+	}
 
 	entry, err := helpers.CreateAuditLogRequestToModel(req)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	result, err := m.mapping.CreateAuditLog(ctx, entry)
@@ -284,7 +349,12 @@ func (m *grpcMappingHandler) CreateAuditLog(ctx context.Context, req *mapping.Cr
 
 func (m *grpcMappingHandler) GetAuditLogList(ctx context.Context, req *mapping.GetAuditLogListRequest) (
 	*mapping.GetAuditLogListResponse, error) {
-	entries, err := m.mapping.GetAuditLogList(ctx)
+	query, err := helpers.GetAuditLogListRequestToModel(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	entries, next, err := m.mapping.GetAuditLogList(ctx, query)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to get audit log list")
 	}
@@ -294,7 +364,41 @@ func (m *grpcMappingHandler) GetAuditLogList(ctx context.Context, req *mapping.G
 		result = append(result, helpers.ModelToGRPCAuditLogEntry(entry))
 	}
 
-	return &mapping.GetAuditLogListResponse{Entries: result}, nil
+	resp := &mapping.GetAuditLogListResponse{Entries: result}
+	if next != nil {
+		resp.NextCursor = next.Encode()
+	}
+
+	return resp, nil
+}
+
+func (m *grpcMappingHandler) ExportAuditLog(req *mapping.ExportAuditLogRequest,
+	stream mapping.Mapping_ExportAuditLogServer) error {
+	filter, err := helpers.AuditLogFilterToModel(req.GetFilter())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "user id is invalid")
+	}
+
+	err = m.mapping.ExportAuditLog(stream.Context(), &filter, userID, helpers.AuditLogEntrySender(stream))
+	if err != nil {
+		return status.Error(codes.Internal, "failed to export audit log")
+	}
+
+	return nil
+}
+
+func (m *grpcMappingHandler) VerifyAuditLog(ctx context.Context, _ *mapping.VerifyAuditLogRequest) (
+	*mapping.VerifyAuditLogResponse, error) {
+	report, err := m.mapping.VerifyAuditLog(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to verify audit log")
+	}
+
+	return helpers.ModelToGRPCAuditChainReport(report), nil
 }
 
 func (m *grpcMappingHandler) UpdateMappingDek(ctx context.Context, req *mapping.UpdateMappingDekRequest) (
@@ -315,6 +419,9 @@ func (m *grpcMappingHandler) UpdateMappingDek(ctx context.Context, req *mapping.
 		if errors.Is(err, errs.ErrMappingNotFound) {
 			return nil, status.Error(codes.NotFound, "mapping not found")
 		}
+		if errors.Is(err, errs.ErrMappingOnHold) {
+			return nil, status.Error(codes.FailedPrecondition, "mapping is under legal hold")
+		}
 		return nil, status.Error(codes.Internal, "failed to update mapping dek")
 	}
 
@@ -342,8 +449,151 @@ func (m *grpcMappingHandler) UpdateMappingCrypto(ctx context.Context, req *mappi
 		if errors.Is(err, errs.ErrMappingNotFound) {
 			return nil, status.Error(codes.NotFound, "mapping not found")
 		}
+		if errors.Is(err, errs.ErrMappingOnHold) {
+			return nil, status.Error(codes.FailedPrecondition, "mapping is under legal hold")
+		}
 		return nil, status.Error(codes.Internal, "failed to update mapping crypto")
 	}
 
 	return &mapping.UpdateMappingCryptoResponse{}, nil
 }
+
+func (m *grpcMappingHandler) ListSubjectMappings(ctx context.Context, req *mapping.ListSubjectMappingsRequest) (
+	*mapping.ListSubjectMappingsResponse, error) {
+	if req.GetSubjectRef() == "" {
+		return nil, status.Error(codes.InvalidArgument, "subject ref is required")
+	}
+
+	mappings, err := m.mapping.GetSubjectMappings(ctx, req.GetSubjectRef(), req.GetMaxAccessLevel())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get subject mappings")
+	}
+
+	var mappingModels []*mapping.MappingModel
+	for _, mp := range mappings {
+		mappingModels = append(mappingModels, helpers.ModelToGRPCMapping(mp))
+	}
+
+	return &mapping.ListSubjectMappingsResponse{MappingModels: mappingModels}, nil
+}
+
+func (m *grpcMappingHandler) EraseSubject(ctx context.Context, req *mapping.EraseSubjectRequest) (
+	*mapping.EraseSubjectResponse, error) {
+	if req.GetSubjectRef() == "" {
+		return nil, status.Error(codes.InvalidArgument, "subject ref is required")
+	}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user id is invalid")
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.GetMappingIds()))
+	for _, rawID := range req.GetMappingIds() {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "mapping id is invalid")
+		}
+		ids = append(ids, id)
+	}
+
+	report, err := m.mapping.EraseSubject(ctx, req.GetSubjectRef(), ids, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrMappingOnHold) {
+			return nil, status.Error(codes.FailedPrecondition, "subject has mappings under legal hold")
+		}
+		if errors.Is(err, errs.ErrSubjectChanged) {
+			return nil, status.Error(codes.Aborted, "subject mappings changed")
+		}
+		return nil, status.Error(codes.Internal, "failed to erase subject")
+	}
+
+	return &mapping.EraseSubjectResponse{Report: helpers.ModelToGRPCErasureReport(report)}, nil
+}
+
+func (m *grpcMappingHandler) CreateLegalHold(ctx context.Context, req *mapping.CreateLegalHoldRequest) (
+	*mapping.CreateLegalHoldResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.GetTargets()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "targets are required")
+	}
+
+	hold, err := helpers.CreateLegalHoldRequestToModel(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := m.mapping.CreateLegalHold(ctx, hold)
+	if err != nil {
+		if errors.Is(err, errs.ErrLegalHoldExists) {
+			return nil, status.Error(codes.AlreadyExists, "legal hold already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to create legal hold")
+	}
+
+	return &mapping.CreateLegalHoldResponse{Hold: helpers.ModelToGRPCLegalHold(result)}, nil
+}
+
+func (m *grpcMappingHandler) ListLegalHolds(ctx context.Context, req *mapping.ListLegalHoldsRequest) (
+	*mapping.ListLegalHoldsResponse, error) {
+	holds, err := m.mapping.GetLegalHolds(ctx, req.GetActiveOnly())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get legal holds")
+	}
+
+	var result []*mapping.LegalHold
+	for _, hold := range holds {
+		result = append(result, helpers.ModelToGRPCLegalHold(hold))
+	}
+
+	return &mapping.ListLegalHoldsResponse{Holds: result}, nil
+}
+
+func (m *grpcMappingHandler) ReleaseLegalHold(ctx context.Context, req *mapping.ReleaseLegalHoldRequest) (
+	*mapping.ReleaseLegalHoldResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "legal hold id is invalid")
+	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user id is invalid")
+	}
+
+	hold, err := m.mapping.ReleaseLegalHold(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrLegalHoldNotFound) {
+			return nil, status.Error(codes.NotFound, "legal hold not found")
+		}
+		if errors.Is(err, errs.ErrLegalHoldReleased) {
+			return nil, status.Error(codes.FailedPrecondition, "legal hold already released")
+		}
+		return nil, status.Error(codes.Internal, "failed to release legal hold")
+	}
+
+	return &mapping.ReleaseLegalHoldResponse{Hold: helpers.ModelToGRPCLegalHold(hold)}, nil
+}
+
+func (m *grpcMappingHandler) GetDestructionAct(ctx context.Context, req *mapping.GetDestructionActRequest) (
+	*mapping.GetDestructionActResponse, error) {
+	if req.GetFrom() == nil || req.GetTo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "period is required")
+	}
+	from, to := req.GetFrom().AsTime(), req.GetTo().AsTime()
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "period is invalid")
+	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user id is invalid")
+	}
+
+	act, err := m.mapping.GetDestructionAct(ctx, from, to, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get destruction act")
+	}
+
+	return &mapping.GetDestructionActResponse{Act: helpers.ModelToGRPCDestructionAct(act)}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"time"
+	"unicode/utf8"
 )
 
 func CreateMappingRequestToModel(req *mapping.CreateMappingRequest) *domain.Mapping {
@@ -17,6 +18,15 @@ func CreateMappingRequestToModel(req *mapping.CreateMappingRequest) *domain.Mapp
 		DekWrapped:    req.DekWrapped,
 		Deterministic: req.Deterministic,
 		AlgoName:      req.AlgoName,
+		SubjectRef:    req.SubjectRef,
+
+		Purposes:   req.Purposes,
+		LegalBasis: req.LegalBasis,
+		ConsentRef: req.ConsentRef,
+	}
+
+	if expiresAt := req.GetConsentExpiresAt(); expiresAt != nil {
+		m.ConsentExpiresAt = expiresAt.AsTime()
 	}
 
 	var tokenTtl time.Duration
@@ -44,6 +54,15 @@ func GPRCMappingToModel(model *mapping.MappingModel) *domain.Mapping {
 		TokenTtl:      model.TokenTtl.AsDuration(),
 		CreatedAt:     model.CreatedAt.AsTime(),
 		AlgoName:      model.AlgoName,
+		SubjectRef:    model.SubjectRef,
+
+		Purposes:   model.Purposes,
+		LegalBasis: model.LegalBasis,
+		ConsentRef: model.ConsentRef,
+	}
+
+	if model.ConsentExpiresAt != nil {
+		m.ConsentExpiresAt = model.ConsentExpiresAt.AsTime()
 	}
 
 	if model.Kind != nil {
@@ -63,6 +82,15 @@ func ModelToGRPCMapping(model *domain.Mapping) *mapping.MappingModel {
 		TokenTtl:      durationpb.New(model.TokenTtl),
 		CreatedAt:     timestamppb.New(model.CreatedAt),
 		AlgoName:      model.AlgoName,
+		SubjectRef:    model.SubjectRef,
+
+		Purposes:   model.Purposes,
+		LegalBasis: model.LegalBasis,
+		ConsentRef: model.ConsentRef,
+	}
+
+	if !model.ConsentExpiresAt.IsZero() {
+		m.ConsentExpiresAt = timestamppb.New(model.ConsentExpiresAt)
 	}
 
 	if model.Kind != nil {
@@ -72,6 +100,20 @@ func ModelToGRPCMapping(model *domain.Mapping) *mapping.MappingModel {
 	return m
 }
 
+func ModelToGRPCErasureReport(report *domain.ErasureReport) *mapping.ErasureReport {
+	erased := make([]*mapping.MappingModel, 0, len(report.Erased))
+	for _, m := range report.Erased {
+		erased = append(erased, ModelToGRPCMapping(m))
+	}
+
+	return &mapping.ErasureReport{
+		SubjectRef: report.SubjectRef,
+		ErasedBy:   report.ErasedBy.String(),
+		ErasedAt:   timestamppb.New(report.ErasedAt),
+		Erased:     erased,
+	}
+}
+
 func CreateKindRequestToModel(req *mapping.CreateKindRequest) *domain.Kind {
 	return &domain.Kind{
 		Name:        req.Name,
@@ -123,16 +165,70 @@ func ModelToGRPCKind(kind *domain.Kind) *mapping.Kind {
 	}
 }
 
+func CreatePurposeRequestToModel(req *mapping.CreatePurposeRequest) *domain.Purpose {
+	return &domain.Purpose{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func ModelToGRPCPurpose(purpose *domain.Purpose) *mapping.Purpose {
+	return &mapping.Purpose{
+		Id:          purpose.Id,
+		Name:        purpose.Name,
+		Description: purpose.Description,
+		CreatedAt:   timestamppb.New(purpose.CreatedAt),
+	}
+}
+
+// Column widths of the audit fields that may carry client input, such as the token of a
+// failed detokenization or a request header; longer values are cut so that the attempt
+// is still recorded.
+const (
+	maxAuditTokenLen         = 100
+	maxAuditPurposeLen       = 50
+	maxAuditReasonLen        = 200
+	maxAuditClientIPLen      = 45
+	maxAuditUserAgentLen     = 300
+	maxAuditRequestIDLen     = 100
+	maxAuditJustificationLen = 500
+)
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
 func CreateAuditLogRequestToModel(req *mapping.CreateAuditLogRequest) (*domain.AuditLogEntry, error) {
 	userID, err := uuid.Parse(req.GetUserId())
 	if err != nil {
 		return nil, fmt.Errorf("invalid user id: %w", err)
 	}
 
+	outcome := req.GetOutcome()
+	if outcome == "" {
+		outcome = domain.AuditOutcomeSuccess
+	}
+	if !domain.IsValidAuditOutcome(outcome) {
+		return nil, fmt.Errorf("invalid outcome %q", outcome)
+	}
+	if len(req.GetClientIp()) > maxAuditClientIPLen {
+		return nil, fmt.Errorf("invalid client ip")
+	}
+
 	entry := &domain.AuditLogEntry{
-		UserID: userID,
-		Action: req.GetAction(),
-		Token:  req.GetToken(),
+		UserID:        userID,
+		Action:        req.GetAction(),
+		Token:         truncateRunes(req.GetToken(), maxAuditTokenLen),
+		Purpose:       truncateRunes(req.GetPurpose(), maxAuditPurposeLen),
+		Outcome:       outcome,
+		Reason:        truncateRunes(req.GetReason(), maxAuditReasonLen),
+		ClientIP:      req.GetClientIp(),
+		UserAgent:     truncateRunes(req.GetUserAgent(), maxAuditUserAgentLen),
+		RequestID:     truncateRunes(req.GetRequestId(), maxAuditRequestIDLen),
+		Justification: truncateRunes(req.GetJustification(), maxAuditJustificationLen),
 	}
 
 	if req.GetKindId() > 0 {
@@ -142,13 +238,98 @@ func CreateAuditLogRequestToModel(req *mapping.CreateAuditLogRequest) (*domain.A
 	return entry, nil
 }
 
+const (
+	defaultAuditLogLimit = 100
+	maxAuditLogLimit     = 1000
+)
+
+func AuditLogFilterToModel(f *mapping.AuditLogFilter) (domain.AuditLogFilter, error) {
+	filter := domain.AuditLogFilter{
+		Action:      f.GetAction(),
+		Token:       f.GetToken(),
+		KindID:      f.GetKindId(),
+		AccessLevel: f.GetAccessLevel(),
+		Outcome:     f.GetOutcome(),
+		RequestID:   f.GetRequestId(),
+		BreakGlass:  f.GetBreakGlass(),
+	}
+	if filter.Outcome != "" && !domain.IsValidAuditOutcome(filter.Outcome) {
+		return filter, fmt.Errorf("invalid outcome %q", filter.Outcome)
+	}
+
+	if f.GetUserId() != "" {
+		userID, err := uuid.Parse(f.GetUserId())
+		if err != nil {
+			return filter, fmt.Errorf("invalid user id: %w", err)
+		}
+		filter.UserID = userID
+	}
+	if f.GetFrom() != nil {
+		filter.From = f.GetFrom().AsTime()
+	}
+	if f.GetTo() != nil {
+		filter.To = f.GetTo().AsTime()
+	}
+
+	return filter, nil
+}
+
+func GetAuditLogListRequestToModel(req *mapping.GetAuditLogListRequest) (*domain.AuditLogQuery, error) {
+	filter, err := AuditLogFilterToModel(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	query := &domain.AuditLogQuery{
+		Filter:     filter,
+		SortBy:     req.GetSortBy(),
+		Descending: !req.GetAscending(),
+		Limit:      int(req.GetLimit()),
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = domain.AuditLogSortTime
+	case domain.AuditLogSortTime, domain.AuditLogSortUserID, domain.AuditLogSortAction, domain.AuditLogSortToken:
+	default:
+		return nil, fmt.Errorf("invalid sort field %q", query.SortBy)
+	}
+
+	switch {
+	case query.Limit == 0:
+		query.Limit = defaultAuditLogLimit
+	case query.Limit < 0 || query.Limit > maxAuditLogLimit:
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxAuditLogLimit)
+	}
+
+	if req.GetCursor() != "" {
+		query.After, err = domain.DecodeAuditLogCursor(req.GetCursor())
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+
+	return query, nil
+}
+
 func ModelToGRPCAuditLogEntry(entry *domain.AuditLogEntry) *mapping.AuditLogEntry {
 	e := &mapping.AuditLogEntry{
-		Id:        entry.ID.String(),
-		UserId:    entry.UserID.String(),
-		Action:    entry.Action,
-		Token:     entry.Token,
-		CreatedAt: timestamppb.New(entry.CreatedAt),
+		Id:            entry.ID.String(),
+		UserId:        entry.UserID.String(),
+		Action:        entry.Action,
+		Token:         entry.Token,
+		CreatedAt:     timestamppb.New(entry.CreatedAt),
+		Purpose:       entry.Purpose,
+		LegalHold:     entry.LegalHold,
+		Seq:           entry.Seq,
+		PrevHash:      entry.PrevHash,
+		RowHash:       entry.RowHash,
+		Outcome:       entry.Outcome,
+		Reason:        entry.Reason,
+		ClientIp:      entry.ClientIP,
+		UserAgent:     entry.UserAgent,
+		RequestId:     entry.RequestID,
+		Justification: entry.Justification,
 	}
 
 	if entry.Kind != nil {
@@ -157,3 +338,121 @@ func ModelToGRPCAuditLogEntry(entry *domain.AuditLogEntry) *mapping.AuditLogEntr
 
 	return e
 }
+
+func CreateLegalHoldRequestToModel(req *mapping.CreateLegalHoldRequest) (*domain.LegalHold, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+
+	hold := &domain.LegalHold{
+		Name:      req.GetName(),
+		Reason:    req.GetReason(),
+		Owner:     req.GetOwner(),
+		CreatedBy: userID,
+	}
+
+	for _, target := range req.GetTargets() {
+		switch target.GetType() {
+		case domain.LegalHoldTargetToken, domain.LegalHoldTargetSubject, domain.LegalHoldTargetKind:
+		default:
+			return nil, fmt.Errorf("invalid target type %q", target.GetType())
+		}
+		if target.GetValue() == "" {
+			return nil, fmt.Errorf("target value is required")
+		}
+		hold.Targets = append(hold.Targets, domain.LegalHoldTarget{Type: target.GetType(), Value: target.GetValue()})
+	}
+
+	if expiresAt := req.GetExpiresAt(); expiresAt != nil {
+		hold.ExpiresAt = expiresAt.AsTime()
+	}
+
+	return hold, nil
+}
+
+func ModelToGRPCLegalHold(hold *domain.LegalHold) *mapping.LegalHold {
+	h := &mapping.LegalHold{
+		Id:        hold.ID.String(),
+		Name:      hold.Name,
+		Reason:    hold.Reason,
+		Owner:     hold.Owner,
+		CreatedBy: hold.CreatedBy.String(),
+		CreatedAt: timestamppb.New(hold.CreatedAt),
+		Active:    hold.Active(time.Now()),
+	}
+
+	for _, target := range hold.Targets {
+		h.Targets = append(h.Targets, &mapping.LegalHoldTarget{Type: target.Type, Value: target.Value})
+	}
+	if !hold.ExpiresAt.IsZero() {
+		h.ExpiresAt = timestamppb.New(hold.ExpiresAt)
+	}
+	if !hold.ReleasedAt.IsZero() {
+		h.ReleasedAt = timestamppb.New(hold.ReleasedAt)
+		h.ReleasedBy = hold.ReleasedBy.String()
+	}
+
+	return h
+}
+
+func ModelToGRPCDestructionAct(act *domain.DestructionAct) *mapping.DestructionAct {
+	a := &mapping.DestructionAct{
+		PeriodFrom:  timestamppb.New(act.PeriodFrom),
+		PeriodTo:    timestamppb.New(act.PeriodTo),
+		GeneratedAt: timestamppb.New(act.GeneratedAt),
+		GeneratedBy: act.GeneratedBy.String(),
+		Total:       int32(act.Total),
+		Digest:      act.Digest,
+		Signature:   act.Signature,
+		SigningKey:  act.SigningKey,
+	}
+
+	for _, group := range act.Groups {
+		g := &mapping.DestructionActGroup{
+			KindId:          group.KindID,
+			KindName:        group.KindName,
+			KindRussianName: group.KindRussianName,
+		}
+		for _, event := range group.Events {
+			g.Events = append(g.Events, &mapping.DestructionEvent{
+				Id:              event.ID.String(),
+				MappingId:       event.MappingID.String(),
+				Token:           event.Token,
+				KindId:          event.KindID,
+				KindName:        event.KindName,
+				KindRussianName: event.KindRussianName,
+				Reason:          event.Reason,
+				Initiator:       event.Initiator,
+				DestroyedAt:     timestamppb.New(event.DestroyedAt),
+			})
+		}
+		a.Groups = append(a.Groups, g)
+	}
+
+	return a
+}
+
+// AuditLogEntrySender returns a callback sending audit log entries to stream.
+func AuditLogEntrySender(stream mapping.Mapping_ExportAuditLogServer) func(entry *domain.AuditLogEntry) error {
+	return func(entry *domain.AuditLogEntry) error {
+		return stream.Send(ModelToGRPCAuditLogEntry(entry))
+	}
+}
+
+func ModelToGRPCAuditChainReport(report *domain.AuditChainReport) *mapping.VerifyAuditLogResponse {
+	r := &mapping.VerifyAuditLogResponse{
+		Valid:               report.Valid,
+		Checked:             report.Checked,
+		LastSeq:             report.LastSeq,
+		CheckpointsVerified: int32(report.CheckpointsVerified),
+		BrokenSeq:           report.BrokenSeq,
+		Reason:              report.Reason,
+	}
+
+	if report.BrokenEntryID != uuid.Nil {
+		r.BrokenEntryId = report.BrokenEntryID.String()
+	}
+
+	return r
+}
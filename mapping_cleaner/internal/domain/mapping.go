@@ -0,0 +1,8 @@
+package domain
+
+import "github.com/google/uuid"
+
+type ExpiredMapping struct {
+	ID    uuid.UUID
+	Token string
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/google/uuid"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -16,13 +16,17 @@ func NewRedisAdapter(client *redis.Client) *RedisAdapter {
 	return &RedisAdapter{client: client}
 }
 
-func (r *RedisAdapter) DeleteMappingById(ctx context.Context, id uuid.UUID) error {
-	result := r.client.Del(ctx, fmt.Sprintf("mapping:id:%s", id))
+// DeleteMapping drops the cached mapping and its token index entry used by the
+// mapping service for detokenization.
+func (r *RedisAdapter) DeleteMapping(ctx context.Context, mapping *domain.ExpiredMapping) error {
+	result := r.client.Del(ctx,
+		fmt.Sprintf("mapping:id:%s", mapping.ID),
+		fmt.Sprintf("mapping:token:%s", mapping.Token))
 	if err := result.Err(); err != nil {
 		if errors.Is(err, redis.Nil) {
 			return nil
 		}
-		return fmt.Errorf("DeleteMappingById: failed to delete mapping: %v", err)
+		return fmt.Errorf("DeleteMapping: failed to delete mapping: %v", err)
 	}
 	return nil
 }
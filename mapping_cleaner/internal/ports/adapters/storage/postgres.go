@@ -3,7 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
-	"github.com/google/uuid"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -15,32 +15,52 @@ func NewPostgresAdapter(pool *pgxpool.Pool) *PostgresAdapter {
 	return &PostgresAdapter{pool: pool}
 }
 
-func (p *PostgresAdapter) DeleteExpiredMappings(ctx context.Context) ([]uuid.UUID, error) {
+// DeleteExpiredMappings deletes mappings that outlived their TTL, except those under
+// legal hold, which are left for a later run after the hold is released. The deletion is
+// recorded as a destruction event with the "ttl" reason and initiator.
+func (p *PostgresAdapter) DeleteExpiredMappings(ctx context.Context) ([]*domain.ExpiredMapping, error) {
 	query := `
 		DELETE FROM mapping.mappings
 		WHERE (created_at + (token_ttl / 1000000000 * interval '1 second')) < now()
 			AND token_ttl IS NOT NULL 
 			AND token_ttl != 0
-		RETURNING id`
+			AND NOT mapping.mapping_on_hold(token, subject_ref, kind_id)
+		RETURNING id, token`
 
-	rows, err := p.pool.Query(ctx, query)
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `SELECT set_config('anonix.destruction_reason', 'ttl', true),
+		set_config('anonix.destruction_initiator', 'ttl', true)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set destruction cause: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete expired mappings from storage: %w", err)
 	}
 	defer rows.Close()
 
-	var ids []uuid.UUID
+	var mappings []*domain.ExpiredMapping
 	for rows.Next() {
-		var id uuid.UUID
-		if err = rows.Scan(&id); err != nil {
+		var mapping domain.ExpiredMapping
+		if err = rows.Scan(&mapping.ID, &mapping.Token); err != nil {
 			return nil, fmt.Errorf("failed to scan id from storage: %w", err)
 		}
-		ids = append(ids, id)
+		mappings = append(mappings, &mapping)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	return ids, nil
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return mappings, nil
 }
@@ -2,13 +2,13 @@ package ports
 
 import (
 	"context"
-	"github.com/google/uuid"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
 )
 
 type StorageRepository interface {
-	DeleteExpiredMappings(ctx context.Context) ([]uuid.UUID, error)
+	DeleteExpiredMappings(ctx context.Context) ([]*domain.ExpiredMapping, error)
 }
 
 type CacheRepository interface {
-	DeleteMappingById(ctx context.Context, id uuid.UUID) error
+	DeleteMapping(ctx context.Context, mapping *domain.ExpiredMapping) error
 }
@@ -23,20 +23,20 @@ func NewMappingCleanerService(storage ports.StorageRepository, cache ports.Cache
 func (m *MappingCleanerService) DeleteExpiredMappings(ctx context.Context) (int, int, error) {
 	var storageCount, cacheCount int
 
-	ids, err := m.storage.DeleteExpiredMappings(ctx)
+	mappings, err := m.storage.DeleteExpiredMappings(ctx)
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Error(ctx, "Error deleting expired mappings",
 			logger.Err(err))
 		return 0, 0, fmt.Errorf("delete expired mappings error: %w", err)
 	}
 
-	storageCount = len(ids)
+	storageCount = len(mappings)
 
-	for _, id := range ids {
-		err = m.cache.DeleteMappingById(ctx, id)
+	for _, mapping := range mappings {
+		err = m.cache.DeleteMapping(ctx, mapping)
 		if err != nil {
 			logger.GetLoggerFromCtx(ctx).Warn(ctx, "Error deleting mapping from cache",
-				slog.String("id", id.String()),
+				slog.String("id", mapping.ID.String()),
 				logger.Err(err))
 		}
 		cacheCount++
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/NeF2le/anonix/mapping/internal/domain"
+	"github.com/google/uuid"
+	"slices"
+	"testing"
+)
+
+// fakeStorage returns the mappings the DELETE of the Postgres adapter would: expired
+// ones that are not under legal hold.
+type fakeStorage struct {
+	expired []*domain.ExpiredMapping
+	held    map[uuid.UUID]bool
+	err     error
+}
+
+func (s *fakeStorage) DeleteExpiredMappings(context.Context) ([]*domain.ExpiredMapping, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	var deleted []*domain.ExpiredMapping
+	for _, mapping := range s.expired {
+		if !s.held[mapping.ID] {
+			deleted = append(deleted, mapping)
+		}
+	}
+	return deleted, nil
+}
+
+type fakeCache struct {
+	deleted []string
+	err     error
+}
+
+func (c *fakeCache) DeleteMapping(_ context.Context, mapping *domain.ExpiredMapping) error {
+	c.deleted = append(c.deleted, mapping.Token)
+	return c.err
+}
+
+func TestDeleteExpiredMappings(t *testing.T) {
+	first := &domain.ExpiredMapping{ID: uuid.New(), Token: "fio_1"}
+	second := &domain.ExpiredMapping{ID: uuid.New(), Token: "fio_2"}
+	held := &domain.ExpiredMapping{ID: uuid.New(), Token: "fio_3"}
+	storageErr := errors.New("connection refused")
+
+	tests := []struct {
+		name             string
+		storageErr       error
+		cacheErr         error
+		wantErr          error
+		wantStorageCount int
+		wantCacheCount   int
+		wantEvicted      []string
+	}{
+		// The held mapping is neither deleted nor evicted, so it is still served from the
+		// cache and expired lazily once the hold is released.
+		{"held mapping kept", nil, nil, nil, 2, 2, []string{"fio_1", "fio_2"}},
+		// Cache entries that could not be evicted expire with their cache TTL and are
+		// still counted.
+		{"cache failure", nil, errors.New("cache down"), nil, 2, 2, []string{"fio_1", "fio_2"}},
+		{"storage failure", storageErr, nil, storageErr, 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &fakeStorage{
+				expired: []*domain.ExpiredMapping{first, held, second},
+				held:    map[uuid.UUID]bool{held.ID: true},
+				err:     tt.storageErr,
+			}
+			cache := &fakeCache{err: tt.cacheErr}
+			m := NewMappingCleanerService(storage, cache)
+
+			storageCount, cacheCount, err := m.DeleteExpiredMappings(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if storageCount != tt.wantStorageCount || cacheCount != tt.wantCacheCount {
+				t.Errorf("got counts %d and %d, want %d and %d",
+					storageCount, cacheCount, tt.wantStorageCount, tt.wantCacheCount)
+			}
+			if !slices.Equal(cache.deleted, tt.wantEvicted) {
+				t.Errorf("evicted %v, want %v", cache.deleted, tt.wantEvicted)
+			}
+		})
+	}
+}
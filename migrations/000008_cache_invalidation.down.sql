@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_kinds_cache_invalidation ON mapping.kinds;
+DROP TRIGGER IF EXISTS trg_mappings_cache_invalidation ON mapping.mappings;
+DROP FUNCTION IF EXISTS mapping.notify_kind_change();
+DROP FUNCTION IF EXISTS mapping.notify_mapping_change();
@@ -0,0 +1,32 @@
+CREATE OR REPLACE FUNCTION mapping.notify_mapping_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('mapping_cache_invalidation', json_build_object(
+        'table', TG_TABLE_NAME,
+        'op', TG_OP,
+        'mapping_id', OLD.id,
+        'token', OLD.token
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION mapping.notify_kind_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('mapping_cache_invalidation', json_build_object(
+        'table', TG_TABLE_NAME,
+        'op', TG_OP,
+        'kind_id', OLD.id
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_mappings_cache_invalidation ON mapping.mappings;
+CREATE TRIGGER trg_mappings_cache_invalidation
+    AFTER UPDATE OR DELETE ON mapping.mappings
+    FOR EACH ROW EXECUTE FUNCTION mapping.notify_mapping_change();
+
+DROP TRIGGER IF EXISTS trg_kinds_cache_invalidation ON mapping.kinds;
+CREATE TRIGGER trg_kinds_cache_invalidation
+    AFTER UPDATE OR DELETE ON mapping.kinds
+    FOR EACH ROW EXECUTE FUNCTION mapping.notify_kind_change();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS mapping.idx_mappings_subject_ref;
+
+ALTER TABLE mapping.mappings DROP COLUMN IF EXISTS subject_ref;
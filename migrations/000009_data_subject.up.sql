@@ -0,0 +1,3 @@
+ALTER TABLE mapping.mappings ADD COLUMN IF NOT EXISTS subject_ref VARCHAR(100) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_mappings_subject_ref ON mapping.mappings(subject_ref) WHERE subject_ref IS NOT NULL;
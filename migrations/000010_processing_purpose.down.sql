@@ -0,0 +1,8 @@
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS purpose;
+
+ALTER TABLE mapping.mappings DROP COLUMN IF EXISTS consent_expires_at;
+ALTER TABLE mapping.mappings DROP COLUMN IF EXISTS consent_ref;
+ALTER TABLE mapping.mappings DROP COLUMN IF EXISTS legal_basis;
+
+DROP TABLE IF EXISTS mapping.mapping_purposes;
+DROP TABLE IF EXISTS mapping.purposes;
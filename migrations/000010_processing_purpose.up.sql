@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS mapping.purposes
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS mapping.mapping_purposes
+(
+    mapping_id uuid NOT NULL REFERENCES mapping.mappings(id) ON DELETE CASCADE,
+    purpose_id INT NOT NULL REFERENCES mapping.purposes(id) ON DELETE RESTRICT,
+    PRIMARY KEY (mapping_id, purpose_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mapping_purposes_purpose_id ON mapping.mapping_purposes(purpose_id);
+
+ALTER TABLE mapping.mappings ADD COLUMN IF NOT EXISTS legal_basis VARCHAR(30) DEFAULT NULL;
+ALTER TABLE mapping.mappings ADD COLUMN IF NOT EXISTS consent_ref VARCHAR(100) DEFAULT NULL;
+ALTER TABLE mapping.mappings ADD COLUMN IF NOT EXISTS consent_expires_at TIMESTAMPTZ DEFAULT NULL;
+
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS purpose VARCHAR(50) DEFAULT NULL;
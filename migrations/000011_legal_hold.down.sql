@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_mappings_legal_hold ON mapping.mappings;
+DROP FUNCTION IF EXISTS mapping.prevent_held_mapping_delete();
+DROP FUNCTION IF EXISTS mapping.mapping_on_hold(TEXT, TEXT, INT);
+
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS legal_hold;
+
+DROP TABLE IF EXISTS mapping.legal_hold_targets;
+DROP TABLE IF EXISTS mapping.legal_holds;
@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS mapping.legal_holds
+(
+    id uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    reason TEXT NOT NULL,
+    owner VARCHAR(100) NOT NULL,
+    created_by uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ DEFAULT NULL,
+    released_at TIMESTAMPTZ DEFAULT NULL,
+    released_by uuid DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS mapping.legal_hold_targets
+(
+    hold_id uuid NOT NULL REFERENCES mapping.legal_holds(id) ON DELETE CASCADE,
+    target_type VARCHAR(10) NOT NULL CHECK (target_type IN ('token', 'subject', 'kind')),
+    target_value VARCHAR(100) NOT NULL,
+    PRIMARY KEY (hold_id, target_type, target_value)
+);
+
+CREATE INDEX IF NOT EXISTS idx_legal_hold_targets_target ON mapping.legal_hold_targets(target_type, target_value);
+
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS legal_hold VARCHAR(100) DEFAULT NULL;
+
+-- A hold is in force until it is released or reaches its expiry. Kind targets hold the
+-- kind id.
+CREATE OR REPLACE FUNCTION mapping.mapping_on_hold(p_token TEXT, p_subject_ref TEXT, p_kind_id INT)
+    RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1
+        FROM mapping.legal_hold_targets t
+        JOIN mapping.legal_holds h ON h.id = t.hold_id
+        WHERE h.released_at IS NULL
+            AND (h.expires_at IS NULL OR h.expires_at > now())
+            AND ((t.target_type = 'token' AND t.target_value = p_token)
+                OR (t.target_type = 'subject' AND t.target_value = p_subject_ref)
+                OR (t.target_type = 'kind' AND t.target_value = p_kind_id::text))
+    );
+$$ LANGUAGE sql STABLE;
+
+-- Last line of defence: no code path may delete a mapping under hold.
+CREATE OR REPLACE FUNCTION mapping.prevent_held_mapping_delete() RETURNS trigger AS $$
+BEGIN
+    IF mapping.mapping_on_hold(OLD.token, OLD.subject_ref, OLD.kind_id) THEN
+        RAISE EXCEPTION 'mapping % is under legal hold', OLD.id USING ERRCODE = 'AXH01';
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_mappings_legal_hold ON mapping.mappings;
+CREATE TRIGGER trg_mappings_legal_hold
+    BEFORE DELETE ON mapping.mappings
+    FOR EACH ROW EXECUTE FUNCTION mapping.prevent_held_mapping_delete();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_mappings_destruction ON mapping.mappings;
+DROP FUNCTION IF EXISTS mapping.record_mapping_destruction();
+
+DROP TABLE IF EXISTS mapping.destruction_events;
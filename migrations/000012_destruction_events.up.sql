@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS mapping.destruction_events
+(
+    id uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    mapping_id uuid NOT NULL,
+    token VARCHAR(100) NOT NULL,
+    kind_id INT DEFAULT NULL,
+    kind_name VARCHAR(100) DEFAULT NULL,
+    kind_russian_name VARCHAR(100) DEFAULT NULL,
+    reason VARCHAR(30) NOT NULL,
+    initiator VARCHAR(50) NOT NULL,
+    destroyed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_destruction_events_destroyed_at ON mapping.destruction_events(destroyed_at);
+
+-- Every deleted mapping leaves a destruction event. Delete paths describe the deletion
+-- with the transaction-local settings anonix.destruction_reason and
+-- anonix.destruction_initiator; deletions that do not are recorded as 'unknown'. The
+-- kind is copied, so the event outlives the kind itself.
+CREATE OR REPLACE FUNCTION mapping.record_mapping_destruction() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO mapping.destruction_events
+        (mapping_id, token, kind_id, kind_name, kind_russian_name, reason, initiator)
+    SELECT OLD.id, OLD.token, OLD.kind_id, k.name, k.russian_name,
+        COALESCE(NULLIF(current_setting('anonix.destruction_reason', true), ''), 'unknown'),
+        COALESCE(NULLIF(current_setting('anonix.destruction_initiator', true), ''), 'unknown')
+    FROM (SELECT 1) AS one
+    LEFT JOIN mapping.kinds k ON k.id = OLD.kind_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_mappings_destruction ON mapping.mappings;
+CREATE TRIGGER trg_mappings_destruction
+    AFTER DELETE ON mapping.mappings
+    FOR EACH ROW EXECUTE FUNCTION mapping.record_mapping_destruction();
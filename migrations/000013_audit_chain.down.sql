@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS mapping.audit_checkpoints;
+
+DROP TRIGGER IF EXISTS trg_audit_log_immutable ON mapping.audit_log;
+DROP FUNCTION IF EXISTS mapping.audit_log_immutable();
+
+DROP TRIGGER IF EXISTS trg_audit_log_chain ON mapping.audit_log;
+DROP FUNCTION IF EXISTS mapping.audit_log_chain();
+DROP FUNCTION IF EXISTS mapping.audit_row_hash_v1(BIGINT, uuid, uuid, TEXT, TEXT, INT, TEXT, TEXT, TIMESTAMPTZ, TEXT);
+
+DROP TABLE IF EXISTS mapping.audit_chain_head;
+
+DROP INDEX IF EXISTS mapping.idx_audit_log_seq;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS chain_version;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS row_hash;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS seq;
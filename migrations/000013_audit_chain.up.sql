@@ -0,0 +1,120 @@
+-- The audit log is a hash chain: every entry gets the next sequence number, the hash of
+-- the previous entry and its own hash computed over its content and that previous hash.
+-- The last sequence number and hash are kept in audit_chain_head; inserts lock that row,
+-- so concurrent writers are appended one at a time in commit order.
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS seq BIGINT;
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS prev_hash CHAR(64);
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS row_hash CHAR(64);
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS chain_version SMALLINT;
+
+CREATE TABLE IF NOT EXISTS mapping.audit_chain_head
+(
+    id BOOLEAN PRIMARY KEY NOT NULL DEFAULT true CHECK (id),
+    seq BIGINT NOT NULL,
+    row_hash CHAR(64) NOT NULL
+);
+
+INSERT INTO mapping.audit_chain_head (id, seq, row_hash)
+VALUES (true, 0, repeat('0', 64))
+ON CONFLICT DO NOTHING;
+
+-- Version 1 of the entry hash: hex SHA-256 of the fields below joined with the unit
+-- separator (0x1F), NULLs as empty strings and created_at in UTC with microseconds.
+-- The mapping service recomputes it independently when verifying the chain.
+CREATE OR REPLACE FUNCTION mapping.audit_row_hash_v1(
+    p_seq BIGINT, p_id uuid, p_user_id uuid, p_action TEXT, p_token TEXT, p_kind_id INT,
+    p_purpose TEXT, p_legal_hold TEXT, p_created_at TIMESTAMPTZ, p_prev_hash TEXT
+) RETURNS CHAR(64) AS $$
+    SELECT encode(sha256(convert_to(concat_ws(chr(31),
+        'v1',
+        p_seq::text,
+        p_id::text,
+        p_user_id::text,
+        p_action,
+        p_token,
+        COALESCE(p_kind_id::text, ''),
+        COALESCE(p_purpose, ''),
+        COALESCE(p_legal_hold, ''),
+        to_char(p_created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+        p_prev_hash
+    ), 'UTF8')), 'hex');
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION mapping.audit_log_chain() RETURNS trigger AS $$
+DECLARE
+    head_seq BIGINT;
+    head_hash CHAR(64);
+BEGIN
+    SELECT seq, row_hash INTO head_seq, head_hash
+    FROM mapping.audit_chain_head
+    WHERE id
+    FOR UPDATE;
+
+    NEW.seq := head_seq + 1;
+    NEW.prev_hash := head_hash;
+    NEW.chain_version := 1;
+    NEW.row_hash := mapping.audit_row_hash_v1(NEW.seq, NEW.id, NEW.user_id, NEW.action, NEW.token,
+        NEW.kind_id, NEW.purpose, NEW.legal_hold, NEW.created_at, NEW.prev_hash);
+
+    UPDATE mapping.audit_chain_head SET seq = NEW.seq, row_hash = NEW.row_hash WHERE id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Chain the entries written before this migration in creation order.
+DO $$
+DECLARE
+    entry RECORD;
+    head_seq BIGINT;
+    head_hash CHAR(64);
+BEGIN
+    SELECT seq, row_hash INTO head_seq, head_hash FROM mapping.audit_chain_head WHERE id FOR UPDATE;
+    FOR entry IN SELECT * FROM mapping.audit_log WHERE seq IS NULL ORDER BY created_at, id LOOP
+        head_seq := head_seq + 1;
+        UPDATE mapping.audit_log
+        SET seq = head_seq,
+            prev_hash = head_hash,
+            chain_version = 1,
+            row_hash = mapping.audit_row_hash_v1(head_seq, entry.id, entry.user_id, entry.action,
+                entry.token, entry.kind_id, entry.purpose, entry.legal_hold, entry.created_at, head_hash)
+        WHERE id = entry.id
+        RETURNING row_hash INTO head_hash;
+    END LOOP;
+    UPDATE mapping.audit_chain_head SET seq = head_seq, row_hash = head_hash WHERE id;
+END;
+$$;
+
+ALTER TABLE mapping.audit_log ALTER COLUMN seq SET NOT NULL;
+ALTER TABLE mapping.audit_log ALTER COLUMN prev_hash SET NOT NULL;
+ALTER TABLE mapping.audit_log ALTER COLUMN row_hash SET NOT NULL;
+ALTER TABLE mapping.audit_log ALTER COLUMN chain_version SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_log_seq ON mapping.audit_log(seq);
+
+DROP TRIGGER IF EXISTS trg_audit_log_chain ON mapping.audit_log;
+CREATE TRIGGER trg_audit_log_chain
+    BEFORE INSERT ON mapping.audit_log
+    FOR EACH ROW EXECUTE FUNCTION mapping.audit_log_chain();
+
+-- The audit log is append-only.
+CREATE OR REPLACE FUNCTION mapping.audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log entries cannot be modified or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_log_immutable ON mapping.audit_log;
+CREATE TRIGGER trg_audit_log_immutable
+    BEFORE UPDATE OR DELETE ON mapping.audit_log
+    FOR EACH ROW EXECUTE FUNCTION mapping.audit_log_immutable();
+
+-- Checkpoints sign the chain head periodically, so rewriting the chain from some entry
+-- on is detected even when every hash after it has been recomputed.
+CREATE TABLE IF NOT EXISTS mapping.audit_checkpoints
+(
+    id uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    seq BIGINT NOT NULL UNIQUE,
+    row_hash CHAR(64) NOT NULL,
+    signature TEXT NOT NULL,
+    signing_key VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
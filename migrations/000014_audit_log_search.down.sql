@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS mapping.idx_audit_log_kind_id;
+DROP INDEX IF EXISTS mapping.idx_audit_log_token_seq;
+DROP INDEX IF EXISTS mapping.idx_audit_log_action_seq;
+DROP INDEX IF EXISTS mapping.idx_audit_log_user_id_seq;
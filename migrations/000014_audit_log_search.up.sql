@@ -0,0 +1,5 @@
+-- Keyset pagination orders by the sort column and then by seq.
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_id_seq ON mapping.audit_log(user_id, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action_seq ON mapping.audit_log(action, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_token_seq ON mapping.audit_log(token, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_kind_id ON mapping.audit_log(kind_id);
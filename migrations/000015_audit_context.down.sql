@@ -0,0 +1,31 @@
+CREATE OR REPLACE FUNCTION mapping.audit_log_chain() RETURNS trigger AS $$
+DECLARE
+    head_seq BIGINT;
+    head_hash CHAR(64);
+BEGIN
+    SELECT seq, row_hash INTO head_seq, head_hash
+    FROM mapping.audit_chain_head
+    WHERE id
+    FOR UPDATE;
+
+    NEW.seq := head_seq + 1;
+    NEW.prev_hash := head_hash;
+    NEW.chain_version := 1;
+    NEW.row_hash := mapping.audit_row_hash_v1(NEW.seq, NEW.id, NEW.user_id, NEW.action, NEW.token,
+        NEW.kind_id, NEW.purpose, NEW.legal_hold, NEW.created_at, NEW.prev_hash);
+
+    UPDATE mapping.audit_chain_head SET seq = NEW.seq, row_hash = NEW.row_hash WHERE id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS mapping.audit_row_hash_v2(BIGINT, uuid, uuid, TEXT, TEXT, INT, TEXT, TEXT, TEXT, TEXT, TEXT, TEXT, TEXT, TIMESTAMPTZ, TEXT);
+
+DROP INDEX IF EXISTS mapping.idx_audit_log_request_id;
+DROP INDEX IF EXISTS mapping.idx_audit_log_outcome_seq;
+
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS request_id;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS client_ip;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS reason;
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS outcome;
@@ -0,0 +1,61 @@
+-- Audit entries record the outcome of the operation, the reason of a denial or error and
+-- the request context they were written in.
+ALTER TABLE mapping.audit_log ALTER COLUMN action TYPE VARCHAR(40);
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS outcome VARCHAR(10) NOT NULL DEFAULT 'success'
+    CHECK (outcome IN ('success', 'denied', 'error'));
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS reason VARCHAR(200);
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS client_ip VARCHAR(45);
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS user_agent VARCHAR(300);
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS request_id VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_outcome_seq ON mapping.audit_log(outcome, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_request_id ON mapping.audit_log(request_id);
+
+-- Version 2 of the entry hash covers the fields of version 1 followed by the outcome and
+-- the request context. Entries chained before this migration keep version 1.
+CREATE OR REPLACE FUNCTION mapping.audit_row_hash_v2(
+    p_seq BIGINT, p_id uuid, p_user_id uuid, p_action TEXT, p_token TEXT, p_kind_id INT,
+    p_purpose TEXT, p_legal_hold TEXT, p_outcome TEXT, p_reason TEXT, p_client_ip TEXT,
+    p_user_agent TEXT, p_request_id TEXT, p_created_at TIMESTAMPTZ, p_prev_hash TEXT
+) RETURNS CHAR(64) AS $$
+    SELECT encode(sha256(convert_to(concat_ws(chr(31),
+        'v2',
+        p_seq::text,
+        p_id::text,
+        p_user_id::text,
+        p_action,
+        p_token,
+        COALESCE(p_kind_id::text, ''),
+        COALESCE(p_purpose, ''),
+        COALESCE(p_legal_hold, ''),
+        p_outcome,
+        COALESCE(p_reason, ''),
+        COALESCE(p_client_ip, ''),
+        COALESCE(p_user_agent, ''),
+        COALESCE(p_request_id, ''),
+        to_char(p_created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+        p_prev_hash
+    ), 'UTF8')), 'hex');
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION mapping.audit_log_chain() RETURNS trigger AS $$
+DECLARE
+    head_seq BIGINT;
+    head_hash CHAR(64);
+BEGIN
+    SELECT seq, row_hash INTO head_seq, head_hash
+    FROM mapping.audit_chain_head
+    WHERE id
+    FOR UPDATE;
+
+    NEW.seq := head_seq + 1;
+    NEW.prev_hash := head_hash;
+    NEW.chain_version := 2;
+    NEW.row_hash := mapping.audit_row_hash_v2(NEW.seq, NEW.id, NEW.user_id, NEW.action, NEW.token,
+        NEW.kind_id, NEW.purpose, NEW.legal_hold, NEW.outcome, NEW.reason, NEW.client_ip,
+        NEW.user_agent, NEW.request_id, NEW.created_at, NEW.prev_hash);
+
+    UPDATE mapping.audit_chain_head SET seq = NEW.seq, row_hash = NEW.row_hash WHERE id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
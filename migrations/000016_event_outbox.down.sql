@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS trg_destruction_events_outbox ON mapping.destruction_events;
+DROP TRIGGER IF EXISTS trg_mappings_key_outbox ON mapping.mappings;
+DROP TRIGGER IF EXISTS trg_audit_log_outbox ON mapping.audit_log;
+
+DROP FUNCTION IF EXISTS mapping.outbox_dek_destroyed();
+DROP FUNCTION IF EXISTS mapping.outbox_mapping_key();
+DROP FUNCTION IF EXISTS mapping.dek_master_key_version(BYTEA);
+DROP FUNCTION IF EXISTS mapping.outbox_audit_entry();
+
+DROP TABLE IF EXISTS mapping.event_outbox;
@@ -0,0 +1,113 @@
+-- Transactional outbox of the events published to Kafka. Rows are written by triggers in
+-- the transaction of the change they describe, so an event exists if and only if the
+-- change was committed. The relay worker of the mapping service publishes them in id
+-- order and sets published_at; published rows are purged after a retention period.
+CREATE TABLE IF NOT EXISTS mapping.event_outbox
+(
+    id BIGSERIAL PRIMARY KEY,
+    event_id uuid NOT NULL DEFAULT gen_random_uuid(),
+    event_type VARCHAR(50) NOT NULL,
+    schema_version SMALLINT NOT NULL,
+    partition_key VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_unpublished ON mapping.event_outbox(id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_event_outbox_published_at ON mapping.event_outbox(published_at) WHERE published_at IS NOT NULL;
+
+-- Every audit entry is published as audit.entry, keyed by its token or, for entries
+-- without one, by its user.
+CREATE OR REPLACE FUNCTION mapping.outbox_audit_entry() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO mapping.event_outbox (event_type, schema_version, partition_key, payload)
+    VALUES ('audit.entry', 1, COALESCE(NULLIF(NEW.token, ''), NEW.user_id::text), jsonb_build_object(
+        'id', NEW.id,
+        'seq', NEW.seq,
+        'user_id', NEW.user_id,
+        'action', NEW.action,
+        'token', NEW.token,
+        'kind_id', NEW.kind_id,
+        'purpose', NEW.purpose,
+        'legal_hold', NEW.legal_hold,
+        'outcome', NEW.outcome,
+        'reason', NEW.reason,
+        'client_ip', NEW.client_ip,
+        'user_agent', NEW.user_agent,
+        'request_id', NEW.request_id,
+        'created_at', NEW.created_at,
+        'prev_hash', NEW.prev_hash,
+        'row_hash', NEW.row_hash,
+        'chain_version', NEW.chain_version
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Key lifecycle of the data encryption key of a mapping, keyed by the mapping token:
+-- key.dek_created when the mapping is stored, key.dek_rewrapped when the DEK is
+-- rewrapped under a newer master key version, key.dek_rotated when the DEK itself is
+-- replaced together with the ciphertext and key.dek_destroyed when the mapping is
+-- deleted. The master key version is parsed from the "vault:vN:" prefix of the wrapped
+-- DEK; the key material never leaves the database.
+CREATE OR REPLACE FUNCTION mapping.dek_master_key_version(p_dek_wrapped BYTEA) RETURNS INT AS $$
+    SELECT substring(encode(substring(p_dek_wrapped FROM 1 FOR 20), 'escape') FROM '^vault:v([0-9]+):')::int;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION mapping.outbox_mapping_key() RETURNS trigger AS $$
+DECLARE
+    v_event_type TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        v_event_type := 'key.dek_created';
+    ELSIF NEW.cipher_text IS DISTINCT FROM OLD.cipher_text THEN
+        v_event_type := 'key.dek_rotated';
+    ELSIF NEW.dek_wrapped IS DISTINCT FROM OLD.dek_wrapped THEN
+        v_event_type := 'key.dek_rewrapped';
+    ELSE
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO mapping.event_outbox (event_type, schema_version, partition_key, payload)
+    VALUES (v_event_type, 1, NEW.token, jsonb_build_object(
+        'mapping_id', NEW.id,
+        'token', NEW.token,
+        'kind_id', NEW.kind_id,
+        'algo_name', NEW.algo_name,
+        'master_key_version', mapping.dek_master_key_version(NEW.dek_wrapped),
+        'occurred_at', now()
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION mapping.outbox_dek_destroyed() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO mapping.event_outbox (event_type, schema_version, partition_key, payload)
+    VALUES ('key.dek_destroyed', 1, NEW.token, jsonb_build_object(
+        'mapping_id', NEW.mapping_id,
+        'token', NEW.token,
+        'kind_id', NEW.kind_id,
+        'reason', NEW.reason,
+        'initiator', NEW.initiator,
+        'occurred_at', NEW.destroyed_at
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_log_outbox ON mapping.audit_log;
+CREATE TRIGGER trg_audit_log_outbox
+    AFTER INSERT ON mapping.audit_log
+    FOR EACH ROW EXECUTE FUNCTION mapping.outbox_audit_entry();
+
+DROP TRIGGER IF EXISTS trg_mappings_key_outbox ON mapping.mappings;
+CREATE TRIGGER trg_mappings_key_outbox
+    AFTER INSERT OR UPDATE OF dek_wrapped, cipher_text ON mapping.mappings
+    FOR EACH ROW EXECUTE FUNCTION mapping.outbox_mapping_key();
+
+DROP TRIGGER IF EXISTS trg_destruction_events_outbox ON mapping.destruction_events;
+CREATE TRIGGER trg_destruction_events_outbox
+    AFTER INSERT ON mapping.destruction_events
+    FOR EACH ROW EXECUTE FUNCTION mapping.outbox_dek_destroyed();
@@ -0,0 +1,53 @@
+CREATE OR REPLACE FUNCTION mapping.outbox_audit_entry() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO mapping.event_outbox (event_type, schema_version, partition_key, payload)
+    VALUES ('audit.entry', 1, COALESCE(NULLIF(NEW.token, ''), NEW.user_id::text), jsonb_build_object(
+        'id', NEW.id,
+        'seq', NEW.seq,
+        'user_id', NEW.user_id,
+        'action', NEW.action,
+        'token', NEW.token,
+        'kind_id', NEW.kind_id,
+        'purpose', NEW.purpose,
+        'legal_hold', NEW.legal_hold,
+        'outcome', NEW.outcome,
+        'reason', NEW.reason,
+        'client_ip', NEW.client_ip,
+        'user_agent', NEW.user_agent,
+        'request_id', NEW.request_id,
+        'created_at', NEW.created_at,
+        'prev_hash', NEW.prev_hash,
+        'row_hash', NEW.row_hash,
+        'chain_version', NEW.chain_version
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION mapping.audit_log_chain() RETURNS trigger AS $$
+DECLARE
+    head_seq BIGINT;
+    head_hash CHAR(64);
+BEGIN
+    SELECT seq, row_hash INTO head_seq, head_hash
+    FROM mapping.audit_chain_head
+    WHERE id
+    FOR UPDATE;
+
+    NEW.seq := head_seq + 1;
+    NEW.prev_hash := head_hash;
+    NEW.chain_version := 2;
+    NEW.row_hash := mapping.audit_row_hash_v2(NEW.seq, NEW.id, NEW.user_id, NEW.action, NEW.token,
+        NEW.kind_id, NEW.purpose, NEW.legal_hold, NEW.outcome, NEW.reason, NEW.client_ip,
+        NEW.user_agent, NEW.request_id, NEW.created_at, NEW.prev_hash);
+
+    UPDATE mapping.audit_chain_head SET seq = NEW.seq, row_hash = NEW.row_hash WHERE id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS mapping.audit_row_hash_v3(BIGINT, uuid, uuid, TEXT, TEXT, INT, TEXT, TEXT, TEXT, TEXT, TEXT, TEXT, TEXT, TEXT, TIMESTAMPTZ, TEXT);
+
+DROP INDEX IF EXISTS mapping.idx_audit_log_break_glass_seq;
+
+ALTER TABLE mapping.audit_log DROP COLUMN IF EXISTS justification;
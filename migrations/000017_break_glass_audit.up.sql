@@ -0,0 +1,83 @@
+-- Audit entries written during a break-glass session record the justification given
+-- when the session was started.
+ALTER TABLE mapping.audit_log ADD COLUMN IF NOT EXISTS justification VARCHAR(500);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_break_glass_seq ON mapping.audit_log(seq) WHERE justification IS NOT NULL;
+
+-- Version 3 of the entry hash covers the fields of version 2 followed by the
+-- justification. Entries chained before this migration keep their version.
+CREATE OR REPLACE FUNCTION mapping.audit_row_hash_v3(
+    p_seq BIGINT, p_id uuid, p_user_id uuid, p_action TEXT, p_token TEXT, p_kind_id INT,
+    p_purpose TEXT, p_legal_hold TEXT, p_outcome TEXT, p_reason TEXT, p_client_ip TEXT,
+    p_user_agent TEXT, p_request_id TEXT, p_justification TEXT, p_created_at TIMESTAMPTZ,
+    p_prev_hash TEXT
+) RETURNS CHAR(64) AS $$
+    SELECT encode(sha256(convert_to(concat_ws(chr(31),
+        'v3',
+        p_seq::text,
+        p_id::text,
+        p_user_id::text,
+        p_action,
+        p_token,
+        COALESCE(p_kind_id::text, ''),
+        COALESCE(p_purpose, ''),
+        COALESCE(p_legal_hold, ''),
+        p_outcome,
+        COALESCE(p_reason, ''),
+        COALESCE(p_client_ip, ''),
+        COALESCE(p_user_agent, ''),
+        COALESCE(p_request_id, ''),
+        COALESCE(p_justification, ''),
+        to_char(p_created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+        p_prev_hash
+    ), 'UTF8')), 'hex');
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION mapping.audit_log_chain() RETURNS trigger AS $$
+DECLARE
+    head_seq BIGINT;
+    head_hash CHAR(64);
+BEGIN
+    SELECT seq, row_hash INTO head_seq, head_hash
+    FROM mapping.audit_chain_head
+    WHERE id
+    FOR UPDATE;
+
+    NEW.seq := head_seq + 1;
+    NEW.prev_hash := head_hash;
+    NEW.chain_version := 3;
+    NEW.row_hash := mapping.audit_row_hash_v3(NEW.seq, NEW.id, NEW.user_id, NEW.action, NEW.token,
+        NEW.kind_id, NEW.purpose, NEW.legal_hold, NEW.outcome, NEW.reason, NEW.client_ip,
+        NEW.user_agent, NEW.request_id, NEW.justification, NEW.created_at, NEW.prev_hash);
+
+    UPDATE mapping.audit_chain_head SET seq = NEW.seq, row_hash = NEW.row_hash WHERE id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION mapping.outbox_audit_entry() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO mapping.event_outbox (event_type, schema_version, partition_key, payload)
+    VALUES ('audit.entry', 1, COALESCE(NULLIF(NEW.token, ''), NEW.user_id::text), jsonb_build_object(
+        'id', NEW.id,
+        'seq', NEW.seq,
+        'user_id', NEW.user_id,
+        'action', NEW.action,
+        'token', NEW.token,
+        'kind_id', NEW.kind_id,
+        'purpose', NEW.purpose,
+        'legal_hold', NEW.legal_hold,
+        'outcome', NEW.outcome,
+        'reason', NEW.reason,
+        'client_ip', NEW.client_ip,
+        'user_agent', NEW.user_agent,
+        'request_id', NEW.request_id,
+        'justification', NEW.justification,
+        'created_at', NEW.created_at,
+        'prev_hash', NEW.prev_hash,
+        'row_hash', NEW.row_hash,
+        'chain_version', NEW.chain_version
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS auth.idx_access_grants_active;
+DROP INDEX IF EXISTS auth.idx_access_grants_user_id_created_at;
+
+DROP TABLE IF EXISTS auth.access_grants;
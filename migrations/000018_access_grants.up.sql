@@ -0,0 +1,22 @@
+-- A grant raises the clearance level of a user, or gives them access to a kind above
+-- their clearance level, until it expires or is revoked. Grants are kept after they end
+-- and after their user is deleted, as the history of who had access to what.
+CREATE TABLE IF NOT EXISTS auth.access_grants
+(
+    id uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL,
+    clearance_level INT DEFAULT NULL,
+    kind_id INT DEFAULT NULL,
+    reason VARCHAR(500) NOT NULL,
+    granted_by uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ DEFAULT NULL,
+    revoked_by uuid DEFAULT NULL,
+    CONSTRAINT chk_access_grants_target CHECK ((clearance_level IS NULL) <> (kind_id IS NULL)),
+    CONSTRAINT chk_access_grants_clearance_level CHECK (clearance_level BETWEEN 1 AND 4),
+    CONSTRAINT chk_access_grants_expires_at CHECK (expires_at > created_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_grants_user_id_created_at ON auth.access_grants(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_access_grants_active ON auth.access_grants(user_id, expires_at) WHERE revoked_at IS NULL;
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS auth.roles_permissions;
+
+DELETE FROM auth.users_roles WHERE role_id IN (SELECT id FROM auth.roles WHERE NOT built_in);
+DELETE FROM auth.roles WHERE NOT built_in;
+
+ALTER TABLE auth.roles ALTER COLUMN id DROP IDENTITY IF EXISTS;
+ALTER TABLE auth.roles DROP COLUMN IF EXISTS built_in;
+ALTER TABLE auth.roles DROP COLUMN IF EXISTS description;
+ALTER TABLE auth.roles ALTER COLUMN name TYPE VARCHAR(20);
+
+DROP TABLE IF EXISTS auth.permissions;
@@ -0,0 +1,106 @@
+-- A permission allows the action of the same name of the gateway access policy. A role
+-- is a set of permissions; the built-in roles are presets that cannot be changed, and
+-- custom roles are created from the catalog of permissions.
+CREATE TABLE IF NOT EXISTS auth.permissions
+(
+    name VARCHAR(50) PRIMARY KEY NOT NULL,
+    description VARCHAR(200) NOT NULL
+);
+
+INSERT INTO auth.permissions (name, description) VALUES
+    ('tokenizer.tokenize', 'Токенизация и анонимизация данных'),
+    ('tokenizer.detokenize', 'Детокенизация данных'),
+    ('mappings.read', 'Просмотр маппингов'),
+    ('mappings.write', 'Изменение и удаление маппингов'),
+    ('mappings.crypto', 'Просмотр криптографических сведений маппингов'),
+    ('subjects.read', 'Просмотр данных субъекта ПДн'),
+    ('subjects.erase', 'Удаление данных субъекта ПДн'),
+    ('legal_holds.read', 'Просмотр юридических удержаний'),
+    ('legal_holds.write', 'Установка и снятие юридических удержаний'),
+    ('reports.read', 'Формирование актов уничтожения ПДн'),
+    ('kinds.read', 'Просмотр видов данных'),
+    ('kinds.write', 'Создание, изменение и удаление видов данных'),
+    ('purposes.read', 'Просмотр целей обработки'),
+    ('purposes.write', 'Создание и удаление целей обработки'),
+    ('users.manage', 'Управление пользователями, их ролями и доступами'),
+    ('roles.read', 'Просмотр ролей и разрешений'),
+    ('roles.manage', 'Создание, изменение и удаление ролей'),
+    ('audit.read', 'Просмотр и выгрузка журнала аудита'),
+    ('keys.rotate', 'Ротация ключей шифрования'),
+    ('security.manage', 'Снятие блокировок пользователей'),
+    ('approvals.use', 'Запрос операций, требующих согласования'),
+    ('approvals.view', 'Просмотр всех запросов на согласование'),
+    ('approvals.review', 'Согласование и отклонение запросов'),
+    ('break_glass.use', 'Экстренный доступ сверх уровня допуска'),
+    ('break_glass.review', 'Просмотр сессий экстренного доступа'),
+    ('break_glass.acknowledge', 'Подтверждение проверки сессий экстренного доступа'),
+    ('policies.read', 'Просмотр политики доступа и проверка решений')
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE auth.roles ALTER COLUMN name TYPE VARCHAR(50);
+ALTER TABLE auth.roles ADD COLUMN IF NOT EXISTS description VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE auth.roles ADD COLUMN IF NOT EXISTS built_in BOOLEAN NOT NULL DEFAULT false;
+-- Custom roles take IDs after those reserved for built-in roles.
+ALTER TABLE auth.roles ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY (START WITH 100);
+
+UPDATE auth.roles SET built_in = true, description = 'Администратор системы' WHERE name = 'admin';
+UPDATE auth.roles SET built_in = true, description = 'Аудитор: журнал аудита, отчёты и проверка экстренного доступа' WHERE name = 'auditor';
+UPDATE auth.roles SET built_in = true, description = 'Специалист: токенизация и работа с данными' WHERE name = 'specialist';
+
+CREATE TABLE IF NOT EXISTS auth.roles_permissions
+(
+    role_id INT NOT NULL REFERENCES auth.roles(id) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES auth.permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+INSERT INTO auth.roles_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM auth.roles r
+JOIN (VALUES
+    ('admin', 'tokenizer.tokenize'),
+    ('admin', 'tokenizer.detokenize'),
+    ('admin', 'mappings.read'),
+    ('admin', 'mappings.write'),
+    ('admin', 'mappings.crypto'),
+    ('admin', 'subjects.read'),
+    ('admin', 'subjects.erase'),
+    ('admin', 'legal_holds.read'),
+    ('admin', 'legal_holds.write'),
+    ('admin', 'reports.read'),
+    ('admin', 'kinds.read'),
+    ('admin', 'kinds.write'),
+    ('admin', 'purposes.read'),
+    ('admin', 'purposes.write'),
+    ('admin', 'users.manage'),
+    ('admin', 'roles.read'),
+    ('admin', 'roles.manage'),
+    ('admin', 'audit.read'),
+    ('admin', 'keys.rotate'),
+    ('admin', 'security.manage'),
+    ('admin', 'approvals.use'),
+    ('admin', 'approvals.view'),
+    ('admin', 'approvals.review'),
+    ('admin', 'break_glass.use'),
+    ('admin', 'break_glass.review'),
+    ('admin', 'policies.read'),
+    ('auditor', 'mappings.read'),
+    ('auditor', 'kinds.read'),
+    ('auditor', 'purposes.read'),
+    ('auditor', 'legal_holds.read'),
+    ('auditor', 'reports.read'),
+    ('auditor', 'audit.read'),
+    ('auditor', 'break_glass.review'),
+    ('auditor', 'break_glass.acknowledge'),
+    ('auditor', 'policies.read'),
+    ('specialist', 'tokenizer.tokenize'),
+    ('specialist', 'tokenizer.detokenize'),
+    ('specialist', 'mappings.read'),
+    ('specialist', 'mappings.write'),
+    ('specialist', 'subjects.read'),
+    ('specialist', 'subjects.erase'),
+    ('specialist', 'kinds.read'),
+    ('specialist', 'purposes.read'),
+    ('specialist', 'approvals.use')
+) AS p(role, permission) ON p.role = r.name
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS auth.kind_operation_grants;
@@ -0,0 +1,26 @@
+-- A kind operation grant allows a user, or every holder of a role, the listed operations
+-- on data of one kind whatever its access level: tokenization, detokenization, viewing
+-- the mappings and deleting them. A user or role has at most one grant per kind.
+CREATE TABLE IF NOT EXISTS auth.kind_operation_grants
+(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id uuid DEFAULT NULL,
+    role_id INT DEFAULT NULL,
+    kind_id INT NOT NULL,
+    operations VARCHAR(20)[] NOT NULL,
+    granted_by uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT fk_kind_operation_grants_user_id FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_kind_operation_grants_role_id FOREIGN KEY (role_id) REFERENCES auth.roles(id) ON DELETE CASCADE,
+    CONSTRAINT chk_kind_operation_grants_holder CHECK ((user_id IS NULL) <> (role_id IS NULL)),
+    CONSTRAINT chk_kind_operation_grants_kind_id CHECK (kind_id > 0),
+    CONSTRAINT chk_kind_operation_grants_operations CHECK (
+        cardinality(operations) > 0
+        AND operations <@ ARRAY['tokenize', 'detokenize', 'view', 'delete']::VARCHAR(20)[]
+    )
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_kind_operation_grants_user_kind
+    ON auth.kind_operation_grants(user_id, kind_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_kind_operation_grants_role_kind
+    ON auth.kind_operation_grants(role_id, kind_id) WHERE role_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DELETE FROM auth.permissions WHERE name = 'service_accounts.manage';
+DROP TABLE IF EXISTS auth.api_keys;
+DROP TABLE IF EXISTS auth.service_accounts;
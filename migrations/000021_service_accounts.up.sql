@@ -0,0 +1,44 @@
+-- A service account is the identity of a machine client. It signs in with API keys
+-- instead of a password; each key is scoped to permissions and kinds, may be limited to
+-- addresses and expires. Only a SHA-256 hash of a key is kept, and the key is found by
+-- its prefix, which is not secret.
+CREATE TABLE IF NOT EXISTS auth.service_accounts
+(
+    id uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    created_by uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS auth.api_keys
+(
+    id uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    service_account_id uuid NOT NULL,
+    prefix VARCHAR(20) NOT NULL UNIQUE,
+    key_hash BYTEA NOT NULL,
+    permissions VARCHAR(50)[] NOT NULL,
+    kind_ids INT[] NOT NULL DEFAULT '{}',
+    allowed_ips VARCHAR(50)[] NOT NULL DEFAULT '{}',
+    created_by uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ DEFAULT NULL,
+    last_used_ip VARCHAR(45) DEFAULT NULL,
+    revoked_at TIMESTAMPTZ DEFAULT NULL,
+    revoked_by uuid DEFAULT NULL,
+    CONSTRAINT fk_api_keys_service_account_id FOREIGN KEY (service_account_id)
+        REFERENCES auth.service_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT chk_api_keys_permissions CHECK (cardinality(permissions) > 0),
+    CONSTRAINT chk_api_keys_expires_at CHECK (expires_at > created_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_service_account_id ON auth.api_keys(service_account_id, created_at);
+
+INSERT INTO auth.permissions (name, description) VALUES
+    ('service_accounts.manage', 'Управление сервисными аккаунтами и их API-ключами')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO auth.roles_permissions (role_id, permission)
+SELECT id, 'service_accounts.manage' FROM auth.roles WHERE name = 'admin'
+ON CONFLICT DO NOTHING;
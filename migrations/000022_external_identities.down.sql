@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS auth.external_identities;
+
+DELETE FROM auth.users WHERE password_hash IS NULL;
+ALTER TABLE auth.users ALTER COLUMN login TYPE VARCHAR(30);
+ALTER TABLE auth.users ALTER COLUMN password_hash SET NOT NULL;
@@ -0,0 +1,18 @@
+-- An external identity links a user to their account at an OpenID Connect identity
+-- provider, identified by the issuer and the subject of its ID tokens. Users signing in
+-- through a provider have no password, and their logins may be e-mail addresses.
+ALTER TABLE auth.users ALTER COLUMN password_hash DROP NOT NULL;
+ALTER TABLE auth.users ALTER COLUMN login TYPE VARCHAR(254);
+
+CREATE TABLE IF NOT EXISTS auth.external_identities
+(
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id uuid NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (issuer, subject)
+);
+
+ALTER TABLE auth.external_identities ADD CONSTRAINT fk_external_identities_user_id
+    FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE;
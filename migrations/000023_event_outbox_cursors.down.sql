@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mapping.event_outbox_cursors;
@@ -0,0 +1,10 @@
+-- Progress of every publisher of the outbox relay: last_id is the id of the last event
+-- the publisher accepted, so a publisher that is unavailable or full does not hold back
+-- the others. An event is marked published once every configured publisher passed it.
+-- A publisher without a cursor starts after the last published event.
+CREATE TABLE IF NOT EXISTS mapping.event_outbox_cursors
+(
+    publisher VARCHAR(50) PRIMARY KEY,
+    last_id BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
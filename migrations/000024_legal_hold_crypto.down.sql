@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_mappings_legal_hold_crypto ON mapping.mappings;
+DROP FUNCTION IF EXISTS mapping.prevent_held_mapping_crypto_update();
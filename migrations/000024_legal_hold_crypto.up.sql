@@ -0,0 +1,17 @@
+-- Holds also block crypto-shredding: the wrapped DEK and the cipher text of a mapping
+-- under hold cannot be replaced, so a rotation cannot make its data undecryptable.
+CREATE OR REPLACE FUNCTION mapping.prevent_held_mapping_crypto_update() RETURNS trigger AS $$
+BEGIN
+    IF mapping.mapping_on_hold(OLD.token, OLD.subject_ref, OLD.kind_id) THEN
+        RAISE EXCEPTION 'mapping % is under legal hold', OLD.id USING ERRCODE = 'AXH01';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_mappings_legal_hold_crypto ON mapping.mappings;
+CREATE TRIGGER trg_mappings_legal_hold_crypto
+    BEFORE UPDATE OF dek_wrapped, cipher_text ON mapping.mappings
+    FOR EACH ROW
+    WHEN (OLD.dek_wrapped IS DISTINCT FROM NEW.dek_wrapped OR OLD.cipher_text IS DISTINCT FROM NEW.cipher_text)
+    EXECUTE FUNCTION mapping.prevent_held_mapping_crypto_update();